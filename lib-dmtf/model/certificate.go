@@ -0,0 +1,104 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+// CertificateService is the redfish CertificateService model according to the 2020.3 release
+type CertificateService struct {
+	OdataContext         string                     `json:"@odata.context,omitempty"`
+	Etag                 string                     `json:"@odata.etag,omitempty"`
+	OdataID              string                     `json:"@odata.id"`
+	OdataType            string                     `json:"@odata.type"`
+	Description          string                     `json:"Description,omitempty"`
+	ID                   string                     `json:"Id"`
+	Name                 string                     `json:"Name"`
+	Actions              *CertificateServiceActions `json:"Actions,omitempty"`
+	CertificateLocations *Link                      `json:"CertificateLocations,omitempty"`
+	Oem                  *Oem                       `json:"Oem,omitempty"`
+}
+
+// CertificateServiceActions contains the actions supported by the CertificateService
+type CertificateServiceActions struct {
+	GenerateCSR        *ActionTarget `json:"#CertificateService.GenerateCSR,omitempty"`
+	ReplaceCertificate *ActionTarget `json:"#CertificateService.ReplaceCertificate,omitempty"`
+}
+
+// CertificateLocations is the redfish CertificateLocations model according to the 2020.3 release
+type CertificateLocations struct {
+	OdataContext string                     `json:"@odata.context,omitempty"`
+	Etag         string                     `json:"@odata.etag,omitempty"`
+	OdataID      string                     `json:"@odata.id"`
+	OdataType    string                     `json:"@odata.type"`
+	Description  string                     `json:"Description,omitempty"`
+	ID           string                     `json:"Id"`
+	Name         string                     `json:"Name"`
+	Links        *CertificateLocationsLinks `json:"Links,omitempty"`
+	Oem          *Oem                       `json:"Oem,omitempty"`
+}
+
+// CertificateLocationsLinks contains the links to the certificates installed on the services
+type CertificateLocationsLinks struct {
+	Certificates      []*Link `json:"Certificates"`
+	CertificatesCount int     `json:"Certificates@odata.count"`
+}
+
+// Certificate is the redfish Certificate model according to the 2020.3 release
+type Certificate struct {
+	OdataContext       string                 `json:"@odata.context,omitempty"`
+	Etag               string                 `json:"@odata.etag,omitempty"`
+	OdataID            string                 `json:"@odata.id"`
+	OdataType          string                 `json:"@odata.type"`
+	Description        string                 `json:"Description,omitempty"`
+	ID                 string                 `json:"Id"`
+	Name               string                 `json:"Name"`
+	CertificateString  string                 `json:"CertificateString,omitempty"`
+	CertificateType    string                 `json:"CertificateType,omitempty"`
+	Issuer             *CertificateIdentifier `json:"Issuer,omitempty"`
+	KeyUsage           []string               `json:"KeyUsage,omitempty"`
+	SerialNumber       string                 `json:"SerialNumber,omitempty"`
+	Subject            *CertificateIdentifier `json:"Subject,omitempty"`
+	UefiSignatureOwner string                 `json:"UefiSignatureOwner,omitempty"`
+	ValidNotAfter      string                 `json:"ValidNotAfter,omitempty"`
+	ValidNotBefore     string                 `json:"ValidNotBefore,omitempty"`
+	Oem                *Oem                   `json:"Oem,omitempty"`
+}
+
+// CertificateIdentifier contains the properties identifying the issuer or the subject of a certificate
+type CertificateIdentifier struct {
+	City               string `json:"City,omitempty"`
+	CommonName         string `json:"CommonName,omitempty"`
+	Country            string `json:"Country,omitempty"`
+	Email              string `json:"Email,omitempty"`
+	Organization       string `json:"Organization,omitempty"`
+	OrganizationalUnit string `json:"OrganizationalUnit,omitempty"`
+	State              string `json:"State,omitempty"`
+}
+
+// CertificateCollection is the redfish CertificateCollection model
+type CertificateCollection struct {
+	OdataContext string  `json:"@odata.context,omitempty"`
+	Etag         string  `json:"@odata.etag,omitempty"`
+	OdataID      string  `json:"@odata.id"`
+	OdataType    string  `json:"@odata.type"`
+	Description  string  `json:"Description,omitempty"`
+	Name         string  `json:"Name"`
+	Members      []*Link `json:"Members"`
+	MembersCount int     `json:"Members@odata.count"`
+}
+
+// GenerateCSRResponse is the response of the CertificateService.GenerateCSR action
+type GenerateCSRResponse struct {
+	CSRString             string `json:"CSRString"`
+	CertificateCollection *Link  `json:"CertificateCollection"`
+}
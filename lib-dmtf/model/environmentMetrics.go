@@ -0,0 +1,47 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+// EnvironmentMetrics is the redfish EnvironmentMetrics model according to the 2021.4 release
+type EnvironmentMetrics struct {
+	ODataContext          string           `json:"@odata.context,omitempty"`
+	ODataEtag             string           `json:"@odata.etag,omitempty"`
+	ODataID               string           `json:"@odata.id"`
+	ODataType             string           `json:"@odata.type"`
+	Actions               *OemActions      `json:"Actions,omitempty"`
+	Description           string           `json:"Description,omitempty"`
+	ID                    string           `json:"Id"`
+	Name                  string           `json:"Name"`
+	Oem                   interface{}      `json:"Oem,omitempty"`
+	AbsoluteHumidity      *SensorExcerpt   `json:"AbsoluteHumidity,omitempty"`
+	DewPointCelsius       *SensorExcerpt   `json:"DewPointCelsius,omitempty"`
+	EnergyJoules          *SensorExcerpt   `json:"EnergyJoules,omitempty"`
+	EnergykWh             *SensorExcerpt   `json:"EnergykWh,omitempty"`
+	FanSpeedsPercent      []*SensorExcerpt `json:"FanSpeedsPercent,omitempty"`
+	HumidityPercent       *SensorExcerpt   `json:"HumidityPercent,omitempty"`
+	PowerLimitWatts       *SensorExcerpt   `json:"PowerLimitWatts,omitempty"`
+	PowerLoadPercent      *SensorExcerpt   `json:"PowerLoadPercent,omitempty"`
+	PowerWatts            *SensorExcerpt   `json:"PowerWatts,omitempty"`
+	TemperatureCelsius    *SensorExcerpt   `json:"TemperatureCelsius,omitempty"`
+	FanSpeedsPercentCount int              `json:"FanSpeedsPercent@odata.count,omitempty"`
+}
+
+// SensorExcerpt is the excerpt of a Sensor which is embedded in other resources
+type SensorExcerpt struct {
+	DataSourceURI string   `json:"DataSourceUri,omitempty"`
+	Reading       *float64 `json:"Reading"` // omitempty is not added to make value as null if the reading is not available
+	DeviceName    string   `json:"DeviceName,omitempty"`
+	SpeedRPM      *float64 `json:"SpeedRPM,omitempty"`
+}
@@ -78,6 +78,7 @@ type LicenseService struct {
 	LicenseExpirationWarningDays int32       `json:"LicenseExpirationWarningDays,omitempty"`
 	Licenses                     *Link       `json:"Licenses,omitempty"`
 	ServiceEnabled               bool        `json:"ServiceEnabled,omitempty"`
+	Oem                          Oem         `json:"Oem,omitempty"`
 }
 
 type LicenseInstallRequest struct {
@@ -0,0 +1,107 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+// PowerSubsystem is the redfish PowerSubsystem model according to the 2021.4 release
+type PowerSubsystem struct {
+	ODataContext          string            `json:"@odata.context,omitempty"`
+	ODataEtag             string            `json:"@odata.etag,omitempty"`
+	ODataID               string            `json:"@odata.id"`
+	ODataType             string            `json:"@odata.type"`
+	Actions               *OemActions       `json:"Actions,omitempty"`
+	Description           string            `json:"Description,omitempty"`
+	ID                    string            `json:"Id"`
+	Name                  string            `json:"Name"`
+	Oem                   interface{}       `json:"Oem,omitempty"`
+	Status                *Status           `json:"Status,omitempty"`
+	Allocation            *PowerAllocation  `json:"Allocation,omitempty"`
+	Batteries             *Link             `json:"Batteries,omitempty"`
+	CapacityWatts         float64           `json:"CapacityWatts,omitempty"`
+	PowerSupplies         *Link             `json:"PowerSupplies,omitempty"`
+	PowerSupplyRedundancy []*RedundantGroup `json:"PowerSupplyRedundancy,omitempty"`
+}
+
+// PowerAllocation redfish model
+type PowerAllocation struct {
+	AllocatedWatts float64 `json:"AllocatedWatts,omitempty"`
+	RequestedWatts float64 `json:"RequestedWatts,omitempty"`
+}
+
+// RedundantGroup redfish model
+type RedundantGroup struct {
+	MaxSupportedInGroup  int     `json:"MaxSupportedInGroup,omitempty"`
+	MinNeededInGroup     int     `json:"MinNeededInGroup,omitempty"`
+	RedundancyGroup      []*Link `json:"RedundancyGroup,omitempty"`
+	RedundancyType       string  `json:"RedundancyType,omitempty"`
+	Status               *Status `json:"Status,omitempty"`
+	RedundancyGroupCount int     `json:"RedundancyGroup@odata.count,omitempty"`
+}
+
+// PowerSupply is the redfish PowerSupply model according to the 2021.4 release
+type PowerSupply struct {
+	ODataContext            string              `json:"@odata.context,omitempty"`
+	ODataEtag               string              `json:"@odata.etag,omitempty"`
+	ODataID                 string              `json:"@odata.id"`
+	ODataType               string              `json:"@odata.type"`
+	Actions                 *OemActions         `json:"Actions,omitempty"`
+	Description             string              `json:"Description,omitempty"`
+	ID                      string              `json:"Id"`
+	Name                    string              `json:"Name"`
+	Oem                     interface{}         `json:"Oem,omitempty"`
+	Status                  *Status             `json:"Status,omitempty"`
+	Assembly                *Link               `json:"Assembly,omitempty"`
+	EfficiencyRatings       []*EfficiencyRating `json:"EfficiencyRatings,omitempty"`
+	FirmwareVersion         string              `json:"FirmwareVersion,omitempty"`
+	HotPluggable            bool                `json:"HotPluggable,omitempty"`
+	InputNominalVoltageType string              `json:"InputNominalVoltageType,omitempty"`
+	InputRanges             []*InputRange       `json:"InputRanges,omitempty"`
+	LineInputStatus         string              `json:"LineInputStatus,omitempty"`
+	Links                   *PowerSupplyLinks   `json:"Links,omitempty"`
+	Location                *Location           `json:"Location,omitempty"`
+	LocationIndicatorActive bool                `json:"LocationIndicatorActive,omitempty"`
+	Manufacturer            string              `json:"Manufacturer,omitempty"`
+	Metrics                 *Link               `json:"Metrics,omitempty"`
+	Model                   string              `json:"Model,omitempty"`
+	PartNumber              string              `json:"PartNumber,omitempty"`
+	PhaseWiringType         string              `json:"PhaseWiringType,omitempty"`
+	PlugType                string              `json:"PlugType,omitempty"`
+	PowerCapacityWatts      float64             `json:"PowerCapacityWatts,omitempty"`
+	PowerSupplyType         string              `json:"PowerSupplyType,omitempty"`
+	ProductionDate          string              `json:"ProductionDate,omitempty"`
+	Replaceable             bool                `json:"Replaceable,omitempty"`
+	SerialNumber            string              `json:"SerialNumber,omitempty"`
+	SparePartNumber         string              `json:"SparePartNumber,omitempty"`
+	Version                 string              `json:"Version,omitempty"`
+}
+
+// EfficiencyRating redfish model
+type EfficiencyRating struct {
+	EfficiencyPercent float64 `json:"EfficiencyPercent,omitempty"`
+	LoadPercent       float64 `json:"LoadPercent,omitempty"`
+}
+
+// InputRange is the redfish input range model of the PowerSupply schema
+type InputRange struct {
+	CapacityWatts      float64 `json:"CapacityWatts,omitempty"`
+	NominalVoltageType string  `json:"NominalVoltageType,omitempty"`
+}
+
+// PowerSupplyLinks redfish model
+type PowerSupplyLinks struct {
+	Outlet               *Link       `json:"Outlet,omitempty"`
+	PoweringChassis      []*Link     `json:"PoweringChassis,omitempty"`
+	Oem                  interface{} `json:"Oem,omitempty"`
+	PoweringChassisCount int         `json:"PoweringChassis@odata.count,omitempty"`
+}
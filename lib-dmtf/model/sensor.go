@@ -0,0 +1,33 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+// Sensor is the redfish Sensor model according to the 2020.3 release
+type Sensor struct {
+	ODataContext    string      `json:"@odata.context,omitempty"`
+	ODataEtag       string      `json:"@odata.etag,omitempty"`
+	ODataID         string      `json:"@odata.id"`
+	ODataType       string      `json:"@odata.type"`
+	Actions         *OemActions `json:"Actions,omitempty"`
+	Description     string      `json:"Description,omitempty"`
+	ID              string      `json:"Id"`
+	Name            string      `json:"Name"`
+	Oem             interface{} `json:"Oem,omitempty"`
+	PhysicalContext string      `json:"PhysicalContext,omitempty"`
+	Reading         *float64    `json:"Reading"` // omitempty is not added to make value as null if the reading is not available
+	ReadingType     string      `json:"ReadingType,omitempty"`
+	ReadingUnits    string      `json:"ReadingUnits,omitempty"`
+	Status          *Status     `json:"Status,omitempty"`
+}
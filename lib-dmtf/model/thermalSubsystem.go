@@ -0,0 +1,59 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+// ThermalSubsystem is the redfish ThermalSubsystem model according to the 2021.4 release
+type ThermalSubsystem struct {
+	ODataContext   string            `json:"@odata.context,omitempty"`
+	ODataEtag      string            `json:"@odata.etag,omitempty"`
+	ODataID        string            `json:"@odata.id"`
+	ODataType      string            `json:"@odata.type"`
+	Actions        *OemActions       `json:"Actions,omitempty"`
+	Description    string            `json:"Description,omitempty"`
+	ID             string            `json:"Id"`
+	Name           string            `json:"Name"`
+	Oem            interface{}       `json:"Oem,omitempty"`
+	Status         *Status           `json:"Status,omitempty"`
+	FanRedundancy  []*RedundantGroup `json:"FanRedundancy,omitempty"`
+	Fans           *Link             `json:"Fans,omitempty"`
+	ThermalMetrics *Link             `json:"ThermalMetrics,omitempty"`
+}
+
+// Fan is the redfish Fan model according to the 2021.4 release
+type Fan struct {
+	ODataContext            string         `json:"@odata.context,omitempty"`
+	ODataEtag               string         `json:"@odata.etag,omitempty"`
+	ODataID                 string         `json:"@odata.id"`
+	ODataType               string         `json:"@odata.type"`
+	Actions                 *OemActions    `json:"Actions,omitempty"`
+	Description             string         `json:"Description,omitempty"`
+	ID                      string         `json:"Id"`
+	Name                    string         `json:"Name"`
+	Oem                     interface{}    `json:"Oem,omitempty"`
+	Status                  *Status        `json:"Status,omitempty"`
+	Assembly                *Link          `json:"Assembly,omitempty"`
+	HotPluggable            bool           `json:"HotPluggable,omitempty"`
+	Location                *Location      `json:"Location,omitempty"`
+	LocationIndicatorActive bool           `json:"LocationIndicatorActive,omitempty"`
+	Manufacturer            string         `json:"Manufacturer,omitempty"`
+	Model                   string         `json:"Model,omitempty"`
+	PartNumber              string         `json:"PartNumber,omitempty"`
+	PhysicalContext         string         `json:"PhysicalContext,omitempty"`
+	PowerWatts              *SensorExcerpt `json:"PowerWatts,omitempty"`
+	Replaceable             bool           `json:"Replaceable,omitempty"`
+	SerialNumber            string         `json:"SerialNumber,omitempty"`
+	SparePartNumber         string         `json:"SparePartNumber,omitempty"`
+	SpeedPercent            *SensorExcerpt `json:"SpeedPercent,omitempty"`
+}
@@ -0,0 +1,68 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package persistencemgr
+
+import (
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+// DBInterface is the set of operations the services perform on the database.
+// ConnPool implements it with the Redis servers configured in DBConf and
+// EmbeddedDB implements it with a local file for a single process.
+type DBInterface interface {
+	Create(table, key string, data interface{}) *errors.Error
+	Update(table, key string, data interface{}) (string, *errors.Error)
+	Read(table, key string) (string, *errors.Error)
+	FindOrNull(table, key string) (string, error)
+	GetAllDetails(table string) ([]string, *errors.Error)
+	Delete(table, key string) *errors.Error
+	CleanUpDB() *errors.Error
+	DeleteServer(key string) *errors.Error
+	GetAllMatchingDetails(table, pattern string) ([]string, *errors.Error)
+	ScanKeys(pattern string) ([]string, *errors.Error)
+	ScanKeysInBatches(pattern string, batch func(keys []string) error) *errors.Error
+	ReadMultipleKeys(keys []string) ([]string, *errors.Error)
+	Transaction(key string, cb func(string) error) *errors.Error
+	GetResourceDetails(key string) (string, *errors.Error)
+	AddResourceData(table, key string, data interface{}) *errors.Error
+	Ping() error
+	CreateIndex(form map[string]interface{}, uuid string) error
+	CreateTaskIndex(index string, value int64, key string) error
+	GetString(index string, cursor float64, match string, regexFlag bool) ([]string, error)
+	GetStorageList(index string, cursor, match float64, condition string, regexFlag bool) ([]string, error)
+	GetRange(index string, min, max int, regexFlag bool) ([]string, error)
+	GetTaskList(index string, min, max int) ([]string, error)
+	Del(index string, k string) error
+	CreateEvtSubscriptionIndex(index string, key interface{}) error
+	GetEvtSubscriptions(index, searchKey string) ([]string, error)
+	DeleteEvtSubscriptions(index, removeKey string) error
+	UpdateEvtSubscriptions(index, subscritionID string, key interface{}) error
+	CreateDeviceSubscriptionIndex(index, hostIP, location string, originResources []string) error
+	GetDeviceSubscription(index string, match string) ([]string, error)
+	DeleteDeviceSubscription(index, hostIP string) error
+	UpdateDeviceSubscription(index, hostIP, location string, originResources []string) error
+	UpdateResourceIndex(form map[string]interface{}, uuid string) error
+	Incr(table, key string) (int, *errors.Error)
+	Decr(table, key string) (int, *errors.Error)
+	SetExpire(table, key string, data interface{}, expiretime int) *errors.Error
+	TTL(table, key string) (int, *errors.Error)
+	AcquireLease(table, key, owner string, expiretime int) (bool, *errors.Error)
+	RenewLease(table, key, owner string, expiretime int) (bool, *errors.Error)
+	ReleaseLease(table, key, owner string) *errors.Error
+	CreateAggregateHostIndex(index, aggregateID string, hostIP []string) error
+	GetAggregateHosts(index string, match string) ([]string, error)
+	UpdateAggregateHosts(index, aggregateID string, hostIP []string) error
+	DeleteAggregateHosts(index, aggregateID string) error
+}
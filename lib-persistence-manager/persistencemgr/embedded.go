@@ -0,0 +1,1109 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package persistencemgr
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+// embeddedDBLockTimeout is the time to wait for the file lock, which is
+// held by the process that opened the embedded DB file
+var embeddedDBLockTimeout = 10 * time.Second
+
+const (
+	// dataBucket holds the table:key entries
+	dataBucket = "data"
+	// expiryBucket holds the expiry time of the table:key entries set with SetExpire
+	expiryBucket = "expiry"
+	// indexBucketPrefix is prefixed to the name of the bucket of each index,
+	// where a member is stored with its score
+	indexBucketPrefix = "index:"
+)
+
+var (
+	embeddedInMemDB  *EmbeddedDB
+	embeddedOnDiskDB *EmbeddedDB
+	embeddedDBMux    sync.Mutex
+)
+
+// EmbeddedDB is the single process implementation of DBInterface, which stores
+// the data in a local file instead of the Redis servers.
+// The file is opened once and locked by the process for its lifetime, every
+// operation runs in a bolt transaction on it. It serves the integration tests and
+// the deployments running all the services in one process; services running as
+// separate processes cannot share the file and have to use the Redis backend.
+type EmbeddedDB struct {
+	Path  string
+	db    *bolt.DB
+	txMux sync.Mutex
+}
+
+// indexEntry is a member of an index along with its score
+type indexEntry struct {
+	member string
+	score  float64
+}
+
+// NewEmbeddedDB creates the embedded DB file at the given path, if it is not present
+func NewEmbeddedDB(path string) (*EmbeddedDB, *errors.Error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, errors.PackError(errors.DBConnFailed, "error while trying to create embedded DB directory: ", err)
+	}
+	boltDB, err := bolt.Open(path, 0600, &bolt.Options{Timeout: embeddedDBLockTimeout})
+	if err == bolt.ErrTimeout {
+		return nil, errors.PackError(errors.DBConnFailed, "error while trying to open embedded DB: "+path+
+			" is locked by another process, the Embedded backend serves a single process and the services running as separate processes have to use the Redis backend")
+	}
+	if err != nil {
+		return nil, errors.PackError(errors.DBConnFailed, "error while trying to open embedded DB: ", err)
+	}
+	db := &EmbeddedDB{Path: path, db: boltDB}
+	if err := db.update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(dataBucket))
+		return err
+	}); err != nil {
+		boltDB.Close()
+		return nil, errors.PackError(errors.DBConnFailed, "error while trying to open embedded DB: ", err)
+	}
+	return db, nil
+}
+
+// Close closes the embedded DB file and releases its lock
+func (e *EmbeddedDB) Close() error {
+	return e.db.Close()
+}
+
+func getEmbeddedDBConnection(dbFlag DbType) (DBInterface, *errors.Error) {
+	embeddedDBMux.Lock()
+	defer embeddedDBMux.Unlock()
+	var err *errors.Error
+	switch dbFlag {
+	case InMemory:
+		if embeddedInMemDB == nil {
+			embeddedInMemDB, err = NewEmbeddedDB(filepath.Join(config.Data.DBConf.EmbeddedDBPath, "inmemory.db"))
+			if err != nil {
+				log.Error("error while trying to get embedded inmemory DB : " + err.Error())
+				return nil, err
+			}
+		}
+		return embeddedInMemDB, nil
+	case OnDisk:
+		if embeddedOnDiskDB == nil {
+			embeddedOnDiskDB, err = NewEmbeddedDB(filepath.Join(config.Data.DBConf.EmbeddedDBPath, "ondisk.db"))
+			if err != nil {
+				log.Error("error while trying to get embedded ondisk DB : " + err.Error())
+				return nil, err
+			}
+		}
+		return embeddedOnDiskDB, nil
+	default:
+		return nil, errors.PackError(errors.UndefinedErrorType, "error invalid db type selection")
+	}
+}
+
+// view runs fn in a read only transaction
+func (e *EmbeddedDB) view(fn func(tx *bolt.Tx) error) error {
+	return e.db.View(fn)
+}
+
+// update runs fn in a read-write transaction, bolt allows one of them at a time
+func (e *EmbeddedDB) update(fn func(tx *bolt.Tx) error) error {
+	return e.db.Update(fn)
+}
+
+// toDBError converts the error returned by view and update to *errors.Error
+func toDBError(err error, errType errors.ErrType, msg string) *errors.Error {
+	if dbErr, ok := err.(*errors.Error); ok {
+		return dbErr
+	}
+	return errors.PackError(errType, msg, err)
+}
+
+// getValue returns the value of the key, or nil if the key is not present or expired
+func getValue(tx *bolt.Tx, key string) []byte {
+	data := tx.Bucket([]byte(dataBucket))
+	if data == nil {
+		return nil
+	}
+	value := data.Get([]byte(key))
+	if value == nil {
+		return nil
+	}
+	if expiry := tx.Bucket([]byte(expiryBucket)); expiry != nil {
+		if expireAt := expiry.Get([]byte(key)); expireAt != nil && time.Now().Unix() >= int64(binary.BigEndian.Uint64(expireAt)) {
+			return nil
+		}
+	}
+	return value
+}
+
+// setValue sets the value of the key, clearing any expiry set earlier
+func setValue(tx *bolt.Tx, key string, value []byte) error {
+	data, err := tx.CreateBucketIfNotExists([]byte(dataBucket))
+	if err != nil {
+		return err
+	}
+	if err = data.Put([]byte(key), value); err != nil {
+		return err
+	}
+	if expiry := tx.Bucket([]byte(expiryBucket)); expiry != nil {
+		return expiry.Delete([]byte(key))
+	}
+	return nil
+}
+
+// setExpiringValue sets the value of the key, which expires after expiretime seconds
+func setExpiringValue(tx *bolt.Tx, key string, value []byte, expiretime int) error {
+	if err := setValue(tx, key, value); err != nil {
+		return err
+	}
+	expiry, err := tx.CreateBucketIfNotExists([]byte(expiryBucket))
+	if err != nil {
+		return err
+	}
+	expireAt := make([]byte, 8)
+	binary.BigEndian.PutUint64(expireAt, uint64(time.Now().Unix()+int64(expiretime)))
+	return expiry.Put([]byte(key), expireAt)
+}
+
+// deleteValue deletes the key along with its expiry
+func deleteValue(tx *bolt.Tx, key string) error {
+	if data := tx.Bucket([]byte(dataBucket)); data != nil {
+		if err := data.Delete([]byte(key)); err != nil {
+			return err
+		}
+	}
+	if expiry := tx.Bucket([]byte(expiryBucket)); expiry != nil {
+		return expiry.Delete([]byte(key))
+	}
+	return nil
+}
+
+// matchingKeys returns all the live keys which matches the pattern
+func matchingKeys(tx *bolt.Tx, pattern string) []string {
+	var keys []string
+	data := tx.Bucket([]byte(dataBucket))
+	if data == nil {
+		return keys
+	}
+	data.ForEach(func(k, _ []byte) error {
+		key := string(k)
+		if globMatch(pattern, key) && getValue(tx, key) != nil {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	return keys
+}
+
+// Create will make an entry into the database with the given values
+func (e *EmbeddedDB) Create(table, key string, data interface{}) *errors.Error {
+	jsondata, err := json.Marshal(data)
+	if err != nil {
+		return errors.PackError(errors.UndefinedErrorType, "Write to DB in json form failed: "+err.Error())
+	}
+	saveID := table + ":" + key
+	err = e.update(func(tx *bolt.Tx) error {
+		if getValue(tx, saveID) != nil {
+			return errors.PackError(errors.DBKeyAlreadyExist, "error: data with key ", key, " already exists")
+		}
+		return setValue(tx, saveID, jsondata)
+	})
+	if err != nil {
+		return toDBError(err, errors.UndefinedErrorType, "Write to DB failed : ")
+	}
+	return nil
+}
+
+// Update will update the existing entry with the given values
+func (e *EmbeddedDB) Update(table, key string, data interface{}) (string, *errors.Error) {
+	jsondata, err := json.Marshal(data)
+	if err != nil {
+		return "", errors.PackError(errors.UndefinedErrorType, "Write to DB in json form failed: "+err.Error())
+	}
+	saveID := table + ":" + key
+	err = e.update(func(tx *bolt.Tx) error {
+		if getValue(tx, saveID) == nil {
+			return errors.PackError(errors.DBKeyNotFound, "error: data with key ", key, " does not exist")
+		}
+		return setValue(tx, saveID, jsondata)
+	})
+	if err != nil {
+		return "", toDBError(err, errors.UndefinedErrorType, "Write to DB failed : ")
+	}
+	return saveID, nil
+}
+
+// Read is for getting singular data
+func (e *EmbeddedDB) Read(table, key string) (string, *errors.Error) {
+	var value string
+	err := e.view(func(tx *bolt.Tx) error {
+		data := getValue(tx, table+":"+key)
+		if data == nil {
+			return errors.PackError(errors.DBKeyNotFound, "no data with the with key ", key, " found")
+		}
+		value = string(data)
+		return nil
+	})
+	if err != nil {
+		return "", toDBError(err, errors.DBKeyFetchFailed, errorCollectingData)
+	}
+	return value, nil
+}
+
+// FindOrNull is a wrapper for Read function. If requested asset doesn't exist errors.DBKeyNotFound error returned by Read is converted to nil
+func (e *EmbeddedDB) FindOrNull(table, key string) (string, error) {
+	r, err := e.Read(table, key)
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	return r, nil
+}
+
+// GetAllDetails will fetch all the keys present in the table
+func (e *EmbeddedDB) GetAllDetails(table string) ([]string, *errors.Error) {
+	return e.getIDs(table, table+":*")
+}
+
+// GetAllMatchingDetails will fetch all the keys of the table which matches pattern
+func (e *EmbeddedDB) GetAllMatchingDetails(table, pattern string) ([]string, *errors.Error) {
+	return e.getIDs(table, table+":*"+pattern+"*")
+}
+
+func (e *EmbeddedDB) getIDs(table, pattern string) ([]string, *errors.Error) {
+	var IDs []string
+	err := e.view(func(tx *bolt.Tx) error {
+		for _, key := range matchingKeys(tx, pattern) {
+			IDs = append(IDs, strings.TrimPrefix(key, table+":"))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, toDBError(err, errors.UndefinedErrorType, errorCollectingData)
+	}
+	return IDs, nil
+}
+
+// ScanKeys will fetch all the keys which matches pattern present in the database
+func (e *EmbeddedDB) ScanKeys(pattern string) ([]string, *errors.Error) {
+	var keys []string
+	err := e.view(func(tx *bolt.Tx) error {
+		keys = matchingKeys(tx, pattern)
+		return nil
+	})
+	if err != nil {
+		return nil, toDBError(err, errors.UndefinedErrorType, errorCollectingData)
+	}
+	return keys, nil
+}
+
+// ScanKeysInBatches passes the keys which matches pattern to the batch function
+// in batches of the SCAN count, outside of the transaction reading the keys.
+// The iteration stops with the error returned by the batch function.
+func (e *EmbeddedDB) ScanKeysInBatches(pattern string, batch func(keys []string) error) *errors.Error {
+	keys, err := e.ScanKeys(pattern)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(keys); start += count {
+		end := start + count
+		if end > len(keys) {
+			end = len(keys)
+		}
+		if err := batch(keys[start:end]); err != nil {
+			return errors.PackError(errors.UndefinedErrorType, err)
+		}
+	}
+	return nil
+}
+
+// ReadMultipleKeys will fetch the data of all the keys in a single transaction,
+// the keys which are not present are skipped
+func (e *EmbeddedDB) ReadMultipleKeys(keys []string) ([]string, *errors.Error) {
+	data := make([]string, 0, len(keys))
+	err := e.view(func(tx *bolt.Tx) error {
+		for _, key := range keys {
+			if value := getValue(tx, key); value != nil {
+				data = append(data, string(value))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, toDBError(err, errors.DBKeyFetchFailed, errorCollectingData)
+	}
+	return data, nil
+}
+
+// Delete will delete the entry of the key from the table
+func (e *EmbeddedDB) Delete(table, key string) *errors.Error {
+	saveID := table + ":" + key
+	err := e.update(func(tx *bolt.Tx) error {
+		if getValue(tx, saveID) == nil {
+			return errors.PackError(errors.DBKeyNotFound, "no data with the with key ", key, " found")
+		}
+		return deleteValue(tx, saveID)
+	})
+	if err != nil {
+		log.Error("Error while deleting data : " + err.Error())
+		return toDBError(err, errors.UndefinedErrorType, "error while trying to delete data: ")
+	}
+	return nil
+}
+
+// CleanUpDB will delete all database entries
+func (e *EmbeddedDB) CleanUpDB() *errors.Error {
+	err := e.update(func(tx *bolt.Tx) error {
+		var buckets [][]byte
+		tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+			buckets = append(buckets, append([]byte{}, name...))
+			return nil
+		})
+		for _, name := range buckets {
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return toDBError(err, errors.UndefinedErrorType, errorCollectingData)
+	}
+	return nil
+}
+
+// DeleteServer will delete all the entries whose keys matches the given pattern
+func (e *EmbeddedDB) DeleteServer(key string) *errors.Error {
+	err := e.update(func(tx *bolt.Tx) error {
+		for _, k := range matchingKeys(tx, key) {
+			if err := deleteValue(tx, k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("Error while Deleting Server : " + err.Error())
+		return toDBError(err, errors.UndefinedErrorType, errorCollectingData)
+	}
+	return nil
+}
+
+// Transaction will run the callback for the key, with the other transactions
+// on the same DB waiting for it to complete. The DB file is locked by the
+// process, so no other process can change the key during the callback.
+func (e *EmbeddedDB) Transaction(key string, cb func(string) error) *errors.Error {
+	e.txMux.Lock()
+	defer e.txMux.Unlock()
+	if err := cb(key); err != nil {
+		return errors.PackError(errors.UndefinedErrorType, err)
+	}
+	return nil
+}
+
+// GetResourceDetails will fetch the data of the key present in any of the tables
+func (e *EmbeddedDB) GetResourceDetails(key string) (string, *errors.Error) {
+	var value string
+	err := e.view(func(tx *bolt.Tx) error {
+		keys := matchingKeys(tx, "*"+key)
+		if len(keys) == 0 {
+			return errors.PackError(errors.DBKeyNotFound, "no data with the with key ", key, " found")
+		}
+		value = string(getValue(tx, keys[len(keys)-1]))
+		return nil
+	})
+	if err != nil {
+		return "", toDBError(err, errors.UndefinedErrorType, errorCollectingData)
+	}
+	return value, nil
+}
+
+// AddResourceData will make an entry into the database with the given values,
+// replacing the existing entry if any
+func (e *EmbeddedDB) AddResourceData(table, key string, data interface{}) *errors.Error {
+	jsondata, err := json.Marshal(data)
+	if err != nil {
+		return errors.PackError(errors.UndefinedErrorType, "Write to DB in json form failed: "+err.Error())
+	}
+	err = e.update(func(tx *bolt.Tx) error {
+		return setValue(tx, table+":"+key, jsondata)
+	})
+	if err != nil {
+		return toDBError(err, errors.UndefinedErrorType, "Write to DB failed : ")
+	}
+	return nil
+}
+
+// Ping will check whether the embedded DB file can be read
+func (e *EmbeddedDB) Ping() error {
+	if err := e.view(func(tx *bolt.Tx) error { return nil }); err != nil {
+		return fmt.Errorf("error while pinging embedded DB: %v", err)
+	}
+	return nil
+}
+
+// Incr is for incrementing the count
+func (e *EmbeddedDB) Incr(table, key string) (int, *errors.Error) {
+	return e.incrBy(table, key, 1)
+}
+
+// Decr is for decrementing the count
+func (e *EmbeddedDB) Decr(table, key string) (int, *errors.Error) {
+	return e.incrBy(table, key, -1)
+}
+
+func (e *EmbeddedDB) incrBy(table, key string, delta int) (int, *errors.Error) {
+	var count int
+	saveID := table + ":" + key
+	err := e.update(func(tx *bolt.Tx) error {
+		current := 0
+		if value := getValue(tx, saveID); value != nil {
+			var err error
+			if current, err = strconv.Atoi(string(value)); err != nil {
+				return errors.PackError(errors.UndefinedErrorType, "error while trying to convert the data into int: ", err)
+			}
+		}
+		count = current + delta
+		return setValue(tx, saveID, []byte(strconv.Itoa(count)))
+	})
+	if err != nil {
+		return 0, toDBError(err, errors.DBKeyFetchFailed, errorCollectingData)
+	}
+	return count, nil
+}
+
+// SetExpire key to hold the string value and set key to timeout after a given number of seconds
+func (e *EmbeddedDB) SetExpire(table, key string, data interface{}, expiretime int) *errors.Error {
+	jsondata, err := json.Marshal(data)
+	if err != nil {
+		return errors.PackError(errors.UndefinedErrorType, "Write to DB in json form failed: "+err.Error())
+	}
+	saveID := table + ":" + key
+	err = e.update(func(tx *bolt.Tx) error {
+		if getValue(tx, saveID) != nil {
+			return errors.PackError(errors.DBKeyAlreadyExist, "error: data with key ", key, " already exists")
+		}
+		return setExpiringValue(tx, saveID, jsondata, expiretime)
+	})
+	if err != nil {
+		return toDBError(err, errors.UndefinedErrorType, "Write to DB failed : ")
+	}
+	return nil
+}
+
+// TTL returns the time left in seconds for the key to expire,
+// -1 if the key has no expiry and -2 if the key is not present
+func (e *EmbeddedDB) TTL(table, key string) (int, *errors.Error) {
+	ttl := -2
+	saveID := table + ":" + key
+	err := e.view(func(tx *bolt.Tx) error {
+		if getValue(tx, saveID) == nil {
+			return nil
+		}
+		ttl = -1
+		if expiry := tx.Bucket([]byte(expiryBucket)); expiry != nil {
+			if expireAt := expiry.Get([]byte(saveID)); expireAt != nil {
+				ttl = int(int64(binary.BigEndian.Uint64(expireAt)) - time.Now().Unix())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, toDBError(err, errors.DBKeyFetchFailed, errorCollectingData)
+	}
+	return ttl, nil
+}
+
+// AcquireLease sets the owner as the holder of the lease with the key, which
+// expires after expiretime seconds. It returns false if the lease is already held.
+func (e *EmbeddedDB) AcquireLease(table, key, owner string, expiretime int) (bool, *errors.Error) {
+	var acquired bool
+	saveID := table + ":" + key
+	err := e.update(func(tx *bolt.Tx) error {
+		if getValue(tx, saveID) != nil {
+			return nil
+		}
+		acquired = true
+		return setExpiringValue(tx, saveID, []byte(owner), expiretime)
+	})
+	if err != nil {
+		return false, toDBError(err, errors.UndefinedErrorType, "Write to DB failed : ")
+	}
+	return acquired, nil
+}
+
+// RenewLease extends the expiry of the lease with the key to expiretime seconds.
+// It returns false if the lease is not held by the owner.
+func (e *EmbeddedDB) RenewLease(table, key, owner string, expiretime int) (bool, *errors.Error) {
+	var renewed bool
+	saveID := table + ":" + key
+	err := e.update(func(tx *bolt.Tx) error {
+		if string(getValue(tx, saveID)) != owner {
+			return nil
+		}
+		renewed = true
+		return setExpiringValue(tx, saveID, []byte(owner), expiretime)
+	})
+	if err != nil {
+		return false, toDBError(err, errors.UndefinedErrorType, "Write to DB failed : ")
+	}
+	return renewed, nil
+}
+
+// ReleaseLease deletes the lease with the key, if it is held by the owner
+func (e *EmbeddedDB) ReleaseLease(table, key, owner string) *errors.Error {
+	saveID := table + ":" + key
+	err := e.update(func(tx *bolt.Tx) error {
+		if string(getValue(tx, saveID)) != owner {
+			return nil
+		}
+		return deleteValue(tx, saveID)
+	})
+	if err != nil {
+		return toDBError(err, errors.UndefinedErrorType, "Write to DB failed : ")
+	}
+	return nil
+}
+
+// zadd adds the member with the score to the index
+func zadd(tx *bolt.Tx, index string, score float64, member string) error {
+	bucket, err := tx.CreateBucketIfNotExists([]byte(indexBucketPrefix + index))
+	if err != nil {
+		return err
+	}
+	return bucket.Put([]byte(member), []byte(strconv.FormatFloat(score, 'f', -1, 64)))
+}
+
+// zrem removes the member from the index
+func zrem(tx *bolt.Tx, index string, member string) error {
+	bucket := tx.Bucket([]byte(indexBucketPrefix + index))
+	if bucket == nil {
+		return nil
+	}
+	return bucket.Delete([]byte(member))
+}
+
+// zentries returns the members of the index sorted by score and then by member
+func zentries(tx *bolt.Tx, index string) ([]indexEntry, error) {
+	var entries []indexEntry
+	bucket := tx.Bucket([]byte(indexBucketPrefix + index))
+	if bucket == nil {
+		return entries, nil
+	}
+	err := bucket.ForEach(func(k, v []byte) error {
+		score, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, indexEntry{member: string(k), score: score})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].score != entries[j].score {
+			return entries[i].score < entries[j].score
+		}
+		return entries[i].member < entries[j].member
+	})
+	return entries, nil
+}
+
+// zscan returns the members of the index which matches the pattern, each
+// followed by its score, in the same form ZSCAN returns them from Redis
+func (e *EmbeddedDB) zscan(index, match string) ([]string, error) {
+	var data []string
+	err := e.view(func(tx *bolt.Tx) error {
+		entries, err := zentries(tx, index)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if globMatch(match, entry.member) {
+				data = append(data, entry.member, strconv.FormatFloat(entry.score, 'f', -1, 64))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to get data: " + err.Error())
+	}
+	return data, nil
+}
+
+// zaddAll adds all the members to the index with the same score
+func (e *EmbeddedDB) zaddAll(index string, score float64, members ...string) error {
+	return e.update(func(tx *bolt.Tx) error {
+		for _, member := range members {
+			if err := zadd(tx, index, score, member); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// zremAll removes all the members from the index
+func (e *EmbeddedDB) zremAll(index string, members ...string) error {
+	return e.update(func(tx *bolt.Tx) error {
+		for _, member := range members {
+			if err := zrem(tx, index, member); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CreateIndex is used to create and save secondary index
+func (e *EmbeddedDB) CreateIndex(form map[string]interface{}, uuid string) error {
+	return e.update(func(tx *bolt.Tx) error {
+		for index, value := range form {
+			var key string
+			var score float64
+			switch v := value.(type) {
+			case int:
+				key = strconv.Itoa(v) + "::" + uuid
+				score = float64(v)
+			case float64:
+				key = strconv.FormatFloat(v, 'f', -1, 64) + "::" + uuid
+				score = v
+			case string:
+				key = strings.ToLower(v) + "::" + uuid
+			case []string:
+				key = strings.ToLower("["+strings.Join(v, " ")+"]") + "::" + uuid
+			case []float64:
+				var floatString []string
+				for _, f := range v {
+					floatString = append(floatString, strconv.FormatFloat(f, 'f', -1, 64))
+				}
+				key = "[" + strings.Join(floatString, " ") + "]" + "::" + uuid
+			default:
+				return fmt.Errorf("error while saving index, unsupported value type %v", v)
+			}
+			if err := zadd(tx, index, score, key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CreateTaskIndex is used to create secondary indexing for task service
+func (e *EmbeddedDB) CreateTaskIndex(index string, value int64, key string) error {
+	return e.zaddAll(index, float64(value), key)
+}
+
+// GetString is used to retrive index values of type string
+func (e *EmbeddedDB) GetString(index string, cursor float64, match string, regexFlag bool) ([]string, error) {
+	var getList []string
+	data, err := e.zscan(index, strings.ToLower(match))
+	if err != nil {
+		return []string{}, err
+	}
+	for _, d := range data {
+		if d == "0" {
+			continue
+		}
+		if regexFlag {
+			getList = append(getList, d)
+		} else {
+			getList = append(getList, strings.Split(d, "::")[1])
+		}
+	}
+	return getList, nil
+}
+
+// GetStorageList is used to storage list of capacity
+func (e *EmbeddedDB) GetStorageList(index string, cursor, match float64, condition string, regexFlag bool) ([]string, error) {
+	var getList, storeList []string
+	data, err := e.zscan(index, "*")
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range data {
+		if d != "0" {
+			getList = append(getList, d)
+		}
+	}
+	if regexFlag {
+		return getList, nil
+	}
+	for _, k := range getList {
+		values := strings.Split(k, "::")[0]
+		id := strings.Split(k, "::")[1]
+		values = strings.Replace(values, "]", "", -1)
+		values = strings.Replace(values, "[", "", -1)
+		for _, value := range strings.Split(values, " ") {
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, err
+			}
+			switch condition {
+			case "eq":
+				if result := big.NewFloat(match).Cmp(big.NewFloat(v)); result == 0 {
+					storeList = append(storeList, id)
+				}
+			case "gt":
+				if v > match {
+					storeList = append(storeList, id)
+				}
+			case "ge":
+				if v >= match {
+					storeList = append(storeList, id)
+				}
+			case "lt":
+				if v < match {
+					storeList = append(storeList, id)
+				}
+			case "le":
+				if v <= match {
+					storeList = append(storeList, id)
+				}
+			}
+		}
+	}
+	return getUniqueSlice(storeList), nil
+}
+
+// GetRange is used to range over float type values
+func (e *EmbeddedDB) GetRange(index string, min, max int, regexFlag bool) ([]string, error) {
+	var data []string
+	err := e.view(func(tx *bolt.Tx) error {
+		entries, err := zentries(tx, index)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if entry.score >= float64(min) && entry.score <= float64(max) {
+				data = append(data, entry.member)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to get data: " + err.Error())
+	}
+	if regexFlag {
+		return data, nil
+	}
+	var getList = []string{}
+	for _, d := range data {
+		getList = append(getList, strings.Split(d, "::")[1])
+	}
+	return getList, nil
+}
+
+// GetTaskList is used to get the members of the index between the ranks min and max
+func (e *EmbeddedDB) GetTaskList(index string, min, max int) ([]string, error) {
+	data := []string{}
+	err := e.view(func(tx *bolt.Tx) error {
+		entries, err := zentries(tx, index)
+		if err != nil {
+			return err
+		}
+		// negative ranks are counted from the end, as with ZRANGE
+		if min < 0 {
+			min += len(entries)
+		}
+		if max < 0 {
+			max += len(entries)
+		}
+		if min < 0 {
+			min = 0
+		}
+		for i := min; i <= max && i < len(entries); i++ {
+			data = append(data, entries[i].member)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to get data: " + err.Error())
+	}
+	return data, nil
+}
+
+// Del is used to delete the index key
+func (e *EmbeddedDB) Del(index string, k string) error {
+	data, err := e.zscan(index, "*"+k)
+	if err != nil {
+		return err
+	}
+	if len(data) < 1 {
+		return fmt.Errorf("no data with ID found")
+	}
+	return e.zremAll(index, data...)
+}
+
+// CreateEvtSubscriptionIndex is used to create and save secondary index
+func (e *EmbeddedDB) CreateEvtSubscriptionIndex(index string, key interface{}) error {
+	matchKey := strings.Replace(key.(string), "[", "\\[", -1)
+	matchKey = strings.Replace(matchKey, "]", "\\]", -1)
+	val, _ := e.GetEvtSubscriptions(index, matchKey)
+	if len(val) > 0 {
+		return fmt.Errorf("Data Already Exist for the index: %v", index)
+	}
+	return e.zaddAll(index, 0, key.(string))
+}
+
+// GetEvtSubscriptions is for to get subscription details
+func (e *EmbeddedDB) GetEvtSubscriptions(index, searchKey string) ([]string, error) {
+	var getList []string
+	data, err := e.zscan(index, searchKey)
+	if err != nil {
+		return []string{}, err
+	}
+	for _, d := range data {
+		if d != "0" {
+			getList = append(getList, d)
+		}
+	}
+	return getList, nil
+}
+
+// DeleteEvtSubscriptions is for to Delete subscription details
+func (e *EmbeddedDB) DeleteEvtSubscriptions(index, removeKey string) error {
+	matchKey := strings.Replace(removeKey, "[", "\\[", -1)
+	matchKey = strings.Replace(matchKey, "]", "\\]", -1)
+	value, err := e.GetEvtSubscriptions(index, matchKey)
+	if err != nil {
+		return err
+	}
+	if len(value) < 1 {
+		return fmt.Errorf("No data found for the key: %v", matchKey)
+	}
+	return e.zremAll(index, value...)
+}
+
+// UpdateEvtSubscriptions is for to Update subscription details
+func (e *EmbeddedDB) UpdateEvtSubscriptions(index, subscritionID string, key interface{}) error {
+	if err := e.DeleteEvtSubscriptions(index, subscritionID); err != nil {
+		return err
+	}
+	if err := e.CreateEvtSubscriptionIndex(index, key); err != nil {
+		return fmt.Errorf("Error while updating subscriptions")
+	}
+	return nil
+}
+
+// CreateDeviceSubscriptionIndex is used to create and save secondary index
+func (e *EmbeddedDB) CreateDeviceSubscriptionIndex(index, hostIP, location string, originResources []string) error {
+	key := hostIP + "||" + location + "||" + "[" + strings.Join(originResources, " ") + "]"
+	searchKey := strings.Replace(key, "[", "\\[", -1)
+	searchKey = strings.Replace(searchKey, "]", "\\]", -1)
+	val, _ := e.GetDeviceSubscription(index, searchKey)
+	if len(val) > 0 {
+		return fmt.Errorf("Data Already Exist for the index: %v", index)
+	}
+	return e.zaddAll(index, 0, key)
+}
+
+// GetDeviceSubscription is used to retrive index values of type string,
+// each followed by its score as returned by the Redis implementation
+func (e *EmbeddedDB) GetDeviceSubscription(index string, match string) ([]string, error) {
+	data, err := e.zscan(index, match)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 1 {
+		return []string{}, fmt.Errorf("No data found for the key: %v", match)
+	}
+	return data, nil
+}
+
+// DeleteDeviceSubscription is for to Delete subscription details of Device
+func (e *EmbeddedDB) DeleteDeviceSubscription(index, hostIP string) error {
+	value, err := e.GetDeviceSubscription(index, hostIP+"*")
+	if err != nil {
+		return err
+	}
+	return e.zremAll(index, value...)
+}
+
+// UpdateDeviceSubscription is for to Update subscription details
+func (e *EmbeddedDB) UpdateDeviceSubscription(index, hostIP, location string, originResources []string) error {
+	if _, err := e.GetDeviceSubscription(index, hostIP+"[^0-9]*"); err != nil {
+		return err
+	}
+	if err := e.DeleteDeviceSubscription(index, hostIP+"[^0-9]"); err != nil {
+		return err
+	}
+	if err := e.CreateDeviceSubscriptionIndex(index, hostIP, location, originResources); err != nil {
+		return fmt.Errorf("Error while updating subscriptions")
+	}
+	return nil
+}
+
+// UpdateResourceIndex is used to update the resource inforamtion which is indexed
+func (e *EmbeddedDB) UpdateResourceIndex(form map[string]interface{}, uuid string) error {
+	for index := range form {
+		err := e.Del(index, uuid)
+		if (err != nil) && (err.Error() != "no data with ID found") {
+			return fmt.Errorf("Error while updating index: %v", err)
+		}
+	}
+	if err := e.CreateIndex(form, uuid); err != nil {
+		return fmt.Errorf("Error while updating index: %v", err)
+	}
+	return nil
+}
+
+// CreateAggregateHostIndex is used to create and save secondary index
+func (e *EmbeddedDB) CreateAggregateHostIndex(index, aggregateID string, hostIP []string) error {
+	return e.zaddAll(index, 0, aggregateID+"||"+"["+strings.Join(hostIP, " ")+"]")
+}
+
+// GetAggregateHosts is used to retrive index values of type string,
+// each followed by its score as returned by the Redis implementation
+func (e *EmbeddedDB) GetAggregateHosts(index string, match string) ([]string, error) {
+	data, err := e.zscan(index, match)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 1 {
+		return []string{}, fmt.Errorf("no data found for the key: %v", match)
+	}
+	return data, nil
+}
+
+// UpdateAggregateHosts is for to Update subscription details
+func (e *EmbeddedDB) UpdateAggregateHosts(index, aggregateID string, hostIP []string) error {
+	if err := e.DeleteAggregateHosts(index, aggregateID+"[^0-9]"); err != nil {
+		return err
+	}
+	if err := e.CreateAggregateHostIndex(index, aggregateID, hostIP); err != nil {
+		return fmt.Errorf("error while updating aggregate host ")
+	}
+	return nil
+}
+
+// DeleteAggregateHosts is for to Delete subscription details of aggregate
+func (e *EmbeddedDB) DeleteAggregateHosts(index, aggregateID string) error {
+	value, err := e.GetAggregateHosts(index, aggregateID+"[^0-9]*")
+	if err != nil {
+		return err
+	}
+	return e.zremAll(index, value...)
+}
+
+// globMatch reports whether str matches the glob style pattern, supporting
+// the same syntax as the MATCH option of the Redis SCAN commands
+func globMatch(pattern, str string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for len(pattern) > 1 && pattern[1] == '*' {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(str); i++ {
+				if globMatch(pattern[1:], str[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(str) == 0 {
+				return false
+			}
+			str = str[1:]
+		case '[':
+			if len(str) == 0 {
+				return false
+			}
+			var matched bool
+			matched, pattern = matchClass(pattern[1:], str[0])
+			if !matched {
+				return false
+			}
+			str = str[1:]
+			continue
+		case '\\':
+			if len(pattern) >= 2 {
+				pattern = pattern[1:]
+			}
+			fallthrough
+		default:
+			if len(str) == 0 || pattern[0] != str[0] {
+				return false
+			}
+			str = str[1:]
+		}
+		pattern = pattern[1:]
+	}
+	return len(str) == 0
+}
+
+// matchClass matches c against the character class at the start of pattern,
+// which is the pattern after the opening '[', and returns the rest of the
+// pattern after the closing ']'
+func matchClass(pattern string, c byte) (bool, string) {
+	not := false
+	if len(pattern) > 0 && pattern[0] == '^' {
+		not = true
+		pattern = pattern[1:]
+	}
+	matched := false
+	for len(pattern) > 0 && pattern[0] != ']' {
+		switch {
+		case pattern[0] == '\\' && len(pattern) >= 2:
+			if pattern[1] == c {
+				matched = true
+			}
+			pattern = pattern[2:]
+		case len(pattern) >= 3 && pattern[1] == '-' && pattern[2] != ']':
+			start, end := pattern[0], pattern[2]
+			if start > end {
+				start, end = end, start
+			}
+			if c >= start && c <= end {
+				matched = true
+			}
+			pattern = pattern[3:]
+		default:
+			if pattern[0] == c {
+				matched = true
+			}
+			pattern = pattern[1:]
+		}
+	}
+	if len(pattern) > 0 {
+		// skip the closing ']'
+		pattern = pattern[1:]
+	}
+	return matched != not, pattern
+}
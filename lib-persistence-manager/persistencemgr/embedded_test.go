@@ -0,0 +1,342 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package persistencemgr
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+func newTestEmbeddedDB(t *testing.T) *EmbeddedDB {
+	db, err := NewEmbeddedDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewEmbeddedDB() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestEmbeddedDBCRUD(t *testing.T) {
+	db := newTestEmbeddedDB(t)
+	data := sample{Data1: "Value1", Data2: "Value2", Data3: "Value3"}
+
+	if err := db.Create("table", "/redfish/v1/Systems/1", data); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := db.Create("table", "/redfish/v1/Systems/1", data); err == nil || err.ErrNo() != errors.DBKeyAlreadyExist {
+		t.Errorf("Create() of existing key error = %v, want DBKeyAlreadyExist", err)
+	}
+	if _, err := db.Update("table", "/redfish/v1/Systems/2", data); err == nil || err.ErrNo() != errors.DBKeyNotFound {
+		t.Errorf("Update() of missing key error = %v, want DBKeyNotFound", err)
+	}
+	data.Data1 = "Updated"
+	if _, err := db.Update("table", "/redfish/v1/Systems/1", data); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	value, err := db.Read("table", "/redfish/v1/Systems/1")
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if value != `{"Data1":"Updated","Data2":"Value2","Data3":"Value3"}` {
+		t.Errorf("Read() got = %v", value)
+	}
+	if value, _ := db.FindOrNull("table", "/redfish/v1/Systems/2"); value != "" {
+		t.Errorf("FindOrNull() of missing key got = %v", value)
+	}
+	if err := db.AddResourceData("other", "/redfish/v1/Systems/2", "data"); err != nil {
+		t.Fatalf("AddResourceData() error = %v", err)
+	}
+	if value, err := db.GetResourceDetails("/redfish/v1/Systems/2"); err != nil || value != `"data"` {
+		t.Errorf("GetResourceDetails() got = %v, error = %v", value, err)
+	}
+	if keys, _ := db.GetAllDetails("table"); !reflect.DeepEqual(keys, []string{"/redfish/v1/Systems/1"}) {
+		t.Errorf("GetAllDetails() got = %v", keys)
+	}
+	if keys, _ := db.GetAllMatchingDetails("other", "Systems"); !reflect.DeepEqual(keys, []string{"/redfish/v1/Systems/2"}) {
+		t.Errorf("GetAllMatchingDetails() got = %v", keys)
+	}
+	if keys, _ := db.ScanKeys("*:/redfish/v1/Systems/*"); len(keys) != 2 {
+		t.Errorf("ScanKeys() got = %v", keys)
+	}
+	var batches [][]string
+	if err := db.ScanKeysInBatches("*:/redfish/v1/Systems/*", func(keys []string) error {
+		batches = append(batches, keys)
+		return nil
+	}); err != nil || len(batches) != 1 || len(batches[0]) != 2 {
+		t.Errorf("ScanKeysInBatches() got = %v, error = %v", batches, err)
+	}
+	values, err := db.ReadMultipleKeys([]string{"table:/redfish/v1/Systems/1", "table:/redfish/v1/Systems/3", "other:/redfish/v1/Systems/2"})
+	if err != nil || !reflect.DeepEqual(values, []string{`{"Data1":"Updated","Data2":"Value2","Data3":"Value3"}`, `"data"`}) {
+		t.Errorf("ReadMultipleKeys() got = %v, error = %v", values, err)
+	}
+	if err := db.Delete("table", "/redfish/v1/Systems/1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := db.Delete("table", "/redfish/v1/Systems/1"); err == nil || err.ErrNo() != errors.DBKeyNotFound {
+		t.Errorf("Delete() of missing key error = %v, want DBKeyNotFound", err)
+	}
+	if err := db.DeleteServer("*Systems/2"); err != nil {
+		t.Fatalf("DeleteServer() error = %v", err)
+	}
+	if _, err := db.Read("other", "/redfish/v1/Systems/2"); err == nil {
+		t.Errorf("Read() after DeleteServer() should fail")
+	}
+}
+
+func TestEmbeddedDBCounterAndExpiry(t *testing.T) {
+	db := newTestEmbeddedDB(t)
+	if count, err := db.Incr("counter", "key"); err != nil || count != 1 {
+		t.Errorf("Incr() got = %v, error = %v", count, err)
+	}
+	if count, err := db.Decr("counter", "key"); err != nil || count != 0 {
+		t.Errorf("Decr() got = %v, error = %v", count, err)
+	}
+	if ttl, _ := db.TTL("session", "token"); ttl != -2 {
+		t.Errorf("TTL() of missing key got = %v, want -2", ttl)
+	}
+	if err := db.SetExpire("session", "token", "data", 100); err != nil {
+		t.Fatalf("SetExpire() error = %v", err)
+	}
+	if ttl, _ := db.TTL("session", "token"); ttl <= 0 || ttl > 100 {
+		t.Errorf("TTL() got = %v", ttl)
+	}
+	if err := db.SetExpire("session", "expired", "data", 0); err != nil {
+		t.Fatalf("SetExpire() error = %v", err)
+	}
+	if _, err := db.Read("session", "expired"); err == nil || err.ErrNo() != errors.DBKeyNotFound {
+		t.Errorf("Read() of expired key error = %v, want DBKeyNotFound", err)
+	}
+	if err := db.CleanUpDB(); err != nil {
+		t.Fatalf("CleanUpDB() error = %v", err)
+	}
+	if _, err := db.Read("session", "token"); err == nil {
+		t.Errorf("Read() after CleanUpDB() should fail")
+	}
+}
+
+func TestEmbeddedDBLease(t *testing.T) {
+	db := newTestEmbeddedDB(t)
+	if acquired, err := db.AcquireLease("lease", "dest", "instance1", 100); err != nil || !acquired {
+		t.Fatalf("AcquireLease() got = %v, error = %v", acquired, err)
+	}
+	if acquired, _ := db.AcquireLease("lease", "dest", "instance2", 100); acquired {
+		t.Errorf("AcquireLease() of a held lease should fail")
+	}
+	if renewed, _ := db.RenewLease("lease", "dest", "instance2", 100); renewed {
+		t.Errorf("RenewLease() by another owner should fail")
+	}
+	if renewed, err := db.RenewLease("lease", "dest", "instance1", 100); err != nil || !renewed {
+		t.Errorf("RenewLease() got = %v, error = %v", renewed, err)
+	}
+	if err := db.ReleaseLease("lease", "dest", "instance2"); err != nil {
+		t.Fatalf("ReleaseLease() error = %v", err)
+	}
+	if acquired, _ := db.AcquireLease("lease", "dest", "instance2", 100); acquired {
+		t.Errorf("ReleaseLease() by another owner should not release the lease")
+	}
+	if err := db.ReleaseLease("lease", "dest", "instance1"); err != nil {
+		t.Fatalf("ReleaseLease() error = %v", err)
+	}
+	if acquired, err := db.AcquireLease("lease", "dest", "instance2", 0); err != nil || !acquired {
+		t.Errorf("AcquireLease() after release got = %v, error = %v", acquired, err)
+	}
+	// the lease acquired above has expired already
+	if acquired, err := db.AcquireLease("lease", "dest", "instance1", 100); err != nil || !acquired {
+		t.Errorf("AcquireLease() after expiry got = %v, error = %v", acquired, err)
+	}
+}
+
+func TestEmbeddedDBIndex(t *testing.T) {
+	db := newTestEmbeddedDB(t)
+	form := map[string]interface{}{
+		"ProcessorSummary/Model":             "Intel Xeon",
+		"MemorySummary/TotalSystemMemoryGiB": []float64{384},
+	}
+	if err := db.CreateIndex(form, "/redfish/v1/Systems/1"); err != nil {
+		t.Fatalf("CreateIndex() error = %v", err)
+	}
+	if list, _ := db.GetString("ProcessorSummary/Model", 0, "*Xeon*", false); !reflect.DeepEqual(list, []string{"/redfish/v1/Systems/1"}) {
+		t.Errorf("GetString() got = %v", list)
+	}
+	if list, _ := db.GetStorageList("MemorySummary/TotalSystemMemoryGiB", 0, 100, "gt", false); !reflect.DeepEqual(list, []string{"/redfish/v1/Systems/1"}) {
+		t.Errorf("GetStorageList() got = %v", list)
+	}
+	form["ProcessorSummary/Model"] = "AMD EPYC"
+	if err := db.UpdateResourceIndex(form, "/redfish/v1/Systems/1"); err != nil {
+		t.Fatalf("UpdateResourceIndex() error = %v", err)
+	}
+	if list, _ := db.GetString("ProcessorSummary/Model", 0, "*", true); !reflect.DeepEqual(list, []string{"amd epyc::/redfish/v1/Systems/1"}) {
+		t.Errorf("GetString() after update got = %v", list)
+	}
+	if err := db.Del("ProcessorSummary/Model", "/redfish/v1/Systems/2"); err == nil || err.Error() != "no data with ID found" {
+		t.Errorf("Del() of missing ID error = %v", err)
+	}
+
+	for i, key := range []string{"admin::30::task3", "admin::10::task1", "admin::20::task2"} {
+		if err := db.CreateTaskIndex("EndTime", int64(30-i*10), key); err != nil {
+			t.Fatalf("CreateTaskIndex() error = %v", err)
+		}
+	}
+	if list, _ := db.GetRange("EndTime", 15, 30, true); !reflect.DeepEqual(list, []string{"admin::10::task1", "admin::30::task3"}) {
+		t.Errorf("GetRange() got = %v", list)
+	}
+	if list, _ := db.GetTaskList("EndTime", 0, -1); len(list) != 3 || list[0] != "admin::20::task2" {
+		t.Errorf("GetTaskList() got = %v", list)
+	}
+}
+
+func TestEmbeddedDBSubscriptions(t *testing.T) {
+	db := newTestEmbeddedDB(t)
+	if err := db.CreateEvtSubscriptionIndex("Subscription", "1||[/redfish/v1/Systems]"); err != nil {
+		t.Fatalf("CreateEvtSubscriptionIndex() error = %v", err)
+	}
+	if err := db.CreateEvtSubscriptionIndex("Subscription", "1||[/redfish/v1/Systems]"); err == nil {
+		t.Errorf("CreateEvtSubscriptionIndex() of existing key should fail")
+	}
+	if err := db.UpdateEvtSubscriptions("Subscription", "1*", "1||[/redfish/v1/Managers]"); err != nil {
+		t.Fatalf("UpdateEvtSubscriptions() error = %v", err)
+	}
+	if list, _ := db.GetEvtSubscriptions("Subscription", "*Managers*"); !reflect.DeepEqual(list, []string{"1||[/redfish/v1/Managers]"}) {
+		t.Errorf("GetEvtSubscriptions() got = %v", list)
+	}
+
+	if err := db.CreateDeviceSubscriptionIndex("Device", "10.0.0.1", "https://dest", []string{"/redfish/v1/Systems"}); err != nil {
+		t.Fatalf("CreateDeviceSubscriptionIndex() error = %v", err)
+	}
+	if err := db.CreateDeviceSubscriptionIndex("Device", "10.0.0.11", "https://dest", nil); err != nil {
+		t.Fatalf("CreateDeviceSubscriptionIndex() error = %v", err)
+	}
+	if err := db.UpdateDeviceSubscription("Device", "10.0.0.1", "https://new", nil); err != nil {
+		t.Fatalf("UpdateDeviceSubscription() error = %v", err)
+	}
+	list, _ := db.GetDeviceSubscription("Device", "10.0.0.1*")
+	sort.Strings(list)
+	if !reflect.DeepEqual(list, []string{"0", "0", "10.0.0.11||https://dest||[]", "10.0.0.1||https://new||[]"}) {
+		t.Errorf("GetDeviceSubscription() got = %v", list)
+	}
+	if err := db.DeleteDeviceSubscription("Device", "10.0.0.11"); err != nil {
+		t.Fatalf("DeleteDeviceSubscription() error = %v", err)
+	}
+	if _, err := db.GetDeviceSubscription("Device", "10.0.0.11*"); err == nil {
+		t.Errorf("GetDeviceSubscription() after delete should fail")
+	}
+
+	if err := db.CreateAggregateHostIndex("Aggregate", "1", []string{"10.0.0.1"}); err != nil {
+		t.Fatalf("CreateAggregateHostIndex() error = %v", err)
+	}
+	if err := db.UpdateAggregateHosts("Aggregate", "1", []string{"10.0.0.2"}); err != nil {
+		t.Fatalf("UpdateAggregateHosts() error = %v", err)
+	}
+	if list, _ := db.GetAggregateHosts("Aggregate", "1[^0-9]*"); !reflect.DeepEqual(list, []string{"1||[10.0.0.2]", "0"}) {
+		t.Errorf("GetAggregateHosts() got = %v", list)
+	}
+}
+
+func TestEmbeddedDBConcurrentIncr(t *testing.T) {
+	db := newTestEmbeddedDB(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := db.Incr("counter", "key"); err != nil {
+				t.Errorf("Incr() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if count, _ := db.Read("counter", "key"); count != "50" {
+		t.Errorf("Incr() count = %v, want 50", count)
+	}
+}
+
+func TestEmbeddedDBLockedByProcess(t *testing.T) {
+	db := newTestEmbeddedDB(t)
+	if _, err := bolt.Open(db.Path, 0600, &bolt.Options{Timeout: 100 * time.Millisecond}); err == nil {
+		t.Errorf("embedded DB file should be locked while it is open")
+	}
+
+	lockTimeout := embeddedDBLockTimeout
+	embeddedDBLockTimeout = 100 * time.Millisecond
+	defer func() { embeddedDBLockTimeout = lockTimeout }()
+	if _, err := NewEmbeddedDB(db.Path); err == nil || !strings.Contains(err.Error(), "locked by another process") {
+		t.Errorf("NewEmbeddedDB() error = %v, want the DB to be reported as locked by another process", err)
+	}
+}
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		str     string
+		want    bool
+	}{
+		{"*", "Systems:/redfish/v1/Systems/1", true},
+		{"Systems:*", "Chassis:/redfish/v1/Chassis/1", false},
+		{"*/1", "Systems:/redfish/v1/Systems/1", true},
+		{"?bc", "abc", true},
+		{"?bc", "bc", false},
+		{"a[bc]d", "acd", true},
+		{"a[^bc]d", "acd", false},
+		{"a[a-z]d", "a-d", false},
+		{"10.0.0.1[^0-9]*", "10.0.0.11||dest", false},
+		{"10.0.0.1[^0-9]*", "10.0.0.1||dest", true},
+		{`\[a\]*`, "[a] b", true},
+		{`\[a\]*`, "a b", false},
+	}
+	for _, tt := range tests {
+		if got := globMatch(tt.pattern, tt.str); got != tt.want {
+			t.Errorf("globMatch(%q, %q) = %v, want %v", tt.pattern, tt.str, got, tt.want)
+		}
+	}
+}
+
+func TestGetDBConnection_Embedded(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.DBConf.Backend = config.EmbeddedDBBackend
+	config.Data.DBConf.EmbeddedDBPath = t.TempDir()
+	defer func() {
+		config.Data.DBConf.Backend = config.RedisDBBackend
+		embeddedInMemDB.Close()
+		embeddedOnDiskDB.Close()
+		embeddedInMemDB, embeddedOnDiskDB = nil, nil
+	}()
+
+	inMemory, err := GetDBConnection(InMemory)
+	if err != nil {
+		t.Fatalf("GetDBConnection() error = %v", err)
+	}
+	onDisk, err := GetDBConnection(OnDisk)
+	if err != nil {
+		t.Fatalf("GetDBConnection() error = %v", err)
+	}
+	if inMemory.(*EmbeddedDB).Path == onDisk.(*EmbeddedDB).Path {
+		t.Errorf("GetDBConnection() returned the same DB file for InMemory and OnDisk")
+	}
+	if err := inMemory.Ping(); err != nil {
+		t.Errorf("Ping() error = %v", err)
+	}
+	if _, err := GetDBConnection(3); err == nil {
+		t.Errorf("GetDBConnection() with invalid db type should fail")
+	}
+}
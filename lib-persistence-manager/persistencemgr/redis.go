@@ -199,7 +199,10 @@ func getOnDiskDBConfig() *Config {
 }
 
 //GetDBConnection is used to get the new Connection Pool for Inmemory/OnDisk DB
-func GetDBConnection(dbFlag DbType) (*ConnPool, *errors.Error) {
+func GetDBConnection(dbFlag DbType) (DBInterface, *errors.Error) {
+	if config.Data.DBConf.Backend == config.EmbeddedDBBackend {
+		return getEmbeddedDBConnection(dbFlag)
+	}
 	var err *errors.Error
 	switch dbFlag {
 	case InMemory:
@@ -594,6 +597,80 @@ func (p *ConnPool) GetAllMatchingDetails(table, pattern string) ([]string, *erro
 	return IDs, nil
 }
 
+//ScanKeys will fetch all the keys which matches pattern present in the database,
+//iterating with SCAN so that the DB is not blocked like with KEYS
+func (p *ConnPool) ScanKeys(pattern string) ([]string, *errors.Error) {
+	var keys []string
+	err := p.ScanKeysInBatches(pattern, func(items []string) error {
+		keys = append(keys, items...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+//ScanKeysInBatches iterates the keys which matches pattern with SCAN and passes
+//each batch of keys read to the batch function, without collecting all the keys.
+//The iteration stops with the error returned by the batch function.
+func (p *ConnPool) ScanKeysInBatches(pattern string, batch func(keys []string) error) *errors.Error {
+	readConn := p.ReadPool.Get()
+	defer readConn.Close()
+	var (
+		cursor int64
+		items  []string
+	)
+	for {
+		values, err := redis.Values(readConn.Do("SCAN", cursor, "MATCH", pattern, "COUNT", count))
+		if err != nil {
+			if errs, aye := isDbConnectError(err); aye {
+				return errs
+			}
+			return errors.PackError(errors.UndefinedErrorType, errorCollectingData, err)
+		}
+		if _, err = redis.Scan(values, &cursor, &items); err != nil {
+			return errors.PackError(errors.UndefinedErrorType, errorCollectingData, err)
+		}
+		if len(items) > 0 {
+			if err = batch(items); err != nil {
+				return errors.PackError(errors.UndefinedErrorType, err)
+			}
+		}
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+//ReadMultipleKeys will fetch the data of all the keys in a single MGET,
+//the keys which are not present are skipped
+func (p *ConnPool) ReadMultipleKeys(keys []string) ([]string, *errors.Error) {
+	if len(keys) == 0 {
+		return []string{}, nil
+	}
+	readConn := p.ReadPool.Get()
+	defer readConn.Close()
+	args := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		args = append(args, key)
+	}
+	values, err := redis.ByteSlices(readConn.Do("MGET", args...))
+	if err != nil {
+		if errs, aye := isDbConnectError(err); aye {
+			return nil, errs
+		}
+		return nil, errors.PackError(errors.DBKeyFetchFailed, errorCollectingData, err)
+	}
+	data := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != nil {
+			data = append(data, string(value))
+		}
+	}
+	return data, nil
+}
+
 //Transaction is to do a atomic operation using optimistic lock
 func (p *ConnPool) Transaction(key string, cb func(string) error) *errors.Error {
 	writePool := (*redis.Pool)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&p.WritePool))))
@@ -1412,6 +1489,78 @@ func (p *ConnPool) TTL(table, key string) (int, *errors.Error) {
 	return time, nil
 }
 
+// renewLeaseScript extends the expiry of the lease only if it is held by the owner
+var renewLeaseScript = redis.NewScript(1, `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("EXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// releaseLeaseScript deletes the lease only if it is held by the owner
+var releaseLeaseScript = redis.NewScript(1, `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// AcquireLease sets the owner as the holder of the lease with the key, which
+// expires after expiretime seconds. It returns false if the lease is already held.
+func (p *ConnPool) AcquireLease(table, key, owner string, expiretime int) (bool, *errors.Error) {
+	writePool := (*redis.Pool)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&p.WritePool))))
+	if writePool == nil {
+		log.Info("AcquireLease : WritePool nil")
+		return false, errors.PackError(errors.UndefinedErrorType, "AcquireLease : WritePool is nil ")
+	}
+	writeConn := writePool.Get()
+	defer writeConn.Close()
+	value, err := writeConn.Do("SET", table+":"+key, owner, "NX", "EX", expiretime)
+	if err != nil {
+		if errs, aye := isDbConnectError(err); aye {
+			return false, errs
+		}
+		return false, errors.PackError(errors.UndefinedErrorType, "Write to DB failed : "+err.Error())
+	}
+	return value != nil, nil
+}
+
+// RenewLease extends the expiry of the lease with the key to expiretime seconds.
+// It returns false if the lease is not held by the owner.
+func (p *ConnPool) RenewLease(table, key, owner string, expiretime int) (bool, *errors.Error) {
+	writePool := (*redis.Pool)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&p.WritePool))))
+	if writePool == nil {
+		log.Info("RenewLease : WritePool nil")
+		return false, errors.PackError(errors.UndefinedErrorType, "RenewLease : WritePool is nil ")
+	}
+	writeConn := writePool.Get()
+	defer writeConn.Close()
+	renewed, err := redis.Int(renewLeaseScript.Do(writeConn, table+":"+key, owner, expiretime))
+	if err != nil {
+		if errs, aye := isDbConnectError(err); aye {
+			return false, errs
+		}
+		return false, errors.PackError(errors.UndefinedErrorType, "Write to DB failed : "+err.Error())
+	}
+	return renewed == 1, nil
+}
+
+// ReleaseLease deletes the lease with the key, if it is held by the owner
+func (p *ConnPool) ReleaseLease(table, key, owner string) *errors.Error {
+	writePool := (*redis.Pool)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&p.WritePool))))
+	if writePool == nil {
+		log.Info("ReleaseLease : WritePool nil")
+		return errors.PackError(errors.UndefinedErrorType, "ReleaseLease : WritePool is nil ")
+	}
+	writeConn := writePool.Get()
+	defer writeConn.Close()
+	if _, err := releaseLeaseScript.Do(writeConn, table+":"+key, owner); err != nil {
+		if errs, aye := isDbConnectError(err); aye {
+			return errs
+		}
+		return errors.PackError(errors.UndefinedErrorType, "Write to DB failed : "+err.Error())
+	}
+	return nil
+}
+
 // CreateAggregateHostIndex is used to create and save secondary index
 /* CreateAggregateHostIndex take the following keys are input:
 1. index is the name of the index to be created
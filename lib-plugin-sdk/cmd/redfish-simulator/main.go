@@ -0,0 +1,87 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// redfish-simulator serves simulated Redfish BMCs, one on every address given,
+// for running the add compute, rediscovery, update and event flows of ODIM locally
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/simulator"
+	log "github.com/sirupsen/logrus"
+)
+
+func main() {
+	mockupDir := flag.String("mockup", "", "directory of the Redfish mockup, the built-in mockup is used when it is not given")
+	addresses := flag.String("addresses", ":8443", "comma separated addresses of the simulated BMCs, every BMC has its own state")
+	certFile := flag.String("cert", "", "certificate of the simulated BMCs")
+	keyFile := flag.String("key", "", "private key of the simulated BMCs")
+	caFile := flag.String("ca", "", "CA certificate trusted when posting the events to the subscribers")
+	userName := flag.String("user", "admin", "user name accepted by the simulated BMCs")
+	password := flag.String("password", "password", "password accepted by the simulated BMCs")
+	flag.Parse()
+
+	if *certFile == "" || *keyFile == "" {
+		log.Fatal("the certificate and the private key of the simulated BMCs are required")
+	}
+	client := http.DefaultClient
+	if *caFile != "" {
+		caCert, err := ioutil.ReadFile(*caFile)
+		if err != nil {
+			log.Fatal("unable to read the CA certificate: " + err.Error())
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			log.Fatal("unable to load the CA certificate " + *caFile)
+		}
+		client = &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+			},
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, address := range strings.Split(*addresses, ",") {
+		bmc, err := simulator.New(simulator.Options{
+			MockupDir: *mockupDir,
+			UserName:  *userName,
+			Password:  *password,
+			Client:    client,
+		})
+		if err != nil {
+			log.Fatal("unable to load the mockup: " + err.Error())
+		}
+		root, _ := bmc.Resource("/redfish/v1")
+		log.Info("simulated BMC ", root["UUID"], " is listening on ", address)
+		wg.Add(1)
+		go func(address string, bmc *simulator.Simulator) {
+			defer wg.Done()
+			server := &http.Server{
+				Addr:      strings.TrimSpace(address),
+				Handler:   bmc,
+				TLSConfig: &tls.Config{MinVersion: tls.VersionTLS12},
+			}
+			log.Error(server.ListenAndServeTLS(*certFile, *keyFile))
+		}(address, bmc)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,316 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package conformance checks that a plugin serves the northbound contract expected by ODIM.
+// Every plugin runs the suite against its own routes from a test of its main package.
+package conformance
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/model"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/simulator"
+)
+
+const (
+	// contractPath is the path of the plugin, all the endpoints of the contract are served under it
+	contractPath = "/ODIM/v1"
+	// deviceUUID is the UUID of the simulated BMC validated by the plugin
+	deviceUUID = "6d1ab5c9-1d2f-4c8e-9a3b-0f7e2d4c5b61"
+)
+
+// Credentials are the credentials of ODIM with the plugin
+type Credentials struct {
+	UserName string
+	Password string
+}
+
+// Subscription is the event subscription the plugin is expected to create on its devices
+type Subscription struct {
+	// DropEventFormatType is set for the plugins whose devices do not support the EventFormatType
+	DropEventFormatType bool
+}
+
+// suite holds the state shared by the checks of a run
+type suite struct {
+	handler http.Handler
+	creds   Credentials
+	token   string
+}
+
+// Run runs the checks of the northbound contract against the handler of the plugin.
+// The plugin configuration must be loaded with the credentials passed, the device
+// validated by the plugin is a simulated BMC served with the certificate of the plugin configuration.
+func Run(t *testing.T, handler http.Handler, creds Credentials) {
+	s := &suite{
+		handler: handler,
+		creds:   creds,
+	}
+	t.Run("Sessions", s.checkSessions)
+	t.Run("Status", s.checkStatus)
+	t.Run("Startup", s.checkStartup)
+	t.Run("Validate", s.checkValidate)
+	t.Run("Subscriptions", s.checkSubscriptions)
+}
+
+func (s *suite) do(method, path string, body interface{}, header http.Header) *httptest.ResponseRecorder {
+	var reqBody []byte
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reqBody = []byte(b)
+	default:
+		reqBody, _ = json.Marshal(b)
+	}
+	req := httptest.NewRequest(method, contractPath+path, bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	recorder := httptest.NewRecorder()
+	s.handler.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func (s *suite) basicAuth(userName, password string) http.Header {
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.SetBasicAuth(userName, password)
+	return req.Header
+}
+
+func tokenHeader(token string) http.Header {
+	return http.Header{"X-Auth-Token": []string{token}}
+}
+
+func expectStatus(t *testing.T, resp *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if resp.Code != want {
+		t.Errorf("status code = %v, want %v, body: %s", resp.Code, want, resp.Body.String())
+	}
+}
+
+func (s *suite) checkSessions(t *testing.T) {
+	resp := s.do(http.MethodPost, "/Sessions", map[string]string{
+		"Username": s.creds.UserName,
+		"Password": s.creds.Password,
+	}, nil)
+	expectStatus(t, resp, http.StatusCreated)
+	s.token = resp.Header().Get("X-Auth-Token")
+	if s.token == "" {
+		t.Error("X-Auth-Token is missing in the response of session creation")
+	}
+
+	resp = s.do(http.MethodPost, "/Sessions", map[string]string{
+		"Username": s.creds.UserName,
+		"Password": s.creds.Password + "invalid",
+	}, nil)
+	expectStatus(t, resp, http.StatusUnauthorized)
+
+	resp = s.do(http.MethodPost, "/Sessions", "{", nil)
+	expectStatus(t, resp, http.StatusBadRequest)
+}
+
+func (s *suite) checkStatus(t *testing.T) {
+	resp := s.do(http.MethodGet, "/Status", nil, tokenHeader(s.token))
+	expectStatus(t, resp, http.StatusOK)
+	var status struct {
+		Name    string
+		Version string
+		Status  struct {
+			Available string
+			Uptime    string
+			TimeStamp string
+		}
+		EventMessageBus struct {
+			EmbType string
+		}
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &status); err != nil {
+		t.Fatalf("unable to decode the plugin status: %v", err)
+	}
+	if status.Name == "" || status.Version == "" {
+		t.Errorf("Name = %q, Version = %q, want both of them set", status.Name, status.Version)
+	}
+	if status.Status.Uptime == "" || status.Status.TimeStamp == "" {
+		t.Errorf("Uptime = %q, TimeStamp = %q, want both of them set", status.Status.Uptime, status.Status.TimeStamp)
+	}
+
+	resp = s.do(http.MethodGet, "/Status", nil, tokenHeader("invalid"))
+	expectStatus(t, resp, http.StatusUnauthorized)
+}
+
+func (s *suite) checkStartup(t *testing.T) {
+	resp := s.do(http.MethodPost, "/Startup", map[string]interface{}{
+		"RequestType": "delta",
+		"Devices":     map[string]interface{}{},
+	}, s.basicAuth(s.creds.UserName, s.creds.Password))
+	expectStatus(t, resp, http.StatusOK)
+
+	resp = s.do(http.MethodPost, "/Startup", "{", s.basicAuth(s.creds.UserName, s.creds.Password))
+	expectStatus(t, resp, http.StatusBadRequest)
+
+	resp = s.do(http.MethodPost, "/Startup", map[string]interface{}{}, s.basicAuth(s.creds.UserName, s.creds.Password+"invalid"))
+	expectStatus(t, resp, http.StatusUnauthorized)
+}
+
+func (s *suite) checkValidate(t *testing.T) {
+	resp := s.do(http.MethodPost, "/validate", map[string]interface{}{}, nil)
+	expectStatus(t, resp, http.StatusUnauthorized)
+
+	resp = s.do(http.MethodPost, "/validate", "{", s.basicAuth(s.creds.UserName, s.creds.Password))
+	expectStatus(t, resp, http.StatusBadRequest)
+
+	resp = s.do(http.MethodPost, "/validate", map[string]interface{}{}, tokenHeader("invalid"))
+	expectStatus(t, resp, http.StatusUnauthorized)
+
+	bmc, err := simulator.New(simulator.Options{UUID: deviceUUID})
+	if err != nil {
+		t.Fatalf("unable to create the simulated BMC: %v", err)
+	}
+	server := startDevice(t, bmc)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "https://")
+	resp = s.do(http.MethodPost, "/validate", model.Device{
+		Host:     host,
+		Username: "admin",
+		Password: []byte("password"),
+	}, s.basicAuth(s.creds.UserName, s.creds.Password))
+	expectStatus(t, resp, http.StatusOK)
+	var device struct {
+		ServerIP   string
+		DeviceUUID string `json:"device_UUID"`
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &device); err != nil {
+		t.Fatalf("unable to decode the validated device: %v, body: %s", err, resp.Body.String())
+	}
+	if device.ServerIP != host || device.DeviceUUID != deviceUUID {
+		t.Errorf("validated device = %+v, want ServerIP %v and DeviceUUID %v", device, host, deviceUUID)
+	}
+}
+
+func (s *suite) checkSubscriptions(t *testing.T) {
+	for _, method := range []string{http.MethodPost, http.MethodDelete} {
+		resp := s.do(method, "/Subscriptions", map[string]interface{}{}, tokenHeader("invalid"))
+		expectStatus(t, resp, http.StatusUnauthorized)
+
+		resp = s.do(method, "/Subscriptions", "{", tokenHeader(s.token))
+		expectStatus(t, resp, http.StatusBadRequest)
+	}
+}
+
+// subscriptionDevice is a BMC which records the body of the event subscriptions created on it
+type subscriptionDevice struct {
+	lock sync.Mutex
+	body []byte
+}
+
+func (d *subscriptionDevice) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/redfish/v1/SessionService/Sessions":
+		w.Header().Set("X-Auth-Token", "token")
+		w.Header().Set("Location", "/redfish/v1/SessionService/Sessions/1")
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodGet && r.URL.Path == "/redfish/v1/EventService/Subscriptions":
+		w.Write([]byte(`{"Members":[]}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/redfish/v1/EventService/Subscriptions":
+		body, _ := ioutil.ReadAll(r.Body)
+		d.lock.Lock()
+		d.body = body
+		d.lock.Unlock()
+		w.Header().Set("Location", "/redfish/v1/EventService/Subscriptions/1")
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// startDevice serves the device over TLS with the certificate of the plugin configuration
+func startDevice(t *testing.T, device http.Handler) *httptest.Server {
+	t.Helper()
+	cert, err := tls.X509KeyPair(config.Data.KeyCertConf.Certificate, config.Data.KeyCertConf.PrivateKey)
+	if err != nil {
+		t.Fatalf("unable to load the certificate of the plugin: %v", err)
+	}
+	server := httptest.NewUnstartedServer(device)
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	server.StartTLS()
+	return server
+}
+
+// CheckSubscription checks the body of the event subscription the plugin creates on a device
+// for a subscription of ODIM. The device is served with the certificate of the plugin
+// configuration, which must be loaded with the credentials passed.
+func CheckSubscription(t *testing.T, handler http.Handler, creds Credentials, want Subscription) {
+	device := &subscriptionDevice{}
+	server := startDevice(t, device)
+	defer server.Close()
+
+	s := &suite{
+		handler: handler,
+		creds:   creds,
+	}
+	resp := s.do(http.MethodPost, "/Sessions", map[string]string{
+		"Username": creds.UserName,
+		"Password": creds.Password,
+	}, nil)
+	expectStatus(t, resp, http.StatusCreated)
+
+	postBody, _ := json.Marshal(model.EvtSubPost{
+		Destination:     "https://odim.example.com/EventService/Events",
+		EventTypes:      []string{"Alert"},
+		MessageIds:      []string{"iLOEvents.0.9.ServerPoweredOn"},
+		ResourceTypes:   []string{"ComputerSystem"},
+		Context:         "ODIMRA_Event",
+		Protocol:        "Redfish",
+		EventFormatType: "Event",
+	})
+	resp = s.do(http.MethodPost, "/Subscriptions", model.Device{
+		Host:     strings.TrimPrefix(server.URL, "https://"),
+		Username: "admin",
+		Password: []byte("password"),
+		PostBody: postBody,
+	}, tokenHeader(resp.Header().Get("X-Auth-Token")))
+	expectStatus(t, resp, http.StatusCreated)
+
+	device.lock.Lock()
+	defer device.lock.Unlock()
+	var got map[string]interface{}
+	if err := json.Unmarshal(device.body, &got); err != nil {
+		t.Fatalf("unable to decode the subscription created on the device: %v, body: %s", err, device.body)
+	}
+	expected := map[string]interface{}{
+		"Destination":     "https://" + config.Data.LoadBalancerConf.Host + ":" + config.Data.LoadBalancerConf.Port + config.Data.EventConf.DestURI,
+		"EventTypes":      []interface{}{"Alert"},
+		"HttpHeaders":     nil,
+		"Context":         "ODIMRA_Event",
+		"Protocol":        "Redfish",
+		"EventFormatType": "Event",
+	}
+	if want.DropEventFormatType {
+		delete(expected, "EventFormatType")
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("subscription created on the device = %s, want %v", device.body, expected)
+	}
+}
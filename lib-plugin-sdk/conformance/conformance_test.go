@@ -0,0 +1,50 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package conformance
+
+import (
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/northbound"
+	iris "github.com/kataras/iris/v12"
+)
+
+func TestRun(t *testing.T) {
+	config.SetUpMockConfig(t)
+	app := iris.New()
+	northbound.RegisterRoutes(app.Party("/ODIM/v1"), northbound.Contract{})
+	if err := app.Build(); err != nil {
+		t.Fatalf("unable to build the routes: %v", err)
+	}
+	Run(t, app, Credentials{UserName: "admin", Password: "Od!m12$4"})
+}
+
+func TestCheckSubscription(t *testing.T) {
+	config.SetUpMockConfig(t)
+	creds := Credentials{UserName: "admin", Password: "Od!m12$4"}
+	for _, want := range []Subscription{{}, {DropEventFormatType: true}} {
+		app := iris.New()
+		contract := northbound.Contract{}
+		if want.DropEventFormatType {
+			contract.PrepareSubscription = northbound.DropEventFormatType
+		}
+		northbound.RegisterRoutes(app.Party("/ODIM/v1"), contract)
+		if err := app.Build(); err != nil {
+			t.Fatalf("unable to build the routes: %v", err)
+		}
+		CheckSubscription(t, app, creds, want)
+	}
+}
@@ -0,0 +1,163 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package events receives the events posted by the devices to the plugin
+// and forwards them to ODIM through the message bus
+package events
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/translation"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	lutilconf "github.com/ODIM-Project/ODIM/lib-utilities/config"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// jobQueueSize is the size of the buffers of the queue of the events to be published
+	jobQueueSize = 10
+	// publishWorkers is the number of events published at a time
+	publishWorkers = 5
+)
+
+var (
+	// In Channel
+	In chan<- interface{}
+	// Out Channel
+	Out <-chan interface{}
+)
+
+// Formatter converts an event received from a device to the form published on the message bus.
+// It is the hook for the vendors whose devices post the events in their own format.
+type Formatter func(event common.Events) (common.Events, error)
+
+// StartForwarding creates the queue of the events received from the devices
+// and starts the workers publishing them to the message bus after formatting with format
+func StartForwarding(format Formatter) {
+	// CreateJobQueue defines the queue which will act as an infinite buffer
+	// In channel is an entry or input channel and the Out channel is an exit or output channel
+	In, Out = common.CreateJobQueue(jobQueueSize)
+
+	// RunReadWorkers will create a worker pool for doing a specific task
+	// which is passed to it as Publish method after reading the data from the channel.
+	go common.RunReadWorkers(Out, func(data interface{}) bool {
+		return Publish(data, format)
+	}, publishWorkers)
+}
+
+// ListenAndServe starts the listener of the events posted by the devices
+func ListenAndServe() {
+	conf := &lutilconf.HTTPConfig{
+		Certificate:   &config.Data.KeyCertConf.Certificate,
+		PrivateKey:    &config.Data.KeyCertConf.PrivateKey,
+		CACertificate: &config.Data.KeyCertConf.RootCACertificate,
+		ServerAddress: config.Data.EventConf.ListenerHost,
+		ServerPort:    config.Data.EventConf.ListenerPort,
+	}
+	evtServer, err := conf.GetHTTPServerObj()
+	if err != nil {
+		log.Fatal("Unable to initialize event server: " + err.Error())
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(config.Data.EventConf.DestURI, RedfishEvents)
+	evtServer.Handler = mux
+	log.Fatal(evtServer.ListenAndServeTLS("", ""))
+}
+
+// RedfishEvents receives the subscribed events from the south bound system
+// Then it will send the received data and ip to publish method
+func RedfishEvents(w http.ResponseWriter, r *http.Request) {
+	var req interface{}
+	// Try to decode the request body into the struct. If there is an error,
+	// respond to the client with the error message and a 400 status code.
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	request, _ := json.Marshal(req)
+	log.Info("Event Request: ", sanitize(string(request)))
+
+	remoteAddr := r.RemoteAddr
+	// if southbound entities are behind a proxy, then
+	// originator address is expected to be in X-Forwarded-For header
+	forwardedFor := sanitize(r.Header.Get("X-Forwarded-For"))
+	if forwardedFor != "" {
+		log.Printf("Request contains X-Forwarded-For: %s; RemoteAddr: %s", forwardedFor, remoteAddr)
+		addrList := strings.Split(forwardedFor, ",")
+		// if multiple proxies are present, then the first address
+		// in the X-Forwarded-For header is considered as originator address
+		remoteAddr = addrList[0]
+	}
+	ip, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		ip = remoteAddr
+	}
+	ip = sanitize(ip)
+	log.Info("After splitting remote address, IP is: ", ip)
+
+	// replacing the device URIs in the event with north bound translation URL
+	event := common.Events{
+		IP:      ip,
+		Request: []byte(translation.ToNorthBound(string(request))),
+	}
+	In <- event
+	w.WriteHeader(http.StatusOK)
+}
+
+// Forward queues an event generated by the plugin itself to be published to the message bus
+func Forward(event common.Events) {
+	go func() {
+		In <- event
+	}()
+}
+
+// SendStartupEvent is for sending startup event
+func SendStartupEvent() {
+	// grace wait time for plugin to be functional
+	time.Sleep(3 * time.Second)
+
+	var pluginIP string
+	if pluginIP = os.Getenv("ASSIGNED_POD_IP"); pluginIP == "" {
+		pluginIP = config.Data.PluginConf.Host
+	}
+
+	startupEvt := common.PluginStatusEvent{
+		Name:         "Plugin startup event",
+		Type:         "PluginStarted",
+		Timestamp:    time.Now().String(),
+		OriginatorID: pluginIP,
+	}
+
+	request, _ := json.Marshal(startupEvt)
+	In <- common.Events{
+		IP:        net.JoinHostPort(config.Data.PluginConf.Host, config.Data.PluginConf.Port),
+		Request:   request,
+		EventType: "PluginStartUp",
+	}
+	log.Info("successfully sent startup event")
+}
+
+// sanitize removes the line breaks from the data received from the devices before it is logged
+func sanitize(data string) string {
+	return strings.Replace(strings.Replace(data, "\n", "", -1), "\r", "", -1)
+}
@@ -0,0 +1,78 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+)
+
+func TestRedfishEvents(t *testing.T) {
+	config.SetUpMockConfig(t)
+	in := make(chan interface{}, 1)
+	In = in
+
+	body := `{"Events":[{"EventType":"Alert","OriginOfCondition":{"@odata.id":"/ODIM/v1/Systems/1"}}]}`
+	req := httptest.NewRequest(http.MethodPost, config.Data.EventConf.DestURI, strings.NewReader(body))
+	req.RemoteAddr = "10.0.0.1:443"
+	req.Header.Set("X-Forwarded-For", "10.0.0.2,10.0.0.3")
+	w := httptest.NewRecorder()
+	RedfishEvents(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("RedfishEvents() status code = %v, want %v", w.Code, http.StatusOK)
+	}
+	event := (<-in).(common.Events)
+	if event.IP != "10.0.0.2" {
+		t.Errorf("RedfishEvents() IP = %v, want 10.0.0.2", event.IP)
+	}
+	// the mock configuration translates ODIM to redfish in the northbound direction
+	if !strings.Contains(string(event.Request), "/redfish/v1/Systems/1") {
+		t.Errorf("RedfishEvents() request = %v, want it to be translated", string(event.Request))
+	}
+
+	w = httptest.NewRecorder()
+	RedfishEvents(w, httptest.NewRequest(http.MethodPost, config.Data.EventConf.DestURI, strings.NewReader("event")))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("RedfishEvents() status code = %v, want %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestFormatRedfishEvent(t *testing.T) {
+	request := `{"@odata.type":"#Event.v1_7_0.Event","Name":"Event","Context":"ODIM","Events":[{"EventId":"1","EventType":"Alert","MessageId":"Alert.1.0.Test","OriginOfCondition":{"@odata.id":"/redfish/v1/Systems/1"}}]}`
+	event, err := FormatRedfishEvent(common.Events{IP: "10.0.0.1", Request: []byte(request)})
+	if err != nil {
+		t.Fatalf("FormatRedfishEvent() error = %v", err)
+	}
+	var message common.MessageData
+	if err := json.Unmarshal(event.Request, &message); err != nil {
+		t.Fatalf("FormatRedfishEvent() returned invalid event: %v", err)
+	}
+	if message.Context != "ODIM" || len(message.Events) != 1 {
+		t.Fatalf("FormatRedfishEvent() = %+v, want the event message to be kept", message)
+	}
+	if message.Events[0].MessageID != "Alert.1.0.Test" || message.Events[0].OriginOfCondition.Oid != "/redfish/v1/Systems/1" {
+		t.Errorf("FormatRedfishEvent() event = %+v, want the event record to be kept", message.Events[0])
+	}
+
+	if _, err := FormatRedfishEvent(common.Events{Request: []byte("event")}); err == nil {
+		t.Error("FormatRedfishEvent() error = nil, want error for malformed event")
+	}
+}
@@ -1,4 +1,4 @@
-//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
 //
 //Licensed under the Apache License, Version 2.0 (the "License"); you may
 //not use this file except in compliance with the License. You may obtain
@@ -12,8 +12,7 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-//Package rfpmessagebus ...
-package rfpmessagebus
+package events
 
 import (
 	"encoding/json"
@@ -23,15 +22,15 @@ import (
 
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/model"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
 	log "github.com/sirupsen/logrus"
 )
 
-// Publish function will handle events request in two originofcondition format
-// originofcondition can be with or without @odata.id
-func Publish(data interface{}) bool {
+// Publish formats the event with format and writes it to the message bus,
+// the startup event of the plugin is written as it is
+func Publish(data interface{}, format Formatter) bool {
 	if data == nil {
 		log.Error("Nil data passed to event publisher")
 		return false
@@ -40,22 +39,9 @@ func Publish(data interface{}) bool {
 	if event.EventType == "PluginStartUp" {
 		return writeToMessageBus(event)
 	}
-
-	// this map is to check what type of event is recieved.
-	// if @odata.type contains MetricReport then its of type MetricReport message objects else event message objects
-	var metricReportEventDataMap map[string]interface{}
-	err := json.Unmarshal(event.Request, &metricReportEventDataMap)
-	if err != nil {
-		log.Error("Failed to unmarshal the event, got: " + err.Error())
-		return false
-	}
-	odatatype := metricReportEventDataMap["@odata.type"]
-	if odatatype != nil && strings.Contains(odatatype.(string), "MetricReport") {
-		event, err = formatMetricReportEventRequest(event)
-	} else {
-		event, err = formatRedfishEventRequest(event)
-	}
+	event, err := format(event)
 	if err != nil {
+		log.Error("Failed to format the event, got: " + err.Error())
 		return false
 	}
 	if !writeToMessageBus(event) {
@@ -66,11 +52,27 @@ func Publish(data interface{}) bool {
 	return true
 }
 
+// FormatRedfishEvent is the Formatter of the events posted in the DMTF Redfish format,
+// it handles both the metric reports and the event messages
+func FormatRedfishEvent(event common.Events) (common.Events, error) {
+	// this map is to check what type of event is recieved.
+	// if @odata.type contains MetricReport then its of type MetricReport message objects else event message objects
+	var metricReportEventDataMap map[string]interface{}
+	if err := json.Unmarshal(event.Request, &metricReportEventDataMap); err != nil {
+		return event, err
+	}
+	odatatype, _ := metricReportEventDataMap["@odata.type"].(string)
+	if strings.Contains(odatatype, "MetricReport") {
+		return formatMetricReportEventRequest(event)
+	}
+	return formatRedfishEventRequest(event)
+}
+
 func formatMetricReportEventRequest(eventRequest common.Events) (common.Events, error) {
 	var event common.Events
 	// prepare the device data
-	var devices []rfpmodel.Device
-	rfpmodel.GetAllDevicesInInventory(&devices)
+	var devices []model.Device
+	model.GetAllDevicesInInventory(&devices)
 
 	systemUUID := getMatchingDeviceUUID(eventRequest.IP, devices)
 	if systemUUID == "" {
@@ -92,19 +94,16 @@ func formatMetricReportEventRequest(eventRequest common.Events) (common.Events,
 }
 
 func formatRedfishEventRequest(eventRequest common.Events) (common.Events, error) {
-	// Since we are deleting the first event from the eventlist,
-	// processing the first event
-	var message common.MessageData
-	err := json.Unmarshal(eventRequest.Request, &message)
-	if err != nil {
-		log.Error("Failed to unmarshal the event: " + err.Error())
+	var messageData dmtf.Event
+	if err := json.Unmarshal(eventRequest.Request, &messageData); err != nil {
 		return eventRequest, err
 	}
-	var messageData dmtf.Event
-	message.Context = messageData.Context
-	message.Name = messageData.Name
-	message.OdataType = messageData.ODataType
-	message.Events = make([]common.Event, 0)
+	message := common.MessageData{
+		Context:   messageData.Context,
+		Name:      messageData.Name,
+		OdataType: messageData.ODataType,
+		Events:    make([]common.Event, 0, len(messageData.Events)),
+	}
 	for i := 0; i < len(messageData.Events); i++ {
 		var eventData common.Event
 		eventData.EventGroupID = messageData.Events[i].EventGroupID
@@ -116,8 +115,10 @@ func formatRedfishEventRequest(eventRequest common.Events) (common.Events, error
 		eventData.Severity = messageData.Events[i].Severity
 		eventData.Oem = messageData.Events[i].Oem
 		eventData.MessageID = messageData.Events[i].MessageID
-		eventData.OriginOfCondition = &common.Link{
-			Oid: messageData.Events[i].OriginOfCondition.Oid,
+		if messageData.Events[i].OriginOfCondition != nil {
+			eventData.OriginOfCondition = &common.Link{
+				Oid: messageData.Events[i].OriginOfCondition.Oid,
+			}
 		}
 		eventData.MessageArgs = messageData.Events[i].MessageArgs
 		message.Events = append(message.Events, eventData)
@@ -126,25 +127,11 @@ func formatRedfishEventRequest(eventRequest common.Events) (common.Events, error
 	return eventRequest, nil
 }
 
-func getMatchingDeviceUUID(serverIP string, devices []rfpmodel.Device) string {
-	if len(devices) < 1 {
-		return ""
-	}
-
-	front := 0
-	rear := len(devices) - 1
-	for front <= rear {
-		host := getIPFromHostName(devices[front].Host)
-		if host == serverIP {
-			return devices[front].SystemID
-		}
-
-		host = getIPFromHostName(devices[rear].Host)
-		if host == serverIP {
-			return devices[rear].SystemID
+func getMatchingDeviceUUID(serverIP string, devices []model.Device) string {
+	for _, device := range devices {
+		if getIPFromHostName(device.Host) == serverIP {
+			return device.SystemID
 		}
-		front++
-		rear--
 	}
 	return ""
 }
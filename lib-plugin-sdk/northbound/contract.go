@@ -0,0 +1,58 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package northbound implements the contract between ODIM and its plugins, the endpoints
+// every plugin serves under /ODIM/v1 regardless of the devices it manages
+package northbound
+
+import (
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/model"
+	iris "github.com/kataras/iris/v12"
+)
+
+// defaultName is reported as the name of the plugin when the Contract has none
+const defaultName = "Common Redfish Plugin Status"
+
+// Contract holds the vendor specific hooks of the northbound contract
+type Contract struct {
+	// Name is reported as the name of the plugin in the plugin status
+	Name string
+	// PrepareSubscription adjusts the event subscription before it is created on a device,
+	// for the devices which do not support all the properties of an event destination
+	PrepareSubscription func(subscription *model.EvtSubPost)
+}
+
+// DropEventFormatType is the PrepareSubscription hook of the plugins
+// whose devices do not support the EventFormatType of the subscriptions
+func DropEventFormatType(subscription *model.EvtSubPost) {
+	subscription.EventFormatType = ""
+}
+
+// contract is the Contract registered by the plugin
+var contract Contract
+
+// RegisterRoutes registers the endpoints of the northbound contract
+// on the /ODIM/v1 party of the plugin with the hooks of the vendor
+func RegisterRoutes(pluginRoutes iris.Party, c Contract) {
+	if c.Name == "" {
+		c.Name = defaultName
+	}
+	contract = c
+	pluginRoutes.Post("/validate", BasicAuth, Validate)
+	pluginRoutes.Post("/Sessions", CreateSession)
+	pluginRoutes.Post("/Subscriptions", BasicAuth, CreateEventSubscription)
+	pluginRoutes.Delete("/Subscriptions", BasicAuth, DeleteEventSubscription)
+	pluginRoutes.Get("/Status", GetPluginStatus)
+	pluginRoutes.Post("/Startup", BasicAuth, GetPluginStartup)
+}
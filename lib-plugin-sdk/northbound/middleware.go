@@ -0,0 +1,60 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package northbound
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	iris "github.com/kataras/iris/v12"
+	log "github.com/sirupsen/logrus"
+)
+
+// BasicAuth is used to validate REST API calls with plugin with basic autherization
+func BasicAuth(ctx iris.Context) {
+	basicAuth := ctx.GetHeader("Authorization")
+	if basicAuth == "" {
+		ctx.Next()
+		return
+	}
+	spl := strings.SplitN(basicAuth, " ", 2)
+	if len(spl) < 2 || spl[0] != "Basic" {
+		log.Error("Not a valid basic auth")
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.WriteString("Not a valid basic auth")
+		return
+	}
+	data, err := base64.StdEncoding.DecodeString(spl[1])
+	if err != nil {
+		log.Error(err.Error())
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.WriteString(err.Error())
+		return
+	}
+	userCred := strings.SplitN(string(data), ":", 2)
+	if len(userCred) < 2 {
+		log.Error("Not a valid basic auth")
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.WriteString("Not a valid basic auth")
+		return
+	}
+	if !validateCredentials(userCred[0], userCred[1]) {
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.WriteString("Invalid Username/Password")
+		return
+	}
+	ctx.Next()
+}
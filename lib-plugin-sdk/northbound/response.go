@@ -0,0 +1,91 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package northbound
+
+import (
+	errorResponse "github.com/ODIM-Project/ODIM/lib-utilities/response"
+	iris "github.com/kataras/iris/v12"
+)
+
+// ErrorResponse is the standard error response of the plugin
+type ErrorResponse struct {
+	Error Error `json:"Error"`
+}
+
+// Error holds the error details of the ErrorResponse
+type Error struct {
+	Code                string            `json:"Code"`
+	Message             string            `json:"Message"`
+	MessageExtendedInfo []MsgExtendedInfo `json:"@Message.ExtendedInfo"`
+}
+
+// MsgExtendedInfo holds the extended information of an error
+type MsgExtendedInfo struct {
+	MessageID   string   `json:"MessageId"`
+	Message     string   `json:"Message"`
+	MessageArgs []string `json:"MessageArgs"`
+}
+
+// DeviceResponse is the response of the device validation
+type DeviceResponse struct {
+	ServerIP   string `json:"ServerIP"`
+	Username   string `json:"Username"`
+	DeviceUUID string `json:"device_UUID"`
+}
+
+// PluginStatusResponse holds the information of response of PluginStatus
+type PluginStatusResponse struct {
+	Comment         string          `json:"_comment"`
+	Name            string          `json:"Name"`
+	Version         string          `json:"Version"`
+	Status          PluginStatus    `json:"Status"`
+	EventMessageBus EventMessageBus `json:"EventMessageBus"`
+}
+
+// PluginStatus holds information of Plugin Status
+type PluginStatus struct {
+	Available string `json:"Available"`
+	Uptime    string `json:"Uptime"`
+	TimeStamp string `json:"TimeStamp"`
+}
+
+// EventMessageBus holds the information of EMB Broker type and EMBQueue information
+type EventMessageBus struct {
+	EmbType  string     `json:"EmbType"`
+	EmbQueue []EmbQueue `json:"EmbQueue"`
+}
+
+// EmbQueue holds the information of Queue Name and Queue Description
+type EmbQueue struct {
+	QueueName string `json:"EmbQueueName"`
+	QueueDesc string `json:"EmbQueueDesc"`
+}
+
+// SetErrorResponse writes the standard error response with the status code to ctx
+func SetErrorResponse(ctx iris.Context, err string, statusCode int32) {
+	ctx.StatusCode(int(statusCode))
+	ctx.JSON(ErrorResponse{
+		Error: Error{
+			Code:    errorResponse.GeneralError,
+			Message: "See @Message.ExtendedInfo for more information.",
+			MessageExtendedInfo: []MsgExtendedInfo{
+				{
+					MessageID: errorResponse.GeneralError,
+					Message:   err,
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,124 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package northbound
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/model"
+	iris "github.com/kataras/iris/v12"
+	uuid "github.com/satori/go.uuid"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/sha3"
+)
+
+// TokenMap is used to define the plugin generated tokens
+type TokenMap struct {
+	Token    string
+	LastUsed time.Time
+}
+
+var (
+	tokenLock    sync.RWMutex
+	tokenDetails []TokenMap
+)
+
+// CreateSession is used to create session for odimra to interact with plugin
+func CreateSession(ctx iris.Context) {
+	var userCreds model.Users
+	rawBodyAsBytes, err := ioutil.ReadAll(ctx.Request().Body)
+	if err != nil {
+		errorMessage := "Unable to validate the credentials: " + err.Error()
+		log.Error(errorMessage)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.WriteString(errorMessage)
+		return
+	}
+	err = json.Unmarshal(rawBodyAsBytes, &userCreds)
+	if err != nil {
+		errorMessage := "Unable to unmarshal user details: " + err.Error()
+		log.Error(errorMessage)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.WriteString(errorMessage)
+		return
+	}
+	//Validate the credentials
+	if !validateCredentials(userCreds.Username, userCreds.Password) {
+		errorMessage := "Invalid credentials for session creation"
+		log.Error(errorMessage)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.WriteString(errorMessage)
+		return
+	}
+	//Create token
+	token := uuid.NewV4().String()
+	tokenLock.Lock()
+	tokenDetails = append(tokenDetails, TokenMap{
+		Token:    token,
+		LastUsed: time.Now(),
+	})
+	tokenLock.Unlock()
+	ctx.StatusCode(http.StatusCreated)
+	ctx.Header("X-Auth-Token", token)
+}
+
+// TokenValidation validates sent token with the list of plugin generated tokens
+func TokenValidation(token string) bool {
+	tokenLock.RLock()
+	defer tokenLock.RUnlock()
+	for _, v := range tokenDetails {
+		if token == v.Token {
+			if time.Since(v.LastUsed).Minutes() > config.Data.SessionTimeoutInMinutes {
+				log.Warn("X-Auth-Token is not used within SessionTimeoutInMinutes")
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// validateCredentials checks the credentials against the ones configured for the plugin,
+// the password is configured as its SHA3-512 hash
+func validateCredentials(userName, password string) bool {
+	if config.Data.PluginConf.UserName != userName {
+		return false
+	}
+	hash := sha3.New512()
+	hash.Write([]byte(password))
+	hashedPassword := base64.URLEncoding.EncodeToString(hash.Sum(nil))
+	if config.Data.PluginConf.Password != hashedPassword {
+		log.Error("Username/password does not match")
+		return false
+	}
+	return true
+}
+
+// validateToken writes the error response when the X-Auth-Token of the request is not valid
+func validateToken(ctx iris.Context) bool {
+	token := ctx.GetHeader("X-Auth-Token")
+	if token != "" && !TokenValidation(token) {
+		log.Error("Invalid/Expired X-Auth-Token")
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.WriteString("Invalid/Expired X-Auth-Token")
+		return false
+	}
+	return true
+}
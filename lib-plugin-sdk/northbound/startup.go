@@ -0,0 +1,169 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package northbound
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/model"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/redfish"
+	iris "github.com/kataras/iris/v12"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetPluginStartup updates the inventory of the plugin with the devices sent by ODIM
+// and, on a full resync, makes sure the event subscription of every device is in place
+func GetPluginStartup(ctx iris.Context) {
+	if !validateToken(ctx) {
+		return
+	}
+	var startup model.StartUpData
+	err := ctx.ReadJSON(&startup)
+	if err != nil {
+		errMsg := "Unable to collect data from request: " + err.Error()
+		log.Error(errMsg)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.WriteString(errMsg)
+		return
+	}
+	if len(startup.Devices) <= 0 {
+		log.Info("startup devices list is empty")
+		ctx.StatusCode(http.StatusOK)
+		return
+	}
+	log.Infof("inventory update request received for %d devices", len(startup.Devices))
+
+	var (
+		lock     sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	respBody := make(map[string]string)
+	for uuid, device := range startup.Devices {
+		if device.Operation == "add" {
+			model.AddDeviceToInventory(uuid, device)
+			log.Info("device " + uuid + " added to the inventory")
+		}
+		if device.Operation == "del" {
+			address := device.Address
+			if inventoryDevice, ok := model.GetDeviceFromInventory(uuid); ok && address == "" {
+				address = inventoryDevice.Address
+			}
+			model.DeleteDeviceInInventory(uuid)
+			// the sessions with the removed device are not needed anymore
+			if address != "" {
+				go redfish.EvictDeviceSession(address)
+			}
+			log.Info("device " + uuid + " removed from the inventory")
+		}
+		if startup.ResyncEvtSubscription && startup.RequestType == "full" {
+			wg.Add(1)
+			go func(device model.DeviceData) {
+				defer wg.Done()
+				location, err := checkCreateSub(device)
+				lock.Lock()
+				defer lock.Unlock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+					}
+					return
+				}
+				respBody[device.Address] = location
+			}(device)
+		}
+	}
+	wg.Wait()
+	if firstErr != nil {
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.WriteString(firstErr.Error())
+		return
+	}
+	ctx.StatusCode(http.StatusOK)
+	ctx.JSON(respBody)
+}
+
+// checkCreateSub recreates the event subscription on the device when it is missing
+// or its event types differ from the ones ODIM has, and returns its location
+func checkCreateSub(server model.DeviceData) (string, error) {
+	subscriptionInfo := server.EventSubscriptionInfo
+	if subscriptionInfo == nil {
+		subscriptionInfo = &model.EventSubscriptionInfo{}
+	}
+	device := &redfish.RedfishDevice{
+		Host:     server.Address,
+		Username: server.UserName,
+		Password: string(server.Password),
+		Location: subscriptionInfo.Location,
+	}
+	redfishClient, err := redfish.GetRedfishClient()
+	if err != nil {
+		return "", err
+	}
+	resp, err := redfishClient.GetSubscriptionDetail(device)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	eventTypes := []string{"Alert"}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return "", err
+		}
+		var obj model.EvtSubPost
+		if err = json.Unmarshal(body, &obj); err != nil {
+			return "", err
+		}
+		if reflect.DeepEqual(obj.EventTypes, subscriptionInfo.EventTypes) {
+			return resp.Header.Get("Location"), nil
+		}
+		deleteResp, err := redfishClient.DeleteSubscriptionDetail(device)
+		if err != nil {
+			return "", err
+		}
+		deleteResp.Body.Close()
+		eventTypes = subscriptionInfo.EventTypes
+	case http.StatusNotFound:
+	default:
+		return resp.Header.Get("Location"), nil
+	}
+
+	req := model.EvtSubPost{
+		Destination: eventDestination(),
+		EventTypes:  eventTypes,
+		Context:     "Event Subscription",
+		Protocol:    "Redfish",
+	}
+	if contract.PrepareSubscription != nil {
+		contract.PrepareSubscription(&req)
+	}
+	device.PostBody, err = json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	subscribeResp, err := redfishClient.SubscribeForEvents(device)
+	if err != nil {
+		return "", err
+	}
+	defer subscribeResp.Body.Close()
+	return subscribeResp.Header.Get("Location"), nil
+}
@@ -0,0 +1,59 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package northbound
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	iris "github.com/kataras/iris/v12"
+)
+
+var (
+	// Status holds the Status of plugin, it is initialized during the startup
+	Status PluginStatus
+	// PluginStartTime holds the time from which plugin started
+	PluginStartTime time.Time
+)
+
+// GetPluginStatus returns the status of the plugin and its event message bus
+func GetPluginStatus(ctx iris.Context) {
+	if !validateToken(ctx) {
+		return
+	}
+	resp := PluginStatusResponse{
+		Comment: "Plugin Status Response",
+		Name:    contract.Name,
+		Version: config.Data.FirmwareVersion,
+		Status:  Status,
+	}
+	if resp.Name == "" {
+		resp.Name = defaultName
+	}
+	resp.Status.Uptime = time.Since(PluginStartTime).String()
+	resp.Status.TimeStamp = time.Now().Format(time.RFC3339)
+	if config.Data.MessageBusConf != nil {
+		resp.EventMessageBus.EmbType = config.Data.MessageBusConf.EmbType
+		for _, queue := range config.Data.MessageBusConf.EmbQueue {
+			resp.EventMessageBus.EmbQueue = append(resp.EventMessageBus.EmbQueue, EmbQueue{
+				QueueName: queue,
+				QueueDesc: "Queue for redfish events",
+			})
+		}
+	}
+	ctx.StatusCode(http.StatusOK)
+	ctx.JSON(resp)
+}
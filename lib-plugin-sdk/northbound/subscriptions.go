@@ -0,0 +1,284 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package northbound
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/model"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/redfish"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/translation"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	iris "github.com/kataras/iris/v12"
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateEventSubscription subscribes for the events of the device, with ODIM as the destination
+func CreateEventSubscription(ctx iris.Context) {
+	device, deviceDetails, err := getDeviceDetails(ctx)
+	if err != nil {
+		return
+	}
+	//First delete existing matching subscription(our subscription) from device
+	deleteMatchingSubscriptions(device)
+
+	var reqPostBody model.EvtSubPost
+	err = json.Unmarshal([]byte(translation.ToSouthBound(string(deviceDetails.PostBody))), &reqPostBody)
+	if err != nil {
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.WriteString(err.Error())
+		return
+	}
+
+	// remove the mesaageids, resourcestypes and originresources from the request and post it to device
+	// since some of device doesnt support these
+	req := model.EvtSubPost{
+		Destination:     eventDestination(),
+		EventTypes:      reqPostBody.EventTypes,
+		Context:         reqPostBody.Context,
+		HTTPHeaders:     reqPostBody.HTTPHeaders,
+		Protocol:        reqPostBody.Protocol,
+		EventFormatType: reqPostBody.EventFormatType,
+	}
+	if contract.PrepareSubscription != nil {
+		contract.PrepareSubscription(&req)
+	}
+	device.PostBody, err = json.Marshal(req)
+	if err != nil {
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.WriteString(err.Error())
+		return
+	}
+
+	redfishClient, err := redfish.GetRedfishClient()
+	if err != nil {
+		errMsg := "Internal processing error: " + err.Error()
+		log.Error(errMsg)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.WriteString(errMsg)
+		return
+	}
+	//Subscribe to Events
+	resp, err := redfishClient.SubscribeForEvents(device)
+	if err != nil {
+		log.Error(err.Error())
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.WriteString(err.Error())
+		return
+	}
+	defer resp.Body.Close()
+	validateResponse(ctx, device, resp, http.MethodPost)
+}
+
+// DeleteEventSubscription deletes the event subscription on the device
+func DeleteEventSubscription(ctx iris.Context) {
+	device, _, err := getDeviceDetails(ctx)
+	if err != nil {
+		return
+	}
+	redfishClient, err := redfish.GetRedfishClient()
+	if err != nil {
+		errMsg := "While trying to create the redfish client, got:" + err.Error()
+		log.Error(errMsg)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.WriteString(errMsg)
+		return
+	}
+	//Delete Subscription details
+	resp, err := redfishClient.DeleteSubscriptionDetail(device)
+	if err != nil {
+		log.Error(err.Error())
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.WriteString(err.Error())
+		return
+	}
+	defer resp.Body.Close()
+	validateResponse(ctx, device, resp, http.MethodDelete)
+}
+
+// eventDestination is the destination of the event subscriptions created on the devices
+func eventDestination() string {
+	return "https://" + config.Data.LoadBalancerConf.Host + ":" + config.Data.LoadBalancerConf.Port + config.Data.EventConf.DestURI
+}
+
+// deleteMatchingSubscriptions deletes the subscriptions of ODIM on the device
+func deleteMatchingSubscriptions(device *redfish.RedfishDevice) {
+	device.Location = "https://" + device.Host + "/redfish/v1/EventService/Subscriptions"
+	redfishClient, err := redfish.GetRedfishClient()
+	if err != nil {
+		log.Error("While trying to create the redfish client, got:" + err.Error())
+		return
+	}
+	resp, err := redfishClient.GetSubscriptionDetail(device)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Error(fmt.Sprintf("Unable to get subscription details for URI: %v got %v", device.Location, resp.StatusCode))
+		return
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	var subscriptions struct {
+		Members []struct {
+			OdataID string `json:"@odata.id"`
+		} `json:"Members"`
+	}
+	if err = json.Unmarshal(body, &subscriptions); err != nil {
+		log.Error(err.Error())
+		return
+	}
+	for _, member := range subscriptions.Members {
+		device.Location = "https://" + device.Host + member.OdataID
+		if isOurSubscription(redfishClient, device) {
+			resp, err := redfishClient.DeleteSubscriptionDetail(device)
+			if err != nil {
+				log.Error(err.Error())
+				return
+			}
+			resp.Body.Close()
+		}
+	}
+}
+
+// isOurSubscription checks whether the destination of the subscription is ODIM
+func isOurSubscription(redfishClient *redfish.RedfishClient, device *redfish.RedfishDevice) bool {
+	resp, err := redfishClient.GetSubscriptionDetail(device)
+	if err != nil {
+		log.Error(err.Error())
+		return false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Error(fmt.Sprintf("Unable to get subscription details for URI: %v got %v", device.Location, resp.StatusCode))
+		return false
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		log.Error(err.Error())
+		return false
+	}
+	var subscription model.EvtSubPost
+	if err = json.Unmarshal(body, &subscription); err != nil {
+		log.Error(err.Error())
+		return false
+	}
+	// if the subscription is ours then the destination should match with LBHOST:LBPORT
+	return strings.Contains(subscription.Destination, config.Data.LoadBalancerConf.Host+":"+config.Data.LoadBalancerConf.Port)
+}
+
+// getDeviceDetails validates the token of the request and reads the device details from its body
+func getDeviceDetails(ctx iris.Context) (*redfish.RedfishDevice, *model.Device, error) {
+	if !validateToken(ctx) {
+		return nil, nil, fmt.Errorf("Invalid/Expired X-Auth-Token")
+	}
+	var deviceDetails model.Device
+	err := ctx.ReadJSON(&deviceDetails)
+	if err != nil {
+		log.Error("Unable to collect data from request: " + err.Error())
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.WriteString("Error: bad request.")
+		return nil, nil, err
+	}
+	device := &redfish.RedfishDevice{
+		Host:     deviceDetails.Host,
+		Username: deviceDetails.Username,
+		Password: string(deviceDetails.Password),
+		Location: deviceDetails.Location,
+	}
+	return device, &deviceDetails, nil
+}
+
+// validateResponse writes the response of the device to ctx. When the device fails to create
+// the subscription, it is created once again without the MessageIds which some devices don't support.
+func validateResponse(ctx iris.Context, device *redfish.RedfishDevice, resp *http.Response, method string) error {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		log.Error(err.Error())
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.WriteString(err.Error())
+		return err
+	}
+	if method == http.MethodPost && resp.StatusCode != http.StatusCreated {
+		if err := removeMessageID(device); err != nil {
+			ctx.StatusCode(http.StatusInternalServerError)
+			ctx.WriteString(err.Error())
+			return err
+		}
+		redfishClient, err := redfish.GetRedfishClient()
+		if err != nil {
+			errMsg := "While trying to create the redfish client, got:" + err.Error()
+			log.Error(errMsg)
+			ctx.StatusCode(http.StatusInternalServerError)
+			ctx.WriteString(errMsg)
+			return err
+		}
+		resp, err = redfishClient.SubscribeForEvents(device)
+		if err != nil {
+			log.Error(err.Error())
+			ctx.StatusCode(http.StatusInternalServerError)
+			ctx.WriteString(err.Error())
+			return err
+		}
+		defer resp.Body.Close()
+		body, err = ioutil.ReadAll(resp.Body)
+		if err != nil {
+			log.Error(err.Error())
+			ctx.StatusCode(http.StatusInternalServerError)
+			ctx.WriteString(err.Error())
+			return err
+		}
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.WriteString("Authtication with the device failed")
+		return errors.New("Authentication with the device failed")
+	}
+	if resp.StatusCode >= 300 {
+		log.Error("Subscription operation failed: " + string(body))
+	}
+	common.SetResponseHeader(ctx, map[string]string{
+		"Location": resp.Header.Get("Location"),
+	})
+	ctx.StatusCode(resp.StatusCode)
+	ctx.WriteString(string(body))
+	return nil
+}
+
+// removeMessageID removes the MessageIds from the subscription request of the device
+func removeMessageID(device *redfish.RedfishDevice) error {
+	var reqPostBody model.EvtSubPost
+	if err := json.Unmarshal(device.PostBody, &reqPostBody); err != nil {
+		return err
+	}
+	reqPostBody.MessageIds = nil
+	postBody, err := json.Marshal(reqPostBody)
+	if err != nil {
+		return err
+	}
+	device.PostBody = postBody
+	return nil
+}
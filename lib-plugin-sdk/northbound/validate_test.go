@@ -0,0 +1,115 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package northbound
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	iris "github.com/kataras/iris/v12"
+)
+
+// startMockDevice starts a device which accepts admin/password, with the certificate of the mock configuration
+func startMockDevice(t *testing.T) string {
+	cert, err := tls.X509KeyPair(config.Data.KeyCertConf.Certificate, config.Data.KeyCertConf.PrivateKey)
+	if err != nil {
+		t.Fatalf("unable to load the certificate of the mock device: %v", err)
+	}
+	device := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redfish/v1" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"UUID": "device-uuid",
+				"Systems": map[string]string{
+					"@odata.id": "/redfish/v1/Systems",
+				},
+			})
+			return
+		}
+		if userName, password, ok := r.BasicAuth(); !ok || userName != "admin" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	device.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	device.StartTLS()
+	t.Cleanup(device.Close)
+	return strings.TrimPrefix(device.URL, "https://")
+}
+
+func TestValidate(t *testing.T) {
+	config.SetUpMockConfig(t)
+	host := startMockDevice(t)
+	app := iris.New()
+	app.Post("/ODIM/v1/validate", BasicAuth, Validate)
+	if err := app.Build(); err != nil {
+		t.Fatalf("unable to build the routes: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		body          string
+		want          int
+	}{
+		{
+			name:          "valid device credentials",
+			authorization: "admin:Od!m12$4",
+			body:          `{"ManagerAddress":"` + host + `","UserName":"admin","Password":"cGFzc3dvcmQ="}`,
+			want:          http.StatusOK,
+		},
+		{
+			name: "no authorization",
+			body: `{"ManagerAddress":"` + host + `","UserName":"admin","Password":"cGFzc3dvcmQ="}`,
+			want: http.StatusUnauthorized,
+		},
+		{
+			name:          "invalid device credentials",
+			authorization: "admin:Od!m12$4",
+			body:          `{"ManagerAddress":"` + host + `","UserName":"admin","Password":"cGFzc3dvcmQx"}`,
+			want:          http.StatusUnauthorized,
+		},
+		{
+			name:          "request body is not JSON",
+			authorization: "admin:Od!m12$4",
+			body:          "requestbody",
+			want:          http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/ODIM/v1/validate", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.authorization != "" {
+				credentials := strings.SplitN(tt.authorization, ":", 2)
+				req.SetBasicAuth(credentials[0], credentials[1])
+			}
+			resp := httptest.NewRecorder()
+			app.ServeHTTP(resp, req)
+			if resp.Code != tt.want {
+				t.Errorf("Validate() status code = %v, want %v, body: %s", resp.Code, tt.want, resp.Body.String())
+			}
+			if tt.want == http.StatusOK && !strings.Contains(resp.Body.String(), "device-uuid") {
+				t.Errorf("Validate() body = %s, want the UUID of the device", resp.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,335 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package redfish
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	lutilconf "github.com/ODIM-Project/ODIM/lib-utilities/config"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	sessionServiceURI = "/redfish/v1/SessionService/Sessions"
+	// defaultSessionIdleTimeout and defaultMaxRequestsPerDevice are used when DeviceSessionConf is not configured
+	defaultSessionIdleTimeout   = 10 * time.Minute
+	defaultMaxRequestsPerDevice = 4
+)
+
+// deviceSession is the session of the plugin with a BMC for a user, which is shared by all the requests
+// of the user to the BMC
+type deviceSession struct {
+	lock     sync.Mutex
+	host     string
+	username string
+	password string
+	token    string
+	location string
+	// basicAuthUntil is set when the BMC does not support sessions,
+	// till then the requests are sent with basic authentication
+	basicAuthUntil time.Time
+	lastUsed       time.Time
+	// inFlight limits the requests sent to the BMC at a time, it is shared by the sessions of all the users
+	inFlight chan struct{}
+}
+
+// sessionKey identifies the session of a user with a BMC
+type sessionKey struct {
+	host     string
+	username string
+}
+
+// sessionPool holds the sessions of all the BMCs, keyed by the host address and the user name, so that
+// a request with the credentials of another user does not replace the session in use
+type sessionPool struct {
+	lock     sync.Mutex
+	sessions map[sessionKey]*deviceSession
+	inFlight map[string]chan struct{}
+	evictor  sync.Once
+}
+
+var devicePool = &sessionPool{
+	sessions: make(map[sessionKey]*deviceSession),
+	inFlight: make(map[string]chan struct{}),
+}
+
+func sessionIdleTimeout() time.Duration {
+	if config.Data.DeviceSessionConf == nil || config.Data.DeviceSessionConf.IdleTimeoutInMinutes <= 0 {
+		return defaultSessionIdleTimeout
+	}
+	return time.Duration(config.Data.DeviceSessionConf.IdleTimeoutInMinutes) * time.Minute
+}
+
+func maxRequestsPerDevice() int {
+	if config.Data.DeviceSessionConf == nil || config.Data.DeviceSessionConf.MaxConcurrentRequestsPerDevice <= 0 {
+		return defaultMaxRequestsPerDevice
+	}
+	return config.Data.DeviceSessionConf.MaxConcurrentRequestsPerDevice
+}
+
+// get returns the session of the user with the host, a new one is added to the pool if there is none
+func (p *sessionPool) get(host, username string) *deviceSession {
+	p.evictor.Do(func() {
+		go p.evictIdleSessions()
+	})
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	key := sessionKey{host: host, username: username}
+	session, ok := p.sessions[key]
+	if !ok {
+		inFlight, ok := p.inFlight[host]
+		if !ok {
+			inFlight = make(chan struct{}, maxRequestsPerDevice())
+			p.inFlight[host] = inFlight
+		}
+		session = &deviceSession{
+			host:     host,
+			username: username,
+			inFlight: inFlight,
+		}
+		p.sessions[key] = session
+	}
+	return session
+}
+
+// remove takes the sessions matching the filter out of the pool and returns them
+func (p *sessionPool) remove(filter func(key sessionKey) bool) []*deviceSession {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var sessions []*deviceSession
+	for key, session := range p.sessions {
+		if filter(key) {
+			sessions = append(sessions, session)
+			delete(p.sessions, key)
+		}
+	}
+	return sessions
+}
+
+// evictIdleSessions periodically deletes the sessions on the BMCs
+// which are not used within the idle timeout
+func (p *sessionPool) evictIdleSessions() {
+	idleTimeout := sessionIdleTimeout()
+	ticker := time.NewTicker(idleTimeout / 2)
+	defer ticker.Stop()
+	for range ticker.C {
+		p.lock.Lock()
+		var sessions []*deviceSession
+		for _, session := range p.sessions {
+			sessions = append(sessions, session)
+		}
+		p.lock.Unlock()
+		for _, session := range sessions {
+			session.lock.Lock()
+			if session.token != "" && len(session.inFlight) == 0 && time.Since(session.lastUsed) > idleTimeout {
+				session.evict()
+			}
+			session.lock.Unlock()
+		}
+	}
+}
+
+// EvictDeviceSession deletes the pooled sessions of the host on the BMC and removes them from the pool,
+// it is called when the device is removed from ODIM
+func EvictDeviceSession(host string) {
+	sessions := devicePool.remove(func(key sessionKey) bool {
+		return key.host == host
+	})
+	devicePool.lock.Lock()
+	delete(devicePool.inFlight, host)
+	devicePool.lock.Unlock()
+	evictAll(sessions)
+}
+
+// CloseDeviceSessions deletes all the pooled sessions on the BMCs, it is called when the plugin is shut down
+func CloseDeviceSessions() {
+	evictAll(devicePool.remove(func(key sessionKey) bool {
+		return true
+	}))
+}
+
+func evictAll(sessions []*deviceSession) {
+	var wg sync.WaitGroup
+	for _, session := range sessions {
+		wg.Add(1)
+		go func(session *deviceSession) {
+			defer wg.Done()
+			session.lock.Lock()
+			defer session.lock.Unlock()
+			session.evict()
+		}(session)
+	}
+	wg.Wait()
+}
+
+// evict deletes the session on the BMC and forgets its token, the caller must hold the lock of the session.
+// A request which is still using the token gets it renewed when the BMC rejects it.
+func (session *deviceSession) evict() {
+	if session.token == "" {
+		return
+	}
+	client, err := GetRedfishClient()
+	if err != nil {
+		log.Error("unable to delete the session of " + session.host + ": " + err.Error())
+		return
+	}
+	client.deleteSession(session.host, session.token, session.location)
+	session.token, session.location = "", ""
+}
+
+// sessionToken returns the token of the session with the device, a session is created when there is
+// no valid one. An empty token is returned when the request has to be sent with basic authentication.
+// A request with another password creates a session of its own, which replaces the pooled one only when
+// the BMC accepts the password, so that a request with invalid credentials does not end the session in use.
+func (client *RedfishClient) sessionToken(session *deviceSession, device *RedfishDevice) string {
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	session.lastUsed = time.Now()
+	passwordChanged := session.password != device.Password
+	if !passwordChanged && (session.token != "" || time.Now().Before(session.basicAuthUntil)) {
+		return session.token
+	}
+
+	body, _ := json.Marshal(map[string]string{
+		"UserName": device.Username,
+		"Password": device.Password,
+	})
+	req, err := http.NewRequest(http.MethodPost, "https://"+device.Host+sessionServiceURI, bytes.NewBuffer(body))
+	if err != nil {
+		log.Error("unable to create the session request for " + device.Host + ": " + err.Error())
+		return ""
+	}
+	req.Close = true
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("OData-Version", "4.0")
+	lutilconf.TLSConfMutex.RLock()
+	resp, err := client.httpClient.Do(req)
+	lutilconf.TLSConfMutex.RUnlock()
+	if err != nil {
+		log.Warn("unable to create a session with " + device.Host + ", falling back to basic authentication: " + err.Error())
+		return ""
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		// the credentials are invalid, the request will be rejected by the device as well
+		return ""
+	}
+	// the BMC accepts the password, the session of the earlier one is of no use
+	if session.token != "" {
+		go client.deleteSession(session.host, session.token, session.location)
+	}
+	session.password = device.Password
+	session.token, session.location = "", ""
+	session.basicAuthUntil = time.Time{}
+	token := resp.Header.Get("X-Auth-Token")
+	if resp.StatusCode >= 300 || token == "" {
+		log.Warn(fmt.Sprintf("%s does not support sessions, got status code %d, using basic authentication", device.Host, resp.StatusCode))
+		session.basicAuthUntil = time.Now().Add(sessionIdleTimeout())
+		return ""
+	}
+	session.token = token
+	session.location = resp.Header.Get("Location")
+	return token
+}
+
+// invalidate forgets the token of the session, if it is not renewed already
+func (session *deviceSession) invalidate(token string) {
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	if session.token == token {
+		session.token, session.location = "", ""
+	}
+}
+
+// deleteSession deletes the session on the BMC
+func (client *RedfishClient) deleteSession(host, token, location string) {
+	if token == "" || location == "" {
+		return
+	}
+	endpoint := location
+	if !strings.HasPrefix(location, "https://") {
+		endpoint = "https://" + host + location
+	}
+	req, err := http.NewRequest(http.MethodDelete, endpoint, nil)
+	if err != nil {
+		log.Error("unable to delete the session " + location + ": " + err.Error())
+		return
+	}
+	req.Close = true
+	req.Header.Set("X-Auth-Token", token)
+	lutilconf.TLSConfMutex.RLock()
+	resp, err := client.httpClient.Do(req)
+	lutilconf.TLSConfMutex.RUnlock()
+	if err != nil {
+		log.Error("unable to delete the session " + location + ": " + err.Error())
+		return
+	}
+	resp.Body.Close()
+}
+
+// sendWithSession sends the request to the device with the token of its pooled session. When the device
+// rejects the token, the session is renewed and the request is sent once again. The number of requests
+// sent to a device at a time is limited by MaxConcurrentRequestsPerDevice.
+func (client *RedfishClient) sendWithSession(device *RedfishDevice, method, endpoint string, body []byte) (*http.Response, error) {
+	session := devicePool.get(device.Host, device.Username)
+	session.inFlight <- struct{}{}
+	defer func() {
+		<-session.inFlight
+	}()
+
+	token := client.sessionToken(session, device)
+	resp, err := client.send(device, method, endpoint, body, token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || token == "" {
+		return resp, err
+	}
+	// the session has expired or is deleted on the device, renew it
+	resp.Body.Close()
+	session.invalidate(token)
+	token = client.sessionToken(session, device)
+	return client.send(device, method, endpoint, body, token)
+}
+
+func (client *RedfishClient) send(device *RedfishDevice, method, endpoint string, body []byte, token string) (*http.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, endpoint, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Close = true
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("X-Auth-Token", token)
+	} else {
+		auth := device.Username + ":" + string(device.Password)
+		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(auth)))
+	}
+	lutilconf.TLSConfMutex.RLock()
+	resp, err := client.httpClient.Do(req)
+	lutilconf.TLSConfMutex.RUnlock()
+	return resp, err
+}
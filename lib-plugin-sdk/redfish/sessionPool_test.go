@@ -0,0 +1,233 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package redfish
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+)
+
+// mockBMC is a BMC which issues a new token for every session created on it
+type mockBMC struct {
+	lock            sync.Mutex
+	sessionsCreated int
+	validToken      string
+	inFlight        int32
+	maxInFlight     int32
+	noSessions      bool
+	// password is the password accepted for creating a session, any password is accepted when it is empty
+	password string
+}
+
+func (bmc *mockBMC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == sessionServiceURI && r.Method == http.MethodPost {
+		if bmc.noSessions {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var credentials map[string]string
+		json.NewDecoder(r.Body).Decode(&credentials)
+		if bmc.password != "" && credentials["Password"] != bmc.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		bmc.lock.Lock()
+		bmc.sessionsCreated++
+		bmc.validToken = "token" + string(rune('0'+bmc.sessionsCreated))
+		w.Header().Set("X-Auth-Token", bmc.validToken)
+		bmc.lock.Unlock()
+		w.Header().Set("Location", sessionServiceURI+"/1")
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+	inFlight := atomic.AddInt32(&bmc.inFlight, 1)
+	defer atomic.AddInt32(&bmc.inFlight, -1)
+	for {
+		max := atomic.LoadInt32(&bmc.maxInFlight)
+		if inFlight <= max || atomic.CompareAndSwapInt32(&bmc.maxInFlight, max, inFlight) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	bmc.lock.Lock()
+	defer bmc.lock.Unlock()
+	if bmc.noSessions {
+		if _, _, ok := r.BasicAuth(); !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+		return
+	}
+	if r.Header.Get("X-Auth-Token") != bmc.validToken {
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+}
+
+func newMockBMC(t *testing.T, bmc *mockBMC) (*RedfishClient, *RedfishDevice) {
+	server := httptest.NewTLSServer(bmc)
+	t.Cleanup(server.Close)
+	device := &RedfishDevice{
+		Host:     strings.TrimPrefix(server.URL, "https://"),
+		Username: "admin",
+		Password: "password",
+	}
+	t.Cleanup(func() {
+		devicePool.remove(func(key sessionKey) bool {
+			return key.host == device.Host
+		})
+		devicePool.lock.Lock()
+		delete(devicePool.inFlight, device.Host)
+		devicePool.lock.Unlock()
+	})
+	return &RedfishClient{httpClient: server.Client()}, device
+}
+
+func TestSendWithSessionReusesAndRenewsSession(t *testing.T) {
+	config.SetUpMockConfig(t)
+	bmc := &mockBMC{}
+	client, device := newMockBMC(t, bmc)
+	endpoint := "https://" + device.Host + "/redfish/v1/Systems"
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.sendWithSession(device, http.MethodGet, endpoint, nil)
+		if err != nil {
+			t.Fatalf("sendWithSession() error = %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("sendWithSession() status code = %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+	}
+	if bmc.sessionsCreated != 1 {
+		t.Errorf("sessions created = %v, want 1", bmc.sessionsCreated)
+	}
+
+	// the session expires on the BMC, the next request must renew it
+	bmc.lock.Lock()
+	bmc.validToken = "expired"
+	bmc.lock.Unlock()
+	resp, err := client.sendWithSession(device, http.MethodGet, endpoint, nil)
+	if err != nil {
+		t.Fatalf("sendWithSession() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("sendWithSession() status code = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	if bmc.sessionsCreated != 2 {
+		t.Errorf("sessions created = %v, want 2", bmc.sessionsCreated)
+	}
+}
+
+func TestSendWithSessionFallsBackToBasicAuth(t *testing.T) {
+	config.SetUpMockConfig(t)
+	client, device := newMockBMC(t, &mockBMC{noSessions: true})
+	resp, err := client.sendWithSession(device, http.MethodGet, "https://"+device.Host+"/redfish/v1/Systems", nil)
+	if err != nil {
+		t.Fatalf("sendWithSession() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("sendWithSession() status code = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestSendWithSessionLimitsConcurrentRequests(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.DeviceSessionConf.MaxConcurrentRequestsPerDevice = 2
+	bmc := &mockBMC{}
+	client, device := newMockBMC(t, bmc)
+	endpoint := "https://" + device.Host + "/redfish/v1/Systems"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.sendWithSession(device, http.MethodGet, endpoint, nil)
+			if err != nil {
+				t.Errorf("sendWithSession() error = %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	if max := atomic.LoadInt32(&bmc.maxInFlight); max > 2 {
+		t.Errorf("requests in flight = %v, want at most 2", max)
+	}
+}
+
+func TestSendWithSessionKeepsSessionOnInvalidPassword(t *testing.T) {
+	config.SetUpMockConfig(t)
+	bmc := &mockBMC{password: "password"}
+	client, device := newMockBMC(t, bmc)
+	endpoint := "https://" + device.Host + "/redfish/v1/Systems"
+
+	send := func(device *RedfishDevice, want int) {
+		resp, err := client.sendWithSession(device, http.MethodGet, endpoint, nil)
+		if err != nil {
+			t.Fatalf("sendWithSession() error = %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("sendWithSession() status code = %v, want %v", resp.StatusCode, want)
+		}
+	}
+	send(device, http.StatusOK)
+	// a validation of invalid credentials of the same user must not end the session in use
+	invalid := *device
+	invalid.Password = "invalid"
+	send(&invalid, http.StatusUnauthorized)
+	send(device, http.StatusOK)
+	if bmc.sessionsCreated != 1 {
+		t.Errorf("sessions created = %v, want 1", bmc.sessionsCreated)
+	}
+}
+
+func TestSessionPoolKeyedByUser(t *testing.T) {
+	config.SetUpMockConfig(t)
+	_, device := newMockBMC(t, &mockBMC{})
+	admin := devicePool.get(device.Host, "admin")
+	operator := devicePool.get(device.Host, "operator")
+	if admin == operator {
+		t.Errorf("get() returned the same session for two users")
+	}
+	if admin.inFlight != operator.inFlight {
+		t.Errorf("get() returned sessions with separate request limits for the same device")
+	}
+	if devicePool.get(device.Host, "admin") != admin {
+		t.Errorf("get() did not return the pooled session of the user")
+	}
+
+	EvictDeviceSession(device.Host)
+	devicePool.lock.Lock()
+	defer devicePool.lock.Unlock()
+	for key := range devicePool.sessions {
+		if key.host == device.Host {
+			t.Errorf("session of %v is not removed from the pool", key)
+		}
+	}
+	if _, ok := devicePool.inFlight[device.Host]; ok {
+		t.Errorf("request limit of %v is not removed from the pool", device.Host)
+	}
+}
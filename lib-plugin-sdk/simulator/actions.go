@@ -0,0 +1,234 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package simulator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// action runs the action of the resource, the actions without a simulated behaviour are accepted without any change
+func (s *Simulator) action(w http.ResponseWriter, r *http.Request, target string) {
+	uri := target[:strings.Index(target, "/Actions/")]
+	actionName := path.Base(target)
+	s.lock.RLock()
+	resource, ok := s.resources[uri]
+	found := ok && hasAction(resource, target)
+	s.lock.RUnlock()
+	if !found {
+		writeError(w, http.StatusNotFound, "Base.1.8.ResourceMissingAtURI", "The resource at the URI "+target+" was not found.")
+		return
+	}
+
+	var request map[string]interface{}
+	body, _ := ioutil.ReadAll(r.Body)
+	if len(body) == 0 {
+		body = []byte("{}")
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		writeError(w, http.StatusBadRequest, "Base.1.8.MalformedJSON", "The request body submitted was malformed JSON: "+err.Error())
+		return
+	}
+
+	var statusCode int
+	var message string
+	switch actionName {
+	case "ComputerSystem.Reset":
+		statusCode, message = s.resetSystem(uri, request)
+	case "Manager.Reset":
+		statusCode, message = s.resetManager(uri, request)
+	case "Bios.ResetBios":
+		statusCode, message = s.resetBios(uri)
+	case "UpdateService.SimpleUpdate":
+		statusCode, message = s.simpleUpdate(request)
+	case "EventService.SubmitTestEvent":
+		statusCode, message = s.submitTestEvent(request)
+	default:
+		statusCode = http.StatusNoContent
+	}
+	if statusCode >= 300 {
+		writeError(w, statusCode, "Base.1.8.ActionParameterValueNotInList", message)
+		return
+	}
+	w.WriteHeader(statusCode)
+}
+
+// hasAction checks if the target is the target of an action of the resource
+func hasAction(resource map[string]interface{}, target string) bool {
+	actions, _ := resource["Actions"].(map[string]interface{})
+	for _, action := range actions {
+		if action, ok := action.(map[string]interface{}); ok && action["target"] == target {
+			return true
+		}
+	}
+	return false
+}
+
+// resetSystem changes the power state of the system and its chassis, the pending BIOS settings
+// are applied when the system boots
+func (s *Simulator) resetSystem(uri string, request map[string]interface{}) (int, string) {
+	resetType, _ := request["ResetType"].(string)
+	s.lock.Lock()
+	system := s.resources[uri]
+	powerState, _ := system["PowerState"].(string)
+	boots := false
+	switch resetType {
+	case "On", "ForceOn":
+		boots = powerState != "On"
+		powerState = "On"
+	case "ForceOff", "GracefulShutdown":
+		powerState = "Off"
+	case "ForceRestart", "GracefulRestart", "PowerCycle":
+		boots = true
+		powerState = "On"
+	case "PushPowerButton":
+		boots = powerState != "On"
+		powerState = "Off"
+		if boots {
+			powerState = "On"
+		}
+	case "Nmi":
+	default:
+		s.lock.Unlock()
+		return http.StatusBadRequest, "The value " + resetType + " for the parameter ResetType is not in the list of acceptable values."
+	}
+	system["PowerState"] = powerState
+	if links, ok := system["Links"].(map[string]interface{}); ok {
+		chassisLinks, _ := links["Chassis"].([]interface{})
+		for _, link := range chassisLinks {
+			chassisURI, _ := link.(map[string]interface{})["@odata.id"].(string)
+			if chassis, ok := s.resources[chassisURI]; ok {
+				chassis["PowerState"] = powerState
+			}
+		}
+	}
+	bios, _ := system["Bios"].(map[string]interface{})
+	biosURI, _ := bios["@odata.id"].(string)
+	biosChanged := boots && s.applyBiosSettings(biosURI)
+	s.lock.Unlock()
+
+	s.PostEvent("StatusChange", "ResourceEvent.1.0.ResourceChanged", "The system is reset with "+resetType+", the power state is "+powerState+".", uri)
+	if biosChanged {
+		s.PostEvent("ResourceUpdated", "ResourceEvent.1.0.ResourceChanged", "The pending BIOS settings are applied.", biosURI)
+	}
+	return http.StatusNoContent, ""
+}
+
+// applyBiosSettings moves the pending attributes of the settings object to the BIOS, the lock has to be held
+func (s *Simulator) applyBiosSettings(biosURI string) bool {
+	bios, ok := s.resources[biosURI]
+	settings, hasSettings := s.resources[biosURI+"/Settings"]
+	if !ok || !hasSettings {
+		return false
+	}
+	pending, _ := settings["Attributes"].(map[string]interface{})
+	if len(pending) == 0 {
+		return false
+	}
+	attributes, ok := bios["Attributes"].(map[string]interface{})
+	if !ok {
+		attributes = make(map[string]interface{})
+		bios["Attributes"] = attributes
+	}
+	for name, value := range pending {
+		attributes[name] = value
+	}
+	settings["Attributes"] = map[string]interface{}{}
+	return true
+}
+
+// resetBios sets the default attributes as the pending settings of the BIOS, they are applied on the next reset
+func (s *Simulator) resetBios(uri string) (int, string) {
+	s.lock.Lock()
+	defaults, ok := s.biosDefaults[uri]
+	settings, hasSettings := s.resources[uri+"/Settings"]
+	if ok && hasSettings {
+		settings["Attributes"] = copyValue(defaults)
+	}
+	s.lock.Unlock()
+	return http.StatusNoContent, ""
+}
+
+func (s *Simulator) resetManager(uri string, request map[string]interface{}) (int, string) {
+	resetType, _ := request["ResetType"].(string)
+	switch resetType {
+	case "", "ForceRestart", "GracefulRestart":
+	default:
+		return http.StatusBadRequest, "The value " + resetType + " for the parameter ResetType is not in the list of acceptable values."
+	}
+	s.PostEvent("StatusChange", "ResourceEvent.1.0.ResourceChanged", "The manager is restarted.", uri)
+	return http.StatusNoContent, ""
+}
+
+// simpleUpdate updates the firmware of the targets, all the updateable firmware when there are no targets.
+// The image is not downloaded, the version of the firmware is set to the name of the image file without its extension.
+func (s *Simulator) simpleUpdate(request map[string]interface{}) (int, string) {
+	imageURI, _ := request["ImageURI"].(string)
+	if imageURI == "" {
+		return http.StatusBadRequest, "The property ImageURI is a required property and must be included in the request."
+	}
+	version := path.Base(imageURI)
+	if extension := path.Ext(version); extension != "" {
+		version = strings.TrimSuffix(version, extension)
+	}
+	targets := map[string]bool{}
+	requestTargets, _ := request["Targets"].([]interface{})
+	for _, target := range requestTargets {
+		if target, ok := target.(string); ok {
+			targets[target] = true
+		}
+	}
+
+	var updated []string
+	s.lock.Lock()
+	for uri, resource := range s.resources {
+		if !strings.HasPrefix(uri, "/redfish/v1/UpdateService/FirmwareInventory/") || resource["Updateable"] != true {
+			continue
+		}
+		if len(targets) > 0 && !targets[uri] {
+			continue
+		}
+		resource["Version"] = version
+		updated = append(updated, uri)
+	}
+	s.lock.Unlock()
+
+	for _, uri := range updated {
+		s.PostEvent("ResourceUpdated", "ResourceEvent.1.0.ResourceChanged", "The firmware is updated to "+version+".", uri)
+	}
+	return http.StatusNoContent, ""
+}
+
+// submitTestEvent posts the event of the request to the subscribers
+func (s *Simulator) submitTestEvent(request map[string]interface{}) (int, string) {
+	record := map[string]interface{}{
+		"EventType": "Alert",
+		"MessageId": "Base.1.8.Success",
+		"Severity":  "OK",
+	}
+	for _, property := range []string{"EventType", "MessageId", "Message", "MessageArgs", "Severity", "OriginOfCondition"} {
+		if value, ok := request[property]; ok {
+			record[property] = value
+		}
+	}
+	if origin, ok := record["OriginOfCondition"].(string); ok {
+		record["OriginOfCondition"] = map[string]string{"@odata.id": origin}
+	}
+	s.postEvent(record)
+	return http.StatusNoContent, ""
+}
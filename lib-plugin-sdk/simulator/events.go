@@ -0,0 +1,166 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package simulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// createSession creates a session for the credentials of the request and returns its token
+func (s *Simulator) createSession(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		UserName string
+		Password string
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "Base.1.8.MalformedJSON", "The request body submitted was malformed JSON: "+err.Error())
+		return
+	}
+	if request.UserName != s.userName || request.Password != s.password {
+		writeError(w, http.StatusUnauthorized, "Base.1.8.NoValidSession", "The credentials are not valid.")
+		return
+	}
+	token := uuid.NewV4().String()
+	s.lock.Lock()
+	uri := s.addMember(sessionsURI, map[string]interface{}{
+		"@odata.type": "#Session.v1_3_0.Session",
+		"Name":        "User Session",
+		"UserName":    request.UserName,
+	})
+	s.sessions[token] = uri
+	session := copyValue(s.resources[uri])
+	s.lock.Unlock()
+
+	w.Header().Set("X-Auth-Token", token)
+	w.Header().Set("Location", uri)
+	writeJSON(w, http.StatusCreated, session)
+}
+
+// deleteSession deletes the session, its token is no longer accepted
+func (s *Simulator) deleteSession(w http.ResponseWriter, uri string) {
+	s.lock.Lock()
+	for token, sessionURI := range s.sessions {
+		if sessionURI == uri {
+			delete(s.sessions, token)
+		}
+	}
+	s.lock.Unlock()
+	s.deleteMember(w, uri)
+}
+
+// createSubscription stores the event subscription, the events are posted to its destination
+func (s *Simulator) createSubscription(w http.ResponseWriter, r *http.Request) {
+	var subscription map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&subscription); err != nil {
+		writeError(w, http.StatusBadRequest, "Base.1.8.MalformedJSON", "The request body submitted was malformed JSON: "+err.Error())
+		return
+	}
+	if destination, _ := subscription["Destination"].(string); destination == "" {
+		writeError(w, http.StatusBadRequest, "Base.1.8.PropertyMissing", "The property Destination is a required property and must be included in the request.")
+		return
+	}
+	subscription["@odata.type"] = "#EventDestination.v1_7_0.EventDestination"
+	if _, ok := subscription["Name"]; !ok {
+		subscription["Name"] = "Event Subscription"
+	}
+	if _, ok := subscription["Protocol"]; !ok {
+		subscription["Protocol"] = "Redfish"
+	}
+	s.lock.Lock()
+	uri := s.addMember(subscriptionsURI, subscription)
+	response := copyValue(subscription)
+	s.lock.Unlock()
+
+	w.Header().Set("Location", uri)
+	writeJSON(w, http.StatusCreated, response)
+}
+
+// PostEvent posts the event to the subscribers of its event type, the event is originated by the resource at the URI
+func (s *Simulator) PostEvent(eventType, messageID, message, originOfCondition string) {
+	s.postEvent(map[string]interface{}{
+		"EventType":         eventType,
+		"MessageId":         messageID,
+		"Message":           message,
+		"Severity":          "OK",
+		"OriginOfCondition": map[string]string{"@odata.id": originOfCondition},
+	})
+}
+
+func (s *Simulator) postEvent(record map[string]interface{}) {
+	s.lock.Lock()
+	s.eventID++
+	record["EventId"] = strconv.Itoa(s.eventID)
+	record["EventTimestamp"] = time.Now().UTC().Format(time.RFC3339)
+	var subscriptions []map[string]interface{}
+	for uri, resource := range s.resources {
+		if path.Dir(uri) == subscriptionsURI && subscribed(resource, record["EventType"]) {
+			subscriptions = append(subscriptions, copyValue(resource).(map[string]interface{}))
+		}
+	}
+	s.lock.Unlock()
+
+	for _, subscription := range subscriptions {
+		event := map[string]interface{}{
+			"@odata.type": "#Event.v1_7_0.Event",
+			"Id":          record["EventId"],
+			"Name":        "Event Array",
+			"Context":     subscription["Context"],
+			"Events":      []interface{}{record},
+		}
+		s.deliveries.Add(1)
+		go s.deliver(subscription["Destination"].(string), event)
+	}
+}
+
+// subscribed checks if the subscription is for the events of the event type, all of them when it has no event types
+func subscribed(subscription map[string]interface{}, eventType interface{}) bool {
+	eventTypes, _ := subscription["EventTypes"].([]interface{})
+	if len(eventTypes) == 0 {
+		return true
+	}
+	for _, subscribedType := range eventTypes {
+		if subscribedType == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Simulator) deliver(destination string, event map[string]interface{}) {
+	defer s.deliveries.Done()
+	data, _ := json.Marshal(event)
+	resp, err := s.client.Post(destination, "application/json", bytes.NewReader(data))
+	if err != nil {
+		log.Error("unable to post the event to " + destination + ": " + err.Error())
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		log.Warn("the event posted to " + destination + " is rejected with status code " + strconv.Itoa(resp.StatusCode))
+	}
+}
+
+// WaitForEvents waits until the events posted so far are delivered to the subscribers
+func (s *Simulator) WaitForEvents() {
+	s.deliveries.Wait()
+}
@@ -0,0 +1,385 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package simulator provides a Redfish BMC simulator, for testing the plugins and ODIM end to end without hardware.
+// The resources of the simulated BMC are loaded from a mockup directory in the layout of the DMTF Redfish mockups,
+// where the resource of every URI is stored in the index.json file of the matching directory.
+package simulator
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	serviceRootURI   = "/redfish/v1"
+	sessionsURI      = "/redfish/v1/SessionService/Sessions"
+	subscriptionsURI = "/redfish/v1/EventService/Subscriptions"
+)
+
+// defaultMockup is the mockup used when no mockup directory is given, it has one system with its chassis,
+// BIOS, storage and manager, the event service, the session service and the update service with the firmware inventory
+//
+//go:embed mockup
+var defaultMockup embed.FS
+
+// Options are the options of a simulated BMC
+type Options struct {
+	// MockupDir is the directory of the mockup, the embedded default mockup is used when it is empty
+	MockupDir string
+	// UserName and Password are the credentials accepted by the BMC, they default to admin and password
+	UserName string
+	Password string
+	// UUID is the UUID of the service root, a random one is used when it is empty so that
+	// every simulated BMC is a different device for ODIM
+	UUID string
+	// Client is the HTTP client used for posting the events to the subscribers, it defaults to http.DefaultClient
+	Client *http.Client
+}
+
+// Simulator is a simulated Redfish BMC, it is an http.Handler to be served over TLS
+type Simulator struct {
+	lock         sync.RWMutex
+	resources    map[string]map[string]interface{}
+	biosDefaults map[string]interface{}
+	sessions     map[string]string
+	lastID       int
+	eventID      int
+	userName     string
+	password     string
+	client       *http.Client
+	deliveries   sync.WaitGroup
+}
+
+// New loads the mockup and returns the simulated BMC
+func New(opts Options) (*Simulator, error) {
+	var mockup fs.FS
+	var err error
+	if opts.MockupDir == "" {
+		if mockup, err = fs.Sub(defaultMockup, "mockup"); err != nil {
+			return nil, err
+		}
+	} else {
+		mockup = os.DirFS(opts.MockupDir)
+	}
+	s := &Simulator{
+		resources:    make(map[string]map[string]interface{}),
+		biosDefaults: make(map[string]interface{}),
+		sessions:     make(map[string]string),
+		userName:     opts.UserName,
+		password:     opts.Password,
+		client:       opts.Client,
+	}
+	if s.userName == "" {
+		s.userName, s.password = "admin", "password"
+	}
+	if s.client == nil {
+		s.client = http.DefaultClient
+	}
+	if err := s.load(mockup); err != nil {
+		return nil, err
+	}
+	root, ok := s.resources[serviceRootURI]
+	if !ok {
+		return nil, fmt.Errorf("the mockup has no service root %s", serviceRootURI)
+	}
+	root["UUID"] = opts.UUID
+	if opts.UUID == "" {
+		root["UUID"] = uuid.NewV4().String()
+	}
+	for uri, resource := range s.resources {
+		if isBios(resource) && !strings.HasSuffix(uri, "/Settings") {
+			if attributes, ok := resource["Attributes"].(map[string]interface{}); ok {
+				s.biosDefaults[uri] = copyValue(attributes)
+			}
+		}
+	}
+	return s, nil
+}
+
+// load reads the index.json files of the mockup, the URI of a resource is the path of its directory
+func (s *Simulator) load(mockup fs.FS) error {
+	return fs.WalkDir(mockup, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || entry.Name() != "index.json" {
+			return nil
+		}
+		data, err := fs.ReadFile(mockup, filePath)
+		if err != nil {
+			return err
+		}
+		var resource map[string]interface{}
+		if err := json.Unmarshal(data, &resource); err != nil {
+			return fmt.Errorf("invalid resource %s in the mockup: %v", filePath, err)
+		}
+		uri := "/" + path.Dir(filePath)
+		s.resources[uri] = resource
+		if id := idOf(uri); id > s.lastID {
+			s.lastID = id
+		}
+		return nil
+	})
+}
+
+// ServeHTTP serves the Redfish requests on the simulated BMC
+func (s *Simulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	uri := strings.TrimSuffix(r.URL.Path, "/")
+	if uri == "/redfish" || uri == serviceRootURI || (uri == sessionsURI && r.Method == http.MethodPost) {
+		s.serve(w, r, uri)
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Base.1.8.NoValidSession", "There is no valid session established with the implementation.")
+		return
+	}
+	s.serve(w, r, uri)
+}
+
+func (s *Simulator) serve(w http.ResponseWriter, r *http.Request, uri string) {
+	switch {
+	case uri == "/redfish" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]string{"v1": serviceRootURI + "/"})
+	case strings.Contains(uri, "/Actions/") && r.Method == http.MethodPost:
+		s.action(w, r, uri)
+	case r.Method == http.MethodGet:
+		s.get(w, uri)
+	case r.Method == http.MethodPatch:
+		s.patch(w, r, uri)
+	case r.Method == http.MethodPost && uri == sessionsURI:
+		s.createSession(w, r)
+	case r.Method == http.MethodPost && uri == subscriptionsURI:
+		s.createSubscription(w, r)
+	case r.Method == http.MethodDelete && path.Dir(uri) == sessionsURI:
+		s.deleteSession(w, uri)
+	case r.Method == http.MethodDelete && path.Dir(uri) == subscriptionsURI:
+		s.deleteMember(w, uri)
+	case r.Method == http.MethodPost && isVolumes(uri):
+		s.createVolume(w, r, uri)
+	case r.Method == http.MethodDelete && isVolumes(path.Dir(uri)):
+		s.deleteVolume(w, uri)
+	default:
+		s.lock.RLock()
+		_, found := s.resources[uri]
+		s.lock.RUnlock()
+		if !found {
+			writeError(w, http.StatusNotFound, "Base.1.8.ResourceMissingAtURI", "The resource at the URI "+uri+" was not found.")
+			return
+		}
+		w.Header().Set("Allow", "GET, PATCH")
+		writeError(w, http.StatusMethodNotAllowed, "Base.1.8.OperationNotAllowed", "The operation is not allowed on "+uri+".")
+	}
+}
+
+// authorized checks the session token or the basic authentication credentials of the request
+func (s *Simulator) authorized(r *http.Request) bool {
+	if token := r.Header.Get("X-Auth-Token"); token != "" {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+		_, ok := s.sessions[token]
+		return ok
+	}
+	userName, password, ok := r.BasicAuth()
+	return ok && userName == s.userName && password == s.password
+}
+
+func (s *Simulator) get(w http.ResponseWriter, uri string) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	resource, ok := s.resources[uri]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Base.1.8.ResourceMissingAtURI", "The resource at the URI "+uri+" was not found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, resource)
+}
+
+// patch merges the request into the resource, as described by the JSON merge patch. The BIOS attributes
+// can be changed only through the settings object, they are applied on the next reset of the system.
+func (s *Simulator) patch(w http.ResponseWriter, r *http.Request, uri string) {
+	var request map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "Base.1.8.MalformedJSON", "The request body submitted was malformed JSON: "+err.Error())
+		return
+	}
+	s.lock.Lock()
+	resource, ok := s.resources[uri]
+	if !ok {
+		s.lock.Unlock()
+		writeError(w, http.StatusNotFound, "Base.1.8.ResourceMissingAtURI", "The resource at the URI "+uri+" was not found.")
+		return
+	}
+	if _, ok := resource["Members"]; ok || (isBios(resource) && !strings.HasSuffix(uri, "/Settings")) {
+		s.lock.Unlock()
+		writeError(w, http.StatusMethodNotAllowed, "Base.1.8.OperationNotAllowed", "The operation is not allowed on "+uri+".")
+		return
+	}
+	for _, property := range []string{"@odata.id", "@odata.type", "@odata.context", "Id"} {
+		delete(request, property)
+	}
+	mergePatch(resource, request)
+	response := copyValue(resource)
+	s.lock.Unlock()
+
+	if !strings.HasSuffix(uri, "/Settings") {
+		s.PostEvent("ResourceUpdated", "ResourceEvent.1.0.ResourceChanged", "The resource "+uri+" is changed.", uri)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Resource returns a copy of the resource at the URI, for checking the state of the BMC in the tests
+func (s *Simulator) Resource(uri string) (map[string]interface{}, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	resource, ok := s.resources[uri]
+	if !ok {
+		return nil, false
+	}
+	return copyValue(resource).(map[string]interface{}), true
+}
+
+// addMember stores the resource and adds it to the members of the collection, the lock has to be held
+func (s *Simulator) addMember(collectionURI string, resource map[string]interface{}) string {
+	s.lastID++
+	id := strconv.Itoa(s.lastID)
+	uri := collectionURI + "/" + id
+	resource["@odata.id"] = uri
+	resource["Id"] = id
+	s.resources[uri] = resource
+	s.updateMembers(collectionURI)
+	return uri
+}
+
+// deleteMember deletes the resource and removes it from the members of its collection,
+// false is returned when there is no resource at the URI
+func (s *Simulator) deleteMember(w http.ResponseWriter, uri string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.resources[uri]; !ok {
+		writeError(w, http.StatusNotFound, "Base.1.8.ResourceMissingAtURI", "The resource at the URI "+uri+" was not found.")
+		return false
+	}
+	delete(s.resources, uri)
+	s.updateMembers(path.Dir(uri))
+	w.WriteHeader(http.StatusNoContent)
+	return true
+}
+
+// updateMembers sets the members of the collection to its member resources, the lock has to be held
+func (s *Simulator) updateMembers(collectionURI string) {
+	collection, ok := s.resources[collectionURI]
+	if !ok {
+		return
+	}
+	var uris []string
+	for uri := range s.resources {
+		if path.Dir(uri) == collectionURI {
+			uris = append(uris, uri)
+		}
+	}
+	sort.Slice(uris, func(i, j int) bool {
+		return idOf(uris[i]) < idOf(uris[j]) || (idOf(uris[i]) == idOf(uris[j]) && uris[i] < uris[j])
+	})
+	members := []interface{}{}
+	for _, uri := range uris {
+		members = append(members, map[string]interface{}{"@odata.id": uri})
+	}
+	collection["Members"] = members
+	collection["Members@odata.count"] = len(members)
+}
+
+// idOf returns the numeric ID at the end of the URI, or 0 when it is not numeric
+func idOf(uri string) int {
+	id, _ := strconv.Atoi(path.Base(uri))
+	return id
+}
+
+func isBios(resource map[string]interface{}) bool {
+	odataType, _ := resource["@odata.type"].(string)
+	return strings.HasPrefix(odataType, "#Bios.")
+}
+
+// mergePatch applies the patch to the resource, a null value removes the property
+func mergePatch(resource, patch map[string]interface{}) {
+	for key, value := range patch {
+		if value == nil {
+			delete(resource, key)
+			continue
+		}
+		patchObject, isObject := value.(map[string]interface{})
+		resourceObject, hasObject := resource[key].(map[string]interface{})
+		if isObject && hasObject {
+			mergePatch(resourceObject, patchObject)
+			continue
+		}
+		resource[key] = copyValue(value)
+	}
+}
+
+// copyValue returns a deep copy of the decoded JSON value
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = copyValue(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyValue(item)
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("OData-Version", "4.0")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes the Redfish error response with the message of the Base registry
+func writeError(w http.ResponseWriter, statusCode int, messageID, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    messageID,
+			"message": message,
+			"@Message.ExtendedInfo": []map[string]interface{}{
+				{
+					"@odata.type": "#Message.v1_1_1.Message",
+					"MessageId":   messageID,
+					"Message":     message,
+					"Severity":    "Critical",
+				},
+			},
+		},
+	})
+}
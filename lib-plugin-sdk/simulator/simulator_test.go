@@ -0,0 +1,362 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package simulator
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/redfish"
+)
+
+func newSimulator(t *testing.T) *Simulator {
+	s, err := New(Options{UUID: "sim-uuid"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return s
+}
+
+// do sends the request to the simulator with the basic authentication credentials, when they are not empty
+func do(s *Simulator, method, uri, body, userName string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, uri, strings.NewReader(body))
+	if userName != "" {
+		req.SetBasicAuth(userName, "password")
+	}
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	return w
+}
+
+func property(t *testing.T, s *Simulator, uri, name string) interface{} {
+	resource, ok := s.Resource(uri)
+	if !ok {
+		t.Fatalf("Resource(%v) is not found", uri)
+	}
+	return resource[name]
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(Options{MockupDir: t.TempDir()}); err == nil {
+		t.Error("New() error = nil, want error for a mockup without service root")
+	}
+	s := newSimulator(t)
+	if uuid := property(t, s, serviceRootURI, "UUID"); uuid != "sim-uuid" {
+		t.Errorf("New() service root UUID = %v, want sim-uuid", uuid)
+	}
+	other, _ := New(Options{})
+	if property(t, other, serviceRootURI, "UUID") == "" {
+		t.Error("New() service root UUID is empty, want a random UUID")
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	s := newSimulator(t)
+	if w := do(s, http.MethodGet, "/redfish/v1/", "", ""); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "sim-uuid") {
+		t.Errorf("GET service root = %v %v, want it without authentication", w.Code, w.Body.String())
+	}
+	if w := do(s, http.MethodGet, "/redfish/v1/Systems", "", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("GET without authentication status code = %v, want %v", w.Code, http.StatusUnauthorized)
+	}
+	if w := do(s, http.MethodGet, "/redfish/v1/Systems", "", "root"); w.Code != http.StatusUnauthorized {
+		t.Errorf("GET with invalid credentials status code = %v, want %v", w.Code, http.StatusUnauthorized)
+	}
+	if w := do(s, http.MethodGet, "/redfish/v1/Systems?$top=1", "", "admin"); w.Code != http.StatusOK {
+		t.Errorf("GET with basic authentication status code = %v, want %v", w.Code, http.StatusOK)
+	}
+
+	w := do(s, http.MethodPost, sessionsURI, `{"UserName":"admin","Password":"invalid"}`, "")
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("create session with invalid credentials status code = %v, want %v", w.Code, http.StatusUnauthorized)
+	}
+	w = do(s, http.MethodPost, sessionsURI, `{"UserName":"admin","Password":"password"}`, "")
+	token, location := w.Header().Get("X-Auth-Token"), w.Header().Get("Location")
+	if w.Code != http.StatusCreated || token == "" || location == "" {
+		t.Fatalf("create session = %v %v, want a token and the location of the session", w.Code, w.Header())
+	}
+	withToken := func(method, uri string) int {
+		req := httptest.NewRequest(method, uri, nil)
+		req.Header.Set("X-Auth-Token", token)
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, req)
+		return w.Code
+	}
+	if code := withToken(http.MethodGet, "/redfish/v1/Systems/1"); code != http.StatusOK {
+		t.Errorf("GET with the session token status code = %v, want %v", code, http.StatusOK)
+	}
+	if code := withToken(http.MethodDelete, location); code != http.StatusNoContent {
+		t.Errorf("delete session status code = %v, want %v", code, http.StatusNoContent)
+	}
+	if code := withToken(http.MethodGet, "/redfish/v1/Systems/1"); code != http.StatusUnauthorized {
+		t.Errorf("GET with a deleted session status code = %v, want %v", code, http.StatusUnauthorized)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	s := newSimulator(t)
+	w := do(s, http.MethodPatch, "/redfish/v1/Systems/1", `{"Boot":{"BootSourceOverrideTarget":"Pxe"},"AssetTag":null,"Id":"2"}`, "admin")
+	if w.Code != http.StatusOK {
+		t.Fatalf("PATCH status code = %v, want %v", w.Code, http.StatusOK)
+	}
+	boot := property(t, s, "/redfish/v1/Systems/1", "Boot").(map[string]interface{})
+	if boot["BootSourceOverrideTarget"] != "Pxe" || boot["BootSourceOverrideMode"] != "UEFI" {
+		t.Errorf("PATCH Boot = %v, want the patch merged into the resource", boot)
+	}
+	if property(t, s, "/redfish/v1/Systems/1", "AssetTag") != nil {
+		t.Error("PATCH AssetTag is kept, want it removed by null")
+	}
+	if id := property(t, s, "/redfish/v1/Systems/1", "Id"); id != "1" {
+		t.Errorf("PATCH Id = %v, want it to be read only", id)
+	}
+
+	tests := []struct {
+		uri  string
+		body string
+		want int
+	}{
+		{"/redfish/v1/Systems/2", `{}`, http.StatusNotFound},
+		{"/redfish/v1/Systems", `{}`, http.StatusMethodNotAllowed},
+		{"/redfish/v1/Systems/1/Bios", `{"Attributes":{"BootMode":"LegacyBios"}}`, http.StatusMethodNotAllowed},
+		{"/redfish/v1/Systems/1", `Boot`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		if w := do(s, http.MethodPatch, tt.uri, tt.body, "admin"); w.Code != tt.want {
+			t.Errorf("PATCH %v status code = %v, want %v", tt.uri, w.Code, tt.want)
+		}
+	}
+}
+
+func TestVolumes(t *testing.T) {
+	s := newSimulator(t)
+	volumesURI := "/redfish/v1/Systems/1/Storage/1/Volumes"
+	tests := []struct {
+		body string
+		want int
+	}{
+		{`RAID1`, http.StatusBadRequest},
+		{`{"Links":{"Drives":[{"@odata.id":"/redfish/v1/Systems/1/Storage/1/Drives/1"}]}}`, http.StatusBadRequest},
+		{`{"RAIDType":"RAID1","Links":{"Drives":[{"@odata.id":"/redfish/v1/Systems/1/Storage/2/Drives/1"}]}}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		if w := do(s, http.MethodPost, volumesURI, tt.body, "admin"); w.Code != tt.want {
+			t.Errorf("POST %v status code = %v, want %v", tt.body, w.Code, tt.want)
+		}
+	}
+
+	w := do(s, http.MethodPost, volumesURI, `{"RAIDType":"RAID1","Links":{"Drives":[{"@odata.id":"/redfish/v1/Systems/1/Storage/1/Drives/1"},{"@odata.id":"/redfish/v1/Systems/1/Storage/1/Drives/2"}]}}`, "admin")
+	location := w.Header().Get("Location")
+	if w.Code != http.StatusCreated || location == "" {
+		t.Fatalf("create volume = %v %v, want the location of the volume", w.Code, w.Body.String())
+	}
+	if raidType := property(t, s, location, "RAIDType"); raidType != "RAID1" {
+		t.Errorf("volume RAIDType = %v, want RAID1", raidType)
+	}
+	if capacity := property(t, s, location, "CapacityBytes"); capacity != 2*480103981056.0 {
+		t.Errorf("volume CapacityBytes = %v, want the capacity of the drives", capacity)
+	}
+	if count := property(t, s, volumesURI, "Members@odata.count"); count != 1 {
+		t.Errorf("volumes count = %v, want 1", count)
+	}
+
+	if w := do(s, http.MethodDelete, location, "", "admin"); w.Code != http.StatusNoContent {
+		t.Errorf("delete volume status code = %v, want %v", w.Code, http.StatusNoContent)
+	}
+	if w := do(s, http.MethodDelete, location, "", "admin"); w.Code != http.StatusNotFound {
+		t.Errorf("delete deleted volume status code = %v, want %v", w.Code, http.StatusNotFound)
+	}
+	if count := property(t, s, volumesURI, "Members@odata.count"); count != 0 {
+		t.Errorf("volumes count = %v, want 0", count)
+	}
+}
+
+func TestResetAndBiosSettings(t *testing.T) {
+	s := newSimulator(t)
+	const bios = "/redfish/v1/Systems/1/Bios"
+	if w := do(s, http.MethodPatch, bios+"/Settings", `{"Attributes":{"BootMode":"LegacyBios"}}`, "admin"); w.Code != http.StatusOK {
+		t.Fatalf("PATCH BIOS settings status code = %v, want %v", w.Code, http.StatusOK)
+	}
+	attribute := func() interface{} {
+		return property(t, s, bios, "Attributes").(map[string]interface{})["BootMode"]
+	}
+	reset := func(resetType string) int {
+		return do(s, http.MethodPost, "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", `{"ResetType":"`+resetType+`"}`, "admin").Code
+	}
+
+	if code := reset("ForceOff"); code != http.StatusNoContent {
+		t.Fatalf("reset status code = %v, want %v", code, http.StatusNoContent)
+	}
+	if state := property(t, s, "/redfish/v1/Systems/1", "PowerState"); state != "Off" {
+		t.Errorf("system power state = %v, want Off", state)
+	}
+	if state := property(t, s, "/redfish/v1/Chassis/1", "PowerState"); state != "Off" {
+		t.Errorf("chassis power state = %v, want Off", state)
+	}
+	if value := attribute(); value != "Uefi" {
+		t.Errorf("BIOS attribute = %v, want the pending setting not to be applied on power off", value)
+	}
+	reset("On")
+	if value := attribute(); value != "LegacyBios" {
+		t.Errorf("BIOS attribute = %v, want the pending setting to be applied on boot", value)
+	}
+	if pending := property(t, s, bios+"/Settings", "Attributes").(map[string]interface{}); len(pending) != 0 {
+		t.Errorf("pending BIOS settings = %v, want them to be cleared", pending)
+	}
+
+	if w := do(s, http.MethodPost, bios+"/Actions/Bios.ResetBios", "", "admin"); w.Code != http.StatusNoContent {
+		t.Fatalf("ResetBios status code = %v, want %v", w.Code, http.StatusNoContent)
+	}
+	reset("ForceRestart")
+	if value := attribute(); value != "Uefi" {
+		t.Errorf("BIOS attribute = %v, want the default to be restored after ResetBios", value)
+	}
+
+	if code := reset("Suspend"); code != http.StatusBadRequest {
+		t.Errorf("reset with invalid type status code = %v, want %v", code, http.StatusBadRequest)
+	}
+	if w := do(s, http.MethodPost, "/redfish/v1/Systems/1/Actions/ComputerSystem.Unknown", "{}", "admin"); w.Code != http.StatusNotFound {
+		t.Errorf("unknown action status code = %v, want %v", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestSimpleUpdate(t *testing.T) {
+	s := newSimulator(t)
+	const bmc = "/redfish/v1/UpdateService/FirmwareInventory/BMC"
+	w := do(s, http.MethodPost, "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate",
+		`{"ImageURI":"https://images/bmc-2.00.bin","Targets":["`+bmc+`"]}`, "admin")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("SimpleUpdate status code = %v, want %v", w.Code, http.StatusNoContent)
+	}
+	if version := property(t, s, bmc, "Version"); version != "bmc-2.00" {
+		t.Errorf("BMC firmware version = %v, want bmc-2.00", version)
+	}
+	if version := property(t, s, "/redfish/v1/UpdateService/FirmwareInventory/BIOS", "Version"); version == "bmc-2.00" {
+		t.Error("BIOS firmware is updated, want only the targets to be updated")
+	}
+	w = do(s, http.MethodPost, "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate", `{}`, "admin")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("SimpleUpdate without image status code = %v, want %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	var lock sync.Mutex
+	var received []map[string]interface{}
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&event)
+		lock.Lock()
+		received = append(received, event)
+		lock.Unlock()
+	}))
+	defer subscriber.Close()
+
+	s := newSimulator(t)
+	w := do(s, http.MethodPost, subscriptionsURI, `{"Context":"ODIM","EventTypes":["ResourceUpdated"]}`, "admin")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("subscription without destination status code = %v, want %v", w.Code, http.StatusBadRequest)
+	}
+	w = do(s, http.MethodPost, subscriptionsURI, `{"Destination":"`+subscriber.URL+`","Context":"ODIM","EventTypes":["ResourceUpdated"]}`, "admin")
+	location := w.Header().Get("Location")
+	if w.Code != http.StatusCreated || location == "" {
+		t.Fatalf("create subscription = %v %v, want the location of the subscription", w.Code, w.Header())
+	}
+	if count := property(t, s, subscriptionsURI, "Members@odata.count"); count != 1 {
+		t.Errorf("subscriptions count = %v, want 1", count)
+	}
+
+	do(s, http.MethodPatch, "/redfish/v1/Systems/1", `{"AssetTag":"rack-1"}`, "admin")
+	do(s, http.MethodPost, "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", `{"ResetType":"ForceOff"}`, "admin")
+	do(s, http.MethodPost, "/redfish/v1/EventService/Actions/EventService.SubmitTestEvent",
+		`{"EventType":"ResourceUpdated","MessageId":"Test.1.0.Test","OriginOfCondition":"/redfish/v1/Managers/1"}`, "admin")
+	s.WaitForEvents()
+
+	lock.Lock()
+	if len(received) != 2 {
+		t.Fatalf("received %v events, want only the 2 events of the subscribed type", len(received))
+	}
+	for _, event := range received {
+		if event["Context"] != "ODIM" {
+			t.Errorf("event context = %v, want the context of the subscription", event["Context"])
+		}
+	}
+	origins := map[interface{}]bool{}
+	for _, event := range received {
+		record := event["Events"].([]interface{})[0].(map[string]interface{})
+		origins[record["OriginOfCondition"].(map[string]interface{})["@odata.id"]] = true
+	}
+	if !origins["/redfish/v1/Systems/1"] || !origins["/redfish/v1/Managers/1"] {
+		t.Errorf("event origins = %v, want the patched system and the origin of the test event", origins)
+	}
+	received = nil
+	lock.Unlock()
+
+	if w := do(s, http.MethodDelete, location, "", "admin"); w.Code != http.StatusNoContent {
+		t.Fatalf("delete subscription status code = %v, want %v", w.Code, http.StatusNoContent)
+	}
+	do(s, http.MethodPatch, "/redfish/v1/Systems/1", `{"AssetTag":"rack-2"}`, "admin")
+	s.WaitForEvents()
+	if len(received) != 0 {
+		t.Errorf("received %v events after the subscription is deleted, want none", len(received))
+	}
+}
+
+// TestRedfishClient runs the Redfish client of the plugins against the simulator
+func TestRedfishClient(t *testing.T) {
+	config.SetUpMockConfig(t)
+	cert, err := tls.X509KeyPair(config.Data.KeyCertConf.Certificate, config.Data.KeyCertConf.PrivateKey)
+	if err != nil {
+		t.Fatalf("unable to load the certificate of the simulator: %v", err)
+	}
+	s := newSimulator(t)
+	bmc := httptest.NewUnstartedServer(s)
+	bmc.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	bmc.StartTLS()
+	defer bmc.Close()
+
+	client, err := redfish.GetRedfishClient()
+	if err != nil {
+		t.Fatalf("GetRedfishClient() error = %v", err)
+	}
+	device := &redfish.RedfishDevice{
+		Host:     strings.TrimPrefix(bmc.URL, "https://"),
+		Username: "admin",
+		Password: "password",
+	}
+	if err := client.GetRootService(device); err != nil {
+		t.Fatalf("GetRootService() error = %v", err)
+	}
+	if device.RootNode.UUID != "sim-uuid" {
+		t.Errorf("GetRootService() UUID = %v, want sim-uuid", device.RootNode.UUID)
+	}
+	if err := client.AuthWithDevice(device); err != nil {
+		t.Fatalf("AuthWithDevice() error = %v", err)
+	}
+
+	device.PostBody = []byte(`{"ResetType":"GracefulShutdown"}`)
+	resp, err := client.ResetComputerSystem(device, "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset")
+	if err != nil || resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("ResetComputerSystem() = %v, %v, want %v", resp, err, http.StatusNoContent)
+	}
+	resp.Body.Close()
+	if state := property(t, s, "/redfish/v1/Systems/1", "PowerState"); state != "Off" {
+		t.Errorf("system power state = %v, want Off", state)
+	}
+}
@@ -0,0 +1,100 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package simulator
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// isVolumes checks if the URI is of a volume collection of a storage
+func isVolumes(uri string) bool {
+	return path.Base(uri) == "Volumes" && strings.Contains(uri, "/Storage/")
+}
+
+// createVolume creates the volume of the RAID type on the drives of the storage,
+// the capacity of the volume is the total capacity of its drives
+func (s *Simulator) createVolume(w http.ResponseWriter, r *http.Request, collectionURI string) {
+	var request struct {
+		Name     string `json:"Name"`
+		RAIDType string `json:"RAIDType"`
+		Links    struct {
+			Drives []struct {
+				ODataID string `json:"@odata.id"`
+			} `json:"Drives"`
+		} `json:"Links"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "Base.1.8.MalformedJSON", "The request body submitted was malformed JSON: "+err.Error())
+		return
+	}
+	if request.RAIDType == "" || len(request.Links.Drives) == 0 {
+		writeError(w, http.StatusBadRequest, "Base.1.8.PropertyMissing", "The RAIDType and Links/Drives properties are required to create a volume.")
+		return
+	}
+
+	s.lock.Lock()
+	if _, ok := s.resources[collectionURI]; !ok {
+		s.lock.Unlock()
+		writeError(w, http.StatusNotFound, "Base.1.8.ResourceMissingAtURI", "The resource at the URI "+collectionURI+" was not found.")
+		return
+	}
+	drivesURI := path.Dir(collectionURI) + "/Drives"
+	var capacity float64
+	var drives []interface{}
+	for _, drive := range request.Links.Drives {
+		resource, ok := s.resources[drive.ODataID]
+		if !ok || path.Dir(drive.ODataID) != drivesURI {
+			s.lock.Unlock()
+			writeError(w, http.StatusBadRequest, "Base.1.8.PropertyValueNotInList", "The drive "+drive.ODataID+" is not a drive of the storage.")
+			return
+		}
+		driveCapacity, _ := resource["CapacityBytes"].(float64)
+		capacity += driveCapacity
+		drives = append(drives, map[string]interface{}{"@odata.id": drive.ODataID})
+	}
+	volume := map[string]interface{}{
+		"@odata.type":   "#Volume.v1_6_0.Volume",
+		"Name":          request.Name,
+		"RAIDType":      request.RAIDType,
+		"CapacityBytes": capacity,
+		"Links": map[string]interface{}{
+			"Drives": drives,
+		},
+		"Status": map[string]interface{}{
+			"State":  "Enabled",
+			"Health": "OK",
+		},
+	}
+	uri := s.addMember(collectionURI, volume)
+	if request.Name == "" {
+		volume["Name"] = "Volume " + path.Base(uri)
+	}
+	response := copyValue(volume)
+	s.lock.Unlock()
+
+	s.PostEvent("ResourceAdded", "ResourceEvent.1.0.ResourceCreated", "The resource "+uri+" is created.", uri)
+	w.Header().Set("Location", uri)
+	writeJSON(w, http.StatusCreated, response)
+}
+
+// deleteVolume deletes the volume and posts a ResourceRemoved event
+func (s *Simulator) deleteVolume(w http.ResponseWriter, uri string) {
+	if s.deleteMember(w, uri) {
+		s.PostEvent("ResourceRemoved", "ResourceEvent.1.0.ResourceRemoved", "The resource "+uri+" is removed.", uri)
+	}
+}
@@ -0,0 +1,40 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package translation translates the URIs between the ODIM northbound (/ODIM/v1)
+// and the device southbound (/redfish/v1) forms, as configured in URLTranslation
+package translation
+
+import (
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+)
+
+// ToSouthBound replaces the northbound URIs in the data with the ones understood by the device
+func ToSouthBound(data string) string {
+	return translate(data, config.Data.URLTranslation.SouthBoundURL)
+}
+
+// ToNorthBound replaces the device URIs in the data with the ones understood by ODIM
+func ToNorthBound(data string) string {
+	return translate(data, config.Data.URLTranslation.NorthBoundURL)
+}
+
+func translate(data string, translations map[string]string) string {
+	for key, value := range translations {
+		data = strings.Replace(data, key, value, -1)
+	}
+	return data
+}
@@ -0,0 +1,61 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package translation
+
+import (
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+)
+
+func TestTranslation(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.URLTranslation = &config.URLTranslation{
+		NorthBoundURL: map[string]string{"redfish": "ODIM"},
+		SouthBoundURL: map[string]string{"ODIM": "redfish"},
+	}
+	tests := []struct {
+		name      string
+		translate func(string) string
+		data      string
+		want      string
+	}{
+		{
+			name:      "southbound URI",
+			translate: ToSouthBound,
+			data:      "/ODIM/v1/Systems/1",
+			want:      "/redfish/v1/Systems/1",
+		},
+		{
+			name:      "northbound body",
+			translate: ToNorthBound,
+			data:      `{"@odata.id":"/redfish/v1/Systems/1","Bios":{"@odata.id":"/redfish/v1/Systems/1/Bios"}}`,
+			want:      `{"@odata.id":"/ODIM/v1/Systems/1","Bios":{"@odata.id":"/ODIM/v1/Systems/1/Bios"}}`,
+		},
+		{
+			name:      "nothing to translate",
+			translate: ToSouthBound,
+			data:      "/redfish/v1/Chassis",
+			want:      "/redfish/v1/Chassis",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.translate(tt.data); got != tt.want {
+				t.Errorf("translate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
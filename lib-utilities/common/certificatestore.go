@@ -0,0 +1,210 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+const (
+	// CertificateStoreTable holds the certificates of ODIM replaced through the CertificateService.
+	// The certificate files of the services are mounted read only and are not shared by
+	// the instances of the services, so the replaced certificates are kept in the db
+	// from where every instance loads them.
+	CertificateStoreTable = "CertificateStore"
+	// RPCCertificateKey is the key of the certificate used by the services for RPC
+	RPCCertificateKey = "RPC"
+	// APIGatewayCertificateKey is the key of the certificate of the API gateway
+	APIGatewayCertificateKey = "APIGateway"
+
+	// storedCertificatePollInterval is the interval at which the services check the db for replaced certificates
+	storedCertificatePollInterval = 30 * time.Second
+)
+
+// StoredCertificate is a certificate along with its private key, both PEM encoded
+type StoredCertificate struct {
+	Certificate []byte `json:"Certificate,omitempty"`
+	PrivateKey  []byte `json:"PrivateKey,omitempty"`
+}
+
+// storedCertificateData is a StoredCertificate as saved in the db. A private key is too large
+// to be encrypted with the RSA key of ODIM, so it is sealed with a random AES key and the AES key
+// is encrypted with the RSA key of ODIM, like the BMC passwords are.
+type storedCertificateData struct {
+	Certificate  []byte `json:"Certificate,omitempty"`
+	EncryptedKey []byte `json:"EncryptedKey,omitempty"`
+	PrivateKey   []byte `json:"PrivateKey,omitempty"`
+}
+
+// encryptPrivateKey seals the private key with a new AES key, and returns
+// the sealed private key along with the AES key encrypted with the RSA key of ODIM
+func encryptPrivateKey(privateKey []byte) ([]byte, []byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, fmt.Errorf("error while trying to generate the encryption key: %v", err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("error while trying to generate the nonce: %v", err)
+	}
+	encryptedKey, err := EncryptWithPublicKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gcm.Seal(nonce, nonce, privateKey, nil), encryptedKey, nil
+}
+
+// decryptPrivateKey opens the private key sealed by encryptPrivateKey
+func decryptPrivateKey(sealedKey, encryptedKey []byte) ([]byte, error) {
+	key, err := DecryptWithPrivateKey(encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealedKey) < gcm.NonceSize() {
+		return nil, fmt.Errorf("error while trying to decrypt the private key: the data is too short")
+	}
+	privateKey, err := gcm.Open(nil, sealedKey[:gcm.NonceSize()], sealedKey[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to decrypt the private key: %v", err)
+	}
+	return privateKey, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to create the cipher: %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to create the cipher: %v", err)
+	}
+	return gcm, nil
+}
+
+// GetStoredCertificate reads the certificate stored with the key,
+// nil is returned when there is no certificate stored
+func GetStoredCertificate(key string) (*StoredCertificate, *errors.Error) {
+	conn, err := GetDBConnection(OnDisk)
+	if err != nil {
+		return nil, err
+	}
+	data, err := conn.Read(CertificateStoreTable, key)
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return nil, nil
+		}
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get the stored certificate ", key, ": ", err.Error())
+	}
+	var certData storedCertificateData
+	if jerr := json.Unmarshal([]byte(data), &certData); jerr != nil {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error while trying to unmarshal the stored certificate ", key, ": ", jerr)
+	}
+	cert := StoredCertificate{Certificate: certData.Certificate}
+	if len(certData.PrivateKey) != 0 {
+		privateKey, cerr := decryptPrivateKey(certData.PrivateKey, certData.EncryptedKey)
+		if cerr != nil {
+			return nil, errors.PackError(errors.UndefinedErrorType, "error while trying to decrypt the private key of the stored certificate ", key, ": ", cerr)
+		}
+		cert.PrivateKey = privateKey
+	}
+	return &cert, nil
+}
+
+// SaveStoredCertificate creates or replaces the certificate stored with the key,
+// the private key is saved encrypted
+func SaveStoredCertificate(key string, cert StoredCertificate) *errors.Error {
+	certData := storedCertificateData{Certificate: cert.Certificate}
+	if len(cert.PrivateKey) != 0 {
+		var cerr error
+		if certData.PrivateKey, certData.EncryptedKey, cerr = encryptPrivateKey(cert.PrivateKey); cerr != nil {
+			return errors.PackError(errors.UndefinedErrorType, "error while trying to encrypt the private key of the stored certificate ", key, ": ", cerr)
+		}
+	}
+	conn, err := GetDBConnection(OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.AddResourceData(CertificateStoreTable, key, certData); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to save the stored certificate ", key, ": ", err.Error())
+	}
+	return nil
+}
+
+// DeleteStoredCertificate deletes the certificate stored with the key
+func DeleteStoredCertificate(key string) *errors.Error {
+	conn, err := GetDBConnection(OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.Delete(CertificateStoreTable, key); err != nil && err.ErrNo() != errors.DBKeyNotFound {
+		return errors.PackError(err.ErrNo(), "error while trying to delete the stored certificate ", key, ": ", err.Error())
+	}
+	return nil
+}
+
+// getStoredCertificates reads the replaced certificates of ODIM from the db
+func getStoredCertificates() (map[string]*StoredCertificate, *errors.Error) {
+	certs := make(map[string]*StoredCertificate)
+	for _, key := range []string{RPCCertificateKey, APIGatewayCertificateKey} {
+		cert, err := GetStoredCertificate(key)
+		if err != nil {
+			return nil, err
+		}
+		if cert != nil {
+			certs[key] = cert
+		}
+	}
+	return certs, nil
+}
+
+// applyStoredCertificates makes the services use the replaced certificates in place of the
+// configured ones, and reports whether any certificate in use changed.
+// It must be called holding config.TLSConfMutex.
+func applyStoredCertificates(certs map[string]*StoredCertificate) bool {
+	changed := false
+	apply := func(cert *StoredCertificate, certificate, privateKey *[]byte) {
+		if cert == nil || (bytes.Equal(cert.Certificate, *certificate) && bytes.Equal(cert.PrivateKey, *privateKey)) {
+			return
+		}
+		*certificate = append([]byte{}, cert.Certificate...)
+		*privateKey = append([]byte{}, cert.PrivateKey...)
+		changed = true
+	}
+	if config.Data.KeyCertConf != nil {
+		apply(certs[RPCCertificateKey], &config.Data.KeyCertConf.RPCCertificate, &config.Data.KeyCertConf.RPCPrivateKey)
+	}
+	if config.Data.APIGatewayConf != nil {
+		apply(certs[APIGatewayCertificateKey], &config.Data.APIGatewayConf.Certificate, &config.Data.APIGatewayConf.PrivateKey)
+	}
+	return changed
+}
@@ -0,0 +1,74 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+func TestApplyStoredCertificates(t *testing.T) {
+	config.Data.KeyCertConf = &config.KeyCertConf{RPCCertificate: []byte("rpc cert"), RPCPrivateKey: []byte("rpc key")}
+	config.Data.APIGatewayConf = &config.APIGatewayConf{Certificate: []byte("gateway cert"), PrivateKey: []byte("gateway key")}
+
+	if applyStoredCertificates(nil) {
+		t.Error("applyStoredCertificates() reported a change without stored certificates")
+	}
+	certs := map[string]*StoredCertificate{
+		RPCCertificateKey: {Certificate: []byte("new rpc cert"), PrivateKey: []byte("new rpc key")},
+	}
+	if !applyStoredCertificates(certs) {
+		t.Error("applyStoredCertificates() did not report the replaced certificate")
+	}
+	if !bytes.Equal(config.Data.KeyCertConf.RPCCertificate, []byte("new rpc cert")) || !bytes.Equal(config.Data.KeyCertConf.RPCPrivateKey, []byte("new rpc key")) {
+		t.Errorf("RPC key pair = %s %s, want the stored key pair", config.Data.KeyCertConf.RPCCertificate, config.Data.KeyCertConf.RPCPrivateKey)
+	}
+	if !bytes.Equal(config.Data.APIGatewayConf.Certificate, []byte("gateway cert")) {
+		t.Errorf("API gateway certificate = %s, want the configured certificate", config.Data.APIGatewayConf.Certificate)
+	}
+	if applyStoredCertificates(certs) {
+		t.Error("applyStoredCertificates() reported a change for the certificates already in use")
+	}
+}
+
+func TestEncryptPrivateKey(t *testing.T) {
+	config.Data.KeyCertConf = &config.KeyCertConf{
+		RSAPublicKey:  []byte(publicKey),
+		RSAPrivateKey: []byte(privateKey),
+	}
+
+	// the private key of ODIM is larger than what the RSA key can encrypt
+	sealedKey, encryptedKey, err := encryptPrivateKey([]byte(privateKey))
+	if err != nil {
+		t.Fatalf("encryptPrivateKey() error = %v", err)
+	}
+	if bytes.Contains(sealedKey, []byte("PRIVATE KEY")) {
+		t.Error("encryptPrivateKey() returned the private key in clear")
+	}
+	decrypted, err := decryptPrivateKey(sealedKey, encryptedKey)
+	if err != nil {
+		t.Fatalf("decryptPrivateKey() error = %v", err)
+	}
+	if string(decrypted) != privateKey {
+		t.Errorf("decryptPrivateKey() = %s, want the encrypted private key", decrypted)
+	}
+
+	sealedKey[len(sealedKey)-1] ^= 1
+	if _, err := decryptPrivateKey(sealedKey, encryptedKey); err == nil {
+		t.Error("decryptPrivateKey() did not fail for a modified private key")
+	}
+}
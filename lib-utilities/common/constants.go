@@ -140,16 +140,32 @@ const (
 	ManagerType = "#Manager.v1_15_0.Manager"
 	// TaskEventType has schema version to be returned with TaskEvent
 	TaskEventType = "TaskEvent.1.0.3"
+	// LicenseEventType has the message registry version used for the license expiry events
+	LicenseEventType = "License.1.0.3"
 	// UpdateServiceType has schema version to be returned with UpdateService
 	UpdateServiceType = "#UpdateService.v1_11_0.UpdateService"
 	// SettingsType has schema version to be returned with Settings in update service
 	SettingsType = "#Settings.v1_3_3.OperationApplyTimeSupport"
 	// TelemetryServiceType has version to be returned with Telemetry Service
 	TelemetryServiceType = "#TelemetryService.v1_3_1.TelemetryService"
+	// TelemetryEventType has the message registry version used for the trigger events
+	TelemetryEventType = "Telemetry.1.0.0"
 	//AggregationSourceType has version to be returned with AggregationSource Service
 	AggregationSourceType = "#AggregationSource.v1_2_0.AggregationSource"
 	//ChassisType has version to be returned with Chassis Service
 	ChassisType = "#Chassis.v1_20_0.Chassis"
+	// SensorType has version to be returned with Sensor
+	SensorType = "#Sensor.v1_2_0.Sensor"
+	// SensorCollectionType has version to be returned with Sensor collection
+	SensorCollectionType = "#SensorCollection.SensorCollection"
+	// CertificateServiceType has version to be returned with CertificateService
+	CertificateServiceType = "#CertificateService.v1_0_2.CertificateService"
+	// CertificateLocationsType has version to be returned with CertificateLocations
+	CertificateLocationsType = "#CertificateLocations.v1_0_2.CertificateLocations"
+	// CertificateType has version to be returned with Certificate
+	CertificateType = "#Certificate.v1_2_4.Certificate"
+	// CertificateCollectionType has version to be returned with Certificate collection
+	CertificateCollectionType = "#CertificateCollection.CertificateCollection"
 	// AggregateSubscriptionIndex is a index name which required for indexing
 	// subscription of aggregate
 	AggregateSubscriptionIndex = "AggregateToHost"
@@ -195,6 +211,7 @@ var SystemResource = map[string]string{
 var ChassisResource = map[string]string{
 	"Power":                  "Power",
 	"Thermal":                "Thermal",
+	"Triggers":               "Triggers",
 	"NetworkAdapters":        "NetworkAdaptersCollection",
 	"NetworkPorts":           "NetworkPortsCollection",
 	"NetworkDeviceFunctions": "NetworkDeviceFunctionsCollection",
@@ -203,6 +220,11 @@ var ChassisResource = map[string]string{
 	"PCIeDevices":            "PCIeDevicesCollection",
 	"Sensors":                "SensorsCollection",
 	"LogServices":            "LogServicesCollection",
+	"PowerSubsystem":         "PowerSubsystem",
+	"PowerSupplies":          "PowerSuppliesCollection",
+	"ThermalSubsystem":       "ThermalSubsystem",
+	"Fans":                   "FansCollection",
+	"EnvironmentMetrics":     "EnvironmentMetrics",
 }
 
 // ManagersResource contains the Resource name and table name
@@ -239,6 +261,7 @@ var ResourceTypes = map[string]string{
 	"IPAddresses":            "IPAddresses",
 	"Job":                    "Job",
 	"JobService":             "JobService",
+	"License":                "Licenses",
 	"LogEntry":               "LogEntry",
 	"LogService":             "LogServices",
 	"Manager":                "Manager",
@@ -278,6 +301,7 @@ var ResourceTypes = map[string]string{
 	"Switch":                 "Switch",
 	"Task":                   "Task",
 	"Thermal":                "Thermal",
+	"Triggers":               "Triggers",
 	"VLanNetworkInterface":   "VLanNetworkInterface",
 	"Volume":                 "Volume",
 	"Zone":                   "Zone",
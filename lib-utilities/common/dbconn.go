@@ -36,7 +36,7 @@ const (
 // dbFlag:
 //	InMemory:	returns In-Memory DB connection pool
 //	OnDsik:  	returns On-Disk DB connection pool
-func GetDBConnection(dbFlag DbType) (*persistencemgr.ConnPool, *errors.Error) {
+func GetDBConnection(dbFlag DbType) (persistencemgr.DBInterface, *errors.Error) {
 	switch dbFlag {
 	case InMemory:
 		pool, err := persistencemgr.GetDBConnection(persistencemgr.InMemory)
@@ -0,0 +1,56 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// durationRegex matches the ISO 8601 duration format used by Redfish,
+// for example P1DT2H, PT30S or PT0.5S
+var durationRegex = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseISO8601Duration converts a Redfish duration string into time.Duration.
+// Only days, hours, minutes and seconds are supported, since years and months
+// do not have a fixed length.
+func ParseISO8601Duration(duration string) (time.Duration, error) {
+	match := durationRegex.FindStringSubmatch(duration)
+	if match == nil || duration == "P" || duration == "PT" {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %v", duration)
+	}
+	var result time.Duration
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute}
+	for i, unit := range units {
+		if match[i+1] == "" {
+			continue
+		}
+		value, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO 8601 duration %v: %v", duration, err)
+		}
+		result += time.Duration(value) * unit
+	}
+	if match[4] != "" {
+		seconds, err := strconv.ParseFloat(match[4], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO 8601 duration %v: %v", duration, err)
+		}
+		result += time.Duration(seconds * float64(time.Second))
+	}
+	return result, nil
+}
@@ -0,0 +1,72 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseISO8601Duration(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration string
+		want     time.Duration
+		wantErr  bool
+	}{
+		{
+			name:     "seconds only",
+			duration: "PT30S",
+			want:     30 * time.Second,
+		},
+		{
+			name:     "fractional seconds",
+			duration: "PT0.5S",
+			want:     500 * time.Millisecond,
+		},
+		{
+			name:     "days hours and minutes",
+			duration: "P1DT2H3M",
+			want:     26*time.Hour + 3*time.Minute,
+		},
+		{
+			name:     "empty time part",
+			duration: "PT",
+			wantErr:  true,
+		},
+		{
+			name:     "unsupported months",
+			duration: "P1M",
+			wantErr:  true,
+		},
+		{
+			name:     "not a duration",
+			duration: "30s",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseISO8601Duration(tt.duration)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseISO8601Duration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseISO8601Duration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
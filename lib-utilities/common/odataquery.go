@@ -0,0 +1,710 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+// Redfish query parameters supported on the collections
+const (
+	QueryFilter = "$filter"
+	QuerySelect = "$select"
+	QueryTop    = "$top"
+	QuerySkip   = "$skip"
+	QueryExpand = "$expand"
+)
+
+// operators of the $filter expressions
+const (
+	filterAnd = "and"
+	filterOr  = "or"
+	filterNot = "not"
+)
+
+var (
+	comparisonOperators = map[string]bool{"eq": true, "ne": true, "gt": true, "ge": true, "lt": true, "le": true}
+	// resourceIdentifiers are retained in the members, along with the properties requested with $select
+	resourceIdentifiers = []string{"@odata.context", "@odata.etag", "@odata.id", "@odata.type"}
+)
+
+// ODataQuery holds the Redfish query parameters of a collection request
+type ODataQuery struct {
+	Filter *FilterExpression
+	Select []string
+	// Top is -1 when $top is not requested
+	Top          int
+	Skip         int
+	Expand       string
+	ExpandLevels int
+	rawQuery     string
+}
+
+// FilterExpression is a node of the parsed $filter expression.
+// Operator is "and", "or" or "not" for the logical expressions, where
+// "not" has only the Left operand. For the comparisons, Operator is one of
+// eq, ne, gt, ge, lt and le, which compares the value of Property with Value.
+type FilterExpression struct {
+	Operator string
+	Left     *FilterExpression
+	Right    *FilterExpression
+	Property string
+	Value    interface{}
+	// rawValue holds the literal as given in the request, when it was not quoted
+	rawValue string
+}
+
+// QueryError is returned for an invalid query, along with the
+// details of the Redfish message to be responded with
+type QueryError struct {
+	StatusMessage string
+	ErrorMessage  string
+	MessageArgs   []interface{}
+}
+
+func (e *QueryError) Error() string {
+	return e.ErrorMessage
+}
+
+// Response returns the error response for the invalid query
+func (e *QueryError) Response() response.RPC {
+	return GeneralError(http.StatusBadRequest, e.StatusMessage, e.ErrorMessage, e.MessageArgs, nil)
+}
+
+func queryValueFormatError(param, value string, err error) *QueryError {
+	return &QueryError{
+		StatusMessage: response.QueryParameterValueFormatError,
+		ErrorMessage:  err.Error(),
+		MessageArgs:   []interface{}{value, param},
+	}
+}
+
+// ParseODataQuery parses the $filter, $select, $top, $skip and $expand query
+// parameters in the raw query of the request URL. The query parameters
+// which are not prefixed with $ are ignored.
+func ParseODataQuery(rawQuery string) (*ODataQuery, *QueryError) {
+	query := &ODataQuery{Top: -1, rawQuery: rawQuery}
+	found := make(map[string]bool)
+	for _, param := range strings.Split(rawQuery, "&") {
+		if param == "" {
+			continue
+		}
+		nameValue := strings.SplitN(param, "=", 2)
+		name, err := url.QueryUnescape(nameValue[0])
+		if err != nil {
+			return nil, queryValueFormatError(nameValue[0], param, err)
+		}
+		var value string
+		if len(nameValue) == 2 {
+			if value, err = url.QueryUnescape(nameValue[1]); err != nil {
+				return nil, queryValueFormatError(name, nameValue[1], err)
+			}
+		}
+		if !strings.HasPrefix(name, "$") && name != "only" && name != "excerpt" {
+			continue
+		}
+		if found[name] {
+			return nil, &QueryError{
+				StatusMessage: response.QueryCombinationInvalid,
+				ErrorMessage:  " query parameter " + name + " is repeated",
+			}
+		}
+		found[name] = true
+		switch name {
+		case QueryFilter:
+			if query.Filter, err = ParseFilter(value); err != nil {
+				return nil, queryValueFormatError(name, value, err)
+			}
+		case QuerySelect:
+			for _, property := range strings.Split(value, ",") {
+				property = strings.TrimSpace(property)
+				if property == "" {
+					return nil, queryValueFormatError(name, value, fmt.Errorf("property to be selected is empty"))
+				}
+				query.Select = append(query.Select, property)
+			}
+		case QueryTop, QuerySkip:
+			count, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, queryValueFormatError(name, value, fmt.Errorf("%v should be an integer", name))
+			}
+			if count < 0 {
+				return nil, &QueryError{
+					StatusMessage: response.QueryParameterOutOfRange,
+					ErrorMessage:  name + " should not be negative",
+					MessageArgs:   []interface{}{value, name, ">= 0"},
+				}
+			}
+			if name == QueryTop {
+				query.Top = count
+			} else {
+				query.Skip = count
+			}
+		case QueryExpand:
+			if qErr := query.parseExpand(value); qErr != nil {
+				return nil, qErr
+			}
+		default:
+			return nil, &QueryError{
+				StatusMessage: response.QueryNotSupported,
+				ErrorMessage:  "query parameter " + name + " is not supported",
+			}
+		}
+	}
+	return query, nil
+}
+
+// parseExpand parses the $expand value, which is one of *, . and ~,
+// optionally followed by the levels as ($levels=1)
+func (q *ODataQuery) parseExpand(value string) *QueryError {
+	expand := value
+	levels := 1
+	if i := strings.Index(value, "("); i >= 0 {
+		expand = value[:i]
+		option := strings.TrimSuffix(value[i+1:], ")")
+		if !strings.HasSuffix(value, ")") || !strings.HasPrefix(option, "$levels=") {
+			return queryValueFormatError(QueryExpand, value, fmt.Errorf("expand option should be of the form ($levels=n)"))
+		}
+		var err error
+		if levels, err = strconv.Atoi(strings.TrimPrefix(option, "$levels=")); err != nil {
+			return queryValueFormatError(QueryExpand, value, fmt.Errorf("$levels should be an integer"))
+		}
+	}
+	if expand != "*" && expand != "." && expand != "~" {
+		return queryValueFormatError(QueryExpand, value, fmt.Errorf("expand type should be one of *, . and ~"))
+	}
+	// only the members of the collections are expanded
+	if levels != 1 {
+		return &QueryError{
+			StatusMessage: response.QueryParameterOutOfRange,
+			ErrorMessage:  "only one level of expansion is supported",
+			MessageArgs:   []interface{}{value, QueryExpand, "$levels=1"},
+		}
+	}
+	q.Expand = expand
+	q.ExpandLevels = levels
+	return nil
+}
+
+// IsEmpty returns true if none of the query parameters are requested
+func (q *ODataQuery) IsEmpty() bool {
+	return q.Filter == nil && len(q.Select) == 0 && q.Top < 0 && q.Skip == 0 && q.Expand == ""
+}
+
+// NeedsMembers returns true if the query can be applied on a collection
+// only with the resources of its members
+func (q *ODataQuery) NeedsMembers() bool {
+	return q.Filter != nil || len(q.Select) > 0 || q.Expand != ""
+}
+
+// ApplyToCollection applies the query on the collection.
+// The members are filtered with $filter and paged with $skip and $top, where
+// Members@odata.count is the number of members after filtering and
+// Members@odata.nextLink is the link to the next page, if any.
+// With $expand or $select, the members are replaced with their resources,
+// having only the selected properties in case of $select.
+// getMember returns the resource of the member, or nil if the member is not
+// present anymore, and is called only if the query has $filter, $select or $expand.
+// Without $filter, only the members of the requested page are fetched.
+// The members are fetched concurrently, so getMember must be safe for concurrent use.
+func (q *ODataQuery) ApplyToCollection(collection map[string]interface{}, getMember func(uri string) (map[string]interface{}, error)) error {
+	members, _ := collection["Members"].([]interface{})
+	if q.Filter == nil {
+		start, end := q.pageBounds(len(members))
+		page := append([]interface{}{}, members[start:end]...)
+		if q.NeedsMembers() {
+			var err error
+			if page, err = q.fetchMembers(page, getMember); err != nil {
+				return err
+			}
+		}
+		setCollectionPage(collection, page, len(members), end < len(members), q.nextLink(collectionURIOf(collection), end))
+		return nil
+	}
+	members, err := q.fetchMembers(members, getMember)
+	if err != nil {
+		return err
+	}
+	start, end := q.pageBounds(len(members))
+	page := append([]interface{}{}, members[start:end]...)
+	setCollectionPage(collection, page, len(members), end < len(members), q.nextLink(collectionURIOf(collection), end))
+	return nil
+}
+
+// memberFetchWorkers is the number of the members of a collection fetched at the same time
+const memberFetchWorkers = 10
+
+// fetchMembers fetches the resources of the members concurrently and returns, in the order
+// of the members, the ones matching $filter, as links or as resources for $select and $expand
+func (q *ODataQuery) fetchMembers(members []interface{}, getMember func(uri string) (map[string]interface{}, error)) ([]interface{}, error) {
+	results := make([]interface{}, len(members))
+	errs := make([]error, len(members))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < memberFetchWorkers && w < len(members); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i], errs[i] = q.fetchMember(members[i], getMember)
+			}
+		}()
+	}
+	for i := range members {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	resources := make([]interface{}, 0, len(members))
+	for i, result := range results {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if result != nil {
+			resources = append(resources, result)
+		}
+	}
+	return resources, nil
+}
+
+// fetchMember returns the member as it appears in the collection after the query,
+// or nil if it does not match $filter or is not present anymore
+func (q *ODataQuery) fetchMember(member interface{}, getMember func(uri string) (map[string]interface{}, error)) (interface{}, error) {
+	link, _ := member.(map[string]interface{})
+	uri, _ := link["@odata.id"].(string)
+	resource, err := getMember(uri)
+	if err != nil || resource == nil {
+		// a nil resource is a member removed after the collection is read
+		return nil, err
+	}
+	if q.Filter != nil && !q.Filter.Evaluate(resource) {
+		return nil, nil
+	}
+	if len(q.Select) > 0 {
+		return SelectProperties(resource, q.Select), nil
+	}
+	if q.Expand == "" {
+		return link, nil
+	}
+	return resource, nil
+}
+
+// pageBounds returns the bounds of the page requested with $skip and $top
+func (q *ODataQuery) pageBounds(total int) (int, int) {
+	start := q.Skip
+	if start > total {
+		start = total
+	}
+	end := total
+	if q.Top >= 0 && start+q.Top < total {
+		end = start + q.Top
+	}
+	return start, end
+}
+
+func collectionURIOf(collection map[string]interface{}) string {
+	collectionURI, _ := collection["@odata.id"].(string)
+	return collectionURI
+}
+
+func setCollectionPage(collection map[string]interface{}, page []interface{}, total int, hasNext bool, nextLink string) {
+	collection["Members"] = page
+	collection["Members@odata.count"] = total
+	delete(collection, "Members@odata.nextLink")
+	if hasNext {
+		collection["Members@odata.nextLink"] = nextLink
+	}
+}
+
+// nextLink returns the link to the page starting at skip,
+// retaining the other query parameters of the request
+func (q *ODataQuery) nextLink(collectionURI string, skip int) string {
+	var params []string
+	for _, param := range strings.Split(q.rawQuery, "&") {
+		nameValue := strings.SplitN(param, "=", 2)
+		name, _ := url.QueryUnescape(nameValue[0])
+		if param == "" || name == QuerySkip {
+			continue
+		}
+		if len(nameValue) == 2 {
+			name += "=" + nameValue[1]
+		}
+		params = append(params, name)
+	}
+	params = append(params, QuerySkip+"="+strconv.Itoa(skip))
+	return collectionURI + "?" + strings.Join(params, "&")
+}
+
+// SelectProperties returns the resource with only the given properties,
+// along with its @odata identifiers. Property of a nested object is
+// given with its path, like Status/Health.
+func SelectProperties(resource map[string]interface{}, properties []string) map[string]interface{} {
+	selected := make(map[string]interface{})
+	for _, key := range resourceIdentifiers {
+		if value, ok := resource[key]; ok {
+			selected[key] = value
+		}
+	}
+	for _, property := range properties {
+		if property == "*" {
+			return resource
+		}
+		copyProperty(resource, selected, strings.Split(property, "/"))
+	}
+	return selected
+}
+
+func copyProperty(src, dst map[string]interface{}, path []string) {
+	value, ok := src[path[0]]
+	if !ok {
+		return
+	}
+	if len(path) == 1 {
+		dst[path[0]] = value
+		return
+	}
+	srcChild, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	dstChild, ok := dst[path[0]].(map[string]interface{})
+	if !ok {
+		dstChild = make(map[string]interface{})
+		dst[path[0]] = dstChild
+	}
+	copyProperty(srcChild, dstChild, path[1:])
+}
+
+// Literal returns the value of the comparison as given in the request,
+// which lets the services look up the value in their own search indexes
+func (f *FilterExpression) Literal() string {
+	if f.rawValue != "" {
+		return f.rawValue
+	}
+	if s, ok := f.Value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(f.Value)
+}
+
+// Evaluate returns true if the resource satisfies the filter expression.
+// If the property is an array, the comparison is true when any of its
+// elements satisfies it, while ne is true when none of them are equal.
+func (f *FilterExpression) Evaluate(resource map[string]interface{}) bool {
+	switch f.Operator {
+	case filterAnd:
+		return f.Left.Evaluate(resource) && f.Right.Evaluate(resource)
+	case filterOr:
+		return f.Left.Evaluate(resource) || f.Right.Evaluate(resource)
+	case filterNot:
+		return !f.Left.Evaluate(resource)
+	}
+	value, _ := getPropertyValue(resource, strings.Split(f.Property, "/"))
+	values, ok := value.([]interface{})
+	if !ok {
+		return f.compare(value)
+	}
+	if f.Operator == "ne" {
+		for _, v := range values {
+			if !f.compare(v) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range values {
+		if f.compare(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// compare compares the value of the property with the literal of the comparison
+func (f *FilterExpression) compare(value interface{}) bool {
+	cmp, comparable := 0, false
+	switch literal := f.Value.(type) {
+	case nil:
+		cmp, comparable = 0, value == nil
+		if !comparable {
+			return f.Operator == "ne"
+		}
+	case string:
+		if v, ok := value.(string); ok {
+			cmp, comparable = strings.Compare(v, literal), true
+		}
+	case float64:
+		switch v := value.(type) {
+		case float64:
+			comparable = true
+			if v < literal {
+				cmp = -1
+			} else if v > literal {
+				cmp = 1
+			}
+		case string:
+			// numbers given without quotes are compared as is with the string properties
+			cmp, comparable = strings.Compare(v, f.rawValue), true
+		}
+	case bool:
+		if v, ok := value.(bool); ok {
+			comparable = f.Operator == "eq" || f.Operator == "ne"
+			if v != literal {
+				cmp = 1
+			}
+		}
+	}
+	if !comparable {
+		return f.Operator == "ne"
+	}
+	switch f.Operator {
+	case "eq":
+		return cmp == 0
+	case "ne":
+		return cmp != 0
+	case "gt":
+		return cmp > 0
+	case "ge":
+		return cmp >= 0
+	case "lt":
+		return cmp < 0
+	case "le":
+		return cmp <= 0
+	}
+	return false
+}
+
+// getPropertyValue returns the value of the property at the path in data.
+// The values are collected from all the elements, for an array in the path.
+func getPropertyValue(data interface{}, path []string) (interface{}, bool) {
+	if len(path) == 0 {
+		return data, true
+	}
+	switch v := data.(type) {
+	case map[string]interface{}:
+		child, ok := v[path[0]]
+		if !ok {
+			return nil, false
+		}
+		return getPropertyValue(child, path[1:])
+	case []interface{}:
+		var values []interface{}
+		for _, element := range v {
+			if value, ok := getPropertyValue(element, path); ok {
+				values = append(values, value)
+			}
+		}
+		return values, len(values) > 0
+	}
+	return nil, false
+}
+
+// filterToken is a token of the $filter expression
+type filterToken struct {
+	text   string
+	quoted bool
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+// ParseFilter parses the $filter expression, which supports the comparison
+// operators eq, ne, gt, ge, lt and le, the logical operators and, or and not,
+// and grouping with parentheses. The precedence of the operators from high
+// to low is parentheses, not, comparisons, and, or.
+// String literals are enclosed in single quotes, where a single quote is
+// escaped as two single quotes. Unquoted literals other than numbers,
+// true, false and null are taken as strings, like enumeration values.
+func ParseFilter(filter string) (*FilterExpression, error) {
+	tokens, err := tokenizeFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("filter expression is empty")
+	}
+	p := &filterParser{tokens: tokens}
+	expression, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %v in the filter expression", p.tokens[p.pos].text)
+	}
+	return expression, nil
+}
+
+func tokenizeFilter(filter string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(filter); {
+		switch c := filter[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, filterToken{text: string(c)})
+			i++
+		case c == '\'':
+			var literal strings.Builder
+			i++
+			for {
+				if i >= len(filter) {
+					return nil, fmt.Errorf("string literal is not terminated in the filter expression")
+				}
+				if filter[i] == '\'' {
+					if i+1 < len(filter) && filter[i+1] == '\'' {
+						literal.WriteByte('\'')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				literal.WriteByte(filter[i])
+				i++
+			}
+			tokens = append(tokens, filterToken{text: literal.String(), quoted: true})
+		default:
+			start := i
+			for i < len(filter) && !strings.ContainsRune(" \t()'", rune(filter[i])) {
+				i++
+			}
+			tokens = append(tokens, filterToken{text: filter[start:i]})
+		}
+	}
+	return tokens, nil
+}
+
+// peekKeyword returns true if the next token is the unquoted keyword
+func (p *filterParser) peekKeyword(keyword string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && p.tokens[p.pos].text == keyword
+}
+
+func (p *filterParser) next() (filterToken, error) {
+	if p.pos >= len(p.tokens) {
+		return filterToken{}, fmt.Errorf("filter expression ends unexpectedly")
+	}
+	p.pos++
+	return p.tokens[p.pos-1], nil
+}
+
+func (p *filterParser) parseOr() (*FilterExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword(filterOr) {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &FilterExpression{Operator: filterOr, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (*FilterExpression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword(filterAnd) {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &FilterExpression{Operator: filterAnd, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (*FilterExpression, error) {
+	if p.peekKeyword(filterNot) {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &FilterExpression{Operator: filterNot, Left: operand}, nil
+	}
+	if p.peekKeyword("(") {
+		p.pos++
+		expression, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peekKeyword(")") {
+			return nil, fmt.Errorf("missing closing parenthesis in the filter expression")
+		}
+		p.pos++
+		return expression, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (*FilterExpression, error) {
+	property, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if property.quoted || property.text == "(" || property.text == ")" || comparisonOperators[property.text] ||
+		property.text == filterAnd || property.text == filterOr || property.text == filterNot {
+		return nil, fmt.Errorf("expected a property instead of %v in the filter expression", property.text)
+	}
+	operator, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if operator.quoted || !comparisonOperators[operator.text] {
+		return nil, fmt.Errorf("expected a comparison operator after %v instead of %v in the filter expression", property.text, operator.text)
+	}
+	literal, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	expression := &FilterExpression{Operator: operator.text, Property: property.text}
+	if literal.quoted {
+		expression.Value = literal.text
+		return expression, nil
+	}
+	if literal.text == "(" || literal.text == ")" {
+		return nil, fmt.Errorf("expected a value after %v instead of %v in the filter expression", operator.text, literal.text)
+	}
+	expression.rawValue = literal.text
+	switch literal.text {
+	case "null":
+		expression.Value = nil
+	case "true", "false":
+		expression.Value = literal.text == "true"
+	default:
+		if number, err := strconv.ParseFloat(literal.text, 64); err == nil {
+			expression.Value = number
+		} else {
+			expression.Value = literal.text
+		}
+	}
+	return expression, nil
+}
@@ -0,0 +1,324 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+func testChassis() map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"/redfish/v1/Chassis/1": {
+			"@odata.id":   "/redfish/v1/Chassis/1",
+			"Id":          "1",
+			"Name":        "Rack Mount Chassis",
+			"ChassisType": "RackMount",
+			"PowerState":  "On",
+			"Status":      map[string]interface{}{"Health": "OK", "State": "Enabled"},
+			"Weight":      float64(25),
+			"Tags":        []interface{}{"edge", "lab"},
+		},
+		"/redfish/v1/Chassis/2": {
+			"@odata.id":   "/redfish/v1/Chassis/2",
+			"Id":          "2",
+			"Name":        "Blade's Enclosure",
+			"ChassisType": "Enclosure",
+			"PowerState":  "Off",
+			"Status":      map[string]interface{}{"Health": "Critical", "State": "Enabled"},
+			"Weight":      float64(120),
+		},
+		"/redfish/v1/Chassis/3": {
+			"@odata.id":   "/redfish/v1/Chassis/3",
+			"Id":          "3",
+			"Name":        "Zone",
+			"ChassisType": "Zone",
+			"Status":      map[string]interface{}{"Health": "OK", "State": "Absent"},
+			"Weight":      nil,
+		},
+	}
+}
+
+func TestParseFilterAndEvaluate(t *testing.T) {
+	resources := testChassis()
+	tests := []struct {
+		filter  string
+		want    []string
+		wantErr bool
+	}{
+		{filter: "ChassisType eq 'RackMount'", want: []string{"1"}},
+		{filter: "ChassisType eq RackMount", want: []string{"1"}},
+		{filter: "Status/Health ne 'OK'", want: []string{"2"}},
+		{filter: "Weight gt 20 and Weight le 120", want: []string{"1", "2"}},
+		{filter: "Weight eq null", want: []string{"3"}},
+		{filter: "PowerState eq null", want: []string{"3"}},
+		{filter: "Id eq 2", want: []string{"2"}},
+		{filter: "Name eq 'Blade''s Enclosure'", want: []string{"2"}},
+		{filter: "Tags eq 'lab'", want: []string{"1"}},
+		{filter: "not Status/Health eq 'OK'", want: []string{"2"}},
+		// and takes precedence over or
+		{filter: "ChassisType eq Zone or Status/Health eq OK and PowerState eq On", want: []string{"1", "3"}},
+		{filter: "(ChassisType eq Zone or Status/Health eq OK) and PowerState eq On", want: []string{"1"}},
+		{filter: "not (Status/State eq Absent or PowerState eq Off)", want: []string{"1"}},
+		{filter: "Name ge 'R' and Name lt 'Z'", want: []string{"1"}},
+		{filter: "", wantErr: true},
+		{filter: "ChassisType eq", wantErr: true},
+		{filter: "ChassisType like 'Rack'", wantErr: true},
+		{filter: "(ChassisType eq Zone", wantErr: true},
+		{filter: "ChassisType eq Zone)", wantErr: true},
+		{filter: "ChassisType eq 'Zone", wantErr: true},
+		{filter: "ChassisType eq Zone and", wantErr: true},
+		{filter: "ChassisType eq Zone Name", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			expression, err := ParseFilter(tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var got []string
+			for _, id := range []string{"1", "2", "3"} {
+				if expression.Evaluate(resources["/redfish/v1/Chassis/"+id]) {
+					got = append(got, id)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterLiteral(t *testing.T) {
+	tests := map[string]string{
+		"Storage/Drives/Capacity eq 40":    "40",
+		"ProcessorSummary/Model eq Int*":   "Int*",
+		"ProcessorSummary/Model eq 'Xeon'": "Xeon",
+		"PowerState eq null":               "null",
+	}
+	for filter, want := range tests {
+		expression, err := ParseFilter(filter)
+		if err != nil {
+			t.Fatalf("ParseFilter(%v) error = %v", filter, err)
+		}
+		if got := expression.Literal(); got != want {
+			t.Errorf("Literal() of %v got = %v, want %v", filter, got, want)
+		}
+	}
+}
+
+func TestParseODataQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		rawQuery      string
+		want          *ODataQuery
+		wantStatusMsg string
+	}{
+		{
+			name:     "no query",
+			rawQuery: "",
+			want:     &ODataQuery{Top: -1},
+		},
+		{
+			name:     "paging, select and expand",
+			rawQuery: "$top=2&$skip=1&$select=Name,%20Status/Health&$expand=.($levels=1)&other=1",
+			want:     &ODataQuery{Top: 2, Skip: 1, Select: []string{"Name", "Status/Health"}, Expand: ".", ExpandLevels: 1},
+		},
+		{
+			name:          "invalid top",
+			rawQuery:      "$top=two",
+			wantStatusMsg: response.QueryParameterValueFormatError,
+		},
+		{
+			name:          "negative skip",
+			rawQuery:      "$skip=-1",
+			wantStatusMsg: response.QueryParameterOutOfRange,
+		},
+		{
+			name:          "invalid filter",
+			rawQuery:      "$filter=Name%20eq",
+			wantStatusMsg: response.QueryParameterValueFormatError,
+		},
+		{
+			name:          "invalid expand",
+			rawQuery:      "$expand=Links",
+			wantStatusMsg: response.QueryParameterValueFormatError,
+		},
+		{
+			name:          "more levels of expand",
+			rawQuery:      "$expand=*($levels=2)",
+			wantStatusMsg: response.QueryParameterOutOfRange,
+		},
+		{
+			name:          "repeated parameter",
+			rawQuery:      "$top=1&$top=2",
+			wantStatusMsg: response.QueryCombinationInvalid,
+		},
+		{
+			name:          "unsupported parameter",
+			rawQuery:      "only",
+			wantStatusMsg: response.QueryNotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseODataQuery(tt.rawQuery)
+			if tt.wantStatusMsg != "" {
+				if err == nil || err.StatusMessage != tt.wantStatusMsg {
+					t.Fatalf("ParseODataQuery() error = %v, want %v", err, tt.wantStatusMsg)
+				}
+				// the error response should be created with the message args of the status message
+				if resp := err.Response(); resp.StatusCode != 400 {
+					t.Errorf("Response() status code = %v, want 400", resp.StatusCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseODataQuery() error = %v", err)
+			}
+			got.rawQuery = ""
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseODataQuery() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyToCollection(t *testing.T) {
+	resources := testChassis()
+	getMember := func(uri string) (map[string]interface{}, error) {
+		resource, ok := resources[uri]
+		if !ok {
+			return nil, fmt.Errorf("%v not found", uri)
+		}
+		return resource, nil
+	}
+	newCollection := func() map[string]interface{} {
+		return map[string]interface{}{
+			"@odata.id": "/redfish/v1/Chassis",
+			"Members": []interface{}{
+				map[string]interface{}{"@odata.id": "/redfish/v1/Chassis/1"},
+				map[string]interface{}{"@odata.id": "/redfish/v1/Chassis/2"},
+				map[string]interface{}{"@odata.id": "/redfish/v1/Chassis/3"},
+			},
+			"Members@odata.count": 3,
+		}
+	}
+	tests := []struct {
+		name         string
+		rawQuery     string
+		wantMembers  []interface{}
+		wantCount    int
+		wantNextLink string
+	}{
+		{
+			name:     "filter",
+			rawQuery: "$filter=Status/Health%20eq%20'OK'",
+			wantMembers: []interface{}{
+				map[string]interface{}{"@odata.id": "/redfish/v1/Chassis/1"},
+				map[string]interface{}{"@odata.id": "/redfish/v1/Chassis/3"},
+			},
+			wantCount: 2,
+		},
+		{
+			name:     "top and skip",
+			rawQuery: "$top=1&$skip=1",
+			wantMembers: []interface{}{
+				map[string]interface{}{"@odata.id": "/redfish/v1/Chassis/2"},
+			},
+			wantCount:    3,
+			wantNextLink: "/redfish/v1/Chassis?$top=1&$skip=2",
+		},
+		{
+			name:         "skip beyond members",
+			rawQuery:     "$skip=5",
+			wantMembers:  []interface{}{},
+			wantCount:    3,
+			wantNextLink: "",
+		},
+		{
+			name:     "filter, select and top",
+			rawQuery: "$filter=Status/State%20eq%20Enabled&$select=Status/Health&$top=1",
+			wantMembers: []interface{}{
+				map[string]interface{}{
+					"@odata.id": "/redfish/v1/Chassis/1",
+					"Status":    map[string]interface{}{"Health": "OK"},
+				},
+			},
+			wantCount:    2,
+			wantNextLink: "/redfish/v1/Chassis?$filter=Status/State%20eq%20Enabled&$select=Status/Health&$top=1&$skip=1",
+		},
+		{
+			name:     "expand",
+			rawQuery: "$expand=*&$skip=2",
+			wantMembers: []interface{}{
+				resources["/redfish/v1/Chassis/3"],
+			},
+			wantCount: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, qErr := ParseODataQuery(tt.rawQuery)
+			if qErr != nil {
+				t.Fatalf("ParseODataQuery() error = %v", qErr)
+			}
+			collection := newCollection()
+			if err := query.ApplyToCollection(collection, getMember); err != nil {
+				t.Fatalf("ApplyToCollection() error = %v", err)
+			}
+			if !reflect.DeepEqual(collection["Members"], tt.wantMembers) {
+				t.Errorf("ApplyToCollection() Members = %v, want %v", collection["Members"], tt.wantMembers)
+			}
+			if collection["Members@odata.count"] != tt.wantCount {
+				t.Errorf("ApplyToCollection() Members@odata.count = %v, want %v", collection["Members@odata.count"], tt.wantCount)
+			}
+			nextLink, _ := collection["Members@odata.nextLink"].(string)
+			if nextLink != tt.wantNextLink {
+				t.Errorf("ApplyToCollection() Members@odata.nextLink = %v, want %v", nextLink, tt.wantNextLink)
+			}
+		})
+	}
+
+	query, _ := ParseODataQuery("$expand=*")
+	if err := query.ApplyToCollection(map[string]interface{}{
+		"Members": []interface{}{map[string]interface{}{"@odata.id": "/redfish/v1/Chassis/4"}},
+	}, getMember); err == nil {
+		t.Errorf("ApplyToCollection() should fail when the member can not be fetched")
+	}
+
+	// without $filter only the members of the page are fetched
+	var lock sync.Mutex
+	var fetched []string
+	query, _ = ParseODataQuery("$select=Name&$top=1&$skip=1")
+	if err := query.ApplyToCollection(newCollection(), func(uri string) (map[string]interface{}, error) {
+		lock.Lock()
+		fetched = append(fetched, uri)
+		lock.Unlock()
+		return getMember(uri)
+	}); err != nil {
+		t.Fatalf("ApplyToCollection() error = %v", err)
+	}
+	if !reflect.DeepEqual(fetched, []string{"/redfish/v1/Chassis/2"}) {
+		t.Errorf("ApplyToCollection() fetched %v, want only the member of the page", fetched)
+	}
+}
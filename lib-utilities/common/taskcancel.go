@@ -0,0 +1,98 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	log "github.com/sirupsen/logrus"
+)
+
+// taskCancelRegistry holds the cancel functions of the tasks which are in progress in this service,
+// a cancel request received for the task cancels the context the task is running with
+var taskCancelRegistry = struct {
+	sync.Mutex
+	cancelFuncs map[string]context.CancelFunc
+}{cancelFuncs: make(map[string]context.CancelFunc)}
+
+// WithTaskCancel returns a copy of the parent context which is cancelled when a
+// cancel request for the task is received by the service.
+// The release function returned must be called once the task is done.
+func WithTaskCancel(parent context.Context, taskID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(parent)
+	taskCancelRegistry.Lock()
+	taskCancelRegistry.cancelFuncs[taskID] = cancel
+	taskCancelRegistry.Unlock()
+	release := func() {
+		taskCancelRegistry.Lock()
+		delete(taskCancelRegistry.cancelFuncs, taskID)
+		taskCancelRegistry.Unlock()
+		cancel()
+	}
+	return ctx, release
+}
+
+// CancelTaskContext cancels the context of the task, it returns false
+// if the task is not in progress in this service.
+// When it returns true, the operation in progress for the task aborts the job
+// started on the plugin and sets the final state of the task.
+func CancelTaskContext(taskID string) bool {
+	taskCancelRegistry.Lock()
+	defer taskCancelRegistry.Unlock()
+	cancel, ok := taskCancelRegistry.cancelFuncs[taskID]
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+// CallWithTaskCancel runs the call till it returns or the context of the task is cancelled,
+// whichever happens first, and returns the context error in the latter case.
+// The calls to the plugins do not take a context, so a call in flight is abandoned
+// on cancel and the results it sets must not be used.
+func CallWithTaskCancel(ctx context.Context, call func()) error {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		call()
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// AbortPluginTask requests the plugin to abort the job started for the cancelled task, by deleting
+// the task monitor of the job through deleteTaskMonitor, which returns the status code of the plugin.
+// Once the job is aborted, setCancelled sets the final state of the task and the Cancelled error
+// is returned, otherwise the failure is reported on the task.
+func AbortPluginTask(taskID, taskMonitorURI string, taskInfo *TaskUpdateInfo, deleteTaskMonitor func() (int32, error), setCancelled func()) error {
+	statusCode, err := deleteTaskMonitor()
+	if err != nil && statusCode != http.StatusNoContent {
+		errMsg := "Task is cancelled, but the job started on the plugin could not be aborted: " + err.Error()
+		log.Warn(errMsg)
+		GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		return fmt.Errorf(errMsg)
+	}
+	log.Info("Job monitored at " + taskMonitorURI + " is aborted on the plugin for the cancelled task " + taskID)
+	setCancelled()
+	return fmt.Errorf(Cancelled)
+}
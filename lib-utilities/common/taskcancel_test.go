@@ -0,0 +1,70 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestWithTaskCancel(t *testing.T) {
+	ctx, release := WithTaskCancel(context.Background(), "task1")
+	if CancelTaskContext("task2") {
+		t.Error("CancelTaskContext() returned true for a task which is not in progress")
+	}
+	if ctx.Err() != nil {
+		t.Error("task context is cancelled before a cancel request")
+	}
+	if !CancelTaskContext("task1") {
+		t.Error("CancelTaskContext() returned false for a task in progress")
+	}
+	if ctx.Err() != context.Canceled {
+		t.Errorf("task context error = %v, want %v", ctx.Err(), context.Canceled)
+	}
+	release()
+	if CancelTaskContext("task1") {
+		t.Error("CancelTaskContext() returned true for a released task")
+	}
+}
+
+func TestCallWithTaskCancel(t *testing.T) {
+	if err := CallWithTaskCancel(context.Background(), func() {}); err != nil {
+		t.Errorf("CallWithTaskCancel() = %v for a call which returned", err)
+	}
+	ctx, release := WithTaskCancel(context.Background(), "task1")
+	defer release()
+	block := make(chan struct{})
+	defer close(block)
+	CancelTaskContext("task1")
+	if err := CallWithTaskCancel(ctx, func() { <-block }); err != context.Canceled {
+		t.Errorf("CallWithTaskCancel() = %v, want %v for a call in flight on cancel", err, context.Canceled)
+	}
+}
+
+func TestAbortPluginTask(t *testing.T) {
+	cancelled := false
+	setCancelled := func() { cancelled = true }
+	err := AbortPluginTask("task1", "/taskmon/1", nil, func() (int32, error) { return http.StatusNoContent, nil }, setCancelled)
+	if err == nil || err.Error() != Cancelled || !cancelled {
+		t.Errorf("AbortPluginTask() = %v, want the task cancelled once the job is aborted", err)
+	}
+	cancelled = false
+	err = AbortPluginTask("task1", "/taskmon/1", nil, func() (int32, error) { return http.StatusNotFound, fmt.Errorf("not found") }, setCancelled)
+	if err == nil || err.Error() == Cancelled || cancelled {
+		t.Errorf("AbortPluginTask() = %v, want the failure reported when the job is not aborted", err)
+	}
+}
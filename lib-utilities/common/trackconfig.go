@@ -16,6 +16,8 @@
 package common
 
 import (
+	"time"
+
 	"github.com/fsnotify/fsnotify"
 	log "github.com/sirupsen/logrus"
 
@@ -23,6 +25,10 @@ import (
 )
 
 // TrackConfigFileChanges monitors the config changes using fsnotfiy
+// The certificate and key files referred by the config are also monitored,
+// so that a rotated certificate is reloaded along with the configuration.
+// The certificates replaced through the CertificateService are read from the db
+// and take precedence over the configured ones.
 func TrackConfigFileChanges(configFilePath string, eventChan chan<- interface{}) {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
@@ -32,6 +38,12 @@ func TrackConfigFileChanges(configFilePath string, eventChan chan<- interface{})
 	if err != nil {
 		log.Error(err.Error())
 	}
+	for _, filePath := range certificateFilePaths() {
+		if err = watcher.Add(filePath); err != nil {
+			log.Error(err.Error())
+		}
+	}
+	go trackStoredCertificates(eventChan)
 	go func() {
 		for {
 			select {
@@ -41,16 +53,26 @@ func TrackConfigFileChanges(configFilePath string, eventChan chan<- interface{})
 				}
 				if fileEvent.Op&fsnotify.Write == fsnotify.Write || fileEvent.Op&fsnotify.Remove == fsnotify.Remove {
 					log.Info("modified file:" + fileEvent.Name)
+					certs, err := getStoredCertificates()
+					if err != nil {
+						log.Error("error while trying to get the stored certificates: " + err.Error())
+					}
 					// update the odim config
 					config.TLSConfMutex.Lock()
 					if err := config.SetConfiguration(); err != nil {
 						log.Error("error while trying to set configuration: " + err.Error())
 					}
+					applyStoredCertificates(certs)
 					config.TLSConfMutex.Unlock()
-					eventChan <- "config file modified"
+					// the event is dropped when nobody is waiting for it,
+					// so that the watch is not blocked by the listener
+					select {
+					case eventChan <- "config file modified":
+					default:
+					}
 				}
 				//Reading file to continue the watch
-				watcher.Add(configFilePath)
+				watcher.Add(fileEvent.Name)
 			case err, _ := <-watcher.Errors:
 				if err != nil {
 					log.Error(err.Error())
@@ -60,3 +82,50 @@ func TrackConfigFileChanges(configFilePath string, eventChan chan<- interface{})
 		}
 	}()
 }
+
+// certificateFilePaths returns the paths of the certificates and keys in use,
+// which can be replaced through the CertificateService
+func certificateFilePaths() []string {
+	config.TLSConfMutex.RLock()
+	defer config.TLSConfMutex.RUnlock()
+	var paths []string
+	if config.Data.KeyCertConf != nil {
+		paths = append(paths, config.Data.KeyCertConf.RootCACertificatePath,
+			config.Data.KeyCertConf.RPCCertificatePath, config.Data.KeyCertConf.RPCPrivateKeyPath)
+	}
+	if config.Data.APIGatewayConf != nil {
+		paths = append(paths, config.Data.APIGatewayConf.CertificatePath, config.Data.APIGatewayConf.PrivateKeyPath)
+	}
+	var filePaths []string
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		if path != "" && !seen[path] {
+			seen[path] = true
+			filePaths = append(filePaths, path)
+		}
+	}
+	return filePaths
+}
+
+// trackStoredCertificates polls the db for the certificates replaced through the
+// CertificateService, so that every instance of the service starts using them
+func trackStoredCertificates(eventChan chan<- interface{}) {
+	for {
+		certs, err := getStoredCertificates()
+		if err != nil {
+			log.Error("error while trying to get the stored certificates: " + err.Error())
+		} else {
+			config.TLSConfMutex.Lock()
+			changed := applyStoredCertificates(certs)
+			config.TLSConfMutex.Unlock()
+			if changed {
+				log.Info("loaded the certificates replaced through the CertificateService")
+				select {
+				case eventChan <- "certificate replaced":
+				default:
+				}
+			}
+		}
+		time.Sleep(storedCertificatePollInterval)
+	}
+}
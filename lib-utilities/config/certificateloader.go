@@ -0,0 +1,90 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// CertificateLoader serves a key pair which can be replaced while the server is running.
+// Certificate and PrivateKey point to the configuration data which is reloaded on
+// file changes, the pair is parsed again whenever the data changes and the last
+// valid pair is kept till both the certificate and the key are updated.
+type CertificateLoader struct {
+	// Certificate contains the certifcate data to be loaded
+	Certificate *[]byte
+	// PrivateKey contains the private key data to be loaded
+	PrivateKey *[]byte
+
+	lock        sync.Mutex
+	certificate []byte
+	privateKey  []byte
+	current     *tls.Certificate
+}
+
+// NewCertificateLoader validates the key pair and returns a loader serving it
+func NewCertificateLoader(certificate, privateKey *[]byte) (*CertificateLoader, error) {
+	cert, err := tls.X509KeyPair(*certificate, *privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error: failed to load key pair: %v", err)
+	}
+	return &CertificateLoader{
+		Certificate: certificate,
+		PrivateKey:  privateKey,
+		certificate: append([]byte{}, *certificate...),
+		privateKey:  append([]byte{}, *privateKey...),
+		current:     &cert,
+	}, nil
+}
+
+// GetCertificate is to be used as tls.Config.GetCertificate
+func (c *CertificateLoader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return c.load(), nil
+}
+
+// GetClientCertificate is to be used as tls.Config.GetClientCertificate
+func (c *CertificateLoader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return c.load(), nil
+}
+
+func (c *CertificateLoader) load() *tls.Certificate {
+	TLSConfMutex.RLock()
+	certificate := append([]byte{}, *c.Certificate...)
+	privateKey := append([]byte{}, *c.PrivateKey...)
+	TLSConfMutex.RUnlock()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if bytes.Equal(certificate, c.certificate) && bytes.Equal(privateKey, c.privateKey) {
+		return c.current
+	}
+	// the data is remembered even when it is not a valid pair, so that
+	// a half written replacement is not parsed again on every handshake
+	c.certificate = certificate
+	c.privateKey = privateKey
+	cert, err := tls.X509KeyPair(certificate, privateKey)
+	if err != nil {
+		log.Warn("failed to load the updated key pair, continuing with the previous one: " + err.Error())
+		return c.current
+	}
+	log.Info("loaded the updated key pair")
+	c.current = &cert
+	return c.current
+}
@@ -0,0 +1,79 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func generateKeyPair(t *testing.T) ([]byte, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal("error: failed to generate key:", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "odim.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal("error: failed to create certificate:", err)
+	}
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return certificate, privateKey
+}
+
+func TestCertificateLoader(t *testing.T) {
+	certificate := append([]byte{}, hostCert...)
+	privateKey := append([]byte{}, hostPrivKey...)
+	if _, err := NewCertificateLoader(&certificate, &nonX509Certificate); err == nil {
+		t.Fatal("error: expected an invalid key pair to be rejected")
+	}
+	loader, err := NewCertificateLoader(&certificate, &privateKey)
+	if err != nil {
+		t.Fatal("error: NewCertificateLoader failed with", err)
+	}
+	initial, _ := loader.GetCertificate(nil)
+
+	// only the certificate is replaced, the previous pair must be served
+	newCertificate, newPrivateKey := generateKeyPair(t)
+	certificate = newCertificate
+	if cert, _ := loader.GetCertificate(nil); cert != initial {
+		t.Error("error: expected the previous key pair while the replacement is incomplete")
+	}
+
+	privateKey = newPrivateKey
+	cert, _ := loader.GetClientCertificate(nil)
+	if cert == initial {
+		t.Fatal("error: expected the replaced key pair to be served")
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal("error: failed to parse the served certificate:", err)
+	}
+	if leaf.Subject.CommonName != "odim.example.com" {
+		t.Errorf("error: unexpected certificate served: %v", leaf.Subject.CommonName)
+	}
+}
@@ -42,6 +42,7 @@ var Data configModel
 type configModel struct {
 	SouthBoundRequestTimeoutInSecs int                      `json:"SouthBoundRequestTimeoutInSecs"` // holds the value of south bound call request time out
 	ServerRediscoveryBatchSize     int                      `json:"ServerRediscoveryBatchSize"`
+	AggregationSourceImportWorkers int                      `json:"AggregationSourceImportWorkers"` // holds the number of aggregation sources added in parallel by a bulk import
 	FirmwareVersion                string                   `json:"FirmwareVersion"`
 	RootServiceUUID                string                   `json:"RootServiceUUID"` //static uuid used for root service
 	SearchAndFilterSchemaPath      string                   `json:"SearchAndFilterSchemaPath"`
@@ -61,6 +62,9 @@ type configModel struct {
 	SupportedPluginTypes           []string                 `json:"SupportedPluginTypes"`
 	ConnectionMethodConf           []ConnectionMethodConf   `json:"ConnectionMethodConf"`
 	EventConf                      *EventConf               `json:"EventConf"`
+	SNMPConf                       *SNMPConf                `json:"SNMPConf"`
+	TaskConf                       *TaskConf                `json:"TaskConf"`
+	LicenseConf                    *LicenseConf             `json:"LicenseConf"`
 	ResourceRateLimit              []string                 `json:"ResourceRateLimit"`
 	RequestLimitCountPerSession    int                      `json:"RequestLimitCountPerSession"`
 	SessionLimitCountPerUser       int                      `json:"SessionLimitCountPerUser"`
@@ -68,6 +72,8 @@ type configModel struct {
 
 // DBConf holds all DB related configurations
 type DBConf struct {
+	Backend                       string `json:"Backend"`
+	EmbeddedDBPath                string `json:"EmbeddedDBPath"`
 	Protocol                      string `json:"Protocol"`
 	InMemoryHost                  string `json:"InMemoryHost"`
 	InMemoryPort                  string `json:"InMemoryPort"`
@@ -177,8 +183,31 @@ type ConnectionMethodConf struct {
 
 // EventConf stores all inforamtion related to event delivery configurations
 type EventConf struct {
-	DeliveryRetryAttempts        int `json:"DeliveryRetryAttempts"`        // holds value of retrying event posting to destination
-	DeliveryRetryIntervalSeconds int `json:"DeliveryRetryIntervalSeconds"` // holds value of retrying events posting in interval
+	DeliveryRetryAttempts           int `json:"DeliveryRetryAttempts"`           // holds value of retrying event posting to destination
+	DeliveryRetryIntervalSeconds    int `json:"DeliveryRetryIntervalSeconds"`    // holds value of retrying events posting in interval
+	DeliveryRetryMaxIntervalSeconds int `json:"DeliveryRetryMaxIntervalSeconds"` // holds the upper limit of the retry interval while backing off
+	SSEReplayBufferSize             int `json:"SSEReplayBufferSize"`             // holds the number of recent events kept for resuming the server-sent event streams
+}
+
+// SNMPConf stores all information related to the SNMP aggregation sources
+type SNMPConf struct {
+	TrapListenerAddress    string `json:"TrapListenerAddress"`    // holds the UDP address on which the SNMP trap receiver listens
+	PollingFrequencyInMins int    `json:"PollingFrequencyInMins"` // holds value of duration in which the SNMP aggregation sources are polled, value will be in minutes
+	ResponseTimeoutInSecs  int    `json:"ResponseTimeoutInSecs"`  // holds value of duration in which it need wait for an SNMP response, value will be in seconds
+	MaxRetryAttempt        int    `json:"MaxRetryAttempt"`        // holds value of number of retries of an unanswered SNMP request
+}
+
+// TaskConf stores all information related to the retention of completed tasks
+type TaskConf struct {
+	TaskAutoDeleteTimeoutMinutes int `json:"TaskAutoDeleteTimeoutMinutes"` // holds value of duration after which a completed task is deleted, value will be in minutes
+	MaxCompletedTasksPerUser     int `json:"MaxCompletedTasksPerUser"`     // holds the maximum number of completed tasks retained for a user
+	TaskArchiveRetentionDays     int `json:"TaskArchiveRetentionDays"`     // holds value of duration for which the deleted tasks are kept for export, value will be in days
+}
+
+// LicenseConf stores all information related to the tracking of the license expiry
+type LicenseConf struct {
+	ExpirationWarningDays     int `json:"ExpirationWarningDays"`     // holds value of days before the expiry of a license from which it is reported as expiring
+	ExpiryCheckIntervalInMins int `json:"ExpiryCheckIntervalInMins"` // holds value of interval between the checks of the license expiry, value will be in minutes
 }
 
 // SetConfiguration will extract the config data from file
@@ -237,6 +266,9 @@ func ValidateConfiguration() error {
 	checkURLTranslation()
 	checkPluginStatusPolling()
 	checkExecPriorityDelayConf()
+	checkSNMPConf()
+	checkTaskConf()
+	checkLicenseConf()
 
 	return nil
 }
@@ -274,6 +306,20 @@ func checkDBConf() error {
 	if Data.DBConf == nil {
 		return fmt.Errorf("error: DBConf is not provided")
 	}
+	switch Data.DBConf.Backend {
+	case "":
+		Data.DBConf.Backend = RedisDBBackend
+	case RedisDBBackend:
+	case EmbeddedDBBackend:
+		if Data.DBConf.EmbeddedDBPath == "" {
+			log.Warn("No value configured for EmbeddedDBPath, setting default value")
+			Data.DBConf.EmbeddedDBPath = DefaultEmbeddedDBPath
+		}
+		// embedded DB is a single process store, which does not need the Redis configurations
+		return nil
+	default:
+		return fmt.Errorf("error: invalid value configured for DB Backend: %s", Data.DBConf.Backend)
+	}
 	if Data.DBConf.Protocol != DefaultDBProtocol {
 		log.Warn("Incorrect value configured for DB Protocol, setting default value")
 		Data.DBConf.Protocol = DefaultDBProtocol
@@ -572,6 +618,35 @@ func checkPluginStatusPolling() {
 	}
 }
 
+func checkSNMPConf() {
+	if Data.SNMPConf == nil {
+		log.Warn("SNMPConf not provided, setting default value")
+		Data.SNMPConf = &SNMPConf{
+			TrapListenerAddress:    DefaultSNMPTrapListenerAddress,
+			PollingFrequencyInMins: DefaultSNMPPollingFrequencyInMins,
+			ResponseTimeoutInSecs:  DefaultSNMPResponseTimeoutInSecs,
+			MaxRetryAttempt:        DefaultSNMPMaxRetryAttempt,
+		}
+		return
+	}
+	if Data.SNMPConf.TrapListenerAddress == "" {
+		log.Warn("No value found for TrapListenerAddress, setting default value")
+		Data.SNMPConf.TrapListenerAddress = DefaultSNMPTrapListenerAddress
+	}
+	if Data.SNMPConf.PollingFrequencyInMins <= 0 {
+		log.Warn("No value found for SNMP PollingFrequencyInMins, setting default value")
+		Data.SNMPConf.PollingFrequencyInMins = DefaultSNMPPollingFrequencyInMins
+	}
+	if Data.SNMPConf.ResponseTimeoutInSecs <= 0 {
+		log.Warn("No value found for SNMP ResponseTimeoutInSecs, setting default value")
+		Data.SNMPConf.ResponseTimeoutInSecs = DefaultSNMPResponseTimeoutInSecs
+	}
+	if Data.SNMPConf.MaxRetryAttempt < 0 {
+		log.Warn("Invalid value found for SNMP MaxRetryAttempt, setting default value")
+		Data.SNMPConf.MaxRetryAttempt = DefaultSNMPMaxRetryAttempt
+	}
+}
+
 func checkExecPriorityDelayConf() {
 	if Data.ExecPriorityDelayConf == nil {
 		log.Warn("ExecPriorityDelayConf not provided, setting default value")
@@ -640,8 +715,10 @@ func checkEventConf() error {
 	if Data.EventConf == nil {
 		log.Warn("EventConf not provided, setting default value")
 		Data.EventConf = &EventConf{
-			DeliveryRetryAttempts:        DefaultDeliveryRetryAttempts,
-			DeliveryRetryIntervalSeconds: DefaultDeliveryRetryIntervalSeconds,
+			DeliveryRetryAttempts:           DefaultDeliveryRetryAttempts,
+			DeliveryRetryIntervalSeconds:    DefaultDeliveryRetryIntervalSeconds,
+			DeliveryRetryMaxIntervalSeconds: DefaultDeliveryRetryMaxIntervalSeconds,
+			SSEReplayBufferSize:             DefaultSSEReplayBufferSize,
 		}
 		return nil
 	}
@@ -653,6 +730,17 @@ func checkEventConf() error {
 		log.Warn("No value found for DeliveryRetryIntervalSeconds, setting default value")
 		Data.EventConf.DeliveryRetryIntervalSeconds = DefaultDeliveryRetryIntervalSeconds
 	}
+	if Data.EventConf.DeliveryRetryMaxIntervalSeconds < Data.EventConf.DeliveryRetryIntervalSeconds {
+		log.Warn("DeliveryRetryMaxIntervalSeconds is less than DeliveryRetryIntervalSeconds, setting default value")
+		Data.EventConf.DeliveryRetryMaxIntervalSeconds = DefaultDeliveryRetryMaxIntervalSeconds
+		if Data.EventConf.DeliveryRetryMaxIntervalSeconds < Data.EventConf.DeliveryRetryIntervalSeconds {
+			Data.EventConf.DeliveryRetryMaxIntervalSeconds = Data.EventConf.DeliveryRetryIntervalSeconds
+		}
+	}
+	if Data.EventConf.SSEReplayBufferSize <= 0 {
+		log.Warn("No value found for SSEReplayBufferSize, setting default value")
+		Data.EventConf.SSEReplayBufferSize = DefaultSSEReplayBufferSize
+	}
 	return nil
 }
 
@@ -668,3 +756,46 @@ func checkResourceRateLimit() error {
 	}
 	return nil
 }
+
+func checkTaskConf() {
+	if Data.TaskConf == nil {
+		log.Warn("TaskConf not provided, setting default value")
+		Data.TaskConf = &TaskConf{
+			TaskAutoDeleteTimeoutMinutes: DefaultTaskAutoDeleteTimeoutMinutes,
+			MaxCompletedTasksPerUser:     DefaultMaxCompletedTasksPerUser,
+			TaskArchiveRetentionDays:     DefaultTaskArchiveRetentionDays,
+		}
+		return
+	}
+	if Data.TaskConf.TaskAutoDeleteTimeoutMinutes <= 0 {
+		log.Warn("No value found for TaskAutoDeleteTimeoutMinutes, setting default value")
+		Data.TaskConf.TaskAutoDeleteTimeoutMinutes = DefaultTaskAutoDeleteTimeoutMinutes
+	}
+	if Data.TaskConf.MaxCompletedTasksPerUser <= 0 {
+		log.Warn("No value found for MaxCompletedTasksPerUser, setting default value")
+		Data.TaskConf.MaxCompletedTasksPerUser = DefaultMaxCompletedTasksPerUser
+	}
+	if Data.TaskConf.TaskArchiveRetentionDays <= 0 {
+		log.Warn("No value found for TaskArchiveRetentionDays, setting default value")
+		Data.TaskConf.TaskArchiveRetentionDays = DefaultTaskArchiveRetentionDays
+	}
+}
+
+func checkLicenseConf() {
+	if Data.LicenseConf == nil {
+		log.Warn("LicenseConf not provided, setting default value")
+		Data.LicenseConf = &LicenseConf{
+			ExpirationWarningDays:     DefaultLicenseExpirationWarningDays,
+			ExpiryCheckIntervalInMins: DefaultLicenseExpiryCheckIntervalInMins,
+		}
+		return
+	}
+	if Data.LicenseConf.ExpirationWarningDays <= 0 {
+		log.Warn("No value found for ExpirationWarningDays, setting default value")
+		Data.LicenseConf.ExpirationWarningDays = DefaultLicenseExpirationWarningDays
+	}
+	if Data.LicenseConf.ExpiryCheckIntervalInMins <= 0 {
+		log.Warn("No value found for ExpiryCheckIntervalInMins, setting default value")
+		Data.LicenseConf.ExpiryCheckIntervalInMins = DefaultLicenseExpiryCheckIntervalInMins
+	}
+}
@@ -611,3 +611,216 @@ func TestValidateConfigurationForEventConf(t *testing.T) {
 	}
 	os.Remove(sampleFileForTest)
 }
+
+func TestCheckEventConfMaxInterval(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventConf EventConf
+		want      int
+	}{
+		{
+			name:      "max interval not configured",
+			eventConf: EventConf{DeliveryRetryAttempts: 3, DeliveryRetryIntervalSeconds: 60},
+			want:      DefaultDeliveryRetryMaxIntervalSeconds,
+		},
+		{
+			name:      "max interval configured",
+			eventConf: EventConf{DeliveryRetryAttempts: 3, DeliveryRetryIntervalSeconds: 60, DeliveryRetryMaxIntervalSeconds: 600},
+			want:      600,
+		},
+		{
+			name:      "retry interval more than the default max interval",
+			eventConf: EventConf{DeliveryRetryAttempts: 3, DeliveryRetryIntervalSeconds: 7200, DeliveryRetryMaxIntervalSeconds: 60},
+			want:      7200,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventConf := tt.eventConf
+			Data.EventConf = &eventConf
+			if err := checkEventConf(); err != nil {
+				t.Errorf("checkEventConf() error = %v", err)
+			}
+			if Data.EventConf.DeliveryRetryMaxIntervalSeconds != tt.want {
+				t.Errorf("checkEventConf() DeliveryRetryMaxIntervalSeconds = %v, want %v", Data.EventConf.DeliveryRetryMaxIntervalSeconds, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSNMPConf(t *testing.T) {
+	tests := []struct {
+		name     string
+		snmpConf *SNMPConf
+		want     SNMPConf
+	}{
+		{
+			name:     "SNMP conf not provided",
+			snmpConf: nil,
+			want: SNMPConf{
+				TrapListenerAddress:    DefaultSNMPTrapListenerAddress,
+				PollingFrequencyInMins: DefaultSNMPPollingFrequencyInMins,
+				ResponseTimeoutInSecs:  DefaultSNMPResponseTimeoutInSecs,
+				MaxRetryAttempt:        DefaultSNMPMaxRetryAttempt,
+			},
+		},
+		{
+			name:     "invalid values configured",
+			snmpConf: &SNMPConf{MaxRetryAttempt: -1},
+			want: SNMPConf{
+				TrapListenerAddress:    DefaultSNMPTrapListenerAddress,
+				PollingFrequencyInMins: DefaultSNMPPollingFrequencyInMins,
+				ResponseTimeoutInSecs:  DefaultSNMPResponseTimeoutInSecs,
+				MaxRetryAttempt:        DefaultSNMPMaxRetryAttempt,
+			},
+		},
+		{
+			name:     "valid values configured",
+			snmpConf: &SNMPConf{TrapListenerAddress: ":162", PollingFrequencyInMins: 1, ResponseTimeoutInSecs: 10},
+			want:     SNMPConf{TrapListenerAddress: ":162", PollingFrequencyInMins: 1, ResponseTimeoutInSecs: 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Data.SNMPConf = tt.snmpConf
+			checkSNMPConf()
+			if *Data.SNMPConf != tt.want {
+				t.Errorf("checkSNMPConf() = %v, want %v", *Data.SNMPConf, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckTaskConf(t *testing.T) {
+	defaults := TaskConf{
+		TaskAutoDeleteTimeoutMinutes: DefaultTaskAutoDeleteTimeoutMinutes,
+		MaxCompletedTasksPerUser:     DefaultMaxCompletedTasksPerUser,
+		TaskArchiveRetentionDays:     DefaultTaskArchiveRetentionDays,
+	}
+	tests := []struct {
+		name     string
+		taskConf *TaskConf
+		want     TaskConf
+	}{
+		{
+			name:     "TaskConf not configured",
+			taskConf: nil,
+			want:     defaults,
+		},
+		{
+			name:     "invalid values configured",
+			taskConf: &TaskConf{TaskAutoDeleteTimeoutMinutes: -1, MaxCompletedTasksPerUser: 0, TaskArchiveRetentionDays: -5},
+			want:     defaults,
+		},
+		{
+			name:     "valid values configured",
+			taskConf: &TaskConf{TaskAutoDeleteTimeoutMinutes: 60, MaxCompletedTasksPerUser: 10, TaskArchiveRetentionDays: 7},
+			want:     TaskConf{TaskAutoDeleteTimeoutMinutes: 60, MaxCompletedTasksPerUser: 10, TaskArchiveRetentionDays: 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Data.TaskConf = tt.taskConf
+			checkTaskConf()
+			if *Data.TaskConf != tt.want {
+				t.Errorf("checkTaskConf() = %v, want %v", *Data.TaskConf, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckLicenseConf(t *testing.T) {
+	defaults := LicenseConf{
+		ExpirationWarningDays:     DefaultLicenseExpirationWarningDays,
+		ExpiryCheckIntervalInMins: DefaultLicenseExpiryCheckIntervalInMins,
+	}
+	tests := []struct {
+		name        string
+		licenseConf *LicenseConf
+		want        LicenseConf
+	}{
+		{
+			name:        "LicenseConf not configured",
+			licenseConf: nil,
+			want:        defaults,
+		},
+		{
+			name:        "invalid values configured",
+			licenseConf: &LicenseConf{ExpirationWarningDays: -1, ExpiryCheckIntervalInMins: 0},
+			want:        defaults,
+		},
+		{
+			name:        "valid values configured",
+			licenseConf: &LicenseConf{ExpirationWarningDays: 14, ExpiryCheckIntervalInMins: 10},
+			want:        LicenseConf{ExpirationWarningDays: 14, ExpiryCheckIntervalInMins: 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Data.LicenseConf = tt.licenseConf
+			checkLicenseConf()
+			if *Data.LicenseConf != tt.want {
+				t.Errorf("checkLicenseConf() = %v, want %v", *Data.LicenseConf, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDBConfBackend(t *testing.T) {
+	tests := []struct {
+		name     string
+		dbConf   DBConf
+		wantPath string
+		wantErr  bool
+	}{
+		{
+			name:    "Invalid value for Backend",
+			dbConf:  DBConf{Backend: "Mongo"},
+			wantErr: true,
+		},
+		{
+			name:     "Embedded backend without path, setting to default",
+			dbConf:   DBConf{Backend: EmbeddedDBBackend},
+			wantPath: DefaultEmbeddedDBPath,
+			wantErr:  false,
+		},
+		{
+			name:     "Embedded backend with path",
+			dbConf:   DBConf{Backend: EmbeddedDBBackend, EmbeddedDBPath: "/tmp/odimra"},
+			wantPath: "/tmp/odimra",
+			wantErr:  false,
+		},
+		{
+			name:    "Redis backend without hosts",
+			dbConf:  DBConf{Backend: RedisDBBackend},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbConf := tt.dbConf
+			Data.DBConf = &dbConf
+			if err := checkDBConf(); (err != nil) != tt.wantErr {
+				t.Errorf("checkDBConf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if Data.DBConf.EmbeddedDBPath != tt.wantPath {
+				t.Errorf("checkDBConf() EmbeddedDBPath = %v, want %v", Data.DBConf.EmbeddedDBPath, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestCheckEventConfSSEReplayBufferSize(t *testing.T) {
+	Data.EventConf = &EventConf{DeliveryRetryAttempts: 3, DeliveryRetryIntervalSeconds: 60}
+	if err := checkEventConf(); err != nil {
+		t.Errorf("checkEventConf() error = %v", err)
+	}
+	if Data.EventConf.SSEReplayBufferSize != DefaultSSEReplayBufferSize {
+		t.Errorf("checkEventConf() SSEReplayBufferSize = %v, want %v", Data.EventConf.SSEReplayBufferSize, DefaultSSEReplayBufferSize)
+	}
+	Data.EventConf.SSEReplayBufferSize = 50
+	checkEventConf()
+	if Data.EventConf.SSEReplayBufferSize != 50 {
+		t.Errorf("checkEventConf() SSEReplayBufferSize = %v, want 50", Data.EventConf.SSEReplayBufferSize)
+	}
+}
@@ -42,6 +42,12 @@ const (
 	DefaultDBMaxActiveConns = 120
 	// DefaultDBMaxIdleConns - default MaxIdleConns value
 	DefaultDBMaxIdleConns = 10
+	// RedisDBBackend - DB backend type for the Redis servers configured in DBConf
+	RedisDBBackend = "Redis"
+	// EmbeddedDBBackend - DB backend type for the embedded file store of a single process
+	EmbeddedDBBackend = "Embedded"
+	// DefaultEmbeddedDBPath - default EmbeddedDBPath value
+	DefaultEmbeddedDBPath = "/var/lib/odimra/db"
 	// DefaultAuthFailureLoggingThreshold - default AuthFailureLoggingThreshold value
 	DefaultAuthFailureLoggingThreshold = 3
 	// DefaultAccountLockoutThreshold - default AccountLockoutThreshold value
@@ -94,6 +100,28 @@ const (
 	DefaultDeliveryRetryAttempts = 3
 	// DefaultDeliveryRetryIntervalSeconds - default DeliveryRetryIntervalSeconds value
 	DefaultDeliveryRetryIntervalSeconds = 60
+	// DefaultDeliveryRetryMaxIntervalSeconds - default DeliveryRetryMaxIntervalSeconds value
+	DefaultDeliveryRetryMaxIntervalSeconds = 3600
+	// DefaultSSEReplayBufferSize - default SSEReplayBufferSize value
+	DefaultSSEReplayBufferSize = 1000
+	// DefaultSNMPTrapListenerAddress - default TrapListenerAddress value
+	DefaultSNMPTrapListenerAddress = ":1162"
+	// DefaultSNMPPollingFrequencyInMins - default SNMP PollingFrequencyInMins value
+	DefaultSNMPPollingFrequencyInMins = 5
+	// DefaultSNMPResponseTimeoutInSecs - default SNMP ResponseTimeoutInSecs value
+	DefaultSNMPResponseTimeoutInSecs = 5
+	// DefaultSNMPMaxRetryAttempt - default SNMP MaxRetryAttempt value
+	DefaultSNMPMaxRetryAttempt = 2
+	// DefaultTaskAutoDeleteTimeoutMinutes - default TaskAutoDeleteTimeoutMinutes value
+	DefaultTaskAutoDeleteTimeoutMinutes = 1440
+	// DefaultMaxCompletedTasksPerUser - default MaxCompletedTasksPerUser value
+	DefaultMaxCompletedTasksPerUser = 1000
+	// DefaultTaskArchiveRetentionDays - default TaskArchiveRetentionDays value
+	DefaultTaskArchiveRetentionDays = 30
+	// DefaultLicenseExpirationWarningDays - default LicenseConf ExpirationWarningDays value
+	DefaultLicenseExpirationWarningDays = 30
+	// DefaultLicenseExpiryCheckIntervalInMins - default LicenseConf ExpiryCheckIntervalInMins value
+	DefaultLicenseExpiryCheckIntervalInMins = 60
 )
 
 var (
@@ -103,13 +103,14 @@ func (config *HTTPConfig) GetHTTPServerObj() (*http.Server, error) {
 func (config *HTTPConfig) LoadCertificates(tlsConfig *tls.Config) error {
 	// for client mode interaction certificates will not be required and
 	// just CA certificate needs to be loaded for server validation
+	// the key pair is served through GetCertificate, so that a certificate
+	// replaced at runtime is used for the new connections
 	if config.loadCertificates {
-		cert, err := tls.X509KeyPair(*config.Certificate, *config.PrivateKey)
+		loader, err := NewCertificateLoader(config.Certificate, config.PrivateKey)
 		if err != nil {
-			return fmt.Errorf("error: failed to load key pair: %v", err)
+			return err
 		}
-		tlsConfig.Certificates = []tls.Certificate{cert}
-		tlsConfig.BuildNameToCertificate()
+		tlsConfig.GetCertificate = loader.GetCertificate
 	}
 
 	capool := x509.NewCertPool()
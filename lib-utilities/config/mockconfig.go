@@ -222,6 +222,7 @@ func SetUpMockConfig(t *testing.T) error {
 	Data.FirmwareVersion = "1.0"
 	Data.SouthBoundRequestTimeoutInSecs = 10
 	Data.ServerRediscoveryBatchSize = 10
+	Data.AggregationSourceImportWorkers = 5
 	path := strings.SplitAfter(workingDir, "ODIM")
 	var basePath string
 	if len(path) > 2 {
@@ -309,6 +310,21 @@ func SetUpMockConfig(t *testing.T) error {
 		StartUpResouceBatchSize: 1,
 		PollingFrequencyInMins:  1,
 	}
+	Data.SNMPConf = &SNMPConf{
+		TrapListenerAddress:    "127.0.0.1:0",
+		PollingFrequencyInMins: 1,
+		ResponseTimeoutInSecs:  1,
+		MaxRetryAttempt:        0,
+	}
+	Data.TaskConf = &TaskConf{
+		TaskAutoDeleteTimeoutMinutes: 1440,
+		MaxCompletedTasksPerUser:     1000,
+		TaskArchiveRetentionDays:     30,
+	}
+	Data.LicenseConf = &LicenseConf{
+		ExpirationWarningDays:     30,
+		ExpiryCheckIntervalInMins: 60,
+	}
 	Data.ExecPriorityDelayConf = &ExecPriorityDelayConf{
 		MinResetPriority:    1,
 		MaxResetPriority:    10,
@@ -338,8 +354,10 @@ func SetUpMockConfig(t *testing.T) error {
 		},
 	}
 	Data.EventConf = &EventConf{
-		DeliveryRetryAttempts:        1,
-		DeliveryRetryIntervalSeconds: 1,
+		DeliveryRetryAttempts:           1,
+		DeliveryRetryIntervalSeconds:    1,
+		DeliveryRetryMaxIntervalSeconds: 4,
+		SSEReplayBufferSize:             3,
 	}
 	SetVerifyPeer(Data.TLSConf.VerifyPeer)
 	SetTLSMinVersion(Data.TLSConf.MinVersion)
@@ -37,6 +37,8 @@ const (
 	actionParameterNotSupportedArgCount = 2
 	propertyUnknownArgCount             = 1
 	propertyValueConflictArgCount       = 2
+	queryParameterValueFormatArgCount   = 2
+	queryParameterOutOfRangeArgCount    = 3
 )
 
 // validateParamTypes will compare string slices and returns bool
@@ -281,6 +283,28 @@ func (a *Args) CreateGenericErrorResponse() CommonError {
 					Severity:   "Warning",
 					Resolution: "Remove the query parameters and resubmit the request if the operation failed.",
 				})
+		case QueryParameterValueFormatError:
+			validateMessageArgs(errArg.MessageArgs, []string{"string", "string"}, queryParameterValueFormatArgCount)
+			e.Error.MessageExtendedInfo = append(e.Error.MessageExtendedInfo,
+				Msg{
+					OdataType:   ErrorMessageOdataType,
+					MessageID:   errArg.StatusMessage,
+					Message:     fmt.Sprintf("The value '%v' for the parameter %v is of a different format than the parameter can accept. %v", errArg.MessageArgs[0], errArg.MessageArgs[1], errArg.ErrorMessage),
+					Severity:    "Warning",
+					MessageArgs: errArg.MessageArgs,
+					Resolution:  "Correct the value for the query parameter in the request and resubmit the request if the operation failed.",
+				})
+		case QueryParameterOutOfRange:
+			validateMessageArgs(errArg.MessageArgs, []string{"string", "string", "string"}, queryParameterOutOfRangeArgCount)
+			e.Error.MessageExtendedInfo = append(e.Error.MessageExtendedInfo,
+				Msg{
+					OdataType:   ErrorMessageOdataType,
+					MessageID:   errArg.StatusMessage,
+					Message:     fmt.Sprintf("The value '%v' for the query parameter %v is out of range %v. %v", errArg.MessageArgs[0], errArg.MessageArgs[1], errArg.MessageArgs[2], errArg.ErrorMessage),
+					Severity:    "Warning",
+					MessageArgs: errArg.MessageArgs,
+					Resolution:  "Reduce the value for the query parameter to a value that is within range, such as a start or count value that is within bounds of the number of resources in a collection or a page that is within the range of valid pages.",
+				})
 		case ActionParameterNotSupported:
 			validateMessageArgs(errArg.MessageArgs, []string{"string", "string"}, actionParameterNotSupportedArgCount)
 			e.Error.MessageExtendedInfo = append(e.Error.MessageExtendedInfo,
@@ -348,6 +372,16 @@ func (a *Args) CreateGenericErrorResponse() CommonError {
 					Severity:   "Critical",
 					Resolution: "Provide a valid URI and resubmit the request.",
 				})
+		case PasswordChangeRequired:
+			e.Error.MessageExtendedInfo = append(e.Error.MessageExtendedInfo,
+				Msg{
+					OdataType:   ErrorMessageOdataType,
+					MessageID:   errArg.StatusMessage,
+					Message:     fmt.Sprintf("The password provided for this account must be changed before access is granted. PATCH the Password property for this account located at the target URI %v to complete this process.", errArg.MessageArgs[0]),
+					Severity:    "Critical",
+					MessageArgs: errArg.MessageArgs,
+					Resolution:  "Change the password for this account using a PATCH to the Password property at the URI provided.",
+				})
 		}
 	}
 	return e
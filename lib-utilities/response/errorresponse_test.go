@@ -550,6 +550,66 @@ func TestCreateErrorResponse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: QueryParameterValueFormatError,
+			args: Args{
+				Code:    QueryParameterValueFormatError,
+				Message: QueryParameterValueFormatError,
+				ErrorArgs: []ErrArgs{
+					ErrArgs{
+						StatusMessage: QueryParameterValueFormatError,
+						ErrorMessage:  errMsg,
+						MessageArgs:   []interface{}{"test1", "test2"},
+					},
+				},
+			},
+			want: CommonError{
+				Error: ErrorClass{
+					Code:    QueryParameterValueFormatError,
+					Message: QueryParameterValueFormatError,
+					MessageExtendedInfo: []Msg{
+						Msg{
+							OdataType:   ErrorMessageOdataType,
+							MessageID:   QueryParameterValueFormatError,
+							Message:     fmt.Sprintf("The value '%v' for the parameter %v is of a different format than the parameter can accept. %v", "test1", "test2", errMsg),
+							Severity:    "Warning",
+							MessageArgs: []interface{}{"test1", "test2"},
+							Resolution:  "Correct the value for the query parameter in the request and resubmit the request if the operation failed.",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: QueryParameterOutOfRange,
+			args: Args{
+				Code:    QueryParameterOutOfRange,
+				Message: QueryParameterOutOfRange,
+				ErrorArgs: []ErrArgs{
+					ErrArgs{
+						StatusMessage: QueryParameterOutOfRange,
+						ErrorMessage:  errMsg,
+						MessageArgs:   []interface{}{"test1", "test2", "test3"},
+					},
+				},
+			},
+			want: CommonError{
+				Error: ErrorClass{
+					Code:    QueryParameterOutOfRange,
+					Message: QueryParameterOutOfRange,
+					MessageExtendedInfo: []Msg{
+						Msg{
+							OdataType:   ErrorMessageOdataType,
+							MessageID:   QueryParameterOutOfRange,
+							Message:     fmt.Sprintf("The value '%v' for the query parameter %v is out of range %v. %v", "test1", "test2", "test3", errMsg),
+							Severity:    "Warning",
+							MessageArgs: []interface{}{"test1", "test2", "test3"},
+							Resolution:  "Reduce the value for the query parameter to a value that is within range, such as a start or count value that is within bounds of the number of resources in a collection or a page that is within the range of valid pages.",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: NoOperation,
 			args: Args{
@@ -606,6 +666,36 @@ func TestCreateErrorResponse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: PasswordChangeRequired,
+			args: Args{
+				Code:    GeneralError,
+				Message: "",
+				ErrorArgs: []ErrArgs{
+					ErrArgs{
+						StatusMessage: PasswordChangeRequired,
+						ErrorMessage:  errMsg,
+						MessageArgs:   []interface{}{"/redfish/v1/AccountService/Accounts/admin"},
+					},
+				},
+			},
+			want: CommonError{
+				Error: ErrorClass{
+					Code:    GeneralError,
+					Message: "An error has occurred. See ExtendedInfo for more information.",
+					MessageExtendedInfo: []Msg{
+						Msg{
+							OdataType:   ErrorMessageOdataType,
+							MessageID:   PasswordChangeRequired,
+							Message:     "The password provided for this account must be changed before access is granted. PATCH the Password property for this account located at the target URI /redfish/v1/AccountService/Accounts/admin to complete this process.",
+							Severity:    "Critical",
+							MessageArgs: []interface{}{"/redfish/v1/AccountService/Accounts/admin"},
+							Resolution:  "Change the password for this account using a PATCH to the Password property at the URI provided.",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
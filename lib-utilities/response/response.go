@@ -61,6 +61,10 @@ const (
 	QueryCombinationInvalid = BaseVersion + "QueryCombinationInvalid"
 	// QueryNotSupported defines the status message at the time of not supported query
 	QueryNotSupported = BaseVersion + "QueryNotSupported"
+	// QueryParameterValueFormatError defines the status message at the time of query parameter with value of unsupported format
+	QueryParameterValueFormatError = BaseVersion + "QueryParameterValueFormatError"
+	// QueryParameterOutOfRange defines the status message at the time of query parameter with value out of the supported range
+	QueryParameterOutOfRange = BaseVersion + "QueryParameterOutOfRange"
 	// ResourceRemoved is the message for successful removal of resource
 	ResourceRemoved = "ResourceEvent.1.2.1.ResourceRemoved"
 	// ResourceCreated is the message for successful creation of resource
@@ -85,6 +89,8 @@ const (
 	SessionLimitExceeded = BaseVersion + "SessionLimitExceeded"
 	// InvalidURL defines the status message at the time of URL Not Found
 	InvalidURI = BaseVersion + "InvalidURI"
+	// PasswordChangeRequired indicates that the password of the account has to be changed before access is granted
+	PasswordChangeRequired = BaseVersion + "PasswordChangeRequired"
 )
 
 // Response holds the generic response from odimra
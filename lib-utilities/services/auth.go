@@ -82,6 +82,29 @@ func GetSessionUserName(sessionToken string) (string, error) {
 	return response.UserName, err
 }
 
+// GetSessionAccountProviderType will get the type of the account provider which authenticated
+// the user of the session token by rpc call to account-session service, it is empty for
+// the sessions of the accounts of ODIM
+func GetSessionAccountProviderType(sessionToken string) (string, error) {
+	conn, err := ODIMService.Client(AccountSession)
+	if err != nil {
+		return "", fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	asService := sessionproto.NewSessionClient(conn)
+	response, err := asService.GetSessionUserName(
+		context.TODO(),
+		&sessionproto.SessionRequest{
+			SessionToken: sessionToken,
+		},
+	)
+	if err != nil && response == nil {
+		log.Error("something went wrong with rpc call: " + err.Error())
+		return "", err
+	}
+	return response.AccountProviderType, err
+}
+
 // GetSessionUserRoleID will get user name from the session token by rpc call to account-session service
 func GetSessionUserRoleID(sessionToken string) (string, error) {
 	conn, err := ODIMService.Client(AccountSession)
@@ -124,3 +147,55 @@ func GetUserDetails(sessionToken string) (string, string) {
 	}
 	return sessionUserName, sessionRoleID
 }
+
+// CreateJobSession will create a session for the owner of the scheduled job by rpc call
+// to account-session service, the session id and the session token used to run the job are returned
+func CreateJobSession(userName, jobURI string) (string, string, error) {
+	conn, err := ODIMService.Client(AccountSession)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	asService := sessionproto.NewSessionClient(conn)
+	response, err := asService.CreateJobSession(
+		context.TODO(),
+		&sessionproto.JobSessionRequest{
+			UserName: userName,
+			JobURI:   jobURI,
+		},
+	)
+	if err != nil && response == nil {
+		log.Error("something went wrong with rpc call: " + err.Error())
+		return "", "", err
+	}
+	if response.StatusCode != http.StatusCreated {
+		return "", "", fmt.Errorf("unable to create session for the job %s: %s", jobURI, response.StatusMessage)
+	}
+	return response.SessionId, response.Header["X-Auth-Token"], nil
+}
+
+// DeleteSession will delete the session by rpc call to account-session service,
+// the session is deleted using its own token
+func DeleteSession(sessionID, sessionToken string) error {
+	conn, err := ODIMService.Client(AccountSession)
+	if err != nil {
+		return fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	asService := sessionproto.NewSessionClient(conn)
+	response, err := asService.DeleteSession(
+		context.TODO(),
+		&sessionproto.SessionRequest{
+			SessionId:    sessionID,
+			SessionToken: sessionToken,
+		},
+	)
+	if err != nil && response == nil {
+		log.Error("something went wrong with rpc call: " + err.Error())
+		return err
+	}
+	if response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("unable to delete the session %s: %s", sessionID, response.StatusMessage)
+	}
+	return nil
+}
@@ -233,17 +233,18 @@ func (s *odimService) loadTLSCredentials(st serviceType) error {
 }
 
 func loadServerTLSConfig() (*tls.Config, error) {
-	cer, err := tls.X509KeyPair(
-		config.Data.KeyCertConf.RPCCertificate,
-		config.Data.KeyCertConf.RPCPrivateKey,
+	loader, err := config.NewCertificateLoader(
+		&config.Data.KeyCertConf.RPCCertificate,
+		&config.Data.KeyCertConf.RPCPrivateKey,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("While trying to load x509 key pair, got: %v", err)
 	}
 	return &tls.Config{
-		Certificates: []tls.Certificate{cer},
-		ClientAuth:   tls.NoClientCert,
-		ServerName:   config.Data.LocalhostFQDN,
+		GetCertificate:       loader.GetCertificate,
+		GetClientCertificate: loader.GetClientCertificate,
+		ClientAuth:           tls.NoClientCert,
+		ServerName:           config.Data.LocalhostFQDN,
 	}, nil
 }
 
@@ -277,8 +278,8 @@ func getTLSConfig() (*tls.Config, error) {
 		return nil, fmt.Errorf("Failed to load client tls: %v", err)
 	}
 	return &tls.Config{
-		RootCAs:      clientTLS.RootCAs,
-		Certificates: serverTLS.Certificates,
+		RootCAs:              clientTLS.RootCAs,
+		GetClientCertificate: serverTLS.GetClientCertificate,
 	}, nil
 }
 
@@ -330,7 +331,7 @@ func GetEnabledServiceList() map[string]bool {
 			if err == nil && len(resp.Kvs) > 0 {
 				data[microService] = true
 			}
-		case "TaskService":
+		case "TaskService", "JobService":
 			resp, err := kv.Get(context.TODO(), Tasks, clientv3.WithPrefix())
 			if err == nil && len(resp.Kvs) > 0 {
 				data[microService] = true
@@ -347,7 +348,7 @@ func GetEnabledServiceList() map[string]bool {
 				data[microService] = true
 			}
 
-		case "Managers":
+		case "Managers", "CertificateService":
 			resp, err := kv.Get(context.TODO(), Managers, clientv3.WithPrefix())
 			if err == nil && len(resp.Kvs) > 0 {
 				data[microService] = true
@@ -17,9 +17,11 @@ package services
 
 import (
 	"context"
+	"fmt"
 	log "github.com/sirupsen/logrus"
 	"time"
 
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
 	"github.com/golang/protobuf/ptypes"
 )
@@ -35,7 +37,8 @@ func CreateTask(sessionUserName string) (string, error) {
 	response, err := taskService.CreateTask(
 		context.TODO(),
 		&taskproto.CreateTaskRequest{
-			UserName: sessionUserName,
+			UserName:    sessionUserName,
+			ServiceName: ODIMService.serverName,
 		},
 	)
 	if err != nil && response == nil {
@@ -58,6 +61,7 @@ func CreateChildTask(sessionUserName string, parentTaskID string) (string, error
 		&taskproto.CreateTaskRequest{
 			UserName:     sessionUserName,
 			ParentTaskID: parentTaskID,
+			ServiceName:  ODIMService.serverName,
 		},
 	)
 	if err != nil && response == nil {
@@ -93,3 +97,35 @@ func UpdateTask(taskID string, taskState string, taskStatus string, percentCompl
 	)
 	return err
 }
+
+// TaskCanceller implements the TaskCancel service. Services running long running tasks
+// register it, so that a cancel request on a task reaches the operation in progress
+type TaskCanceller struct{}
+
+// CancelTask cancels the context of the task in progress in the service
+func (t *TaskCanceller) CancelTask(ctx context.Context, req *taskproto.CancelTaskRequest) (*taskproto.CancelTaskResponse, error) {
+	return &taskproto.CancelTaskResponse{
+		InProgress: common.CancelTaskContext(req.TaskID),
+	}, nil
+}
+
+// CancelTask function is to contact the service instance which owns the task through the rpc call,
+// it returns false if the task is not in progress in the service instance
+func CancelTask(serviceName string, taskID string) (bool, error) {
+	conn, err := ODIMService.Client(serviceName)
+	if err != nil {
+		return false, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	taskCancel := taskproto.NewTaskCancelClient(conn)
+	response, err := taskCancel.CancelTask(
+		context.TODO(),
+		&taskproto.CancelTaskRequest{
+			TaskID: taskID,
+		},
+	)
+	if err != nil {
+		return false, fmt.Errorf("rpc error while cancelling the task: %v", err)
+	}
+	return response.InProgress, nil
+}
@@ -17,8 +17,8 @@ package dphandler
 
 import (
 	"encoding/json"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/translation"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dpresponse"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
@@ -38,14 +38,13 @@ type DeviceClient struct {
 //ChangeSettings is generic function where we can do following operations on different call
 // 1. change bios settings
 // 2. change boot order settings
+// 3. change power limit of chassis
 func ChangeSettings(ctx iris.Context) {
 	//Get token from Request
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = translation.ToSouthBound(uri)
 	//Validating the token
 	if token != "" {
 		flag := TokenValidation(token)
@@ -16,14 +16,13 @@
 package dphandler
 
 import (
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/translation"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 	iris "github.com/kataras/iris/v12"
 	log "github.com/sirupsen/logrus"
 	"io/ioutil"
 	"net/http"
-	"strings"
 )
 
 // SetDefaultBootOrder : sets the defult boot order
@@ -45,9 +44,7 @@ func SetDefaultBootOrder(ctx iris.Context) {
 	var deviceDetails dpmodel.Device
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = translation.ToSouthBound(uri)
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
 	if err != nil {
@@ -106,8 +103,8 @@ func SetDefaultBootOrder(ctx iris.Context) {
 		return
 	}
 
-	//Subscribe to Events
-	resp, err := redfishClient.SetDefaultBootOrder(device, uri)
+	// the default boot order of the Dell servers is set with a PATCH on the action
+	resp, err := redfishClient.DeviceCall(device, uri, http.MethodPatch)
 	if err != nil {
 		errorMessage := "while trying to set default boot order, got: " + err.Error()
 		log.Error(errorMessage)
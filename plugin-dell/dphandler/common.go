@@ -22,7 +22,8 @@ import (
 	"net/http"
 	"strings"
 
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/northbound"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/translation"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 )
 
@@ -70,8 +71,10 @@ func queryDevice(uri string, device *dputilities.RedfishDevice, method string) (
 
 //replacing the request url with south bound translation URL
 func replaceURI(uri string) string {
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
-	return uri
+	return translation.ToSouthBound(uri)
+}
+
+//TokenValidation validates sent token with the list of plugin generated tokens
+func TokenValidation(token string) bool {
+	return northbound.TokenValidation(token)
 }
@@ -17,7 +17,7 @@ package dphandler
 
 import (
 	"encoding/json"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/translation"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 	iris "github.com/kataras/iris/v12"
@@ -33,9 +33,7 @@ func GetResource(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = translation.ToSouthBound(uri)
 	//Getting storageinstance from URI
 	storageInstance := ctx.Params().Get("id2")
 
@@ -110,9 +108,7 @@ func GetResource(ctx iris.Context) {
 	}
 	respData := string(body)
 	//replacing the response with north bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
-		respData = strings.Replace(respData, key, value, -1)
-	}
+	respData = translation.ToNorthBound(respData)
 	respData = convertToNorthBoundURI(respData, storageInstance)
 
 	//Transforming NetworkAdapters URI's
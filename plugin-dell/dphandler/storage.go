@@ -23,9 +23,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/events"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/translation"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dpresponse"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
@@ -39,9 +40,7 @@ func CreateVolume(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = translation.ToSouthBound(uri)
 
 	//Validating the token
 	if token != "" {
@@ -190,9 +189,7 @@ func DeleteVolume(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = translation.ToSouthBound(uri)
 
 	storageInstance := ctx.Params().Get("id2")
 	uri = convertToSouthBoundURI(uri, storageInstance)
@@ -307,15 +304,12 @@ func manualEvents(req common.MessageData, hostAddress string) {
 	request, _ := json.Marshal(req)
 	reqData := string(request)
 	//replacing the response with north bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
-		reqData = strings.Replace(reqData, key, value, -1)
-	}
+	reqData = translation.ToNorthBound(reqData)
 	event := common.Events{
 		IP:      hostAddress,
 		Request: []byte(reqData),
 	}
-	// Call writeEventToJobQueue to write events to worker pool
-	writeEventToJobQueue(event)
+	events.Forward(event)
 }
 
 // getVolumeCollection lists all the available volumes in the device
@@ -16,14 +16,13 @@
 package dphandler
 
 import (
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/translation"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 	iris "github.com/kataras/iris/v12"
 	log "github.com/sirupsen/logrus"
 	"io/ioutil"
 	"net/http"
-	"strings"
 )
 
 // SimpleUpdate updates the BMC resources
@@ -51,12 +50,9 @@ func SimpleUpdate(ctx iris.Context) {
 		return
 	}
 
-	var reqData string
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-		reqData = strings.Replace(string(deviceDetails.PostBody), key, value, -1)
-	}
+	uri = translation.ToSouthBound(uri)
+	reqData := translation.ToSouthBound(string(deviceDetails.PostBody))
 
 	device := &dputilities.RedfishDevice{
 		Host:     deviceDetails.Host,
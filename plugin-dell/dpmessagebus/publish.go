@@ -12,46 +12,25 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-//Package dpmessagebus ...
+// Package dpmessagebus ...
 package dpmessagebus
 
 import (
 	"encoding/json"
-	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
 	log "github.com/sirupsen/logrus"
 )
 
-// Publish ...
-func Publish(data interface{}) bool {
-	if data == nil {
-		log.Error("Invalid data on publishing events")
-		return false
-	}
-	event := data.(common.Events)
-
-	topic := config.Data.MessageBusConf.EmbQueue[0]
-	K, err := dc.Communicator(dc.KAFKA, config.Data.MessageBusConf.MessageBusConfigFilePath, topic)
-	if err != nil {
-		log.Error("Unable communicate with kafka: " + err.Error())
-		return false
-	}
-
-	// Since we are deleting the first event from the eventlist,
-	// processing the first event
+// FormatEvent is the event Formatter of the Dell servers. The events are forwarded
+// to the message bus as they are posted, once they are found to be event messages.
+func FormatEvent(event common.Events) (common.Events, error) {
 	var message common.MessageData
-	err = json.Unmarshal(event.Request, &message)
-	if err != nil {
-		log.Error("Failed to unmarshal the event, got: " + err.Error())
-		return false
-	}
-	if err := K.Distribute(event); err != nil {
-		log.Error("Unable Publish events to kafka: " + err.Error())
-		return false
+	if err := json.Unmarshal(event.Request, &message); err != nil {
+		return event, err
 	}
 	for _, eventMessage := range message.Events {
-		log.Info(eventMessage.EventType + " Event Published")
+		log.Debug(eventMessage.EventType + " event received")
 	}
-	return true
+	return event, nil
 }
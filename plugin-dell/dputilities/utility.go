@@ -35,12 +35,8 @@ import (
 	"crypto/sha512"
 	"crypto/x509"
 	"encoding/pem"
-	"time"
 
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpresponse"
-	"github.com/fsnotify/fsnotify"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -74,45 +70,3 @@ func GetPlainText(password []byte) ([]byte, error) {
 		nil,
 	)
 }
-
-//Status holds the Status of plugin it will be intizaied during startup time
-var Status dpresponse.Status
-
-// PluginStartTime hold the time from which plugin started
-var PluginStartTime time.Time
-
-// TrackConfigFileChanges monitors the config changes using fsnotfiy
-func TrackConfigFileChanges(configFilePath string) {
-	watcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		log.Fatal(err.Error())
-	}
-	err = watcher.Add(configFilePath)
-	if err != nil {
-		log.Fatal(err.Error())
-	}
-	go func() {
-		for {
-			select {
-			case fileEvent, ok := <-watcher.Events:
-				if !ok {
-					continue
-				}
-				if fileEvent.Op&fsnotify.Write == fsnotify.Write || fileEvent.Op&fsnotify.Remove == fsnotify.Remove {
-					log.Debug("Modified file: " + fileEvent.Name)
-					// update the plugin config
-					if err := config.SetConfiguration(); err != nil {
-						log.Error("While trying to set configuration, got: " + err.Error())
-					}
-				}
-				//Reading file to continue the watch
-				watcher.Add(configFilePath)
-			case err, _ := <-watcher.Errors:
-				if err != nil {
-					log.Error(err.Error())
-					defer watcher.Close()
-				}
-			}
-		}
-	}()
-}
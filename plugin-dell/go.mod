@@ -3,8 +3,9 @@ module github.com/ODIM-Project/ODIM/plugin-dell
 go 1.17
 
 require (
-	github.com/ODIM-Project/ODIM/lib-dmtf v0.0.0-00010101000000-000000000000
+	github.com/ODIM-Project/ODIM/lib-dmtf v0.0.0-20210201172557-4fa2adafe1e3
 	github.com/ODIM-Project/ODIM/lib-messagebus v0.0.0-20220118101906-b2873faecdba
+	github.com/ODIM-Project/ODIM/lib-plugin-sdk v0.0.0-00010101000000-000000000000
 	github.com/ODIM-Project/ODIM/lib-utilities v0.0.0-20220426104855-9b203a83173f
 	github.com/fsnotify/fsnotify v1.5.1
 	github.com/gofrs/uuid v3.2.0+incompatible
@@ -81,6 +82,7 @@ require (
 	github.com/yosssi/ace v0.0.5 // indirect
 	github.com/yudai/gojsondiff v1.0.0 // indirect
 	github.com/yudai/golcs v0.0.0-20170316035057-ecda9a501e82 // indirect
+	go.etcd.io/bbolt v1.3.6 // indirect
 	golang.org/x/net v0.0.0-20220225172249-27dd8689420f // indirect
 	golang.org/x/oauth2 v0.0.0-20210402161424-2e8d93401602 // indirect
 	golang.org/x/sys v0.0.0-20220227234510-4e6760a101f9 // indirect
@@ -107,6 +109,7 @@ replace (
 	github.com/ODIM-Project/ODIM/lib-dmtf => ../lib-dmtf
 	github.com/ODIM-Project/ODIM/lib-messagebus => ../lib-messagebus
 	github.com/ODIM-Project/ODIM/lib-persistence-manager => ../lib-persistence-manager
+	github.com/ODIM-Project/ODIM/lib-plugin-sdk => ../lib-plugin-sdk
 	github.com/ODIM-Project/ODIM/lib-rest-client => ../lib-rest-client
 	github.com/ODIM-Project/ODIM/lib-utilities => ../lib-utilities
 )
@@ -14,26 +14,23 @@
 package main
 
 import (
-	"encoding/json"
-	"net"
 	"net/http"
 	"os"
 	"time"
 
 	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
-	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	sdkconfig "github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/events"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/northbound"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/redfish"
 	lutilconf "github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/plugin-dell/config"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dphandler"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dpmessagebus"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmiddleware"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 	iris "github.com/kataras/iris/v12"
 	"github.com/sirupsen/logrus"
 )
 
-var subscriptionInfo []dpmodel.Device
 var log = logrus.New()
 
 // TokenObject will contains the generated token and public key of odimra
@@ -44,8 +41,8 @@ type TokenObject struct {
 
 func main() {
 	// intializing the plugin start time
-	dputilities.PluginStartTime = time.Now()
-	log.Info("Plugin Start time:", dputilities.PluginStartTime.Format(time.RFC3339))
+	northbound.PluginStartTime = time.Now()
+	log.Info("Plugin Start time:", northbound.PluginStartTime.Format(time.RFC3339))
 
 	// verifying the uid of the user
 	if uid := os.Geteuid(); uid == 0 {
@@ -60,29 +57,23 @@ func main() {
 		log.Fatal("While trying to set messagebus configuration, got: " + err.Error())
 	}
 
-	// CreateJobQueue defines the queue which will act as an infinite buffer
-	// In channel is an entry or input channel and the Out channel is an exit or output channel
-	jobQueueSize := 10
-	dphandler.In, dphandler.Out = common.CreateJobQueue(jobQueueSize)
-
-	// RunReadWorkers will create a worker pool for doing a specific task
-	// which is passed to it as Publish method after reading the data from the channel.
-	go common.RunReadWorkers(dphandler.Out, dpmessagebus.Publish, 5)
+	// StartForwarding starts publishing the events of the devices to the message bus
+	events.StartForwarding(dpmessagebus.FormatEvent)
 
 	configFilePath := os.Getenv("PLUGIN_CONFIG_FILE_PATH")
 	if configFilePath == "" {
 		log.Fatal("No value get the environment variable PLUGIN_CONFIG_FILE_PATH")
 	}
 	// TrackConfigFileChanges monitors the dell config changes using fsnotfiy
-	go dputilities.TrackConfigFileChanges(configFilePath)
+	go sdkconfig.TrackConfigFileChanges(configFilePath)
 
 	intializePluginStatus()
 	app()
 }
 
 func app() {
-	go sendStartupEvent()
-	go eventsrouters()
+	go events.SendStartupEvent()
+	go events.ListenAndServe()
 
 	app := routers()
 	conf := &lutilconf.HTTPConfig{
@@ -96,6 +87,8 @@ func app() {
 	if err != nil {
 		log.Fatal("While initializing plugin server: " + err.Error())
 	}
+	// the sessions of the plugin with the BMCs are deleted when the plugin is stopped
+	iris.RegisterOnInterrupt(redfish.CloseDeviceSessions)
 	app.Run(iris.Server(pluginServer))
 }
 
@@ -113,13 +106,10 @@ func routers() *iris.Application {
 
 	pluginRoutes := app.Party("/ODIM/v1")
 	{
-		pluginRoutes.Post("/validate", dpmiddleware.BasicAuth, dphandler.Validate)
-		pluginRoutes.Post("/Sessions", dphandler.CreateSession)
-		pluginRoutes.Post("/Subscriptions", dpmiddleware.BasicAuth, dphandler.CreateEventSubscription)
-		pluginRoutes.Delete("/Subscriptions", dpmiddleware.BasicAuth, dphandler.DeleteEventSubscription)
+		northbound.RegisterRoutes(pluginRoutes, northbound.Contract{PrepareSubscription: northbound.DropEventFormatType})
 
 		//Adding routes related to all system gets
-		systems := pluginRoutes.Party("/Systems", dpmiddleware.BasicAuth)
+		systems := pluginRoutes.Party("/Systems", northbound.BasicAuth)
 		systems.Get("", dphandler.GetResource)
 		systems.Get("/{id}", dphandler.GetResource)
 		systems.Get("/{id}/Storage", dphandler.GetResource)
@@ -184,6 +174,7 @@ func routers() *iris.Application {
 		// Chassis Power URl routes
 		chassisPower := chassis.Party("/{id}/Power")
 		chassisPower.Get("/", dphandler.GetResource)
+		chassisPower.Patch("/", dphandler.ChangeSettings)
 		chassisPower.Get("#PowerControl/{id1}", dphandler.GetResource)
 		chassisPower.Get("#PowerSupplies/{id1}", dphandler.GetResource)
 		chassisPower.Get("#Redundancy/{id1}", dphandler.GetResource)
@@ -195,7 +186,7 @@ func routers() *iris.Application {
 		chassisThermal.Get("#Temperatures/{id1}", dphandler.GetResource)
 
 		// Manager routers
-		managers := pluginRoutes.Party("/Managers", dpmiddleware.BasicAuth)
+		managers := pluginRoutes.Party("/Managers", northbound.BasicAuth)
 		managers.Get("", dphandler.GetManagersCollection)
 		managers.Get("/{id}", dphandler.GetManagersInfo)
 		managers.Get("/{id}/EthernetInterfaces", dphandler.GetResource)
@@ -215,18 +206,18 @@ func routers() *iris.Application {
 		managers.Post("/{id}/LogServices/{rid}/Actions/LogService.ClearLog", dphandler.GetResource)
 
 		//Registries routers
-		registries := pluginRoutes.Party("/Registries", dpmiddleware.BasicAuth)
+		registries := pluginRoutes.Party("/Registries", northbound.BasicAuth)
 		registries.Get("", dphandler.GetResource)
 		registries.Get("/{id}", dphandler.GetResource)
 
-		registryStore := pluginRoutes.Party("/registrystore", dpmiddleware.BasicAuth)
+		registryStore := pluginRoutes.Party("/registrystore", northbound.BasicAuth)
 		registryStore.Get("/registries/en/{id}", dphandler.GetResource)
 
-		registryStoreCap := pluginRoutes.Party("/RegistryStore", dpmiddleware.BasicAuth)
+		registryStoreCap := pluginRoutes.Party("/RegistryStore", northbound.BasicAuth)
 		registryStoreCap.Get("/registries/en/{id}", dphandler.GetResource)
 
 		// Routes related to Update service
-		update := pluginRoutes.Party("/UpdateService", dpmiddleware.BasicAuth)
+		update := pluginRoutes.Party("/UpdateService", northbound.BasicAuth)
 		update.Post("/Actions/UpdateService.SimpleUpdate", dphandler.SimpleUpdate)
 		update.Post("/Actions/UpdateService.StartUpdate", dphandler.StartUpdate)
 		update.Get("/FirmwareInventory", dphandler.GetResource)
@@ -234,60 +225,10 @@ func routers() *iris.Application {
 		update.Get("/SoftwareInventory", dphandler.GetResource)
 		update.Get("/SoftwareInventory/{id}", dphandler.GetResource)
 	}
-	pluginRoutes.Get("/Status", dphandler.GetPluginStatus)
-	pluginRoutes.Post("/Startup", dpmiddleware.BasicAuth, dphandler.GetPluginStartup)
 	return app
 }
 
-func eventsrouters() {
-	conf := &lutilconf.HTTPConfig{
-		Certificate:   &config.Data.KeyCertConf.Certificate,
-		PrivateKey:    &config.Data.KeyCertConf.PrivateKey,
-		CACertificate: &config.Data.KeyCertConf.RootCACertificate,
-		ServerAddress: config.Data.EventConf.ListenerHost,
-		ServerPort:    config.Data.EventConf.ListenerPort,
-	}
-	evtServer, err := conf.GetHTTPServerObj()
-	if err != nil {
-		log.Fatalf("fatal: error while initializing event server: %v", err)
-	}
-	mux := http.NewServeMux()
-	mux.HandleFunc(config.Data.EventConf.DestURI, dphandler.RedfishEvents)
-	evtServer.Handler = mux
-	log.Fatal(evtServer.ListenAndServeTLS("", ""))
-}
-
 // intializePluginStatus sets plugin status
 func intializePluginStatus() {
-	dputilities.Status.Available = "yes"
-}
-
-// sendStartupEvent is for sending startup event
-func sendStartupEvent() {
-	// grace wait time for plugin to be functional
-	time.Sleep(3 * time.Second)
-
-	var pluginIP string
-	if pluginIP = os.Getenv("ASSIGNED_POD_IP"); pluginIP == "" {
-		pluginIP = config.Data.PluginConf.Host
-	}
-
-	startupEvt := common.PluginStatusEvent{
-		Name:         "Plugin startup event",
-		Type:         "PluginStarted",
-		Timestamp:    time.Now().String(),
-		OriginatorID: pluginIP,
-	}
-
-	request, _ := json.Marshal(startupEvt)
-	event := common.Events{
-		IP:        net.JoinHostPort(config.Data.PluginConf.Host, config.Data.PluginConf.Port),
-		Request:   request,
-		EventType: "PluginStartUp",
-	}
-
-	done := make(chan bool)
-	events := []interface{}{event}
-	go common.RunWriteWorkers(dphandler.In, events, 1, done)
-	log.Info("successfully sent startup event")
+	northbound.Status.Available = "yes"
 }
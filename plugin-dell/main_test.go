@@ -0,0 +1,33 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/conformance"
+	"github.com/ODIM-Project/ODIM/plugin-dell/config"
+)
+
+func TestContractConformance(t *testing.T) {
+	config.SetUpMockConfig(t)
+	app := routers()
+	if err := app.Build(); err != nil {
+		t.Fatalf("unable to build the routes: %v", err)
+	}
+	creds := conformance.Credentials{UserName: "admin", Password: "Od!m12$4"}
+	conformance.Run(t, app, creds)
+	conformance.CheckSubscription(t, app, creds, conformance.Subscription{DropEventFormatType: true})
+}
@@ -25,7 +25,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-lenovo/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/translation"
 	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpmodel"
 	"github.com/ODIM-Project/ODIM/plugin-lenovo/lputilities"
 	iris "github.com/kataras/iris/v12"
@@ -37,9 +37,8 @@ func GetResource(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the reuest url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	} //Validating the token
+	uri = translation.ToSouthBound(uri)
+	//Validating the token
 	if token != "" {
 		flag := TokenValidation(token)
 		if !flag {
@@ -115,9 +114,7 @@ func GetResource(ctx iris.Context) {
 	}
 	respData := string(body)
 	//replacing the resposne with north bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
-		respData = strings.Replace(respData, key, value, -1)
-	}
+	respData = translation.ToNorthBound(respData)
 
 	respData = strings.Replace(respData, "/Bios/Pending", "/Bios/Settings", -1)
 
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/translation"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	pluginConfig "github.com/ODIM-Project/ODIM/plugin-lenovo/config"
 	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpmodel"
@@ -119,9 +120,7 @@ func GetManagersInfo(ctx iris.Context) {
 
 func getInfoFromDevice(uri string, deviceDetails lpmodel.Device, ctx iris.Context) {
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = translation.ToSouthBound(uri)
 	device := &lputilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
@@ -168,9 +167,7 @@ func getInfoFromDevice(uri string, deviceDetails lpmodel.Device, ctx iris.Contex
 	}
 	respData := string(body)
 	//replacing the resposne with north bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
-		respData = strings.Replace(respData, key, value, -1)
-	}
+	respData = translation.ToNorthBound(respData)
 	ctx.StatusCode(resp.StatusCode)
 	ctx.Write([]byte(respData))
 }
@@ -179,9 +176,7 @@ func getInfoFromDevice(uri string, deviceDetails lpmodel.Device, ctx iris.Contex
 func VirtualMediaActions(ctx iris.Context) {
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = translation.ToSouthBound(uri)
 	var deviceDetails lpmodel.Device
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
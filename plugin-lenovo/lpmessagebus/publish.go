@@ -12,74 +12,55 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-//Package lpmessagebus ...
+// Package lpmessagebus ...
 package lpmessagebus
 
 import (
 	"encoding/json"
+
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
-	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/config"
-	log "github.com/sirupsen/logrus"
 )
 
-// Publish function will handle events request in two originofcondition format
-// originofcondition can be with or without @odata.id
-func Publish(data interface{}) bool {
-	if data == nil {
-		log.Error("Nil data passed to event publisher")
-		return false
+// FormatEvent is the event Formatter of the Lenovo servers, it handles the events
+// with the originofcondition both with and without @odata.id
+func FormatEvent(event common.Events) (common.Events, error) {
+	var message common.MessageData
+	if err := json.Unmarshal(event.Request, &message); err == nil {
+		return event, nil
 	}
-	event := data.(common.Events)
-
-	topic := config.Data.MessageBusConf.EmbQueue[0]
-	K, err := dc.Communicator(dc.KAFKA, config.Data.MessageBusConf.MessageBusConfigFilePath, topic)
-	if err != nil {
-		log.Error("Unable communicate with kafka, got:" + err.Error())
-		return false
+	var messageData dmtf.Event
+	if err := json.Unmarshal(event.Request, &messageData); err != nil {
+		return event, err
 	}
-
-	// Since we are deleting the first event from the eventlist,
-	// processing the first event
-	var message common.MessageData
-	err = json.Unmarshal(event.Request, &message)
-	if err != nil {
-		var messageData dmtf.Event
-		if err := json.Unmarshal(event.Request, &messageData); err != nil {
-			log.Error("Failed to unmarshal the event: " + err.Error())
-			return false
+	message.Context = messageData.Context
+	message.Name = messageData.Name
+	message.OdataType = messageData.ODataType
+	message.Events = make([]common.Event, 0, len(messageData.Events))
+	for _, eventMessage := range messageData.Events {
+		eventData := common.Event{
+			EventGroupID:   eventMessage.EventGroupID,
+			EventID:        eventMessage.EventID,
+			EventTimestamp: eventMessage.EventTimestamp,
+			EventType:      eventMessage.EventType,
+			Message:        eventMessage.Message,
+			MemberID:       eventMessage.MemberID,
+			Severity:       eventMessage.Severity,
+			Oem:            eventMessage.Oem,
+			MessageID:      eventMessage.MessageID,
+			MessageArgs:    eventMessage.MessageArgs,
 		}
-		message.Context = messageData.Context
-		message.Name = messageData.Name
-		message.OdataType = messageData.ODataType
-		message.Events = make([]common.Event, 0)
-		for i := 0; i < len(messageData.Events); i++ {
-			var eventData common.Event
-			eventData.EventGroupID = messageData.Events[i].EventGroupID
-			eventData.EventID = messageData.Events[i].EventID
-			eventData.EventTimestamp = messageData.Events[i].EventTimestamp
-			eventData.EventType = messageData.Events[i].EventType
-			eventData.Message = messageData.Events[i].Message
-			eventData.MemberID = messageData.Events[i].MemberID
-			eventData.Severity = messageData.Events[i].Severity
-			eventData.Oem = messageData.Events[i].Oem
-			eventData.MessageID = messageData.Events[i].MessageID
+		if eventMessage.OriginOfCondition != nil {
 			eventData.OriginOfCondition = &common.Link{
-				Oid: messageData.Events[i].OriginOfCondition.Oid,
+				Oid: eventMessage.OriginOfCondition.Oid,
 			}
-			eventData.MessageArgs = messageData.Events[i].MessageArgs
-			message.Events = append(message.Events, eventData)
 		}
-		event.Request, _ = json.Marshal(message)
+		message.Events = append(message.Events, eventData)
 	}
-	if err := K.Distribute(event); err != nil {
-		log.Error("Unable Publish events to kafka, got:" + err.Error())
-		return false
-	}
-	log.Info("forwarded event" + string(event.Request))
-	for _, eventMessage := range message.Events {
-		log.Info(eventMessage.EventType + " Event published")
+	request, err := json.Marshal(message)
+	if err != nil {
+		return event, err
 	}
-	return true
+	event.Request = request
+	return event, nil
 }
@@ -12,37 +12,16 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-//Package lpmodel ...
+// Package lpmodel ...
 package lpmodel
 
-//Device struct definition
-type Device struct {
-	Host     string `json:"ManagerAddress"`
-	Username string `json:"UserName"`
-	Password []byte `json:"Password"`
-	PostBody []byte `json:"PostBody"`
-	Location string `json:"Location"`
-}
+import (
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/model"
+)
 
-//EvtSubPost ...
-type EvtSubPost struct {
-	Destination   string        `json:"Destination"`
-	EventTypes    []string      `json:"EventTypes,omitempty"`
-	MessageIds    []string      `json:"MessageIds,omitempty"`
-	ResourceTypes []string      `json:"ResourceTypes,omitempty"`
-	HTTPHeaders   []HTTPHeaders `json:"HttpHeaders"`
-	Context       string        `json:"Context"`
-	Protocol      string        `json:"Protocol"`
-}
-
-//HTTPHeaders ...
-type HTTPHeaders struct {
-	ContentType string `json:"Content-Type"`
-}
-
-// Startup struct recieve request on Startup call
-type Startup struct {
-	Location   string   `json:"Location"`
-	EventTypes []string `json:"EventTypes,omitempty"`
-	Device     Device   `json:"Device"`
-}
+// The device models of the plugin are the ones of the plugin SDK
+type (
+	Device      = model.Device
+	EvtSubPost  = model.EvtSubPost
+	HTTPHeaders = model.HTTPHeaders
+)
@@ -35,14 +35,10 @@ import (
 	"crypto/sha512"
 	"crypto/x509"
 	"encoding/pem"
-	"time"
 
 	log "github.com/sirupsen/logrus"
 
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/config"
 	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpresponse"
-	"github.com/fsnotify/fsnotify"
 )
 
 // GetPlainText ...
@@ -76,46 +72,3 @@ func GetPlainText(password []byte) ([]byte, error) {
 		nil,
 	)
 }
-
-//Status holds the Status of plugin it will be intizaied during startup time
-var Status lpresponse.Status
-
-// PluginStartTime hold the time from which plugin started
-var PluginStartTime time.Time
-
-// TrackConfigFileChanges monitors the odim config changes using fsnotfiy
-func TrackConfigFileChanges(configFilePath string) {
-	watcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		log.Error(err)
-	}
-	err = watcher.Add(configFilePath)
-	if err != nil {
-		log.Error(err)
-	}
-	go func() {
-		for {
-			select {
-			case fileEvent, ok := <-watcher.Events:
-				if !ok {
-					continue
-				}
-				log.Info("event:", fileEvent)
-				if fileEvent.Op&fsnotify.Write == fsnotify.Write || fileEvent.Op&fsnotify.Remove == fsnotify.Remove {
-					log.Info("modified file:", fileEvent.Name)
-					// update the odim config
-					if err := config.SetConfiguration(); err != nil {
-						log.Error("error while trying to set configuration" + err.Error())
-					}
-				}
-				//Reading file to continue the watch
-				watcher.Add(configFilePath)
-			case err, _ := <-watcher.Errors:
-				if err != nil {
-					log.Error(err)
-					defer watcher.Close()
-				}
-			}
-		}
-	}()
-}
@@ -21,19 +21,17 @@ import (
 	"github.com/sirupsen/logrus"
 
 	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
-	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	sdkconfig "github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/events"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/northbound"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/redfish"
 	lutilconf "github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/plugin-lenovo/config"
 	"github.com/ODIM-Project/ODIM/plugin-lenovo/lphandler"
 	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpmessagebus"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpmiddleware"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lputilities"
 	iris "github.com/kataras/iris/v12"
 )
 
-var subscriptionInfo []lpmodel.Device
-
 // TokenObject will contains the generated token and public key of odimra
 type TokenObject struct {
 	AuthToken string `json:"authToken"`
@@ -44,8 +42,8 @@ var log = logrus.New()
 
 func main() {
 	// intializing the plugin start time
-	lputilities.PluginStartTime = time.Now()
-	log.Info("Plugin Start time:", lputilities.PluginStartTime.Format(time.RFC3339))
+	northbound.PluginStartTime = time.Now()
+	log.Info("Plugin Start time:", northbound.PluginStartTime.Format(time.RFC3339))
 
 	// verifying the uid of the user
 	if uid := os.Geteuid(); uid == 0 {
@@ -60,31 +58,25 @@ func main() {
 		log.Fatal("While trying to set messagebus configuration, got: " + err.Error())
 	}
 
-	// CreateJobQueue defines the queue which will act as an infinite buffer
-	// In channel is an entry or input channel and the Out channel is an exit or output channel
-	jobQueueSize := 10
-	lphandler.In, lphandler.Out = common.CreateJobQueue(jobQueueSize)
-
-	// RunReadWorkers will create a worker pool for doing a specific task
-	// which is passed to it as Publish method after reading the data from the channel.
-	go common.RunReadWorkers(lphandler.Out, lpmessagebus.Publish, 5)
+	// StartForwarding starts publishing the events of the devices to the message bus
+	events.StartForwarding(lpmessagebus.FormatEvent)
 
 	configFilePath := os.Getenv("PLUGIN_CONFIG_FILE_PATH")
 	if configFilePath == "" {
 		log.Fatal("No value get the environment variable PLUGIN_CONFIG_FILE_PATH")
 	}
 	// TrackConfigFileChanges monitors the odim config changes using fsnotfiy
-	go lputilities.TrackConfigFileChanges(configFilePath)
+	go sdkconfig.TrackConfigFileChanges(configFilePath)
 
 	intializePluginStatus()
 	app()
 }
 
 func app() {
+	go events.SendStartupEvent()
+	go events.ListenAndServe()
+
 	app := routers()
-	go func() {
-		eventsrouters()
-	}()
 	conf := &lutilconf.HTTPConfig{
 		Certificate:   &config.Data.KeyCertConf.Certificate,
 		PrivateKey:    &config.Data.KeyCertConf.PrivateKey,
@@ -96,6 +88,8 @@ func app() {
 	if err != nil {
 		log.Fatal("Unable to initialize plugin : " + err.Error())
 	}
+	// the sessions of the plugin with the BMCs are deleted when the plugin is stopped
+	iris.RegisterOnInterrupt(redfish.CloseDeviceSessions)
 	app.Run(iris.Server(pluginServer))
 }
 
@@ -113,13 +107,10 @@ func routers() *iris.Application {
 
 	pluginRoutes := app.Party("/ODIM/v1")
 	{
-		pluginRoutes.Post("/validate", lpmiddleware.BasicAuth, lphandler.Validate)
-		pluginRoutes.Post("/Sessions", lphandler.CreateSession)
-		pluginRoutes.Post("/Subscriptions", lpmiddleware.BasicAuth, lphandler.CreateEventSubscription)
-		pluginRoutes.Delete("/Subscriptions", lpmiddleware.BasicAuth, lphandler.DeleteEventSubscription)
+		northbound.RegisterRoutes(pluginRoutes, northbound.Contract{PrepareSubscription: northbound.DropEventFormatType})
 
 		//Adding routes related to all system gets
-		systems := pluginRoutes.Party("/Systems", lpmiddleware.BasicAuth)
+		systems := pluginRoutes.Party("/Systems", northbound.BasicAuth)
 		systems.Get("", lphandler.GetResource)
 		systems.Get("/{id}", lphandler.GetResource)
 		systems.Get("/{id}/Storage", lphandler.GetResource)
@@ -194,6 +185,7 @@ func routers() *iris.Application {
 		// Chassis Power URl routes
 		chassisPower := chassis.Party("/{id}/Power")
 		chassisPower.Get("/", lphandler.GetResource)
+		chassisPower.Patch("/", lphandler.ChangeSettings)
 		chassisPower.Get("#PowerControl/{id1}", lphandler.GetResource)
 		chassisPower.Get("#PowerSupplies/{id1}", lphandler.GetResource)
 		chassisPower.Get("#Redundancy/{id1}", lphandler.GetResource)
@@ -205,7 +197,7 @@ func routers() *iris.Application {
 		chassisThermal.Get("#Temperatures/{id1}", lphandler.GetResource)
 
 		// Manager routers
-		managers := pluginRoutes.Party("/Managers", lpmiddleware.BasicAuth)
+		managers := pluginRoutes.Party("/Managers", northbound.BasicAuth)
 		managers.Get("", lphandler.GetManagersCollection)
 		managers.Get("/{id}", lphandler.GetManagersInfo)
 		managers.Get("/{id}/EthernetInterfaces", lphandler.GetResource)
@@ -227,15 +219,15 @@ func routers() *iris.Application {
 		managers.Post("/{id}/LogServices/{rid}/Actions/LogService.ClearLog", lphandler.GetResource)
 
 		//Registries routers
-		registries := pluginRoutes.Party("/Registries", lpmiddleware.BasicAuth)
+		registries := pluginRoutes.Party("/Registries", northbound.BasicAuth)
 		registries.Get("", lphandler.GetResource)
 		registries.Get("/{id}", lphandler.GetResource)
 
-		registryStore := pluginRoutes.Party("/schemas", lpmiddleware.BasicAuth)
+		registryStore := pluginRoutes.Party("/schemas", northbound.BasicAuth)
 		registryStore.Get("/registries/{id}", lphandler.GetResource)
 
 		// Routes related to Update service
-		update := pluginRoutes.Party("/UpdateService", lpmiddleware.BasicAuth)
+		update := pluginRoutes.Party("/UpdateService", northbound.BasicAuth)
 		update.Post("/Actions/UpdateService.SimpleUpdate", lphandler.SimpleUpdate)
 		update.Post("/Actions/UpdateService.StartUpdate", lphandler.SimpleUpdate)
 		update.Get("/FirmwareInventory", lphandler.GetResource)
@@ -244,36 +236,17 @@ func routers() *iris.Application {
 		update.Get("/SoftwareInventory/{id}", lphandler.GetResource)
 
 		//Adding routes related to telemetry service
-		telemetry := pluginRoutes.Party("/TelemetryService", lpmiddleware.BasicAuth)
+		telemetry := pluginRoutes.Party("/TelemetryService", northbound.BasicAuth)
 		telemetry.Get("/MetricDefinitions", lphandler.GetResource)
 		telemetry.Get("/MetricReportDefinitions", lphandler.GetResource)
 		telemetry.Get("/MetricReports", lphandler.GetResource)
 		telemetry.Get("/Triggers", lphandler.GetResource)
+		telemetry.Patch("/Triggers/{id}", lphandler.ChangeSettings)
 	}
-	pluginRoutes.Get("/Status", lphandler.GetPluginStatus)
-	pluginRoutes.Post("/Startup", lpmiddleware.BasicAuth, lphandler.GetPluginStartup)
 	return app
 }
 
-func eventsrouters() {
-	conf := &lutilconf.HTTPConfig{
-		Certificate:   &config.Data.KeyCertConf.Certificate,
-		PrivateKey:    &config.Data.KeyCertConf.PrivateKey,
-		CACertificate: &config.Data.KeyCertConf.RootCACertificate,
-		ServerAddress: config.Data.EventConf.ListenerHost,
-		ServerPort:    config.Data.EventConf.ListenerPort,
-	}
-	evtServer, err := conf.GetHTTPServerObj()
-	if err != nil {
-		log.Fatal("Unable to initialize event server: " + err.Error())
-	}
-	mux := http.NewServeMux()
-	mux.HandleFunc(config.Data.EventConf.DestURI, lphandler.RedfishEvents)
-	evtServer.Handler = mux
-	log.Fatal(evtServer.ListenAndServeTLS("", ""))
-}
-
 // intializePluginStatus sets plugin status
 func intializePluginStatus() {
-	lputilities.Status.Available = "yes"
+	northbound.Status.Available = "yes"
 }
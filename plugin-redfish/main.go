@@ -14,27 +14,22 @@
 package main
 
 import (
-	"encoding/json"
-	"net"
 	"net/http"
 	"os"
 	"time"
 
 	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
-	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	sdkconfig "github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/events"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/northbound"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/redfish"
 	lutilconf "github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfphandler"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmessagebus"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmiddleware"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
 	iris "github.com/kataras/iris/v12"
 	"github.com/sirupsen/logrus"
 )
 
-var subscriptionInfo []rfpmodel.Device
-
 // TokenObject will contains the generated token and public key of odimra
 type TokenObject struct {
 	AuthToken string `json:"authToken"`
@@ -45,8 +40,8 @@ var log = logrus.New()
 
 func main() {
 	// intializing the plugin start time
-	rfputilities.PluginStartTime = time.Now()
-	log.Info("Plugin Start time:", rfputilities.PluginStartTime.Format(time.RFC3339))
+	northbound.PluginStartTime = time.Now()
+	log.Info("Plugin Start time:", northbound.PluginStartTime.Format(time.RFC3339))
 
 	// verifying the uid of the user
 	if uid := os.Geteuid(); uid == 0 {
@@ -61,29 +56,23 @@ func main() {
 		log.Fatal("While trying to set messagebus configuration, got: " + err.Error())
 	}
 
-	// CreateJobQueue defines the queue which will act as an infinite buffer
-	// In channel is an entry or input channel and the Out channel is an exit or output channel
-	jobQueueSize := 10
-	rfphandler.In, rfphandler.Out = common.CreateJobQueue(jobQueueSize)
-
-	// RunReadWorkers will create a worker pool for doing a specific task
-	// which is passed to it as Publish method after reading the data from the channel.
-	go common.RunReadWorkers(rfphandler.Out, rfpmessagebus.Publish, 5)
+	// StartForwarding starts publishing the events of the devices to the message bus
+	events.StartForwarding(events.FormatRedfishEvent)
 
 	configFilePath := os.Getenv("PLUGIN_CONFIG_FILE_PATH")
 	if configFilePath == "" {
 		log.Fatal("No value get the environment variable PLUGIN_CONFIG_FILE_PATH")
 	}
 	// TrackConfigFileChanges monitors the odim config changes using fsnotfiy
-	go rfputilities.TrackConfigFileChanges(configFilePath)
+	go sdkconfig.TrackConfigFileChanges(configFilePath)
 
 	intializePluginStatus()
 	app()
 }
 
 func app() {
-	go sendStartupEvent()
-	go eventsrouters()
+	go events.SendStartupEvent()
+	go events.ListenAndServe()
 
 	app := routers()
 	conf := &lutilconf.HTTPConfig{
@@ -97,6 +86,8 @@ func app() {
 	if err != nil {
 		log.Fatal("Unable to initialize plugin : " + err.Error())
 	}
+	// the sessions of the plugin with the BMCs are deleted when the plugin is stopped
+	iris.RegisterOnInterrupt(redfish.CloseDeviceSessions)
 	app.Run(iris.Server(pluginServer))
 }
 
@@ -117,13 +108,10 @@ func routers() *iris.Application {
 	}
 	pluginRoutes := app.Party("/ODIM/v1")
 	{
-		pluginRoutes.Post("/validate", rfpmiddleware.BasicAuth, rfphandler.Validate)
-		pluginRoutes.Post("/Sessions", rfphandler.CreateSession)
-		pluginRoutes.Post("/Subscriptions", rfpmiddleware.BasicAuth, rfphandler.CreateEventSubscription)
-		pluginRoutes.Delete("/Subscriptions", rfpmiddleware.BasicAuth, rfphandler.DeleteEventSubscription)
+		northbound.RegisterRoutes(pluginRoutes, northbound.Contract{})
 
 		//Adding routes related to all system gets
-		systems := pluginRoutes.Party("/Systems", rfpmiddleware.BasicAuth)
+		systems := pluginRoutes.Party("/Systems", northbound.BasicAuth)
 		systems.Get("", rfphandler.GetResource)
 		systems.Get("/{id}", rfphandler.GetResource)
 		systems.Get("/{id}/Storage", rfphandler.GetResource)
@@ -200,6 +188,7 @@ func routers() *iris.Application {
 		// Chassis Power URl routes
 		chassisPower := chassis.Party("/{id}/Power")
 		chassisPower.Get("/", rfphandler.GetResource)
+		chassisPower.Patch("/", rfphandler.ChangeSettings)
 		chassisPower.Get("#PowerControl/{id1}", rfphandler.GetResource)
 		chassisPower.Get("#PowerSupplies/{id1}", rfphandler.GetResource)
 		chassisPower.Get("#Redundancy/{id1}", rfphandler.GetResource)
@@ -211,7 +200,7 @@ func routers() *iris.Application {
 		chassisThermal.Get("#Temperatures/{id1}", rfphandler.GetResource)
 
 		// Manager routers
-		managers := pluginRoutes.Party("/Managers", rfpmiddleware.BasicAuth)
+		managers := pluginRoutes.Party("/Managers", northbound.BasicAuth)
 		managers.Get("", rfphandler.GetManagersCollection)
 		managers.Get("/{id}", rfphandler.GetManagersInfo)
 		managers.Get("/{id}/EthernetInterfaces", rfphandler.GetResource)
@@ -233,18 +222,18 @@ func routers() *iris.Application {
 		managers.Post("/{id}/LogServices/{rid}/Actions/LogService.ClearLog", rfphandler.GetResource)
 
 		//Registries routers
-		registries := pluginRoutes.Party("/Registries", rfpmiddleware.BasicAuth)
+		registries := pluginRoutes.Party("/Registries", northbound.BasicAuth)
 		registries.Get("", rfphandler.GetResource)
 		registries.Get("/{id}", rfphandler.GetResource)
 
-		registryStore := pluginRoutes.Party("/registrystore", rfpmiddleware.BasicAuth)
+		registryStore := pluginRoutes.Party("/registrystore", northbound.BasicAuth)
 		registryStore.Get("/registries/en/{id}", rfphandler.GetResource)
 
-		registryStoreCap := pluginRoutes.Party("/RegistryStore", rfpmiddleware.BasicAuth)
+		registryStoreCap := pluginRoutes.Party("/RegistryStore", northbound.BasicAuth)
 		registryStoreCap.Get("/registries/en/{id}", rfphandler.GetResource)
 
 		// Routes related to Update service
-		update := pluginRoutes.Party("/UpdateService", rfpmiddleware.BasicAuth)
+		update := pluginRoutes.Party("/UpdateService", northbound.BasicAuth)
 		update.Post("/Actions/UpdateService.SimpleUpdate", rfphandler.SimpleUpdate)
 		update.Post("/Actions/UpdateService.StartUpdate", rfphandler.StartUpdate)
 		update.Get("/FirmwareInventory", rfphandler.GetResource)
@@ -253,7 +242,7 @@ func routers() *iris.Application {
 		update.Get("/SoftwareInventory/{id}", rfphandler.GetResource)
 
 		//Adding routes related to telemetry service
-		telemetry := pluginRoutes.Party("/TelemetryService", rfpmiddleware.BasicAuth)
+		telemetry := pluginRoutes.Party("/TelemetryService", northbound.BasicAuth)
 		telemetry.Get("/MetricDefinitions", rfphandler.GetResource)
 		telemetry.Get("/MetricReportDefinitions", rfphandler.GetResource)
 		telemetry.Get("/MetricReports", rfphandler.GetResource)
@@ -262,62 +251,13 @@ func routers() *iris.Application {
 		telemetry.Get("/MetricDefinitions/{id}", rfphandler.GetResource)
 		telemetry.Get("/MetricReportDefinitions/{id}", rfphandler.GetResource)
 		telemetry.Get("/Triggers/{id}", rfphandler.GetResource)
+		telemetry.Patch("/Triggers/{id}", rfphandler.ChangeSettings)
 
 	}
-	pluginRoutes.Get("/Status", rfphandler.GetPluginStatus)
-	pluginRoutes.Post("/Startup", rfpmiddleware.BasicAuth, rfphandler.GetPluginStartup)
 	return app
 }
 
-func eventsrouters() {
-	conf := &lutilconf.HTTPConfig{
-		Certificate:   &config.Data.KeyCertConf.Certificate,
-		PrivateKey:    &config.Data.KeyCertConf.PrivateKey,
-		CACertificate: &config.Data.KeyCertConf.RootCACertificate,
-		ServerAddress: config.Data.EventConf.ListenerHost,
-		ServerPort:    config.Data.EventConf.ListenerPort,
-	}
-	evtServer, err := conf.GetHTTPServerObj()
-	if err != nil {
-		log.Fatal("Unable to initialize event server: " + err.Error())
-	}
-	mux := http.NewServeMux()
-	mux.HandleFunc(config.Data.EventConf.DestURI, rfphandler.RedfishEvents)
-	evtServer.Handler = mux
-	log.Fatal(evtServer.ListenAndServeTLS("", ""))
-}
-
 // intializePluginStatus sets plugin status
 func intializePluginStatus() {
-	rfputilities.Status.Available = "yes"
-}
-
-// sendStartupEvent is for sending startup event
-func sendStartupEvent() {
-	// grace wait time for plugin to be functional
-	time.Sleep(3 * time.Second)
-
-	var pluginIP string
-	if pluginIP = os.Getenv("ASSIGNED_POD_IP"); pluginIP == "" {
-		pluginIP = config.Data.PluginConf.Host
-	}
-
-	startupEvt := common.PluginStatusEvent{
-		Name:         "Plugin startup event",
-		Type:         "PluginStarted",
-		Timestamp:    time.Now().String(),
-		OriginatorID: pluginIP,
-	}
-
-	request, _ := json.Marshal(startupEvt)
-	event := common.Events{
-		IP:        net.JoinHostPort(config.Data.PluginConf.Host, config.Data.PluginConf.Port),
-		Request:   request,
-		EventType: "PluginStartUp",
-	}
-
-	done := make(chan bool)
-	events := []interface{}{event}
-	go common.RunWriteWorkers(rfphandler.In, events, 1, done)
-	log.Info("successfully sent startup event")
+	northbound.Status.Available = "yes"
 }
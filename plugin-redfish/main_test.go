@@ -0,0 +1,168 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/conformance"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/simulator"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
+)
+
+func TestContractConformance(t *testing.T) {
+	config.SetUpMockConfig(t)
+	app := routers()
+	if err := app.Build(); err != nil {
+		t.Fatalf("unable to build the routes: %v", err)
+	}
+	creds := conformance.Credentials{UserName: "admin", Password: "Od!m12$4"}
+	conformance.Run(t, app, creds)
+	conformance.CheckSubscription(t, app, creds, conformance.Subscription{})
+}
+
+// computeSystem is a BMC added as a compute system through the plugin
+type computeSystem struct {
+	uuid string
+	host string
+}
+
+// startComputeSystem serves a simulated BMC with the certificate of the plugin configuration
+func startComputeSystem(t *testing.T, uuid string) computeSystem {
+	bmc, err := simulator.New(simulator.Options{UUID: uuid})
+	if err != nil {
+		t.Fatalf("unable to create the simulated BMC: %v", err)
+	}
+	cert, err := tls.X509KeyPair(config.Data.KeyCertConf.Certificate, config.Data.KeyCertConf.PrivateKey)
+	if err != nil {
+		t.Fatalf("unable to load the certificate of the plugin: %v", err)
+	}
+	server := httptest.NewUnstartedServer(bmc)
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return computeSystem{uuid: uuid, host: strings.TrimPrefix(server.URL, "https://")}
+}
+
+// pluginRequest sends the request of ODIM for the BMC to the plugin and decodes the response body
+func pluginRequest(t *testing.T, handler http.Handler, method, uri string, bmc computeSystem, resource interface{}) {
+	t.Helper()
+	body, _ := json.Marshal(rfpmodel.Device{
+		Host:     bmc.host,
+		Username: "admin",
+		Password: []byte("password"),
+	})
+	req := httptest.NewRequest(method, uri, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth("admin", "Od!m12$4")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("%v %v for %v: status code = %v, want %v, body: %s", method, uri, bmc.host, recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), resource); err != nil {
+		t.Fatalf("%v %v for %v: unable to decode the response: %v, body: %s", method, uri, bmc.host, err, recorder.Body.String())
+	}
+}
+
+// link is a link of a Redfish resource
+type link struct {
+	ODataID string `json:"@odata.id"`
+}
+
+// collectionMembers gets the collection through the plugin and returns the links of its members,
+// the links must be translated to the URIs of the plugin
+func collectionMembers(t *testing.T, handler http.Handler, uri string, bmc computeSystem) []string {
+	t.Helper()
+	var collection struct {
+		Members []link
+	}
+	pluginRequest(t, handler, http.MethodGet, uri, bmc, &collection)
+	if len(collection.Members) == 0 {
+		t.Fatalf("collection %v of %v has no members", uri, bmc.host)
+	}
+	var members []string
+	for _, member := range collection.Members {
+		if !strings.HasPrefix(member.ODataID, uri+"/") {
+			t.Errorf("member %v of the collection %v is not translated to the URIs of the plugin", member.ODataID, uri)
+		}
+		members = append(members, member.ODataID)
+	}
+	return members
+}
+
+func TestAddCompute(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.URLTranslation = &config.URLTranslation{
+		NorthBoundURL: map[string]string{"redfish": "ODIM"},
+		SouthBoundURL: map[string]string{"ODIM": "redfish"},
+	}
+	app := routers()
+	if err := app.Build(); err != nil {
+		t.Fatalf("unable to build the routes: %v", err)
+	}
+	bmcs := []computeSystem{
+		startComputeSystem(t, "0c5c8c3e-6d4b-4bfa-a1b5-3f1b2f0e9a11"),
+		startComputeSystem(t, "8f2e4d6a-2b7c-4c1d-9e3f-5a6b7c8d9e22"),
+	}
+	for _, bmc := range bmcs {
+		// the BMC is validated and identified by the UUID of its service root
+		var device struct {
+			ServerIP   string
+			DeviceUUID string `json:"device_UUID"`
+		}
+		pluginRequest(t, app, http.MethodPost, "/ODIM/v1/validate", bmc, &device)
+		if device.ServerIP != bmc.host || device.DeviceUUID != bmc.uuid {
+			t.Errorf("validated device = %+v, want ServerIP %v and DeviceUUID %v", device, bmc.host, bmc.uuid)
+		}
+
+		// the inventory of the BMC is collected from its systems, chassis and managers
+		for _, systemURI := range collectionMembers(t, app, "/ODIM/v1/Systems", bmc) {
+			var system struct {
+				Bios    link
+				Storage link
+			}
+			pluginRequest(t, app, http.MethodGet, systemURI, bmc, &system)
+			if system.Bios.ODataID != systemURI+"/Bios" {
+				t.Errorf("BIOS of the system %v = %v, want %v", systemURI, system.Bios.ODataID, systemURI+"/Bios")
+			}
+			var bios map[string]interface{}
+			pluginRequest(t, app, http.MethodGet, system.Bios.ODataID, bmc, &bios)
+			for _, storageURI := range collectionMembers(t, app, system.Storage.ODataID, bmc) {
+				var storage struct {
+					Drives []link
+				}
+				pluginRequest(t, app, http.MethodGet, storageURI, bmc, &storage)
+				for _, drive := range storage.Drives {
+					var resource map[string]interface{}
+					pluginRequest(t, app, http.MethodGet, drive.ODataID, bmc, &resource)
+				}
+			}
+		}
+		for _, collectionURI := range []string{"/ODIM/v1/Chassis", "/ODIM/v1/Managers"} {
+			for _, memberURI := range collectionMembers(t, app, collectionURI, bmc) {
+				var resource map[string]interface{}
+				pluginRequest(t, app, http.MethodGet, memberURI, bmc, &resource)
+			}
+		}
+	}
+}
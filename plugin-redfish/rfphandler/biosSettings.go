@@ -20,11 +20,10 @@ import (
 	"io/ioutil"
 	"net/http"
 
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/translation"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
 	iris "github.com/kataras/iris/v12"
-	"strings"
 )
 
 // DeviceClient struct to call device for the operation
@@ -36,14 +35,14 @@ type DeviceClient struct {
 //ChangeSettings is generic function where we can do following operations on different call
 // 1. change bios settings
 // 2. change boot order settings
+// 3. change power limit of chassis
+// 4. change thresholds of telemetry trigger
 func ChangeSettings(ctx iris.Context) {
 	//Get token from Request
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = translation.ToSouthBound(uri)
 	//Validating the token
 	if token != "" {
 		flag := TokenValidation(token)
@@ -16,19 +16,17 @@
 package rfphandler
 
 import (
-	"bytes"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
-	"fmt"
 	log "github.com/sirupsen/logrus"
-	"io/ioutil"
 	"net"
 	"net/http"
 	testhttp "net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/simulator"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpresponse"
 	iris "github.com/kataras/iris/v12"
@@ -207,56 +205,43 @@ func startTestServer(handler mockHandlerFunc) *testhttp.Server {
 	return ts
 }
 
-func mockDeviceHandler(username, password, url string, w http.ResponseWriter) {
-	resp, err := mockChangeBiosSettings(username, url)
-	if err != nil && resp == nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+// startSimulator serves a simulated BMC with the certificate of the plugin configuration,
+// the URIs are translated for it by the plugin as configured in config.json
+func startSimulator(t *testing.T) (*simulator.Simulator, string) {
+	config.Data.URLTranslation = &config.URLTranslation{
+		NorthBoundURL: map[string]string{"redfish": "ODIM"},
+		SouthBoundURL: map[string]string{"ODIM": "redfish"},
 	}
-	w.WriteHeader(resp.StatusCode)
-	return
-}
-
-func mockChangeBiosSettings(username, url string) (*http.Response, error) {
-	if url == "/ODIM/v1/Systems/1" && username == "admin" {
-		return &http.Response{
-			StatusCode: http.StatusOK,
-			Body:       ioutil.NopCloser(bytes.NewBufferString("Success")),
-		}, nil
-	}
-	if url == "/ODIM/v1/Systems/1" && username != "admin" {
-		return &http.Response{
-			StatusCode: http.StatusBadRequest,
-			Body:       ioutil.NopCloser(bytes.NewBufferString("Failed")),
-		}, fmt.Errorf("Error")
+	bmc, err := simulator.New(simulator.Options{UserName: "admin", Password: "P@$$w0rd"})
+	if err != nil {
+		t.Fatalf("unable to create the simulated BMC: %v", err)
 	}
-	if url == "/ODIM/v1/Systems/1/bios/settings" && username == "admin" {
-		return &http.Response{
-			StatusCode: http.StatusOK,
-			Body:       ioutil.NopCloser(bytes.NewBufferString("Success")),
-		}, nil
+	cert, err := tls.X509KeyPair(config.Data.KeyCertConf.Certificate, config.Data.KeyCertConf.PrivateKey)
+	if err != nil {
+		t.Fatalf("unable to load the certificate of the plugin: %v", err)
 	}
-	if url == "/ODIM/v1/Systems/1/bios/settings" && username != "admin" {
-		return &http.Response{
-			StatusCode: http.StatusBadRequest,
-			Body:       ioutil.NopCloser(bytes.NewBufferString("Failed")),
-		}, fmt.Errorf("Error")
+	ts := testhttp.NewUnstartedServer(bmc)
+	ts.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	ts.StartTLS()
+	t.Cleanup(ts.Close)
+	return bmc, strings.TrimPrefix(ts.URL, "https://")
+}
+
+// resourceProperty returns the property of the resource on the simulated BMC
+func resourceProperty(t *testing.T, bmc *simulator.Simulator, uri, name string) interface{} {
+	resource, ok := bmc.Resource(uri)
+	if !ok {
+		t.Fatalf("resource %v is not found on the simulated BMC", uri)
 	}
-	return nil, fmt.Errorf("Error")
+	return resource[name]
 }
 
 func TestChangeBootOrderSettings(t *testing.T) {
 	config.SetUpMockConfig(t)
-
-	deviceHost := "localhost"
-	devicePort := "1234"
-	ts := startTestServer(mockDeviceHandler)
-	// Start the server.
-	ts.StartTLS()
-	defer ts.Close()
+	bmc, host := startSimulator(t)
 
 	mockApp := iris.New()
-	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes := mockApp.Party("/ODIM/v1")
 
 	redfishRoutes.Patch("/Systems/{id}", ChangeSettings)
 
@@ -265,52 +250,100 @@ func TestChangeBootOrderSettings(t *testing.T) {
 	e := httptest.New(t, mockApp)
 
 	requestBody := map[string]interface{}{
-		"ManagerAddress": fmt.Sprintf("%s:%s", deviceHost, devicePort),
+		"ManagerAddress": host,
 		"UserName":       "admin",
 		"Password":       []byte("P@$$w0rd"),
+		"PostBody":       []byte(`{"Boot":{"BootOrder":["Boot0003","Boot0001","Boot0002"]}}`),
 	}
 	//Unit Test for success scenario
-	e.PATCH("/redfish/v1/Systems/1").WithJSON(requestBody).Expect().Status(http.StatusOK)
+	e.PATCH("/ODIM/v1/Systems/1").WithJSON(requestBody).Expect().Status(http.StatusOK)
+	boot := resourceProperty(t, bmc, "/redfish/v1/Systems/1", "Boot").(map[string]interface{})
+	if bootOrder := boot["BootOrder"].([]interface{}); bootOrder[0] != "Boot0003" {
+		t.Errorf("boot order on the BMC = %v, want Boot0003 first", bootOrder)
+	}
 
 	//Case for invalid token
-	e.PATCH("/redfish/v1/Systems/1").WithHeader("X-Auth-Token", "token").WithJSON(requestBody).Expect().Status(http.StatusUnauthorized)
+	e.PATCH("/ODIM/v1/Systems/1").WithHeader("X-Auth-Token", "token").WithJSON(requestBody).Expect().Status(http.StatusUnauthorized)
+
+	//Case for invalid device credentials
+	requestBody["Password"] = []byte("password")
+	e.PATCH("/ODIM/v1/Systems/1").WithJSON(requestBody).Expect().Status(http.StatusUnauthorized)
 
 	//unittest for bad request scenario: given device details are wrong
 	requestBody1 := "requestbody"
-	e.PATCH("/redfish/v1/Systems/1").WithJSON(requestBody1).Expect().Status(http.StatusBadRequest)
+	e.PATCH("/ODIM/v1/Systems/1").WithJSON(requestBody1).Expect().Status(http.StatusBadRequest)
 }
 
 func TestChangeBiosSettings(t *testing.T) {
 	config.SetUpMockConfig(t)
+	bmc, host := startSimulator(t)
 
-	deviceHost := "localhost"
-	devicePort := "1234"
-	ts := startTestServer(mockDeviceHandler)
-	// Start the server.
-	ts.StartTLS()
-	defer ts.Close()
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/ODIM/v1")
+
+	redfishRoutes.Patch("/Systems/{id}/Bios/Settings", ChangeSettings)
+
+	rfpresponse.PluginToken = "token"
+
+	e := httptest.New(t, mockApp)
+
+	requestBody := map[string]interface{}{
+		"ManagerAddress": host,
+		"UserName":       "admin",
+		"Password":       []byte("P@$$w0rd"),
+		"PostBody":       []byte(`{"Attributes":{"BootMode":"LegacyBios"}}`),
+	}
+	//Unit Test for success scenario
+	e.PATCH("/ODIM/v1/Systems/1/Bios/Settings").WithJSON(requestBody).Expect().Status(http.StatusOK)
+	attributes := resourceProperty(t, bmc, "/redfish/v1/Systems/1/Bios/Settings", "Attributes").(map[string]interface{})
+	if attributes["BootMode"] != "LegacyBios" {
+		t.Errorf("pending BIOS attributes on the BMC = %v, want BootMode LegacyBios", attributes)
+	}
+
+	//Case for invalid token
+	e.PATCH("/ODIM/v1/Systems/1/Bios/Settings").WithHeader("X-Auth-Token", "token").WithJSON(requestBody).Expect().Status(http.StatusUnauthorized)
+
+	//Case for the BIOS attributes, which can be changed only through the settings object
+	e.PATCH("/ODIM/v1/Systems/1/Bios").WithJSON(requestBody).Expect().Status(http.StatusNotFound)
+
+	//unittest for bad request scenario: given device details are wrong
+	requestBody1 := "requestbody"
+	e.PATCH("/ODIM/v1/Systems/1/Bios/Settings").WithJSON(requestBody1).Expect().Status(http.StatusBadRequest)
+}
+
+func TestChangeChassisPowerLimit(t *testing.T) {
+	config.SetUpMockConfig(t)
+	bmc, host := startSimulator(t)
 
 	mockApp := iris.New()
-	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes := mockApp.Party("/ODIM/v1")
 
-	redfishRoutes.Patch("/Systems/{id}/bios/settings", ChangeSettings)
+	redfishRoutes.Patch("/Chassis/{id}/Power", ChangeSettings)
 
 	rfpresponse.PluginToken = "token"
 
 	e := httptest.New(t, mockApp)
 
 	requestBody := map[string]interface{}{
-		"ManagerAddress": fmt.Sprintf("%s:%s", deviceHost, devicePort),
+		"ManagerAddress": host,
 		"UserName":       "admin",
 		"Password":       []byte("P@$$w0rd"),
+		"PostBody":       []byte(`{"PowerControl":[{"PowerLimit":{"LimitInWatts":300,"LimitException":"LogEventOnly"}}]}`),
 	}
 	//Unit Test for success scenario
-	e.PATCH("/redfish/v1/Systems/1/bios/settings").WithJSON(requestBody).Expect().Status(http.StatusOK)
+	e.PATCH("/ODIM/v1/Chassis/1/Power").WithJSON(requestBody).Expect().Status(http.StatusOK)
+	powerControl := resourceProperty(t, bmc, "/redfish/v1/Chassis/1/Power", "PowerControl").([]interface{})
+	if powerLimit := powerControl[0].(map[string]interface{})["PowerLimit"].(map[string]interface{}); powerLimit["LimitInWatts"] != 300.0 {
+		t.Errorf("power limit on the BMC = %v, want 300 watts", powerLimit)
+	}
 
 	//Case for invalid token
-	e.PATCH("/redfish/v1/Systems/1/bios/settings").WithHeader("X-Auth-Token", "token").WithJSON(requestBody).Expect().Status(http.StatusUnauthorized)
+	e.PATCH("/ODIM/v1/Chassis/1/Power").WithHeader("X-Auth-Token", "token").WithJSON(requestBody).Expect().Status(http.StatusUnauthorized)
+
+	//Case for a chassis which is not on the device
+	e.PATCH("/ODIM/v1/Chassis/2/Power").WithJSON(requestBody).Expect().Status(http.StatusNotFound)
 
 	//unittest for bad request scenario: given device details are wrong
 	requestBody1 := "requestbody"
-	e.PATCH("/redfish/v1/Systems/1/bios/settings").WithJSON(requestBody1).Expect().Status(http.StatusBadRequest)
+	e.PATCH("/ODIM/v1/Chassis/1/Power").WithJSON(requestBody1).Expect().Status(http.StatusBadRequest)
 }
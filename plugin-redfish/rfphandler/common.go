@@ -19,19 +19,21 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"strings"
 
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/northbound"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/translation"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
 	log "github.com/sirupsen/logrus"
 )
 
 //translateToSouthBoundURL replacing the request url with south bound translation URL
 func translateToSouthBoundURL(uri string) string {
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
-	return uri
+	return translation.ToSouthBound(uri)
+}
+
+//TokenValidation validates sent token with the list of plugin generated tokens
+func TokenValidation(token string) bool {
+	return northbound.TokenValidation(token)
 }
 
 // queryDevice is for querying a Dell server
@@ -24,7 +24,7 @@ import (
 	"sync"
 
 	dmtfmodel "github.com/ODIM-Project/ODIM/lib-dmtf/model"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/translation"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
 	iris "github.com/kataras/iris/v12"
@@ -46,9 +46,7 @@ func (e *ExternalInterface) GetMetricReport(ctx iris.Context) {
 	reqURI := ctx.Request().RequestURI
 
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		reqURI = strings.Replace(reqURI, key, value, -1)
-	}
+	reqURI = translation.ToSouthBound(reqURI)
 	//Validating the token
 	if token != "" {
 		flag := e.TokenValidation(token)
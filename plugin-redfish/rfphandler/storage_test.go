@@ -16,10 +16,7 @@
 package rfphandler
 
 import (
-	"bytes"
 	"encoding/json"
-	"fmt"
-	"io/ioutil"
 	"net/http"
 	"testing"
 
@@ -30,55 +27,23 @@ import (
 	"github.com/kataras/iris/v12/httptest"
 )
 
-func mockCreateVolume(username, url string) (*http.Response, error) {
-	if url == "/ODIM/v1/Systems/1/Storage/1/Volumes" && username == "admin" {
-		return &http.Response{
-			StatusCode: http.StatusOK,
-			Body:       ioutil.NopCloser(bytes.NewBufferString("Success")),
-		}, nil
+// createdVolume returns the volume from the response body of the volume creation
+func createdVolume(t *testing.T, body string) (volume struct {
+	ODataID string `json:"@odata.id"`
+	ID      string `json:"Id"`
+}) {
+	if err := json.Unmarshal([]byte(body), &volume); err != nil {
+		t.Fatalf("unable to decode the created volume: %v", err)
 	}
-	if url == "/ODIM/v1/Systems/1/Storage/1/Volumes" && username != "admin" {
-		return &http.Response{
-			StatusCode: http.StatusBadRequest,
-			Body:       ioutil.NopCloser(bytes.NewBufferString("Failed")),
-		}, fmt.Errorf("Error")
-	}
-	if url == "/ODIM/v1/Systems/1/Storage/1/Volumes/1" && username == "admin" {
-		return &http.Response{
-			StatusCode: http.StatusOK,
-			Body:       ioutil.NopCloser(bytes.NewBufferString("Success")),
-		}, nil
-	}
-	if url == "/ODIM/v1/Systems/1/Storage/1/Volumes/1" && username != "admin" {
-		return &http.Response{
-			StatusCode: http.StatusBadRequest,
-			Body:       ioutil.NopCloser(bytes.NewBufferString("Failed")),
-		}, fmt.Errorf("Error")
-	}
-	return nil, fmt.Errorf("Error")
-}
-
-func mockDevice(username, password, url string, w http.ResponseWriter) {
-	resp, err := mockCreateVolume(username, url)
-	if err != nil && resp == nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.WriteHeader(resp.StatusCode)
-	return
+	return volume
 }
 
 func TestCreateVolume(t *testing.T) {
 	config.SetUpMockConfig(t)
-	deviceHost := "localhost"
-	devicePort := "1234"
-	ts := startTestServer(mockDevice)
-	// Start the server.
-	ts.StartTLS()
-	defer ts.Close()
+	bmc, host := startSimulator(t)
 
 	mockApp := iris.New()
-	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes := mockApp.Party("/ODIM/v1")
 
 	redfishRoutes.Post("/Systems/{id}/Storage/{rid}/Volumes", CreateVolume)
 
@@ -89,56 +54,80 @@ func TestCreateVolume(t *testing.T) {
 	reqPostBody := map[string]interface{}{
 		"RAIDType": "RAID0",
 		"Links": &dmtf.Links{
-			Drives: []*dmtf.Link{&dmtf.Link{Oid: "/ODIM/v1/Systems/5a9e8356-265c-413b-80d2-58210592d931.1/Storage/ArrayControllers-0/Drives/0"}},
+			Drives: []*dmtf.Link{&dmtf.Link{Oid: "/redfish/v1/Systems/1/Storage/1/Drives/1"}},
 		},
 	}
 	reqBodyBytes, _ := json.Marshal(reqPostBody)
 	requestBody := map[string]interface{}{
-		"ManagerAddress": fmt.Sprintf("%s:%s", deviceHost, devicePort),
+		"ManagerAddress": host,
 		"UserName":       "admin",
 		"Password":       []byte("P@$$w0rd"),
 		"PostBody":       reqBodyBytes,
 	}
 
 	//Unit Test for success scenario
-	e.POST("/redfish/v1/Systems/1/Storage/1/Volumes").WithJSON(requestBody).Expect().Status(http.StatusOK)
+	volume := createdVolume(t, e.POST("/ODIM/v1/Systems/1/Storage/1/Volumes").WithJSON(requestBody).Expect().Status(http.StatusCreated).Body().Raw())
+	if _, ok := bmc.Resource(volume.ODataID); !ok {
+		t.Error("volume is not created on the BMC")
+	}
 
 	//Case for invalid token
-	e.POST("/redfish/v1/Systems/1/Storage/1/Volumes").WithHeader("X-Auth-Token", "token").WithJSON(requestBody).Expect().Status(http.StatusUnauthorized)
+	e.POST("/ODIM/v1/Systems/1/Storage/1/Volumes").WithHeader("X-Auth-Token", "token").WithJSON(requestBody).Expect().Status(http.StatusUnauthorized)
+
+	//Case for a drive which is not on the storage
+	reqPostBody["Links"] = &dmtf.Links{
+		Drives: []*dmtf.Link{&dmtf.Link{Oid: "/redfish/v1/Systems/1/Storage/1/Drives/3"}},
+	}
+	requestBody["PostBody"], _ = json.Marshal(reqPostBody)
+	e.POST("/ODIM/v1/Systems/1/Storage/1/Volumes").WithJSON(requestBody).Expect().Status(http.StatusBadRequest)
 
 	//unittest for bad request scenario
 	invalidRequestBody := "invalid"
-	e.POST("/redfish/v1/Systems/1/Storage/1/Volumes").WithJSON(invalidRequestBody).Expect().Status(http.StatusBadRequest)
+	e.POST("/ODIM/v1/Systems/1/Storage/1/Volumes").WithJSON(invalidRequestBody).Expect().Status(http.StatusBadRequest)
 }
 
-func TesDeleteVolume(t *testing.T) {
+func TestDeleteVolume(t *testing.T) {
 	config.SetUpMockConfig(t)
-	deviceHost := "localhost"
-	devicePort := "1234"
-	ts := startTestServer(mockDevice)
-	// Start the server.
-	ts.StartTLS()
-	defer ts.Close()
+	bmc, host := startSimulator(t)
 
 	mockApp := iris.New()
-	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes := mockApp.Party("/ODIM/v1")
 
-	redfishRoutes.Delete("/Systems/{id}/Storage/{id2}/Volumes/rid", CreateVolume)
+	redfishRoutes.Post("/Systems/{id}/Storage/{rid}/Volumes", CreateVolume)
+	redfishRoutes.Delete("/Systems/{id}/Storage/{rid}/Volumes/{rid2}", DeleteVolume)
 
 	rfpresponse.PluginToken = "token"
 
 	e := httptest.New(t, mockApp)
 
+	reqBodyBytes, _ := json.Marshal(map[string]interface{}{
+		"RAIDType": "RAID1",
+		"Links": &dmtf.Links{
+			Drives: []*dmtf.Link{
+				&dmtf.Link{Oid: "/redfish/v1/Systems/1/Storage/1/Drives/1"},
+				&dmtf.Link{Oid: "/redfish/v1/Systems/1/Storage/1/Drives/2"},
+			},
+		},
+	})
 	requestBody := map[string]interface{}{
-		"ManagerAddress": fmt.Sprintf("%s:%s", deviceHost, devicePort),
+		"ManagerAddress": host,
 		"UserName":       "admin",
 		"Password":       []byte("P@$$w0rd"),
+		"PostBody":       reqBodyBytes,
 	}
-
-	//Unit Test for success scenario
-	e.DELETE("/redfish/v1/Systems/1/Storage/1/Volumes/1").WithJSON(requestBody).Expect().Status(http.StatusOK)
+	volume := createdVolume(t, e.POST("/ODIM/v1/Systems/1/Storage/1/Volumes").WithJSON(requestBody).Expect().Status(http.StatusCreated).Body().Raw())
+	volumeURI, volumeID := volume.ODataID, volume.ID
+	delete(requestBody, "PostBody")
 
 	//Case for invalid token
-	e.DELETE("/redfish/v1/Systems/1/Storage/1/Volumes/1").WithHeader("X-Auth-Token", "token").WithJSON(requestBody).Expect().Status(http.StatusUnauthorized)
+	e.DELETE("/ODIM/v1/Systems/1/Storage/1/Volumes/"+volumeID).WithHeader("X-Auth-Token", "token").WithJSON(requestBody).Expect().Status(http.StatusUnauthorized)
+
+	//Unit Test for success scenario
+	e.DELETE("/ODIM/v1/Systems/1/Storage/1/Volumes/" + volumeID).WithJSON(requestBody).Expect().Status(http.StatusNoContent)
+	if _, ok := bmc.Resource(volumeURI); ok {
+		t.Error("volume is not deleted on the BMC")
+	}
 
+	//Case for a volume which is not on the BMC
+	e.DELETE("/ODIM/v1/Systems/1/Storage/1/Volumes/" + volumeID).WithJSON(requestBody).Expect().Status(http.StatusNotFound)
 }
@@ -16,14 +16,13 @@
 package rfphandler
 
 import (
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/translation"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
 	iris "github.com/kataras/iris/v12"
 	log "github.com/sirupsen/logrus"
 	"io/ioutil"
 	"net/http"
-	"strings"
 )
 
 // SimpleUpdate updates the BMC resources
@@ -52,12 +51,9 @@ func SimpleUpdate(ctx iris.Context) {
 		return
 	}
 
-	var reqData string
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-		reqData = strings.Replace(string(deviceDetails.PostBody), key, value, -1)
-	}
+	uri = translation.ToSouthBound(uri)
+	reqData := translation.ToSouthBound(string(deviceDetails.PostBody))
 
 	device := &rfputilities.RedfishDevice{
 		Host:     deviceDetails.Host,
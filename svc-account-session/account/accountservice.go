@@ -0,0 +1,384 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asresponse"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
+	log "github.com/sirupsen/logrus"
+)
+
+// authenticationTypeUsernameAndPassword is the only supported AuthenticationType
+// of an external account provider
+const authenticationTypeUsernameAndPassword = "UsernameAndPassword"
+
+// accountServiceUpdateRequest is the PATCH request body of the AccountService
+type accountServiceUpdateRequest struct {
+	AccountLockoutThreshold         *int                            `json:"AccountLockoutThreshold,omitempty"`
+	AccountLockoutDuration          *int                            `json:"AccountLockoutDuration,omitempty"`
+	AccountLockoutCounterResetAfter *int                            `json:"AccountLockoutCounterResetAfter,omitempty"`
+	PasswordExpirationDays          *int                            `json:"PasswordExpirationDays,omitempty"`
+	LDAP                            *externalAccountProviderRequest `json:"LDAP,omitempty"`
+	ActiveDirectory                 *externalAccountProviderRequest `json:"ActiveDirectory,omitempty"`
+	Oem                             *accountServiceOemRequest       `json:"Oem,omitempty"`
+}
+
+type accountServiceOemRequest struct {
+	Odim *accountServiceOdimRequest `json:"Odim,omitempty"`
+}
+
+type accountServiceOdimRequest struct {
+	PasswordHistoryCount *int `json:"PasswordHistoryCount,omitempty"`
+}
+
+// externalAccountProviderRequest holds the properties of an external account
+// provider which can be modified, nil properties are left unchanged
+type externalAccountProviderRequest struct {
+	ServiceEnabled    *bool                          `json:"ServiceEnabled,omitempty"`
+	ServiceAddresses  []string                       `json:"ServiceAddresses,omitempty"`
+	Authentication    *providerAuthenticationRequest `json:"Authentication,omitempty"`
+	LDAPService       *ldapServiceRequest            `json:"LDAPService,omitempty"`
+	RemoteRoleMapping []asmodel.RoleMapping          `json:"RemoteRoleMapping,omitempty"`
+}
+
+type providerAuthenticationRequest struct {
+	AuthenticationType *string `json:"AuthenticationType,omitempty"`
+	Username           *string `json:"Username,omitempty"`
+	Password           *string `json:"Password,omitempty"`
+}
+
+type ldapServiceRequest struct {
+	SearchSettings *ldapSearchSettingsRequest `json:"SearchSettings,omitempty"`
+}
+
+type ldapSearchSettingsRequest struct {
+	BaseDistinguishedNames []string `json:"BaseDistinguishedNames,omitempty"`
+	UsernameAttribute      *string  `json:"UsernameAttribute,omitempty"`
+	GroupsAttribute        *string  `json:"GroupsAttribute,omitempty"`
+	GroupNameAttribute     *string  `json:"GroupNameAttribute,omitempty"`
+}
+
+// UpdateAccountService defines the modification of the AccountService properties,
+// which are the account lockout and password policies and
+// the LDAP and ActiveDirectory external account providers.
+//
+// As input parameters we need to pass the request, which contains the properties to be
+// modified, and Session, which contains all session data especially configureUsers privilege.
+//
+// Output is the RPC response, which contains the status code, status message, headers and body.
+func (e *ExternalInterface) UpdateAccountService(req *accountproto.UpdateAccountServiceRequest, session *asmodel.Session) response.RPC {
+	if !session.Privileges[common.PrivilegeConfigureUsers] {
+		errorMessage := "User " + session.UserName + " does not have the privilege to update the account service"
+		resp := common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, errorMessage, []interface{}{}, nil)
+		auth.CustomAuthLog(session.Token, errorMessage, resp.StatusCode)
+		return resp
+	}
+
+	if isEmptyRequest(req.RequestBody) {
+		errMsg := "empty request can not be processed"
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"request body"}, nil)
+	}
+
+	var updateReq accountServiceUpdateRequest
+	if err := json.Unmarshal(req.RequestBody, &updateReq); err != nil {
+		errMsg := "unable to parse the update account service request: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
+	}
+
+	// Validating the request JSON properties for case sensitive
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, updateReq)
+	if err != nil {
+		errMsg := "Request parameters validaton failed: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	} else if invalidProperties != "" {
+		errorMessage := "One or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, nil)
+	}
+	if unknownProperties := getUnsupportedProperties(req.RequestBody, updateReq); unknownProperties != "" {
+		errorMessage := "One or more properties given in the request body can not be modified"
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{unknownProperties}, nil)
+	}
+
+	policy, gerr := e.GetAccountPolicy()
+	if gerr != nil {
+		errorMessage := "Unable to get account policy: " + gerr.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	policyModified, resp := updateAccountPolicy(updateReq, &policy)
+	if resp != nil {
+		return *resp
+	}
+
+	providerRequests := map[string]*externalAccountProviderRequest{
+		asmodel.LDAPProvider:            updateReq.LDAP,
+		asmodel.ActiveDirectoryProvider: updateReq.ActiveDirectory,
+	}
+	// all the providers are validated before any of them is saved,
+	// so that an invalid request does not leave a partial update
+	providers := make(map[string]asmodel.ExternalAccountProvider)
+	var modifiedProviders []string
+	for _, providerType := range []string{asmodel.LDAPProvider, asmodel.ActiveDirectoryProvider} {
+		providerReq := providerRequests[providerType]
+		provider, gerr := e.GetExternalAccountProvider(providerType)
+		if gerr != nil && gerr.ErrNo() != errors.DBKeyNotFound {
+			errorMessage := "Unable to get " + providerType + " configuration: " + gerr.Error()
+			log.Error(errorMessage)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+		if providerReq == nil {
+			if gerr == nil {
+				providers[providerType] = provider
+			}
+			continue
+		}
+		if resp := e.updateExternalAccountProvider(providerType, providerReq, &provider); resp != nil {
+			return *resp
+		}
+		providers[providerType] = provider
+		modifiedProviders = append(modifiedProviders, providerType)
+	}
+
+	for _, providerType := range modifiedProviders {
+		if serr := e.SaveExternalAccountProvider(providerType, providers[providerType]); serr != nil {
+			errorMessage := "Unable to save " + providerType + " configuration: " + serr.Error()
+			log.Error(errorMessage)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+		log.Info(providerType + " external account provider is updated by " + session.UserName)
+	}
+	if policyModified {
+		if serr := e.SaveAccountPolicy(policy); serr != nil {
+			errorMessage := "Unable to save account policy: " + serr.Error()
+			log.Error(errorMessage)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+		log.Info("Account policy is updated by " + session.UserName)
+	}
+
+	return accountServiceResponse(policy, providers)
+}
+
+// updateAccountPolicy applies the account lockout and password policy properties of the
+// request on the policy, and reports whether any of them is present in the request.
+// It returns the error response when the request is not valid.
+func updateAccountPolicy(updateReq accountServiceUpdateRequest, policy *asmodel.AccountPolicy) (bool, *response.RPC) {
+	var passwordHistoryCount *int
+	if updateReq.Oem != nil && updateReq.Oem.Odim != nil {
+		passwordHistoryCount = updateReq.Oem.Odim.PasswordHistoryCount
+	}
+	properties := []struct {
+		name  string
+		value *int
+		field *int
+	}{
+		{"AccountLockoutThreshold", updateReq.AccountLockoutThreshold, &policy.AccountLockoutThreshold},
+		{"AccountLockoutDuration", updateReq.AccountLockoutDuration, &policy.AccountLockoutDuration},
+		{"AccountLockoutCounterResetAfter", updateReq.AccountLockoutCounterResetAfter, &policy.AccountLockoutCounterResetAfter},
+		{"PasswordExpirationDays", updateReq.PasswordExpirationDays, &policy.PasswordExpirationDays},
+		{"Oem/Odim/PasswordHistoryCount", passwordHistoryCount, &policy.PasswordHistoryCount},
+	}
+	passwordExpirationEnabled := policy.PasswordExpirationDays != 0
+	modified := false
+	for _, property := range properties {
+		if property.value == nil {
+			continue
+		}
+		if *property.value < 0 {
+			errorMessage := property.name + " can not be negative"
+			log.Error(errorMessage)
+			resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage, []interface{}{strconv.Itoa(*property.value), property.name}, nil)
+			return false, &resp
+		}
+		*property.field = *property.value
+		modified = true
+	}
+	if !passwordExpirationEnabled && policy.PasswordExpirationDays != 0 {
+		policy.PasswordExpirationEnabledTime = time.Now()
+	}
+	// the failed login counter has to be reset before the lock is released
+	if policy.AccountLockoutDuration != 0 && policy.AccountLockoutDuration < policy.AccountLockoutCounterResetAfter {
+		errorMessage := "AccountLockoutCounterResetAfter should not be greater than AccountLockoutDuration"
+		log.Error(errorMessage)
+		resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errorMessage, []interface{}{"AccountLockoutCounterResetAfter", "AccountLockoutDuration"}, nil)
+		return false, &resp
+	}
+	return modified, nil
+}
+
+// updateExternalAccountProvider applies the request on the provider and validates the result.
+// It returns the error response when the request is not valid.
+func (e *ExternalInterface) updateExternalAccountProvider(providerType string, providerReq *externalAccountProviderRequest, provider *asmodel.ExternalAccountProvider) *response.RPC {
+	if providerReq.ServiceEnabled != nil {
+		provider.ServiceEnabled = *providerReq.ServiceEnabled
+	}
+	if providerReq.ServiceAddresses != nil {
+		for _, address := range providerReq.ServiceAddresses {
+			serviceURL, err := url.Parse(address)
+			if err != nil || (serviceURL.Scheme != "ldap" && serviceURL.Scheme != "ldaps") || serviceURL.Host == "" {
+				errorMessage := "Invalid service address " + address + ", it should be an ldap:// or ldaps:// URL"
+				log.Error(errorMessage)
+				resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage, []interface{}{address, providerType + "/ServiceAddresses"}, nil)
+				return &resp
+			}
+		}
+		provider.ServiceAddresses = providerReq.ServiceAddresses
+	}
+	if authReq := providerReq.Authentication; authReq != nil {
+		if authReq.AuthenticationType != nil {
+			if *authReq.AuthenticationType != authenticationTypeUsernameAndPassword {
+				errorMessage := "Unsupported AuthenticationType " + *authReq.AuthenticationType
+				log.Error(errorMessage)
+				resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage, []interface{}{*authReq.AuthenticationType, providerType + "/Authentication/AuthenticationType"}, nil)
+				return &resp
+			}
+			provider.Authentication.AuthenticationType = *authReq.AuthenticationType
+		}
+		if authReq.Username != nil {
+			provider.Authentication.Username = *authReq.Username
+		}
+		if authReq.Password != nil {
+			encryptedPassword, err := common.EncryptWithPublicKey([]byte(*authReq.Password))
+			if err != nil {
+				errorMessage := "Unable to encrypt the " + providerType + " service account password: " + err.Error()
+				log.Error(errorMessage)
+				resp := common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+				return &resp
+			}
+			provider.Authentication.Password = encryptedPassword
+		}
+	}
+	if provider.Authentication.AuthenticationType == "" {
+		provider.Authentication.AuthenticationType = authenticationTypeUsernameAndPassword
+	}
+	if providerReq.LDAPService != nil && providerReq.LDAPService.SearchSettings != nil {
+		settingsReq := providerReq.LDAPService.SearchSettings
+		settings := &provider.LDAPService.SearchSettings
+		if settingsReq.BaseDistinguishedNames != nil {
+			settings.BaseDistinguishedNames = settingsReq.BaseDistinguishedNames
+		}
+		if settingsReq.UsernameAttribute != nil {
+			settings.UsernameAttribute = *settingsReq.UsernameAttribute
+		}
+		if settingsReq.GroupsAttribute != nil {
+			settings.GroupsAttribute = *settingsReq.GroupsAttribute
+		}
+		if settingsReq.GroupNameAttribute != nil {
+			settings.GroupNameAttribute = *settingsReq.GroupNameAttribute
+		}
+	}
+	if providerReq.RemoteRoleMapping != nil {
+		for _, mapping := range providerReq.RemoteRoleMapping {
+			if mapping.RemoteGroup == "" {
+				errorMessage := "RemoteGroup is missing in the RemoteRoleMapping of " + providerType
+				log.Error(errorMessage)
+				resp := common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage, []interface{}{providerType + "/RemoteRoleMapping/RemoteGroup"}, nil)
+				return &resp
+			}
+			if _, err := e.GetRoleDetailsByID(mapping.LocalRole); err != nil {
+				errorMessage := "Invalid LocalRole " + mapping.LocalRole + " present in the RemoteRoleMapping of " + providerType
+				log.Error(errorMessage)
+				resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage, []interface{}{mapping.LocalRole, providerType + "/RemoteRoleMapping/LocalRole"}, nil)
+				return &resp
+			}
+		}
+		provider.RemoteRoleMapping = providerReq.RemoteRoleMapping
+	}
+
+	if provider.ServiceEnabled {
+		if len(provider.ServiceAddresses) == 0 {
+			errorMessage := "ServiceAddresses are required for enabling " + providerType
+			log.Error(errorMessage)
+			resp := common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage, []interface{}{providerType + "/ServiceAddresses"}, nil)
+			return &resp
+		}
+		if len(provider.LDAPService.SearchSettings.BaseDistinguishedNames) == 0 {
+			errorMessage := "BaseDistinguishedNames are required for enabling " + providerType
+			log.Error(errorMessage)
+			resp := common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage, []interface{}{providerType + "/LDAPService/SearchSettings/BaseDistinguishedNames"}, nil)
+			return &resp
+		}
+	}
+	return nil
+}
+
+// getUnsupportedProperties returns the top level properties of the request
+// which are not modifiable through the AccountService
+func getUnsupportedProperties(requestBody []byte, updateReq accountServiceUpdateRequest) string {
+	var request map[string]interface{}
+	json.Unmarshal(requestBody, &request)
+	supported := map[string]bool{
+		"AccountLockoutThreshold":         true,
+		"AccountLockoutDuration":          true,
+		"AccountLockoutCounterResetAfter": true,
+		"PasswordExpirationDays":          true,
+		asmodel.LDAPProvider:              true,
+		asmodel.ActiveDirectoryProvider:   true,
+		"Oem":                             true,
+	}
+	var unsupported []string
+	for key := range request {
+		if !supported[key] {
+			unsupported = append(unsupported, key)
+		}
+	}
+	sort.Strings(unsupported)
+	return strings.Join(unsupported, " ")
+}
+
+// externalAccountProviderResponse converts the stored provider to the AccountService
+// representation, the service account password is never returned
+func externalAccountProviderResponse(provider asmodel.ExternalAccountProvider) *asresponse.ExternalAccountProvider {
+	resp := asresponse.ExternalAccountProvider{
+		ServiceEnabled:   provider.ServiceEnabled,
+		ServiceAddresses: provider.ServiceAddresses,
+		Authentication: asresponse.ProviderAuthentication{
+			AuthenticationType: provider.Authentication.AuthenticationType,
+			Username:           provider.Authentication.Username,
+		},
+		LDAPService: asresponse.LDAPService{
+			SearchSettings: asresponse.LDAPSearchSettings{
+				BaseDistinguishedNames: provider.LDAPService.SearchSettings.BaseDistinguishedNames,
+				UsernameAttribute:      provider.LDAPService.SearchSettings.UsernameAttribute,
+				GroupsAttribute:        provider.LDAPService.SearchSettings.GroupsAttribute,
+				GroupNameAttribute:     provider.LDAPService.SearchSettings.GroupNameAttribute,
+			},
+		},
+	}
+	for _, mapping := range provider.RemoteRoleMapping {
+		resp.RemoteRoleMapping = append(resp.RemoteRoleMapping, asresponse.RoleMapping{
+			RemoteGroup: mapping.RemoteGroup,
+			LocalRole:   mapping.LocalRole,
+		})
+	}
+	return &resp
+}
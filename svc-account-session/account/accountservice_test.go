@@ -0,0 +1,300 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asresponse"
+)
+
+var mockExternalAccountProviders = map[string]asmodel.ExternalAccountProvider{}
+
+func mockGetExternalAccountProvider(providerType string) (asmodel.ExternalAccountProvider, *errors.Error) {
+	provider, ok := mockExternalAccountProviders[providerType]
+	if !ok {
+		return provider, errors.PackError(errors.DBKeyNotFound, "no data with the with key "+providerType+" found")
+	}
+	return provider, nil
+}
+
+func mockSaveExternalAccountProvider(providerType string, provider asmodel.ExternalAccountProvider) *errors.Error {
+	mockExternalAccountProviders[providerType] = provider
+	return nil
+}
+
+var mockAccountPolicy asmodel.AccountPolicy
+
+func mockGetAccountPolicy() (asmodel.AccountPolicy, *errors.Error) {
+	return mockAccountPolicy, nil
+}
+
+func mockSaveAccountPolicy(policy asmodel.AccountPolicy) *errors.Error {
+	mockAccountPolicy = policy
+	return nil
+}
+
+func TestUpdateAccountService(t *testing.T) {
+	config.SetUpMockConfig(t)
+	acc := getMockExternalInterface()
+	defer func() {
+		mockExternalAccountProviders = map[string]asmodel.ExternalAccountProvider{}
+	}()
+	mockExternalAccountProviders[asmodel.ActiveDirectoryProvider] = asmodel.ExternalAccountProvider{
+		ServiceAddresses: []string{"ldaps://ad.example.com"},
+	}
+	adminSession := &asmodel.Session{
+		UserName: "admin",
+		Privileges: map[string]bool{
+			common.PrivilegeConfigureUsers: true,
+		},
+	}
+
+	validRequest, _ := json.Marshal(map[string]interface{}{
+		"LDAP": map[string]interface{}{
+			"ServiceEnabled":   true,
+			"ServiceAddresses": []string{"ldap://10.0.0.1:389", "ldaps://ldap.example.com"},
+			"Authentication": map[string]interface{}{
+				"Username": "cn=odim,dc=example,dc=com",
+				"Password": "secret",
+			},
+			"LDAPService": map[string]interface{}{
+				"SearchSettings": map[string]interface{}{
+					"BaseDistinguishedNames": []string{"ou=people,dc=example,dc=com"},
+					"UsernameAttribute":      "uid",
+				},
+			},
+			"RemoteRoleMapping": []map[string]string{
+				{"RemoteGroup": "admins", "LocalRole": common.RoleAdmin},
+			},
+		},
+	})
+	tests := []struct {
+		name        string
+		session     *asmodel.Session
+		reqBody     []byte
+		wantCode    int32
+		wantMessage string
+	}{
+		{
+			name:        "insufficient privilege",
+			session:     &asmodel.Session{UserName: "operator", Privileges: map[string]bool{common.PrivilegeLogin: true}},
+			reqBody:     validRequest,
+			wantCode:    http.StatusForbidden,
+			wantMessage: response.InsufficientPrivilege,
+		},
+		{
+			name:        "empty request",
+			session:     adminSession,
+			reqBody:     []byte(`{}`),
+			wantCode:    http.StatusBadRequest,
+			wantMessage: response.PropertyMissing,
+		},
+		{
+			name:        "malformed request",
+			session:     adminSession,
+			reqBody:     []byte(`{"LDAP": {"ServiceEnabled": "yes"}}`),
+			wantCode:    http.StatusBadRequest,
+			wantMessage: response.MalformedJSON,
+		},
+		{
+			name:        "property in wrong case",
+			session:     adminSession,
+			reqBody:     []byte(`{"LDAP": {"serviceEnabled": false}}`),
+			wantCode:    http.StatusBadRequest,
+			wantMessage: response.PropertyUnknown,
+		},
+		{
+			name:        "property which can not be modified",
+			session:     adminSession,
+			reqBody:     []byte(`{"MinPasswordLength": 14}`),
+			wantCode:    http.StatusBadRequest,
+			wantMessage: response.PropertyUnknown,
+		},
+		{
+			name:        "invalid service address",
+			session:     adminSession,
+			reqBody:     []byte(`{"LDAP": {"ServiceAddresses": ["https://10.0.0.1"]}}`),
+			wantCode:    http.StatusBadRequest,
+			wantMessage: response.PropertyValueFormatError,
+		},
+		{
+			name:        "unsupported authentication type",
+			session:     adminSession,
+			reqBody:     []byte(`{"LDAP": {"Authentication": {"AuthenticationType": "KerberosKeytab"}}}`),
+			wantCode:    http.StatusBadRequest,
+			wantMessage: response.PropertyValueNotInList,
+		},
+		{
+			name:        "unknown local role",
+			session:     adminSession,
+			reqBody:     []byte(`{"LDAP": {"RemoteRoleMapping": [{"RemoteGroup": "admins", "LocalRole": "xyz"}]}}`),
+			wantCode:    http.StatusBadRequest,
+			wantMessage: response.PropertyValueNotInList,
+		},
+		{
+			name:        "missing remote group",
+			session:     adminSession,
+			reqBody:     []byte(`{"LDAP": {"RemoteRoleMapping": [{"LocalRole": "Administrator"}]}}`),
+			wantCode:    http.StatusBadRequest,
+			wantMessage: response.PropertyMissing,
+		},
+		{
+			name:        "enabling without base distinguished names",
+			session:     adminSession,
+			reqBody:     []byte(`{"ActiveDirectory": {"ServiceEnabled": true}}`),
+			wantCode:    http.StatusBadRequest,
+			wantMessage: response.PropertyMissing,
+		},
+		{
+			name:        "successful update",
+			session:     adminSession,
+			reqBody:     validRequest,
+			wantCode:    http.StatusOK,
+			wantMessage: response.Success,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := acc.UpdateAccountService(&accountproto.UpdateAccountServiceRequest{RequestBody: tt.reqBody}, tt.session)
+			if got.StatusCode != tt.wantCode || got.StatusMessage != tt.wantMessage {
+				t.Errorf("UpdateAccountService() = %v %v, want %v %v", got.StatusCode, got.StatusMessage, tt.wantCode, tt.wantMessage)
+			}
+		})
+	}
+
+	provider := mockExternalAccountProviders[asmodel.LDAPProvider]
+	password, err := common.DecryptWithPrivateKey(provider.Authentication.Password)
+	if err != nil || string(password) != "secret" {
+		t.Errorf("service account password should be stored encrypted, got %v, %v", string(password), err)
+	}
+	if provider.Authentication.AuthenticationType != "UsernameAndPassword" {
+		t.Errorf("AuthenticationType = %v, want UsernameAndPassword", provider.Authentication.AuthenticationType)
+	}
+
+	// a later PATCH only modifies the given properties
+	got := acc.UpdateAccountService(&accountproto.UpdateAccountServiceRequest{
+		RequestBody: []byte(`{"LDAP": {"ServiceEnabled": false}}`),
+	}, adminSession)
+	if got.StatusCode != http.StatusOK {
+		t.Fatalf("UpdateAccountService() status = %v, want %v", got.StatusCode, http.StatusOK)
+	}
+	accountService := got.Body.(asresponse.AccountService)
+	want := &asresponse.ExternalAccountProvider{
+		ServiceEnabled:   false,
+		ServiceAddresses: []string{"ldap://10.0.0.1:389", "ldaps://ldap.example.com"},
+		Authentication: asresponse.ProviderAuthentication{
+			AuthenticationType: "UsernameAndPassword",
+			Username:           "cn=odim,dc=example,dc=com",
+		},
+		LDAPService: asresponse.LDAPService{
+			SearchSettings: asresponse.LDAPSearchSettings{
+				BaseDistinguishedNames: []string{"ou=people,dc=example,dc=com"},
+				UsernameAttribute:      "uid",
+			},
+		},
+		RemoteRoleMapping: []asresponse.RoleMapping{
+			{RemoteGroup: "admins", LocalRole: common.RoleAdmin},
+		},
+	}
+	if !reflect.DeepEqual(accountService.LDAP, want) {
+		t.Errorf("UpdateAccountService() LDAP = %v, want %v", accountService.LDAP, want)
+	}
+	if accountService.ActiveDirectory == nil || accountService.ActiveDirectory.ServiceEnabled {
+		t.Errorf("UpdateAccountService() should return the unchanged ActiveDirectory provider, got %v", accountService.ActiveDirectory)
+	}
+
+	// nothing is saved when any part of the request is invalid
+	got = acc.UpdateAccountService(&accountproto.UpdateAccountServiceRequest{
+		RequestBody: []byte(`{"LDAP": {"ServiceEnabled": true}, "ActiveDirectory": {"ServiceAddresses": ["https://10.0.0.1"]}, "AccountLockoutThreshold": 3}`),
+	}, adminSession)
+	if got.StatusCode != http.StatusBadRequest {
+		t.Fatalf("UpdateAccountService() status = %v, want %v", got.StatusCode, http.StatusBadRequest)
+	}
+	if mockExternalAccountProviders[asmodel.LDAPProvider].ServiceEnabled {
+		t.Errorf("UpdateAccountService() should not save LDAP when ActiveDirectory is invalid")
+	}
+	if mockAccountPolicy.AccountLockoutThreshold == 3 {
+		t.Errorf("UpdateAccountService() should not save the account policy when ActiveDirectory is invalid")
+	}
+}
+
+func TestUpdateAccountServiceAccountPolicy(t *testing.T) {
+	config.SetUpMockConfig(t)
+	acc := getMockExternalInterface()
+	defer func() {
+		mockAccountPolicy = asmodel.AccountPolicy{}
+	}()
+	adminSession := &asmodel.Session{
+		UserName: "admin",
+		Privileges: map[string]bool{
+			common.PrivilegeConfigureUsers: true,
+		},
+	}
+	tests := []struct {
+		name        string
+		reqBody     string
+		wantCode    int32
+		wantMessage string
+	}{
+		{"negative threshold", `{"AccountLockoutThreshold": -1}`, http.StatusBadRequest, response.PropertyValueFormatError},
+		{"negative password history", `{"Oem": {"Odim": {"PasswordHistoryCount": -1}}}`, http.StatusBadRequest, response.PropertyValueFormatError},
+		{"counter reset after the lockout ends", `{"AccountLockoutDuration": 60, "AccountLockoutCounterResetAfter": 120}`, http.StatusBadRequest, response.PropertyValueConflict},
+		{"successful update", `{"AccountLockoutThreshold": 5, "AccountLockoutDuration": 600, "AccountLockoutCounterResetAfter": 300, "PasswordExpirationDays": 90, "Oem": {"Odim": {"PasswordHistoryCount": 4}}}`, http.StatusOK, response.Success},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := acc.UpdateAccountService(&accountproto.UpdateAccountServiceRequest{RequestBody: []byte(tt.reqBody)}, adminSession)
+			if got.StatusCode != tt.wantCode || got.StatusMessage != tt.wantMessage {
+				t.Errorf("UpdateAccountService() = %v %v, want %v %v", got.StatusCode, got.StatusMessage, tt.wantCode, tt.wantMessage)
+			}
+		})
+	}
+
+	want := asmodel.AccountPolicy{
+		AccountLockoutThreshold:         5,
+		AccountLockoutDuration:          600,
+		AccountLockoutCounterResetAfter: 300,
+		PasswordExpirationDays:          90,
+		PasswordHistoryCount:            4,
+	}
+	// enabling the password expiration records when it got enabled
+	if mockAccountPolicy.PasswordExpirationEnabledTime.IsZero() {
+		t.Errorf("saved account policy does not have PasswordExpirationEnabledTime")
+	}
+	want.PasswordExpirationEnabledTime = mockAccountPolicy.PasswordExpirationEnabledTime
+	if mockAccountPolicy != want {
+		t.Errorf("saved account policy = %v, want %v", mockAccountPolicy, want)
+	}
+
+	// a later PATCH only modifies the given properties
+	got := acc.UpdateAccountService(&accountproto.UpdateAccountServiceRequest{
+		RequestBody: []byte(`{"AccountLockoutThreshold": 0}`),
+	}, adminSession)
+	accountService := got.Body.(asresponse.AccountService)
+	if accountService.AccountLockoutThreshold != 0 || accountService.AccountLockoutDuration != 600 ||
+		!accountService.AccountLockoutCounterResetEnabled || accountService.PasswordExpirationDays != 90 ||
+		accountService.Oem.Odim.PasswordHistoryCount != 4 {
+		t.Errorf("UpdateAccountService() returned unexpected policy %+v", accountService)
+	}
+}
@@ -15,8 +15,11 @@
 package account
 
 import (
+	"time"
+
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asresponse"
 )
 
 const (
@@ -25,18 +28,36 @@ const (
 
 // ExternalInterface holds all the external connections account package functions uses
 type ExternalInterface struct {
-	CreateUser         func(asmodel.User) *errors.Error
-	GetUserDetails     func(string) (asmodel.User, *errors.Error)
-	GetRoleDetailsByID func(string) (asmodel.Role, *errors.Error)
-	UpdateUserDetails  func(asmodel.User, asmodel.User) *errors.Error
+	CreateUser                  func(asmodel.User) *errors.Error
+	GetUserDetails              func(string) (asmodel.User, *errors.Error)
+	GetRoleDetailsByID          func(string) (asmodel.Role, *errors.Error)
+	UpdateUserDetails           func(asmodel.User, asmodel.User) *errors.Error
+	GetExternalAccountProvider  func(string) (asmodel.ExternalAccountProvider, *errors.Error)
+	SaveExternalAccountProvider func(string, asmodel.ExternalAccountProvider) *errors.Error
+	GetAccountPolicy            func() (asmodel.AccountPolicy, *errors.Error)
+	SaveAccountPolicy           func(asmodel.AccountPolicy) *errors.Error
 }
 
 // GetExternalInterface retrieves all the external connections account package functions uses
 func GetExternalInterface() *ExternalInterface {
 	return &ExternalInterface{
-		CreateUser:         asmodel.CreateUser,
-		GetUserDetails:     asmodel.GetUserDetails,
-		GetRoleDetailsByID: asmodel.GetRoleDetailsByID,
-		UpdateUserDetails:  asmodel.UpdateUserDetails,
+		CreateUser:                  asmodel.CreateUser,
+		GetUserDetails:              asmodel.GetUserDetails,
+		GetRoleDetailsByID:          asmodel.GetRoleDetailsByID,
+		UpdateUserDetails:           asmodel.UpdateUserDetails,
+		GetExternalAccountProvider:  asmodel.GetExternalAccountProvider,
+		SaveExternalAccountProvider: asmodel.SaveExternalAccountProvider,
+		GetAccountPolicy:            asmodel.GetAccountPolicy,
+		SaveAccountPolicy:           asmodel.SaveAccountPolicy,
+	}
+}
+
+// setAccountStatus fills the lockout and password status of the user in the account response
+func setAccountStatus(account *asresponse.Account, user asmodel.User, policy asmodel.AccountPolicy) {
+	now := time.Now()
+	account.Locked = policy.IsLocked(user, now)
+	account.PasswordChangeRequired = user.PasswordChangeRequired || policy.IsPasswordExpired(user, now)
+	if expiration := policy.PasswordExpiration(user); !expiration.IsZero() {
+		account.PasswordExpiration = expiration.UTC().Format(time.RFC3339)
 	}
 }
@@ -43,10 +43,14 @@ func TestGetExternalInterface(t *testing.T) {
 
 func getMockExternalInterface() *ExternalInterface {
 	return &ExternalInterface{
-		CreateUser:         mockCreateUser,
-		GetUserDetails:     mockGetUserDetails,
-		GetRoleDetailsByID: mockGetRoleDetailsByID,
-		UpdateUserDetails:  mockUpdateUserDetails,
+		CreateUser:                  mockCreateUser,
+		GetUserDetails:              mockGetUserDetails,
+		GetRoleDetailsByID:          mockGetRoleDetailsByID,
+		UpdateUserDetails:           mockUpdateUserDetails,
+		GetExternalAccountProvider:  mockGetExternalAccountProvider,
+		SaveExternalAccountProvider: mockSaveExternalAccountProvider,
+		GetAccountPolicy:            mockGetAccountPolicy,
+		SaveAccountPolicy:           mockSaveAccountPolicy,
 	}
 }
 
@@ -27,6 +27,7 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
@@ -84,6 +85,9 @@ func (e *ExternalInterface) Create(req *accountproto.CreateAccountRequest, sessi
 		Password: createAccount.Password,
 		RoleID:   createAccount.RoleID,
 	}
+	if createAccount.PasswordChangeRequired != nil {
+		user.PasswordChangeRequired = *createAccount.PasswordChangeRequired
+	}
 
 	if !(session.Privileges[common.PrivilegeConfigureUsers]) {
 		errorMessage := "User does not have the privilege to create a new user"
@@ -124,6 +128,11 @@ func (e *ExternalInterface) Create(req *accountproto.CreateAccountRequest, sessi
 		log.Error(errorMessage)
 		return resp, fmt.Errorf(errorMessage)
 	}
+	if createAccount.Locked != nil && *createAccount.Locked {
+		errorMessage := "Account can not be created in locked state"
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage, []interface{}{"true", "Locked"}, nil), fmt.Errorf(errorMessage)
+	}
 	if _, gerr := e.GetRoleDetailsByID(user.RoleID); gerr != nil {
 		errorMessage := "Invalid RoleID present " + gerr.Error()
 		log.Error(errorMessage)
@@ -154,7 +163,15 @@ func (e *ExternalInterface) Create(req *accountproto.CreateAccountRequest, sessi
 	hashSum := hash.Sum(nil)
 	hashedPassword := base64.URLEncoding.EncodeToString(hashSum)
 	user.Password = hashedPassword
+	user.PasswordChangedTime = time.Now()
 	user.AccountTypes = []string{"Redfish"}
+	policy, gerr := e.GetAccountPolicy()
+	if gerr != nil {
+		errorMessage := "Unable to get account policy: " + gerr.Error()
+		resp.CreateInternalErrorResponse(errorMessage)
+		log.Error(errorMessage)
+		return resp, fmt.Errorf(errorMessage)
+	}
 	if cerr := e.CreateUser(user); cerr != nil {
 		errorMessage := "Unable to add new user: " + cerr.Error()
 		if errors.DBKeyAlreadyExist == cerr.ErrNo() {
@@ -188,7 +205,7 @@ func (e *ExternalInterface) Create(req *accountproto.CreateAccountRequest, sessi
 	}
 
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
-	resp.Body = asresponse.Account{
+	account := asresponse.Account{
 		Response:     commonResponse,
 		UserName:     user.UserName,
 		RoleID:       user.RoleID,
@@ -199,6 +216,8 @@ func (e *ExternalInterface) Create(req *accountproto.CreateAccountRequest, sessi
 			},
 		},
 	}
+	setAccountStatus(&account, user, policy)
+	resp.Body = account
 
 	return resp, nil
 
@@ -171,6 +171,14 @@ func GetAccount(session *asmodel.Session, accountID string) response.RPC {
 		return resp
 	}
 
+	policy, err := asmodel.GetAccountPolicy()
+	if err != nil {
+		errorMessage := "Unable to get account policy: " + err.Error()
+		resp.CreateInternalErrorResponse(errorMessage)
+		log.Error(errorMessage)
+		return resp
+	}
+
 	resp.StatusCode = http.StatusOK
 	resp.StatusMessage = response.Success
 
@@ -182,7 +190,7 @@ func GetAccount(session *asmodel.Session, accountID string) response.RPC {
 	commonResponse.Message = ""
 	commonResponse.MessageID = ""
 	commonResponse.Severity = ""
-	resp.Body = asresponse.Account{
+	account := asresponse.Account{
 		Response:     commonResponse,
 		UserName:     user.UserName,
 		RoleID:       user.RoleID,
@@ -193,17 +201,45 @@ func GetAccount(session *asmodel.Session, accountID string) response.RPC {
 			},
 		},
 	}
+	setAccountStatus(&account, user, policy)
+	resp.Body = account
 
 	return resp
 
 }
 
 // GetAccountService defines the functionality for knowing whether
-// the account service is enabled or not
+// the account service is enabled or not, along with the account lockout and
+// password policies and the configured LDAP and ActiveDirectory external account providers
 //
 // As return parameters RPC response, which contains status code, message, headers and data,
 // error will be passed back.
 func GetAccountService() response.RPC {
+	policy, err := asmodel.GetAccountPolicy()
+	if err != nil {
+		errorMessage := "Unable to get account policy: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	providers := make(map[string]asmodel.ExternalAccountProvider)
+	for _, providerType := range []string{asmodel.LDAPProvider, asmodel.ActiveDirectoryProvider} {
+		provider, err := asmodel.GetExternalAccountProvider(providerType)
+		if err != nil {
+			if err.ErrNo() == errors.DBKeyNotFound {
+				continue
+			}
+			errorMessage := "Unable to get " + providerType + " configuration: " + err.Error()
+			log.Error(errorMessage)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+		providers[providerType] = provider
+	}
+	return accountServiceResponse(policy, providers)
+}
+
+// accountServiceResponse builds the AccountService response with the account
+// policy and the external account providers which are configured
+func accountServiceResponse(policy asmodel.AccountPolicy, providers map[string]asmodel.ExternalAccountProvider) response.RPC {
 	commonResponse := response.Response{
 		OdataType:    common.AccountServiceType,
 		OdataID:      "/redfish/v1/AccountService",
@@ -234,22 +270,39 @@ func GetAccountService() response.RPC {
 	commonResponse.Message = ""
 	commonResponse.MessageID = ""
 	commonResponse.Severity = ""
-	resp.Body = asresponse.AccountService{
+	accountService := asresponse.AccountService{
 		Response: commonResponse,
 		//TODO: Yet to implement AccountService state and health
 		Status: asresponse.Status{
 			State:  serviceState,
 			Health: "OK",
 		},
-		ServiceEnabled:    isServiceEnabled,
-		MinPasswordLength: config.Data.AuthConf.PasswordRules.MinPasswordLength,
+		ServiceEnabled:                    isServiceEnabled,
+		MinPasswordLength:                 config.Data.AuthConf.PasswordRules.MinPasswordLength,
+		AccountLockoutThreshold:           policy.AccountLockoutThreshold,
+		AccountLockoutDuration:            policy.AccountLockoutDuration,
+		AccountLockoutCounterResetAfter:   policy.AccountLockoutCounterResetAfter,
+		AccountLockoutCounterResetEnabled: policy.AccountLockoutCounterResetAfter != 0,
+		PasswordExpirationDays:            policy.PasswordExpirationDays,
 		Accounts: asresponse.Accounts{
 			OdataID: "/redfish/v1/AccountService/Accounts",
 		},
 		Roles: asresponse.Accounts{
 			OdataID: "/redfish/v1/AccountService/Roles",
 		},
+		Oem: &asresponse.AccountServiceOem{
+			Odim: asresponse.AccountServiceOdimOem{
+				PasswordHistoryCount: policy.PasswordHistoryCount,
+			},
+		},
+	}
+	if provider, ok := providers[asmodel.LDAPProvider]; ok {
+		accountService.LDAP = externalAccountProviderResponse(provider)
+	}
+	if provider, ok := providers[asmodel.ActiveDirectoryProvider]; ok {
+		accountService.ActiveDirectory = externalAccountProviderResponse(provider)
 	}
+	resp.Body = accountService
 
 	return resp
 
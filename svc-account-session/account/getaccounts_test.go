@@ -337,6 +337,7 @@ func TestGetAccountService(t *testing.T) {
 					Roles: asresponse.Accounts{
 						OdataID: "/redfish/v1/AccountService/Roles",
 					},
+					Oem: &asresponse.AccountServiceOem{},
 				},
 			},
 		},
@@ -362,6 +363,7 @@ func TestGetAccountService(t *testing.T) {
 					Roles: asresponse.Accounts{
 						OdataID: "/redfish/v1/AccountService/Roles",
 					},
+					Oem: &asresponse.AccountServiceOem{},
 				},
 			},
 		},
@@ -31,6 +31,8 @@ import (
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/crypto/sha3"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 // Update defines the updation of the account details. Every account details can be
@@ -39,6 +41,7 @@ import (
 // For updating an account, two parameters need to be passed UpdateAccountRequest and Session.
 // New Password and RoleID will be part of UpdateAccountRequest,
 // and Session parameter will have all session related data, espically the privileges.
+// Unlocking the account and modifying PasswordChangeRequired needs ConfigureUsers privilege.
 //
 // Output is the RPC response, which contains the status code, status message, headers and body.
 func (e *ExternalInterface) Update(req *accountproto.UpdateAccountRequest, session *asmodel.Session) response.RPC {
@@ -206,6 +209,27 @@ func (e *ExternalInterface) Update(req *accountproto.UpdateAccountRequest, sessi
 		}
 	}
 
+	// Unlocking the account and forcing the password change are allowed only with PrivilegeConfigureUsers
+	if (updateAccount.Locked != nil || updateAccount.PasswordChangeRequired != nil) && !session.Privileges[common.PrivilegeConfigureUsers] {
+		errorMessage := "User does not have the privilege to modify the lockout and password status of any account, including his own account"
+		resp := common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, errorMessage, []interface{}{}, nil)
+		auth.CustomAuthLog(session.Token, errorMessage, resp.StatusCode)
+		return resp
+	}
+	if updateAccount.Locked != nil && *updateAccount.Locked {
+		errorMessage := "Locked can only be set to false for unlocking the account"
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage, []interface{}{"true", "Locked"}, nil)
+	}
+
+	policy, gerr := e.GetAccountPolicy()
+	if gerr != nil {
+		errorMessage := "Unable to get account policy: " + gerr.Error()
+		resp.CreateInternalErrorResponse(errorMessage)
+		log.Error(errorMessage)
+		return resp
+	}
+
 	if requestUser.Password != "" {
 		// Password modification not allowed, if user doesn't have ConfigureSelf or ConfigureUsers privilege
 		if !session.Privileges[common.PrivilegeConfigureSelf] && !session.Privileges[common.PrivilegeConfigureUsers] {
@@ -253,6 +277,21 @@ func (e *ExternalInterface) Update(req *accountproto.UpdateAccountRequest, sessi
 		hashSum := hash.Sum(nil)
 		hashedPassword := base64.URLEncoding.EncodeToString(hashSum)
 		requestUser.Password = hashedPassword
+		if isPasswordReused(user, hashedPassword, policy.PasswordHistoryCount) {
+			errorMessage := "Password should not be same as any of the last " + strconv.Itoa(policy.PasswordHistoryCount) + " passwords"
+			log.Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage, []interface{}{"******", "Password"}, nil)
+		}
+		user.PasswordHistory = passwordHistory(user, policy.PasswordHistoryCount)
+		user.PasswordChangedTime = time.Now()
+		user.PasswordChangeRequired = false
+	}
+	if updateAccount.Locked != nil {
+		user.Locked = false
+		user.FailedLoginCount = 0
+	}
+	if updateAccount.PasswordChangeRequired != nil {
+		user.PasswordChangeRequired = *updateAccount.PasswordChangeRequired
 	}
 
 	if uerr := e.UpdateUserDetails(user, requestUser); uerr != nil {
@@ -273,7 +312,7 @@ func (e *ExternalInterface) Update(req *accountproto.UpdateAccountRequest, sessi
 		user.RoleID = requestUser.RoleID
 	}
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
-	resp.Body = asresponse.Account{
+	account := asresponse.Account{
 		Response:     commonResponse,
 		UserName:     user.UserName,
 		RoleID:       user.RoleID,
@@ -284,10 +323,45 @@ func (e *ExternalInterface) Update(req *accountproto.UpdateAccountRequest, sessi
 			},
 		},
 	}
+	setAccountStatus(&account, user, policy)
+	resp.Body = account
 
 	return resp
 }
 
+// isPasswordReused checks whether the hashed password is same as any
+// of the historyCount most recent passwords of the user, including the current one
+func isPasswordReused(user asmodel.User, hashedPassword string, historyCount int) bool {
+	if historyCount == 0 {
+		return false
+	}
+	if user.Password == hashedPassword {
+		return true
+	}
+	for i, previousPassword := range user.PasswordHistory {
+		if i >= historyCount-1 {
+			break
+		}
+		if previousPassword == hashedPassword {
+			return true
+		}
+	}
+	return false
+}
+
+// passwordHistory returns the previous passwords of the user to be remembered
+// once the current password is replaced
+func passwordHistory(user asmodel.User, historyCount int) []string {
+	if historyCount <= 1 {
+		return nil
+	}
+	history := append([]string{user.Password}, user.PasswordHistory...)
+	if len(history) > historyCount-1 {
+		history = history[:historyCount-1]
+	}
+	return history
+}
+
 func isEmptyRequest(requestBody []byte) bool {
 	var updateRequest map[string]interface{}
 	json.Unmarshal(requestBody, &updateRequest)
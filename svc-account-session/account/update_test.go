@@ -14,16 +14,21 @@
 package account
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asresponse"
+	"golang.org/x/crypto/sha3"
 )
 
 func TestUpdate(t *testing.T) {
@@ -573,3 +578,90 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateAccountStatusAndPasswordHistory(t *testing.T) {
+	config.SetUpMockConfig(t)
+	hashPassword := func(password string) string {
+		hash := sha3.New512()
+		hash.Write([]byte(password))
+		return base64.URLEncoding.EncodeToString(hash.Sum(nil))
+	}
+	storedUser := asmodel.User{
+		UserName:               "testUser1",
+		Password:               hashPassword("Current@12345"),
+		RoleID:                 common.RoleAdmin,
+		PasswordHistory:        []string{hashPassword("Previous@12345"), hashPassword("Oldest@123456")},
+		PasswordChangeRequired: true,
+		FailedLoginCount:       3,
+		Locked:                 true,
+		LockedTime:             time.Now(),
+	}
+	var savedUser asmodel.User
+	acc := getMockExternalInterface()
+	acc.GetUserDetails = func(userName string) (asmodel.User, *errors.Error) {
+		return storedUser, nil
+	}
+	acc.UpdateUserDetails = func(user, newData asmodel.User) *errors.Error {
+		savedUser = user
+		if newData.Password != "" {
+			savedUser.Password = newData.Password
+		}
+		return nil
+	}
+	mockAccountPolicy = asmodel.AccountPolicy{PasswordHistoryCount: 2}
+	defer func() {
+		mockAccountPolicy = asmodel.AccountPolicy{}
+	}()
+	adminSession := &asmodel.Session{
+		UserName: "admin",
+		Privileges: map[string]bool{
+			common.PrivilegeConfigureUsers: true,
+		},
+	}
+	selfSession := &asmodel.Session{
+		UserName: "testUser1",
+		Privileges: map[string]bool{
+			common.PrivilegeConfigureSelf: true,
+		},
+	}
+	tests := []struct {
+		name        string
+		session     *asmodel.Session
+		reqBody     string
+		wantCode    int32
+		wantMessage string
+	}{
+		{"unlocking without ConfigureUsers", selfSession, `{"Locked": false}`, http.StatusForbidden, response.InsufficientPrivilege},
+		{"forcing password change without ConfigureUsers", selfSession, `{"PasswordChangeRequired": false}`, http.StatusForbidden, response.InsufficientPrivilege},
+		{"locking the account", adminSession, `{"Locked": true}`, http.StatusBadRequest, response.PropertyValueNotInList},
+		{"reusing the current password", selfSession, `{"Password": "Current@12345"}`, http.StatusBadRequest, response.PropertyValueFormatError},
+		{"reusing a remembered password", selfSession, `{"Password": "Previous@12345"}`, http.StatusBadRequest, response.PropertyValueFormatError},
+		{"reusing a forgotten password", selfSession, `{"Password": "Oldest@123456"}`, http.StatusOK, response.AccountModified},
+		{"unlocking the account", adminSession, `{"Locked": false, "PasswordChangeRequired": true}`, http.StatusOK, response.AccountModified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := acc.Update(&accountproto.UpdateAccountRequest{AccountID: "testUser1", RequestBody: []byte(tt.reqBody)}, tt.session)
+			if got.StatusCode != tt.wantCode || got.StatusMessage != tt.wantMessage {
+				t.Errorf("Update() = %v %v, want %v %v", got.StatusCode, got.StatusMessage, tt.wantCode, tt.wantMessage)
+			}
+		})
+	}
+
+	// last successful request unlocked the account and forced the password change
+	if savedUser.Locked || savedUser.FailedLoginCount != 0 || !savedUser.PasswordChangeRequired {
+		t.Errorf("saved user Locked = %v, FailedLoginCount = %v, PasswordChangeRequired = %v, want false, 0, true",
+			savedUser.Locked, savedUser.FailedLoginCount, savedUser.PasswordChangeRequired)
+	}
+
+	acc.Update(&accountproto.UpdateAccountRequest{AccountID: "testUser1", RequestBody: []byte(`{"Password": "Changed@12345"}`)}, selfSession)
+	if savedUser.Password != hashPassword("Changed@12345") {
+		t.Error("password should be changed")
+	}
+	if !reflect.DeepEqual(savedUser.PasswordHistory, []string{storedUser.Password}) {
+		t.Errorf("PasswordHistory = %v, want the replaced password only", savedUser.PasswordHistory)
+	}
+	if savedUser.PasswordChangeRequired || savedUser.PasswordChangedTime.IsZero() {
+		t.Errorf("changing the password should clear PasswordChangeRequired and set PasswordChangedTime")
+	}
+}
@@ -17,24 +17,52 @@ package asmodel
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	uuid "github.com/satori/go.uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// loginLockTable holds the locks on the failed login details of the accounts
+	loginLockTable = "LoginLock"
+	// loginLockSeconds is the time after which a lock, which was not released, expires
+	loginLockSeconds = 5
+	// loginLockRetryInterval is the interval between the attempts to take a held lock
+	loginLockRetryInterval = 50 * time.Millisecond
 )
 
 // Account is the model for creating/updating an Account
 type Account struct {
-	UserName string `json:"UserName"`
-	Password string `json:"Password"`
-	RoleID   string `json:"RoleId"`
+	UserName               string `json:"UserName"`
+	Password               string `json:"Password"`
+	RoleID                 string `json:"RoleId"`
+	Locked                 *bool  `json:"Locked,omitempty"`
+	PasswordChangeRequired *bool  `json:"PasswordChangeRequired,omitempty"`
 }
 
 // User is the model for User Account
+//
+// PasswordHistory holds the hashes of the previous passwords, latest first.
+// FailedLoginCount and LastFailedLoginTime track the consecutive failed
+// logins used for the account lockout, LockedTime is when the account got locked.
 type User struct {
-	UserName     string   `json:"UserName"`
-	Password     string   `json:"Password"`
-	RoleID       string   `json:"RoleId"`
-	AccountTypes []string `json:"AccountTypes"`
+	UserName               string    `json:"UserName"`
+	Password               string    `json:"Password"`
+	RoleID                 string    `json:"RoleId"`
+	AccountTypes           []string  `json:"AccountTypes"`
+	PasswordHistory        []string  `json:"PasswordHistory,omitempty"`
+	PasswordChangedTime    time.Time `json:"PasswordChangedTime,omitempty"`
+	PasswordChangeRequired bool      `json:"PasswordChangeRequired,omitempty"`
+	FailedLoginCount       int       `json:"FailedLoginCount,omitempty"`
+	LastFailedLoginTime    time.Time `json:"LastFailedLoginTime,omitempty"`
+	Locked                 bool      `json:"Locked,omitempty"`
+	LockedTime             time.Time `json:"LockedTime,omitempty"`
+	// AccountProviderType is the external account provider which authenticated
+	// the user, it is empty for the accounts stored in ODIM
+	AccountProviderType string `json:"-"`
 }
 
 var (
@@ -134,3 +162,34 @@ func UpdateUserDetails(user, newData User) *errors.Error {
 	}
 	return nil
 }
+
+// LockLoginDetails takes the lock on the failed login details of the account.
+// The lock is kept in the db, so that the logins handled by different instances
+// of the service do not overwrite the failed login count of each other.
+// It returns the function which releases the lock.
+func LockLoginDetails(userName string) (func(), *errors.Error) {
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return nil, err
+	}
+	owner := uuid.NewV4().String()
+	deadline := time.Now().Add(loginLockSeconds * time.Second)
+	for {
+		acquired, err := conn.AcquireLease(loginLockTable, userName, owner, loginLockSeconds)
+		if err != nil {
+			return nil, errors.PackError(err.ErrNo(), "error while trying to lock the login details of account ", userName, ": ", err.Error())
+		}
+		if acquired {
+			break
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.PackError(errors.UndefinedErrorType, "timed out waiting for the lock on the login details of account ", userName)
+		}
+		time.Sleep(loginLockRetryInterval)
+	}
+	return func() {
+		if err := conn.ReleaseLease(loginLockTable, userName, owner); err != nil {
+			log.Error("Unable to unlock the login details of account " + userName + ": " + err.Error())
+		}
+	}, nil
+}
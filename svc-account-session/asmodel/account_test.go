@@ -46,7 +46,7 @@ func TestCreate(t *testing.T) {
 		common.TruncateDB(common.OnDisk)
 		common.TruncateDB(common.InMemory)
 	}()
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return common.GetDBConnection(dbFlag)
 	}
 	err := CreateUser(user)
@@ -59,7 +59,7 @@ func TestGetAllUsers(t *testing.T) {
 		common.TruncateDB(common.OnDisk)
 		common.TruncateDB(common.InMemory)
 	}()
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return common.GetDBConnection(dbFlag)
 	}
 	mockData(common.OnDisk, "User", "successID", User{UserName: "successID"})
@@ -80,7 +80,7 @@ func TestGetUserDetails(t *testing.T) {
 	tests := []struct {
 		name                string
 		args                args
-		GetDBConnectionFunc func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error)
+		GetDBConnectionFunc func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error)
 		want                User
 		wantErr             bool
 	}{
@@ -89,7 +89,7 @@ func TestGetUserDetails(t *testing.T) {
 			args: args{
 				key: "successID",
 			},
-			GetDBConnectionFunc: func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+			GetDBConnectionFunc: func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 				return nil, &errors.Error{}
 			},
 			want:    User{},
@@ -100,7 +100,7 @@ func TestGetUserDetails(t *testing.T) {
 			args: args{
 				key: "successID",
 			},
-			GetDBConnectionFunc: func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+			GetDBConnectionFunc: func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 				return common.GetDBConnection(dbFlag)
 			},
 			want:    User{UserName: "successID"},
@@ -111,7 +111,7 @@ func TestGetUserDetails(t *testing.T) {
 			args: args{
 				key: "InvalidID",
 			},
-			GetDBConnectionFunc: func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) { return nil, &errors.Error{} },
+			GetDBConnectionFunc: func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) { return nil, &errors.Error{} },
 			want:                User{},
 			wantErr:             true,
 		},
@@ -144,7 +144,7 @@ func TestDeleteUser(t *testing.T) {
 	tests := []struct {
 		name                string
 		args                args
-		GetDBConnectionFunc func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error)
+		GetDBConnectionFunc func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error)
 		want                *errors.Error
 	}{
 		{
@@ -152,7 +152,7 @@ func TestDeleteUser(t *testing.T) {
 			args: args{
 				key: "successID",
 			},
-			GetDBConnectionFunc: func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+			GetDBConnectionFunc: func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 				return nil, &errors.Error{}
 			},
 			want: &errors.Error{},
@@ -162,7 +162,7 @@ func TestDeleteUser(t *testing.T) {
 			args: args{
 				key: "successID",
 			},
-			GetDBConnectionFunc: func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+			GetDBConnectionFunc: func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 				return common.GetDBConnection(dbFlag)
 			},
 			want: nil,
@@ -172,7 +172,7 @@ func TestDeleteUser(t *testing.T) {
 			args: args{
 				key: "InvalidID",
 			},
-			GetDBConnectionFunc: func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+			GetDBConnectionFunc: func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 				return common.GetDBConnection(dbFlag)
 			},
 			want: errors.PackError(errors.DBKeyNotFound, "no data with the with key InvalidID found"),
@@ -227,13 +227,13 @@ func TestUpdateUserDetails(t *testing.T) {
 	tests := []struct {
 		name                string
 		args                args
-		GetDBConnectionFunc func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error)
+		GetDBConnectionFunc func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error)
 		wantErr             bool
 	}{
 		{
 			name: "Db conn error",
 			args: args{userData: User{UserName: "successID"}},
-			GetDBConnectionFunc: func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+			GetDBConnectionFunc: func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 				return nil, &errors.Error{}
 			},
 			wantErr: true,
@@ -241,7 +241,7 @@ func TestUpdateUserDetails(t *testing.T) {
 		{
 			name: "positive case",
 			args: args{userData: User{UserName: "successID"}},
-			GetDBConnectionFunc: func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+			GetDBConnectionFunc: func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 				return common.GetDBConnection(dbFlag)
 			},
 			wantErr: false,
@@ -249,7 +249,7 @@ func TestUpdateUserDetails(t *testing.T) {
 		{
 			name: "positive case1",
 			args: args{userData: user1},
-			GetDBConnectionFunc: func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+			GetDBConnectionFunc: func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 				return common.GetDBConnection(dbFlag)
 			},
 			wantErr: false,
@@ -257,7 +257,7 @@ func TestUpdateUserDetails(t *testing.T) {
 		{
 			name: "positive case2",
 			args: args{userData: user2},
-			GetDBConnectionFunc: func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+			GetDBConnectionFunc: func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 				return common.GetDBConnection(dbFlag)
 			},
 			wantErr: false,
@@ -265,7 +265,7 @@ func TestUpdateUserDetails(t *testing.T) {
 		{
 			name: "positive case3",
 			args: args{userData: user3},
-			GetDBConnectionFunc: func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+			GetDBConnectionFunc: func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 				return common.GetDBConnection(dbFlag)
 			},
 			wantErr: false,
@@ -273,7 +273,7 @@ func TestUpdateUserDetails(t *testing.T) {
 		{
 			name: "positive case4",
 			args: args{userData: user4},
-			GetDBConnectionFunc: func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+			GetDBConnectionFunc: func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 				return common.GetDBConnection(dbFlag)
 			},
 			wantErr: false,
@@ -309,7 +309,7 @@ func TestCreateUser(t *testing.T) {
 	tests := []struct {
 		name                string
 		args                args
-		GetDBConnectionFunc func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error)
+		GetDBConnectionFunc func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error)
 		want                *errors.Error
 	}{
 		{
@@ -320,7 +320,7 @@ func TestCreateUser(t *testing.T) {
 				RoleID:       "fakeRole",
 				AccountTypes: []string{"fake"},
 			}},
-			GetDBConnectionFunc: func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+			GetDBConnectionFunc: func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 				return nil, &errors.Error{}
 			},
 			want: &errors.Error{},
@@ -335,7 +335,7 @@ func TestCreateUser(t *testing.T) {
 }
 
 func TestGetAllUsersDBError(t *testing.T) {
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return nil, &errors.Error{}
 	}
 	got, got1 := GetAllUsers()
@@ -0,0 +1,118 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+package asmodel
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+const (
+	accountPolicyTable = "AccountPolicy"
+	accountPolicyKey   = "AccountService"
+)
+
+// AccountPolicy is the model for the account lockout and password policies
+// of the AccountService. A zero value disables the respective policy.
+//
+// AccountLockoutThreshold is the number of consecutive failed logins after which
+// the account is locked. AccountLockoutDuration is the time in seconds the account
+// stays locked, zero keeps it locked until an administrator unlocks it.
+// AccountLockoutCounterResetAfter is the time in seconds after the last failed login
+// at which the failed login counter is reset.
+// PasswordExpirationDays is the number of days after which a password has to be changed
+// and PasswordHistoryCount is the number of most recent passwords, including the
+// current one, which can not be reused.
+// PasswordExpirationEnabledTime is when the password expiration got enabled, it is
+// used as the password change time of the accounts which never changed their password.
+type AccountPolicy struct {
+	AccountLockoutThreshold         int       `json:"AccountLockoutThreshold"`
+	AccountLockoutDuration          int       `json:"AccountLockoutDuration"`
+	AccountLockoutCounterResetAfter int       `json:"AccountLockoutCounterResetAfter"`
+	PasswordExpirationDays          int       `json:"PasswordExpirationDays"`
+	PasswordHistoryCount            int       `json:"PasswordHistoryCount"`
+	PasswordExpirationEnabledTime   time.Time `json:"PasswordExpirationEnabledTime,omitempty"`
+}
+
+// GetAccountPolicy will fetch the account policy from the db,
+// the zero policy is returned when it was never configured
+func GetAccountPolicy() (AccountPolicy, *errors.Error) {
+	var policy AccountPolicy
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return policy, err
+	}
+	data, err := conn.Read(accountPolicyTable, accountPolicyKey)
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return policy, nil
+		}
+		return policy, errors.PackError(err.ErrNo(), "error while trying to get account policy: ", err.Error())
+	}
+	if jerr := json.Unmarshal([]byte(data), &policy); jerr != nil {
+		return policy, errors.PackError(errors.UndefinedErrorType, jerr)
+	}
+	return policy, nil
+}
+
+// SaveAccountPolicy creates or replaces the account policy in the db
+func SaveAccountPolicy(policy AccountPolicy) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.AddResourceData(accountPolicyTable, accountPolicyKey, policy); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to save account policy: ", err.Error())
+	}
+	return nil
+}
+
+// IsLocked reports whether the account is locked at the given time.
+// A lock which outlived the lockout duration is not considered.
+func (policy AccountPolicy) IsLocked(user User, now time.Time) bool {
+	if !user.Locked {
+		return false
+	}
+	if policy.AccountLockoutDuration == 0 {
+		return true
+	}
+	return now.Before(user.LockedTime.Add(time.Duration(policy.AccountLockoutDuration) * time.Second))
+}
+
+// IsPasswordExpired reports whether the password of the account is expired at the given time
+func (policy AccountPolicy) IsPasswordExpired(user User, now time.Time) bool {
+	expiration := policy.PasswordExpiration(user)
+	return !expiration.IsZero() && !now.Before(expiration)
+}
+
+// PasswordExpiration returns the time at which the password of the account expires,
+// the zero time is returned when the password does not expire.
+// The password of an account which never changed it, like the default admin account,
+// expires counting from the time the password expiration got enabled.
+func (policy AccountPolicy) PasswordExpiration(user User) time.Time {
+	if policy.PasswordExpirationDays == 0 {
+		return time.Time{}
+	}
+	changedTime := user.PasswordChangedTime
+	if changedTime.IsZero() {
+		changedTime = policy.PasswordExpirationEnabledTime
+	}
+	if changedTime.IsZero() {
+		return time.Time{}
+	}
+	return changedTime.AddDate(0, 0, policy.PasswordExpirationDays)
+}
@@ -0,0 +1,110 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+package asmodel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-persistence-manager/persistencemgr"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveAndGetAccountPolicy(t *testing.T) {
+	config.SetUpMockConfig(t)
+	common.SetUpMockConfig()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+	}()
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
+		return common.GetDBConnection(dbFlag)
+	}
+	got, err := GetAccountPolicy()
+	assert.Nil(t, err, "There should be no error when the policy is not configured")
+	assert.Equal(t, AccountPolicy{}, got, "policies should be disabled by default")
+
+	policy := AccountPolicy{
+		AccountLockoutThreshold:         3,
+		AccountLockoutDuration:          600,
+		AccountLockoutCounterResetAfter: 300,
+		PasswordExpirationDays:          90,
+		PasswordHistoryCount:            5,
+	}
+	err = SaveAccountPolicy(policy)
+	assert.Nil(t, err, "There should be no error")
+	got, err = GetAccountPolicy()
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, policy, got, "saved policy should be returned")
+}
+
+func TestAccountPolicyDBError(t *testing.T) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
+		return nil, errors.PackError(errors.DBConnFailed, "connection failed")
+	}
+	defer func() {
+		GetDBConnectionFunc = common.GetDBConnection
+	}()
+	_, err := GetAccountPolicy()
+	assert.NotNil(t, err, "There should be an error")
+	err = SaveAccountPolicy(AccountPolicy{})
+	assert.NotNil(t, err, "There should be an error")
+}
+
+func TestAccountPolicyIsLocked(t *testing.T) {
+	now := time.Now()
+	lockedUser := User{Locked: true, LockedTime: now.Add(-time.Minute)}
+	tests := []struct {
+		name   string
+		policy AccountPolicy
+		user   User
+		want   bool
+	}{
+		{"account not locked", AccountPolicy{AccountLockoutDuration: 300}, User{}, false},
+		{"lockout duration not elapsed", AccountPolicy{AccountLockoutDuration: 300}, lockedUser, true},
+		{"lockout duration elapsed", AccountPolicy{AccountLockoutDuration: 30}, lockedUser, false},
+		{"locked until unlocked", AccountPolicy{}, lockedUser, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.IsLocked(tt.user, now))
+		})
+	}
+}
+
+func TestAccountPolicyIsPasswordExpired(t *testing.T) {
+	now := time.Now()
+	user := User{PasswordChangedTime: now.AddDate(0, 0, -10)}
+	tests := []struct {
+		name   string
+		policy AccountPolicy
+		user   User
+		want   bool
+	}{
+		{"expiration disabled", AccountPolicy{}, user, false},
+		{"password not expired", AccountPolicy{PasswordExpirationDays: 30}, user, false},
+		{"password expired", AccountPolicy{PasswordExpirationDays: 7}, user, true},
+		{"password change time unknown", AccountPolicy{PasswordExpirationDays: 7}, User{}, false},
+		{"password never changed", AccountPolicy{PasswordExpirationDays: 7, PasswordExpirationEnabledTime: now.AddDate(0, 0, -8)}, User{}, true},
+		{"password never changed, expiration recently enabled", AccountPolicy{PasswordExpirationDays: 7, PasswordExpirationEnabledTime: now.AddDate(0, 0, -1)}, User{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.IsPasswordExpired(tt.user, now))
+		})
+	}
+	assert.Equal(t, user.PasswordChangedTime.AddDate(0, 0, 30), AccountPolicy{PasswordExpirationDays: 30}.PasswordExpiration(user))
+}
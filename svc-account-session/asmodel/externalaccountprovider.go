@@ -0,0 +1,100 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+package asmodel
+
+import (
+	"encoding/json"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+const (
+	// LDAPProvider is the AccountService property and the key under which
+	// the generic LDAP external account provider is stored
+	LDAPProvider = "LDAP"
+	// ActiveDirectoryProvider is the AccountService property and the key under which
+	// the Active Directory external account provider is stored
+	ActiveDirectoryProvider = "ActiveDirectory"
+
+	externalAccountProviderTable = "ExternalAccountProvider"
+)
+
+// ExternalAccountProvider is the model for a directory service configured
+// under the AccountService, which is used for authenticating users without
+// a local account
+type ExternalAccountProvider struct {
+	ServiceEnabled    bool                   `json:"ServiceEnabled"`
+	ServiceAddresses  []string               `json:"ServiceAddresses"`
+	Authentication    ProviderAuthentication `json:"Authentication"`
+	LDAPService       LDAPService            `json:"LDAPService"`
+	RemoteRoleMapping []RoleMapping          `json:"RemoteRoleMapping"`
+}
+
+// ProviderAuthentication holds the credentials of the service account
+// used for searching the directory. Password is stored encrypted
+// with the ODIMRA public key.
+type ProviderAuthentication struct {
+	AuthenticationType string `json:"AuthenticationType"`
+	Username           string `json:"Username"`
+	Password           []byte `json:"Password"`
+}
+
+// LDAPService holds the directory specific settings of the provider
+type LDAPService struct {
+	SearchSettings LDAPSearchSettings `json:"SearchSettings"`
+}
+
+// LDAPSearchSettings defines where and how the user entries are searched
+type LDAPSearchSettings struct {
+	BaseDistinguishedNames []string `json:"BaseDistinguishedNames"`
+	UsernameAttribute      string   `json:"UsernameAttribute"`
+	GroupsAttribute        string   `json:"GroupsAttribute"`
+	GroupNameAttribute     string   `json:"GroupNameAttribute"`
+}
+
+// RoleMapping maps a directory group to an ODIM role
+type RoleMapping struct {
+	RemoteGroup string `json:"RemoteGroup"`
+	LocalRole   string `json:"LocalRole"`
+}
+
+// GetExternalAccountProvider will fetch the external account provider of the given type from the db
+func GetExternalAccountProvider(providerType string) (ExternalAccountProvider, *errors.Error) {
+	var provider ExternalAccountProvider
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return provider, err
+	}
+	data, err := conn.Read(externalAccountProviderTable, providerType)
+	if err != nil {
+		return provider, errors.PackError(err.ErrNo(), "error while trying to get external account provider ", providerType, ": ", err.Error())
+	}
+	if jerr := json.Unmarshal([]byte(data), &provider); jerr != nil {
+		return provider, errors.PackError(errors.UndefinedErrorType, jerr)
+	}
+	return provider, nil
+}
+
+// SaveExternalAccountProvider creates or replaces the external account provider of the given type in the db
+func SaveExternalAccountProvider(providerType string, provider ExternalAccountProvider) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.AddResourceData(externalAccountProviderTable, providerType, provider); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to save external account provider ", providerType, ": ", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+package asmodel
+
+import (
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-persistence-manager/persistencemgr"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveAndGetExternalAccountProvider(t *testing.T) {
+	config.SetUpMockConfig(t)
+	common.SetUpMockConfig()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+	}()
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
+		return common.GetDBConnection(dbFlag)
+	}
+	_, err := GetExternalAccountProvider(LDAPProvider)
+	assert.NotNil(t, err, "There should be an error")
+	assert.Equal(t, errors.DBKeyNotFound, err.ErrNo(), "error should be key not found")
+
+	provider := ExternalAccountProvider{
+		ServiceEnabled:   true,
+		ServiceAddresses: []string{"ldap://10.0.0.1:389"},
+		RemoteRoleMapping: []RoleMapping{
+			{RemoteGroup: "admins", LocalRole: common.RoleAdmin},
+		},
+	}
+	err = SaveExternalAccountProvider(LDAPProvider, provider)
+	assert.Nil(t, err, "There should be no error")
+	provider.ServiceEnabled = false
+	err = SaveExternalAccountProvider(LDAPProvider, provider)
+	assert.Nil(t, err, "There should be no error while replacing the provider")
+
+	got, err := GetExternalAccountProvider(LDAPProvider)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, provider, got, "saved provider should be returned")
+	_, err = GetExternalAccountProvider(ActiveDirectoryProvider)
+	assert.NotNil(t, err, "providers should be stored separately")
+}
+
+func TestExternalAccountProviderDBError(t *testing.T) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
+		return nil, &errors.Error{}
+	}
+	defer func() {
+		GetDBConnectionFunc = common.GetDBConnection
+	}()
+	_, err := GetExternalAccountProvider(LDAPProvider)
+	assert.Equalf(t, &errors.Error{}, err, "GetExternalAccountProvider() ")
+	err = SaveExternalAccountProvider(LDAPProvider, ExternalAccountProvider{})
+	assert.Equalf(t, &errors.Error{}, err, "SaveExternalAccountProvider() ")
+}
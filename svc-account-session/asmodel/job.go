@@ -0,0 +1,49 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package asmodel
+
+import (
+	"encoding/json"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+// jobTable is the table in which the task service stores the scheduled jobs
+const jobTable = "Job"
+
+// JobOwner is the owner of a scheduled job, as stored by the task service
+type JobOwner struct {
+	UserName            string
+	RoleID              string
+	AccountProviderType string
+}
+
+// GetJobOwner reads the owner of the scheduled job with the given ID
+func GetJobOwner(jobID string) (JobOwner, *errors.Error) {
+	var owner JobOwner
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return owner, err
+	}
+	data, err := conn.Read(jobTable, jobID)
+	if err != nil {
+		return owner, errors.PackError(err.ErrNo(), "error while trying to get the job: ", err.Error())
+	}
+	if jerr := json.Unmarshal([]byte(data), &owner); jerr != nil {
+		return owner, errors.PackError(errors.UndefinedErrorType, jerr)
+	}
+	return owner, nil
+}
@@ -37,7 +37,7 @@ func TestCreateRedfishRoles(t *testing.T) {
 		common.TruncateDB(common.OnDisk)
 		common.TruncateDB(common.InMemory)
 	}()
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return common.GetDBConnection(dbFlag)
 	}
 	err := roles.Create()
@@ -50,7 +50,7 @@ func TestGetRedfishRoles(t *testing.T) {
 		common.TruncateDB(common.OnDisk)
 		common.TruncateDB(common.InMemory)
 	}()
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return common.GetDBConnection(dbFlag)
 	}
 	mockData(common.OnDisk, "roles", "redfishdefined", roles)
@@ -64,7 +64,7 @@ func TestGetRedfishRolesNegativeTestCase(t *testing.T) {
 		common.TruncateDB(common.OnDisk)
 		common.TruncateDB(common.InMemory)
 	}()
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return common.GetDBConnection(dbFlag)
 	}
 	_, err := GetRedfishRoles()
@@ -75,7 +75,7 @@ func TestGetRedfishRolesNegativeTestCase(t *testing.T) {
 }
 
 func TestCreateRedfishRolesDBError(t *testing.T) {
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return nil, &errors.Error{}
 	}
 	err := roles.Create()
@@ -83,7 +83,7 @@ func TestCreateRedfishRolesDBError(t *testing.T) {
 }
 
 func TestGetRedfishRolesDBError(t *testing.T) {
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return nil, &errors.Error{}
 	}
 	role, err := GetRedfishRoles()
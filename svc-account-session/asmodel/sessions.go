@@ -35,6 +35,9 @@ type Session struct {
 	Origin       string
 	CreatedTime  time.Time
 	LastUsedTime time.Time
+	// AccountProviderType is the external account provider which authenticated
+	// the user, it is empty for the accounts stored in ODIM
+	AccountProviderType string
 }
 
 //CreateSession will hold input request for creating a session
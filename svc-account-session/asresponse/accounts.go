@@ -23,12 +23,15 @@ import (
 // Account struct is used to ommit password for display purposes
 type Account struct {
 	response.Response
-	UserName     string   `json:"UserName"`
-	RoleID       string   `json:"RoleId"`
-	AccountTypes []string `json:"AccountTypes"`
-	Password     *string  `json:"Password"`
-	Links        Links    `json:"Links"`
-	OEM          *OEM     `json:"Oem,omitempty"`
+	UserName               string   `json:"UserName"`
+	RoleID                 string   `json:"RoleId"`
+	AccountTypes           []string `json:"AccountTypes"`
+	Password               *string  `json:"Password"`
+	Locked                 bool     `json:"Locked"`
+	PasswordChangeRequired bool     `json:"PasswordChangeRequired"`
+	PasswordExpiration     string   `json:"PasswordExpiration,omitempty"`
+	Links                  Links    `json:"Links"`
+	OEM                    *OEM     `json:"Oem,omitempty"`
 }
 
 //OEM struct definition
@@ -48,31 +51,41 @@ type Role struct {
 //AccountService struct definition
 type AccountService struct {
 	response.Response
-	Status                             Status           `json:"Status,omitempty"`
-	ServiceEnabled                     bool             `json:"ServiceEnabled,omitempty"`
-	AuthFailureLoggingThreshold        int              `json:"AuthFailureLoggingThreshold,omitempty"`
-	MinPasswordLength                  int              `json:"MinPasswordLength,omitempty"`
-	AccountLockoutThreshold            int              `json:"AccountLockoutThreshold,omitempty"`
-	AccountLockoutDuration             int              `json:"AccountLockoutDuration,omitempty"`
-	AccountLockoutCounterResetAfter    int              `json:"AccountLockoutCounterResetAfter,omitempty"`
-	Accounts                           Accounts         `json:"Accounts,omitempty"`
-	Roles                              Accounts         `json:"Roles,omitempty"`
-	AccountLockoutCounterResetEnabled  bool             `json:"AccountLockoutCounterResetEnabled,omitempty"`
-	Actions                            *dmtf.OemActions `json:"Actions,omitempty"`
-	ActiveDirectory                    *ActiveDirectory `json:"ActiveDirectory,omitempty"`
-	AdditionalExternalAccountProviders *dmtf.Link       `json:"AdditionalExternalAccountProviders,omitempty"`
-	LDAP                               *LDAP            `json:"LDAP,omitempty"`
-	LocalAccountAuth                   string           `json:"LocalAccountAuth,omitempty"`
-	MaxPasswordLength                  int              `json:"MaxPasswordLength,omitempty"`
-	OAuth2                             *OAuth2          `json:"OAuth2,omitempty"`
-	Oem                                *OEM             `json:"Oem,omitempty"`
-	PasswordExpirationDays             int              `json:"PasswordExpirationDays,omitempty"`
-	PrivilegeMap                       *dmtf.Link       `json:"PrivilegeMap,omitempty"`
-	RestrictedOemPrivileges            []string         `json:"RestrictedOemPrivileges,omitempty"`
-	RestrictedPrivileges               []string         `json:"RestrictedPrivileges,omitempty"`
-	SupportedAccountTypes              []string         `json:"SupportedAccountTypes,omitempty"`
-	SupportedOEMAccountTypes           []string         `json:"SupportedOEMAccountTypes,omitempty"`
-	TACACSplus                         *TACACSplus      `json:"TACACSplus,omitempty"`
+	Status                             Status                   `json:"Status,omitempty"`
+	ServiceEnabled                     bool                     `json:"ServiceEnabled,omitempty"`
+	AuthFailureLoggingThreshold        int                      `json:"AuthFailureLoggingThreshold,omitempty"`
+	MinPasswordLength                  int                      `json:"MinPasswordLength,omitempty"`
+	AccountLockoutThreshold            int                      `json:"AccountLockoutThreshold"`
+	AccountLockoutDuration             int                      `json:"AccountLockoutDuration"`
+	AccountLockoutCounterResetAfter    int                      `json:"AccountLockoutCounterResetAfter"`
+	Accounts                           Accounts                 `json:"Accounts,omitempty"`
+	Roles                              Accounts                 `json:"Roles,omitempty"`
+	AccountLockoutCounterResetEnabled  bool                     `json:"AccountLockoutCounterResetEnabled"`
+	Actions                            *dmtf.OemActions         `json:"Actions,omitempty"`
+	ActiveDirectory                    *ExternalAccountProvider `json:"ActiveDirectory,omitempty"`
+	AdditionalExternalAccountProviders *dmtf.Link               `json:"AdditionalExternalAccountProviders,omitempty"`
+	LDAP                               *ExternalAccountProvider `json:"LDAP,omitempty"`
+	LocalAccountAuth                   string                   `json:"LocalAccountAuth,omitempty"`
+	MaxPasswordLength                  int                      `json:"MaxPasswordLength,omitempty"`
+	OAuth2                             *OAuth2                  `json:"OAuth2,omitempty"`
+	Oem                                *AccountServiceOem       `json:"Oem,omitempty"`
+	PasswordExpirationDays             int                      `json:"PasswordExpirationDays"`
+	PrivilegeMap                       *dmtf.Link               `json:"PrivilegeMap,omitempty"`
+	RestrictedOemPrivileges            []string                 `json:"RestrictedOemPrivileges,omitempty"`
+	RestrictedPrivileges               []string                 `json:"RestrictedPrivileges,omitempty"`
+	SupportedAccountTypes              []string                 `json:"SupportedAccountTypes,omitempty"`
+	SupportedOEMAccountTypes           []string                 `json:"SupportedOEMAccountTypes,omitempty"`
+	TACACSplus                         *TACACSplus              `json:"TACACSplus,omitempty"`
+}
+
+//AccountServiceOem struct definition
+type AccountServiceOem struct {
+	Odim AccountServiceOdimOem `json:"Odim"`
+}
+
+//AccountServiceOdimOem holds the ODIM specific password policy
+type AccountServiceOdimOem struct {
+	PasswordHistoryCount int `json:"PasswordHistoryCount"`
 }
 
 //Accounts struct definition
@@ -84,12 +97,40 @@ type Accounts struct {
 type OAuth2 struct {
 }
 
-// ActiveDirectory struct definition
-type ActiveDirectory struct {
+// ExternalAccountProvider struct definition, used for both
+// the LDAP and the ActiveDirectory properties of the AccountService
+type ExternalAccountProvider struct {
+	ServiceEnabled    bool                   `json:"ServiceEnabled"`
+	ServiceAddresses  []string               `json:"ServiceAddresses"`
+	Authentication    ProviderAuthentication `json:"Authentication"`
+	LDAPService       LDAPService            `json:"LDAPService"`
+	RemoteRoleMapping []RoleMapping          `json:"RemoteRoleMapping"`
+}
+
+// ProviderAuthentication struct definition, Password is always null in the response
+type ProviderAuthentication struct {
+	AuthenticationType string  `json:"AuthenticationType"`
+	Username           string  `json:"Username"`
+	Password           *string `json:"Password"`
+}
+
+// LDAPService struct definition
+type LDAPService struct {
+	SearchSettings LDAPSearchSettings `json:"SearchSettings"`
+}
+
+// LDAPSearchSettings struct definition
+type LDAPSearchSettings struct {
+	BaseDistinguishedNames []string `json:"BaseDistinguishedNames"`
+	UsernameAttribute      string   `json:"UsernameAttribute"`
+	GroupsAttribute        string   `json:"GroupsAttribute"`
+	GroupNameAttribute     string   `json:"GroupNameAttribute"`
 }
 
-// LDAP struct definition
-type LDAP struct {
+// RoleMapping struct definition
+type RoleMapping struct {
+	RemoteGroup string `json:"RemoteGroup"`
+	LocalRole   string `json:"LocalRole"`
 }
 
 // TACACSplus struct definition
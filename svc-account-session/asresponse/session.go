@@ -39,8 +39,9 @@ type ExtendedInfo struct {
 // Session struct is used to omit password for display purposes
 type Session struct {
 	response.Response
-	UserName    string `json:"UserName"`
-	CreatedTime string `json:"CreatedTime,omitempty"`
+	UserName            string         `json:"UserName"`
+	CreatedTime         string         `json:"CreatedTime,omitempty"`
+	MessageExtendedInfo []response.Msg `json:"@Message.ExtendedInfo,omitempty"`
 }
 
 //SessionService struct definition
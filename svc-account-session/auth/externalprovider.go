@@ -0,0 +1,220 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	ldap "github.com/go-ldap/ldap/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// directoryTimeout bounds the connection and every request made to a directory service
+	directoryTimeout = 10 * time.Second
+
+	defaultGroupsAttribute    = "memberOf"
+	defaultGroupNameAttribute = "cn"
+)
+
+// externalAccountProviders are the directory services which are tried, in order,
+// for a user who does not have a local account
+var externalAccountProviders = []string{asmodel.LDAPProvider, asmodel.ActiveDirectoryProvider}
+
+// defaultUsernameAttribute is the attribute holding the login name of a user
+// when the provider configuration does not set UsernameAttribute
+var defaultUsernameAttribute = map[string]string{
+	asmodel.LDAPProvider:            "uid",
+	asmodel.ActiveDirectoryProvider: "sAMAccountName",
+}
+
+// directoryUser is the user entry found in a directory service
+type directoryUser struct {
+	DN     string
+	Groups []string
+}
+
+// checkExternalAccountProviders authenticates a user against the enabled directory
+// services and maps the directory groups of the user to an ODIM role through
+// the RemoteRoleMapping of the provider. The first provider which authenticates
+// the user and maps a role to it decides the role of the session.
+func checkExternalAccountProviders(userName, password string) (*asmodel.User, *errors.Error) {
+	for _, providerType := range externalAccountProviders {
+		provider, err := asmodel.GetExternalAccountProvider(providerType)
+		if err != nil {
+			if err.ErrNo() == errors.DBKeyNotFound {
+				continue
+			}
+			return nil, err
+		}
+		if !provider.ServiceEnabled {
+			continue
+		}
+		user, derr := authenticateDirectoryUser(providerType, provider, userName, password)
+		if derr != nil {
+			log.Info("user " + userName + " is not authenticated by " + providerType + ": " + derr.Error())
+			continue
+		}
+		roleID := mapRemoteRole(provider, user.Groups)
+		if roleID == "" {
+			log.Info("none of the " + providerType + " groups of user " + userName + " is mapped to a role")
+			continue
+		}
+		return &asmodel.User{
+			UserName:            userName,
+			RoleID:              roleID,
+			AccountTypes:        []string{"Redfish"},
+			AccountProviderType: providerType,
+		}, nil
+	}
+	return nil, errors.PackError(errors.UndefinedErrorType, "error: Invalid username or password ")
+}
+
+// authenticateDirectoryUser looks up the user with the service account of the provider
+// and verifies the password by binding as the user. The service addresses are tried
+// in order until one of them can be reached.
+func authenticateDirectoryUser(providerType string, provider asmodel.ExternalAccountProvider, userName, password string) (*directoryUser, error) {
+	if password == "" {
+		return nil, fmt.Errorf("empty password is not allowed for directory users")
+	}
+	if len(provider.ServiceAddresses) == 0 {
+		return nil, fmt.Errorf("no service address configured")
+	}
+	var err error
+	for _, address := range provider.ServiceAddresses {
+		var conn *ldap.Conn
+		conn, err = dialDirectory(address)
+		if err != nil {
+			log.Warn("unable to connect to " + providerType + " service " + address + ": " + err.Error())
+			continue
+		}
+		defer conn.Close()
+		return searchAndBind(conn, providerType, provider, userName, password)
+	}
+	return nil, err
+}
+
+// dialDirectory connects to an ldap:// or ldaps:// service address. The
+// certificate of an ldaps service is verified with the ODIMRA root CA.
+func dialDirectory(address string) (*ldap.Conn, error) {
+	tlsConfig := &tls.Config{}
+	config.Client.SetTLSConfig(tlsConfig)
+	if len(config.Data.KeyCertConf.RootCACertificate) != 0 {
+		rootCAs := x509.NewCertPool()
+		rootCAs.AppendCertsFromPEM(config.Data.KeyCertConf.RootCACertificate)
+		tlsConfig.RootCAs = rootCAs
+	}
+	conn, err := ldap.DialURL(address,
+		ldap.DialWithDialer(&net.Dialer{Timeout: directoryTimeout}),
+		ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(directoryTimeout)
+	return conn, nil
+}
+
+func searchAndBind(conn *ldap.Conn, providerType string, provider asmodel.ExternalAccountProvider, userName, password string) (*directoryUser, error) {
+	if provider.Authentication.Username != "" {
+		servicePassword, err := common.DecryptWithPrivateKey(provider.Authentication.Password)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decrypt the service account password: %v", err)
+		}
+		if err = conn.Bind(provider.Authentication.Username, string(servicePassword)); err != nil {
+			return nil, fmt.Errorf("service account bind failed: %v", err)
+		}
+	}
+
+	settings := provider.LDAPService.SearchSettings
+	usernameAttribute := settings.UsernameAttribute
+	if usernameAttribute == "" {
+		usernameAttribute = defaultUsernameAttribute[providerType]
+	}
+	groupsAttribute := settings.GroupsAttribute
+	if groupsAttribute == "" {
+		groupsAttribute = defaultGroupsAttribute
+	}
+	filter := fmt.Sprintf("(%s=%s)", ldap.EscapeFilter(usernameAttribute), ldap.EscapeFilter(userName))
+
+	var user *directoryUser
+	for _, baseDN := range settings.BaseDistinguishedNames {
+		searchReq := ldap.NewSearchRequest(baseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+			2, int(directoryTimeout.Seconds()), false, filter, []string{groupsAttribute}, nil)
+		result, err := conn.Search(searchReq)
+		if err != nil {
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+				continue
+			}
+			return nil, fmt.Errorf("search under %s failed: %v", baseDN, err)
+		}
+		if len(result.Entries) > 1 || (len(result.Entries) == 1 && user != nil) {
+			return nil, fmt.Errorf("more than one entry matches %s", filter)
+		}
+		if len(result.Entries) == 1 {
+			user = &directoryUser{
+				DN:     result.Entries[0].DN,
+				Groups: result.Entries[0].GetAttributeValues(groupsAttribute),
+			}
+		}
+	}
+	if user == nil {
+		return nil, fmt.Errorf("no entry matches %s", filter)
+	}
+	if err := conn.Bind(user.DN, password); err != nil {
+		return nil, fmt.Errorf("user bind failed: %v", err)
+	}
+	return user, nil
+}
+
+// mapRemoteRole returns the LocalRole of the first RemoteRoleMapping whose
+// RemoteGroup matches one of the groups of the user. A group matches when
+// it is equal to the full distinguished name of the group or to its name,
+// which is the value of GroupNameAttribute in the first RDN.
+func mapRemoteRole(provider asmodel.ExternalAccountProvider, groups []string) string {
+	groupNameAttribute := provider.LDAPService.SearchSettings.GroupNameAttribute
+	if groupNameAttribute == "" {
+		groupNameAttribute = defaultGroupNameAttribute
+	}
+	for _, mapping := range provider.RemoteRoleMapping {
+		for _, group := range groups {
+			if strings.EqualFold(mapping.RemoteGroup, group) ||
+				strings.EqualFold(mapping.RemoteGroup, groupName(group, groupNameAttribute)) {
+				return mapping.LocalRole
+			}
+		}
+	}
+	return ""
+}
+
+func groupName(group, groupNameAttribute string) string {
+	dn, err := ldap.ParseDN(group)
+	if err != nil || len(dn.RDNs) == 0 {
+		return group
+	}
+	for _, attribute := range dn.RDNs[0].Attributes {
+		if strings.EqualFold(attribute.Type, groupNameAttribute) {
+			return attribute.Value
+		}
+	}
+	return group
+}
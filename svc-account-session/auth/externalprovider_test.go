@@ -0,0 +1,245 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+package auth
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+var mockDirectoryEntries = []mockDirectoryEntry{
+	{
+		DN:       "cn=odim,ou=services,dc=example,dc=com",
+		Password: "servicePassword",
+	},
+	{
+		DN:       "uid=alice,ou=people,dc=example,dc=com",
+		Password: "alicePassword",
+		Attributes: map[string][]string{
+			"uid":      {"alice"},
+			"memberOf": {"cn=operators,ou=groups,dc=example,dc=com", "cn=admins,ou=groups,dc=example,dc=com"},
+		},
+	},
+	{
+		DN:       "uid=bob,ou=people,dc=example,dc=com",
+		Password: "bobPassword",
+		Attributes: map[string][]string{
+			"uid":      {"bob"},
+			"memberOf": {"cn=guests,ou=groups,dc=example,dc=com"},
+		},
+	},
+	{
+		DN:       "cn=Carol,cn=Users,dc=corp,dc=example,dc=com",
+		Password: "carolPassword",
+		Attributes: map[string][]string{
+			"sAMAccountName": {"carol"},
+			"memberOf":       {"CN=Domain Admins,CN=Users,DC=corp,DC=example,DC=com"},
+		},
+	},
+}
+
+func saveMockProvider(t *testing.T, providerType string, provider asmodel.ExternalAccountProvider) {
+	if provider.Authentication.Username != "" {
+		password, err := common.EncryptWithPublicKey([]byte("servicePassword"))
+		if err != nil {
+			t.Fatalf("error while encrypting service account password: %v", err)
+		}
+		provider.Authentication.Password = password
+	}
+	if err := asmodel.SaveExternalAccountProvider(providerType, provider); err != nil {
+		t.Fatalf("error while saving mock %s provider: %v", providerType, err)
+	}
+}
+
+// unreachableAddress returns an ldap URL on which nothing is listening
+func unreachableAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error while reserving a port: %v", err)
+	}
+	address := "ldap://" + listener.Addr().String()
+	listener.Close()
+	return address
+}
+
+func TestCheckSessionCreationCredentialsWithExternalProviders(t *testing.T) {
+	config.SetUpMockConfig(t)
+	Lock.Lock()
+	common.SetUpMockConfig()
+	Lock.Unlock()
+	defer func() {
+		if err := common.TruncateDB(common.OnDisk); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+	if err := createMockUser("alice", common.RoleClient); err != nil {
+		t.Fatalf("Error in creating mock user %v", err)
+	}
+
+	directory := startMockDirectory(t, mockDirectoryEntries)
+	saveMockProvider(t, asmodel.LDAPProvider, asmodel.ExternalAccountProvider{
+		ServiceEnabled:   true,
+		ServiceAddresses: []string{unreachableAddress(t), directory.URL()},
+		Authentication: asmodel.ProviderAuthentication{
+			AuthenticationType: "UsernameAndPassword",
+			Username:           "cn=odim,ou=services,dc=example,dc=com",
+		},
+		LDAPService: asmodel.LDAPService{
+			SearchSettings: asmodel.LDAPSearchSettings{
+				BaseDistinguishedNames: []string{"ou=services,dc=example,dc=com", "ou=people,dc=example,dc=com"},
+			},
+		},
+		RemoteRoleMapping: []asmodel.RoleMapping{
+			{RemoteGroup: "Operators", LocalRole: common.RoleMonitor},
+			{RemoteGroup: "cn=admins,ou=groups,dc=example,dc=com", LocalRole: common.RoleAdmin},
+		},
+	})
+	saveMockProvider(t, asmodel.ActiveDirectoryProvider, asmodel.ExternalAccountProvider{
+		ServiceEnabled:   true,
+		ServiceAddresses: []string{directory.URL()},
+		LDAPService: asmodel.LDAPService{
+			SearchSettings: asmodel.LDAPSearchSettings{
+				BaseDistinguishedNames: []string{"dc=corp,dc=example,dc=com"},
+			},
+		},
+		RemoteRoleMapping: []asmodel.RoleMapping{
+			{RemoteGroup: "domain admins", LocalRole: common.RoleAdmin},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		userName string
+		password string
+		want     *asmodel.User
+		wantErr  bool
+	}{
+		{
+			name:     "local account takes precedence over the directory",
+			userName: "alice",
+			password: "alicePassword",
+			wantErr:  true,
+		},
+		{
+			name:     "directory user without a local account",
+			userName: "Alice",
+			password: "alicePassword",
+			want:     &asmodel.User{UserName: "Alice", RoleID: common.RoleMonitor, AccountTypes: []string{"Redfish"}, AccountProviderType: asmodel.LDAPProvider},
+		},
+		{
+			name:     "directory user with a wrong password",
+			userName: "Alice",
+			password: "wrongPassword",
+			wantErr:  true,
+		},
+		{
+			name:     "directory user without a mapped group",
+			userName: "bob",
+			password: "bobPassword",
+			wantErr:  true,
+		},
+		{
+			name:     "unknown user",
+			userName: "dave",
+			password: "davePassword",
+			wantErr:  true,
+		},
+		{
+			name:     "active directory user mapped by group name",
+			userName: "carol",
+			password: "carolPassword",
+			want:     &asmodel.User{UserName: "carol", RoleID: common.RoleAdmin, AccountTypes: []string{"Redfish"}, AccountProviderType: asmodel.ActiveDirectoryProvider},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckSessionCreationCredentials(tt.userName, tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckSessionCreationCredentials() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckSessionCreationCredentials() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if binds := directory.Binds(); len(binds) == 0 || binds[0] != "cn=odim,ou=services,dc=example,dc=com" {
+		t.Errorf("service account bind is expected before the user bind, got %v", binds)
+	}
+
+	// disabling the providers leaves only the local accounts
+	saveMockProvider(t, asmodel.LDAPProvider, asmodel.ExternalAccountProvider{ServiceAddresses: []string{directory.URL()}})
+	saveMockProvider(t, asmodel.ActiveDirectoryProvider, asmodel.ExternalAccountProvider{ServiceAddresses: []string{directory.URL()}})
+	if _, err := CheckSessionCreationCredentials("carol", "carolPassword"); err == nil {
+		t.Errorf("CheckSessionCreationCredentials() should fail when the providers are disabled")
+	}
+}
+
+func TestMapRemoteRole(t *testing.T) {
+	provider := asmodel.ExternalAccountProvider{
+		RemoteRoleMapping: []asmodel.RoleMapping{
+			{RemoteGroup: "ou=ops", LocalRole: common.RoleMonitor},
+			{RemoteGroup: "odim-admins", LocalRole: common.RoleAdmin},
+		},
+	}
+	tests := []struct {
+		name               string
+		groupNameAttribute string
+		groups             []string
+		want               string
+	}{
+		{
+			name:   "group name from the default cn attribute",
+			groups: []string{"cn=odim-admins,ou=groups,dc=example,dc=com"},
+			want:   common.RoleAdmin,
+		},
+		{
+			name:   "plain group name",
+			groups: []string{"ODIM-Admins"},
+			want:   common.RoleAdmin,
+		},
+		{
+			name:   "first mapping wins",
+			groups: []string{"odim-admins", "ou=ops"},
+			want:   common.RoleMonitor,
+		},
+		{
+			name:               "custom group name attribute",
+			groupNameAttribute: "ou",
+			groups:             []string{"ou=odim-admins,dc=example,dc=com"},
+			want:               common.RoleAdmin,
+		},
+		{
+			name:   "group name attribute does not match",
+			groups: []string{"ou=odim-admins,dc=example,dc=com"},
+			want:   "",
+		},
+		{
+			name: "no groups",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider.LDAPService.SearchSettings.GroupNameAttribute = tt.groupNameAttribute
+			if got := mapRemoteRole(provider, tt.groups); got != tt.want {
+				t.Errorf("mapRemoteRole() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+package auth
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	ldap "github.com/go-ldap/ldap/v3"
+)
+
+// mockDirectoryEntry is an entry served by the mock directory
+type mockDirectoryEntry struct {
+	DN         string
+	Password   string
+	Attributes map[string][]string
+}
+
+// mockDirectory is an in-process LDAP stand-in which supports simple binds
+// and equality searches, which is all the external account providers use
+type mockDirectory struct {
+	listener net.Listener
+	entries  []mockDirectoryEntry
+	mutex    sync.Mutex
+	binds    []string
+}
+
+// startMockDirectory serves the entries on a local port until the test ends
+func startMockDirectory(t *testing.T, entries []mockDirectoryEntry) *mockDirectory {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error while starting mock directory: %v", err)
+	}
+	directory := &mockDirectory{listener: listener, entries: entries}
+	go directory.serve()
+	t.Cleanup(func() { listener.Close() })
+	return directory
+}
+
+// URL returns the service address of the mock directory
+func (d *mockDirectory) URL() string {
+	return "ldap://" + d.listener.Addr().String()
+}
+
+// Binds returns the DNs of the successful binds made so far
+func (d *mockDirectory) Binds() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return append([]string{}, d.binds...)
+}
+
+func (d *mockDirectory) serve() {
+	for {
+		conn, err := d.listener.Accept()
+		if err != nil {
+			return
+		}
+		go d.handle(conn)
+	}
+}
+
+func (d *mockDirectory) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageID := packet.Children[0].Value
+		request := packet.Children[1]
+		var responses []*ber.Packet
+		switch request.Tag {
+		case ldap.ApplicationBindRequest:
+			responses = []*ber.Packet{d.bind(request)}
+		case ldap.ApplicationSearchRequest:
+			responses = d.search(request)
+		default:
+			return
+		}
+		for _, response := range responses {
+			envelope := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+			envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "MessageID"))
+			envelope.AppendChild(response)
+			if _, err := conn.Write(envelope.Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (d *mockDirectory) bind(request *ber.Packet) *ber.Packet {
+	dn := request.Children[1].Data.String()
+	password := request.Children[2].Data.String()
+	resultCode := ldap.LDAPResultInvalidCredentials
+	if dn == "" && password == "" {
+		resultCode = ldap.LDAPResultSuccess
+	}
+	for _, entry := range d.entries {
+		if strings.EqualFold(entry.DN, dn) && entry.Password != "" && entry.Password == password {
+			resultCode = ldap.LDAPResultSuccess
+			d.mutex.Lock()
+			d.binds = append(d.binds, entry.DN)
+			d.mutex.Unlock()
+		}
+	}
+	return ldapResult(ldap.ApplicationBindResponse, resultCode)
+}
+
+func (d *mockDirectory) search(request *ber.Packet) []*ber.Packet {
+	baseDN := request.Children[0].Data.String()
+	filter, err := ldap.DecompileFilter(request.Children[6])
+	if err != nil {
+		return []*ber.Packet{ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultProtocolError)}
+	}
+	// only equality filters like (uid=value) are expected
+	assertion := strings.SplitN(strings.Trim(filter, "()"), "=", 2)
+	if len(assertion) != 2 {
+		return []*ber.Packet{ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultUnwillingToPerform)}
+	}
+	attribute, value := assertion[0], assertion[1]
+	var requested []string
+	for _, child := range request.Children[7].Children {
+		requested = append(requested, child.Data.String())
+	}
+
+	var responses []*ber.Packet
+	for _, entry := range d.entries {
+		if !strings.HasSuffix(strings.ToLower(entry.DN), strings.ToLower(baseDN)) {
+			continue
+		}
+		if !containsFold(entry.Attributes[attribute], value) {
+			continue
+		}
+		result := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+		result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.DN, "objectName"))
+		attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "attributes")
+		for _, name := range requested {
+			attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "attribute")
+			attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "type"))
+			values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "values")
+			for _, v := range entry.Attributes[name] {
+				values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "value"))
+			}
+			attr.AppendChild(values)
+			attributes.AppendChild(attr)
+		}
+		result.AppendChild(attributes)
+		responses = append(responses, result)
+	}
+	return append(responses, ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+}
+
+func ldapResult(tag ber.Tag, resultCode int) *ber.Packet {
+	result := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	result.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(resultCode), "resultCode"))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matchedDN"))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "diagnosticMessage"))
+	return result
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
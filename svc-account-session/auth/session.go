@@ -18,6 +18,7 @@ package auth
 import (
 	"encoding/base64"
 	log "github.com/sirupsen/logrus"
+	"strconv"
 	"sync"
 	"time"
 
@@ -40,18 +41,98 @@ func CheckSessionCreationCredentials(userName, password string) (*asmodel.User,
 	}
 	user, err := asmodel.GetUserDetails(userName)
 	if err != nil {
+		// users without a local account are authenticated by the external account providers
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return checkExternalAccountProviders(userName, password)
+		}
 		return nil, errors.PackError(err.ErrNo(), "error: Invalid username or password :", err.Error())
 	}
+	policy, err := asmodel.GetAccountPolicy()
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get account policy: ", err.Error())
+	}
+	now := time.Now()
+	// the response for a locked account is same as for invalid credentials,
+	// so that the lockout does not reveal the existence of the account
+	if policy.IsLocked(user, now) {
+		log.Warn("Login attempt for the locked account " + userName)
+		return nil, errors.PackError(errors.UndefinedErrorType, "error: Invalid username or password ")
+	}
 	hash := sha3.New512()
 	hash.Write([]byte(password))
 	hashSum := hash.Sum(nil)
 	hashedPassword := base64.URLEncoding.EncodeToString(hashSum)
 	if user.Password != hashedPassword {
+		recordFailedLogin(userName, policy, now)
 		return nil, errors.PackError(errors.UndefinedErrorType, "error: Invalid username or password ")
 	}
+	if user.FailedLoginCount != 0 || user.Locked {
+		resetFailedLogins(userName)
+	}
+	if policy.IsPasswordExpired(user, now) {
+		user.PasswordChangeRequired = true
+	}
 	return &user, nil
 }
 
+// recordFailedLogin increments the failed login counter of the account
+// and locks the account once the lockout threshold is reached
+func recordFailedLogin(userName string, policy asmodel.AccountPolicy, now time.Time) {
+	if policy.AccountLockoutThreshold == 0 {
+		return
+	}
+	unlock, err := asmodel.LockLoginDetails(userName)
+	if err != nil {
+		log.Error("Unable to record the failed login of account " + userName + ": " + err.Error())
+		return
+	}
+	defer unlock()
+	user, err := asmodel.GetUserDetails(userName)
+	if err != nil {
+		log.Error("Unable to get account " + userName + " for recording the failed login: " + err.Error())
+		return
+	}
+	resetAfter := time.Duration(policy.AccountLockoutCounterResetAfter) * time.Second
+	if policy.AccountLockoutCounterResetAfter != 0 && now.Sub(user.LastFailedLoginTime) >= resetAfter {
+		user.FailedLoginCount = 0
+	}
+	// a lock which outlived the lockout duration starts a new count
+	if user.Locked && !policy.IsLocked(user, now) {
+		user.Locked = false
+		user.FailedLoginCount = 0
+	}
+	user.FailedLoginCount++
+	user.LastFailedLoginTime = now
+	if user.FailedLoginCount >= policy.AccountLockoutThreshold {
+		user.Locked = true
+		user.LockedTime = now
+		log.Warn("Account " + userName + " is locked after " + strconv.Itoa(user.FailedLoginCount) + " failed login attempts")
+	}
+	if err = asmodel.UpdateUserDetails(user, asmodel.User{}); err != nil {
+		log.Error("Unable to record the failed login of account " + userName + ": " + err.Error())
+	}
+}
+
+// resetFailedLogins clears the failed login counter and the lock of the account
+func resetFailedLogins(userName string) {
+	unlock, err := asmodel.LockLoginDetails(userName)
+	if err != nil {
+		log.Error("Unable to reset the failed logins of account " + userName + ": " + err.Error())
+		return
+	}
+	defer unlock()
+	user, err := asmodel.GetUserDetails(userName)
+	if err != nil {
+		log.Error("Unable to get account " + userName + " for resetting the failed logins: " + err.Error())
+		return
+	}
+	user.FailedLoginCount = 0
+	user.Locked = false
+	if err = asmodel.UpdateUserDetails(user, asmodel.User{}); err != nil {
+		log.Error("Unable to reset the failed logins of account " + userName + ": " + err.Error())
+	}
+}
+
 // CheckSessionTimeOut defines the session validity check
 func CheckSessionTimeOut(sessionToken string) (*asmodel.Session, *errors.Error) {
 	go expiredSessionCleanUp()
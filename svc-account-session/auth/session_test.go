@@ -175,3 +175,93 @@ func TestCheckSessionTimeOut(t *testing.T) {
 		time.Sleep(4 * time.Second)
 	}
 }
+
+func TestCheckSessionCreationCredentialsWithAccountPolicy(t *testing.T) {
+	config.SetUpMockConfig(t)
+	Lock.Lock()
+	common.SetUpMockConfig()
+	Lock.Unlock()
+	defer func() {
+		err := common.TruncateDB(common.OnDisk)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+	if err := createMockUser("lockoutUser", common.RoleAdmin); err != nil {
+		t.Fatalf("Error in creating mock user %v", err)
+	}
+	policy := asmodel.AccountPolicy{
+		AccountLockoutThreshold:         3,
+		AccountLockoutDuration:          600,
+		AccountLockoutCounterResetAfter: 300,
+	}
+	if err := asmodel.SaveAccountPolicy(policy); err != nil {
+		t.Fatalf("Error in saving the account policy %v", err)
+	}
+	login := func(password string) bool {
+		_, err := CheckSessionCreationCredentials("lockoutUser", password)
+		return err == nil
+	}
+	getUser := func() asmodel.User {
+		user, err := asmodel.GetUserDetails("lockoutUser")
+		if err != nil {
+			t.Fatalf("Error in getting mock user %v", err)
+		}
+		return user
+	}
+
+	// successful login resets the failed login counter
+	login("wrong")
+	login("wrong")
+	if !login("P@$$w0rd") {
+		t.Fatal("login should succeed before reaching the lockout threshold")
+	}
+	if user := getUser(); user.FailedLoginCount != 0 {
+		t.Errorf("FailedLoginCount = %v, want 0", user.FailedLoginCount)
+	}
+
+	// failed logins older than the counter reset time are not counted
+	login("wrong")
+	login("wrong")
+	user := getUser()
+	user.LastFailedLoginTime = time.Now().Add(-time.Hour)
+	asmodel.UpdateUserDetails(user, asmodel.User{})
+	login("wrong")
+	if user := getUser(); user.FailedLoginCount != 1 || user.Locked {
+		t.Errorf("FailedLoginCount = %v, Locked = %v, want 1, false", user.FailedLoginCount, user.Locked)
+	}
+
+	// account is locked once the threshold is reached
+	login("wrong")
+	login("wrong")
+	if user := getUser(); !user.Locked {
+		t.Fatal("account should be locked after reaching the lockout threshold")
+	}
+	if login("P@$$w0rd") {
+		t.Error("login should fail while the account is locked")
+	}
+
+	// lock is released after the lockout duration
+	user = getUser()
+	user.LockedTime = time.Now().Add(-time.Hour)
+	asmodel.UpdateUserDetails(user, asmodel.User{})
+	if !login("P@$$w0rd") {
+		t.Error("login should succeed after the lockout duration")
+	}
+	if user := getUser(); user.Locked || user.FailedLoginCount != 0 {
+		t.Errorf("Locked = %v, FailedLoginCount = %v, want false, 0", user.Locked, user.FailedLoginCount)
+	}
+
+	// expired password has to be changed
+	asmodel.SaveAccountPolicy(asmodel.AccountPolicy{PasswordExpirationDays: 1})
+	user = getUser()
+	user.PasswordChangedTime = time.Now().AddDate(0, 0, -2)
+	asmodel.UpdateUserDetails(user, asmodel.User{})
+	got, err := CheckSessionCreationCredentials("lockoutUser", "P@$$w0rd")
+	if err != nil {
+		t.Fatalf("login with expired password should succeed, got %v", err)
+	}
+	if !got.PasswordChangeRequired {
+		t.Error("PasswordChangeRequired should be set for the expired password")
+	}
+}
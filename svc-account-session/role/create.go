@@ -45,9 +45,9 @@ func Create(req *roleproto.RoleRequest, session *asmodel.Session) response.RPC {
 	var createRoleReq asmodel.Role
 	err := json.Unmarshal(req.RequestBody, &createRoleReq)
 	if err != nil {
-		errMsg := "unable to parse the add request" + err.Error()
+		errMsg := "unable to parse the create role request: " + err.Error()
 		log.Error(errMsg)
-		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
 	}
 
 	commonResponse := response.Response{
@@ -139,6 +139,13 @@ func Create(req *roleproto.RoleRequest, session *asmodel.Session) response.RPC {
 		return resp
 	}
 
+	privilege, duplicatePresent := isDuplicatePrivilegesPresent(createRoleReq)
+	if duplicatePresent {
+		errorMessage := "Duplicate privileges can not be assigned to a role"
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errorMessage, []interface{}{privilege, privilege}, nil)
+	}
+
 	if len(createRoleReq.AssignedPrivileges) != 0 {
 		status, messageArgs, err := validateAssignedPrivileges(createRoleReq.AssignedPrivileges)
 		if err != nil {
@@ -249,6 +256,9 @@ func Create(req *roleproto.RoleRequest, session *asmodel.Session) response.RPC {
 
 	resp.StatusCode = http.StatusCreated
 	resp.StatusMessage = response.ResourceCreated
+	resp.Header = map[string]string{
+		"Location": "/redfish/v1/AccountService/Roles/" + role.ID,
+	}
 
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
 	commonResponse.ID = createRoleReq.ID
@@ -56,6 +56,19 @@ func mockPrivilegeRegistry() error {
 	return nil
 }
 
+func mockOEMPrivilegeRegistry() error {
+	list := asmodel.OEMPrivileges{
+		List: []string{
+			"OemClearLogs",
+			"OemManageFirmware",
+		},
+	}
+	if err := list.Create(); err != nil {
+		return err
+	}
+	return nil
+}
+
 func TestCreate(t *testing.T) {
 	config.SetUpMockConfig(t)
 	common.SetUpMockConfig()
@@ -185,6 +198,9 @@ func TestCreate(t *testing.T) {
 			want: response.RPC{
 				StatusCode:    http.StatusCreated,
 				StatusMessage: response.ResourceCreated,
+				Header: map[string]string{
+					"Location": "/redfish/v1/AccountService/Roles/testRole",
+				},
 				Body: asresponse.UserRole{
 					IsPredefined:       false,
 					AssignedPrivileges: []string{common.PrivilegeLogin},
@@ -310,3 +326,128 @@ func TestCreate(t *testing.T) {
 	}
 
 }
+
+func TestCreateWithPrivilegeRegistry(t *testing.T) {
+	config.SetUpMockConfig(t)
+	common.SetUpMockConfig()
+	defer truncateDB(t)
+	session := &asmodel.Session{
+		Privileges: map[string]bool{
+			common.PrivilegeConfigureUsers: true,
+		},
+	}
+	if err := mockRedfishRoles(); err != nil {
+		t.Fatalf("Error in creating mock redfish predefined roles %v", err)
+	}
+
+	reqBodyOEMRole, _ := json.Marshal(asmodel.Role{
+		ID:            "oemRole",
+		OEMPrivileges: []string{"OemClearLogs"},
+	})
+	// privilege registries are not yet loaded
+	got := Create(&roleproto.RoleRequest{RequestBody: reqBodyOEMRole}, session)
+	if got.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Create() without OEM privilege registry status = %v, want %v", got.StatusCode, http.StatusInternalServerError)
+	}
+	reqBodyLoginRole, _ := json.Marshal(asmodel.Role{
+		ID:                 "loginRole",
+		AssignedPrivileges: []string{common.PrivilegeLogin},
+	})
+	got = Create(&roleproto.RoleRequest{RequestBody: reqBodyLoginRole}, session)
+	if got.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Create() without privilege registry status = %v, want %v", got.StatusCode, http.StatusInternalServerError)
+	}
+
+	if err := mockPrivilegeRegistry(); err != nil {
+		t.Fatalf("Error in creating mock privilege registry %v", err)
+	}
+	if err := mockOEMPrivilegeRegistry(); err != nil {
+		t.Fatalf("Error in creating mock OEM privilege registry %v", err)
+	}
+
+	reqBodyInvalidOEM, _ := json.Marshal(asmodel.Role{
+		ID:            "oemRole",
+		OEMPrivileges: []string{"OemReboot"},
+	})
+	reqBodyDuplicate, _ := json.Marshal(asmodel.Role{
+		ID:                 "dupRole",
+		AssignedPrivileges: []string{common.PrivilegeLogin, common.PrivilegeLogin},
+	})
+	reqBodyDuplicateOEM, _ := json.Marshal(asmodel.Role{
+		ID:            "dupRole",
+		OEMPrivileges: []string{"OemClearLogs", "OemClearLogs"},
+	})
+	tests := []struct {
+		name          string
+		reqBody       []byte
+		wantCode      int32
+		wantMessage   string
+		wantErrorArgs []interface{}
+	}{
+		{
+			name:        "malformed request body",
+			reqBody:     []byte(`{"RoleId":`),
+			wantCode:    http.StatusBadRequest,
+			wantMessage: response.MalformedJSON,
+		},
+		{
+			name:          "invalid OEM privilege",
+			reqBody:       reqBodyInvalidOEM,
+			wantCode:      http.StatusBadRequest,
+			wantMessage:   response.PropertyValueNotInList,
+			wantErrorArgs: []interface{}{"OemReboot", "OemPrivileges"},
+		},
+		{
+			name:          "duplicate assigned privileges",
+			reqBody:       reqBodyDuplicate,
+			wantCode:      http.StatusBadRequest,
+			wantMessage:   response.PropertyValueConflict,
+			wantErrorArgs: []interface{}{common.PrivilegeLogin, common.PrivilegeLogin},
+		},
+		{
+			name:          "duplicate OEM privileges",
+			reqBody:       reqBodyDuplicateOEM,
+			wantCode:      http.StatusBadRequest,
+			wantMessage:   response.PropertyValueConflict,
+			wantErrorArgs: []interface{}{"OemClearLogs", "OemClearLogs"},
+		},
+		{
+			name:        "role with only OEM privileges",
+			reqBody:     reqBodyOEMRole,
+			wantCode:    http.StatusCreated,
+			wantMessage: response.ResourceCreated,
+		},
+		{
+			name:        "role with assigned privileges",
+			reqBody:     reqBodyLoginRole,
+			wantCode:    http.StatusCreated,
+			wantMessage: response.ResourceCreated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Create(&roleproto.RoleRequest{RequestBody: tt.reqBody}, session)
+			if got.StatusCode != tt.wantCode || got.StatusMessage != tt.wantMessage {
+				t.Fatalf("Create() = %v %v, want %v %v", got.StatusCode, got.StatusMessage, tt.wantCode, tt.wantMessage)
+			}
+			if tt.wantErrorArgs == nil {
+				return
+			}
+			body, ok := got.Body.(response.CommonError)
+			if !ok || len(body.Error.MessageExtendedInfo) == 0 {
+				t.Fatalf("Create() returned unexpected error body %v", got.Body)
+			}
+			if !reflect.DeepEqual(body.Error.MessageExtendedInfo[0].MessageArgs, tt.wantErrorArgs) {
+				t.Errorf("Create() message args = %v, want %v", body.Error.MessageExtendedInfo[0].MessageArgs, tt.wantErrorArgs)
+			}
+		})
+	}
+
+	role, err := asmodel.GetRoleDetailsByID("oemRole")
+	if err != nil {
+		t.Fatalf("error while reading created role: %v", err)
+	}
+	if !reflect.DeepEqual(role.OEMPrivileges, []string{"OemClearLogs"}) {
+		t.Errorf("stored OEM privileges = %v, want [OemClearLogs]", role.OEMPrivileges)
+	}
+}
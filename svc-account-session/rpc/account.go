@@ -410,3 +410,66 @@ func (a *Account) Delete(ctx context.Context, req *accountproto.DeleteAccountReq
 
 	return &resp, nil
 }
+
+// UpdateAccountService defines the operations which handles the RPC request response
+// for the update of the account service properties of account-session micro service.
+// The functionality retrives the request and return backs the response to
+// RPC according to the protoc file defined in the util-lib package.
+// The function also checks for the session time out of the token
+// which is present in the request.
+func (a *Account) UpdateAccountService(ctx context.Context, req *accountproto.UpdateAccountServiceRequest) (*accountproto.AccountResponse, error) {
+	var resp accountproto.AccountResponse
+	errorArgs := []response.ErrArgs{
+		response.ErrArgs{
+			StatusMessage: "",
+			ErrorMessage:  "",
+			MessageArgs:   []interface{}{},
+		},
+	}
+	args := &response.Args{
+		Code:      response.GeneralError,
+		Message:   "",
+		ErrorArgs: errorArgs,
+	}
+	sess, errs := CheckSessionTimeOutFunc(req.SessionToken)
+	if errs != nil {
+		errorMessage := "error while authorizing session token: " + errs.Error()
+		resp.StatusCode, resp.StatusMessage = errs.GetAuthStatusCodeAndMessage()
+		if resp.StatusCode == http.StatusServiceUnavailable {
+			resp.Body, _ = json.Marshal(common.GeneralError(resp.StatusCode, resp.StatusMessage, errorMessage, []interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil).Body)
+			log.Error(errorMessage)
+		} else {
+			resp.Body, _ = json.Marshal(common.GeneralError(resp.StatusCode, resp.StatusMessage, errorMessage, nil, nil).Body)
+			auth.CustomAuthLog(req.SessionToken, "Invalid session token", resp.StatusCode)
+		}
+		return &resp, nil
+	}
+
+	err := UpdateLastUsedTimeFunc(req.SessionToken)
+	if err != nil {
+		errorMessage := "error while updating last used time of session with token " + req.SessionToken + ": " + err.Error()
+		resp.StatusCode = http.StatusInternalServerError
+		resp.StatusMessage = response.InternalError
+		errorArgs[0].ErrorMessage = errorMessage
+		errorArgs[0].StatusMessage = resp.StatusMessage
+		resp.Body, _ = json.Marshal(args.CreateGenericErrorResponse())
+		log.Error(errorMessage)
+		return &resp, nil
+	}
+
+	acc := account.GetExternalInterface()
+
+	data := acc.UpdateAccountService(req, sess)
+	resp.Body, err = MarshalFunc(data.Body)
+	if err != nil {
+		resp.StatusCode = http.StatusInternalServerError
+		resp.StatusMessage = "error while trying to marshal the response body for update account service: " + err.Error()
+		log.Error(resp.StatusMessage)
+		return &resp, nil
+	}
+	resp.StatusCode = data.StatusCode
+	resp.StatusMessage = data.StatusMessage
+	resp.Header = data.Header
+
+	return &resp, nil
+}
@@ -587,3 +587,72 @@ func TestAccount_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestAccount_UpdateAccountService(t *testing.T) {
+	common.SetUpMockConfig()
+	tests := []struct {
+		name                    string
+		CheckSessionTimeOutFunc func(sessionToken string) (*asmodel.Session, *errors.Error)
+		UpdateLastUsedTimeFunc  func(token string) error
+		MarshalFunc             func(v any) ([]byte, error)
+		wantStatusCode          int32
+		wantStatusMessage       string
+	}{
+		{
+			name: "Session Timeout Error for 401(not valid session)",
+			CheckSessionTimeOutFunc: func(sessionToken string) (*asmodel.Session, *errors.Error) {
+				return nil, errors.PackError(errors.InvalidAuthToken, "error: invalid token ", sessionToken)
+			},
+			UpdateLastUsedTimeFunc: func(token string) error { return nil },
+			MarshalFunc:            func(v any) ([]byte, error) { return nil, nil },
+			wantStatusCode:         401,
+			wantStatusMessage:      response.NoValidSession,
+		},
+		{
+			name: "UpdateLastUsedTime error",
+			CheckSessionTimeOutFunc: func(sessionToken string) (*asmodel.Session, *errors.Error) {
+				return &asmodel.Session{}, nil
+			},
+			UpdateLastUsedTimeFunc: func(token string) error { return e.New("fakeError") },
+			MarshalFunc:            func(v any) ([]byte, error) { return nil, nil },
+			wantStatusCode:         500,
+			wantStatusMessage:      response.InternalError,
+		},
+		{
+			name: "Marshall error",
+			CheckSessionTimeOutFunc: func(sessionToken string) (*asmodel.Session, *errors.Error) {
+				return &asmodel.Session{}, nil
+			},
+			UpdateLastUsedTimeFunc: func(token string) error { return nil },
+			MarshalFunc:            func(v any) ([]byte, error) { return nil, e.New("fakeError") },
+			wantStatusCode:         500,
+			wantStatusMessage:      "error while trying to marshal the response body for update account service: fakeError",
+		},
+		{
+			name: "Insufficient privilege",
+			CheckSessionTimeOutFunc: func(sessionToken string) (*asmodel.Session, *errors.Error) {
+				return &asmodel.Session{}, nil
+			},
+			UpdateLastUsedTimeFunc: func(token string) error { return nil },
+			MarshalFunc:            func(v any) ([]byte, error) { return nil, nil },
+			wantStatusCode:         403,
+			wantStatusMessage:      response.InsufficientPrivilege,
+		},
+	}
+	for _, tt := range tests {
+		CheckSessionTimeOutFunc = tt.CheckSessionTimeOutFunc
+		UpdateLastUsedTimeFunc = tt.UpdateLastUsedTimeFunc
+		MarshalFunc = tt.MarshalFunc
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Account{}
+			got, err := a.UpdateAccountService(context.TODO(), &accountproto.UpdateAccountServiceRequest{})
+			if err != nil {
+				t.Errorf("UpdateAccountService() error = %v", err)
+				return
+			}
+			if got.StatusCode != tt.wantStatusCode || got.StatusMessage != tt.wantStatusMessage {
+				t.Errorf("UpdateAccountService() got = %v %v, want %v %v", got.StatusCode, got.StatusMessage, tt.wantStatusCode, tt.wantStatusMessage)
+			}
+		})
+	}
+}
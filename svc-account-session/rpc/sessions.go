@@ -38,6 +38,7 @@ var (
 	GetSessionServiceFunc    = session.GetSessionService
 	GetSessionUserNameFunc   = session.GetSessionUserName
 	GetSessionUserRoleIDFunc = session.GetSessionUserRoleID
+	CreateJobSessionFunc     = session.CreateJobSession
 	MarshalFunc              = json.Marshal
 )
 
@@ -116,6 +117,18 @@ func (s *Session) GetSessionUserRoleID(ctx context.Context, req *sessionproto.Se
 	return resp, err
 }
 
+// CreateJobSession is a rpc call to create a session for the owner of a scheduled job
+// the job is run using the session when it is due
+func (s *Session) CreateJobSession(ctx context.Context, req *sessionproto.JobSessionRequest) (*sessionproto.SessionCreateResponse, error) {
+	var resp sessionproto.SessionCreateResponse
+	response, sessionID := CreateJobSessionFunc(req)
+	resp.SessionId = sessionID
+	resp.StatusCode = response.StatusCode
+	resp.StatusMessage = response.StatusMessage
+	resp.Header = response.Header
+	return &resp, nil
+}
+
 // GetAllActiveSessions is a rpc call to get all active sessions
 // This method will accepts the sessionrequest which has session id and session token
 // and it will call GetAllActiveSessions from the session package
@@ -110,15 +110,24 @@ func CreateNewSession(req *sessionproto.SessionCreateRequest) (response.RPC, str
 		return common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, errorMessage, nil, nil), ""
 	}
 
+	// session of an account whose password has to be changed is only allowed
+	// to modify its own account, until the password is changed
+	if user.PasswordChangeRequired {
+		rolePrivilege = map[string]bool{
+			common.PrivilegeConfigureSelf: true,
+		}
+	}
+
 	currentTime := time.Now()
 	sess := asmodel.Session{
-		ID:           uuid.NewV4().String(),
-		Token:        uuid.NewV4().String(),
-		UserName:     user.UserName,
-		RoleID:       user.RoleID,
-		Privileges:   rolePrivilege,
-		CreatedTime:  currentTime,
-		LastUsedTime: currentTime,
+		ID:                  uuid.NewV4().String(),
+		Token:               uuid.NewV4().String(),
+		UserName:            user.UserName,
+		RoleID:              user.RoleID,
+		Privileges:          rolePrivilege,
+		CreatedTime:         currentTime,
+		LastUsedTime:        currentTime,
+		AccountProviderType: user.AccountProviderType,
 	}
 	auth.Lock.Lock()
 	defer auth.Lock.Unlock()
@@ -147,10 +156,22 @@ func CreateNewSession(req *sessionproto.SessionCreateRequest) (response.RPC, str
 	commonResponse.ID = sess.ID
 	commonResponse.OdataID = "/redfish/v1/SessionService/Sessions/" + commonResponse.ID
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
-	resp.Body = asresponse.Session{
+	sessionResponse := asresponse.Session{
 		Response: commonResponse,
 		UserName: createSession.UserName,
 	}
+	if user.PasswordChangeRequired {
+		args := response.Args{
+			ErrorArgs: []response.ErrArgs{
+				response.ErrArgs{
+					StatusMessage: response.PasswordChangeRequired,
+					MessageArgs:   []interface{}{"/redfish/v1/AccountService/Accounts/" + user.UserName},
+				},
+			},
+		}
+		sessionResponse.MessageExtendedInfo = args.CreateGenericErrorResponse().Error.MessageExtendedInfo
+	}
+	resp.Body = sessionResponse
 
 	return resp, commonResponse.ID
 }
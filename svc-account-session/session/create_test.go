@@ -224,3 +224,41 @@ func TestCreateSession(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateSessionWithPasswordChangeRequired(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	auth.Lock.Lock()
+	common.SetUpMockConfig()
+	auth.Lock.Unlock()
+	if err := createMockRole(common.RoleAdmin, []string{common.PrivilegeConfigureUsers, common.PrivilegeLogin}, []string{}); err != nil {
+		t.Fatalf("Error while creating role: %v", err)
+	}
+	if err := createMockUser("newuser", common.RoleAdmin); err != nil {
+		t.Fatalf("Error while creating account: %v", err)
+	}
+	user, _ := asmodel.GetUserDetails("newuser")
+	user.PasswordChangeRequired = true
+	asmodel.UpdateUserDetails(user, asmodel.User{})
+
+	reqBody, _ := json.Marshal(asmodel.CreateSession{UserName: "newuser", Password: "P@$$w0rd"})
+	resp, sessionID := CreateNewSession(&sessionproto.SessionCreateRequest{RequestBody: reqBody})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateNewSession() status = %v, want %v", resp.StatusCode, http.StatusCreated)
+	}
+	body := resp.Body.(asresponse.Session)
+	if len(body.MessageExtendedInfo) != 1 || body.MessageExtendedInfo[0].MessageID != response.PasswordChangeRequired {
+		t.Errorf("session response should report PasswordChangeRequired, got %v", body.MessageExtendedInfo)
+	}
+	sess, err := asmodel.GetSession(resp.Header["X-Auth-Token"])
+	if err != nil {
+		t.Fatalf("Error while getting session %v: %v", sessionID, err)
+	}
+	want := map[string]bool{common.PrivilegeConfigureSelf: true}
+	if !reflect.DeepEqual(sess.Privileges, want) {
+		t.Errorf("session privileges = %v, want %v", sess.Privileges, want)
+	}
+}
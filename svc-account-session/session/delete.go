@@ -110,6 +110,10 @@ func DeleteSession(req *sessionproto.SessionRequest) response.RPC {
 }
 
 func checkPrivilege(sessionToken string, session, currentSession *asmodel.Session) bool {
+	// the session of a job run is deleted with its own token once the run is done
+	if session.Origin != "" && session.Token == sessionToken {
+		return true
+	}
 	if (session.UserName == currentSession.UserName && currentSession.Privileges[common.PrivilegeConfigureSelf]) ||
 		currentSession.Privileges[common.PrivilegeConfigureUsers] {
 		return true
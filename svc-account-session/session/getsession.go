@@ -46,6 +46,7 @@ func GetSessionUserName(req *sessionproto.SessionRequest) (*sessionproto.Session
 		return &resp, errs
 	}
 	resp.UserName = currentSession.UserName
+	resp.AccountProviderType = currentSession.AccountProviderType
 	return &resp, nil
 }
 
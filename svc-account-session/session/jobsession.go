@@ -0,0 +1,130 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package session
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	sessionproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/session"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
+	uuid "github.com/satori/go.uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateJobSession creates a session on behalf of the owner of a scheduled job,
+// the job is run with the session when it is due.
+// The owner is taken from the job stored by the task service, so that a session
+// is only created for the owner of an existing job.
+// The privileges of the session are of the current role of the account, so that
+// a job does not run with the privileges which are revoked after it is scheduled,
+// and the job of an account which is deleted fails to run.
+// For a user of an external account provider, which is not stored in ODIM,
+// the role the user had when the job was scheduled is used.
+func CreateJobSession(req *sessionproto.JobSessionRequest) (response.RPC, string) {
+	var resp response.RPC
+	owner, err := asmodel.GetJobOwner(path.Base(req.JobURI))
+	if err != nil {
+		errorMessage := "Unable to get the job " + req.JobURI + ": " + err.Error()
+		log.Error(errorMessage)
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil), ""
+		}
+		resp.CreateInternalErrorResponse(errorMessage)
+		return resp, ""
+	}
+	if owner.UserName != req.UserName {
+		errorMessage := "account " + req.UserName + " is not the owner of the job " + req.JobURI
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil), ""
+	}
+
+	roleID := owner.RoleID
+	if owner.AccountProviderType == "" {
+		user, err := asmodel.GetUserDetails(req.UserName)
+		if err != nil {
+			errorMessage := "Unable to get the account of the owner of the job " + req.JobURI + ": " + err.Error()
+			log.Error(errorMessage)
+			if err.ErrNo() == errors.DBKeyNotFound {
+				return common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil), ""
+			}
+			resp.CreateInternalErrorResponse(errorMessage)
+			return resp, ""
+		}
+		if user.Locked || user.PasswordChangeRequired {
+			errorMessage := "account " + req.UserName + " is not allowed to run the job " + req.JobURI
+			log.Error(errorMessage)
+			return common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, errorMessage, nil, nil), ""
+		}
+		roleID = user.RoleID
+	}
+	if roleID == "" {
+		errorMessage := "no role found for the owner of the job " + req.JobURI
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil), ""
+	}
+
+	role, err := asmodel.GetRoleDetailsByID(roleID)
+	if err != nil {
+		errorMessage := "Unable to get role privileges for job session creation: " + err.Error()
+		log.Error(errorMessage)
+		resp.CreateInternalErrorResponse(errorMessage)
+		return resp, ""
+	}
+	rolePrivilege := make(map[string]bool)
+	for _, privilege := range role.AssignedPrivileges {
+		rolePrivilege[privilege] = true
+	}
+
+	currentTime := time.Now()
+	sess := asmodel.Session{
+		ID:                  uuid.NewV4().String(),
+		Token:               uuid.NewV4().String(),
+		UserName:            req.UserName,
+		RoleID:              roleID,
+		Privileges:          rolePrivilege,
+		Origin:              req.JobURI,
+		CreatedTime:         currentTime,
+		LastUsedTime:        currentTime,
+		AccountProviderType: owner.AccountProviderType,
+	}
+	auth.Lock.Lock()
+	defer auth.Lock.Unlock()
+	if err = sess.Persist(); err != nil {
+		errMsg := "error while trying to insert session details: " + err.Error()
+		if err.ErrNo() == errors.DBConnFailed {
+			msgArgs := []interface{}{fmt.Sprintf("%v:%v", config.Data.DBConf.InMemoryHost, config.Data.DBConf.InMemoryPort)}
+			resp = common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, errMsg, msgArgs, nil)
+		} else {
+			resp = common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+		}
+		log.Error(errMsg)
+		return resp, ""
+	}
+
+	resp.StatusCode = http.StatusCreated
+	resp.StatusMessage = response.Created
+	resp.Header = map[string]string{
+		"X-Auth-Token": sess.Token,
+	}
+	return resp, sess.ID
+}
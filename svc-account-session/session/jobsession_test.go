@@ -0,0 +1,125 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package session
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	sessionproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/session"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
+)
+
+func TestCreateJobSession(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	auth.Lock.Lock()
+	common.SetUpMockConfig()
+	auth.Lock.Unlock()
+	if err := createMockRole(common.RoleAdmin, []string{common.PrivilegeConfigureComponents, common.PrivilegeLogin}, []string{}); err != nil {
+		t.Fatalf("Error while creating role: %v", err)
+	}
+	if err := createMockRole(common.RoleMonitor, []string{common.PrivilegeLogin}, []string{}); err != nil {
+		t.Fatalf("Error while creating role: %v", err)
+	}
+	if err := createMockUser("admin", common.RoleAdmin); err != nil {
+		t.Fatalf("Error while creating account: %v", err)
+	}
+	if err := asmodel.CreateUser(asmodel.User{UserName: "locked", RoleID: common.RoleAdmin, Locked: true}); err != nil {
+		t.Fatalf("Error while creating account: %v", err)
+	}
+	jobs := map[string]asmodel.JobOwner{
+		"1": {UserName: "admin", RoleID: common.RoleMonitor},
+		"2": {UserName: "directoryuser", RoleID: common.RoleMonitor, AccountProviderType: asmodel.LDAPProvider},
+		"3": {UserName: "directoryuser", AccountProviderType: asmodel.LDAPProvider},
+		"4": {UserName: "deleted", RoleID: common.RoleAdmin},
+		"5": {UserName: "locked", RoleID: common.RoleAdmin},
+	}
+	conn, _ := common.GetDBConnection(common.OnDisk)
+	for id, owner := range jobs {
+		if err := conn.AddResourceData("Job", id, owner); err != nil {
+			t.Fatalf("Error while creating job: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		req            *sessionproto.JobSessionRequest
+		wantStatusCode int32
+		wantRoleID     string
+	}{
+		{
+			name:           "local account",
+			req:            &sessionproto.JobSessionRequest{UserName: "admin", JobURI: "/redfish/v1/JobService/Jobs/1"},
+			wantStatusCode: http.StatusCreated,
+			wantRoleID:     common.RoleAdmin,
+		},
+		{
+			name:           "external account",
+			req:            &sessionproto.JobSessionRequest{UserName: "directoryuser", JobURI: "/redfish/v1/JobService/Jobs/2"},
+			wantStatusCode: http.StatusCreated,
+			wantRoleID:     common.RoleMonitor,
+		},
+		{
+			name:           "external account without role",
+			req:            &sessionproto.JobSessionRequest{UserName: "directoryuser", JobURI: "/redfish/v1/JobService/Jobs/3"},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "deleted account",
+			req:            &sessionproto.JobSessionRequest{UserName: "deleted", JobURI: "/redfish/v1/JobService/Jobs/4"},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "locked account",
+			req:            &sessionproto.JobSessionRequest{UserName: "locked", JobURI: "/redfish/v1/JobService/Jobs/5"},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "not the owner of the job",
+			req:            &sessionproto.JobSessionRequest{UserName: "admin", JobURI: "/redfish/v1/JobService/Jobs/2"},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "job not found",
+			req:            &sessionproto.JobSessionRequest{UserName: "admin", JobURI: "/redfish/v1/JobService/Jobs/6"},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, _ := CreateJobSession(tt.req)
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("CreateJobSession() status = %v, want %v", resp.StatusCode, tt.wantStatusCode)
+			}
+			if tt.wantStatusCode != http.StatusCreated {
+				return
+			}
+			session, err := asmodel.GetSession(resp.Header["X-Auth-Token"])
+			if err != nil {
+				t.Fatalf("error while getting the session: %v", err)
+			}
+			if session.RoleID != tt.wantRoleID || session.Origin != tt.req.JobURI {
+				t.Errorf("CreateJobSession() session = %+v, want role %v", session, tt.wantRoleID)
+			}
+		})
+	}
+}
@@ -57,7 +57,7 @@ type PluginStatusRecord struct {
 // SupportedConnectionMethodTypes is for validating the connection method type
 var SupportedConnectionMethodTypes = map[string]bool{
 	"Redfish": true,
-	"SNMP":    false,
+	"SNMP":    true,
 	"OEM":     false,
 	"NETCONF": false,
 	"IPMI15":  false,
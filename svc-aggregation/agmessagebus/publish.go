@@ -75,6 +75,36 @@ func Publish(systemID, eventType, collectionType string) {
 
 }
 
+// PublishDeviceEvent publishes the event raised by the device at host to the message bus,
+// the event service maps the event to the subscriptions of the device
+func PublishDeviceEvent(host string, event common.Event) {
+	topicName := config.Data.MessageBusConf.MessageBusQueue[0]
+	k, err := dc.Communicator(config.Data.MessageBusConf.MessageBusType, config.Data.MessageBusConf.MessageBusConfigFilePath, topicName)
+	if err != nil {
+		log.Error("Unable to connect to " + config.Data.MessageBusConf.MessageBusType + " " + err.Error())
+		return
+	}
+	if event.EventID == "" {
+		event.EventID = uuid.NewV4().String()
+	}
+	var messageData = common.MessageData{
+		Name:      "Device Event",
+		Context:   "/redfish/v1/$metadata#Event.Event",
+		OdataType: common.EventType,
+		Events:    []common.Event{event},
+	}
+	data, _ := json.Marshal(messageData)
+	var mbevent = common.Events{
+		IP:      host,
+		Request: data,
+	}
+	if err := k.Distribute(mbevent); err != nil {
+		log.Error("Unable Publish events to kafka" + err.Error())
+		return
+	}
+	log.Info("Event of device " + host + " Published")
+}
+
 // PublishCtrlMsg publishes ODIM control messages to the message bus
 func PublishCtrlMsg(msgType common.ControlMessage, msg interface{}) error {
 	topicName := config.Data.MessageBusConf.MessageBusQueue[0]
@@ -0,0 +1,54 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package agmodel
+
+import (
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// instanceID identifies this instance of the aggregation service as the owner of the leases it holds
+var instanceID = uuid.NewV4().String()
+
+// AcquireLease takes the lease with the key for expiretime seconds, so that the work guarded
+// by it is done by only one instance of the aggregation service. false is returned when
+// the lease is held by another instance.
+func AcquireLease(table, key string, expiretime int) (bool, *errors.Error) {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return false, err
+	}
+	return conn.AcquireLease(table, key, instanceID, expiretime)
+}
+
+// RenewLease extends the lease with the key held by this instance to expiretime seconds.
+// false is returned when the lease is no longer held by this instance.
+func RenewLease(table, key string, expiretime int) (bool, *errors.Error) {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return false, err
+	}
+	return conn.RenewLease(table, key, instanceID, expiretime)
+}
+
+// ReleaseLease gives up the lease with the key, if it is held by this instance
+func ReleaseLease(table, key string) *errors.Error {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return err
+	}
+	return conn.ReleaseLease(table, key, instanceID)
+}
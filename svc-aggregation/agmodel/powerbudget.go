@@ -0,0 +1,81 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package agmodel
+
+import (
+	"encoding/json"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+// PowerBudgetTable is the table holding the power budgets of the aggregates
+const PowerBudgetTable = "AggregatePowerBudget"
+
+// PowerBudgetRebalanceTable is the table holding the leases of the power budget rebalances,
+// a rebalance of the power budget of an aggregate is done by the instance holding the lease
+const PowerBudgetRebalanceTable = "AggregatePowerBudgetRebalance"
+
+// PowerBudget is the power budget of an aggregate, it is stored against the aggregate URI
+// along with the outcome of the last rebalance of the budget across the aggregate elements
+type PowerBudget struct {
+	LimitInWatts               float64            `json:"LimitInWatts"`
+	RebalanceIntervalInSeconds int                `json:"RebalanceIntervalInSeconds"`
+	ConsumedWatts              float64            `json:"ConsumedWatts"`
+	AllocatedWatts             map[string]float64 `json:"AllocatedWatts"`
+	BudgetExceeded             bool               `json:"BudgetExceeded"`
+	LastRebalanceTime          string             `json:"LastRebalanceTime,omitempty"`
+}
+
+// SavePowerBudget will save the power budget of the aggregate, the existing budget is overwritten
+func SavePowerBudget(aggregateURI string, budget PowerBudget) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.AddResourceData(PowerBudgetTable, aggregateURI, budget); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to save power budget: ", err.Error())
+	}
+	return nil
+}
+
+// GetPowerBudget fetches the power budget of the aggregate
+func GetPowerBudget(aggregateURI string) (PowerBudget, *errors.Error) {
+	var budget PowerBudget
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return budget, err
+	}
+	data, err := conn.Read(PowerBudgetTable, aggregateURI)
+	if err != nil {
+		return budget, errors.PackError(err.ErrNo(), "error: while trying to fetch power budget: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &budget); err != nil {
+		return budget, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return budget, nil
+}
+
+// DeletePowerBudget will delete the power budget of the aggregate
+func DeletePowerBudget(aggregateURI string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.Delete(PowerBudgetTable, aggregateURI); err != nil {
+		return err
+	}
+	return nil
+}
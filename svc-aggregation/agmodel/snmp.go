@@ -0,0 +1,117 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package agmodel
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+// SNMPAgentTable is the table holding the SNMP agents added as aggregation sources
+const SNMPAgentTable = "SNMPAgent"
+
+// SNMPLeaseTable is the table holding the leases of the SNMP polling and of the SNMP
+// trap receiver, which are run by only one instance of the aggregation service
+const SNMPLeaseTable = "SNMPLease"
+
+// SNMPAgent holds the details of an SNMP agent added as aggregation source,
+// it is stored against the IP address of the agent
+type SNMPAgent struct {
+	DeviceUUID           string `json:"DeviceUUID"`
+	AggregationSourceURI string `json:"AggregationSourceURI"`
+	ChassisURI           string `json:"ChassisURI"`
+}
+
+// SaveSNMPAgent will save the SNMP agent details against the agent IP address
+func SaveSNMPAgent(agentIP string, agent SNMPAgent) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.Create(SNMPAgentTable, agentIP, agent); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetSNMPAgent fetches the SNMP agent details of the given agent IP address
+func GetSNMPAgent(agentIP string) (SNMPAgent, *errors.Error) {
+	var agent SNMPAgent
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return agent, err
+	}
+	data, err := conn.Read(SNMPAgentTable, agentIP)
+	if err != nil {
+		return agent, errors.PackError(err.ErrNo(), "error: while trying to fetch SNMP agent data: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &agent); err != nil {
+		return agent, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return agent, nil
+}
+
+// DeleteSNMPAgent will delete the SNMP agent details of the given agent IP address
+func DeleteSNMPAgent(agentIP string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.Delete(SNMPAgentTable, agentIP); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SaveDeviceSubscription is to create the subscription details of device
+func SaveDeviceSubscription(devSubscription common.DeviceSubscription) error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err := conn.CreateDeviceSubscriptionIndex(common.DeviceSubscriptionIndex, devSubscription.EventHostIP, devSubscription.Location, devSubscription.OriginResources); err != nil {
+		return fmt.Errorf("error while trying to save subscription of device %v", err.Error())
+	}
+	return nil
+}
+
+// DeleteDeviceSubscription is to delete the subscription details of device
+func DeleteDeviceSubscription(hostIP string) error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	// search key is suffixed with a non digit pattern to avoid deleting
+	// the subscriptions of the hosts sharing the same IP prefix
+	if err := conn.DeleteDeviceSubscription(common.DeviceSubscriptionIndex, hostIP+`[^0-9]`); err != nil {
+		return fmt.Errorf("error while trying to delete subscription of device %v", err.Error())
+	}
+	return nil
+}
+
+// DeleteDeviceResources will delete all the resources of the device from InMemory DB
+func DeleteDeviceResources(deviceUUID string) *errors.Error {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return err
+	}
+	if err = conn.DeleteServer("*" + deviceUUID + "*"); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to delete device resources: ", err.Error())
+	}
+	return nil
+}
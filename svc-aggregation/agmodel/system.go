@@ -98,11 +98,12 @@ type AggregationSource struct {
 }
 
 // SNMP  payload of adding a SNMP
+// AuthenticationKey and EncryptionKey are stored encrypted
 type SNMP struct {
-	AuthenticationKey      string `json:"AuthenticationKey,omitempty"`
-	AuthenticationKeySet   string `json:"AuthenticationKeySet,omitempty"`
+	AuthenticationKey      []byte `json:"AuthenticationKey,omitempty"`
+	AuthenticationKeySet   bool   `json:"AuthenticationKeySet,omitempty"`
 	AuthenticationProtocol string `json:"AuthenticationProtocol,omitempty"`
-	EncryptionKey          string `json:"EncryptionKey,omitempty"`
+	EncryptionKey          []byte `json:"EncryptionKey,omitempty"`
 	EncryptionKeySet       bool   `json:"EncryptionKeySet,omitempty"`
 	EncryptionProtocol     string `json:"EncryptionProtocol,omitempty"`
 	TrapCommunity          string `json:"TrapCommunity,omitempty"`
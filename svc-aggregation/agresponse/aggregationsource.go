@@ -35,7 +35,7 @@ type AggregationSourceResponse struct {
 // SNMP defines the response for SNMP
 type SNMP struct {
 	AuthenticationKey      string `json:"AuthenticationKey,omitempty"`
-	AuthenticationKeySet   string `json:"AuthenticationKeySet,omitempty"`
+	AuthenticationKeySet   bool   `json:"AuthenticationKeySet,omitempty"`
 	AuthenticationProtocol string `json:"AuthenticationProtocol,omitempty"`
 	EncryptionKey          string `json:"EncryptionKey,omitempty"`
 	EncryptionKeySet       bool   `json:"EncryptionKeySet,omitempty"`
@@ -54,12 +54,73 @@ type AggregateGetResponse struct {
 	ElementsCount int               `json:"ElementsCount,omitempty"`
 	Elements      []agmodel.OdataID `json:"Elements"`
 	Actions       AggregateActions  `json:"Actions,omitempty"`
+	Oem           *AggregateOem     `json:"Oem,omitempty"`
 }
 
 // AggregateActions defines the links to the actions available under the service
 type AggregateActions struct {
-	AggregateReset               Action `json:"#Aggregate.Reset"`
-	AggregateSetDefaultBootOrder Action `json:"#Aggregate.SetDefaultBootOrder"`
-	AggregateAddElements         Action `json:"#Aggregate.AddElements"`
-	AggregateRemoveElements      Action `json:"#Aggregate.RemoveElements"`
+	AggregateReset               Action              `json:"#Aggregate.Reset"`
+	AggregateSetDefaultBootOrder Action              `json:"#Aggregate.SetDefaultBootOrder"`
+	AggregateAddElements         Action              `json:"#Aggregate.AddElements"`
+	AggregateRemoveElements      Action              `json:"#Aggregate.RemoveElements"`
+	Oem                          AggregateOemActions `json:"Oem"`
+}
+
+// AggregateOemActions defines the links to the ODIM specific actions of an aggregate
+type AggregateOemActions struct {
+	SetPowerBudget Action `json:"#Odim.SetPowerBudget"`
+}
+
+// AggregateOem defines the ODIM specific properties of an aggregate
+type AggregateOem struct {
+	Odim AggregateOdimOem `json:"Odim"`
+}
+
+// AggregateOdimOem defines the ODIM specific properties of an aggregate
+type AggregateOdimOem struct {
+	PowerBudget *PowerBudget `json:"PowerBudget,omitempty"`
+}
+
+// PowerBudget defines the response for the power budget of an aggregate
+type PowerBudget struct {
+	LimitInWatts               float64                 `json:"LimitInWatts"`
+	RebalanceIntervalInSeconds int                     `json:"RebalanceIntervalInSeconds"`
+	ConsumedWatts              float64                 `json:"ConsumedWatts"`
+	BudgetExceeded             bool                    `json:"BudgetExceeded"`
+	LastRebalanceTime          string                  `json:"LastRebalanceTime,omitempty"`
+	Allocations                []PowerBudgetAllocation `json:"Allocations"`
+}
+
+// PowerBudgetAllocation defines the share of the power budget allocated to an element of the aggregate
+type PowerBudgetAllocation struct {
+	Element        agmodel.OdataID `json:"Element"`
+	AllocatedWatts float64         `json:"AllocatedWatts"`
+}
+
+// ValidateAggregationSourceResponse defines the result of the pre-flight check of an aggregation source
+type ValidateAggregationSourceResponse struct {
+	HostName         string `json:"HostName"`
+	Connectivity     string `json:"Connectivity"`
+	Authentication   string `json:"Authentication"`
+	Manufacturer     string `json:"Manufacturer,omitempty"`
+	Model            string `json:"Model,omitempty"`
+	FirmwareVersion  string `json:"FirmwareVersion,omitempty"`
+	PluginID         string `json:"PluginId,omitempty"`
+	ConnectionMethod string `json:"ConnectionMethod"`
+	Message          string `json:"Message,omitempty"`
+}
+
+// ImportAggregationSourcesResponse defines the summary of a bulk import of aggregation sources
+type ImportAggregationSourcesResponse struct {
+	response.Response
+	Results []ImportAggregationSourceResult `json:"Results"`
+}
+
+// ImportAggregationSourceResult defines the outcome of a single row of a bulk import
+type ImportAggregationSourceResult struct {
+	HostName          string           `json:"HostName"`
+	SubTask           *agmodel.OdataID `json:"SubTask,omitempty"`
+	StatusCode        int32            `json:"StatusCode,omitempty"`
+	AggregationSource *agmodel.OdataID `json:"AggregationSource,omitempty"`
+	Message           string           `json:"Message,omitempty"`
 }
@@ -47,8 +47,15 @@ type AggregationServiceResponse struct {
 
 //Actions struct definition
 type Actions struct {
-	Reset               Action `json:"#AggregationService.Reset"`
-	SetDefaultBootOrder Action `json:"#AggregationService.SetDefaultBootOrder"`
+	Reset               Action                       `json:"#AggregationService.Reset"`
+	SetDefaultBootOrder Action                       `json:"#AggregationService.SetDefaultBootOrder"`
+	Oem                 AggregationServiceOemActions `json:"Oem"`
+}
+
+// AggregationServiceOemActions defines the links to the ODIM specific actions of the aggregation service
+type AggregationServiceOemActions struct {
+	ValidateAggregationSource Action `json:"#Odim.ValidateAggregationSource"`
+	ImportAggregationSources  Action `json:"#Odim.ImportAggregationSources"`
 }
 
 //Status struct definition
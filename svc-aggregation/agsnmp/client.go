@@ -0,0 +1,368 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package agsnmp
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/gosnmp/gosnmp"
+)
+
+const (
+	// ChassisID is the device local ID of the chassis created for an SNMP agent,
+	// ODIM exposes it as <device UUID>.ChassisID
+	ChassisID = "1"
+	// AuthenticationCommunityString is the authentication protocol of the SNMPv1/v2c agents
+	AuthenticationCommunityString = "CommunityString"
+	// ProtocolNone disables the SNMPv3 authentication or encryption
+	ProtocolNone = "None"
+
+	defaultPort = 161
+	// minKeyLength is the minimum length of an SNMPv3 pass phrase as per RFC 3414
+	minKeyLength = 8
+)
+
+// OIDs of the system group of the SNMPv2-MIB
+const (
+	sysDescrOID    = ".1.3.6.1.2.1.1.1.0"
+	sysObjectIDOID = ".1.3.6.1.2.1.1.2.0"
+	sysContactOID  = ".1.3.6.1.2.1.1.4.0"
+	sysNameOID     = ".1.3.6.1.2.1.1.5.0"
+	sysLocationOID = ".1.3.6.1.2.1.1.6.0"
+)
+
+// OIDs of the ENTITY-MIB and ENTITY-SENSOR-MIB used for collecting the sensors
+const (
+	entPhysicalNameOID   = ".1.3.6.1.2.1.47.1.1.1.1.7"
+	entPhySensorEntryOID = ".1.3.6.1.2.1.99.1.1.1"
+
+	entPhySensorType       = "1"
+	entPhySensorScale      = "2"
+	entPhySensorPrecision  = "3"
+	entPhySensorValue      = "4"
+	entPhySensorOperStatus = "5"
+)
+
+var authenticationProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+	ProtocolNone:     gosnmp.NoAuth,
+	"HMAC_MD5":       gosnmp.MD5,
+	"HMAC_SHA96":     gosnmp.SHA,
+	"HMAC128_SHA224": gosnmp.SHA224,
+	"HMAC192_SHA256": gosnmp.SHA256,
+	"HMAC256_SHA384": gosnmp.SHA384,
+	"HMAC384_SHA512": gosnmp.SHA512,
+}
+
+var encryptionProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+	ProtocolNone:    gosnmp.NoPriv,
+	"CBC_DES":       gosnmp.DES,
+	"CFB128_AES128": gosnmp.AES,
+}
+
+// sensorTypes maps the EntitySensorDataType of the ENTITY-SENSOR-MIB
+// to the redfish ReadingType and ReadingUnits of a sensor
+var sensorTypes = map[int64][2]string{
+	3:  {"Voltage", "V"},         // voltsAC
+	4:  {"Voltage", "V"},         // voltsDC
+	5:  {"Current", "A"},         // amperes
+	6:  {"Power", "W"},           // watts
+	7:  {"Frequency", "Hz"},      // hertz
+	8:  {"Temperature", "Cel"},   // celsius
+	9:  {"Humidity", "%"},        // percentRH
+	10: {"Rotational", "RPM"},    // rpm
+	11: {"AirFlowCMM", "m3/min"}, // cmm
+}
+
+// Credentials holds the decrypted credentials of an SNMP agent
+type Credentials struct {
+	// UserName is the SNMPv3 security name
+	UserName string
+	// Password is the community string when CommunityString authentication is used
+	Password               string
+	AuthenticationProtocol string
+	AuthenticationKey      string
+	EncryptionProtocol     string
+	EncryptionKey          string
+	// TrapCommunity is the community string of the SNMPv1/v2c traps sent by the agent
+	TrapCommunity string
+}
+
+// DeviceInfo holds the details collected from an SNMP agent
+type DeviceInfo struct {
+	Description string
+	ObjectID    string
+	Name        string
+	Contact     string
+	Location    string
+	Sensors     []Sensor
+}
+
+// Sensor holds a sensor of the ENTITY-SENSOR-MIB converted to redfish units
+type Sensor struct {
+	ID           string
+	Name         string
+	ReadingType  string
+	ReadingUnits string
+	Reading      *float64
+	State        string
+	Health       string
+}
+
+// IsSNMPv3 tells whether the credentials are of an SNMPv3 user
+func (c Credentials) IsSNMPv3() bool {
+	return c.AuthenticationProtocol != "" && c.AuthenticationProtocol != AuthenticationCommunityString
+}
+
+// Validate checks the protocols and the keys of the credentials.
+// It returns the name of the invalid property along with the error.
+func (c Credentials) Validate() (string, error) {
+	if !c.IsSNMPv3() {
+		if c.Password == "" {
+			return "Password", fmt.Errorf("community string is required for %s authentication", AuthenticationCommunityString)
+		}
+		if c.EncryptionProtocol != "" && c.EncryptionProtocol != ProtocolNone {
+			return "EncryptionProtocol", fmt.Errorf("encryption is not supported with %s authentication", AuthenticationCommunityString)
+		}
+		return "", nil
+	}
+	authProtocol, ok := authenticationProtocols[c.AuthenticationProtocol]
+	if !ok {
+		return "AuthenticationProtocol", fmt.Errorf("authentication protocol %s is not supported", c.AuthenticationProtocol)
+	}
+	if c.UserName == "" {
+		return "UserName", fmt.Errorf("user name is required for SNMPv3 authentication")
+	}
+	if authProtocol != gosnmp.NoAuth && len(c.AuthenticationKey) < minKeyLength {
+		return "AuthenticationKey", fmt.Errorf("authentication key must be at least %d characters long", minKeyLength)
+	}
+	privProtocol, ok := encryptionProtocols[c.encryptionProtocol()]
+	if !ok {
+		return "EncryptionProtocol", fmt.Errorf("encryption protocol %s is not supported", c.EncryptionProtocol)
+	}
+	if privProtocol != gosnmp.NoPriv {
+		if authProtocol == gosnmp.NoAuth {
+			return "EncryptionProtocol", fmt.Errorf("encryption requires an authentication protocol")
+		}
+		if len(c.EncryptionKey) < minKeyLength {
+			return "EncryptionKey", fmt.Errorf("encryption key must be at least %d characters long", minKeyLength)
+		}
+	}
+	return "", nil
+}
+
+func (c Credentials) encryptionProtocol() string {
+	if c.EncryptionProtocol == "" {
+		return ProtocolNone
+	}
+	return c.EncryptionProtocol
+}
+
+// newClient returns the SNMP client for the agent at the address,
+// the address may carry a port, otherwise the standard SNMP port is used
+func newClient(address string, c Credentials) (*gosnmp.GoSNMP, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		host, port = address, strconv.Itoa(defaultPort)
+	}
+	portNumber, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %s: %v", port, err)
+	}
+	client := &gosnmp.GoSNMP{
+		Target:    host,
+		Port:      uint16(portNumber),
+		Transport: "udp",
+		Timeout:   time.Duration(config.Data.SNMPConf.ResponseTimeoutInSecs) * time.Second,
+		Retries:   config.Data.SNMPConf.MaxRetryAttempt,
+		MaxOids:   gosnmp.MaxOids,
+	}
+	if !c.IsSNMPv3() {
+		client.Version = gosnmp.Version2c
+		client.Community = c.Password
+		return client, nil
+	}
+	setUserSecurityModel(client, c)
+	return client, nil
+}
+
+// setUserSecurityModel sets the SNMPv3 user and its security level on the client
+func setUserSecurityModel(client *gosnmp.GoSNMP, c Credentials) {
+	authProtocol := authenticationProtocols[c.AuthenticationProtocol]
+	privProtocol := encryptionProtocols[c.encryptionProtocol()]
+	client.Version = gosnmp.Version3
+	client.SecurityModel = gosnmp.UserSecurityModel
+	switch {
+	case privProtocol != gosnmp.NoPriv:
+		client.MsgFlags = gosnmp.AuthPriv
+	case authProtocol != gosnmp.NoAuth:
+		client.MsgFlags = gosnmp.AuthNoPriv
+	default:
+		client.MsgFlags = gosnmp.NoAuthNoPriv
+	}
+	client.SecurityParameters = &gosnmp.UsmSecurityParameters{
+		UserName:                 c.UserName,
+		AuthenticationProtocol:   authProtocol,
+		AuthenticationPassphrase: c.AuthenticationKey,
+		PrivacyProtocol:          privProtocol,
+		PrivacyPassphrase:        c.EncryptionKey,
+	}
+}
+
+// Poll connects to the SNMP agent at the address and collects
+// the system details and the sensor readings of the agent
+func Poll(address string, c Credentials) (*DeviceInfo, error) {
+	client, err := newClient(address, c)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Connect(); err != nil {
+		return nil, fmt.Errorf("unable to connect to SNMP agent %s: %v", address, err)
+	}
+	defer client.Conn.Close()
+
+	result, err := client.Get([]string{sysDescrOID, sysObjectIDOID, sysContactOID, sysNameOID, sysLocationOID})
+	if err != nil {
+		return nil, fmt.Errorf("unable to reach SNMP agent %s: %v", address, err)
+	}
+	if result.Error != gosnmp.NoError {
+		return nil, fmt.Errorf("SNMP agent %s returned %v for the system details", address, result.Error)
+	}
+	var info DeviceInfo
+	for _, variable := range result.Variables {
+		switch variable.Name {
+		case sysDescrOID:
+			info.Description = toString(variable)
+		case sysObjectIDOID:
+			info.ObjectID = toString(variable)
+		case sysContactOID:
+			info.Contact = toString(variable)
+		case sysNameOID:
+			info.Name = toString(variable)
+		case sysLocationOID:
+			info.Location = toString(variable)
+		}
+	}
+	if info.Sensors, err = collectSensors(client); err != nil {
+		return nil, fmt.Errorf("unable to collect the sensors of SNMP agent %s: %v", address, err)
+	}
+	return &info, nil
+}
+
+// collectSensors walks the entPhySensorTable and converts
+// the supported sensors to redfish reading types and units
+func collectSensors(client *gosnmp.GoSNMP) ([]Sensor, error) {
+	sensorEntries, err := client.BulkWalkAll(entPhySensorEntryOID)
+	if err != nil {
+		return nil, err
+	}
+	// sensor columns are indexed by the entPhysicalIndex of the sensor
+	var indexes []string
+	columns := make(map[string]map[string]int64)
+	for _, variable := range sensorEntries {
+		column, index, ok := splitColumnOID(variable.Name, entPhySensorEntryOID)
+		if !ok {
+			continue
+		}
+		if _, exist := columns[index]; !exist {
+			columns[index] = make(map[string]int64)
+			indexes = append(indexes, index)
+		}
+		if value := gosnmp.ToBigInt(variable.Value); value != nil {
+			columns[index][column] = value.Int64()
+		}
+	}
+	if len(indexes) == 0 {
+		return []Sensor{}, nil
+	}
+
+	// ENTITY-MIB is optional for the agent, sensors are named after their index when it is absent
+	names := make(map[string]string)
+	if nameEntries, err := client.BulkWalkAll(entPhysicalNameOID); err == nil {
+		for _, variable := range nameEntries {
+			index := strings.TrimPrefix(variable.Name, entPhysicalNameOID+".")
+			names[index] = toString(variable)
+		}
+	}
+
+	sensors := make([]Sensor, 0, len(indexes))
+	for _, index := range indexes {
+		column := columns[index]
+		sensorType, ok := sensorTypes[column[entPhySensorType]]
+		if !ok {
+			continue
+		}
+		sensor := Sensor{
+			ID:           index,
+			Name:         names[index],
+			ReadingType:  sensorType[0],
+			ReadingUnits: sensorType[1],
+		}
+		if sensor.Name == "" {
+			sensor.Name = "Sensor " + index
+		}
+		switch column[entPhySensorOperStatus] {
+		case 1: // ok
+			reading := scaleReading(column[entPhySensorValue], column[entPhySensorScale], column[entPhySensorPrecision])
+			sensor.Reading = &reading
+			sensor.State, sensor.Health = "Enabled", "OK"
+		case 3: // nonoperational
+			sensor.State, sensor.Health = "Enabled", "Critical"
+		default: // unavailable
+			sensor.State = "UnavailableOffline"
+		}
+		sensors = append(sensors, sensor)
+	}
+	return sensors, nil
+}
+
+// scaleReading converts the sensor value to its base unit using
+// the EntitySensorDataScale and EntitySensorPrecision of the sensor
+func scaleReading(value, scale, precision int64) float64 {
+	// scale units(9) is 10^0 and each step changes the exponent by 3,
+	// the value is considered to be in units when the agent doesn't report the scale
+	exponent := -int(precision)
+	if scale != 0 {
+		exponent += int(scale-9) * 3
+	}
+	return float64(value) * math.Pow10(exponent)
+}
+
+// splitColumnOID splits the OID of a table cell into its column and index
+func splitColumnOID(oid, entryOID string) (string, string, bool) {
+	parts := strings.SplitN(strings.TrimPrefix(oid, entryOID+"."), ".", 2)
+	if len(parts) != 2 || !strings.HasPrefix(oid, entryOID+".") {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func toString(variable gosnmp.SnmpPDU) string {
+	switch value := variable.Value.(type) {
+	case []byte:
+		return string(value)
+	case string:
+		return value
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,181 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package agsnmp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agsnmp/snmpsim"
+	"github.com/gosnmp/gosnmp"
+)
+
+func startMockAgent(t *testing.T) *snmpsim.Agent {
+	agent := snmpsim.NewAgent("public", []gosnmp.SnmpPDU{
+		{Name: sysDescrOID, Type: gosnmp.OctetString, Value: []byte("Rack PDU")},
+		{Name: sysObjectIDOID, Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.318.1.3.4.5"},
+		{Name: sysContactOID, Type: gosnmp.OctetString, Value: []byte("admin")},
+		{Name: sysNameOID, Type: gosnmp.OctetString, Value: []byte("pdu-1")},
+		{Name: sysLocationOID, Type: gosnmp.OctetString, Value: []byte("rack-1")},
+		// temperature sensor reporting 23.5 Cel
+		{Name: entPhySensorEntryOID + ".1.10", Type: gosnmp.Integer, Value: 8},
+		{Name: entPhySensorEntryOID + ".2.10", Type: gosnmp.Integer, Value: 9},
+		{Name: entPhySensorEntryOID + ".3.10", Type: gosnmp.Integer, Value: 1},
+		{Name: entPhySensorEntryOID + ".4.10", Type: gosnmp.Integer, Value: 235},
+		{Name: entPhySensorEntryOID + ".5.10", Type: gosnmp.Integer, Value: 1},
+		// power sensor reporting 2 kW
+		{Name: entPhySensorEntryOID + ".1.11", Type: gosnmp.Integer, Value: 6},
+		{Name: entPhySensorEntryOID + ".2.11", Type: gosnmp.Integer, Value: 10},
+		{Name: entPhySensorEntryOID + ".3.11", Type: gosnmp.Integer, Value: 0},
+		{Name: entPhySensorEntryOID + ".4.11", Type: gosnmp.Integer, Value: 2},
+		{Name: entPhySensorEntryOID + ".5.11", Type: gosnmp.Integer, Value: 3},
+		// unsupported truthvalue sensor
+		{Name: entPhySensorEntryOID + ".1.12", Type: gosnmp.Integer, Value: 12},
+		{Name: entPhySensorEntryOID + ".5.12", Type: gosnmp.Integer, Value: 1},
+		{Name: entPhysicalNameOID + ".10", Type: gosnmp.OctetString, Value: []byte("Inlet Temperature")},
+	})
+	if err := agent.Start("127.0.0.1:0"); err != nil {
+		t.Fatalf("error while starting the SNMP agent: %v", err)
+	}
+	return agent
+}
+
+func TestPoll(t *testing.T) {
+	config.SetUpMockConfig(t)
+	agent := startMockAgent(t)
+	defer agent.Close()
+
+	info, err := Poll(agent.Addr(), Credentials{AuthenticationProtocol: AuthenticationCommunityString, Password: "public"})
+	if err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	temperature, power := 23.5, 2000.0
+	want := &DeviceInfo{
+		Description: "Rack PDU",
+		ObjectID:    ".1.3.6.1.4.1.318.1.3.4.5",
+		Name:        "pdu-1",
+		Contact:     "admin",
+		Location:    "rack-1",
+		Sensors: []Sensor{
+			{ID: "10", Name: "Inlet Temperature", ReadingType: "Temperature", ReadingUnits: "Cel", Reading: &temperature, State: "Enabled", Health: "OK"},
+			{ID: "11", Name: "Sensor 11", ReadingType: "Power", ReadingUnits: "W", State: "Enabled", Health: "Critical"},
+		},
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("Poll() = %+v, want %+v", info, want)
+	}
+
+	agent.Set(gosnmp.SnmpPDU{Name: entPhySensorEntryOID + ".5.11", Type: gosnmp.Integer, Value: 1})
+	info, err = Poll(agent.Addr(), Credentials{Password: "public"})
+	if err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	if info.Sensors[1].Reading == nil || *info.Sensors[1].Reading != power {
+		t.Errorf("Poll() power reading = %v, want %v", info.Sensors[1].Reading, power)
+	}
+
+	if _, err := Poll(agent.Addr(), Credentials{Password: "private"}); err == nil {
+		t.Errorf("Poll() with invalid community string should fail")
+	}
+}
+
+func TestCredentialsValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		credentials  Credentials
+		wantProperty string
+	}{
+		{
+			name:        "community string",
+			credentials: Credentials{Password: "public"},
+		},
+		{
+			name:         "community string missing",
+			credentials:  Credentials{AuthenticationProtocol: AuthenticationCommunityString},
+			wantProperty: "Password",
+		},
+		{
+			name:         "encryption without SNMPv3",
+			credentials:  Credentials{Password: "public", EncryptionProtocol: "CFB128_AES128"},
+			wantProperty: "EncryptionProtocol",
+		},
+		{
+			name:        "SNMPv3 with authentication and encryption",
+			credentials: Credentials{UserName: "admin", AuthenticationProtocol: "HMAC_SHA96", AuthenticationKey: "authpass1", EncryptionProtocol: "CFB128_AES128", EncryptionKey: "privpass1"},
+		},
+		{
+			name:        "SNMPv3 without authentication",
+			credentials: Credentials{UserName: "admin", AuthenticationProtocol: ProtocolNone},
+		},
+		{
+			name:         "unsupported authentication protocol",
+			credentials:  Credentials{UserName: "admin", AuthenticationProtocol: "HMAC_SHA1"},
+			wantProperty: "AuthenticationProtocol",
+		},
+		{
+			name:         "SNMPv3 user name missing",
+			credentials:  Credentials{AuthenticationProtocol: "HMAC_MD5", AuthenticationKey: "authpass1"},
+			wantProperty: "UserName",
+		},
+		{
+			name:         "short authentication key",
+			credentials:  Credentials{UserName: "admin", AuthenticationProtocol: "HMAC_MD5", AuthenticationKey: "short"},
+			wantProperty: "AuthenticationKey",
+		},
+		{
+			name:         "unsupported encryption protocol",
+			credentials:  Credentials{UserName: "admin", AuthenticationProtocol: "HMAC_MD5", AuthenticationKey: "authpass1", EncryptionProtocol: "CFB128_AES256"},
+			wantProperty: "EncryptionProtocol",
+		},
+		{
+			name:         "encryption without authentication",
+			credentials:  Credentials{UserName: "admin", AuthenticationProtocol: ProtocolNone, EncryptionProtocol: "CBC_DES", EncryptionKey: "privpass1"},
+			wantProperty: "EncryptionProtocol",
+		},
+		{
+			name:         "short encryption key",
+			credentials:  Credentials{UserName: "admin", AuthenticationProtocol: "HMAC_MD5", AuthenticationKey: "authpass1", EncryptionProtocol: "CBC_DES"},
+			wantProperty: "EncryptionKey",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			property, err := tt.credentials.Validate()
+			if property != tt.wantProperty || (err != nil) != (tt.wantProperty != "") {
+				t.Errorf("Validate() = %v, %v, want %v", property, err, tt.wantProperty)
+			}
+		})
+	}
+}
+
+func TestNewClientSNMPv3(t *testing.T) {
+	config.SetUpMockConfig(t)
+	client, err := newClient("10.0.0.1:1161", Credentials{UserName: "admin", AuthenticationProtocol: "HMAC192_SHA256", AuthenticationKey: "authpass1", EncryptionProtocol: "CFB128_AES128", EncryptionKey: "privpass1"})
+	if err != nil {
+		t.Fatalf("newClient() error = %v", err)
+	}
+	if client.Target != "10.0.0.1" || client.Port != 1161 || client.Version != gosnmp.Version3 || client.MsgFlags != gosnmp.AuthPriv {
+		t.Errorf("newClient() returned unexpected client %+v", client)
+	}
+	params := client.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+	if params.AuthenticationProtocol != gosnmp.SHA256 || params.PrivacyProtocol != gosnmp.AES {
+		t.Errorf("newClient() returned unexpected security parameters %+v", params)
+	}
+	client, err = newClient("10.0.0.1", Credentials{Password: "public"})
+	if err != nil || client.Port != defaultPort || client.Version != gosnmp.Version2c || client.Community != "public" {
+		t.Errorf("newClient() = %+v, %v", client, err)
+	}
+}
@@ -0,0 +1,263 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package snmpsim
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+const defaultMaxRepetitions = 10
+
+// engineID is the SNMP engine ID of the simulator, in the RFC 3411 text format
+const engineID = "\x80\x00\x00\x00\x04snmpsim"
+
+// Agent is a minimal SNMPv1/v2c agent which serves a static set of objects.
+// It is used to exercise the SNMP aggregation sources without a real device.
+type Agent struct {
+	// Community is the community string accepted by the agent
+	Community string
+
+	lock    sync.RWMutex
+	objects map[string]gosnmp.SnmpPDU
+	conn    *net.UDPConn
+	done    chan struct{}
+}
+
+// NewAgent returns an agent serving the given objects for the community
+func NewAgent(community string, objects []gosnmp.SnmpPDU) *Agent {
+	a := &Agent{
+		Community: community,
+		objects:   make(map[string]gosnmp.SnmpPDU),
+	}
+	for _, object := range objects {
+		a.Set(object)
+	}
+	return a
+}
+
+// Set adds or replaces an object served by the agent
+func (a *Agent) Set(object gosnmp.SnmpPDU) {
+	object.Name = normalizeOID(object.Name)
+	a.lock.Lock()
+	a.objects[object.Name] = object
+	a.lock.Unlock()
+}
+
+// Start starts serving the SNMP requests on the given UDP address
+func (a *Agent) Start(address string) error {
+	udpAddr, err := net.ResolveUDPAddr("udp", address)
+	if err != nil {
+		return err
+	}
+	a.conn, err = net.ListenUDP("udp", udpAddr)
+	if err != nil {
+		return err
+	}
+	a.done = make(chan struct{})
+	go a.serve()
+	return nil
+}
+
+// Addr returns the address on which the agent is serving
+func (a *Agent) Addr() string {
+	return a.conn.LocalAddr().String()
+}
+
+// Close stops the agent
+func (a *Agent) Close() {
+	a.conn.Close()
+	<-a.done
+}
+
+func (a *Agent) serve() {
+	defer close(a.done)
+	decoder := &gosnmp.GoSNMP{Version: gosnmp.Version2c}
+	buf := make([]byte, 65535)
+	for {
+		n, remote, err := a.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		request, err := decoder.SnmpDecodePacket(buf[:n])
+		if err != nil || request.Community != a.Community {
+			// requests which can't be decoded or authenticated are dropped as a real agent does
+			continue
+		}
+		response, err := a.respond(request).MarshalMsg()
+		if err != nil {
+			continue
+		}
+		a.conn.WriteToUDP(response, remote)
+	}
+}
+
+func (a *Agent) respond(request *gosnmp.SnmpPacket) *gosnmp.SnmpPacket {
+	response := &gosnmp.SnmpPacket{
+		Version:   request.Version,
+		Community: request.Community,
+		PDUType:   gosnmp.GetResponse,
+		RequestID: request.RequestID,
+	}
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	switch request.PDUType {
+	case gosnmp.GetRequest:
+		for i, variable := range request.Variables {
+			object := a.get(variable.Name)
+			if object.Type == gosnmp.NoSuchObject && request.Version == gosnmp.Version1 {
+				response.Error = gosnmp.NoSuchName
+				response.ErrorIndex = uint8(i + 1)
+			}
+			response.Variables = append(response.Variables, object)
+		}
+	case gosnmp.GetNextRequest:
+		for _, variable := range request.Variables {
+			response.Variables = append(response.Variables, a.next(variable.Name))
+		}
+	case gosnmp.GetBulkRequest:
+		maxRepetitions := request.MaxRepetitions
+		if maxRepetitions == 0 {
+			// the decoder doesn't report the max-repetitions of the request
+			maxRepetitions = defaultMaxRepetitions
+		}
+		for i, variable := range request.Variables {
+			if i < int(request.NonRepeaters) {
+				response.Variables = append(response.Variables, a.next(variable.Name))
+				continue
+			}
+			name := variable.Name
+			for j := uint32(0); j < maxRepetitions; j++ {
+				object := a.next(name)
+				response.Variables = append(response.Variables, object)
+				if object.Type == gosnmp.EndOfMibView {
+					break
+				}
+				name = object.Name
+			}
+		}
+	default:
+		response.Error = gosnmp.GenErr
+	}
+	return response
+}
+
+func (a *Agent) get(name string) gosnmp.SnmpPDU {
+	name = normalizeOID(name)
+	if object, ok := a.objects[name]; ok {
+		return object
+	}
+	return gosnmp.SnmpPDU{Name: name, Type: gosnmp.NoSuchObject}
+}
+
+func (a *Agent) next(name string) gosnmp.SnmpPDU {
+	name = normalizeOID(name)
+	names := make([]string, 0, len(a.objects))
+	for oid := range a.objects {
+		names = append(names, oid)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return compareOID(names[i], names[j]) < 0
+	})
+	for _, oid := range names {
+		if compareOID(oid, name) > 0 {
+			return a.objects[oid]
+		}
+	}
+	return gosnmp.SnmpPDU{Name: name, Type: gosnmp.EndOfMibView}
+}
+
+// SendTrap sends an SNMPv2c trap with the given trap OID and variables to the receiver address
+func SendTrap(address, community, trapOID string, variables []gosnmp.SnmpPDU) error {
+	client, err := newTrapClient(address)
+	if err != nil {
+		return err
+	}
+	client.Version = gosnmp.Version2c
+	client.Community = community
+	return sendTrap(client, trapOID, variables)
+}
+
+// SendTrapV3 sends an SNMPv3 trap of the user with the given security level
+// and the trap OID and variables to the receiver address
+func SendTrapV3(address string, msgFlags gosnmp.SnmpV3MsgFlags, user *gosnmp.UsmSecurityParameters, trapOID string, variables []gosnmp.SnmpPDU) error {
+	client, err := newTrapClient(address)
+	if err != nil {
+		return err
+	}
+	client.Version = gosnmp.Version3
+	client.SecurityModel = gosnmp.UserSecurityModel
+	client.MsgFlags = msgFlags
+	// the sender of a trap is the authoritative SNMP engine
+	user.AuthoritativeEngineID = engineID
+	client.SecurityParameters = user
+	return sendTrap(client, trapOID, variables)
+}
+
+func newTrapClient(address string) (*gosnmp.GoSNMP, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	portNumber, err := strconv.Atoi(port)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %s: %v", port, err)
+	}
+	return &gosnmp.GoSNMP{
+		Target:  host,
+		Port:    uint16(portNumber),
+		Timeout: gosnmp.Default.Timeout,
+		Retries: 0,
+	}, nil
+}
+
+func sendTrap(client *gosnmp.GoSNMP, trapOID string, variables []gosnmp.SnmpPDU) error {
+	if err := client.Connect(); err != nil {
+		return err
+	}
+	defer client.Conn.Close()
+	trap := gosnmp.SnmpTrap{
+		Variables: append([]gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(0)},
+			{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: trapOID},
+		}, variables...),
+	}
+	_, err := client.SendTrap(trap)
+	return err
+}
+
+func normalizeOID(oid string) string {
+	return "." + strings.TrimPrefix(oid, ".")
+}
+
+// compareOID compares the OIDs by their numeric sub-identifiers
+func compareOID(a, b string) int {
+	x := strings.Split(strings.TrimPrefix(a, "."), ".")
+	y := strings.Split(strings.TrimPrefix(b, "."), ".")
+	for i := 0; i < len(x) && i < len(y); i++ {
+		m, _ := strconv.Atoi(x[i])
+		n, _ := strconv.Atoi(y[i])
+		if m != n {
+			return m - n
+		}
+	}
+	return len(x) - len(y)
+}
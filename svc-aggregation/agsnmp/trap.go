@@ -0,0 +1,271 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package agsnmp
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/gosnmp/gosnmp"
+	uuid "github.com/satori/go.uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// TrapMessageID is the MessageId of the events raised for the SNMP traps
+	TrapMessageID = "Odim.1.0.SNMPTrapReceived"
+
+	snmpTrapOID         = ".1.3.6.1.6.3.1.1.4.1.0"
+	sysUpTimeOID        = ".1.3.6.1.2.1.1.3.0"
+	genericTrapOIDBase  = ".1.3.6.1.6.3.1.1.5."
+	enterpriseSpecific  = 6
+	linkDownTrapOID     = genericTrapOIDBase + "3"
+	authFailureTrapOID  = genericTrapOIDBase + "5"
+	trapAgentAddressOID = ".1.3.6.1.6.3.18.1.3.0"
+	// maxTrapSize is the largest UDP payload
+	maxTrapSize = 65535
+)
+
+// TrapReceiver receives the SNMP traps sent by the SNMP aggregation sources
+// and converts them into redfish events. The traps of the SNMPv1/v2c agents are
+// authenticated by their trap community and the traps of the SNMPv3 agents by
+// the user of the agent.
+type TrapReceiver struct {
+	// GetCredentials returns the credentials of the aggregation source with the
+	// given IP address, it returns an error for an unknown agent
+	GetCredentials func(string) (Credentials, error)
+	// PublishEvent publishes the event raised by the agent with the given IP address
+	PublishEvent func(string, common.Event)
+
+	conn      net.PacketConn
+	listening chan bool
+	closed    int32
+}
+
+// TrapVariable is an SNMP variable binding of a trap carried in the event Oem
+type TrapVariable struct {
+	OID   string      `json:"OID"`
+	Type  string      `json:"Type"`
+	Value interface{} `json:"Value"`
+}
+
+// NewTrapReceiver returns the trap receiver which uses the given functions
+// for authenticating the agents and publishing the events
+func NewTrapReceiver(getCredentials func(string) (Credentials, error), publishEvent func(string, common.Event)) *TrapReceiver {
+	return &TrapReceiver{
+		GetCredentials: getCredentials,
+		PublishEvent:   publishEvent,
+		listening:      make(chan bool),
+	}
+}
+
+// Listen receives the traps on the UDP address until the receiver is closed
+func (r *TrapReceiver) Listen(address string) error {
+	conn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return err
+	}
+	r.conn = conn
+	close(r.listening)
+	log.Info("SNMP trap receiver listening on " + address)
+	if atomic.LoadInt32(&r.closed) == 1 {
+		conn.Close()
+		return nil
+	}
+	buf := make([]byte, maxTrapSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if atomic.LoadInt32(&r.closed) == 1 {
+				return nil
+			}
+			conn.Close()
+			return err
+		}
+		udpAddr, ok := addr.(*net.UDPAddr)
+		if !ok {
+			continue
+		}
+		r.handle(buf[:n], udpAddr)
+	}
+}
+
+// Listening returns the channel which is closed when the receiver is ready
+func (r *TrapReceiver) Listening() <-chan bool {
+	return r.listening
+}
+
+// Close stops the receiver
+func (r *TrapReceiver) Close() {
+	if !atomic.CompareAndSwapInt32(&r.closed, 0, 1) {
+		return
+	}
+	select {
+	case <-r.listening:
+		r.conn.Close()
+	default:
+	}
+}
+
+// handle authenticates the trap with the credentials of the agent at the source
+// address of the packet and publishes it. The agent address carried in SNMPv1 traps
+// is not used for identifying the agent, as it is not covered by any authentication.
+func (r *TrapReceiver) handle(msg []byte, addr *net.UDPAddr) {
+	agentIP := addr.IP.String()
+	credentials, err := r.GetCredentials(agentIP)
+	if err != nil {
+		log.Warn("dropping SNMP trap from " + agentIP + ": " + err.Error())
+		return
+	}
+	packet, err := unmarshalTrap(msg, credentials)
+	if err != nil {
+		log.Warn("dropping SNMP trap from " + agentIP + ": " + err.Error())
+		return
+	}
+	r.PublishEvent(agentIP, TrapToEvent(packet, agentIP))
+	if packet.PDUType == gosnmp.InformRequest {
+		r.acknowledgeInform(packet, addr)
+	}
+}
+
+// unmarshalTrap decodes the trap and checks that it is sent by the agent with the credentials
+func unmarshalTrap(msg []byte, c Credentials) (*gosnmp.SnmpPacket, error) {
+	params := &gosnmp.GoSNMP{
+		Version: gosnmp.Version2c,
+	}
+	if c.IsSNMPv3() {
+		setUserSecurityModel(params, c)
+	}
+	packet, err := params.UnmarshalTrap(msg, false)
+	if err != nil {
+		return nil, err
+	}
+	if !c.IsSNMPv3() {
+		switch {
+		case packet.Version == gosnmp.Version3:
+			return nil, fmt.Errorf("SNMPv3 trap from an agent with %s authentication", AuthenticationCommunityString)
+		case c.TrapCommunity == "":
+			return nil, fmt.Errorf("trap community is not configured")
+		case packet.Community != c.TrapCommunity:
+			return nil, fmt.Errorf("trap community mismatch")
+		}
+		return packet, nil
+	}
+	if packet.Version != gosnmp.Version3 {
+		return nil, fmt.Errorf("SNMPv%s trap from an SNMPv3 agent", packet.Version)
+	}
+	if packet.PDUType == gosnmp.InformRequest {
+		return nil, fmt.Errorf("SNMPv3 informs are not supported")
+	}
+	securityParameters, ok := packet.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+	if !ok || securityParameters.UserName != c.UserName {
+		return nil, fmt.Errorf("user mismatch")
+	}
+	// the authentication and the encryption of the trap are checked by the decoding
+	// only when they are in use by the trap, the trap must be of the agent's security level
+	if packet.MsgFlags&gosnmp.AuthPriv < params.MsgFlags&gosnmp.AuthPriv {
+		return nil, fmt.Errorf("security level of the trap is lower than that of the agent")
+	}
+	return packet, nil
+}
+
+// acknowledgeInform sends the response of the inform request to the agent
+func (r *TrapReceiver) acknowledgeInform(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+	packet.PDUType = gosnmp.GetResponse
+	packet.Error = gosnmp.NoError
+	packet.ErrorIndex = 0
+	msg, err := packet.MarshalMsg()
+	if err == nil {
+		_, err = r.conn.WriteTo(msg, addr)
+	}
+	if err != nil {
+		log.Warn("unable to acknowledge SNMP inform from " + addr.IP.String() + ": " + err.Error())
+	}
+}
+
+// TrapToEvent converts the SNMP trap sent by the agent into a redfish event.
+// The origin of condition is the device local URI of the agent's chassis,
+// which the event service translates like the events of the other devices.
+func TrapToEvent(packet *gosnmp.SnmpPacket, agentIP string) common.Event {
+	trapOID := getTrapOID(packet)
+	var variables = make([]TrapVariable, 0, len(packet.Variables))
+	for _, variable := range packet.Variables {
+		if variable.Name == sysUpTimeOID || variable.Name == snmpTrapOID || variable.Name == trapAgentAddressOID {
+			continue
+		}
+		variables = append(variables, TrapVariable{
+			OID:   variable.Name,
+			Type:  variable.Type.String(),
+			Value: variableValue(variable),
+		})
+	}
+	severity := "OK"
+	if trapOID == linkDownTrapOID || trapOID == authFailureTrapOID || !strings.HasPrefix(trapOID, genericTrapOIDBase) {
+		severity = "Warning"
+	}
+	return common.Event{
+		EventType:      "Alert",
+		EventID:        uuid.NewV4().String(),
+		Severity:       severity,
+		EventTimestamp: time.Now().Format(time.RFC3339),
+		Message:        fmt.Sprintf("SNMP trap %s received from %s.", trapOID, agentIP),
+		MessageArgs:    []string{trapOID, agentIP},
+		MessageID:      TrapMessageID,
+		Oem: map[string]interface{}{
+			"Odim": map[string]interface{}{
+				"SNMP": map[string]interface{}{
+					"TrapOID":   trapOID,
+					"Variables": variables,
+				},
+			},
+		},
+		OriginOfCondition: &common.Link{
+			Oid: "/redfish/v1/Chassis/" + ChassisID,
+		},
+	}
+}
+
+// getTrapOID returns the snmpTrapOID of an SNMPv2 trap, the SNMPv1 traps
+// are translated as per RFC 3584 section 3.1
+func getTrapOID(packet *gosnmp.SnmpPacket) string {
+	if packet.Version == gosnmp.Version1 {
+		if packet.GenericTrap == enterpriseSpecific {
+			return strings.TrimSuffix(packet.Enterprise, ".") + ".0." + fmt.Sprint(packet.SpecificTrap)
+		}
+		return genericTrapOIDBase + fmt.Sprint(packet.GenericTrap+1)
+	}
+	for _, variable := range packet.Variables {
+		if variable.Name == snmpTrapOID {
+			return toString(variable)
+		}
+	}
+	return ""
+}
+
+func variableValue(variable gosnmp.SnmpPDU) interface{} {
+	switch value := variable.Value.(type) {
+	case []byte:
+		if variable.Type == gosnmp.OctetString {
+			return string(value)
+		}
+		return fmt.Sprintf("%x", value)
+	default:
+		return value
+	}
+}
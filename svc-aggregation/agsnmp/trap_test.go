@@ -0,0 +1,238 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package agsnmp
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agsnmp/snmpsim"
+	"github.com/gosnmp/gosnmp"
+)
+
+func getFreeUDPAddress(t *testing.T) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error while getting a free port: %v", err)
+	}
+	defer conn.Close()
+	return conn.LocalAddr().String()
+}
+
+// startTrapReceiver starts the receiver of the traps of the agent with the credentials at 127.0.0.1
+func startTrapReceiver(t *testing.T, credentials Credentials) (string, chan common.Event, func()) {
+	events := make(chan common.Event, 1)
+	receiver := NewTrapReceiver(func(ip string) (Credentials, error) {
+		if ip != "127.0.0.1" {
+			return Credentials{}, fmt.Errorf("unknown agent")
+		}
+		return credentials, nil
+	}, func(host string, event common.Event) {
+		if host == "127.0.0.1" {
+			events <- event
+		}
+	})
+	address := getFreeUDPAddress(t)
+	go receiver.Listen(address)
+	<-receiver.Listening()
+	return address, events, receiver.Close
+}
+
+func assertNoEvent(t *testing.T, events chan common.Event) {
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event %+v", event)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestTrapReceiver(t *testing.T) {
+	address, events, closeReceiver := startTrapReceiver(t, Credentials{
+		AuthenticationProtocol: AuthenticationCommunityString,
+		Password:               "public",
+		TrapCommunity:          "traps",
+	})
+	defer closeReceiver()
+
+	// trap with an invalid community is dropped
+	if err := snmpsim.SendTrap(address, "public", ".1.3.6.1.4.1.318.0.5", nil); err != nil {
+		t.Fatalf("error while sending the trap: %v", err)
+	}
+	variables := []gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.4.1.318.2.3.3.0", Type: gosnmp.OctetString, Value: "Outlet 3 turned off"},
+	}
+	if err := snmpsim.SendTrap(address, "traps", ".1.3.6.1.4.1.318.0.5", variables); err != nil {
+		t.Fatalf("error while sending the trap: %v", err)
+	}
+	select {
+	case event := <-events:
+		if event.MessageID != TrapMessageID || event.Severity != "Warning" || event.EventType != "Alert" {
+			t.Errorf("unexpected event %+v", event)
+		}
+		if event.OriginOfCondition.Oid != "/redfish/v1/Chassis/"+ChassisID {
+			t.Errorf("unexpected origin of condition %v", event.OriginOfCondition.Oid)
+		}
+		if len(event.MessageArgs) != 2 || event.MessageArgs[0] != ".1.3.6.1.4.1.318.0.5" || event.MessageArgs[1] != "127.0.0.1" {
+			t.Errorf("unexpected message args %v", event.MessageArgs)
+		}
+		trap := event.Oem.(map[string]interface{})["Odim"].(map[string]interface{})["SNMP"].(map[string]interface{})
+		trapVariables := trap["Variables"].([]TrapVariable)
+		if len(trapVariables) != 1 || trapVariables[0].Value != "Outlet 3 turned off" {
+			t.Errorf("unexpected trap variables %v", trapVariables)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("trap was not converted to an event")
+	}
+	assertNoEvent(t, events)
+}
+
+func TestTrapReceiverWithoutTrapCommunity(t *testing.T) {
+	address, events, closeReceiver := startTrapReceiver(t, Credentials{
+		AuthenticationProtocol: AuthenticationCommunityString,
+		Password:               "public",
+	})
+	defer closeReceiver()
+
+	for _, community := range []string{"", "public"} {
+		if err := snmpsim.SendTrap(address, community, ".1.3.6.1.4.1.318.0.5", nil); err != nil {
+			t.Fatalf("error while sending the trap: %v", err)
+		}
+	}
+	assertNoEvent(t, events)
+}
+
+func TestTrapReceiverSNMPv3(t *testing.T) {
+	address, events, closeReceiver := startTrapReceiver(t, Credentials{
+		UserName:               "odim",
+		AuthenticationProtocol: "HMAC192_SHA256",
+		AuthenticationKey:      "authentication",
+		EncryptionProtocol:     "CFB128_AES128",
+		EncryptionKey:          "encryption",
+	})
+	defer closeReceiver()
+
+	tests := []struct {
+		name      string
+		msgFlags  gosnmp.SnmpV3MsgFlags
+		user      *gosnmp.UsmSecurityParameters
+		wantEvent bool
+	}{
+		{
+			name:     "unknown user",
+			msgFlags: gosnmp.AuthPriv,
+			user: &gosnmp.UsmSecurityParameters{
+				UserName:                 "admin",
+				AuthenticationProtocol:   gosnmp.SHA256,
+				AuthenticationPassphrase: "authentication",
+				PrivacyProtocol:          gosnmp.AES,
+				PrivacyPassphrase:        "encryption",
+			},
+		},
+		{
+			name:     "invalid authentication key",
+			msgFlags: gosnmp.AuthPriv,
+			user: &gosnmp.UsmSecurityParameters{
+				UserName:                 "odim",
+				AuthenticationProtocol:   gosnmp.SHA256,
+				AuthenticationPassphrase: "authentication1",
+				PrivacyProtocol:          gosnmp.AES,
+				PrivacyPassphrase:        "encryption",
+			},
+		},
+		{
+			name:     "lower security level",
+			msgFlags: gosnmp.NoAuthNoPriv,
+			user: &gosnmp.UsmSecurityParameters{
+				UserName: "odim",
+			},
+		},
+		{
+			name:     "trap of the agent",
+			msgFlags: gosnmp.AuthPriv,
+			user: &gosnmp.UsmSecurityParameters{
+				UserName:                 "odim",
+				AuthenticationProtocol:   gosnmp.SHA256,
+				AuthenticationPassphrase: "authentication",
+				PrivacyProtocol:          gosnmp.AES,
+				PrivacyPassphrase:        "encryption",
+			},
+			wantEvent: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := snmpsim.SendTrapV3(address, tt.msgFlags, tt.user, ".1.3.6.1.6.3.1.1.5.3", nil); err != nil {
+				t.Fatalf("error while sending the trap: %v", err)
+			}
+			if !tt.wantEvent {
+				assertNoEvent(t, events)
+				return
+			}
+			select {
+			case event := <-events:
+				if event.MessageArgs[0] != ".1.3.6.1.6.3.1.1.5.3" || event.Severity != "Warning" {
+					t.Errorf("unexpected event %+v", event)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("trap was not converted to an event")
+			}
+		})
+	}
+
+	// SNMPv2c traps of an SNMPv3 agent are dropped
+	if err := snmpsim.SendTrap(address, "public", ".1.3.6.1.6.3.1.1.5.3", nil); err != nil {
+		t.Fatalf("error while sending the trap: %v", err)
+	}
+	assertNoEvent(t, events)
+}
+
+func TestTrapToEventSNMPv1(t *testing.T) {
+	tests := []struct {
+		name         string
+		trap         gosnmp.SnmpTrap
+		wantOID      string
+		wantSeverity string
+	}{
+		{
+			name:         "generic link down trap",
+			trap:         gosnmp.SnmpTrap{GenericTrap: 2},
+			wantOID:      ".1.3.6.1.6.3.1.1.5.3",
+			wantSeverity: "Warning",
+		},
+		{
+			name:         "generic cold start trap",
+			trap:         gosnmp.SnmpTrap{GenericTrap: 0},
+			wantOID:      ".1.3.6.1.6.3.1.1.5.1",
+			wantSeverity: "OK",
+		},
+		{
+			name:         "enterprise specific trap",
+			trap:         gosnmp.SnmpTrap{Enterprise: ".1.3.6.1.4.1.318", GenericTrap: 6, SpecificTrap: 5},
+			wantOID:      ".1.3.6.1.4.1.318.0.5",
+			wantSeverity: "Warning",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := TrapToEvent(&gosnmp.SnmpPacket{Version: gosnmp.Version1, SnmpTrap: tt.trap}, "10.0.0.1")
+			if event.MessageArgs[0] != tt.wantOID || event.Severity != tt.wantSeverity {
+				t.Errorf("TrapToEvent() = %v, %v, want %v, %v", event.MessageArgs[0], event.Severity, tt.wantOID, tt.wantSeverity)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agmessagebus"
@@ -74,17 +75,19 @@ func main() {
 
 	aggregator := rpc.GetAggregator()
 	aggregatorproto.RegisterAggregatorServer(services.ODIMService.Server(), aggregator)
+	taskproto.RegisterTaskCancelServer(services.ODIMService.Server(), new(services.TaskCanceller))
 
 	// Rediscover the Resources by looking in OnDisk DB, populate the resources in InMemory DB
 	//This happens only if the InMemory DB lost it contents due to DB reboot or host VM reboot.
 	p := system.ExternalInterface{
-		ContactClient:   pmbhandle.ContactPlugin,
-		Auth:            services.IsAuthorized,
-		PublishEventMB:  agmessagebus.Publish,
-		GetPluginStatus: agcommon.GetPluginStatus,
-		SubscribeToEMB:  services.SubscribeToEMB,
-		DecryptPassword: common.DecryptWithPrivateKey,
-		UpdateTask:      system.UpdateTaskData,
+		ContactClient:      pmbhandle.ContactPlugin,
+		Auth:               services.IsAuthorized,
+		PublishEventMB:     agmessagebus.Publish,
+		GetPluginStatus:    agcommon.GetPluginStatus,
+		SubscribeToEMB:     services.SubscribeToEMB,
+		DecryptPassword:    common.DecryptWithPrivateKey,
+		UpdateTask:         system.UpdateTaskData,
+		PublishDeviceEvent: agmessagebus.PublishDeviceEvent,
 	}
 	go p.RediscoverResources()
 
@@ -96,6 +99,12 @@ func main() {
 
 	go system.PerformPluginHealthCheck()
 
+	go system.PerformSNMPPolling()
+
+	go p.PerformPowerBudgetRebalancing()
+
+	go system.RunSNMPTrapReceiver(config.Data.SNMPConf.TrapListenerAddress, agmessagebus.PublishDeviceEvent)
+
 	if err := services.ODIMService.Run(); err != nil {
 		log.Fatal("failed to run a service: " + err.Error())
 	}
@@ -83,6 +83,14 @@ func (a *Aggregator) GetAggregationService(ctx context.Context, req *aggregatorp
 				Target:     "/redfish/v1/AggregationService/Actions/AggregationService.SetDefaultBootOrder/",
 				ActionInfo: "/redfish/v1/AggregationService/SetDefaultBootOrderActionInfo",
 			},
+			Oem: agresponse.AggregationServiceOemActions{
+				ValidateAggregationSource: agresponse.Action{
+					Target: "/redfish/v1/AggregationService/Actions/Oem/Odim.ValidateAggregationSource/",
+				},
+				ImportAggregationSources: agresponse.Action{
+					Target: "/redfish/v1/AggregationService/Actions/Oem/Odim.ImportAggregationSources/",
+				},
+			},
 		},
 		Aggregates: agresponse.OdataID{
 			OdataID: "/redfish/v1/AggregationService/Aggregates",
@@ -360,6 +368,114 @@ func (a *Aggregator) AddAggregationSource(ctx context.Context, req *aggregatorpr
 	return resp, nil
 }
 
+// ValidateAggregationSource defines the operations which handles the RPC request response
+// for the ValidateAggregationSource service of aggregation micro service.
+// The functionality checks the connectivity and credentials of the aggregation source
+// without adding it, and returns back the result synchronously.
+// The function also checks for the session time out of the token
+// which is present in the request.
+func (a *Aggregator) ValidateAggregationSource(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	var validateRequest system.AggregationSource
+	if err := json.Unmarshal(req.RequestBody, &validateRequest); err != nil {
+		errMsg := "Unable to parse the validate request: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil), resp)
+		log.Error(errMsg)
+		return resp, nil
+	}
+	invalidParam := validateAggregationSourceRequest(validateRequest)
+	if invalidParam != "" {
+		errMsg := "Mandatory field " + invalidParam + " Missing"
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{invalidParam}, nil), resp)
+		log.Error(errMsg)
+		return resp, nil
+	}
+	if err := validateManagerAddress(validateRequest.HostName); err != nil {
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{validateRequest.HostName, "ManagerAddress"}, nil), resp)
+		log.Error(err.Error())
+		return resp, nil
+	}
+	rpcResponce := a.connector.ValidateAggregationSource(req)
+	generateResponse(rpcResponce, resp)
+	return resp, nil
+}
+
+// ImportAggregationSources defines the operations which handles the RPC request response
+// for the ImportAggregationSources service of aggregation micro service.
+// The functionality creates a task and adds all the aggregation sources of the
+// request asynchronously, with one SubTask for each of them.
+// The function also checks for the session time out of the token
+// which is present in the request.
+func (a *Aggregator) ImportAggregationSources(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	var taskID string
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(req.SessionToken)
+	if err != nil {
+		errMsg := "Unable to get session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		log.Error(errMsg)
+		return resp, nil
+	}
+	var importRequest system.ImportAggregationSourcesRequest
+	if err := json.Unmarshal(req.RequestBody, &importRequest); err != nil {
+		errMsg := "Unable to parse the import request: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil), resp)
+		log.Error(errMsg)
+		return resp, nil
+	}
+	if len(importRequest.AggregationSources) == 0 {
+		errMsg := "Mandatory field AggregationSources Missing"
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"AggregationSources"}, nil), resp)
+		log.Error(errMsg)
+		return resp, nil
+	}
+
+	// Task Service using RPC and get the taskID
+	taskURI, err := a.connector.CreateTask(sessionUserName)
+	if err != nil {
+		errMsg := "Unable to create the task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		log.Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	// spawn the thread here to process the action asynchronously
+	go a.connector.ImportAggregationSources(taskID, sessionUserName, req)
+
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+	return resp, nil
+}
+
 func validateAggregationSourceRequest(req system.AggregationSource) string {
 	param := ""
 	if req.HostName == "" {
@@ -837,6 +953,27 @@ func (a *Aggregator) SetDefaultBootOrderElementsOfAggregate(ctx context.Context,
 	return resp, nil
 }
 
+// SetPowerBudgetOfAggregate defines the operations which handles the RPC request response
+// for the SetPowerBudgetOfAggregate service of aggregation micro service.
+// The functionality retrives the request and return backs the response to
+// RPC according to the protoc file defined in the util-lib package.
+// The function also checks for the session time out of the token
+// which is present in the request.
+func (a *Aggregator) SetPowerBudgetOfAggregate(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	rpcResponce := a.connector.SetPowerBudgetOfAggregate(req)
+	generateResponse(rpcResponce, resp)
+	return resp, nil
+}
+
 // GetAllConnectionMethods defines the operations which handles the RPC request response
 // for the GetAllConnectionMethods service of systems micro service.
 // The functionality retrives the request and return backs the response to
@@ -66,6 +66,7 @@ func GetAggregator() *Aggregator {
 			DeleteMetricRequest:      agmodel.DeleteMetricRequest,
 			GetResource:              agmodel.GetResource,
 			Delete:                   agmodel.Delete,
+			PublishDeviceEvent:       agmessagebus.PublishDeviceEvent,
 		},
 	}
 }
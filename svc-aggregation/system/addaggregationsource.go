@@ -134,33 +134,40 @@ func (e *ExternalInterface) addAggregationSource(taskID, targetURI, reqBody stri
 		log.Error(errMsg)
 		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"connectionmethod id", addResourceRequest.ConnectionMethod.OdataID}, taskInfo)
 	}
-	cmVariants := getConnectionMethodVariants(connectionMethod.ConnectionMethodVariant)
-	var pluginContactRequest getResourceRequest
-	pluginContactRequest.ContactClient = e.ContactClient
-	pluginContactRequest.GetPluginStatus = e.GetPluginStatus
-	pluginContactRequest.TargetURI = targetURI
-	pluginContactRequest.UpdateTask = e.UpdateTask
-	pluginContactRequest.TaskRequest = reqBody
 	var aggregationSourceUUID string
 	var cipherText []byte
+	var snmp *agmodel.SNMP
 
-	// check status will do call on the URI /ODIM/v1/Status to the requested manager address
-	// if its success then add the plugin, else if its not found then add BMC
-	// else return the response
-	statusResp, statusCode, queueList := checkStatus(pluginContactRequest, addResourceRequest, cmVariants, taskInfo)
-	if statusCode == http.StatusOK {
+	// SNMP agents are managed directly by ODIM, there is no plugin to contact
+	if connectionMethod.ConnectionMethodType == SNMPConnectionMethodType {
+		resp, aggregationSourceUUID, cipherText, snmp = e.addSNMPAgent(aggregationSourceRequest, taskInfo)
+	} else {
+		cmVariants := getConnectionMethodVariants(connectionMethod.ConnectionMethodVariant)
+		var pluginContactRequest getResourceRequest
+		pluginContactRequest.ContactClient = e.ContactClient
+		pluginContactRequest.GetPluginStatus = e.GetPluginStatus
+		pluginContactRequest.TargetURI = targetURI
+		pluginContactRequest.UpdateTask = e.UpdateTask
+		pluginContactRequest.TaskRequest = reqBody
+
+		// check status will do call on the URI /ODIM/v1/Status to the requested manager address
+		// if its success then add the plugin, else if its not found then add BMC
+		// else return the response
+		statusResp, statusCode, queueList := checkStatus(pluginContactRequest, addResourceRequest, cmVariants, taskInfo)
+		if statusCode == http.StatusOK {
 
-		// check if AggregationSource has any values, if its there means its managing the bmcs
-		if len(connectionMethod.Links.AggregationSources) > 0 {
-			errMsg := "Cant proceed to add aggregation source, since connection method is already managing other aggregation sources"
-			log.Error(errMsg)
-			return common.GeneralError(http.StatusConflict, response.ResourceInUse, errMsg, nil, taskInfo)
+			// check if AggregationSource has any values, if its there means its managing the bmcs
+			if len(connectionMethod.Links.AggregationSources) > 0 {
+				errMsg := "Cant proceed to add aggregation source, since connection method is already managing other aggregation sources"
+				log.Error(errMsg)
+				return common.GeneralError(http.StatusConflict, response.ResourceInUse, errMsg, nil, taskInfo)
+			}
+			resp, aggregationSourceUUID, cipherText = e.addPluginData(addResourceRequest, taskID, targetURI, pluginContactRequest, queueList, cmVariants)
+		} else if statusCode == http.StatusNotFound {
+			resp, aggregationSourceUUID, cipherText = e.addCompute(taskID, targetURI, cmVariants.PluginID, percentComplete, addResourceRequest, pluginContactRequest)
+		} else {
+			return statusResp
 		}
-		resp, aggregationSourceUUID, cipherText = e.addPluginData(addResourceRequest, taskID, targetURI, pluginContactRequest, queueList, cmVariants)
-	} else if statusCode == http.StatusNotFound {
-		resp, aggregationSourceUUID, cipherText = e.addCompute(taskID, targetURI, cmVariants.PluginID, percentComplete, addResourceRequest, pluginContactRequest)
-	} else {
-		return statusResp
 	}
 	if resp.StatusMessage != "" {
 		return resp
@@ -171,6 +178,7 @@ func (e *ExternalInterface) addAggregationSource(taskID, targetURI, reqBody stri
 		UserName: aggregationSourceRequest.UserName,
 		Password: cipherText,
 		Links:    aggregationSourceRequest.Links,
+		SNMP:     snmp,
 	}
 	var aggregationSourceURI = fmt.Sprintf("%s/%s", targetURI, aggregationSourceUUID)
 	dbErr := agmodel.AddAggregationSource(aggregationSourceData, aggregationSourceURI)
@@ -207,6 +215,7 @@ func (e *ExternalInterface) addAggregationSource(taskID, targetURI, reqBody stri
 		HostName: aggregationSourceRequest.HostName,
 		UserName: aggregationSourceRequest.UserName,
 		Links:    aggregationSourceRequest.Links,
+		SNMP:     getSNMPResponse(snmp),
 	}
 	resp.StatusCode = http.StatusCreated
 	percentComplete = 100
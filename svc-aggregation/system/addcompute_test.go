@@ -356,3 +356,45 @@ func TestExternalInterface_addcompute(t *testing.T) {
 		})
 	}
 }
+
+func TestGetResourceNameOfChassisSubsystems(t *testing.T) {
+	tests := []struct {
+		oid        string
+		memberFlag bool
+		want       string
+	}{
+		{"/redfish/v1/Chassis/1/PowerSubsystem", false, "PowerSubsystem"},
+		{"/redfish/v1/Chassis/System.Embedded.1/PowerSubsystem", false, "PowerSubsystem"},
+		{"/redfish/v1/Chassis/System.Embedded.1/PowerSubsystem/PowerSupplies", true, "PowerSuppliesCollection"},
+		{"/redfish/v1/Chassis/System.Embedded.1/PowerSubsystem/PowerSupplies/PSU.Slot.1", false, "PowerSupplies"},
+		{"/redfish/v1/Chassis/1/ThermalSubsystem", false, "ThermalSubsystem"},
+		{"/redfish/v1/Chassis/1/ThermalSubsystem/Fans", true, "FansCollection"},
+		{"/redfish/v1/Chassis/1/ThermalSubsystem/Fans/Fan1", false, "Fans"},
+		{"/redfish/v1/Chassis/System.Embedded.1/EnvironmentMetrics", false, "EnvironmentMetrics"},
+	}
+	for _, tt := range tests {
+		if got := getResourceName(tt.oid, tt.memberFlag); got != tt.want {
+			t.Errorf("getResourceName(%v) = %v, want %v", tt.oid, got, tt.want)
+		}
+	}
+}
+
+func TestCheckRetrievalOfChassisSubsystems(t *testing.T) {
+	config.SetUpMockConfig(t)
+	tests := []struct {
+		oid       string
+		parentoid string
+		want      bool
+	}{
+		{"/redfish/v1/Chassis/1/PowerSubsystem/PowerSupplies", "/redfish/v1/Chassis/1/PowerSubsystem", true},
+		{"/redfish/v1/Chassis/1/ThermalSubsystem/Fans", "/redfish/v1/Chassis/1/ThermalSubsystem", true},
+		{"/redfish/v1/Chassis/1/Power/FastPowerMeter", "/redfish/v1/Chassis/1/Power", false},
+		{"/redfish/v1/Chassis/1", "/redfish/v1/Chassis/1/Power#/PowerControl/0", false},
+		{"/redfish/v1/Chassis/1/Thermal#/Fans/0", "/redfish/v1/Chassis/1/Thermal", false},
+	}
+	for _, tt := range tests {
+		if got := checkRetrieval(tt.oid, tt.parentoid, map[string]bool{}); got != tt.want {
+			t.Errorf("checkRetrieval(%v, %v) = %v, want %v", tt.oid, tt.parentoid, got, tt.want)
+		}
+	}
+}
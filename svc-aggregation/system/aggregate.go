@@ -223,8 +223,22 @@ func (e *ExternalInterface) GetAggregate(req *aggregatorproto.AggregatorRequest)
 			AggregateRemoveElements: agresponse.Action{
 				Target: "/redfish/v1/AggregationService/Aggregates/" + ID + "/Actions/Aggregate.RemoveElements",
 			},
+			Oem: agresponse.AggregateOemActions{
+				SetPowerBudget: agresponse.Action{
+					Target: "/redfish/v1/AggregationService/Aggregates/" + ID + "/Actions/Oem/Odim.SetPowerBudget",
+				},
+			},
 		},
 	}
+	if budget, err := agmodel.GetPowerBudget(req.URL); err == nil {
+		body := resp.Body.(agresponse.AggregateGetResponse)
+		body.Oem = &agresponse.AggregateOem{
+			Odim: agresponse.AggregateOdimOem{
+				PowerBudget: getPowerBudgetResponse(budget),
+			},
+		}
+		resp.Body = body
+	}
 	return resp
 }
 
@@ -254,6 +268,10 @@ func (e *ExternalInterface) DeleteAggregate(req *aggregatorproto.AggregatorReque
 	if err1 != nil {
 		log.Error("Error while delete subscription details ", err.Error())
 	}
+	if budget, err := agmodel.GetPowerBudget(req.URL); err == nil {
+		e.releasePowerBudget(aggregate.Elements, budget)
+		agmodel.DeletePowerBudget(req.URL)
+	}
 	resp.StatusCode = http.StatusNoContent
 	return resp
 }
@@ -695,7 +713,10 @@ func (e *ExternalInterface) resetSystem(taskID, reqBody string, subTaskChan chan
 		return
 	}
 	if getResponse.StatusCode == http.StatusAccepted {
+		// the sub task is cancelled along with the job started on the plugin for it
+		ctx, release := common.WithTaskCancel(context.Background(), subTaskID)
 		getResponse, err = e.monitorPluginTask(subTaskChan, &monitorTaskRequest{
+			ctx:               ctx,
 			subTaskID:         subTaskID,
 			serverURI:         targetURI,
 			updateRequestBody: reqBody,
@@ -706,6 +727,7 @@ func (e *ExternalInterface) resetSystem(taskID, reqBody string, subTaskChan chan
 			pluginRequest:     pluginContactRequest,
 			resp:              resp,
 		})
+		release()
 
 		if err != nil {
 			return
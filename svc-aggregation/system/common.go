@@ -61,6 +61,14 @@ const (
 	EntriesCollection = "EntriesCollection"
 )
 
+// chassisSubsystems are the singleton resources of a chassis, which are saved in
+// the table of their own name whatever the id of the chassis is
+var chassisSubsystems = map[string]bool{
+	"PowerSubsystem":     true,
+	"ThermalSubsystem":   true,
+	"EnvironmentMetrics": true,
+}
+
 // WildCard is used to reduce the size the of list of metric properties
 type WildCard struct {
 	Name   string
@@ -106,6 +114,7 @@ type ExternalInterface struct {
 	DeleteMetricRequest      func(string) *errors.Error
 	GetResource              func(string, string) (string, *errors.Error)
 	Delete                   func(string, string, common.DbType) *errors.Error
+	PublishDeviceEvent       func(string, common.Event)
 }
 
 type responseStatus struct {
@@ -192,6 +201,16 @@ type AggregationSource struct {
 	UserName string `json:"UserName"`
 	Password string `json:"Password"`
 	Links    *Links `json:"Links,omitempty"`
+	SNMP     *SNMP  `json:"SNMP,omitempty"`
+}
+
+// SNMP holds the SNMP settings of an aggregation source added with SNMP connection method
+type SNMP struct {
+	AuthenticationKey      string `json:"AuthenticationKey,omitempty"`
+	AuthenticationProtocol string `json:"AuthenticationProtocol,omitempty"`
+	EncryptionKey          string `json:"EncryptionKey,omitempty"`
+	EncryptionProtocol     string `json:"EncryptionProtocol,omitempty"`
+	TrapCommunity          string `json:"TrapCommunity,omitempty"`
 }
 
 // Links holds information of Oem
@@ -208,6 +227,7 @@ type connectionMethodVariants struct {
 
 // monitorTaskRequest hold values required monitorTask function
 type monitorTaskRequest struct {
+	ctx               context.Context
 	respBody          []byte
 	subTaskID         string
 	serverURI         string
@@ -280,6 +300,9 @@ func UpdateTaskData(taskData common.TaskData) error {
 
 	err := services.UpdateTask(taskData.TaskID, taskData.TaskState, taskData.TaskStatus, taskData.PercentComplete, payLoad, time.Now())
 	if err != nil && (err.Error() == common.Cancelling) {
+		if common.CancelTaskContext(taskData.TaskID) {
+			return err
+		}
 		// We cant do anything here as the task has done it work completely, we cant reverse it.
 		//Unless if we can do opposite/reverse action for delete server which is add server.
 		services.UpdateTask(taskData.TaskID, common.Cancelled, taskData.TaskStatus, taskData.PercentComplete, payLoad, time.Now())
@@ -1051,6 +1074,9 @@ func getResourceName(oDataID string, memberFlag bool) string {
 	if memberFlag {
 		return str[len(str)-1] + "Collection"
 	}
+	if _, ok := chassisSubsystems[str[len(str)-1]]; ok {
+		return str[len(str)-1]
+	}
 	if _, err := strconv.Atoi(str[len(str)-2]); err == nil {
 		return str[len(str)-1]
 	}
@@ -1101,13 +1127,27 @@ func checkRetrieval(oid, parentoid string, traversedLinks map[string]bool) bool
 	//skiping the Retrieval if parent oid contains links in other resource of config
 	// TODO : beyond second level Retrieval need to be taken from config it will be implemented in RUCE-1239
 	for _, resourceName := range config.Data.AddComputeSkipResources.SkipResourceListUnderOthers {
-		if strings.Contains(parentoid, resourceName) {
+		if isResourceInPath(parentoid, resourceName) {
 			return false
 		}
 	}
 	return true
 }
 
+// isResourceInPath checks whether resourceName is one of the segments of the oid,
+// so that skipping the resources under Power will not skip the ones under PowerSubsystem
+func isResourceInPath(oid, resourceName string) bool {
+	segments := strings.FieldsFunc(oid, func(r rune) bool {
+		return r == '/' || r == '#'
+	})
+	for _, segment := range segments {
+		if segment == resourceName {
+			return true
+		}
+	}
+	return false
+}
+
 func removeRetrievalLinks(retrievalLinks map[string]bool, parentoid string, resourceList []string, traversedLinks map[string]bool) {
 	for resoureOID := range retrievalLinks {
 		// check if oid is already traversed
@@ -1733,15 +1773,30 @@ func (e *ExternalInterface) monitorPluginTask(subTaskChannel chan<- int32, monit
 		var updatetask = fillTaskData(monitorTaskData.subTaskID, monitorTaskData.serverURI, monitorTaskData.updateRequestBody, monitorTaskData.resp, task.TaskState, task.TaskStatus, task.PercentComplete, http.MethodPost)
 		err := e.UpdateTask(updatetask)
 		if err != nil && err.Error() == common.Cancelling {
-			var updatetask = fillTaskData(monitorTaskData.subTaskID, monitorTaskData.serverURI, monitorTaskData.updateRequestBody, monitorTaskData.resp, common.Cancelled, common.Critical, 100, http.MethodPost)
+			getResponse, err := e.abortPluginTask(monitorTaskData)
 			subTaskChannel <- http.StatusInternalServerError
-			e.UpdateTask(updatetask)
-			return monitorTaskData.getResponse, err
+			return getResponse, err
+		}
+		select {
+		case <-monitorTaskData.ctx.Done():
+			getResponse, err := e.abortPluginTask(monitorTaskData)
+			subTaskChannel <- http.StatusInternalServerError
+			return getResponse, err
+		case <-time.After(time.Second * 5):
+		}
+		pluginRequest := monitorTaskData.pluginRequest
+		pluginRequest.OID = monitorTaskData.location
+		pluginRequest.HTTPMethodType = http.MethodGet
+		var respBody []byte
+		var getResponse responseStatus
+		if common.CallWithTaskCancel(monitorTaskData.ctx, func() {
+			respBody, _, getResponse, err = contactPlugin(pluginRequest, "error while performing simple update action: ")
+		}) != nil {
+			getResponse, err := e.abortPluginTask(monitorTaskData)
+			subTaskChannel <- http.StatusInternalServerError
+			return getResponse, err
 		}
-		time.Sleep(time.Second * 5)
-		monitorTaskData.pluginRequest.OID = monitorTaskData.location
-		monitorTaskData.pluginRequest.HTTPMethodType = http.MethodGet
-		monitorTaskData.respBody, _, monitorTaskData.getResponse, err = contactPlugin(monitorTaskData.pluginRequest, "error while performing simple update action: ")
+		monitorTaskData.respBody, monitorTaskData.getResponse = respBody, getResponse
 		if err != nil {
 			subTaskChannel <- monitorTaskData.getResponse.StatusCode
 			errMsg := err.Error()
@@ -1755,3 +1810,22 @@ func (e *ExternalInterface) monitorPluginTask(subTaskChannel chan<- int32, monit
 	}
 	return monitorTaskData.getResponse, nil
 }
+
+// abortPluginTask aborts the job being monitored on the plugin for the cancelled sub task
+func (e *ExternalInterface) abortPluginTask(monitorTaskData *monitorTaskRequest) (responseStatus, error) {
+	var getResponse responseStatus
+	err := common.AbortPluginTask(monitorTaskData.subTaskID, monitorTaskData.location, monitorTaskData.taskInfo,
+		func() (int32, error) {
+			pluginRequest := monitorTaskData.pluginRequest
+			pluginRequest.OID = monitorTaskData.location
+			pluginRequest.HTTPMethodType = http.MethodDelete
+			var err error
+			_, _, getResponse, err = contactPlugin(pluginRequest, "error while aborting the task on the plugin: ")
+			return getResponse.StatusCode, err
+		},
+		func() {
+			var task = fillTaskData(monitorTaskData.subTaskID, monitorTaskData.serverURI, monitorTaskData.updateRequestBody, monitorTaskData.resp, common.Cancelled, common.Warning, 100, http.MethodPost)
+			e.UpdateTask(task)
+		})
+	return getResponse, err
+}
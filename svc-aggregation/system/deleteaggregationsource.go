@@ -62,7 +62,9 @@ func (e *ExternalInterface) DeleteAggregationSource(req *aggregatorproto.Aggrega
 	resource := requestData[0]
 	uuid := resource[strings.LastIndexByte(resource, '/')+1:]
 	target, terr := agmodel.GetTarget(uuid)
-	if terr != nil || target == nil {
+	if connectionMethod.ConnectionMethodType == SNMPConnectionMethodType {
+		resp = e.deleteSNMPAgent(uuid, aggregationSource)
+	} else if terr != nil || target == nil {
 		cmVariants := getConnectionMethodVariants(connectionMethod.ConnectionMethodVariant)
 		if len(connectionMethod.Links.AggregationSources) > 1 {
 			errMsg := fmt.Sprintf("Plugin " + cmVariants.PluginID + " can't be removed since it managing devices")
@@ -109,6 +111,7 @@ func (e *ExternalInterface) DeleteAggregationSource(req *aggregatorproto.Aggrega
 			RequestType: "delta",
 			Devices: map[string]agmodel.DeviceData{
 				target.DeviceUUID: agmodel.DeviceData{
+					Address:   target.ManagerAddress,
 					Operation: "del",
 				},
 			},
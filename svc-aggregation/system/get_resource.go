@@ -110,6 +110,7 @@ func (e *ExternalInterface) GetAggregationSource(reqURI string) response.RPC {
 		HostName: aggregationSource.HostName,
 		UserName: aggregationSource.UserName,
 		Links:    aggregationSource.Links,
+		SNMP:     getSNMPResponse(aggregationSource.SNMP),
 	}
 	return resp
 }
@@ -0,0 +1,268 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+// defaultImportWorkers is the number of aggregation sources added in parallel
+// when AggregationSourceImportWorkers is not configured
+const defaultImportWorkers = 5
+
+// ImportAggregationSourcesRequest holds the aggregation sources of a bulk import
+type ImportAggregationSourcesRequest struct {
+	AggregationSources []AggregationSource `json:"AggregationSources"`
+}
+
+// importRow is a single aggregation source of a bulk import, as passed to the import workers
+type importRow struct {
+	index  int
+	source AggregationSource
+}
+
+// importState is shared by the import workers to collect the result of each row
+type importState struct {
+	lock     sync.Mutex
+	wg       sync.WaitGroup
+	results  []agresponse.ImportAggregationSourceResult
+	finished int
+	failed   int
+}
+
+// ImportAggregationSources adds all the aggregation sources of the request through the same path
+// as a single add, with at most AggregationSourceImportWorkers of them being added at a time.
+// Every row is tracked by its own SubTask, the parent task completes with a summary of all the rows
+// and with Warning status when one or more of them failed.
+func (e *ExternalInterface) ImportAggregationSources(taskID, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	targetURI := "/redfish/v1/AggregationService/Actions/Oem/Odim.ImportAggregationSources"
+	var resp response.RPC
+	var percentComplete int32
+	taskInfo := &common.TaskUpdateInfo{TaskID: taskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: string(req.RequestBody)}
+	if err := e.UpdateTask(fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Running, common.OK, percentComplete, http.MethodPost)); err != nil {
+		errMsg := "error while starting the task: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+
+	var importRequest ImportAggregationSourcesRequest
+	if err := json.Unmarshal(req.RequestBody, &importRequest); err != nil {
+		errMsg := "unable to parse the import request: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, taskInfo)
+	}
+	// Validating the request JSON properties for case sensitive
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, importRequest)
+	if err != nil {
+		errMsg := "error while validating request parameters: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	} else if invalidProperties != "" {
+		errMsg := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, taskInfo)
+	}
+	if len(importRequest.AggregationSources) == 0 {
+		errMsg := "error: no aggregation sources found in the import request"
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"AggregationSources"}, taskInfo)
+	}
+
+	workerCount := config.Data.AggregationSourceImportWorkers
+	if workerCount <= 0 {
+		workerCount = defaultImportWorkers
+	}
+	state := &importState{
+		results: make([]agresponse.ImportAggregationSourceResult, len(importRequest.AggregationSources)),
+	}
+	rows := make([]importRow, 0, len(importRequest.AggregationSources))
+	firstRow := make(map[string]int)
+	for index, source := range importRequest.AggregationSources {
+		// a device listed more than once is added only for its first row,
+		// adding it in parallel from several rows would create duplicate systems
+		hostName := strings.ToLower(strings.TrimSpace(source.HostName))
+		if first, exists := firstRow[hostName]; exists && hostName != "" {
+			state.complete(index, agresponse.ImportAggregationSourceResult{
+				HostName:   source.HostName,
+				StatusCode: http.StatusConflict,
+				Message:    fmt.Sprintf("HostName %s is a duplicate of the aggregation source at index %d", source.HostName, first),
+			})
+			continue
+		}
+		firstRow[hostName] = index
+		rows = append(rows, importRow{index: index, source: source})
+	}
+
+	// the task context is cancelled when a cancel request is received for the task,
+	// the rows which are not yet started are then skipped
+	ctx, release := common.WithTaskCancel(context.Background(), taskID)
+	total := len(importRequest.AggregationSources)
+	state.wg.Add(len(rows))
+	jobChannel := make(chan interface{})
+	common.RunReadWorkers(jobChannel, func(data interface{}) bool {
+		defer state.wg.Done()
+		row := data.(importRow)
+		result := e.importAggregationSource(ctx, taskID, sessionUserName, row.source)
+		state.complete(row.index, result)
+		if percent := state.percentComplete(total); percent < 100 && ctx.Err() == nil {
+			e.UpdateTask(fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Running, common.OK, percent, http.MethodPost))
+		}
+		return result.StatusCode == http.StatusCreated
+	}, workerCount)
+	// the rows are fed one at a time, the concurrency is bounded by the read workers
+	for _, row := range rows {
+		jobChannel <- row
+	}
+	close(jobChannel)
+	state.wg.Wait()
+	cancelled := ctx.Err() != nil
+	release()
+
+	taskState, taskStatus := common.Completed, common.OK
+	if cancelled {
+		taskState = common.Cancelled
+	}
+	if state.failed > 0 {
+		taskStatus = common.Warning
+		log.Error(fmt.Sprintf("%d of %d aggregation sources failed to import, for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/%s",
+			state.failed, total, taskID))
+	} else {
+		log.Info("all the aggregation sources are imported successfully, for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/" + taskID)
+	}
+	commonResponse := response.Response{
+		OdataType: common.TaskType,
+		ID:        taskID,
+		Name:      "Import Aggregation Sources",
+	}
+	commonResponse.CreateGenericResponse(response.Success)
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	resp.Body = agresponse.ImportAggregationSourcesResponse{
+		Response: commonResponse,
+		Results:  state.results,
+	}
+	percentComplete = 100
+	e.UpdateTask(fillTaskData(taskID, targetURI, string(req.RequestBody), resp, taskState, taskStatus, percentComplete, http.MethodPost))
+	return resp
+}
+
+// importAggregationSource adds a single row of a bulk import under its own SubTask
+func (e *ExternalInterface) importAggregationSource(ctx context.Context, taskID, sessionUserName string, source AggregationSource) agresponse.ImportAggregationSourceResult {
+	result := agresponse.ImportAggregationSourceResult{
+		HostName: source.HostName,
+	}
+	if ctx.Err() != nil {
+		result.Message = "import of the aggregation source skipped, the task is cancelled"
+		return result
+	}
+	subTaskURI, err := e.CreateChildTask(sessionUserName, taskID)
+	if err != nil {
+		result.StatusCode = http.StatusInternalServerError
+		result.Message = "error while trying to create sub task: " + err.Error()
+		log.Error(result.Message)
+		return result
+	}
+	subTaskURI = strings.TrimSuffix(subTaskURI, "/")
+	subTaskID := subTaskURI[strings.LastIndex(subTaskURI, "/")+1:]
+	// a cancel of the sub task must not end the worker adding it, the add
+	// returns with the error of the task update instead
+	_, release := common.WithTaskCancel(ctx, subTaskID)
+	defer release()
+	result.SubTask = &agmodel.OdataID{OdataID: subTaskURI}
+
+	targetURI := "/redfish/v1/AggregationService/AggregationSources"
+	reqBody, _ := json.Marshal(source)
+	taskInfo := &common.TaskUpdateInfo{TaskID: subTaskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: string(reqBody)}
+
+	var resp response.RPC
+	if invalidParam := validateImportRow(source); invalidParam != "" {
+		errMsg := "error: mandatory field " + invalidParam + " missing"
+		log.Error(errMsg)
+		resp = common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{invalidParam}, taskInfo)
+	} else if err := validateManagerAddress(source.HostName); err != nil {
+		log.Error(err.Error())
+		resp = common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{source.HostName, "HostName"}, taskInfo)
+	} else {
+		e.UpdateTask(fillTaskData(subTaskID, targetURI, string(reqBody), resp, common.Running, common.OK, 0, http.MethodPost))
+		resp = e.addAggregationSource(subTaskID, targetURI, string(reqBody), 0, source, taskInfo)
+	}
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode == http.StatusCreated {
+		result.AggregationSource = &agmodel.OdataID{OdataID: resp.Header["Location"]}
+	} else {
+		result.Message = getErrorMessage(resp)
+	}
+	return result
+}
+
+// validateImportRow returns the name of the first mandatory property missing in a row of a bulk import
+func validateImportRow(source AggregationSource) string {
+	switch {
+	case source.HostName == "":
+		return "HostName"
+	case source.Links == nil || source.Links.ConnectionMethod == nil || source.Links.ConnectionMethod.OdataID == "":
+		return "ConnectionMethod"
+	case source.SNMP == nil && source.UserName == "":
+		return "UserName"
+	case source.SNMP == nil && source.Password == "":
+		return "Password"
+	}
+	return ""
+}
+
+// getErrorMessage returns the reason of the failure carried in an error response
+func getErrorMessage(resp response.RPC) string {
+	errResp, ok := resp.Body.(response.CommonError)
+	if !ok {
+		return http.StatusText(int(resp.StatusCode))
+	}
+	for _, msg := range errResp.Error.MessageExtendedInfo {
+		if msg.Message != "" {
+			return msg.Message
+		}
+	}
+	return errResp.Error.Message
+}
+
+func (s *importState) complete(index int, result agresponse.ImportAggregationSourceResult) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.results[index] = result
+	s.finished++
+	if result.StatusCode != http.StatusCreated {
+		s.failed++
+	}
+}
+
+func (s *importState) percentComplete(total int) int32 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return int32(s.finished * 100 / total)
+}
@@ -0,0 +1,271 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+func TestExternalInterface_ImportAggregationSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	var lock sync.Mutex
+	tasks := make(map[string]common.TaskData)
+	e := &ExternalInterface{
+		CreateChildTask: mockCreateChildTask,
+		UpdateTask: func(task common.TaskData) error {
+			lock.Lock()
+			defer lock.Unlock()
+			tasks[task.TaskID] = task
+			return nil
+		},
+	}
+	connectionMethod := &Links{ConnectionMethod: &ConnectionMethod{OdataID: validateConnectionMethodURI}}
+	body, _ := json.Marshal(ImportAggregationSourcesRequest{
+		AggregationSources: []AggregationSource{
+			{HostName: "", UserName: "admin", Password: "password", Links: connectionMethod},
+			{HostName: "10.0.0.1", UserName: "admin", Password: "password"},
+			{HostName: "10.0.0.2", Password: "password", Links: connectionMethod},
+			{HostName: "unresolvable.invalid", UserName: "admin", Password: "password", Links: connectionMethod},
+			{HostName: " 10.0.0.2", UserName: "admin", Password: "password", Links: connectionMethod},
+		},
+	})
+	resp := e.ImportAggregationSources("importTask", "admin", &aggregatorproto.AggregatorRequest{RequestBody: body})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("ImportAggregationSources() status code = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	results := resp.Body.(agresponse.ImportAggregationSourcesResponse).Results
+	if len(results) != 5 {
+		t.Fatalf("ImportAggregationSources() returned %v results, want 5", len(results))
+	}
+	if results[4].StatusCode != http.StatusConflict || results[4].SubTask != nil || !strings.Contains(results[4].Message, "index 2") {
+		t.Errorf("duplicate row: result = %+v, want a conflict with the row at index 2 without a SubTask", results[4])
+	}
+	wantReasons := []string{"HostName", "ConnectionMethod", "UserName", "failed to resolve ManagerAddress"}
+	for i, result := range results[:4] {
+		if result.StatusCode != http.StatusBadRequest {
+			t.Errorf("row %d: status code = %v, want %v", i, result.StatusCode, http.StatusBadRequest)
+		}
+		if !strings.Contains(result.Message, wantReasons[i]) {
+			t.Errorf("row %d: message = %q, want it to contain %q", i, result.Message, wantReasons[i])
+		}
+		if result.SubTask == nil || result.SubTask.OdataID != "someSubTaskID" {
+			t.Errorf("row %d: SubTask = %v, want someSubTaskID", i, result.SubTask)
+		}
+	}
+	if task := tasks["importTask"]; task.TaskState != common.Completed || task.TaskStatus != common.Warning {
+		t.Errorf("parent task state = %v, status = %v, want %v, %v", task.TaskState, task.TaskStatus, common.Completed, common.Warning)
+	}
+	if task := tasks["someSubTaskID"]; task.TaskState != common.Exception {
+		t.Errorf("sub task state = %v, want %v", task.TaskState, common.Exception)
+	}
+}
+
+func TestExternalInterface_ImportAggregationSourcesSuccess(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	config.Data.AddComputeSkipResources = &config.AddComputeSkipResources{
+		SkipResourceListUnderSystem: []string{"Chassis", "LogServices"},
+	}
+	defer func() {
+		err := common.TruncateDB(common.OnDisk)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		err = common.TruncateDB(common.InMemory)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+	mockPluginData(t, "GRF_v1.0.0")
+	mockPluginData(t, "XAuthPlugin_v1.0.0")
+	mockManagersData("/redfish/v1/Managers/1s7sda8asd-asdas8as0", map[string]interface{}{
+		"Name": "GRF_v1.0.0",
+		"UUID": "1s7sda8asd-asdas8as0",
+	})
+	mockManagersData("/redfish/v1/Managers/1234877451-1234", map[string]interface{}{
+		"Name": "GRF_v1.0.0",
+		"UUID": "1234877451-1234",
+	})
+
+	var lock sync.Mutex
+	var subTasks int
+	tasks := make(map[string]common.TaskData)
+	activeRequests := make(map[string]bool)
+	e := getMockExternalInterface()
+	e.CheckActiveRequest = func(managerAddress string) (bool, *errors.Error) {
+		lock.Lock()
+		defer lock.Unlock()
+		active := activeRequests[managerAddress]
+		activeRequests[managerAddress] = true
+		return active, nil
+	}
+	e.DeleteActiveRequest = func(managerAddress string) *errors.Error {
+		lock.Lock()
+		defer lock.Unlock()
+		delete(activeRequests, managerAddress)
+		return nil
+	}
+	e.CreateChildTask = func(sessionUserName, taskID string) (string, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		subTasks++
+		return fmt.Sprintf("/redfish/v1/TaskService/Tasks/%s/SubTasks/subTask%d", taskID, subTasks), nil
+	}
+	e.UpdateTask = func(task common.TaskData) error {
+		lock.Lock()
+		defer lock.Unlock()
+		tasks[task.TaskID] = task
+		return nil
+	}
+	body, _ := json.Marshal(ImportAggregationSourcesRequest{
+		AggregationSources: []AggregationSource{
+			{
+				HostName: "100.0.0.1",
+				UserName: "admin",
+				Password: "password",
+				Links:    &Links{ConnectionMethod: &ConnectionMethod{OdataID: "/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73"}},
+			},
+			{
+				HostName: "100.0.0.2",
+				UserName: "admin",
+				Password: "password",
+				Links:    &Links{ConnectionMethod: &ConnectionMethod{OdataID: "/redfish/v1/AggregationService/ConnectionMethods/0a8992dc-8b47-4fe3-b26c-4c34048cf0d2"}},
+			},
+		},
+	})
+	resp := e.ImportAggregationSources("importTask", "admin", &aggregatorproto.AggregatorRequest{RequestBody: body})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("ImportAggregationSources() status code = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	for i, result := range resp.Body.(agresponse.ImportAggregationSourcesResponse).Results {
+		if result.StatusCode != http.StatusCreated {
+			t.Errorf("row %d: status code = %v, want %v: %s", i, result.StatusCode, http.StatusCreated, result.Message)
+		}
+		if result.AggregationSource == nil || !strings.HasPrefix(result.AggregationSource.OdataID, "/redfish/v1/AggregationService/AggregationSources/") {
+			t.Errorf("row %d: AggregationSource = %v, want the URI of the added aggregation source", i, result.AggregationSource)
+		}
+	}
+	if task := tasks["importTask"]; task.TaskState != common.Completed || task.TaskStatus != common.OK || task.PercentComplete != 100 {
+		t.Errorf("parent task state = %v, status = %v, percent = %v, want %v, %v, 100", task.TaskState, task.TaskStatus, task.PercentComplete, common.Completed, common.OK)
+	}
+}
+
+func TestExternalInterface_ImportAggregationSourcesCancelled(t *testing.T) {
+	config.SetUpMockConfig(t)
+	var lock sync.Mutex
+	var subTasks int
+	tasks := make(map[string]common.TaskData)
+	e := &ExternalInterface{
+		CreateChildTask: func(sessionUserName, taskID string) (string, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			subTasks++
+			return fmt.Sprintf("subTask%d", subTasks), nil
+		},
+		UpdateTask: func(task common.TaskData) error {
+			lock.Lock()
+			tasks[task.TaskID] = task
+			lock.Unlock()
+			// the task is cancelled once the first row is done, as UpdateTaskData
+			// does for a task which is in Cancelling state
+			if task.TaskID == "importTask" && task.TaskState == common.Running && task.PercentComplete > 0 {
+				if common.CancelTaskContext(task.TaskID) {
+					return fmt.Errorf(common.Cancelling)
+				}
+			}
+			return nil
+		},
+	}
+	connectionMethod := &Links{ConnectionMethod: &ConnectionMethod{OdataID: validateConnectionMethodURI}}
+	var sources []AggregationSource
+	for i := 0; i < 30; i++ {
+		sources = append(sources, AggregationSource{HostName: fmt.Sprintf("10.0.0.%d", i+1), Password: "password", Links: connectionMethod})
+	}
+	body, _ := json.Marshal(ImportAggregationSourcesRequest{AggregationSources: sources})
+
+	done := make(chan response.RPC)
+	go func() {
+		done <- e.ImportAggregationSources("importTask", "admin", &aggregatorproto.AggregatorRequest{RequestBody: body})
+	}()
+	var resp response.RPC
+	select {
+	case resp = <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("ImportAggregationSources() did not return after the task is cancelled")
+	}
+	skipped := 0
+	for _, result := range resp.Body.(agresponse.ImportAggregationSourcesResponse).Results {
+		if result.SubTask == nil && strings.Contains(result.Message, "skipped") {
+			skipped++
+		}
+	}
+	if skipped == 0 {
+		t.Error("ImportAggregationSources() added all the rows, want the rows not started before the cancel to be skipped")
+	}
+	if task := tasks["importTask"]; task.TaskState != common.Cancelled {
+		t.Errorf("parent task state = %v, want %v", task.TaskState, common.Cancelled)
+	}
+}
+
+func TestExternalInterface_ImportAggregationSourcesInvalidRequest(t *testing.T) {
+	config.SetUpMockConfig(t)
+	e := &ExternalInterface{
+		CreateChildTask: mockCreateChildTask,
+		UpdateTask:      mockUpdateTask,
+	}
+	tests := []struct {
+		name string
+		body string
+		want int32
+	}{
+		{
+			name: "malformed request",
+			body: `{"AggregationSources":`,
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "invalid property case",
+			body: `{"aggregationsources":[]}`,
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "no aggregation sources",
+			body: `{"AggregationSources":[]}`,
+			want: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := e.ImportAggregationSources("importTask", "admin", &aggregatorproto.AggregatorRequest{RequestBody: []byte(tt.body)})
+			if resp.StatusCode != tt.want {
+				t.Errorf("ImportAggregationSources() status code = %v, want %v", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
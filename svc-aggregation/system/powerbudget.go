@@ -0,0 +1,568 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// PowerBudgetExceededMessageID is the message id of the event raised when the power
+	// consumed by the elements of an aggregate exceeds the power budget of the aggregate
+	PowerBudgetExceededMessageID = "Odim.1.0.PowerBudgetExceeded"
+	// PowerBudgetRestoredMessageID is the message id of the event raised when the power
+	// consumed by the elements of an aggregate is back within the power budget of the aggregate
+	PowerBudgetRestoredMessageID = "Odim.1.0.PowerBudgetRestored"
+
+	defaultRebalanceIntervalInSeconds = 300
+	minRebalanceIntervalInSeconds     = 30
+	// powerBudgetCheckInterval is the interval in which the power budgets are checked for rebalance
+	powerBudgetCheckInterval = 30 * time.Second
+)
+
+// PowerBudgetRequest is the request body of the Odim.SetPowerBudget action of an aggregate
+type PowerBudgetRequest struct {
+	LimitInWatts               *float64 `json:"LimitInWatts"`
+	RebalanceIntervalInSeconds int      `json:"RebalanceIntervalInSeconds"`
+}
+
+// powerControlConnection holds the details required to reach the power control of an element
+type powerControlConnection struct {
+	pluginRequest getResourceRequest
+	target        *agmodel.Target
+	host          string
+	systemID      string
+	chassisOID    string
+}
+
+// elementPower is the power consumed by an element of the aggregate
+type elementPower struct {
+	element       string
+	connection    *powerControlConnection
+	consumedWatts float64
+	err           error
+}
+
+// SetPowerBudgetOfAggregate is the handler for the Odim.SetPowerBudget action of an aggregate.
+// The budget is saved and divided across the elements of the aggregate right away, it is
+// rebalanced afterwards in the interval requested. A limit of zero removes the budget.
+func (e *ExternalInterface) SetPowerBudgetOfAggregate(req *aggregatorproto.AggregatorRequest) response.RPC {
+	var budgetRequest PowerBudgetRequest
+	if err := json.Unmarshal(req.RequestBody, &budgetRequest); err != nil {
+		errMsg := "error while trying to validate request fields: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
+	}
+
+	// Validating the request JSON properties for case sensitive
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, budgetRequest)
+	if err != nil {
+		errMsg := "error while validating request parameters: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	} else if invalidProperties != "" {
+		errorMessage := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, nil)
+	}
+
+	if budgetRequest.LimitInWatts == nil {
+		errMsg := "error: mandatory field LimitInWatts is missing"
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"LimitInWatts"}, nil)
+	}
+	if *budgetRequest.LimitInWatts < 0 {
+		errMsg := "error: LimitInWatts cannot be negative"
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{fmt.Sprint(*budgetRequest.LimitInWatts), "LimitInWatts"}, nil)
+	}
+	if budgetRequest.RebalanceIntervalInSeconds == 0 {
+		budgetRequest.RebalanceIntervalInSeconds = defaultRebalanceIntervalInSeconds
+	}
+	if budgetRequest.RebalanceIntervalInSeconds < minRebalanceIntervalInSeconds {
+		errMsg := fmt.Sprintf("error: RebalanceIntervalInSeconds must be at least %d", minRebalanceIntervalInSeconds)
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{fmt.Sprint(budgetRequest.RebalanceIntervalInSeconds), "RebalanceIntervalInSeconds"}, nil)
+	}
+
+	aggregateURI := "/redfish/v1/AggregationService/Aggregates/" + getAggregateID(req.URL)
+	aggregate, dbErr := agmodel.GetAggregate(aggregateURI)
+	if dbErr != nil {
+		errMsg := "error getting aggregate: " + dbErr.Error()
+		log.Error(errMsg)
+		if errors.DBKeyNotFound == dbErr.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"Aggregate", aggregateURI}, nil)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+
+	budget, dbErr := agmodel.GetPowerBudget(aggregateURI)
+	if dbErr != nil && errors.DBKeyNotFound != dbErr.ErrNo() {
+		errMsg := "error getting power budget of the aggregate: " + dbErr.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+
+	if *budgetRequest.LimitInWatts == 0 {
+		e.releasePowerBudget(aggregate.Elements, budget)
+		if dbErr == nil {
+			if dbErr = agmodel.DeletePowerBudget(aggregateURI); dbErr != nil {
+				errMsg := "error while deleting power budget of the aggregate: " + dbErr.Error()
+				log.Error(errMsg)
+				return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+			}
+		}
+		return response.RPC{
+			StatusCode:    http.StatusOK,
+			StatusMessage: response.Success,
+			Body: response.ErrorClass{
+				Code:    response.Success,
+				Message: "Request completed successfully.",
+			},
+		}
+	}
+
+	budget.LimitInWatts = *budgetRequest.LimitInWatts
+	budget.RebalanceIntervalInSeconds = budgetRequest.RebalanceIntervalInSeconds
+	if budget, err = e.rebalancePowerBudget(aggregateURI, aggregate.Elements, budget); err != nil {
+		errMsg := "error: LimitInWatts is too low, " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusConflict, response.PropertyValueConflict, errMsg, []interface{}{"LimitInWatts", "AllocatedWatts"}, nil)
+	}
+	if dbErr = agmodel.SavePowerBudget(aggregateURI, budget); dbErr != nil {
+		errMsg := "error while saving power budget of the aggregate: " + dbErr.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          getPowerBudgetResponse(budget),
+	}
+}
+
+// getPowerBudgetResponse converts the stored power budget to the response format
+func getPowerBudgetResponse(budget agmodel.PowerBudget) *agresponse.PowerBudget {
+	resp := &agresponse.PowerBudget{
+		LimitInWatts:               budget.LimitInWatts,
+		RebalanceIntervalInSeconds: budget.RebalanceIntervalInSeconds,
+		ConsumedWatts:              budget.ConsumedWatts,
+		BudgetExceeded:             budget.BudgetExceeded,
+		LastRebalanceTime:          budget.LastRebalanceTime,
+		Allocations:                []agresponse.PowerBudgetAllocation{},
+	}
+	for element, watts := range budget.AllocatedWatts {
+		resp.Allocations = append(resp.Allocations, agresponse.PowerBudgetAllocation{
+			Element:        agmodel.OdataID{OdataID: element},
+			AllocatedWatts: watts,
+		})
+	}
+	return resp
+}
+
+// PerformPowerBudgetRebalancing periodically rebalances the power budgets of the aggregates
+// whose rebalance interval has elapsed
+func (e *ExternalInterface) PerformPowerBudgetRebalancing() {
+	log.Info("aggregate power budget rebalancing routine started")
+	for {
+		if aggregateURIs, err := agmodel.GetAllKeysFromTable(agmodel.PowerBudgetTable); err != nil {
+			log.Error("failed to get list of all power budgets: " + err.Error())
+		} else {
+			for _, aggregateURI := range aggregateURIs {
+				e.rebalanceIfDue(aggregateURI)
+			}
+		}
+		time.Sleep(powerBudgetCheckInterval)
+	}
+}
+
+func (e *ExternalInterface) rebalanceIfDue(aggregateURI string) {
+	budget, dbErr := agmodel.GetPowerBudget(aggregateURI)
+	if dbErr != nil {
+		log.Error("failed to get power budget of aggregate " + aggregateURI + ": " + dbErr.Error())
+		return
+	}
+	if lastRebalance, err := time.Parse(time.RFC3339, budget.LastRebalanceTime); err == nil &&
+		time.Since(lastRebalance) < time.Duration(budget.RebalanceIntervalInSeconds)*time.Second {
+		return
+	}
+	// every instance of the service runs the rebalancing routine, the rebalance of
+	// an interval is done by the one which holds the lease for it
+	claimed, dbErr := agmodel.AcquireLease(agmodel.PowerBudgetRebalanceTable, aggregateURI, budget.RebalanceIntervalInSeconds)
+	if dbErr != nil {
+		log.Error("failed to claim the rebalance of power budget of aggregate " + aggregateURI + ": " + dbErr.Error())
+		return
+	}
+	if !claimed {
+		return
+	}
+	aggregate, dbErr := agmodel.GetAggregate(aggregateURI)
+	if dbErr != nil {
+		log.Error("failed to get aggregate " + aggregateURI + ": " + dbErr.Error())
+		if errors.DBKeyNotFound == dbErr.ErrNo() {
+			agmodel.DeletePowerBudget(aggregateURI)
+		}
+		return
+	}
+	budget, err := e.rebalancePowerBudget(aggregateURI, aggregate.Elements, budget)
+	if err != nil {
+		log.Warn("power limits of aggregate " + aggregateURI + " are left unchanged: " + err.Error())
+		return
+	}
+	if dbErr = agmodel.SavePowerBudget(aggregateURI, budget); dbErr != nil {
+		log.Error("failed to save power budget of aggregate " + aggregateURI + ": " + dbErr.Error())
+	}
+}
+
+// rebalancePowerBudget reads the power consumed by the elements of the aggregate, divides the
+// budget across them and applies the allocations as the power limits of the elements.
+// Limits set earlier on systems which are no longer part of the aggregate are released.
+// Error is returned, without any limit being changed, when the allocations kept for the
+// elements which could not be reached leave no budget for the rest of the elements.
+func (e *ExternalInterface) rebalancePowerBudget(aggregateURI string, elements []agmodel.OdataID, budget agmodel.PowerBudget) (agmodel.PowerBudget, error) {
+	readings := e.readElementsPower(elements)
+
+	var consumedWatts, reservedWatts float64
+	consumption := make(map[string]float64)
+	allocation := make(map[string]float64)
+	for _, reading := range readings {
+		if reading.err != nil {
+			// the element could not be reached, it keeps its last allocation so that
+			// the rest of the elements are not given the share of it
+			log.Error("unable to read power consumption of " + reading.element + ": " + reading.err.Error())
+			watts, exist := budget.AllocatedWatts[reading.element]
+			if !exist {
+				watts = math.Floor(budget.LimitInWatts / float64(len(readings)))
+			}
+			allocation[reading.element] = watts
+			reservedWatts += watts
+			continue
+		}
+		consumedWatts += reading.consumedWatts
+		consumption[reading.element] = reading.consumedWatts
+	}
+	if len(consumption) > 0 && reservedWatts >= budget.LimitInWatts {
+		return budget, fmt.Errorf("the %.0f watts kept for the elements which could not be reached leave no power budget out of %.0f watts for the rest of the elements",
+			reservedWatts, budget.LimitInWatts)
+	}
+	for element, watts := range allocatePowerBudget(budget.LimitInWatts-reservedWatts, consumption) {
+		allocation[element] = watts
+	}
+
+	var wg sync.WaitGroup
+	for _, reading := range readings {
+		if reading.err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(reading elementPower) {
+			defer wg.Done()
+			watts := allocation[reading.element]
+			if err := reading.connection.setPowerLimit(watts); err != nil {
+				log.Error("unable to set power limit of " + reading.element + ": " + err.Error())
+			}
+		}(reading)
+	}
+	wg.Wait()
+
+	var removed []agmodel.OdataID
+	for element := range budget.AllocatedWatts {
+		if _, exist := allocation[element]; !exist {
+			removed = append(removed, agmodel.OdataID{OdataID: element})
+		}
+	}
+	e.releasePowerBudget(removed, agmodel.PowerBudget{})
+
+	budgetExceeded := consumedWatts > budget.LimitInWatts
+	budget.ConsumedWatts = consumedWatts
+	budget.AllocatedWatts = allocation
+	budget.LastRebalanceTime = time.Now().Format(time.RFC3339)
+	if budgetExceeded != budget.BudgetExceeded {
+		budget.BudgetExceeded = budgetExceeded
+		e.publishPowerBudgetEvent(aggregateURI, budget, readings)
+	}
+	return budget, nil
+}
+
+// allocatePowerBudget divides the limit across the elements, half of the limit is shared
+// equally and the other half in proportion to the power consumed by each element
+func allocatePowerBudget(limitInWatts float64, consumption map[string]float64) map[string]float64 {
+	allocation := make(map[string]float64, len(consumption))
+	if len(consumption) == 0 {
+		return allocation
+	}
+	var totalConsumed float64
+	for _, watts := range consumption {
+		totalConsumed += watts
+	}
+	share := limitInWatts / 2 / float64(len(consumption))
+	for element, watts := range consumption {
+		demandShare := share
+		if totalConsumed > 0 {
+			demandShare = limitInWatts / 2 * watts / totalConsumed
+		}
+		allocation[element] = math.Floor(share + demandShare)
+	}
+	return allocation
+}
+
+// releasePowerBudget removes the power limits set on the elements as part of the power budget
+func (e *ExternalInterface) releasePowerBudget(elements []agmodel.OdataID, budget agmodel.PowerBudget) {
+	released := make(map[string]bool)
+	for _, element := range elements {
+		released[element.OdataID] = true
+	}
+	for element := range budget.AllocatedWatts {
+		released[element] = true
+	}
+	var wg sync.WaitGroup
+	for element := range released {
+		wg.Add(1)
+		go func(element string) {
+			defer wg.Done()
+			connection, err := e.getPowerControlConnection(element)
+			if err == nil {
+				err = connection.releasePowerLimit()
+			}
+			if err != nil {
+				log.Error("unable to release power limit of " + element + ": " + err.Error())
+			}
+		}(element)
+	}
+	wg.Wait()
+}
+
+// readElementsPower reads the power consumed by each of the elements concurrently
+func (e *ExternalInterface) readElementsPower(elements []agmodel.OdataID) []elementPower {
+	readings := make([]elementPower, len(elements))
+	var wg sync.WaitGroup
+	for i, element := range elements {
+		wg.Add(1)
+		go func(i int, element string) {
+			defer wg.Done()
+			readings[i].element = element
+			readings[i].connection, readings[i].err = e.getPowerControlConnection(element)
+			if readings[i].err == nil {
+				readings[i].consumedWatts, readings[i].err = readings[i].connection.readConsumedWatts()
+			}
+		}(i, element.OdataID)
+	}
+	wg.Wait()
+	return readings
+}
+
+// getPowerControlConnection finds the chassis of the system and prepares the request
+// to contact the plugin managing it
+func (e *ExternalInterface) getPowerControlConnection(element string) (*powerControlConnection, error) {
+	systemData, dbErr := agmodel.GetComputerSystem(element)
+	if dbErr != nil {
+		return nil, dbErr
+	}
+	var system struct {
+		Links struct {
+			Chassis []agmodel.OdataID `json:"Chassis"`
+		} `json:"Links"`
+	}
+	if err := json.Unmarshal([]byte(systemData), &system); err != nil {
+		return nil, fmt.Errorf("error while trying to unmarshal system data: %v", err)
+	}
+	if len(system.Links.Chassis) == 0 {
+		return nil, fmt.Errorf("no chassis linked to the system")
+	}
+	systemID := strings.SplitN(element[strings.LastIndex(element, "/")+1:], ".", 2)
+	chassisURI := system.Links.Chassis[0].OdataID
+	chassisID := strings.SplitN(chassisURI[strings.LastIndex(chassisURI, "/")+1:], ".", 2)
+	if len(systemID) <= 1 || len(chassisID) <= 1 {
+		return nil, fmt.Errorf("invalid system or chassis URI")
+	}
+
+	target, err := agmodel.GetTarget(systemID[0])
+	if err != nil {
+		return nil, err
+	}
+	decryptedPasswordByte, err := e.DecryptPassword(target.Password)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to decrypt device password: %v", err)
+	}
+	target.Password = decryptedPasswordByte
+	plugin, dbErr := agmodel.GetPluginData(target.PluginID)
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	connection := &powerControlConnection{
+		target:     target,
+		host:       target.ManagerAddress,
+		systemID:   systemID[1],
+		chassisOID: "/ODIM/v1/Chassis/" + chassisID[1],
+	}
+	if ip, _, _, err := agcommon.LookupHost(target.ManagerAddress); err == nil {
+		connection.host = ip
+	}
+	connection.pluginRequest = getResourceRequest{
+		ContactClient:   e.ContactClient,
+		GetPluginStatus: e.GetPluginStatus,
+		Plugin:          plugin,
+		StatusPoll:      true,
+	}
+	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		connection.pluginRequest.HTTPMethodType = http.MethodPost
+		connection.pluginRequest.DeviceInfo = map[string]interface{}{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+		connection.pluginRequest.OID = "/ODIM/v1/Sessions"
+		_, token, _, err := contactPlugin(connection.pluginRequest, "error while logging in to plugin: ")
+		if err != nil {
+			return nil, err
+		}
+		connection.pluginRequest.Token = token
+	} else {
+		connection.pluginRequest.LoginCredentials = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+	}
+	return connection, nil
+}
+
+// readConsumedWatts reads the power consumed from the Power resource of the chassis,
+// EnvironmentMetrics of the chassis is read when the Power resource is not available
+func (c *powerControlConnection) readConsumedWatts() (float64, error) {
+	c.pluginRequest.DeviceInfo = c.target
+	c.pluginRequest.HTTPMethodType = http.MethodGet
+	c.pluginRequest.OID = c.chassisOID + "/Power"
+	body, _, _, err := contactPlugin(c.pluginRequest, "error while reading the power of the chassis: ")
+	if err == nil {
+		var power struct {
+			PowerControl []struct {
+				PowerConsumedWatts *float64 `json:"PowerConsumedWatts"`
+			} `json:"PowerControl"`
+		}
+		if err = json.Unmarshal(body, &power); err == nil {
+			for _, powerControl := range power.PowerControl {
+				if powerControl.PowerConsumedWatts != nil {
+					return *powerControl.PowerConsumedWatts, nil
+				}
+			}
+		}
+	}
+
+	c.pluginRequest.OID = c.chassisOID + "/EnvironmentMetrics"
+	body, _, _, err = contactPlugin(c.pluginRequest, "error while reading the environment metrics of the chassis: ")
+	if err != nil {
+		return 0, err
+	}
+	var metrics struct {
+		PowerWatts *struct {
+			Reading *float64 `json:"Reading"`
+		} `json:"PowerWatts"`
+	}
+	if err = json.Unmarshal(body, &metrics); err != nil {
+		return 0, fmt.Errorf("error while trying to unmarshal environment metrics: %v", err)
+	}
+	if metrics.PowerWatts == nil || metrics.PowerWatts.Reading == nil {
+		return 0, fmt.Errorf("power consumption is not reported by the chassis")
+	}
+	return *metrics.PowerWatts.Reading, nil
+}
+
+// setPowerLimit sets the power limit of the chassis
+func (c *powerControlConnection) setPowerLimit(limitInWatts float64) error {
+	return c.patchPowerLimit(map[string]interface{}{
+		"LimitInWatts":   limitInWatts,
+		"LimitException": "LogEventOnly",
+	})
+}
+
+// releasePowerLimit removes the power limit of the chassis
+func (c *powerControlConnection) releasePowerLimit() error {
+	return c.patchPowerLimit(map[string]interface{}{
+		"LimitInWatts": nil,
+	})
+}
+
+func (c *powerControlConnection) patchPowerLimit(powerLimit map[string]interface{}) error {
+	postBody, _ := json.Marshal(map[string]interface{}{
+		"PowerControl": []interface{}{
+			map[string]interface{}{"PowerLimit": powerLimit},
+		},
+	})
+	target := *c.target
+	target.PostBody = postBody
+	c.pluginRequest.DeviceInfo = &target
+	c.pluginRequest.HTTPMethodType = http.MethodPatch
+	c.pluginRequest.OID = c.chassisOID + "/Power"
+	_, _, getResponse, err := contactPlugin(c.pluginRequest, "error while setting the power limit of the chassis: ")
+	if err != nil && getResponse.StatusCode != http.StatusNoContent {
+		return err
+	}
+	return nil
+}
+
+// publishPowerBudgetEvent publishes the violation or the restoration of the power budget
+// of the aggregate as an event of each of its elements
+func (e *ExternalInterface) publishPowerBudgetEvent(aggregateURI string, budget agmodel.PowerBudget, readings []elementPower) {
+	messageID, severity := PowerBudgetRestoredMessageID, "OK"
+	message := fmt.Sprintf("The power consumed by the aggregate %s is %.0f watts, within its power budget of %.0f watts.", aggregateURI, budget.ConsumedWatts, budget.LimitInWatts)
+	if budget.BudgetExceeded {
+		messageID, severity = PowerBudgetExceededMessageID, "Warning"
+		message = fmt.Sprintf("The power consumed by the aggregate %s is %.0f watts, exceeding its power budget of %.0f watts.", aggregateURI, budget.ConsumedWatts, budget.LimitInWatts)
+	}
+	for _, reading := range readings {
+		if reading.connection == nil {
+			continue
+		}
+		e.PublishDeviceEvent(reading.connection.host, common.Event{
+			EventType:      "Alert",
+			EventID:        uuid.NewV4().String(),
+			Severity:       severity,
+			EventTimestamp: time.Now().Format(time.RFC3339),
+			Message:        message,
+			MessageArgs:    []string{aggregateURI, fmt.Sprintf("%.0f", budget.ConsumedWatts), fmt.Sprintf("%.0f", budget.LimitInWatts)},
+			MessageID:      messageID,
+			Oem: map[string]interface{}{
+				"Odim": map[string]interface{}{
+					"PowerBudget": map[string]interface{}{
+						"Aggregate":      aggregateURI,
+						"LimitInWatts":   budget.LimitInWatts,
+						"ConsumedWatts":  budget.ConsumedWatts,
+						"AllocatedWatts": budget.AllocatedWatts[reading.element],
+					},
+				},
+			},
+			OriginOfCondition: &common.Link{
+				Oid: "/redfish/v1/Systems/" + reading.connection.systemID,
+			},
+		})
+	}
+}
@@ -0,0 +1,377 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const (
+	powerBudgetAggregateURI = "/redfish/v1/AggregationService/Aggregates/0b3a46b4-0ef5-4e3b-a9c4-9c4b9e3b5b2e"
+	powerBudgetSystemURI    = "/redfish/v1/Systems/c14d91b5-3333-48bb-a7b7-75f74a137d48.1"
+)
+
+type mockPowerControl struct {
+	lock          sync.Mutex
+	limits        map[string]interface{}
+	events        []common.Event
+	consumedWatts map[string]float64
+	unreachable   map[string]bool
+}
+
+func (m *mockPowerControl) contactClient(url, method, token string, odataID string, body interface{}, credentials map[string]string) (*http.Response, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	target, _ := body.(*agmodel.Target)
+	if target == nil || url != "https://localhost:9091/ODIM/v1/Chassis/1/Power" {
+		return nil, fmt.Errorf("unexpected request %s %s", method, url)
+	}
+	if m.unreachable[target.ManagerAddress] {
+		return nil, fmt.Errorf("%s is not reachable", target.ManagerAddress)
+	}
+	switch method {
+	case http.MethodGet:
+		respBody := fmt.Sprintf(`{"PowerControl":[{"PowerConsumedWatts":%v}]}`, m.consumedWatts[target.ManagerAddress])
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(respBody)),
+		}, nil
+	case http.MethodPatch:
+		var power struct {
+			PowerControl []struct {
+				PowerLimit map[string]interface{} `json:"PowerLimit"`
+			} `json:"PowerControl"`
+		}
+		json.Unmarshal(target.PostBody, &power)
+		m.limits[target.ManagerAddress] = power.PowerControl[0].PowerLimit["LimitInWatts"]
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+		}, nil
+	}
+	return nil, fmt.Errorf("unexpected request %s %s", method, url)
+}
+
+func (m *mockPowerControl) publishDeviceEvent(host string, event common.Event) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.events = append(m.events, event)
+}
+
+func mockPowerBudgetAggregate(t *testing.T) {
+	systems := map[string]string{
+		"6d4a0a66-7efa-578e-83cf-44dc68d2874e": "100.0.0.1",
+		"c14d91b5-3333-48bb-a7b7-75f74a137d48": "100.0.0.2",
+	}
+	var aggregate agmodel.Aggregate
+	for deviceUUID, address := range systems {
+		systemURI := "/redfish/v1/Systems/" + deviceUUID + ".1"
+		systemData, _ := json.Marshal(map[string]interface{}{
+			"@odata.id": systemURI,
+			"Links": map[string]interface{}{
+				"Chassis": []interface{}{
+					map[string]string{"@odata.id": "/redfish/v1/Chassis/" + deviceUUID + ".1"},
+				},
+			},
+		})
+		if err := mockSystemResourceData(systemData, "ComputerSystem", systemURI); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		if err := mockDeviceData(deviceUUID, agmodel.Target{
+			ManagerAddress: address,
+			Password:       []byte("password"),
+			UserName:       "admin",
+			DeviceUUID:     deviceUUID,
+			PluginID:       "GRF",
+		}); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		aggregate.Elements = append(aggregate.Elements, agmodel.OdataID{OdataID: systemURI})
+	}
+	if err := mockPluginData(t, "GRF"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if err := agmodel.CreateAggregate(aggregate, powerBudgetAggregateURI); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+}
+
+func TestAllocatePowerBudget(t *testing.T) {
+	tests := []struct {
+		name         string
+		limitInWatts float64
+		consumption  map[string]float64
+		want         map[string]float64
+	}{
+		{
+			name:         "no elements",
+			limitInWatts: 1000,
+			consumption:  map[string]float64{},
+			want:         map[string]float64{},
+		},
+		{
+			name:         "no consumption",
+			limitInWatts: 1000,
+			consumption:  map[string]float64{"a": 0, "b": 0},
+			want:         map[string]float64{"a": 500, "b": 500},
+		},
+		{
+			name:         "shared by consumption",
+			limitInWatts: 1000,
+			consumption:  map[string]float64{"a": 100, "b": 300},
+			want:         map[string]float64{"a": 375, "b": 625},
+		},
+		{
+			name:         "fractions are rounded down",
+			limitInWatts: 1000,
+			consumption:  map[string]float64{"a": 1, "b": 1, "c": 1},
+			want:         map[string]float64{"a": 333, "b": 333, "c": 333},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := allocatePowerBudget(tt.limitInWatts, tt.consumption); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("allocatePowerBudget() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExternalInterface_SetPowerBudgetOfAggregate(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	mockPowerBudgetAggregate(t)
+
+	powerControl := &mockPowerControl{
+		limits: make(map[string]interface{}),
+		consumedWatts: map[string]float64{
+			"100.0.0.1": 300,
+			"100.0.0.2": 500,
+		},
+	}
+	e := getMockExternalInterface()
+	e.ContactClient = powerControl.contactClient
+	e.PublishDeviceEvent = powerControl.publishDeviceEvent
+
+	actionURL := powerBudgetAggregateURI + "/Actions/Oem/Odim.SetPowerBudget"
+	tests := []struct {
+		name           string
+		url            string
+		reqBody        string
+		wantStatusCode int32
+	}{
+		{
+			name:           "malformed request",
+			url:            actionURL,
+			reqBody:        `{"LimitInWatts":`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown property",
+			url:            actionURL,
+			reqBody:        `{"limitinwatts":600}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "missing limit",
+			url:            actionURL,
+			reqBody:        `{"RebalanceIntervalInSeconds":60}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "negative limit",
+			url:            actionURL,
+			reqBody:        `{"LimitInWatts":-1}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "too short rebalance interval",
+			url:            actionURL,
+			reqBody:        `{"LimitInWatts":600,"RebalanceIntervalInSeconds":10}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown aggregate",
+			url:            "/redfish/v1/AggregationService/Aggregates/unknown/Actions/Oem/Odim.SetPowerBudget",
+			reqBody:        `{"LimitInWatts":600}`,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "budget set",
+			url:            actionURL,
+			reqBody:        `{"LimitInWatts":600}`,
+			wantStatusCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := e.SetPowerBudgetOfAggregate(&aggregatorproto.AggregatorRequest{
+				SessionToken: "validToken",
+				URL:          tt.url,
+				RequestBody:  []byte(tt.reqBody),
+			})
+			if got.StatusCode != tt.wantStatusCode {
+				t.Errorf("SetPowerBudgetOfAggregate() = %v, want %v", got.StatusCode, tt.wantStatusCode)
+			}
+		})
+	}
+
+	budget, err := agmodel.GetPowerBudget(powerBudgetAggregateURI)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if budget.RebalanceIntervalInSeconds != defaultRebalanceIntervalInSeconds || budget.ConsumedWatts != 800 || !budget.BudgetExceeded {
+		t.Errorf("unexpected power budget %+v", budget)
+	}
+	wantLimits := map[string]interface{}{"100.0.0.1": float64(262), "100.0.0.2": float64(337)}
+	if !reflect.DeepEqual(powerControl.limits, wantLimits) {
+		t.Errorf("power limits = %v, want %v", powerControl.limits, wantLimits)
+	}
+	if len(powerControl.events) != 2 || powerControl.events[0].MessageID != PowerBudgetExceededMessageID {
+		t.Errorf("unexpected events %+v", powerControl.events)
+	}
+
+	aggregate := e.GetAggregate(&aggregatorproto.AggregatorRequest{URL: powerBudgetAggregateURI})
+	if body := aggregate.Body.(agresponse.AggregateGetResponse); body.Oem == nil || body.Oem.Odim.PowerBudget.LimitInWatts != 600 {
+		t.Errorf("power budget missing in aggregate %+v", aggregate.Body)
+	}
+
+	got := e.SetPowerBudgetOfAggregate(&aggregatorproto.AggregatorRequest{
+		SessionToken: "validToken",
+		URL:          actionURL,
+		RequestBody:  []byte(`{"LimitInWatts":0}`),
+	})
+	if got.StatusCode != http.StatusOK {
+		t.Errorf("SetPowerBudgetOfAggregate() = %v, want %v", got.StatusCode, http.StatusOK)
+	}
+	if _, err := agmodel.GetPowerBudget(powerBudgetAggregateURI); err == nil {
+		t.Errorf("power budget is not removed")
+	}
+	wantLimits = map[string]interface{}{"100.0.0.1": nil, "100.0.0.2": nil}
+	if !reflect.DeepEqual(powerControl.limits, wantLimits) {
+		t.Errorf("power limits = %v, want %v", powerControl.limits, wantLimits)
+	}
+}
+
+func TestExternalInterface_SetPowerBudgetOfAggregateReserved(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	mockPowerBudgetAggregate(t)
+	if err := agmodel.SavePowerBudget(powerBudgetAggregateURI, agmodel.PowerBudget{
+		LimitInWatts:               1000,
+		RebalanceIntervalInSeconds: defaultRebalanceIntervalInSeconds,
+		AllocatedWatts:             map[string]float64{powerBudgetSystemURI: 600},
+	}); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	powerControl := &mockPowerControl{
+		limits:        make(map[string]interface{}),
+		consumedWatts: map[string]float64{"100.0.0.1": 300},
+		unreachable:   map[string]bool{"100.0.0.2": true},
+	}
+	e := getMockExternalInterface()
+	e.ContactClient = powerControl.contactClient
+	e.PublishDeviceEvent = powerControl.publishDeviceEvent
+
+	// the 600 watts kept for the unreachable system leave nothing for the other system
+	got := e.SetPowerBudgetOfAggregate(&aggregatorproto.AggregatorRequest{
+		SessionToken: "validToken",
+		URL:          powerBudgetAggregateURI + "/Actions/Oem/Odim.SetPowerBudget",
+		RequestBody:  []byte(`{"LimitInWatts":500}`),
+	})
+	if got.StatusCode != http.StatusConflict {
+		t.Errorf("SetPowerBudgetOfAggregate() = %v, want %v", got.StatusCode, http.StatusConflict)
+	}
+	if len(powerControl.limits) != 0 {
+		t.Errorf("power limits = %v, want no limit to be set", powerControl.limits)
+	}
+	if budget, err := agmodel.GetPowerBudget(powerBudgetAggregateURI); err != nil || budget.LimitInWatts != 1000 {
+		t.Errorf("power budget = %+v, %v, want the earlier budget to be kept", budget, err)
+	}
+}
+
+func TestExternalInterface_RebalanceIfDue(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	mockPowerBudgetAggregate(t)
+	if err := agmodel.SavePowerBudget(powerBudgetAggregateURI, agmodel.PowerBudget{
+		LimitInWatts:               600,
+		RebalanceIntervalInSeconds: defaultRebalanceIntervalInSeconds,
+	}); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	powerControl := &mockPowerControl{
+		limits: make(map[string]interface{}),
+		consumedWatts: map[string]float64{
+			"100.0.0.1": 300,
+			"100.0.0.2": 300,
+		},
+	}
+	e := getMockExternalInterface()
+	e.ContactClient = powerControl.contactClient
+	e.PublishDeviceEvent = powerControl.publishDeviceEvent
+
+	// the rebalance is claimed by another instance of the service
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if claimed, err := conn.AcquireLease(agmodel.PowerBudgetRebalanceTable, powerBudgetAggregateURI, "otherInstance", 60); err != nil || !claimed {
+		t.Fatalf("error while claiming the rebalance: %v", err)
+	}
+	e.rebalanceIfDue(powerBudgetAggregateURI)
+	if len(powerControl.limits) != 0 {
+		t.Errorf("power limits = %v, want no limit to be set by the instance without the lease", powerControl.limits)
+	}
+
+	if err := conn.ReleaseLease(agmodel.PowerBudgetRebalanceTable, powerBudgetAggregateURI, "otherInstance"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	e.rebalanceIfDue(powerBudgetAggregateURI)
+	wantLimits := map[string]interface{}{"100.0.0.1": float64(300), "100.0.0.2": float64(300)}
+	if !reflect.DeepEqual(powerControl.limits, wantLimits) {
+		t.Errorf("power limits = %v, want %v", powerControl.limits, wantLimits)
+	}
+}
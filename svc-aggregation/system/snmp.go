@@ -0,0 +1,560 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agsnmp"
+	uuid "github.com/satori/go.uuid"
+)
+
+// SNMPConnectionMethodType is the connection method type of the aggregation sources
+// which are managed directly by ODIM using SNMP, without a plugin
+const SNMPConnectionMethodType = "SNMP"
+
+const (
+	snmpPollingLease      = "Polling"
+	snmpTrapReceiverLease = "TrapReceiver"
+	// snmpTrapReceiverLeaseSeconds is the time after which another instance
+	// takes over the traps when the instance holding the lease fails
+	snmpTrapReceiverLeaseSeconds       = 30
+	snmpTrapReceiverLeaseRenewInterval = 10 * time.Second
+)
+
+// PollSNMPAgent function pointer for the agsnmp.Poll
+var PollSNMPAgent = agsnmp.Poll
+
+// snmpCredentials returns the SNMP settings and the credentials of the SNMP agent in the request
+func snmpCredentials(aggregationSourceRequest AggregationSource) (*SNMP, agsnmp.Credentials) {
+	snmpRequest := aggregationSourceRequest.SNMP
+	if snmpRequest == nil {
+		snmpRequest = &SNMP{}
+	}
+	return snmpRequest, agsnmp.Credentials{
+		UserName:               aggregationSourceRequest.UserName,
+		Password:               aggregationSourceRequest.Password,
+		AuthenticationProtocol: snmpRequest.AuthenticationProtocol,
+		AuthenticationKey:      snmpRequest.AuthenticationKey,
+		EncryptionProtocol:     snmpRequest.EncryptionProtocol,
+		EncryptionKey:          snmpRequest.EncryptionKey,
+	}
+}
+
+// addSNMPAgent validates the SNMP agent at the requested host name and stores
+// its chassis and sensors. It returns the response, the aggregation source ID,
+// the encrypted password and the SNMP settings to be stored with the aggregation source.
+func (e *ExternalInterface) addSNMPAgent(aggregationSourceRequest AggregationSource, taskInfo *common.TaskUpdateInfo) (response.RPC, string, []byte, *agmodel.SNMP) {
+	var resp response.RPC
+	snmpRequest, credentials := snmpCredentials(aggregationSourceRequest)
+	if property, err := credentials.Validate(); err != nil {
+		errMsg := "error: invalid SNMP settings: " + err.Error()
+		log.Error(errMsg)
+		switch property {
+		case "UserName", "Password":
+			return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{property}, taskInfo), "", nil, nil
+		case "AuthenticationProtocol":
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{snmpRequest.AuthenticationProtocol, property}, taskInfo), "", nil, nil
+		case "EncryptionProtocol":
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{snmpRequest.EncryptionProtocol, property}, taskInfo), "", nil, nil
+		default:
+			// keys are not echoed back in the response
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{"******", property}, taskInfo), "", nil, nil
+		}
+	}
+
+	agentIP, _, _, err := agcommon.LookupHost(aggregationSourceRequest.HostName)
+	if err != nil {
+		errMsg := "error: unable to resolve the host name " + aggregationSourceRequest.HostName + ": " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{aggregationSourceRequest.HostName, "HostName"}, taskInfo), "", nil, nil
+	}
+	if _, dbErr := agmodel.GetSNMPAgent(agentIP); dbErr == nil {
+		errMsg := "error: SNMP agent " + agentIP + " is already added"
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusConflict, response.ResourceAlreadyExists, errMsg, []interface{}{"AggregationSource", "HostName", agentIP}, taskInfo), "", nil, nil
+	} else if errors.DBKeyNotFound != dbErr.ErrNo() {
+		errMsg := "error while trying to get SNMP agent details: " + dbErr.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo), "", nil, nil
+	}
+
+	// polling the agent validates both the reachability and the credentials
+	deviceInfo, err := PollSNMPAgent(aggregationSourceRequest.HostName, credentials)
+	if err != nil {
+		errMsg := "error: unable to poll SNMP agent: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.CouldNotEstablishConnection, errMsg, []interface{}{aggregationSourceRequest.HostName}, taskInfo), "", nil, nil
+	}
+
+	cipherText, err := e.EncryptPassword([]byte(aggregationSourceRequest.Password))
+	if err != nil {
+		errMsg := "error while trying to encrypt: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo), "", nil, nil
+	}
+	snmp := &agmodel.SNMP{
+		AuthenticationProtocol: snmpRequest.AuthenticationProtocol,
+		EncryptionProtocol:     snmpRequest.EncryptionProtocol,
+		TrapCommunity:          snmpRequest.TrapCommunity,
+	}
+	if snmpRequest.AuthenticationKey != "" {
+		if snmp.AuthenticationKey, err = e.EncryptPassword([]byte(snmpRequest.AuthenticationKey)); err != nil {
+			errMsg := "error while trying to encrypt: " + err.Error()
+			log.Error(errMsg)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo), "", nil, nil
+		}
+		snmp.AuthenticationKeySet = true
+	}
+	if snmpRequest.EncryptionKey != "" {
+		if snmp.EncryptionKey, err = e.EncryptPassword([]byte(snmpRequest.EncryptionKey)); err != nil {
+			errMsg := "error while trying to encrypt: " + err.Error()
+			log.Error(errMsg)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo), "", nil, nil
+		}
+		snmp.EncryptionKeySet = true
+	}
+
+	deviceUUID := uuid.NewV4().String()
+	aggregationSourceID := deviceUUID + "." + agsnmp.ChassisID
+	chassisURI := getSNMPChassisURI(deviceUUID)
+	if err := saveSNMPAgentResources(deviceUUID, deviceInfo); err != nil {
+		go agmodel.DeleteDeviceResources(deviceUUID)
+		errMsg := "error while trying to save SNMP agent resources: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo), "", nil, nil
+	}
+	agent := agmodel.SNMPAgent{
+		DeviceUUID:           deviceUUID,
+		AggregationSourceURI: "/redfish/v1/AggregationService/AggregationSources/" + aggregationSourceID,
+		ChassisURI:           chassisURI,
+	}
+	if dbErr := agmodel.SaveSNMPAgent(agentIP, agent); dbErr != nil {
+		go agmodel.DeleteDeviceResources(deviceUUID)
+		errMsg := "error while trying to save SNMP agent details: " + dbErr.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo), "", nil, nil
+	}
+	// device subscription lets the event service map the traps of the agent to its chassis
+	devSubscription := common.DeviceSubscription{
+		EventHostIP:     agentIP,
+		OriginResources: []string{chassisURI},
+	}
+	if err := agmodel.SaveDeviceSubscription(devSubscription); err != nil {
+		log.Error("error while trying to save device subscription of SNMP agent " + agentIP + ": " + err.Error())
+	}
+	e.PublishEvent([]string{chassisURI}, "ChassisCollection")
+	log.Info("successfully added SNMP agent " + aggregationSourceRequest.HostName)
+	return resp, aggregationSourceID, cipherText, snmp
+}
+
+// deleteSNMPAgent removes the resources, the agent details and
+// the device subscription of the SNMP agent
+func (e *ExternalInterface) deleteSNMPAgent(deviceUUID string, aggregationSource agmodel.AggregationSource) response.RPC {
+	var resp response.RPC
+	if dbErr := agmodel.DeleteDeviceResources(deviceUUID); dbErr != nil {
+		errMsg := "error while trying to delete SNMP agent resources: " + dbErr.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	agentIP, _, _, err := agcommon.LookupHost(aggregationSource.HostName)
+	if err != nil {
+		log.Error("unable to resolve the host name " + aggregationSource.HostName + ": " + err.Error())
+	} else {
+		if dbErr := agmodel.DeleteSNMPAgent(agentIP); dbErr != nil {
+			log.Error("error while trying to delete SNMP agent details: " + dbErr.Error())
+		}
+		if err := agmodel.DeleteDeviceSubscription(agentIP); err != nil {
+			log.Error("error while trying to delete device subscription of SNMP agent: " + err.Error())
+		}
+	}
+	e.EventNotification(getSNMPChassisURI(deviceUUID), "ResourceRemoved", "ChassisCollection")
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.ResourceRemoved
+	return resp
+}
+
+// updateSNMPAggregationSource validates the updated credentials of the SNMP agent
+func (e *ExternalInterface) updateSNMPAggregationSource(url string, updateRequest map[string]interface{}, hostNameUpdated bool) response.RPC {
+	if hostNameUpdated {
+		errMsg := "error: HostName of an SNMP aggregation source can't be updated"
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.ActionNotSupported, errMsg, []interface{}{http.MethodPatch}, nil)
+	}
+	aggregationSource, dbErr := agmodel.GetAggregationSourceInfo(url)
+	if dbErr != nil {
+		errMsg := "error while trying to get aggregation source info: " + dbErr.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	aggregationSource.UserName = updateRequest["UserName"].(string)
+	credentials, err := e.getSNMPCredentials(aggregationSource)
+	if err != nil {
+		errMsg := err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	credentials.Password = string(updateRequest["Password"].([]byte))
+	if property, err := credentials.Validate(); err != nil {
+		errMsg := "error: invalid SNMP settings: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{property}, nil)
+	}
+	if _, err := PollSNMPAgent(aggregationSource.HostName, credentials); err != nil {
+		errMsg := "error: unable to poll SNMP agent: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.CouldNotEstablishConnection, errMsg, []interface{}{aggregationSource.HostName}, nil)
+	}
+	cipherText, err := e.EncryptPassword(updateRequest["Password"].([]byte))
+	if err != nil {
+		errMsg := "error while trying to encrypt: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	updateRequest["Password"] = cipherText
+	return response.RPC{}
+}
+
+// getSNMPCredentials returns the decrypted credentials of the SNMP aggregation source
+func (e *ExternalInterface) getSNMPCredentials(aggregationSource agmodel.AggregationSource) (agsnmp.Credentials, error) {
+	credentials := agsnmp.Credentials{
+		UserName: aggregationSource.UserName,
+	}
+	password, err := e.DecryptPassword(aggregationSource.Password)
+	if err != nil {
+		return credentials, fmt.Errorf("unable to decrypt SNMP agent password: %v", err)
+	}
+	credentials.Password = string(password)
+	snmp := aggregationSource.SNMP
+	if snmp == nil {
+		return credentials, nil
+	}
+	credentials.AuthenticationProtocol = snmp.AuthenticationProtocol
+	credentials.EncryptionProtocol = snmp.EncryptionProtocol
+	if snmp.AuthenticationKeySet {
+		key, err := e.DecryptPassword(snmp.AuthenticationKey)
+		if err != nil {
+			return credentials, fmt.Errorf("unable to decrypt SNMP authentication key: %v", err)
+		}
+		credentials.AuthenticationKey = string(key)
+	}
+	if snmp.EncryptionKeySet {
+		key, err := e.DecryptPassword(snmp.EncryptionKey)
+		if err != nil {
+			return credentials, fmt.Errorf("unable to decrypt SNMP encryption key: %v", err)
+		}
+		credentials.EncryptionKey = string(key)
+	}
+	return credentials, nil
+}
+
+// getSNMPResponse returns the SNMP settings of the aggregation source
+// to be sent in the response, the keys are never returned
+func getSNMPResponse(snmp *agmodel.SNMP) *agresponse.SNMP {
+	if snmp == nil {
+		return nil
+	}
+	return &agresponse.SNMP{
+		AuthenticationKeySet:   snmp.AuthenticationKeySet,
+		AuthenticationProtocol: snmp.AuthenticationProtocol,
+		EncryptionKeySet:       snmp.EncryptionKeySet,
+		EncryptionProtocol:     snmp.EncryptionProtocol,
+	}
+}
+
+func getSNMPChassisURI(deviceUUID string) string {
+	return "/redfish/v1/Chassis/" + deviceUUID + "." + agsnmp.ChassisID
+}
+
+// saveSNMPAgentResources stores the chassis, the sensors collection and the sensors
+// of the SNMP agent, the sensors no longer reported by the agent are removed
+func saveSNMPAgentResources(deviceUUID string, deviceInfo *agsnmp.DeviceInfo) error {
+	chassisURI := getSNMPChassisURI(deviceUUID)
+	sensorsURI := chassisURI + "/Sensors"
+	chassis := dmtf.Chassis{
+		Ocontext:    "/redfish/v1/$metadata#Chassis.Chassis",
+		Oid:         chassisURI,
+		Otype:       common.ChassisType,
+		ID:          deviceUUID + "." + agsnmp.ChassisID,
+		Name:        deviceInfo.Name,
+		Description: deviceInfo.Description,
+		ChassisType: "Other",
+		Sensors:     &dmtf.Sensors{Oid: sensorsURI},
+		Status: &dmtf.Status{
+			State:  "Enabled",
+			Health: "OK",
+		},
+	}
+	var oem dmtf.Oem = map[string]interface{}{
+		"Odim": map[string]interface{}{
+			"SNMP": map[string]string{
+				"ObjectID": deviceInfo.ObjectID,
+				"Contact":  deviceInfo.Contact,
+				"Location": deviceInfo.Location,
+			},
+		},
+	}
+	chassis.Oem = &oem
+	if chassis.Name == "" {
+		chassis.Name = "SNMP Agent"
+	}
+	if err := saveResource(chassis, "Chassis", chassisURI); err != nil {
+		return err
+	}
+
+	sensorsCollection := agresponse.List{
+		Response: response.Response{
+			OdataType:    common.SensorCollectionType,
+			OdataID:      sensorsURI,
+			OdataContext: "/redfish/v1/$metadata#SensorCollection.SensorCollection",
+			Name:         "Sensors",
+		},
+		Members: []agresponse.ListMember{},
+	}
+	var sensorURIs = make(map[string]bool)
+	for _, sensor := range deviceInfo.Sensors {
+		sensorURI := sensorsURI + "/" + sensor.ID
+		sensorURIs[sensorURI] = true
+		sensorsCollection.Members = append(sensorsCollection.Members, agresponse.ListMember{OdataID: sensorURI})
+		resource := dmtf.Sensor{
+			ODataContext: "/redfish/v1/$metadata#Sensor.Sensor",
+			ODataID:      sensorURI,
+			ODataType:    common.SensorType,
+			ID:           sensor.ID,
+			Name:         sensor.Name,
+			Reading:      sensor.Reading,
+			ReadingType:  sensor.ReadingType,
+			ReadingUnits: sensor.ReadingUnits,
+			Status: &dmtf.Status{
+				State:  sensor.State,
+				Health: sensor.Health,
+			},
+		}
+		if err := saveResource(resource, "Sensors", sensorURI); err != nil {
+			return err
+		}
+	}
+	sensorsCollection.MembersCount = len(sensorsCollection.Members)
+	if err := saveResource(sensorsCollection, common.ChassisResource["Sensors"], sensorsURI); err != nil {
+		return err
+	}
+
+	savedSensors, dbErr := agmodel.GetAllMatchingDetails("Sensors", sensorsURI+"/", common.InMemory)
+	if dbErr != nil {
+		return dbErr
+	}
+	for _, sensorURI := range savedSensors {
+		if !sensorURIs[sensorURI] {
+			if dbErr := agmodel.Delete("Sensors", sensorURI, common.InMemory); dbErr != nil {
+				log.Error("error while trying to delete sensor " + sensorURI + ": " + dbErr.Error())
+			}
+		}
+	}
+	return nil
+}
+
+func saveResource(resource interface{}, table, key string) error {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return fmt.Errorf("unable to marshal %s: %v", key, err)
+	}
+	return agmodel.GenericSave(data, table, key)
+}
+
+// PerformSNMPPolling periodically polls the SNMP agents added as aggregation sources
+// and refreshes their chassis and sensors
+func PerformSNMPPolling() {
+	log.Info("SNMP agents polling routine started")
+	e := ExternalInterface{
+		DecryptPassword: DecryptWithPrivateKey,
+	}
+	for {
+		e.pollSNMPAgents()
+		time.Sleep(time.Minute * time.Duration(config.Data.SNMPConf.PollingFrequencyInMins))
+	}
+}
+
+// pollSNMPAgents polls all the SNMP agents. Every instance of the service runs the
+// polling routine, the agents are polled once in an interval by the one which holds
+// the lease for it.
+func (e *ExternalInterface) pollSNMPAgents() {
+	claimed, dbErr := agmodel.AcquireLease(agmodel.SNMPLeaseTable, snmpPollingLease, config.Data.SNMPConf.PollingFrequencyInMins*60)
+	if dbErr != nil {
+		log.Error("failed to claim the polling of SNMP agents: " + dbErr.Error())
+		return
+	}
+	if !claimed {
+		return
+	}
+	agentIPs, err := agmodel.GetAllKeysFromTable(agmodel.SNMPAgentTable)
+	if err != nil {
+		log.Error("failed to get list of all SNMP agents: " + err.Error())
+		return
+	}
+	for _, agentIP := range agentIPs {
+		go e.pollSNMPAgent(agentIP)
+	}
+}
+
+func (e *ExternalInterface) pollSNMPAgent(agentIP string) {
+	agent, dbErr := agmodel.GetSNMPAgent(agentIP)
+	if dbErr != nil {
+		log.Error("failed to get SNMP agent " + agentIP + ": " + dbErr.Error())
+		return
+	}
+	aggregationSource, dbErr := agmodel.GetAggregationSourceInfo(agent.AggregationSourceURI)
+	if dbErr != nil {
+		log.Error("failed to get aggregation source of SNMP agent " + agentIP + ": " + dbErr.Error())
+		return
+	}
+	credentials, err := e.getSNMPCredentials(aggregationSource)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	deviceInfo, err := PollSNMPAgent(aggregationSource.HostName, credentials)
+	if err != nil {
+		log.Error("failed to poll SNMP agent " + agentIP + ": " + err.Error())
+		setSNMPChassisOffline(agent.ChassisURI)
+		return
+	}
+	if err := saveSNMPAgentResources(agent.DeviceUUID, deviceInfo); err != nil {
+		log.Error("failed to update resources of SNMP agent " + agentIP + ": " + err.Error())
+	}
+}
+
+// setSNMPChassisOffline marks the chassis of an unreachable SNMP agent as offline,
+// the sensors are left with their last readings
+func setSNMPChassisOffline(chassisURI string) {
+	data, dbErr := agmodel.GetResource("Chassis", chassisURI)
+	if dbErr != nil {
+		log.Error("failed to get chassis " + chassisURI + ": " + dbErr.Error())
+		return
+	}
+	var chassis dmtf.Chassis
+	if err := json.Unmarshal([]byte(data), &chassis); err != nil {
+		log.Error("failed to unmarshal chassis " + chassisURI + ": " + err.Error())
+		return
+	}
+	chassis.Status = &dmtf.Status{
+		State:  "UnavailableOffline",
+		Health: "Critical",
+	}
+	if err := saveResource(chassis, "Chassis", chassisURI); err != nil {
+		log.Error("failed to update chassis " + chassisURI + ": " + err.Error())
+	}
+}
+
+// GetSNMPTrapCredentials returns the credentials and the trap community of the SNMP agent,
+// which authenticate its traps. Error is returned when the agent is not added as aggregation source.
+func GetSNMPTrapCredentials(agentIP string) (agsnmp.Credentials, error) {
+	agent, dbErr := agmodel.GetSNMPAgent(agentIP)
+	if dbErr != nil {
+		return agsnmp.Credentials{}, fmt.Errorf("unknown SNMP agent: %v", dbErr.Error())
+	}
+	aggregationSource, dbErr := agmodel.GetAggregationSourceInfo(agent.AggregationSourceURI)
+	if dbErr != nil {
+		return agsnmp.Credentials{}, fmt.Errorf("unable to get aggregation source: %v", dbErr.Error())
+	}
+	e := ExternalInterface{
+		DecryptPassword: DecryptWithPrivateKey,
+	}
+	credentials, err := e.getSNMPCredentials(aggregationSource)
+	if err != nil {
+		return credentials, err
+	}
+	if aggregationSource.SNMP != nil {
+		credentials.TrapCommunity = aggregationSource.SNMP.TrapCommunity
+	}
+	return credentials, nil
+}
+
+// RunSNMPTrapReceiver receives the SNMP traps on the address while this instance of the
+// service holds the lease of the trap receiver, so that a trap is published only once.
+// The lease is renewed while the receiver runs, another instance takes over the traps
+// when this instance fails to renew it.
+func RunSNMPTrapReceiver(address string, publishEvent func(string, common.Event)) {
+	runner := snmpTrapReceiverRunner{
+		address:      address,
+		publishEvent: publishEvent,
+	}
+	for {
+		runner.refresh()
+		time.Sleep(snmpTrapReceiverLeaseRenewInterval)
+	}
+}
+
+type snmpTrapReceiverRunner struct {
+	address      string
+	publishEvent func(string, common.Event)
+	receiver     *agsnmp.TrapReceiver
+	stopped      chan struct{}
+}
+
+// refresh starts the trap receiver when the lease is acquired
+// and stops it when the lease is lost or the receiver failed
+func (r *snmpTrapReceiverRunner) refresh() {
+	if r.receiver != nil {
+		select {
+		case <-r.stopped:
+			r.receiver = nil
+			if dbErr := agmodel.ReleaseLease(agmodel.SNMPLeaseTable, snmpTrapReceiverLease); dbErr != nil {
+				log.Error("failed to release the lease of the SNMP trap receiver: " + dbErr.Error())
+			}
+			return
+		default:
+		}
+		renewed, dbErr := agmodel.RenewLease(agmodel.SNMPLeaseTable, snmpTrapReceiverLease, snmpTrapReceiverLeaseSeconds)
+		if dbErr != nil {
+			log.Error("failed to renew the lease of the SNMP trap receiver: " + dbErr.Error())
+		}
+		if !renewed {
+			log.Info("SNMP trap receiver lease is lost, stopping the trap receiver")
+			r.receiver.Close()
+			r.receiver = nil
+		}
+		return
+	}
+	claimed, dbErr := agmodel.AcquireLease(agmodel.SNMPLeaseTable, snmpTrapReceiverLease, snmpTrapReceiverLeaseSeconds)
+	if dbErr != nil {
+		log.Error("failed to claim the lease of the SNMP trap receiver: " + dbErr.Error())
+		return
+	}
+	if !claimed {
+		return
+	}
+	receiver := agsnmp.NewTrapReceiver(GetSNMPTrapCredentials, r.publishEvent)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		if err := receiver.Listen(r.address); err != nil {
+			log.Error("SNMP trap receiver stopped: " + err.Error())
+		}
+	}()
+	r.receiver, r.stopped = receiver, stopped
+}
@@ -0,0 +1,233 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agsnmp/snmpsim"
+	"github.com/gosnmp/gosnmp"
+)
+
+const snmpConnectionMethodURI = "/redfish/v1/AggregationService/ConnectionMethods/d1b6c3ea-2c57-4bd2-bd6c-6e0a1b0a5c5e"
+
+func mockGetSNMPConnectionMethod(connectionMethodURI string) (agmodel.ConnectionMethod, *errors.Error) {
+	return agmodel.ConnectionMethod{
+		ConnectionMethodType:    SNMPConnectionMethodType,
+		ConnectionMethodVariant: "Chassis:SNMP:SNMP_v1.0.0",
+	}, nil
+}
+
+func startMockSNMPAgent(t *testing.T) *snmpsim.Agent {
+	agent := snmpsim.NewAgent("public", []gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: []byte("Rack PDU")},
+		{Name: ".1.3.6.1.2.1.1.5.0", Type: gosnmp.OctetString, Value: []byte("pdu-1")},
+		{Name: ".1.3.6.1.2.1.99.1.1.1.1.10", Type: gosnmp.Integer, Value: 8},
+		{Name: ".1.3.6.1.2.1.99.1.1.1.2.10", Type: gosnmp.Integer, Value: 9},
+		{Name: ".1.3.6.1.2.1.99.1.1.1.3.10", Type: gosnmp.Integer, Value: 0},
+		{Name: ".1.3.6.1.2.1.99.1.1.1.4.10", Type: gosnmp.Integer, Value: 24},
+		{Name: ".1.3.6.1.2.1.99.1.1.1.5.10", Type: gosnmp.Integer, Value: 1},
+	})
+	if err := agent.Start("127.0.0.1:0"); err != nil {
+		t.Fatalf("error while starting the SNMP agent: %v", err)
+	}
+	return agent
+}
+
+func getSNMPAggregationSourceRequest(t *testing.T, hostName, community string) *aggregatorproto.AggregatorRequest {
+	reqBody, err := json.Marshal(AggregationSource{
+		HostName: hostName,
+		Password: community,
+		Links: &Links{
+			ConnectionMethod: &ConnectionMethod{OdataID: snmpConnectionMethodURI},
+		},
+		SNMP: &SNMP{
+			AuthenticationProtocol: "CommunityString",
+			TrapCommunity:          "traps",
+		},
+	})
+	if err != nil {
+		t.Fatalf("error while marshalling the request: %v", err)
+	}
+	return &aggregatorproto.AggregatorRequest{
+		SessionToken: "validToken",
+		RequestBody:  reqBody,
+	}
+}
+
+func TestExternalInterface_SNMPAggregationSource(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	agent := startMockSNMPAgent(t)
+	defer agent.Close()
+	// the active request flag of the mock may be left set by the other tests
+	activeReqFlag = false
+
+	p := getMockExternalInterface()
+	p.GetConnectionMethod = mockGetSNMPConnectionMethod
+
+	resp := p.AddAggregationSource("123", "validUserName", getSNMPAggregationSourceRequest(t, agent.Addr(), "private"))
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("AddAggregationSource() with invalid community status = %v, want %v", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	resp = p.AddAggregationSource("123", "validUserName", getSNMPAggregationSourceRequest(t, agent.Addr(), "public"))
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("AddAggregationSource() status = %v, want %v: %v", resp.StatusCode, http.StatusCreated, resp.Body)
+	}
+	snmpAgent, dbErr := agmodel.GetSNMPAgent("127.0.0.1")
+	if dbErr != nil {
+		t.Fatalf("error while getting the SNMP agent: %v", dbErr)
+	}
+	if resp.Header["Location"] != snmpAgent.AggregationSourceURI {
+		t.Errorf("AddAggregationSource() location = %v, want %v", resp.Header["Location"], snmpAgent.AggregationSourceURI)
+	}
+	if _, dbErr := agmodel.GetResource("Chassis", snmpAgent.ChassisURI); dbErr != nil {
+		t.Errorf("error while getting the chassis: %v", dbErr)
+	}
+	sensor, dbErr := agmodel.GetResource("Sensors", snmpAgent.ChassisURI+"/Sensors/10")
+	if dbErr != nil {
+		t.Fatalf("error while getting the sensor: %v", dbErr)
+	}
+	var sensorData map[string]interface{}
+	json.Unmarshal([]byte(sensor), &sensorData)
+	if sensorData["Reading"] != 24.0 || sensorData["ReadingUnits"] != "Cel" {
+		t.Errorf("unexpected sensor %v", sensor)
+	}
+	DecryptWithPrivateKey = stubDevicePassword
+	defer func() {
+		DecryptWithPrivateKey = common.DecryptWithPrivateKey
+	}()
+	if credentials, err := GetSNMPTrapCredentials("127.0.0.1"); err != nil || credentials.TrapCommunity != "traps" || credentials.Password != "public" {
+		t.Errorf("GetSNMPTrapCredentials() = %+v, %v, want trap community traps", credentials, err)
+	}
+
+	// re-adding the agent is a conflict
+	resp = p.AddAggregationSource("123", "validUserName", getSNMPAggregationSourceRequest(t, agent.Addr(), "public"))
+	if resp.StatusCode != http.StatusConflict {
+		t.Errorf("AddAggregationSource() of existing agent status = %v, want %v", resp.StatusCode, http.StatusConflict)
+	}
+
+	// polling refreshes the sensor readings
+	agent.Set(gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.99.1.1.1.4.10", Type: gosnmp.Integer, Value: 30})
+	p.pollSNMPAgent("127.0.0.1")
+	sensor, _ = agmodel.GetResource("Sensors", snmpAgent.ChassisURI+"/Sensors/10")
+	json.Unmarshal([]byte(sensor), &sensorData)
+	if sensorData["Reading"] != 30.0 {
+		t.Errorf("sensor reading after polling = %v, want 30", sensorData["Reading"])
+	}
+
+	// agents are polled once in the polling interval
+	agent.Set(gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.99.1.1.1.4.10", Type: gosnmp.Integer, Value: 35})
+	p.pollSNMPAgents()
+	for i := 0; i < 50 && sensorData["Reading"] != 35.0; i++ {
+		time.Sleep(100 * time.Millisecond)
+		sensor, _ = agmodel.GetResource("Sensors", snmpAgent.ChassisURI+"/Sensors/10")
+		json.Unmarshal([]byte(sensor), &sensorData)
+	}
+	if sensorData["Reading"] != 35.0 {
+		t.Errorf("sensor reading after polling = %v, want 35", sensorData["Reading"])
+	}
+	agent.Set(gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.99.1.1.1.4.10", Type: gosnmp.Integer, Value: 40})
+	p.pollSNMPAgents()
+	time.Sleep(500 * time.Millisecond)
+	sensor, _ = agmodel.GetResource("Sensors", snmpAgent.ChassisURI+"/Sensors/10")
+	json.Unmarshal([]byte(sensor), &sensorData)
+	if sensorData["Reading"] != 35.0 {
+		t.Errorf("sensor reading after polling again in the interval = %v, want 35", sensorData["Reading"])
+	}
+
+	resp = p.DeleteAggregationSource(&aggregatorproto.AggregatorRequest{
+		SessionToken: "validToken",
+		URL:          snmpAgent.AggregationSourceURI,
+	})
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("DeleteAggregationSource() status = %v, want %v", resp.StatusCode, http.StatusNoContent)
+	}
+	if _, dbErr := agmodel.GetSNMPAgent("127.0.0.1"); dbErr == nil {
+		t.Error("SNMP agent is not deleted")
+	}
+	if _, dbErr := agmodel.GetResource("Chassis", snmpAgent.ChassisURI); dbErr == nil {
+		t.Error("chassis of the SNMP agent is not deleted")
+	}
+	if _, err := GetSNMPTrapCredentials("127.0.0.1"); err == nil {
+		t.Error("GetSNMPTrapCredentials() of deleted agent should fail")
+	}
+}
+
+func TestSNMPTrapReceiverRunner(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	defer common.TruncateDB(common.InMemory)
+	conn, dbErr := common.GetDBConnection(common.InMemory)
+	if dbErr != nil {
+		t.Fatalf("error while getting the DB connection: %v", dbErr)
+	}
+
+	runner := snmpTrapReceiverRunner{
+		address:      "127.0.0.1:0",
+		publishEvent: func(string, common.Event) {},
+	}
+	// receiver is not started while another instance holds the lease
+	if _, dbErr := conn.AcquireLease(agmodel.SNMPLeaseTable, snmpTrapReceiverLease, "other", 60); dbErr != nil {
+		t.Fatalf("error while acquiring the lease: %v", dbErr)
+	}
+	runner.refresh()
+	if runner.receiver != nil {
+		t.Fatal("trap receiver is started while the lease is held by another instance")
+	}
+
+	if dbErr := conn.ReleaseLease(agmodel.SNMPLeaseTable, snmpTrapReceiverLease, "other"); dbErr != nil {
+		t.Fatalf("error while releasing the lease: %v", dbErr)
+	}
+	runner.refresh()
+	if runner.receiver == nil {
+		t.Fatal("trap receiver is not started after acquiring the lease")
+	}
+	<-runner.receiver.Listening()
+	runner.refresh()
+	if runner.receiver == nil {
+		t.Fatal("trap receiver is stopped after renewing the lease")
+	}
+
+	// receiver is stopped when the lease is lost
+	if dbErr := conn.Delete(agmodel.SNMPLeaseTable, snmpTrapReceiverLease); dbErr != nil {
+		t.Fatalf("error while deleting the lease: %v", dbErr)
+	}
+	stopped := runner.stopped
+	runner.refresh()
+	if runner.receiver != nil {
+		t.Fatal("trap receiver is not stopped after losing the lease")
+	}
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("trap receiver is still listening after losing the lease")
+	}
+}
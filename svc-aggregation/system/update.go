@@ -148,6 +148,7 @@ func (e *ExternalInterface) UpdateAggregationSource(req *aggregatorproto.Aggrega
 		HostName: updateRequest["HostName"].(string),
 		UserName: updateRequest["UserName"].(string),
 		Links:    aggregationSource.Links,
+		SNMP:     getSNMPResponse(aggregationSource.SNMP),
 	}
 	resp.StatusCode = http.StatusOK
 	resp.StatusMessage = response.Success
@@ -165,6 +166,9 @@ func (e *ExternalInterface) updateAggregationSourceWithConnectionMethod(url stri
 		}
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
 	}
+	if connectionMethod.ConnectionMethodType == SNMPConnectionMethodType {
+		return e.updateSNMPAggregationSource(url, updateRequest, hostNameUpdated)
+	}
 	cmVariants := getConnectionMethodVariants(connectionMethod.ConnectionMethodVariant)
 	var data = strings.Split(url, "/redfish/v1/AggregationService/AggregationSources/")
 	uuid := url[strings.LastIndexByte(url, '/')+1:]
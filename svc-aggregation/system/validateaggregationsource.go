@@ -0,0 +1,225 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+// results of the connectivity and authentication checks of an aggregation source
+const (
+	validationReachable   = "Reachable"
+	validationUnreachable = "Unreachable"
+	validationSuccess     = "Success"
+	validationFailed      = "Failed"
+	validationNotVerified = "NotVerified"
+)
+
+// ValidateAggregationSource checks the reachability and the credentials of an aggregation
+// source with the plugin chosen by its connection method, without adding it. When the
+// credentials are valid the manufacturer, model and firmware version of the BMC are reported.
+// Nothing is stored in the DB and no task is created, the result is returned with 200 OK.
+func (e *ExternalInterface) ValidateAggregationSource(req *aggregatorproto.AggregatorRequest) response.RPC {
+	var validateRequest AggregationSource
+	if err := json.Unmarshal(req.RequestBody, &validateRequest); err != nil {
+		errMsg := "unable to parse the validate aggregation source request: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
+	}
+	// Validating the request JSON properties for case sensitive
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, validateRequest)
+	if err != nil {
+		errMsg := "error while validating request parameters: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	} else if invalidProperties != "" {
+		errMsg := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, nil)
+	}
+	if validateRequest.Links == nil || validateRequest.Links.ConnectionMethod == nil {
+		errMsg := "error: mandatory ConnectionMethod block missing in the request"
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"ConnectionMethod"}, nil)
+	}
+	connectionMethodURI := validateRequest.Links.ConnectionMethod.OdataID
+	connectionMethod, dbErr := e.GetConnectionMethod(connectionMethodURI)
+	if dbErr != nil {
+		errMsg := "unable to get connection method id: " + dbErr.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"connectionmethod id", connectionMethodURI}, nil)
+	}
+
+	result := agresponse.ValidateAggregationSourceResponse{
+		HostName:         validateRequest.HostName,
+		ConnectionMethod: connectionMethodURI,
+		Connectivity:     validationUnreachable,
+		Authentication:   validationNotVerified,
+	}
+	if connectionMethod.ConnectionMethodType == SNMPConnectionMethodType {
+		e.validateSNMPAgent(validateRequest, &result)
+	} else if resp := e.validateBMC(validateRequest, connectionMethod, &result); resp.StatusCode != 0 {
+		return resp
+	}
+	log.Info("validated aggregation source " + validateRequest.HostName + ": connectivity " +
+		result.Connectivity + ", authentication " + result.Authentication)
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Header: map[string]string{
+			"Content-type": "application/json; charset=utf-8",
+		},
+		Body: result,
+	}
+}
+
+// validateSNMPAgent polls the SNMP agent, which validates both the reachability and the credentials
+func (e *ExternalInterface) validateSNMPAgent(validateRequest AggregationSource, result *agresponse.ValidateAggregationSourceResponse) {
+	_, credentials := snmpCredentials(validateRequest)
+	if _, err := credentials.Validate(); err != nil {
+		result.Message = "invalid SNMP settings: " + err.Error()
+		return
+	}
+	if _, err := PollSNMPAgent(validateRequest.HostName, credentials); err != nil {
+		result.Message = "unable to poll SNMP agent: " + err.Error()
+		return
+	}
+	result.Connectivity = validationReachable
+	result.Authentication = validationSuccess
+}
+
+// validateBMC calls the validate endpoint of the plugin of the connection method. A non empty
+// response is returned only when the plugin itself could not be used for the validation.
+func (e *ExternalInterface) validateBMC(validateRequest AggregationSource, connectionMethod agmodel.ConnectionMethod, result *agresponse.ValidateAggregationSourceResponse) response.RPC {
+	cmVariants := getConnectionMethodVariants(connectionMethod.ConnectionMethodVariant)
+	result.PluginID = cmVariants.PluginID
+	plugin, errs := e.GetPluginMgrAddr(cmVariants.PluginID)
+	if errs != nil {
+		errMsg := "error while getting plugin data: " + errs.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"plugin", cmVariants.PluginID}, nil)
+	}
+
+	pluginContactRequest := getResourceRequest{
+		ContactClient:   e.ContactClient,
+		GetPluginStatus: e.GetPluginStatus,
+		Plugin:          plugin,
+		StatusPoll:      true,
+	}
+	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		pluginContactRequest.HTTPMethodType = http.MethodPost
+		pluginContactRequest.DeviceInfo = map[string]interface{}{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+		pluginContactRequest.OID = "/ODIM/v1/Sessions"
+		_, token, getResponse, err := contactPlugin(pluginContactRequest, "error while creating the session with the plugin: ")
+		if err != nil {
+			log.Error(err.Error())
+			return common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, err.Error(), getResponse.MsgArgs, nil)
+		}
+		pluginContactRequest.Token = token
+	} else {
+		pluginContactRequest.LoginCredentials = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+	}
+
+	device := agmodel.SaveSystem{
+		ManagerAddress: strings.ToLower(validateRequest.HostName),
+		UserName:       validateRequest.UserName,
+		Password:       []byte(validateRequest.Password),
+		PluginID:       cmVariants.PluginID,
+	}
+	pluginContactRequest.DeviceInfo = device
+	pluginContactRequest.OID = "/ODIM/v1/validate"
+	pluginContactRequest.HTTPMethodType = http.MethodPost
+	_, _, getResponse, err := contactPlugin(pluginContactRequest, "error while trying to authenticate the compute server: ")
+	if err != nil {
+		switch getResponse.StatusCode {
+		case http.StatusServiceUnavailable:
+			// the plugin is not reachable, the BMC could not be validated
+			log.Error(err.Error())
+			return common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, err.Error(), getResponse.MsgArgs, nil)
+		case http.StatusUnauthorized:
+			result.Connectivity = validationReachable
+			result.Authentication = validationFailed
+		}
+		result.Message = err.Error()
+		return response.RPC{}
+	}
+	result.Connectivity = validationReachable
+	result.Authentication = validationSuccess
+
+	// the inventory details are best effort, the validation result is returned without them
+	pluginContactRequest.DeviceInfo = map[string]interface{}{
+		"ManagerAddress": device.ManagerAddress,
+		"UserName":       device.UserName,
+		"Password":       device.Password,
+	}
+	pluginContactRequest.HTTPMethodType = http.MethodGet
+	if system, err := getFirstMember(pluginContactRequest, "/redfish/v1/Systems"); err != nil {
+		log.Error("unable to get the system of " + validateRequest.HostName + ": " + err.Error())
+	} else {
+		result.Manufacturer, _ = system["Manufacturer"].(string)
+		result.Model, _ = system["Model"].(string)
+	}
+	if manager, err := getFirstMember(pluginContactRequest, "/redfish/v1/Managers"); err != nil {
+		log.Error("unable to get the manager of " + validateRequest.HostName + ": " + err.Error())
+	} else {
+		result.FirmwareVersion, _ = manager["FirmwareVersion"].(string)
+	}
+	return response.RPC{}
+}
+
+// getFirstMember returns the first member of the collection read through the plugin
+func getFirstMember(req getResourceRequest, collectionURI string) (map[string]interface{}, error) {
+	req.OID = collectionURI
+	body, _, _, err := contactPlugin(req, "error while trying to get "+collectionURI+": ")
+	if err != nil {
+		return nil, err
+	}
+	var collection struct {
+		Members []agmodel.OdataID `json:"Members"`
+	}
+	if err := json.Unmarshal(body, &collection); err != nil {
+		return nil, err
+	}
+	if len(collection.Members) == 0 || collection.Members[0].OdataID == "" {
+		return nil, fmt.Errorf("no members found in " + collectionURI)
+	}
+	req.OID = collection.Members[0].OdataID
+	body, _, _, err = contactPlugin(req, "error while trying to get "+req.OID+": ")
+	if err != nil {
+		return nil, err
+	}
+	member := make(map[string]interface{})
+	if err := json.Unmarshal(body, &member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
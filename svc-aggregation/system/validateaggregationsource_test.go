@@ -0,0 +1,184 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const validateConnectionMethodURI = "/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73"
+
+func mockValidateContactClient(url, method, token string, odataID string, body interface{}, credentials map[string]string) (*http.Response, error) {
+	respond := func(statusCode int, data string) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(data)),
+		}, nil
+	}
+	if strings.HasPrefix(url, "https://downhost") {
+		return nil, fmt.Errorf("plugin not reachable")
+	}
+	switch {
+	case strings.HasSuffix(url, "/ODIM/v1/validate"):
+		device := body.(agmodel.SaveSystem)
+		switch {
+		case device.ManagerAddress == "10.0.0.2":
+			return respond(http.StatusBadRequest, "unable to connect to the device")
+		case device.UserName != "admin":
+			return respond(http.StatusUnauthorized, "invalid credentials")
+		}
+		return respond(http.StatusOK, `{"ServerIP":"10.0.0.1"}`)
+	case strings.HasSuffix(url, "/v1/Systems"):
+		return respond(http.StatusOK, `{"Members":[{"@odata.id":"/redfish/v1/Systems/1"}]}`)
+	case strings.HasSuffix(url, "/v1/Systems/1"):
+		return respond(http.StatusOK, `{"Manufacturer":"HPE","Model":"ProLiant DL360 Gen10"}`)
+	case strings.HasSuffix(url, "/v1/Managers"):
+		return respond(http.StatusOK, `{"Members":[{"@odata.id":"/redfish/v1/Managers/1"}]}`)
+	case strings.HasSuffix(url, "/v1/Managers/1"):
+		return respond(http.StatusOK, `{"FirmwareVersion":"iLO 5 v2.44"}`)
+	}
+	return respond(http.StatusNotFound, "not found")
+}
+
+func TestExternalInterface_ValidateAggregationSource(t *testing.T) {
+	config.SetUpMockConfig(t)
+	e := &ExternalInterface{
+		ContactClient:       mockValidateContactClient,
+		GetPluginStatus:     GetPluginStatusForTesting,
+		GetConnectionMethod: mockGetConnectionMethod,
+		GetPluginMgrAddr: func(pluginID string) (agmodel.Plugin, *errors.Error) {
+			if pluginID == "ILO_v1.0.0" {
+				return agmodel.Plugin{}, errors.PackError(errors.DBKeyNotFound, "plugin not found")
+			}
+			host := "validatehost"
+			if pluginID == "XAuthPlugin_v1.0.0" {
+				host = "downhost"
+			}
+			return agmodel.Plugin{ID: pluginID, IP: host, Port: "9091", PreferredAuthType: "BasicAuth"}, nil
+		},
+	}
+	request := func(hostName, userName, connectionMethodURI string) *aggregatorproto.AggregatorRequest {
+		body, _ := json.Marshal(AggregationSource{
+			HostName: hostName,
+			UserName: userName,
+			Password: "password",
+			Links:    &Links{ConnectionMethod: &ConnectionMethod{OdataID: connectionMethodURI}},
+		})
+		return &aggregatorproto.AggregatorRequest{RequestBody: body}
+	}
+	tests := []struct {
+		name               string
+		req                *aggregatorproto.AggregatorRequest
+		wantStatusCode     int32
+		wantConnectivity   string
+		wantAuthentication string
+		wantModel          string
+	}{
+		{
+			name:               "valid credentials",
+			req:                request("10.0.0.1", "admin", validateConnectionMethodURI),
+			wantStatusCode:     http.StatusOK,
+			wantConnectivity:   validationReachable,
+			wantAuthentication: validationSuccess,
+			wantModel:          "ProLiant DL360 Gen10",
+		},
+		{
+			name:               "invalid credentials",
+			req:                request("10.0.0.1", "operator", validateConnectionMethodURI),
+			wantStatusCode:     http.StatusOK,
+			wantConnectivity:   validationReachable,
+			wantAuthentication: validationFailed,
+		},
+		{
+			name:               "unreachable BMC",
+			req:                request("10.0.0.2", "admin", validateConnectionMethodURI),
+			wantStatusCode:     http.StatusOK,
+			wantConnectivity:   validationUnreachable,
+			wantAuthentication: validationNotVerified,
+		},
+		{
+			name:           "unknown connection method",
+			req:            request("10.0.0.1", "admin", "/redfish/v1/AggregationService/ConnectionMethods/unknown"),
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "unknown plugin",
+			req:            request("10.0.0.1", "admin", "/redfish/v1/AggregationService/ConnectionMethods/c41cbd97-937d-1b73-c41c-1b7385d39069"),
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "plugin not reachable",
+			req:            request("10.0.0.1", "admin", "/redfish/v1/AggregationService/ConnectionMethods/0a8992dc-8b47-4fe3-b26c-4c34048cf0d2"),
+			wantStatusCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "missing connection method",
+			req:            &aggregatorproto.AggregatorRequest{RequestBody: []byte(`{"HostName":"10.0.0.1","UserName":"admin","Password":"password"}`)},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := e.ValidateAggregationSource(tt.req)
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("ValidateAggregationSource() status code = %v, want %v", resp.StatusCode, tt.wantStatusCode)
+			}
+			if resp.StatusCode != http.StatusOK {
+				return
+			}
+			result := resp.Body.(agresponse.ValidateAggregationSourceResponse)
+			if result.Connectivity != tt.wantConnectivity || result.Authentication != tt.wantAuthentication ||
+				result.Model != tt.wantModel || result.PluginID != "GRF_v1.0.0" {
+				t.Errorf("ValidateAggregationSource() = %+v", result)
+			}
+		})
+	}
+}
+
+func TestExternalInterface_ValidateAggregationSourceFirmwareVersion(t *testing.T) {
+	config.SetUpMockConfig(t)
+	e := &ExternalInterface{
+		ContactClient:       mockValidateContactClient,
+		GetPluginStatus:     GetPluginStatusForTesting,
+		GetConnectionMethod: mockGetConnectionMethod,
+		GetPluginMgrAddr: func(pluginID string) (agmodel.Plugin, *errors.Error) {
+			return agmodel.Plugin{ID: pluginID, IP: "validatehost", Port: "9091", PreferredAuthType: "BasicAuth"}, nil
+		},
+	}
+	body, _ := json.Marshal(AggregationSource{
+		HostName: "10.0.0.1",
+		UserName: "admin",
+		Password: "password",
+		Links:    &Links{ConnectionMethod: &ConnectionMethod{OdataID: validateConnectionMethodURI}},
+	})
+	resp := e.ValidateAggregationSource(&aggregatorproto.AggregatorRequest{RequestBody: body})
+	result, ok := resp.Body.(agresponse.ValidateAggregationSourceResponse)
+	if !ok || result.Manufacturer != "HPE" || result.FirmwareVersion != "iLO 5 v2.44" {
+		t.Errorf("ValidateAggregationSource() = %+v", resp.Body)
+	}
+}
@@ -29,6 +29,7 @@ import (
 // AccountRPCs defines all the RPC methods in account service
 type AccountRPCs struct {
 	GetServiceRPC     func(accountproto.AccountRequest) (*accountproto.AccountResponse, error)
+	UpdateServiceRPC  func(accountproto.UpdateAccountServiceRequest) (*accountproto.AccountResponse, error)
 	CreateRPC         func(accountproto.CreateAccountRequest) (*accountproto.AccountResponse, error)
 	GetAllAccountsRPC func(accountproto.AccountRequest) (*accountproto.AccountResponse, error)
 	GetAccountRPC     func(accountproto.GetAccountRequest) (*accountproto.AccountResponse, error)
@@ -66,7 +67,62 @@ func (a *AccountRPCs) GetAccountService(ctx iris.Context) {
 		return
 	}
 
-	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
+
+// UpdateAccountService defines the UpdateAccountService iris handler.
+// The method extract the session token and the request body
+// and creates the RPC request.
+// After the RPC call the method will feed the response to the iris
+// and gives out a proper response.
+func (a *AccountRPCs) UpdateAccountService(ctx iris.Context) {
+	defer ctx.Next()
+	var req interface{}
+
+	err := ctx.ReadJSON(&req)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the account service update request body: " + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	sessionToken := ctx.Request().Header.Get("X-Auth-Token")
+	if sessionToken == "" {
+		errorMessage := "no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	// Marshalling the req to make account service request
+	// Since account service update request accepts byte stream
+	request, err := json.Marshal(req)
+	updateRequest := accountproto.UpdateAccountServiceRequest{
+		SessionToken: sessionToken,
+		RequestBody:  request,
+	}
+
+	resp, err := a.UpdateServiceRPC(updateRequest)
+	if err != nil && resp == nil {
+		errorMessage := "something went wrong with the RPC calls: " + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
@@ -78,6 +78,15 @@ func mockUpdateAccountRPC(req accountproto.UpdateAccountRequest) (*accountproto.
 	}, nil
 }
 
+func mockUpdateAccountServiceRPC(req accountproto.UpdateAccountServiceRequest) (*accountproto.AccountResponse, error) {
+	if req.SessionToken == "TokenRPC" {
+		return nil, errors.New("RPC Error")
+	}
+	return &accountproto.AccountResponse{
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
 func mockDeleteAccountRPC(req accountproto.DeleteAccountRequest) (*accountproto.AccountResponse, error) {
 	if req.SessionToken == "TokenRPC" {
 		return nil, errors.New("RPC Error")
@@ -88,7 +97,7 @@ func mockDeleteAccountRPC(req accountproto.DeleteAccountRequest) (*accountproto.
 }
 
 func TestAccountRPCs_GetAccountService(t *testing.T) {
-	header["Allow"] = []string{"GET"}
+	header["Allow"] = []string{"GET, PATCH"}
 	defer delete(header, "Allow")
 	var a AccountRPCs
 	a.GetServiceRPC = mockGetAccountServiceRPC
@@ -120,6 +129,35 @@ func TestAccountRPCs_GetAccountServiceWithOutToken(t *testing.T) {
 	).Expect().Status(http.StatusUnauthorized).Headers().Equal(header)
 }
 
+func TestAccountRPCs_UpdateAccountService(t *testing.T) {
+	var a AccountRPCs
+	a.UpdateServiceRPC = mockUpdateAccountServiceRPC
+
+	body := map[string]interface{}{
+		"LDAP": map[string]interface{}{
+			"ServiceEnabled":   true,
+			"ServiceAddresses": []string{"ldaps://10.0.0.1"},
+		},
+	}
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Patch("/AccountService", a.UpdateAccountService)
+
+	e := httptest.New(t, mockApp)
+	e.PATCH(
+		"/redfish/v1/AccountService",
+	).WithHeader("X-Auth-Token", "token").WithJSON(body).Expect().Status(http.StatusOK).Header("Allow").Equal("GET, PATCH")
+	e.PATCH(
+		"/redfish/v1/AccountService",
+	).WithHeader("X-Auth-Token", "").WithJSON(body).Expect().Status(http.StatusUnauthorized)
+	e.PATCH(
+		"/redfish/v1/AccountService",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusBadRequest)
+	e.PATCH(
+		"/redfish/v1/AccountService",
+	).WithHeader("X-Auth-Token", "TokenRPC").WithJSON(body).Expect().Status(http.StatusInternalServerError)
+}
+
 func TestAccountRPCs_CreateAccount(t *testing.T) {
 	var a AccountRPCs
 	a.CreateRPC = mockCreateAccountRPC
@@ -16,8 +16,12 @@
 package handle
 
 import (
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
+	"mime"
 	"net/http"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
@@ -45,6 +49,9 @@ type AggregatorRPCs struct {
 	RemoveElementsFromAggregateRPC          func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	ResetAggregateElementsRPC               func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	SetDefaultBootOrderAggregateElementsRPC func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	SetPowerBudgetOfAggregateRPC            func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	ValidateAggregationSourceRPC            func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	ImportAggregationSourcesRPC             func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetAllConnectionMethodsRPC              func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetConnectionMethodRPC                  func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetResetActionInfoServiceRPC            func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
@@ -269,6 +276,10 @@ func (a *AggregatorRPCs) GetAllAggregationSource(ctx iris.Context) {
 		ctx.JSON(&response.Body)
 		return
 	}
+	query, ok := getCollectionQuery(ctx)
+	if !ok {
+		return
+	}
 	resp, err := a.GetAllAggregationSourceRPC(req)
 	if err != nil {
 		errorMessage := " RPC error:" + err.Error()
@@ -282,8 +293,18 @@ func (a *AggregatorRPCs) GetAllAggregationSource(ctx iris.Context) {
 
 	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	common.SetResponseHeader(ctx, resp.Header)
-	ctx.StatusCode(int(resp.StatusCode))
-	ctx.Write(resp.Body)
+	statusCode, body := applyCollectionQuery(query, resp.StatusCode, resp.Body, func(uri string) (int32, []byte, error) {
+		memberResp, err := a.GetAggregationSourceRPC(aggregatorproto.AggregatorRequest{
+			SessionToken: req.SessionToken,
+			URL:          uri,
+		})
+		if err != nil {
+			return 0, nil, err
+		}
+		return memberResp.StatusCode, memberResp.Body, nil
+	})
+	ctx.StatusCode(int(statusCode))
+	ctx.Write(body)
 }
 
 // GetAggregationSource is the handler for getting  AggregationSource details
@@ -728,6 +749,199 @@ func (a *AggregatorRPCs) SetDefaultBootOrderAggregateElements(ctx iris.Context)
 	ctx.Write(resp.Body)
 }
 
+// SetPowerBudgetOfAggregate is the handler for setting the power budget of an aggregate
+func (a *AggregatorRPCs) SetPowerBudgetOfAggregate(ctx iris.Context) {
+	defer ctx.Next()
+	var req interface{}
+	err := ctx.ReadJSON(&req)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the aggregator request body: " + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	sessionToken := ctx.Request().Header.Get("X-Auth-Token")
+
+	if sessionToken == "" {
+		errorMessage := "no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	// marshalling the req to make aggregator power budget request
+	request, _ := json.Marshal(req)
+
+	powerBudgetRequest := aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		URL:          ctx.Request().RequestURI,
+		RequestBody:  request,
+	}
+
+	resp, err := a.SetPowerBudgetOfAggregateRPC(powerBudgetRequest)
+	if err != nil {
+		errorMessage := "something went wrong with the RPC calls: " + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
+
+// ValidateAggregationSource is the handler for the pre-flight check of an aggregation source
+func (a *AggregatorRPCs) ValidateAggregationSource(ctx iris.Context) {
+	defer ctx.Next()
+	var req interface{}
+	err := ctx.ReadJSON(&req)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the aggregator request body: " + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	sessionToken := ctx.Request().Header.Get("X-Auth-Token")
+
+	if sessionToken == "" {
+		errorMessage := "no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	// marshalling the req to make aggregator validate request
+	request, _ := json.Marshal(req)
+
+	validateRequest := aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		RequestBody:  request,
+	}
+
+	resp, err := a.ValidateAggregationSourceRPC(validateRequest)
+	if err != nil {
+		errorMessage := "something went wrong with the RPC calls: " + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
+
+// ImportAggregationSources is the handler for the bulk import of aggregation sources.
+// The aggregation sources are accepted either as JSON or as CSV with a header row
+// naming the HostName, UserName, Password and ConnectionMethod columns.
+func (a *AggregatorRPCs) ImportAggregationSources(ctx iris.Context) {
+	defer ctx.Next()
+	var req interface{}
+	var err error
+	mediaType, _, _ := mime.ParseMediaType(ctx.GetHeader("Content-Type"))
+	if mediaType == "text/csv" {
+		req, err = readAggregationSourcesCSV(ctx)
+	} else {
+		err = ctx.ReadJSON(&req)
+	}
+	if err != nil {
+		errorMessage := "error while trying to get the aggregation sources from the aggregator request body: " + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	sessionToken := ctx.Request().Header.Get("X-Auth-Token")
+
+	if sessionToken == "" {
+		errorMessage := "no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	// marshalling the req to make aggregator import request
+	request, _ := json.Marshal(req)
+
+	importRequest := aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		RequestBody:  request,
+	}
+
+	resp, err := a.ImportAggregationSourcesRPC(importRequest)
+	if err != nil {
+		errorMessage := "something went wrong with the RPC calls: " + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
+
+// readAggregationSourcesCSV converts the CSV rows of a bulk import into the JSON import request
+func readAggregationSourcesCSV(ctx iris.Context) (interface{}, error) {
+	records, err := csv.NewReader(ctx.Request().Body).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("no aggregation sources found after the header row")
+	}
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"HostName", "UserName", "Password", "ConnectionMethod"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("column %s is missing in the header row", name)
+		}
+	}
+	var sources []interface{}
+	for _, record := range records[1:] {
+		sources = append(sources, map[string]interface{}{
+			"HostName": strings.TrimSpace(record[columns["HostName"]]),
+			"UserName": strings.TrimSpace(record[columns["UserName"]]),
+			"Password": record[columns["Password"]],
+			"Links": map[string]interface{}{
+				"ConnectionMethod": map[string]string{
+					"@odata.id": strings.TrimSpace(record[columns["ConnectionMethod"]]),
+				},
+			},
+		})
+	}
+	return map[string]interface{}{"AggregationSources": sources}, nil
+}
+
 // GetAllConnectionMethods is the handler for get all connection methods
 func (a *AggregatorRPCs) GetAllConnectionMethods(ctx iris.Context) {
 	defer ctx.Next()
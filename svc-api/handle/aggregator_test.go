@@ -673,6 +673,145 @@ func TestSetDefaultBootOrderAggregateElements(t *testing.T) {
 	).WithHeader("X-Auth-Token", "token").WithJSON(aggregateRequest).Expect().Status(http.StatusInternalServerError)
 }
 
+func TestSetPowerBudgetOfAggregate(t *testing.T) {
+	var a AggregatorRPCs
+	a.SetPowerBudgetOfAggregateRPC = testGetAggregateRPCCall
+	var powerBudgetRequest = map[string]interface{}{
+		"LimitInWatts":               1200,
+		"RebalanceIntervalInSeconds": 60,
+	}
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Aggregates/{id}/Actions/Oem/Odim.SetPowerBudget")
+	redfishRoutes.Post("/", a.SetPowerBudgetOfAggregate)
+	test := httptest.New(t, testApp)
+	// test with valid token
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Oem/Odim.SetPowerBudget",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(powerBudgetRequest).Expect().Status(http.StatusOK)
+
+	// test with Invalid token
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Oem/Odim.SetPowerBudget",
+	).WithHeader("X-Auth-Token", "InvalidToken").WithJSON(powerBudgetRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Oem/Odim.SetPowerBudget",
+	).WithHeader("X-Auth-Token", "").WithJSON(powerBudgetRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without request body
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Oem/Odim.SetPowerBudget",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+
+	// test for RPC Error
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Oem/Odim.SetPowerBudget",
+	).WithHeader("X-Auth-Token", "token").WithJSON(powerBudgetRequest).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestValidateAggregationSource(t *testing.T) {
+	var a AggregatorRPCs
+	a.ValidateAggregationSourceRPC = testGetAggregateRPCCall
+	var validateRequest = map[string]interface{}{
+		"HostName": "10.24.0.14",
+		"UserName": "admin",
+		"Password": "password",
+		"Links": map[string]interface{}{
+			"ConnectionMethod": map[string]string{
+				"@odata.id": "/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73",
+			},
+		},
+	}
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Actions/Oem/Odim.ValidateAggregationSource")
+	redfishRoutes.Post("/", a.ValidateAggregationSource)
+	test := httptest.New(t, testApp)
+	// test with valid token
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/Oem/Odim.ValidateAggregationSource",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(validateRequest).Expect().Status(http.StatusOK)
+
+	// test with Invalid token
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/Oem/Odim.ValidateAggregationSource",
+	).WithHeader("X-Auth-Token", "InvalidToken").WithJSON(validateRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/Oem/Odim.ValidateAggregationSource",
+	).WithHeader("X-Auth-Token", "").WithJSON(validateRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without request body
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/Oem/Odim.ValidateAggregationSource",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+
+	// test for RPC Error
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/Oem/Odim.ValidateAggregationSource",
+	).WithHeader("X-Auth-Token", "token").WithJSON(validateRequest).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestImportAggregationSources(t *testing.T) {
+	var a AggregatorRPCs
+	a.ImportAggregationSourcesRPC = testGetAggregateRPCCall
+	var importRequest = map[string]interface{}{
+		"AggregationSources": []map[string]interface{}{
+			{
+				"HostName": "10.24.0.14",
+				"UserName": "admin",
+				"Password": "password",
+				"Links": map[string]interface{}{
+					"ConnectionMethod": map[string]string{
+						"@odata.id": "/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73",
+					},
+				},
+			},
+		},
+	}
+	importCSV := "HostName,UserName,Password,ConnectionMethod\n" +
+		"10.24.0.14,admin,password,/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73\n"
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Actions/Oem/Odim.ImportAggregationSources")
+	redfishRoutes.Post("/", a.ImportAggregationSources)
+	test := httptest.New(t, testApp)
+	// test with valid token
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/Oem/Odim.ImportAggregationSources",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(importRequest).Expect().Status(http.StatusOK)
+
+	// test with CSV request body
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/Oem/Odim.ImportAggregationSources",
+	).WithHeader("X-Auth-Token", "ValidToken").WithHeader("Content-Type", "text/csv").WithBytes([]byte(importCSV)).Expect().Status(http.StatusOK)
+
+	// test with CSV request body without the ConnectionMethod column
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/Oem/Odim.ImportAggregationSources",
+	).WithHeader("X-Auth-Token", "ValidToken").WithHeader("Content-Type", "text/csv").WithBytes([]byte("HostName,UserName,Password\n10.24.0.14,admin,password\n")).Expect().Status(http.StatusBadRequest)
+
+	// test with Invalid token
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/Oem/Odim.ImportAggregationSources",
+	).WithHeader("X-Auth-Token", "InvalidToken").WithJSON(importRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/Oem/Odim.ImportAggregationSources",
+	).WithHeader("X-Auth-Token", "").WithJSON(importRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without request body
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/Oem/Odim.ImportAggregationSources",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+
+	// test for RPC Error
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/Oem/Odim.ImportAggregationSources",
+	).WithHeader("X-Auth-Token", "token").WithJSON(importRequest).Expect().Status(http.StatusInternalServerError)
+}
+
 func TestGetAllConnectionMethods(t *testing.T) {
 	var a AggregatorRPCs
 	a.GetAllConnectionMethodsRPC = testGetAggregateRPCCall
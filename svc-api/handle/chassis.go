@@ -19,6 +19,7 @@ package handle
 import (
 	"encoding/json"
 	"net/http"
+	"path"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	chassisproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/chassis"
@@ -94,6 +95,10 @@ func (chassis *ChassisRPCs) GetChassisCollection(ctx iris.Context) {
 		ctx.JSON(&response.Body)
 		return
 	}
+	query, ok := getCollectionQuery(ctx)
+	if !ok {
+		return
+	}
 	resp, err := chassis.GetChassisCollectionRPC(req)
 	if err != nil {
 		errorMessage := " RPC error:" + err.Error()
@@ -107,8 +112,19 @@ func (chassis *ChassisRPCs) GetChassisCollection(ctx iris.Context) {
 
 	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	common.SetResponseHeader(ctx, resp.Header)
-	ctx.StatusCode(int(resp.StatusCode))
-	ctx.Write(resp.Body)
+	statusCode, body := applyCollectionQuery(query, resp.StatusCode, resp.Body, func(uri string) (int32, []byte, error) {
+		memberResp, err := chassis.GetChassisRPC(chassisproto.GetChassisRequest{
+			SessionToken: req.SessionToken,
+			RequestParam: path.Base(uri),
+			URL:          uri,
+		})
+		if err != nil {
+			return 0, nil, err
+		}
+		return memberResp.StatusCode, memberResp.Body, nil
+	})
+	ctx.StatusCode(int(statusCode))
+	ctx.Write(body)
 }
 
 // GetChassisResource defines the GetChassisResource iris handler.
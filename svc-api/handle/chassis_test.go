@@ -101,6 +101,52 @@ func TestChassisRPCs_GetChassisCollectionWithRPCError(t *testing.T) {
 	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
 }
 
+func mockGetChassisCollectionWithMembers(chassisproto.GetChassisRequest) (*chassisproto.GetChassisResponse, error) {
+	return &chassisproto.GetChassisResponse{
+		StatusCode: http.StatusOK,
+		Body: []byte(`{"@odata.id":"/redfish/v1/Chassis","Members":[{"@odata.id":"/redfish/v1/Chassis/1"},` +
+			`{"@odata.id":"/redfish/v1/Chassis/2"}],"Members@odata.count":2}`),
+	}, nil
+}
+
+func mockGetChassisMember(req chassisproto.GetChassisRequest) (*chassisproto.GetChassisResponse, error) {
+	return &chassisproto.GetChassisResponse{
+		StatusCode: http.StatusOK,
+		Body:       []byte(fmt.Sprintf(`{"@odata.id":"%s","Id":"%s","ChassisType":"RackMount"}`, req.URL, req.RequestParam)),
+	}, nil
+}
+
+func TestChassisRPCs_GetChassisCollectionWithQuery(t *testing.T) {
+	var cha ChassisRPCs
+	cha.GetChassisCollectionRPC = mockGetChassisCollectionWithMembers
+	cha.GetChassisRPC = mockGetChassisMember
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/Chassis")
+	redfishRoutes.Get("/", cha.GetChassisCollection)
+
+	e := httptest.New(t, mockApp)
+	resp := e.GET("/redfish/v1/Chassis/").WithQuery("$top", 1).
+		WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusOK).JSON().Object()
+	resp.Value("Members").Array().Length().Equal(1)
+	resp.Value("Members@odata.count").Equal(2)
+	resp.Value("Members@odata.nextLink").Equal("/redfish/v1/Chassis?$top=1&$skip=1")
+
+	resp = e.GET("/redfish/v1/Chassis/").WithQuery("$filter", "Id eq '2'").WithQuery("$select", "Id").
+		WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusOK).JSON().Object()
+	resp.Value("Members@odata.count").Equal(1)
+	resp.Value("Members").Array().Element(0).Object().Equal(map[string]interface{}{
+		"@odata.id": "/redfish/v1/Chassis/2",
+		"Id":        "2",
+	})
+
+	e.GET("/redfish/v1/Chassis/").WithQuery("$top", "-1").
+		WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusBadRequest)
+	e.GET("/redfish/v1/Chassis/").WithQuery("$filter", "Id eq").
+		WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusBadRequest)
+	e.GET("/redfish/v1/Chassis/").WithQuery("only", "").
+		WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusBadRequest)
+}
+
 func TestChassisRPCs_GetChassis(t *testing.T) {
 	var cha ChassisRPCs
 	cha.GetChassisRPC = mockGetChassisResource
@@ -16,8 +16,11 @@
 package handle
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"path"
 
 	log "github.com/sirupsen/logrus"
 
@@ -35,6 +38,12 @@ type EventsRPCs struct {
 	GetEventSubscriptionRPC            func(eventsproto.EventRequest) (*eventsproto.EventSubResponse, error)
 	DeleteEventSubscriptionRPC         func(eventsproto.EventRequest) (*eventsproto.EventSubResponse, error)
 	GetEventSubscriptionsCollectionRPC func(eventsproto.EventRequest) (*eventsproto.EventSubResponse, error)
+	GetUndeliveredEventsRPC            func(eventsproto.EventRequest) (*eventsproto.EventSubResponse, error)
+	ReplayUndeliveredEventsRPC         func(eventsproto.EventRequest) (*eventsproto.EventSubResponse, error)
+	PurgeUndeliveredEventsRPC          func(eventsproto.EventRequest) (*eventsproto.EventSubResponse, error)
+	UpdateEventServiceRPC              func(eventsproto.EventSubRequest) (*eventsproto.EventSubResponse, error)
+	UpdateEventSubscriptionRPC         func(eventsproto.EventRequest) (*eventsproto.EventSubResponse, error)
+	GetServerSentEventsRPC             func(context.Context, eventsproto.EventRequest, func(*eventsproto.ServerSentEvent) error) error
 }
 
 // GetEventService is the handler to get the Event Service details.
@@ -61,7 +70,7 @@ func (e *EventsRPCs) GetEventService(ctx iris.Context) {
 		return
 	}
 
-	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
@@ -180,7 +189,7 @@ func (e *EventsRPCs) GetEventSubscription(ctx iris.Context) {
 		ctx.JSON(&response)
 		return
 	}
-	ctx.ResponseWriter().Header().Set("Allow", "GET, DELETE")
+	ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH, DELETE")
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
@@ -232,6 +241,10 @@ func (e *EventsRPCs) GetEventSubscriptionsCollection(ctx iris.Context) {
 		return
 	}
 
+	query, ok := getCollectionQuery(ctx)
+	if !ok {
+		return
+	}
 	resp, err := e.GetEventSubscriptionsCollectionRPC(req)
 	if err != nil {
 		log.Error(err.Error())
@@ -243,7 +256,250 @@ func (e *EventsRPCs) GetEventSubscriptionsCollection(ctx iris.Context) {
 	}
 
 	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
+	common.SetResponseHeader(ctx, resp.Header)
+	statusCode, body := applyCollectionQuery(query, resp.StatusCode, resp.Body, func(uri string) (int32, []byte, error) {
+		memberResp, err := e.GetEventSubscriptionRPC(eventsproto.EventRequest{
+			SessionToken:        req.SessionToken,
+			EventSubscriptionID: path.Base(uri),
+		})
+		if err != nil {
+			return 0, nil, err
+		}
+		return memberResp.StatusCode, memberResp.Body, nil
+	})
+	ctx.StatusCode(int(statusCode))
+	ctx.Write(body)
+}
+
+// GetUndeliveredEvents is the handler for getting the events which could not be delivered to the event subscription
+func (e *EventsRPCs) GetUndeliveredEvents(ctx iris.Context) {
+	defer ctx.Next()
+	var req eventsproto.EventRequest
+	req.EventSubscriptionID = ctx.Params().Get("id")
+	req.SessionToken = ctx.Request().Header.Get("X-Auth-Token")
+
+	if req.SessionToken == "" {
+		errorMessage := "no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	resp, err := e.GetUndeliveredEventsRPC(req)
+	if err != nil {
+		log.Error(err.Error())
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+	ctx.ResponseWriter().Header().Set("Allow", "GET, DELETE")
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
+
+// ReplayUndeliveredEvents is the handler for delivering again the undelivered events of the event subscription
+func (e *EventsRPCs) ReplayUndeliveredEvents(ctx iris.Context) {
+	defer ctx.Next()
+	var req eventsproto.EventRequest
+	req.EventSubscriptionID = ctx.Params().Get("id")
+	req.SessionToken = ctx.Request().Header.Get("X-Auth-Token")
+
+	if req.SessionToken == "" {
+		errorMessage := "no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	resp, err := e.ReplayUndeliveredEventsRPC(req)
+	if err != nil {
+		log.Error(err.Error())
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
+
+// PurgeUndeliveredEvents is the handler for deleting the undelivered events of the event subscription
+func (e *EventsRPCs) PurgeUndeliveredEvents(ctx iris.Context) {
+	defer ctx.Next()
+	var req eventsproto.EventRequest
+	req.EventSubscriptionID = ctx.Params().Get("id")
+	req.SessionToken = ctx.Request().Header.Get("X-Auth-Token")
+
+	if req.SessionToken == "" {
+		errorMessage := "no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	resp, err := e.PurgeUndeliveredEventsRPC(req)
+	if err != nil {
+		log.Error(err.Error())
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
+
+// UpdateEventService is the handler to update the delivery retry settings of the Event Service
+func (e *EventsRPCs) UpdateEventService(ctx iris.Context) {
+	defer ctx.Next()
+	var req eventsproto.EventSubRequest
+	var eventServiceReq interface{}
+	err := ctx.ReadJSON(&eventServiceReq)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the event service request body: " + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	req.SessionToken = ctx.Request().Header.Get("X-Auth-Token")
+	if req.SessionToken == "" {
+		errorMessage := "no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+	req.PostBody, _ = json.Marshal(&eventServiceReq)
+
+	resp, err := e.UpdateEventServiceRPC(req)
+	if err != nil {
+		log.Error(err.Error())
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
 }
+
+// UpdateEventSubscription is the handler to update the filters of an event subscription
+func (e *EventsRPCs) UpdateEventSubscription(ctx iris.Context) {
+	defer ctx.Next()
+	var req eventsproto.EventRequest
+	var subscriptionReq interface{}
+	err := ctx.ReadJSON(&subscriptionReq)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the event subscription request body: " + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	req.EventSubscriptionID = ctx.Params().Get("id")
+	req.SessionToken = ctx.Request().Header.Get("X-Auth-Token")
+	if req.SessionToken == "" {
+		errorMessage := "no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+	req.RequestBody, _ = json.Marshal(&subscriptionReq)
+
+	resp, err := e.UpdateEventSubscriptionRPC(req)
+	if err != nil {
+		log.Error(err.Error())
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
+
+// GetServerSentEvents is the handler to stream the events to the client as server-sent events.
+// The events are filtered with the $filter query parameter, and the events after the
+// Last-Event-ID header are streamed first, when the client reconnects.
+func (e *EventsRPCs) GetServerSentEvents(ctx iris.Context) {
+	defer ctx.Next()
+	req := eventsproto.EventRequest{
+		SessionToken: ctx.Request().Header.Get("X-Auth-Token"),
+		Filter:       ctx.URLParam("$filter"),
+		LastEventID:  ctx.Request().Header.Get("Last-Event-ID"),
+	}
+	if req.SessionToken == "" {
+		errorMessage := "no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	var streaming bool
+	err := e.GetServerSentEventsRPC(ctx.Request().Context(), req, func(event *eventsproto.ServerSentEvent) error {
+		if !streaming {
+			// the first message has the status of the request
+			streaming = true
+			if event.StatusCode != http.StatusOK {
+				common.SetResponseHeader(ctx, event.Header)
+				ctx.StatusCode(int(event.StatusCode))
+				ctx.Write(event.Body)
+				return nil
+			}
+			common.SetResponseHeader(ctx, map[string]string{
+				"Content-type": "text/event-stream; charset=utf-8",
+			})
+			ctx.StatusCode(http.StatusOK)
+			ctx.ResponseWriter().Flush()
+			return nil
+		}
+		if _, err := fmt.Fprintf(ctx.ResponseWriter(), "id: %s\ndata: %s\n\n", event.ID, event.Data); err != nil {
+			return err
+		}
+		ctx.ResponseWriter().Flush()
+		return nil
+	})
+	if err != nil {
+		log.Error(err.Error())
+		if streaming {
+			return
+		}
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+	}
+}
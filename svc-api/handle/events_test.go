@@ -14,6 +14,7 @@
 package handle
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -103,7 +104,7 @@ func mockGetEventSubscriptionRPC(req eventsproto.EventRequest) (*eventsproto.Eve
 }
 
 func TestGetEventServiceRPC(t *testing.T) {
-	header["Allow"] = []string{"GET"}
+	header["Allow"] = []string{"GET, PATCH"}
 	defer delete(header, "Allow")
 	var event EventsRPCs
 	event.GetEventServiceRPC = mockGetEventServiceRPC
@@ -312,3 +313,168 @@ func TestGetEventSubscriptionsCollectionRPC(t *testing.T) {
 		"/redfish/v1/EventService/Subscriptions",
 	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
 }
+
+func TestUndeliveredEventsRPC(t *testing.T) {
+	var s EventsRPCs
+	s.GetUndeliveredEventsRPC = mockGetEventSubscriptionRPC
+	s.ReplayUndeliveredEventsRPC = mockGetEventSubscriptionRPC
+	s.PurgeUndeliveredEventsRPC = mockGetEventSubscriptionRPC
+
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Get("/EventService/Subscriptions/{id}/UndeliveredEvents", s.GetUndeliveredEvents)
+	redfishRoutes.Delete("/EventService/Subscriptions/{id}/UndeliveredEvents", s.PurgeUndeliveredEvents)
+	redfishRoutes.Post("/EventService/Subscriptions/{id}/UndeliveredEvents/Actions/UndeliveredEvents.Replay", s.ReplayUndeliveredEvents)
+	e := httptest.New(t, mockApp)
+
+	// test with valid token
+	e.GET(
+		"/redfish/v1/EventService/Subscriptions/1A/UndeliveredEvents",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	e.DELETE(
+		"/redfish/v1/EventService/Subscriptions/1A/UndeliveredEvents",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	e.POST(
+		"/redfish/v1/EventService/Subscriptions/1A/UndeliveredEvents/Actions/UndeliveredEvents.Replay",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+
+	// test with invalid token
+	e.GET(
+		"/redfish/v1/EventService/Subscriptions/1A/UndeliveredEvents",
+	).WithHeader("X-Auth-Token", "InValidToken").Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	e.POST(
+		"/redfish/v1/EventService/Subscriptions/1A/UndeliveredEvents/Actions/UndeliveredEvents.Replay",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+
+	// test with RPC error
+	e.DELETE(
+		"/redfish/v1/EventService/Subscriptions/1A/UndeliveredEvents",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
+
+func TestUpdateEventServiceRPC(t *testing.T) {
+	var event EventsRPCs
+	event.UpdateEventServiceRPC = mockGetEventServiceRPC
+
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/EventService")
+	redfishRoutes.Patch("/", event.UpdateEventService)
+	body := map[string]interface{}{
+		"DeliveryRetryAttempts":        5,
+		"DeliveryRetryIntervalSeconds": 30,
+	}
+	e := httptest.New(t, mockApp)
+	// test with valid token
+	e.PATCH(
+		"/redfish/v1/EventService",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(body).Expect().Status(http.StatusOK)
+
+	// test with Invalid token
+	e.PATCH(
+		"/redfish/v1/EventService",
+	).WithHeader("X-Auth-Token", "InValidToken").WithJSON(body).Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	e.PATCH(
+		"/redfish/v1/EventService",
+	).WithHeader("X-Auth-Token", "").WithJSON(body).Expect().Status(http.StatusUnauthorized)
+
+	// test without RequestBody
+	e.PATCH(
+		"/redfish/v1/EventService",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+
+	// test for RPC Error
+	e.PATCH(
+		"/redfish/v1/EventService",
+	).WithHeader("X-Auth-Token", "token").WithJSON(body).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestUpdateEventSubscriptionRPC(t *testing.T) {
+	var event EventsRPCs
+	event.UpdateEventSubscriptionRPC = mockGetEventSubscriptionRPC
+
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Patch("/EventService/Subscriptions/{id}", event.UpdateEventSubscription)
+	body := map[string]interface{}{
+		"EventTypes": []string{"Alert", "StatusChange"},
+	}
+	e := httptest.New(t, mockApp)
+	// test with valid token
+	e.PATCH(
+		"/redfish/v1/EventService/Subscriptions/1A",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(body).Expect().Status(http.StatusOK)
+
+	// test with Invalid token
+	e.PATCH(
+		"/redfish/v1/EventService/Subscriptions/1A",
+	).WithHeader("X-Auth-Token", "InValidToken").WithJSON(body).Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	e.PATCH(
+		"/redfish/v1/EventService/Subscriptions/1A",
+	).WithHeader("X-Auth-Token", "").WithJSON(body).Expect().Status(http.StatusUnauthorized)
+
+	// test without RequestBody
+	e.PATCH(
+		"/redfish/v1/EventService/Subscriptions/1A",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+
+	// test for RPC Error
+	e.PATCH(
+		"/redfish/v1/EventService/Subscriptions/1A",
+	).WithHeader("X-Auth-Token", "token").WithJSON(body).Expect().Status(http.StatusInternalServerError)
+}
+
+func mockGetServerSentEventsRPC(ctx context.Context, req eventsproto.EventRequest, send func(*eventsproto.ServerSentEvent) error) error {
+	switch req.SessionToken {
+	case "token":
+		return fmt.Errorf("RPC Error")
+	case "InValidToken":
+		return send(&eventsproto.ServerSentEvent{StatusCode: http.StatusUnauthorized})
+	}
+	if req.Filter != "" && req.Filter != "EventType eq Alert" {
+		return send(&eventsproto.ServerSentEvent{StatusCode: http.StatusBadRequest})
+	}
+	send(&eventsproto.ServerSentEvent{StatusCode: http.StatusOK})
+	send(&eventsproto.ServerSentEvent{ID: "2", Data: []byte(`{"Id":"2","Events":[]}`)})
+	if req.LastEventID == "" {
+		send(&eventsproto.ServerSentEvent{ID: "3", Data: []byte(`{"Id":"3","Events":[]}`)})
+	}
+	return nil
+}
+
+func TestGetServerSentEventsRPC(t *testing.T) {
+	var event EventsRPCs
+	event.GetServerSentEventsRPC = mockGetServerSentEventsRPC
+
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/EventService")
+	redfishRoutes.Get("/SSE", event.GetServerSentEvents)
+	e := httptest.New(t, mockApp)
+	// test with valid token
+	resp := e.GET("/redfish/v1/EventService/SSE").WithQuery("$filter", "EventType eq Alert").
+		WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	resp.Header("Content-Type").Equal("text/event-stream; charset=utf-8")
+	resp.Body().Equal("id: 2\ndata: {\"Id\":\"2\",\"Events\":[]}\n\nid: 3\ndata: {\"Id\":\"3\",\"Events\":[]}\n\n")
+
+	// test with Last-Event-ID
+	e.GET("/redfish/v1/EventService/SSE").WithHeader("X-Auth-Token", "ValidToken").WithHeader("Last-Event-ID", "1").
+		Expect().Status(http.StatusOK).Body().Equal("id: 2\ndata: {\"Id\":\"2\",\"Events\":[]}\n\n")
+
+	// test with invalid filter
+	e.GET("/redfish/v1/EventService/SSE").WithQuery("$filter", "Severity eq OK").
+		WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+
+	// test with Invalid token
+	e.GET("/redfish/v1/EventService/SSE").WithHeader("X-Auth-Token", "InValidToken").Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	e.GET("/redfish/v1/EventService/SSE").WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+
+	// test for RPC Error
+	e.GET("/redfish/v1/EventService/SSE").WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
@@ -93,6 +93,9 @@ func getService(microServices []string, uuid string) models.ServiceRoot {
 		case "TaskService":
 			serviceRoot.Tasks = &models.Service{OdataID: servicePath}
 
+		case "JobService":
+			serviceRoot.JobService = &models.Service{OdataID: servicePath}
+
 		case "AggregationService":
 			serviceRoot.AggregationService = &models.Service{OdataID: servicePath}
 		case "Fabrics":
@@ -113,6 +116,9 @@ func getService(microServices []string, uuid string) models.ServiceRoot {
 		case "LicenseService":
 			serviceRoot.LicenseService = &models.Service{OdataID: servicePath}
 
+		case "CertificateService":
+			serviceRoot.CertificateService = &models.Service{OdataID: servicePath}
+
 		}
 	}
 
@@ -239,6 +245,29 @@ func GetMetadata(ctx iris.Context) {
 					models.Include{Namespace: "BootOptionCollection"},
 				},
 			},
+			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/Certificate_v1.xml",
+				TopInclude: []models.Include{
+					models.Include{Namespace: "Certificate"},
+					models.Include{Namespace: "Certificate.v1_2_4"},
+				},
+			},
+			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/CertificateCollection_v1.xml",
+				TopInclude: []models.Include{
+					models.Include{Namespace: "CertificateCollection"},
+				},
+			},
+			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/CertificateLocations_v1.xml",
+				TopInclude: []models.Include{
+					models.Include{Namespace: "CertificateLocations"},
+					models.Include{Namespace: "CertificateLocations.v1_0_2"},
+				},
+			},
+			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/CertificateService_v1.xml",
+				TopInclude: []models.Include{
+					models.Include{Namespace: "CertificateService"},
+					models.Include{Namespace: "CertificateService.v1_0_2"},
+				},
+			},
 			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/Chassis_v1.xml",
 				TopInclude: []models.Include{
 					models.Include{Namespace: "Chassis"},
@@ -295,6 +324,12 @@ func GetMetadata(ctx iris.Context) {
 					models.Include{Namespace: "EndpointCollection"},
 				},
 			},
+			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/EnvironmentMetrics_v1.xml",
+				TopInclude: []models.Include{
+					models.Include{Namespace: "EnvironmentMetrics"},
+					models.Include{Namespace: "EnvironmentMetrics.v1_2_0"},
+				},
+			},
 			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/EthernetInterface_v1.xml",
 				TopInclude: []models.Include{
 					models.Include{Namespace: "EthernetInterface"},
@@ -334,6 +369,17 @@ func GetMetadata(ctx iris.Context) {
 					models.Include{Namespace: "FabricCollection"},
 				},
 			},
+			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/Fan_v1.xml",
+				TopInclude: []models.Include{
+					models.Include{Namespace: "Fan"},
+					models.Include{Namespace: "Fan.v1_1_1"},
+				},
+			},
+			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/FanCollection_v1.xml",
+				TopInclude: []models.Include{
+					models.Include{Namespace: "FanCollection"},
+				},
+			},
 			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/HostInterface_v1.xml",
 				TopInclude: []models.Include{
 					models.Include{Namespace: "HostInterface"},
@@ -350,6 +396,23 @@ func GetMetadata(ctx iris.Context) {
 					models.Include{Namespace: "IPAddresses"},
 				},
 			},
+			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/Job_v1.xml",
+				TopInclude: []models.Include{
+					models.Include{Namespace: "Job"},
+					models.Include{Namespace: "Job.v1_2_0"},
+				},
+			},
+			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/JobCollection_v1.xml",
+				TopInclude: []models.Include{
+					models.Include{Namespace: "JobCollection"},
+				},
+			},
+			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/JobService_v1.xml",
+				TopInclude: []models.Include{
+					models.Include{Namespace: "JobService"},
+					models.Include{Namespace: "JobService.v1_0_3"},
+				},
+			},
 			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/LogEntry_v1.xml",
 				TopInclude: []models.Include{
 					models.Include{Namespace: "LogEntry"},
@@ -489,6 +552,23 @@ func GetMetadata(ctx iris.Context) {
 					models.Include{Namespace: "Power.v1_7_1"},
 				},
 			},
+			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/PowerSubsystem_v1.xml",
+				TopInclude: []models.Include{
+					models.Include{Namespace: "PowerSubsystem"},
+					models.Include{Namespace: "PowerSubsystem.v1_1_0"},
+				},
+			},
+			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/PowerSupply_v1.xml",
+				TopInclude: []models.Include{
+					models.Include{Namespace: "PowerSupply"},
+					models.Include{Namespace: "PowerSupply.v1_3_0"},
+				},
+			},
+			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/PowerSupplyCollection_v1.xml",
+				TopInclude: []models.Include{
+					models.Include{Namespace: "PowerSupplyCollection"},
+				},
+			},
 			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/PrivilegeRegistry_v1.xml",
 				TopInclude: []models.Include{
 					models.Include{Namespace: "PrivilegeRegistry"},
@@ -629,6 +709,12 @@ func GetMetadata(ctx iris.Context) {
 					models.Include{Namespace: "Thermal.v1_7_1"},
 				},
 			},
+			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/ThermalSubsystem_v1.xml",
+				TopInclude: []models.Include{
+					models.Include{Namespace: "ThermalSubsystem"},
+					models.Include{Namespace: "ThermalSubsystem.v1_0_0"},
+				},
+			},
 			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/UpdateService_v1.xml",
 				TopInclude: []models.Include{
 					models.Include{Namespace: "UpdateService"},
@@ -1073,7 +1159,7 @@ func AsMethodNotAllowed(ctx iris.Context) {
 	id := ctx.Params().Get("id")
 	switch path {
 	case "/redfish/v1/AccountService":
-		ctx.ResponseWriter().Header().Set("Allow", "GET")
+		ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
 	case "/redfish/v1/AccountService/Accounts":
 		ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	case "/redfish/v1/AccountService/Accounts/" + id:
@@ -1189,9 +1275,30 @@ func LicenseMethodNotAllowed(ctx iris.Context) {
 	path := url.Path
 
 	// Extend switch case, when each path, requires different handling
+	id := ctx.Params().Get("id")
 	switch path {
 	case "/redfish/v1/LicenseService/Licenses":
 		ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
+	case "/redfish/v1/LicenseService/Licenses/" + id:
+		ctx.ResponseWriter().Header().Set("Allow", "GET, DELETE")
+	default:
+		ctx.ResponseWriter().Header().Set("Allow", "GET")
+	}
+
+	fillMethodNotAllowedErrorResponse(ctx)
+}
+
+// CertificateServiceMethodNotAllowed holds builds reponse for the unallowed http operation on CertificateService URLs and returns 405 error.
+func CertificateServiceMethodNotAllowed(ctx iris.Context) {
+	defer ctx.Next()
+	url := ctx.Request().URL
+	path := url.Path
+
+	// Extend switch case, when each path, requires different handling
+	switch path {
+	case "/redfish/v1/CertificateService/Actions/CertificateService.GenerateCSR",
+		"/redfish/v1/CertificateService/Actions/CertificateService.ReplaceCertificate":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	default:
 		ctx.ResponseWriter().Header().Set("Allow", "GET")
 	}
@@ -1227,7 +1334,28 @@ func ManagersMethodNotAllowed(ctx iris.Context) {
 // TsMethodNotAllowed holds builds reponse for the unallowed http operation on Task Service URLs and returns 405 error.
 func TsMethodNotAllowed(ctx iris.Context) {
 	defer ctx.Next()
-	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	switch ctx.Request().URL.Path {
+	case "/redfish/v1/TaskService", "/redfish/v1/TaskService/":
+		ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
+	default:
+		ctx.ResponseWriter().Header().Set("Allow", "GET")
+	}
+	fillMethodNotAllowedErrorResponse(ctx)
+}
+
+// JsMethodNotAllowed holds builds response for the unallowed http operation on Job Service URLs and returns 405 error.
+func JsMethodNotAllowed(ctx iris.Context) {
+	defer ctx.Next()
+	path := ctx.Request().URL.Path
+	id := ctx.Params().Get("id")
+	switch path {
+	case "/redfish/v1/JobService/Jobs", "/redfish/v1/JobService/Jobs/":
+		ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
+	case "/redfish/v1/JobService/Jobs/" + id:
+		ctx.ResponseWriter().Header().Set("Allow", "GET, DELETE")
+	default:
+		ctx.ResponseWriter().Header().Set("Allow", "GET")
+	}
 	fillMethodNotAllowedErrorResponse(ctx)
 }
 
@@ -1263,15 +1391,22 @@ func EvtMethodNotAllowed(ctx iris.Context) {
 	defer ctx.Next()
 	url := ctx.Request().URL
 	path := url.Path
+	id := ctx.Params().Get("id")
 
 	// Extend switch case, when each path, requires different handling
 	switch path {
 	case "/redfish/v1/EventService":
-		ctx.ResponseWriter().Header().Set("Allow", "GET")
+		ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
 	case "/redfish/v1/EventService/Actions":
 		ctx.ResponseWriter().Header().Set("Allow", "")
+	case "/redfish/v1/EventService/SSE":
+		ctx.ResponseWriter().Header().Set("Allow", "GET")
 	case "/redfish/v1/EventService/Actions/EventService.SubmitTestEvent":
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/EventService/Subscriptions/" + id + "/UndeliveredEvents":
+		ctx.ResponseWriter().Header().Set("Allow", "GET, DELETE")
+	case "/redfish/v1/EventService/Subscriptions/" + id + "/UndeliveredEvents/Actions/UndeliveredEvents.Replay":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	}
 	fillMethodNotAllowedErrorResponse(ctx)
 }
@@ -1289,6 +1424,10 @@ func AggMethodNotAllowed(ctx iris.Context) {
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	case "/redfish/v1/AggregationService/Actions/AggregationService.Reset":
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Actions/Oem/Odim.ValidateAggregationSource":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Actions/Oem/Odim.ImportAggregationSources":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	case "/redfish/v1/AggregationService/AggregationSources":
 		ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	case "/redfish/v1/AggregationService/AggregationSources/" + id:
@@ -1348,9 +1487,9 @@ func RoleMethodNotAllowed(ctx iris.Context) {
 	path := url.Path
 	id := ctx.Params().Get("id")
 	switch path {
-	case "/redfish/v1/Roles":
+	case "/redfish/v1/AccountService/Roles":
 		ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
-	case "/redfish/v1/Roles/" + id:
+	case "/redfish/v1/AccountService/Roles/" + id:
 		ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH, DELETE")
 	}
 	fillMethodNotAllowedErrorResponse(ctx)
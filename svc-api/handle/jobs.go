@@ -0,0 +1,121 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package handle
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	iris "github.com/kataras/iris/v12"
+)
+
+// JobRPCs defines all the RPC methods in job service
+type JobRPCs struct {
+	GetJobServiceRPC    func(req taskproto.JobRequest) (*taskproto.TaskResponse, error)
+	GetJobCollectionRPC func(req taskproto.JobRequest) (*taskproto.TaskResponse, error)
+	GetJobRPC           func(req taskproto.JobRequest) (*taskproto.TaskResponse, error)
+	CreateJobRPC        func(req taskproto.JobRequest) (*taskproto.TaskResponse, error)
+	DeleteJobRPC        func(req taskproto.JobRequest) (*taskproto.TaskResponse, error)
+}
+
+// GetJobService is the handler to get the JobService details
+func (j *JobRPCs) GetJobService(ctx iris.Context) {
+	defer ctx.Next()
+	req := taskproto.JobRequest{
+		SessionToken: ctx.Request().Header.Get("X-Auth-Token"),
+	}
+	j.writeJobResponse(ctx, j.GetJobServiceRPC, req, "GET")
+}
+
+// GetJobCollection is the handler to get the scheduled jobs
+func (j *JobRPCs) GetJobCollection(ctx iris.Context) {
+	defer ctx.Next()
+	req := taskproto.JobRequest{
+		SessionToken: ctx.Request().Header.Get("X-Auth-Token"),
+	}
+	j.writeJobResponse(ctx, j.GetJobCollectionRPC, req, "GET, POST")
+}
+
+// GetJob is the handler to get the scheduled job
+func (j *JobRPCs) GetJob(ctx iris.Context) {
+	defer ctx.Next()
+	req := taskproto.JobRequest{
+		JobID:        ctx.Params().Get("id"),
+		SessionToken: ctx.Request().Header.Get("X-Auth-Token"),
+	}
+	j.writeJobResponse(ctx, j.GetJobRPC, req, "GET, DELETE")
+}
+
+// CreateJob is the handler to schedule a job
+func (j *JobRPCs) CreateJob(ctx iris.Context) {
+	defer ctx.Next()
+	var req interface{}
+	err := ctx.ReadJSON(&req)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the create job request body: " + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.JSON(&response.Body)
+		return
+	}
+	request, _ := json.Marshal(req)
+	jobRequest := taskproto.JobRequest{
+		SessionToken: ctx.Request().Header.Get("X-Auth-Token"),
+		RequestBody:  request,
+	}
+	j.writeJobResponse(ctx, j.CreateJobRPC, jobRequest, "GET, POST")
+}
+
+// DeleteJob is the handler to remove the scheduled job
+func (j *JobRPCs) DeleteJob(ctx iris.Context) {
+	defer ctx.Next()
+	req := taskproto.JobRequest{
+		JobID:        ctx.Params().Get("id"),
+		SessionToken: ctx.Request().Header.Get("X-Auth-Token"),
+	}
+	j.writeJobResponse(ctx, j.DeleteJobRPC, req, "GET, DELETE")
+}
+
+func (j *JobRPCs) writeJobResponse(ctx iris.Context, rpcCall func(taskproto.JobRequest) (*taskproto.TaskResponse, error), req taskproto.JobRequest, allow string) {
+	if req.SessionToken == "" {
+		errorMessage := "error: no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+	resp, err := rpcCall(req)
+	if err != nil {
+		errorMessage := "RPC error: " + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+	ctx.ResponseWriter().Header().Set("Allow", allow)
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
@@ -0,0 +1,96 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package handle
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
+	iris "github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
+)
+
+func mockJobRPC(req taskproto.JobRequest) (*taskproto.TaskResponse, error) {
+	switch {
+	case req.SessionToken == "token":
+		return nil, fmt.Errorf("RPC Error")
+	case req.JobID == "invalidID":
+		return &taskproto.TaskResponse{
+			StatusCode:    http.StatusNotFound,
+			StatusMessage: "ResourceNotFound",
+			Body:          []byte(`{"Response":"ResourceNotFound"}`),
+		}, nil
+	}
+	return &taskproto.TaskResponse{
+		StatusCode:    http.StatusOK,
+		StatusMessage: "Success",
+		Body:          []byte(`{"Response":"Success"}`),
+	}, nil
+}
+
+func mockCreateJobRPC(req taskproto.JobRequest) (*taskproto.TaskResponse, error) {
+	if req.SessionToken == "token" {
+		return nil, fmt.Errorf("RPC Error")
+	}
+	return &taskproto.TaskResponse{
+		StatusCode:    http.StatusCreated,
+		StatusMessage: "Created",
+		Header:        map[string]string{"Location": "/redfish/v1/JobService/Jobs/1"},
+		Body:          []byte(`{"Response":"Created"}`),
+	}, nil
+}
+
+func TestJobService(t *testing.T) {
+	js := JobRPCs{
+		GetJobServiceRPC:    mockJobRPC,
+		GetJobCollectionRPC: mockJobRPC,
+		GetJobRPC:           mockJobRPC,
+		CreateJobRPC:        mockCreateJobRPC,
+		DeleteJobRPC:        mockJobRPC,
+	}
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/JobService")
+	redfishRoutes.SetRegisterRule(iris.RouteSkip)
+	redfishRoutes.Get("/", js.GetJobService)
+	redfishRoutes.Get("/Jobs", js.GetJobCollection)
+	redfishRoutes.Post("/Jobs", js.CreateJob)
+	redfishRoutes.Get("/Jobs/{id}", js.GetJob)
+	redfishRoutes.Delete("/Jobs/{id}", js.DeleteJob)
+	redfishRoutes.Any("/Jobs/{id}", JsMethodNotAllowed)
+	test := httptest.New(t, mockApp)
+
+	test.GET("/redfish/v1/JobService").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET("/redfish/v1/JobService").Expect().Status(http.StatusUnauthorized)
+	test.GET("/redfish/v1/JobService").WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+	test.GET("/redfish/v1/JobService/Jobs").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET("/redfish/v1/JobService/Jobs/1").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET("/redfish/v1/JobService/Jobs/invalidID").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusNotFound)
+	test.DELETE("/redfish/v1/JobService/Jobs/1").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.PATCH("/redfish/v1/JobService/Jobs/1").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusMethodNotAllowed).
+		Header("Allow").Equal("GET, DELETE")
+
+	body := map[string]interface{}{
+		"Payload": map[string]string{
+			"TargetUri": "/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset",
+			"JsonBody":  `{"ResetType":"ForceRestart"}`,
+		},
+	}
+	test.POST("/redfish/v1/JobService/Jobs").WithHeader("X-Auth-Token", "ValidToken").WithJSON(body).Expect().
+		Status(http.StatusCreated).Header("Location").Equal("/redfish/v1/JobService/Jobs/1")
+	test.POST("/redfish/v1/JobService/Jobs").WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte("{")).Expect().Status(http.StatusBadRequest)
+	test.POST("/redfish/v1/JobService/Jobs").WithHeader("X-Auth-Token", "token").WithJSON(body).Expect().Status(http.StatusInternalServerError)
+}
@@ -32,6 +32,8 @@ type LicenseRPCs struct {
 	GetLicenseCollectionRPC  func(req licenseproto.GetLicenseRequest) (*licenseproto.GetLicenseResponse, error)
 	GetLicenseResourceRPC    func(req licenseproto.GetLicenseResourceRequest) (*licenseproto.GetLicenseResponse, error)
 	InstallLicenseServiceRPC func(req licenseproto.InstallLicenseRequest) (*licenseproto.GetLicenseResponse, error)
+	DeleteLicenseRPC         func(req licenseproto.GetLicenseResourceRequest) (*licenseproto.GetLicenseResponse, error)
+	GetLicenseReportRPC      func(req licenseproto.GetLicenseRequest) (*licenseproto.GetLicenseResponse, error)
 }
 
 func (l *LicenseRPCs) GetLicenseService(ctx iris.Context) {
@@ -120,7 +122,7 @@ func (l *LicenseRPCs) GetLicenseResource(ctx iris.Context) {
 		return
 	}
 
-	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	ctx.ResponseWriter().Header().Set("Allow", "GET, DELETE")
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
@@ -177,3 +179,96 @@ func (l *LicenseRPCs) InstallLicenseService(ctx iris.Context) {
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
 }
+
+// DeleteLicense deletes the license from the manager it is installed on
+func (l *LicenseRPCs) DeleteLicense(ctx iris.Context) {
+	defer ctx.Next()
+	req := licenseproto.GetLicenseResourceRequest{
+		SessionToken: ctx.Request().Header.Get("X-Auth-Token"),
+		URL:          ctx.Request().URL.Path,
+		ResourceID:   ctx.Params().Get("id"),
+	}
+	if req.SessionToken == "" {
+		errorMessage := "error: no X-Auth-Token found in request header"
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+	resp, err := l.DeleteLicenseRPC(req)
+	if err != nil {
+		errorMessage := "error:  RPC error:" + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
+
+// GetLicenseReport fetches the licenses of all the managers with their expiry,
+// $filter, $select, $top and $skip are applied on the license entries of the report
+func (l *LicenseRPCs) GetLicenseReport(ctx iris.Context) {
+	defer ctx.Next()
+	req := licenseproto.GetLicenseRequest{
+		SessionToken: ctx.Request().Header.Get("X-Auth-Token"),
+		URL:          ctx.Request().URL.Path,
+	}
+	if req.SessionToken == "" {
+		errorMessage := "error: no X-Auth-Token found in request header"
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+	query, ok := getCollectionQuery(ctx)
+	if !ok {
+		return
+	}
+	resp, err := l.GetLicenseReportRPC(req)
+	if err != nil {
+		errorMessage := "error:  RPC error:" + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	common.SetResponseHeader(ctx, resp.Header)
+	statusCode, body := applyCollectionQuery(query, resp.StatusCode, resp.Body, licenseReportEntries(resp.Body))
+	ctx.StatusCode(int(statusCode))
+	ctx.Write(body)
+}
+
+// licenseReportEntries returns the function to get the license entries of the report for the
+// collection query, the members of the report are the entries themselves
+func licenseReportEntries(body []byte) func(uri string) (int32, []byte, error) {
+	var report struct {
+		Members []json.RawMessage `json:"Members"`
+	}
+	json.Unmarshal(body, &report)
+	entries := make(map[string][]byte, len(report.Members))
+	for _, member := range report.Members {
+		var entry struct {
+			OdataID string `json:"@odata.id"`
+		}
+		if err := json.Unmarshal(member, &entry); err == nil {
+			entries[entry.OdataID] = member
+		}
+	}
+	return func(uri string) (int32, []byte, error) {
+		entry, ok := entries[uri]
+		if !ok {
+			return http.StatusNotFound, nil, nil
+		}
+		return http.StatusOK, entry, nil
+	}
+}
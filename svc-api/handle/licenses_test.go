@@ -100,6 +100,39 @@ func testInstallLicenseService(req licenseproto.InstallLicenseRequest) (*license
 	return response, nil
 }
 
+func testDeleteLicense(req licenseproto.GetLicenseResourceRequest) (*licenseproto.GetLicenseResponse, error) {
+	var response = &licenseproto.GetLicenseResponse{}
+	if req.SessionToken == "ValidToken" && req.ResourceID == "uuid.1.1" {
+		response = &licenseproto.GetLicenseResponse{
+			StatusCode: 204,
+		}
+	} else if req.SessionToken == "ValidToken" {
+		response = &licenseproto.GetLicenseResponse{
+			StatusCode:    404,
+			StatusMessage: "ResourceNotFound", Body: []byte(`{"Response":"ResourceNotFound"}`),
+		}
+	} else if req.SessionToken == "token" {
+		return &licenseproto.GetLicenseResponse{}, errors.New("Unable to RPC Call")
+	}
+	return response, nil
+}
+
+func testLicenseReport(req licenseproto.GetLicenseRequest) (*licenseproto.GetLicenseResponse, error) {
+	var response = &licenseproto.GetLicenseResponse{}
+	if req.SessionToken == "ValidToken" {
+		response = &licenseproto.GetLicenseResponse{
+			StatusCode:    200,
+			StatusMessage: "Success",
+			Body: []byte(`{"@odata.id":"/redfish/v1/LicenseService/Oem/Odim/LicenseReport","Members":[` +
+				`{"@odata.id":"/redfish/v1/LicenseService/Licenses/uuid.1.1","ExpiryState":"Active"},` +
+				`{"@odata.id":"/redfish/v1/LicenseService/Licenses/uuid.1.2","ExpiryState":"Expired"}],"Members@odata.count":2}`),
+		}
+	} else if req.SessionToken == "token" {
+		return &licenseproto.GetLicenseResponse{}, errors.New("Unable to RPC Call")
+	}
+	return response, nil
+}
+
 func TestGetLicenseService(t *testing.T) {
 	header["Allow"] = []string{"GET"}
 	defer delete(header, "Allow")
@@ -141,7 +174,7 @@ func TestGetLicenseCollection(t *testing.T) {
 }
 
 func TestGetLicenseResource(t *testing.T) {
-	header["Allow"] = []string{"GET"}
+	header["Allow"] = []string{"GET, DELETE"}
 	defer delete(header, "Allow")
 	var a LicenseRPCs
 	a.GetLicenseResourceRPC = testLicenseResource
@@ -186,3 +219,48 @@ func TestInstallLicenseService(t *testing.T) {
 		"LicenseString":     "XYZ",
 	}).Expect().Status(http.StatusInternalServerError)
 }
+
+func TestDeleteLicense(t *testing.T) {
+	var a LicenseRPCs
+	a.DeleteLicenseRPC = testDeleteLicense
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/LicenseService")
+	redfishRoutes.Delete("/Licenses/{id}", a.DeleteLicense)
+	test := httptest.New(t, testApp)
+	test.DELETE(
+		"/redfish/v1/LicenseService/Licenses/uuid.1.1",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusNoContent)
+	test.DELETE(
+		"/redfish/v1/LicenseService/Licenses/uuid.1.2",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusNotFound)
+	test.DELETE(
+		"/redfish/v1/LicenseService/Licenses/uuid.1.1",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.DELETE(
+		"/redfish/v1/LicenseService/Licenses/uuid.1.1",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
+
+func TestGetLicenseReport(t *testing.T) {
+	header["Allow"] = []string{"GET"}
+	defer delete(header, "Allow")
+	var a LicenseRPCs
+	a.GetLicenseReportRPC = testLicenseReport
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/LicenseService")
+	redfishRoutes.Get("/Oem/Odim/LicenseReport", a.GetLicenseReport)
+	test := httptest.New(t, testApp)
+	test.GET(
+		"/redfish/v1/LicenseService/Oem/Odim/LicenseReport",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK).Headers().Equal(header)
+	test.GET(
+		"/redfish/v1/LicenseService/Oem/Odim/LicenseReport",
+	).WithHeader("X-Auth-Token", "ValidToken").WithQuery("$filter", "ExpiryState eq 'Expired'").
+		Expect().Status(http.StatusOK).JSON().Object().Value("Members@odata.count").Equal(1)
+	test.GET(
+		"/redfish/v1/LicenseService/Oem/Odim/LicenseReport",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.GET(
+		"/redfish/v1/LicenseService/Oem/Odim/LicenseReport",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
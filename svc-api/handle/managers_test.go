@@ -523,3 +523,53 @@ func TestUpdateRemoteAccountService(t *testing.T) {
 		"/redfish/v1/Managers/1A/RemoteAccountService/Accounts",
 	).WithHeader("X-Auth-Token", "").WithJSON(payload).Expect().Status(http.StatusUnauthorized)
 }
+
+func mockCertificateServiceRequest(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error) {
+	switch req.SessionToken {
+	case "ValidToken":
+		return &managersproto.ManagerResponse{
+			StatusCode:    200,
+			StatusMessage: "Success",
+			Body:          []byte(`{"Response":"Success"}`),
+		}, nil
+	case "InvalidToken":
+		return &managersproto.ManagerResponse{
+			StatusCode:    401,
+			StatusMessage: "Unauthorized",
+			Body:          []byte(`{"Response":"Unauthorized"}`),
+		}, nil
+	}
+	return nil, fmt.Errorf("RPC Error")
+}
+
+func TestCertificateService(t *testing.T) {
+	var mgr ManagersRPCs
+	mgr.GetCertificateServiceRPC = mockCertificateServiceRequest
+	mgr.GetCertificateLocationsRPC = mockCertificateServiceRequest
+	mgr.GenerateCSRRPC = mockCertificateServiceRequest
+	mgr.ReplaceCertificateRPC = mockCertificateServiceRequest
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/CertificateService")
+	redfishRoutes.Get("/", mgr.GetCertificateService)
+	redfishRoutes.Get("/CertificateLocations", mgr.GetCertificateLocations)
+	redfishRoutes.Post("/Actions/CertificateService.GenerateCSR", mgr.GenerateCSR)
+	redfishRoutes.Post("/Actions/CertificateService.ReplaceCertificate", mgr.ReplaceCertificate)
+	test := httptest.New(t, mockApp)
+
+	for _, uri := range []string{"/redfish/v1/CertificateService", "/redfish/v1/CertificateService/CertificateLocations"} {
+		test.GET(uri).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+		test.GET(uri).WithHeader("X-Auth-Token", "InvalidToken").Expect().Status(http.StatusUnauthorized)
+		test.GET(uri).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+		test.GET(uri).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+	}
+	for _, uri := range []string{
+		"/redfish/v1/CertificateService/Actions/CertificateService.GenerateCSR",
+		"/redfish/v1/CertificateService/Actions/CertificateService.ReplaceCertificate",
+	} {
+		test.POST(uri).WithHeader("X-Auth-Token", "ValidToken").WithJSON(map[string]string{"CommonName": "odim"}).Expect().Status(http.StatusOK)
+		test.POST(uri).WithHeader("X-Auth-Token", "InvalidToken").WithJSON(map[string]string{"CommonName": "odim"}).Expect().Status(http.StatusUnauthorized)
+		test.POST(uri).WithHeader("X-Auth-Token", "").WithJSON(map[string]string{"CommonName": "odim"}).Expect().Status(http.StatusUnauthorized)
+		test.POST(uri).WithHeader("X-Auth-Token", "token").WithJSON(map[string]string{"CommonName": "odim"}).Expect().Status(http.StatusInternalServerError)
+		test.POST(uri).WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte(`{"CommonName":`)).Expect().Status(http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,84 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package handle
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	errResponse "github.com/ODIM-Project/ODIM/lib-utilities/response"
+	iris "github.com/kataras/iris/v12"
+)
+
+// getCollectionQuery parses the Redfish query parameters of the collection request.
+// For an invalid query, the error response is written and false is returned.
+func getCollectionQuery(ctx iris.Context) (*common.ODataQuery, bool) {
+	query, err := common.ParseODataQuery(ctx.Request().URL.RawQuery)
+	if err != nil {
+		log.Error("invalid query in the request " + ctx.Request().RequestURI + ": " + err.Error())
+		resp := err.Response()
+		common.SetResponseHeader(ctx, resp.Header)
+		ctx.StatusCode(int(resp.StatusCode))
+		ctx.JSON(&resp.Body)
+		return nil, false
+	}
+	return query, true
+}
+
+// applyCollectionQuery applies the query on the collection returned by the service.
+// getMember fetches the member resource from the service and is used
+// only if the query has $filter, $select or $expand, it is called
+// concurrently and, without $filter, only for the members of the page.
+func applyCollectionQuery(query *common.ODataQuery, statusCode int32, body []byte, getMember func(uri string) (int32, []byte, error)) (int32, []byte) {
+	if statusCode != http.StatusOK || query.IsEmpty() {
+		return statusCode, body
+	}
+	var collection map[string]interface{}
+	if err := json.Unmarshal(body, &collection); err != nil {
+		return collectionQueryError("error while trying to unmarshal the collection: " + err.Error())
+	}
+	err := query.ApplyToCollection(collection, func(uri string) (map[string]interface{}, error) {
+		memberStatusCode, memberBody, err := getMember(uri)
+		if err != nil {
+			return nil, err
+		}
+		if memberStatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		var member map[string]interface{}
+		if err := json.Unmarshal(memberBody, &member); err != nil {
+			return nil, err
+		}
+		return member, nil
+	})
+	if err != nil {
+		return collectionQueryError("error while trying to apply the query on the collection: " + err.Error())
+	}
+	data, err := json.Marshal(collection)
+	if err != nil {
+		return collectionQueryError("error while trying to marshal the collection: " + err.Error())
+	}
+	return statusCode, data
+}
+
+func collectionQueryError(errorMessage string) (int32, []byte) {
+	log.Error(errorMessage)
+	resp := common.GeneralError(http.StatusInternalServerError, errResponse.InternalError, errorMessage, nil, nil)
+	data, _ := json.Marshal(resp.Body)
+	return resp.StatusCode, data
+}
@@ -30,6 +30,7 @@ import (
 type RoleRPCs struct {
 	GetAllRolesRPC func(roleproto.GetRoleRequest) (*roleproto.RoleResponse, error)
 	GetRoleRPC     func(roleproto.GetRoleRequest) (*roleproto.RoleResponse, error)
+	CreateRoleRPC  func(roleproto.RoleRequest) (*roleproto.RoleResponse, error)
 	UpdateRoleRPC  func(roleproto.UpdateRoleRequest) (*roleproto.RoleResponse, error)
 	DeleteRoleRPC  func(roleproto.DeleteRoleRequest) (*roleproto.RoleResponse, error)
 }
@@ -61,7 +62,56 @@ func (r *RoleRPCs) GetAllRoles(ctx iris.Context) {
 		return
 	}
 
-	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
+
+// CreateRole defines the CreateRole iris handler.
+// The method extract the session token and the role details
+// from the request body and creates the RPC request.
+// After the RPC call the method will feed the response to the iris
+// and gives out a proper response.
+func (r *RoleRPCs) CreateRole(ctx iris.Context) {
+	defer ctx.Next()
+
+	var req roleproto.RoleRequest
+
+	//Read Body from Request
+	var roleReq interface{}
+	err := ctx.ReadJSON(&roleReq)
+	if err != nil {
+		log.Error("Error while trying to collect data from request: " + err.Error())
+		errorMessage := "error while trying to get JSON body from the role create request body: " + err.Error()
+		response := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.JSON(response.Body)
+		return
+	}
+
+	req.SessionToken = ctx.Request().Header.Get("X-Auth-Token")
+	if req.SessionToken == "" {
+		errorMessage := "error: no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+	req.RequestBody, _ = json.Marshal(&roleReq)
+	resp, err := r.CreateRoleRPC(req)
+	if err != nil {
+		errorMessage := "RPC error:" + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
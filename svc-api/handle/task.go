@@ -16,7 +16,11 @@
 package handle
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"path"
 
 	log "github.com/sirupsen/logrus"
 
@@ -28,13 +32,15 @@ import (
 
 // TaskRPCs defines all the RPC methods in task service
 type TaskRPCs struct {
-	DeleteTaskRPC     func(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error)
-	GetTaskRPC        func(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error)
-	GetSubTasksRPC    func(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error)
-	GetSubTaskRPC     func(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error)
-	GetTaskMonitorRPC func(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error)
-	TaskCollectionRPC func(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error)
-	GetTaskServiceRPC func(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error)
+	DeleteTaskRPC        func(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error)
+	GetTaskRPC           func(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error)
+	GetSubTasksRPC       func(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error)
+	GetSubTaskRPC        func(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error)
+	GetTaskMonitorRPC    func(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error)
+	TaskCollectionRPC    func(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error)
+	GetTaskServiceRPC    func(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error)
+	UpdateTaskServiceRPC func(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error)
+	ExportTaskHistoryRPC func(ctx context.Context, req *taskproto.GetTaskRequest, send func(*taskproto.TaskHistoryRecord) error) error
 }
 
 // DeleteTask deletes the task with given TaskID
@@ -114,7 +120,7 @@ func (task *TaskRPCs) GetSubTasks(ctx iris.Context) {
 		return
 	}
 
-	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
 	common.SetResponseHeader(ctx, response.Header)
 	ctx.StatusCode(int(response.StatusCode))
 	ctx.Write(response.Body)
@@ -122,6 +128,105 @@ func (task *TaskRPCs) GetSubTasks(ctx iris.Context) {
 	return
 }
 
+// UpdateTaskService updates the settings of the Task Service
+// It takes iris context and extract auth token and request body from the context
+// Create a request object in task proto request format and pass it to rpc call
+func (task *TaskRPCs) UpdateTaskService(ctx iris.Context) {
+	defer ctx.Next()
+	var req interface{}
+	err := ctx.ReadJSON(&req)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the TaskService update request body: " + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusBadRequest, errResponse.MalformedJSON, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.JSON(&response.Body)
+		return
+	}
+	request, _ := json.Marshal(req)
+	taskReq := &taskproto.GetTaskRequest{
+		SessionToken: ctx.Request().Header.Get("X-Auth-Token"),
+		RequestBody:  request,
+	}
+	if taskReq.SessionToken == "" {
+		errorMessage := "error: no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, errResponse.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+	response, err := task.UpdateTaskServiceRPC(taskReq)
+	if err != nil {
+		errorMessage := "RPC error: " + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, errResponse.InternalError, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
+	common.SetResponseHeader(ctx, response.Header)
+	ctx.StatusCode(int(response.StatusCode))
+	ctx.Write(response.Body)
+}
+
+// ExportTaskHistory streams the records of the deleted tasks as newline delimited JSON
+// It takes iris context and extract auth token from the context
+// Create a request object in task proto request format and pass it to rpc call
+func (task *TaskRPCs) ExportTaskHistory(ctx iris.Context) {
+	defer ctx.Next()
+	req := &taskproto.GetTaskRequest{
+		SessionToken: ctx.Request().Header.Get("X-Auth-Token"),
+	}
+	if req.SessionToken == "" {
+		errorMessage := "error: no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, errResponse.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	var streaming bool
+	err := task.ExportTaskHistoryRPC(ctx.Request().Context(), req, func(record *taskproto.TaskHistoryRecord) error {
+		if !streaming {
+			// the first message has the status of the request
+			streaming = true
+			if record.StatusCode != http.StatusOK {
+				common.SetResponseHeader(ctx, record.Header)
+				ctx.StatusCode(int(record.StatusCode))
+				ctx.Write(record.Body)
+				return nil
+			}
+			ctx.ResponseWriter().Header().Set("Allow", "GET")
+			common.SetResponseHeader(ctx, map[string]string{
+				"Content-type": "application/x-ndjson; charset=utf-8",
+			})
+			ctx.StatusCode(http.StatusOK)
+			return nil
+		}
+		if _, err := fmt.Fprintf(ctx.ResponseWriter(), "%s\n", record.Record); err != nil {
+			return err
+		}
+		ctx.ResponseWriter().Flush()
+		return nil
+	})
+	if err != nil {
+		log.Error(err.Error())
+		if streaming {
+			return
+		}
+		response := common.GeneralError(http.StatusInternalServerError, errResponse.InternalError, err.Error(), nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+	}
+}
+
 // GetSubTask fetches sub task details
 // It takes iris context and extract auth token, TaskID and subTasks from the context
 // Create a request object in task proto request format and pass it to rpc call
@@ -196,6 +301,10 @@ func (task *TaskRPCs) TaskCollection(ctx iris.Context) {
 		common.SetResponseHeader(ctx, nil)
 		return
 	}
+	query, ok := getCollectionQuery(ctx)
+	if !ok {
+		return
+	}
 	response, err := task.TaskCollectionRPC(req)
 	common.SetResponseHeader(ctx, response.Header)
 
@@ -210,8 +319,18 @@ func (task *TaskRPCs) TaskCollection(ctx iris.Context) {
 
 	ctx.ResponseWriter().Header().Set("Allow", "GET")
 	common.SetResponseHeader(ctx, response.Header)
-	ctx.StatusCode(int(response.StatusCode))
-	ctx.Write(response.Body)
+	statusCode, body := applyCollectionQuery(query, response.StatusCode, response.Body, func(uri string) (int32, []byte, error) {
+		memberResp, err := task.GetTaskRPC(&taskproto.GetTaskRequest{
+			SessionToken: req.SessionToken,
+			TaskID:       path.Base(uri),
+		})
+		if err != nil {
+			return 0, nil, err
+		}
+		return memberResp.StatusCode, memberResp.Body, nil
+	})
+	ctx.StatusCode(int(statusCode))
+	ctx.Write(body)
 
 	return
 }
@@ -14,6 +14,7 @@
 package handle
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -274,3 +275,75 @@ func TestDeleteTask_ValidToken(t *testing.T) {
 		"/redfish/v1/TaskService/Tasks/3A",
 	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusInternalServerError).Headers().Equal(header)
 }
+
+func mockUpdateTaskService(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error) {
+	if req.SessionToken == "token" {
+		return nil, fmt.Errorf("RPC Error")
+	}
+	return &taskproto.TaskResponse{
+		StatusCode:    200,
+		StatusMessage: "Success",
+		Body:          req.RequestBody,
+	}, nil
+}
+
+func TestUpdateTaskService(t *testing.T) {
+	var task TaskRPCs
+	task.UpdateTaskServiceRPC = mockUpdateTaskService
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/TaskService")
+	redfishRoutes.Patch("/", task.UpdateTaskService)
+	test := httptest.New(t, mockApp)
+	body := map[string]interface{}{"CompletedTaskOverWritePolicy": "Manual"}
+	test.PATCH(
+		"/redfish/v1/TaskService",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(body).Expect().Status(http.StatusOK).JSON().Object().ValueEqual("CompletedTaskOverWritePolicy", "Manual")
+	test.PATCH(
+		"/redfish/v1/TaskService",
+	).WithHeader("X-Auth-Token", "").WithJSON(body).Expect().Status(http.StatusUnauthorized)
+	test.PATCH(
+		"/redfish/v1/TaskService",
+	).WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte(`{"CompletedTaskOverWritePolicy"`)).Expect().Status(http.StatusBadRequest)
+	test.PATCH(
+		"/redfish/v1/TaskService",
+	).WithHeader("X-Auth-Token", "token").WithJSON(body).Expect().Status(http.StatusInternalServerError)
+}
+
+func mockExportTaskHistory(ctx context.Context, req *taskproto.GetTaskRequest, send func(*taskproto.TaskHistoryRecord) error) error {
+	switch req.SessionToken {
+	case "InvalidToken":
+		return send(&taskproto.TaskHistoryRecord{StatusCode: http.StatusUnauthorized, Body: []byte(`{"Response":"Unauthorized"}`)})
+	case "token":
+		return fmt.Errorf("RPC Error")
+	}
+	if err := send(&taskproto.TaskHistoryRecord{StatusCode: http.StatusOK}); err != nil {
+		return err
+	}
+	for _, record := range []string{`{"Id":"task1"}`, `{"Id":"task2"}`} {
+		if err := send(&taskproto.TaskHistoryRecord{Record: []byte(record)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExportTaskHistory(t *testing.T) {
+	var task TaskRPCs
+	task.ExportTaskHistoryRPC = mockExportTaskHistory
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/TaskService")
+	redfishRoutes.Get("/Oem/Odim/TaskHistory", task.ExportTaskHistory)
+	test := httptest.New(t, mockApp)
+	test.GET(
+		"/redfish/v1/TaskService/Oem/Odim/TaskHistory",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK).Body().Equal("{\"Id\":\"task1\"}\n{\"Id\":\"task2\"}\n")
+	test.GET(
+		"/redfish/v1/TaskService/Oem/Odim/TaskHistory",
+	).WithHeader("X-Auth-Token", "InvalidToken").Expect().Status(http.StatusUnauthorized)
+	test.GET(
+		"/redfish/v1/TaskService/Oem/Odim/TaskHistory",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.GET(
+		"/redfish/v1/TaskService/Oem/Odim/TaskHistory",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
@@ -16,6 +16,7 @@
 package handle
 
 import (
+	"encoding/json"
 	"net/http"
 
 	log "github.com/sirupsen/logrus"
@@ -332,11 +333,23 @@ func (a *TelemetryRPCs) GetTrigger(ctx iris.Context) {
 
 }
 
-// UpdateTrigger is the handler for getting TelemetryService details
+// UpdateTrigger is the handler for updating the Trigger details
 func (a *TelemetryRPCs) UpdateTrigger(ctx iris.Context) {
 	defer ctx.Next()
+	var triggerReq interface{}
+	err := ctx.ReadJSON(&triggerReq)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the trigger update request body: " + err.Error()
+		log.Error(errorMessage)
+		response := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.JSON(&response.Body)
+		return
+	}
 	req := telemetryproto.TelemetryRequest{
 		SessionToken: ctx.Request().Header.Get("X-Auth-Token"),
+		URL:          ctx.Request().RequestURI,
 	}
 	if req.SessionToken == "" {
 		errorMessage := "error: no X-Auth-Token found in request header"
@@ -346,6 +359,7 @@ func (a *TelemetryRPCs) UpdateTrigger(ctx iris.Context) {
 		ctx.JSON(&response.Body)
 		return
 	}
+	req.RequestBody, _ = json.Marshal(&triggerReq)
 	resp, err := a.UpdateTriggerRPC(req)
 	if err != nil {
 		errorMessage := "error: something went wrong with the RPC calls: " + err.Error()
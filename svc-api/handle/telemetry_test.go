@@ -221,13 +221,21 @@ func TestUpdateTrigger(t *testing.T) {
 	redfishRoutes := testApp.Party("/redfish/v1/TelemetryService")
 	redfishRoutes.Patch("/Triggers/{id}", a.UpdateTrigger)
 	test := httptest.New(t, testApp)
+	triggerReq := map[string]interface{}{
+		"NumericThresholds": map[string]interface{}{
+			"UpperCritical": map[string]interface{}{"Reading": 90},
+		},
+	}
 	test.PATCH(
 		"/redfish/v1/TelemetryService/Triggers/1",
-	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK).Headers().Equal(header)
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(triggerReq).Expect().Status(http.StatusOK).Headers().Equal(header)
 	test.PATCH(
 		"/redfish/v1/TelemetryService/Triggers/1",
-	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	).WithHeader("X-Auth-Token", "").WithJSON(triggerReq).Expect().Status(http.StatusUnauthorized)
 	test.PATCH(
 		"/redfish/v1/TelemetryService/Triggers/1",
-	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+	).WithHeader("X-Auth-Token", "token").WithJSON(triggerReq).Expect().Status(http.StatusInternalServerError)
+	test.PATCH(
+		"/redfish/v1/TelemetryService/Triggers/1",
+	).WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte("{")).Expect().Status(http.StatusBadRequest)
 }
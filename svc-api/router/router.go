@@ -42,11 +42,13 @@ func Router() *iris.Application {
 	r := handle.RoleRPCs{
 		GetAllRolesRPC: rpc.GetAllRoles,
 		GetRoleRPC:     rpc.GetRole,
+		CreateRoleRPC:  rpc.CreateRole,
 		UpdateRoleRPC:  rpc.UpdateRole,
 		DeleteRoleRPC:  rpc.DeleteRole,
 	}
 	a := handle.AccountRPCs{
 		GetServiceRPC:     rpc.DoGetAccountServiceRequest,
+		UpdateServiceRPC:  rpc.DoUpdateAccountServiceRequest,
 		CreateRPC:         rpc.DoAccountCreationRequest,
 		GetAllAccountsRPC: rpc.DoGetAllAccountRequest,
 		GetAccountRPC:     rpc.DoGetAccountRequest,
@@ -70,6 +72,9 @@ func Router() *iris.Application {
 		RemoveElementsFromAggregateRPC:          rpc.DoRemoveElementsFromAggregate,
 		ResetAggregateElementsRPC:               rpc.DoResetAggregateElements,
 		SetDefaultBootOrderAggregateElementsRPC: rpc.DoSetDefaultBootOrderAggregateElements,
+		SetPowerBudgetOfAggregateRPC:            rpc.DoSetPowerBudgetOfAggregate,
+		ValidateAggregationSourceRPC:            rpc.DoValidateAggregationSource,
+		ImportAggregationSourcesRPC:             rpc.DoImportAggregationSources,
 		GetAllConnectionMethodsRPC:              rpc.DoGetAllConnectionMethods,
 		GetConnectionMethodRPC:                  rpc.DoGetConnectionMethod,
 		GetResetActionInfoServiceRPC:            rpc.DoGetResetActionInfoService,
@@ -85,13 +90,23 @@ func Router() *iris.Application {
 	}
 
 	ts := handle.TaskRPCs{
-		DeleteTaskRPC:     rpc.DeleteTaskRequest,
-		GetTaskRPC:        rpc.GetTaskRequest,
-		GetSubTasksRPC:    rpc.GetSubTasks,
-		GetSubTaskRPC:     rpc.GetSubTask,
-		GetTaskMonitorRPC: rpc.GetTaskMonitor,
-		TaskCollectionRPC: rpc.TaskCollection,
-		GetTaskServiceRPC: rpc.GetTaskService,
+		DeleteTaskRPC:        rpc.DeleteTaskRequest,
+		GetTaskRPC:           rpc.GetTaskRequest,
+		GetSubTasksRPC:       rpc.GetSubTasks,
+		GetSubTaskRPC:        rpc.GetSubTask,
+		GetTaskMonitorRPC:    rpc.GetTaskMonitor,
+		TaskCollectionRPC:    rpc.TaskCollection,
+		GetTaskServiceRPC:    rpc.GetTaskService,
+		UpdateTaskServiceRPC: rpc.UpdateTaskService,
+		ExportTaskHistoryRPC: rpc.DoExportTaskHistory,
+	}
+
+	js := handle.JobRPCs{
+		GetJobServiceRPC:    rpc.DoGetJobService,
+		GetJobCollectionRPC: rpc.DoGetJobCollection,
+		GetJobRPC:           rpc.DoGetJob,
+		CreateJobRPC:        rpc.DoCreateJob,
+		DeleteJobRPC:        rpc.DoDeleteJob,
 	}
 
 	system := handle.SystemRPCs{
@@ -122,6 +137,12 @@ func Router() *iris.Application {
 		GetEventSubscriptionRPC:            rpc.DoGetEventSubscription,
 		DeleteEventSubscriptionRPC:         rpc.DoDeleteEventSubscription,
 		GetEventSubscriptionsCollectionRPC: rpc.DoGetEventSubscriptionsCollection,
+		GetUndeliveredEventsRPC:            rpc.DoGetUndeliveredEvents,
+		ReplayUndeliveredEventsRPC:         rpc.DoReplayUndeliveredEvents,
+		PurgeUndeliveredEventsRPC:          rpc.DoPurgeUndeliveredEvents,
+		UpdateEventServiceRPC:              rpc.DoUpdateEventService,
+		UpdateEventSubscriptionRPC:         rpc.DoUpdateEventSubscription,
+		GetServerSentEventsRPC:             rpc.DoGetServerSentEvents,
 	}
 
 	fab := handle.FabricRPCs{
@@ -140,6 +161,10 @@ func Router() *iris.Application {
 		CreateRemoteAccountServiceRPC: rpc.CreateRemoteAccountService,
 		UpdateRemoteAccountServiceRPC: rpc.UpdateRemoteAccountService,
 		DeleteRemoteAccountServiceRPC: rpc.DeleteRemoteAccountService,
+		GetCertificateServiceRPC:      rpc.GetCertificateService,
+		GetCertificateLocationsRPC:    rpc.GetCertificateLocations,
+		GenerateCSRRPC:                rpc.GenerateCSR,
+		ReplaceCertificateRPC:         rpc.ReplaceCertificate,
 	}
 
 	update := handle.UpdateRPCs{
@@ -186,6 +211,8 @@ func Router() *iris.Application {
 		GetLicenseCollectionRPC:  rpc.GetLicenseCollection,
 		GetLicenseResourceRPC:    rpc.GetLicenseResource,
 		InstallLicenseServiceRPC: rpc.InstallLicenseService,
+		DeleteLicenseRPC:         rpc.DeleteLicense,
+		GetLicenseReportRPC:      rpc.GetLicenseReport,
 	}
 
 	registryFile := handle.Registry{
@@ -309,6 +336,7 @@ func Router() *iris.Application {
 	account := v1.Party("/AccountService", middleware.SessionDelMiddleware)
 	account.SetRegisterRule(iris.RouteSkip)
 	account.Get("/", a.GetAccountService)
+	account.Patch("/", a.UpdateAccountService)
 	account.Get("/Accounts", a.GetAllAccounts)
 	account.Get("/Accounts/{id}", a.GetAccount)
 	account.Post("/Accounts", a.CreateAccount)
@@ -321,6 +349,7 @@ func Router() *iris.Application {
 	role := account.Party("/Roles", middleware.SessionDelMiddleware)
 	role.SetRegisterRule(iris.RouteSkip)
 	role.Get("/", r.GetAllRoles)
+	role.Post("/", r.CreateRole)
 	role.Get("/{id}", r.GetRole)
 	role.Patch("/{id}", r.UpdateRole)
 	role.Delete("/{id}", r.DeleteRole)
@@ -330,6 +359,8 @@ func Router() *iris.Application {
 	task := v1.Party("/TaskService", middleware.SessionDelMiddleware)
 	task.SetRegisterRule(iris.RouteSkip)
 	task.Get("/", ts.GetTaskService)
+	task.Patch("/", ts.UpdateTaskService)
+	task.Get("/Oem/Odim/TaskHistory", ts.ExportTaskHistory)
 	task.Get("/Tasks", ts.TaskCollection)
 	task.Get("/Tasks/{TaskID}", ts.GetTaskStatus)
 	task.Get("/Tasks/{TaskID}/SubTasks", ts.GetSubTasks)
@@ -340,6 +371,18 @@ func Router() *iris.Application {
 	task.Any("/Tasks/{TaskID}", handle.TsMethodNotAllowed)
 	task.Any("/Tasks/{TaskID}/SubTasks", handle.TsMethodNotAllowed)
 	task.Any("/Tasks/{TaskID}/SubTasks/{subTaskID}", handle.TsMethodNotAllowed)
+	task.Any("/Oem/Odim/TaskHistory", handle.TsMethodNotAllowed)
+
+	jobService := v1.Party("/JobService", middleware.SessionDelMiddleware)
+	jobService.SetRegisterRule(iris.RouteSkip)
+	jobService.Get("/", js.GetJobService)
+	jobService.Get("/Jobs", js.GetJobCollection)
+	jobService.Post("/Jobs", js.CreateJob)
+	jobService.Get("/Jobs/{id}", js.GetJob)
+	jobService.Delete("/Jobs/{id}", js.DeleteJob)
+	jobService.Any("/", handle.JsMethodNotAllowed)
+	jobService.Any("/Jobs", handle.JsMethodNotAllowed)
+	jobService.Any("/Jobs/{id}", handle.JsMethodNotAllowed)
 
 	systems := v1.Party("/Systems", middleware.SessionDelMiddleware)
 	systems.SetRegisterRule(iris.RouteSkip)
@@ -444,6 +487,10 @@ func Router() *iris.Application {
 	aggregation.Any("/Actions/AggregationService.Reset/", handle.AggMethodNotAllowed)
 	aggregation.Post("/Actions/AggregationService.SetDefaultBootOrder/", pc.SetDefaultBootOrder)
 	aggregation.Any("/Actions/AggregationService.SetDefaultBootOrder/", handle.AggMethodNotAllowed)
+	aggregation.Post("/Actions/Oem/Odim.ValidateAggregationSource/", pc.ValidateAggregationSource)
+	aggregation.Any("/Actions/Oem/Odim.ValidateAggregationSource/", handle.AggMethodNotAllowed)
+	aggregation.Post("/Actions/Oem/Odim.ImportAggregationSources/", pc.ImportAggregationSources)
+	aggregation.Any("/Actions/Oem/Odim.ImportAggregationSources/", handle.AggMethodNotAllowed)
 	aggregation.Any("/", handle.AggMethodNotAllowed)
 
 	aggregationSource := aggregation.Party("/AggregationSources", middleware.SessionDelMiddleware)
@@ -476,6 +523,8 @@ func Router() *iris.Application {
 	aggregates.Any("/{id}/Actions/Aggregate.Reset/", handle.AggregateMethodNotAllowed)
 	aggregates.Post("/{id}/Actions/Aggregate.SetDefaultBootOrder/", pc.SetDefaultBootOrderAggregateElements)
 	aggregates.Any("/{id}/Actions/Aggregate.SetDefaultBootOrder/", handle.AggregateMethodNotAllowed)
+	aggregates.Post("/{id}/Actions/Oem/Odim.SetPowerBudget/", pc.SetPowerBudgetOfAggregate)
+	aggregates.Any("/{id}/Actions/Oem/Odim.SetPowerBudget/", handle.AggregateMethodNotAllowed)
 
 	chassis := v1.Party("/Chassis", middleware.SessionDelMiddleware)
 	chassis.SetRegisterRule(iris.RouteSkip)
@@ -516,6 +565,20 @@ func Router() *iris.Application {
 	chassis.Get("/{id}/Sensors/{rid}", cha.GetChassisResource)
 	chassis.Any("/{id}/Sensors", handle.ChassisMethodNotAllowed)
 	chassis.Any("/{id}/Sensors/{rid}", handle.ChassisMethodNotAllowed)
+	chassis.Get("/{id}/PowerSubsystem", cha.GetChassisResource)
+	chassis.Get("/{id}/PowerSubsystem/PowerSupplies", cha.GetChassisResource)
+	chassis.Get("/{id}/PowerSubsystem/PowerSupplies/{rid}", cha.GetChassisResource)
+	chassis.Any("/{id}/PowerSubsystem", handle.ChassisMethodNotAllowed)
+	chassis.Any("/{id}/PowerSubsystem/PowerSupplies", handle.ChassisMethodNotAllowed)
+	chassis.Any("/{id}/PowerSubsystem/PowerSupplies/{rid}", handle.ChassisMethodNotAllowed)
+	chassis.Get("/{id}/ThermalSubsystem", cha.GetChassisResource)
+	chassis.Get("/{id}/ThermalSubsystem/Fans", cha.GetChassisResource)
+	chassis.Get("/{id}/ThermalSubsystem/Fans/{rid}", cha.GetChassisResource)
+	chassis.Any("/{id}/ThermalSubsystem", handle.ChassisMethodNotAllowed)
+	chassis.Any("/{id}/ThermalSubsystem/Fans", handle.ChassisMethodNotAllowed)
+	chassis.Any("/{id}/ThermalSubsystem/Fans/{rid}", handle.ChassisMethodNotAllowed)
+	chassis.Get("/{id}/EnvironmentMetrics", cha.GetChassisResource)
+	chassis.Any("/{id}/EnvironmentMetrics", handle.ChassisMethodNotAllowed)
 	chassis.Get("/{id}/LogServices", cha.GetChassisResource)
 	chassis.Get("/{id}/LogServices/{rid}", cha.GetChassisResource)
 	chassis.Get("/{id}/LogServices/{rid}/Entries", ratelimiter.ResourceRateLimiter, cha.GetChassisResource)
@@ -551,15 +614,24 @@ func Router() *iris.Application {
 	events := v1.Party("/EventService", middleware.SessionDelMiddleware)
 	events.SetRegisterRule(iris.RouteSkip)
 	events.Get("/", evt.GetEventService)
+	events.Get("/SSE", evt.GetServerSentEvents)
 	events.Get("/Subscriptions", evt.GetEventSubscriptionsCollection)
 	events.Get("/Subscriptions/{id}", evt.GetEventSubscription)
 	events.Post("/Subscriptions", evt.CreateEventSubscription)
 	events.Post("/Actions/EventService.SubmitTestEvent", evt.SubmitTestEvent)
+	events.Patch("/", evt.UpdateEventService)
+	events.Patch("/Subscriptions/{id}", evt.UpdateEventSubscription)
 	events.Delete("/Subscriptions/{id}", evt.DeleteEventSubscription)
+	events.Get("/Subscriptions/{id}/UndeliveredEvents", evt.GetUndeliveredEvents)
+	events.Delete("/Subscriptions/{id}/UndeliveredEvents", evt.PurgeUndeliveredEvents)
+	events.Post("/Subscriptions/{id}/UndeliveredEvents/Actions/UndeliveredEvents.Replay", evt.ReplayUndeliveredEvents)
 	events.Any("/", handle.EvtMethodNotAllowed)
 	events.Any("/Actions", handle.EvtMethodNotAllowed)
+	events.Any("/SSE", handle.EvtMethodNotAllowed)
 	events.Any("/Actions/EventService.SubmitTestEvent", handle.EvtMethodNotAllowed)
 	events.Any("/Subscriptions", handle.EvtMethodNotAllowed)
+	events.Any("/Subscriptions/{id}/UndeliveredEvents", handle.EvtMethodNotAllowed)
+	events.Any("/Subscriptions/{id}/UndeliveredEvents/Actions/UndeliveredEvents.Replay", handle.EvtMethodNotAllowed)
 
 	fabrics := v1.Party("/Fabrics", middleware.SessionDelMiddleware)
 	fabrics.SetRegisterRule(iris.RouteSkip)
@@ -611,6 +683,10 @@ func Router() *iris.Application {
 	managers.Any("/{id}/EthernetInterfaces/{rid}", handle.ManagersMethodNotAllowed)
 	managers.Get("/{id}/NetworkProtocol", manager.GetManagersResource)
 	managers.Get("/{id}/NetworkProtocol/{rid}", manager.GetManagersResource)
+	managers.Get("/{id}/NetworkProtocol/HTTPS/Certificates", manager.GetManagersResource)
+	managers.Get("/{id}/NetworkProtocol/HTTPS/Certificates/{rid}", manager.GetManagersResource)
+	managers.Any("/{id}/NetworkProtocol/HTTPS/Certificates", handle.ManagersMethodNotAllowed)
+	managers.Any("/{id}/NetworkProtocol/HTTPS/Certificates/{rid}", handle.ManagersMethodNotAllowed)
 	managers.Any("/{id}/NetworkProtocol", handle.ManagersMethodNotAllowed)
 	managers.Any("/{id}/NetworkProtocol/{rid}", handle.ManagersMethodNotAllowed)
 	managers.Get("/{id}/HostInterfaces", manager.GetManagersResource)
@@ -693,15 +769,29 @@ func Router() *iris.Application {
 	telemetryService.Any("/MetricReports/{id}", handle.MethodNotAllowed)
 	telemetryService.Any("/Triggers/{id}", handle.MethodNotAllowed)
 
+	certificateService := v1.Party("/CertificateService", middleware.SessionDelMiddleware)
+	certificateService.SetRegisterRule(iris.RouteSkip)
+	certificateService.Get("/", manager.GetCertificateService)
+	certificateService.Get("/CertificateLocations", manager.GetCertificateLocations)
+	certificateService.Post("/Actions/CertificateService.GenerateCSR", manager.GenerateCSR)
+	certificateService.Post("/Actions/CertificateService.ReplaceCertificate", manager.ReplaceCertificate)
+	certificateService.Any("/", handle.CertificateServiceMethodNotAllowed)
+	certificateService.Any("/CertificateLocations", handle.CertificateServiceMethodNotAllowed)
+	certificateService.Any("/Actions/CertificateService.GenerateCSR", handle.CertificateServiceMethodNotAllowed)
+	certificateService.Any("/Actions/CertificateService.ReplaceCertificate", handle.CertificateServiceMethodNotAllowed)
+
 	licenseService := v1.Party("/LicenseService", middleware.SessionDelMiddleware)
 	licenseService.SetRegisterRule(iris.RouteSkip)
 	licenseService.Get("/", licenses.GetLicenseService)
 	licenseService.Get("/Licenses", licenses.GetLicenseCollection)
 	licenseService.Get("/Licenses/{id}", licenses.GetLicenseResource)
 	licenseService.Post("/Licenses", licenses.InstallLicenseService)
+	licenseService.Delete("/Licenses/{id}", licenses.DeleteLicense)
+	licenseService.Get("/Oem/Odim/LicenseReport", licenses.GetLicenseReport)
 	licenseService.Any("/", handle.LicenseMethodNotAllowed)
 	licenseService.Any("/Licenses", handle.LicenseMethodNotAllowed)
 	licenseService.Any("/Licenses/{id}", handle.LicenseMethodNotAllowed)
+	licenseService.Any("/Oem/Odim/LicenseReport", handle.LicenseMethodNotAllowed)
 
 	// composition service
 	compositionService := v1.Party("/CompositionService", middleware.SessionDelMiddleware)
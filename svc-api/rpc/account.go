@@ -113,6 +113,23 @@ func DoUpdateAccountRequest(req accountproto.UpdateAccountRequest) (*accountprot
 	return resp, err
 }
 
+// DoUpdateAccountServiceRequest defines the RPC call function for
+// the UpdateAccountService from account-session micro service
+func DoUpdateAccountServiceRequest(req accountproto.UpdateAccountServiceRequest) (*accountproto.AccountResponse, error) {
+	conn, err := ClientFunc(services.AccountSession)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	account := NewAccountClientFunc(conn)
+
+	resp, err := account.UpdateAccountService(context.TODO(), &req)
+	if err != nil && resp == nil {
+		return nil, fmt.Errorf("error: something went wrong with rpc call: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
 // DoAccountDeleteRequest defines the RPC call function for
 // the AccountDelete from account-session micro service
 func DoAccountDeleteRequest(req accountproto.DeleteAccountRequest) (*accountproto.AccountResponse, error) {
@@ -328,6 +328,63 @@ func DoSetDefaultBootOrderAggregateElements(req aggregatorproto.AggregatorReques
 	return resp, err
 }
 
+// DoSetPowerBudgetOfAggregate defines the RPC call function for
+// the set power budget of an aggregate from aggregator micro service
+func DoSetPowerBudgetOfAggregate(req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.SetPowerBudgetOfAggregate(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoValidateAggregationSource defines the RPC call function for
+// the pre-flight check of an aggregation source from aggregator micro service
+func DoValidateAggregationSource(req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.ValidateAggregationSource(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoImportAggregationSources defines the RPC call function for
+// the bulk import of aggregation sources from aggregator micro service
+func DoImportAggregationSources(req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.ImportAggregationSources(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
 // DoGetAllConnectionMethods defines the RPC call function for
 // the get connection method collection from aggregator micro service
 func DoGetAllConnectionMethods(req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
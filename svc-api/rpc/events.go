@@ -18,6 +18,7 @@ package rpc
 import (
 	"context"
 	"fmt"
+	"io"
 
 	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
@@ -138,3 +139,123 @@ func DoGetEventSubscriptionsCollection(req eventsproto.EventRequest) (*eventspro
 	defer conn.Close()
 	return resp, err
 }
+
+// DoGetUndeliveredEvents defines the RPC call function for
+// the GetUndeliveredEvents from events micro service
+func DoGetUndeliveredEvents(req eventsproto.EventRequest) (*eventsproto.EventSubResponse, error) {
+
+	conn, err := ClientFunc(services.Events)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	events := NewEventsClientFunc(conn)
+
+	resp, err := events.GetUndeliveredEvents(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return resp, err
+}
+
+// DoReplayUndeliveredEvents defines the RPC call function for
+// the ReplayUndeliveredEvents from events micro service
+func DoReplayUndeliveredEvents(req eventsproto.EventRequest) (*eventsproto.EventSubResponse, error) {
+
+	conn, err := ClientFunc(services.Events)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	events := NewEventsClientFunc(conn)
+
+	resp, err := events.ReplayUndeliveredEvents(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return resp, err
+}
+
+// DoPurgeUndeliveredEvents defines the RPC call function for
+// the PurgeUndeliveredEvents from events micro service
+func DoPurgeUndeliveredEvents(req eventsproto.EventRequest) (*eventsproto.EventSubResponse, error) {
+
+	conn, err := ClientFunc(services.Events)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	events := NewEventsClientFunc(conn)
+
+	resp, err := events.PurgeUndeliveredEvents(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return resp, err
+}
+
+// DoUpdateEventService defines the RPC call function for
+// the UpdateEventService from events micro service
+func DoUpdateEventService(req eventsproto.EventSubRequest) (*eventsproto.EventSubResponse, error) {
+
+	conn, err := ClientFunc(services.Events)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	events := NewEventsClientFunc(conn)
+
+	resp, err := events.UpdateEventService(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return resp, err
+}
+
+// DoUpdateEventSubscription defines the RPC call function for
+// the UpdateEventSubscription from events micro service
+func DoUpdateEventSubscription(req eventsproto.EventRequest) (*eventsproto.EventSubResponse, error) {
+
+	conn, err := ClientFunc(services.Events)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	events := NewEventsClientFunc(conn)
+
+	resp, err := events.UpdateEventSubscription(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return resp, err
+}
+
+// DoGetServerSentEvents defines the RPC call function for
+// the GetServerSentEvents from events micro service.
+// send is called with each message of the stream, till the ctx is done.
+func DoGetServerSentEvents(ctx context.Context, req eventsproto.EventRequest, send func(*eventsproto.ServerSentEvent) error) error {
+
+	conn, err := ClientFunc(services.Events)
+	if err != nil {
+		return fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	events := NewEventsClientFunc(conn)
+
+	stream, err := events.GetServerSentEvents(ctx, &req)
+	if err != nil {
+		return fmt.Errorf("error: RPC error: %v", err)
+	}
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error: RPC error: %v", err)
+		}
+		if err := send(event); err != nil {
+			return err
+		}
+	}
+}
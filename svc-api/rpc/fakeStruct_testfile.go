@@ -48,6 +48,10 @@ func (fakeStruct) Delete(ctx context.Context, in *accountproto.DeleteAccountRequ
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) UpdateAccountService(ctx context.Context, in *accountproto.UpdateAccountServiceRequest, opts ...grpc.CallOption) (*accountproto.AccountResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
 //------------------------------------AGGREGATOR-------------------------------------------------
 
 func (fakeStruct) Reset(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
@@ -159,6 +163,18 @@ func (fakeStruct) GetSetDefaultBootOrderActionInfo(ctx context.Context, in *aggr
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) SetPowerBudgetOfAggregate(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) ValidateAggregationSource(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) ImportAggregationSources(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
 func (fakeStruct) IsAggregateHaveSubscription(ctx context.Context, in *events.EventUpdateRequest, opts ...grpc.CallOption) (*events.SubscribeEMBResponse, error) {
 
 	return nil, errors.New("fakeError")
@@ -237,6 +253,30 @@ func (fakeStruct) SubsribeEMB(ctx context.Context, in *eventsproto.SubscribeEMBR
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) GetUndeliveredEvents(ctx context.Context, in *eventsproto.EventRequest, opts ...grpc.CallOption) (*eventsproto.EventSubResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) ReplayUndeliveredEvents(ctx context.Context, in *eventsproto.EventRequest, opts ...grpc.CallOption) (*eventsproto.EventSubResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) PurgeUndeliveredEvents(ctx context.Context, in *eventsproto.EventRequest, opts ...grpc.CallOption) (*eventsproto.EventSubResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) UpdateEventSubscription(ctx context.Context, in *eventsproto.EventRequest, opts ...grpc.CallOption) (*eventsproto.EventSubResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) UpdateEventService(ctx context.Context, in *eventsproto.EventSubRequest, opts ...grpc.CallOption) (*eventsproto.EventSubResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetServerSentEvents(ctx context.Context, in *eventsproto.EventRequest, opts ...grpc.CallOption) (eventsproto.Events_GetServerSentEventsClient, error) {
+	return nil, errors.New("fakeError")
+}
+
 //--------------------------------------FABRICS--------------------------------------
 
 func (fakeStruct) GetFabricResource(ctx context.Context, in *fabricsproto.FabricRequest, opts ...grpc.CallOption) (*fabricsproto.FabricResponse, error) {
@@ -296,6 +336,22 @@ func (fakeStruct) DeleteRemoteAccountService(ctx context.Context, in *managerspr
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) GetCertificateService(ctx context.Context, in *managersproto.ManagerRequest, opts ...grpc.CallOption) (*managersproto.ManagerResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetCertificateLocations(ctx context.Context, in *managersproto.ManagerRequest, opts ...grpc.CallOption) (*managersproto.ManagerResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GenerateCSR(ctx context.Context, in *managersproto.ManagerRequest, opts ...grpc.CallOption) (*managersproto.ManagerResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) ReplaceCertificate(ctx context.Context, in *managersproto.ManagerRequest, opts ...grpc.CallOption) (*managersproto.ManagerResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
 //------------------------------------ROLE-------------------------------------------------
 
 func (fakeStruct) CreateRole(ctx context.Context, in *roleproto.RoleRequest, opts ...grpc.CallOption) (*roleproto.RoleResponse, error) {
@@ -348,6 +404,10 @@ func (fakeStruct) GetSessionUserRoleID(ctx context.Context, in *sessionproto.Ses
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) CreateJobSession(ctx context.Context, in *sessionproto.JobSessionRequest, opts ...grpc.CallOption) (*sessionproto.SessionCreateResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
 //--------------------------------------------SYSTEM-----------------------------------------
 
 func (fakeStruct2) GetSystemsCollection(ctx context.Context, in *systemsproto.GetSystemsRequest, opts ...grpc.CallOption) (*systemsproto.SystemsResponse, error) {
@@ -428,6 +488,14 @@ func (fakeStruct) UpdateTask(ctx context.Context, in *taskproto.UpdateTaskReques
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) UpdateTaskService(ctx context.Context, in *taskproto.GetTaskRequest, opts ...grpc.CallOption) (*taskproto.TaskResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) ExportTaskHistory(ctx context.Context, in *taskproto.GetTaskRequest, opts ...grpc.CallOption) (taskproto.GetTaskService_ExportTaskHistoryClient, error) {
+	return nil, errors.New("fakeError")
+}
+
 //------------------------------------------TELEMETRY---------------------------------------
 
 func (fakeStruct) GetTelemetryService(ctx context.Context, in *teleproto.TelemetryRequest, opts ...grpc.CallOption) (*teleproto.TelemetryResponse, error) {
@@ -470,6 +538,10 @@ func (fakeStruct) UpdateTrigger(ctx context.Context, in *teleproto.TelemetryRequ
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) EvaluateTriggers(ctx context.Context, in *teleproto.TelemetryRequest, opts ...grpc.CallOption) (*teleproto.TelemetryResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
 //--------------------------------------------UPDATE----------------------------------------
 
 func (fakeStruct) GetUpdateService(ctx context.Context, in *updateproto.UpdateRequest, opts ...grpc.CallOption) (*updateproto.UpdateResponse, error) {
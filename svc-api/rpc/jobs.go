@@ -0,0 +1,107 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
+	"github.com/ODIM-Project/ODIM/lib-utilities/services"
+)
+
+var (
+	NewJobServiceClientFunc = taskproto.NewJobServiceClient
+)
+
+// DoGetJobService defines the RPC call function for
+// the GetJobService from task micro service
+func DoGetJobService(req taskproto.JobRequest) (*taskproto.TaskResponse, error) {
+	conn, err := ClientFunc(services.Tasks)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	jobService := NewJobServiceClientFunc(conn)
+	resp, err := jobService.GetJobService(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return resp, nil
+}
+
+// DoGetJobCollection defines the RPC call function for
+// the GetJobCollection from task micro service
+func DoGetJobCollection(req taskproto.JobRequest) (*taskproto.TaskResponse, error) {
+	conn, err := ClientFunc(services.Tasks)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	jobService := NewJobServiceClientFunc(conn)
+	resp, err := jobService.GetJobCollection(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return resp, nil
+}
+
+// DoGetJob defines the RPC call function for
+// the GetJob from task micro service
+func DoGetJob(req taskproto.JobRequest) (*taskproto.TaskResponse, error) {
+	conn, err := ClientFunc(services.Tasks)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	jobService := NewJobServiceClientFunc(conn)
+	resp, err := jobService.GetJob(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return resp, nil
+}
+
+// DoCreateJob defines the RPC call function for
+// the CreateJob from task micro service
+func DoCreateJob(req taskproto.JobRequest) (*taskproto.TaskResponse, error) {
+	conn, err := ClientFunc(services.Tasks)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	jobService := NewJobServiceClientFunc(conn)
+	resp, err := jobService.CreateJob(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return resp, nil
+}
+
+// DoDeleteJob defines the RPC call function for
+// the DeleteJob from task micro service
+func DoDeleteJob(req taskproto.JobRequest) (*taskproto.TaskResponse, error) {
+	conn, err := ClientFunc(services.Tasks)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	jobService := NewJobServiceClientFunc(conn)
+	resp, err := jobService.DeleteJob(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return resp, nil
+}
@@ -82,3 +82,33 @@ func InstallLicenseService(req licenseproto.InstallLicenseRequest) (*licenseprot
 
 	return resp, err
 }
+
+// DeleteLicense will do the rpc call to delete a License
+func DeleteLicense(req licenseproto.GetLicenseResourceRequest) (*licenseproto.GetLicenseResponse, error) {
+	conn, err := services.ODIMService.Client(services.Licenses)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	licenseService := licenseproto.NewLicensesClient(conn)
+	resp, err := licenseService.DeleteLicense(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	return resp, nil
+}
+
+// GetLicenseReport will do the rpc call to get the report of the licenses of all the managers
+func GetLicenseReport(req licenseproto.GetLicenseRequest) (*licenseproto.GetLicenseResponse, error) {
+	conn, err := services.ODIMService.Client(services.Licenses)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	licenseService := licenseproto.NewLicensesClient(conn)
+	resp, err := licenseService.GetLicenseReport(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	return resp, nil
+}
@@ -169,3 +169,67 @@ func DeleteRemoteAccountService(req managersproto.ManagerRequest) (*managersprot
 	defer conn.Close()
 	return resp, nil
 }
+
+// GetCertificateService will do the rpc call to get the CertificateService
+func GetCertificateService(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error) {
+	conn, err := ClientFunc(services.Managers)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	mService := NewManagersClientFunc(conn)
+	resp, err := mService.GetCertificateService(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, nil
+}
+
+// GetCertificateLocations will do the rpc call to get the locations of the certificates
+func GetCertificateLocations(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error) {
+	conn, err := ClientFunc(services.Managers)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	mService := NewManagersClientFunc(conn)
+	resp, err := mService.GetCertificateLocations(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, nil
+}
+
+// GenerateCSR will do the rpc call to generate a certificate signing request
+func GenerateCSR(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error) {
+	conn, err := ClientFunc(services.Managers)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	mService := NewManagersClientFunc(conn)
+	resp, err := mService.GenerateCSR(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, nil
+}
+
+// ReplaceCertificate will do the rpc call to replace a certificate
+func ReplaceCertificate(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error) {
+	conn, err := ClientFunc(services.Managers)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	mService := NewManagersClientFunc(conn)
+	resp, err := mService.ReplaceCertificate(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, nil
+}
@@ -43,6 +43,23 @@ func GetRole(req roleproto.GetRoleRequest) (*roleproto.RoleResponse, error) {
 	return resp, err
 }
 
+// CreateRole defines the RPC call function for
+// the CreateRole from account-session micro service
+func CreateRole(req roleproto.RoleRequest) (*roleproto.RoleResponse, error) {
+	conn, err := ClientFunc(services.AccountSession)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	asService := NewRolesClientFunc(conn)
+	resp, err := asService.CreateRole(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
 // GetAllRoles defines the RPC call function for
 // the GetAllRoles from account-session micro service
 func GetAllRoles(req roleproto.GetRoleRequest) (*roleproto.RoleResponse, error) {
@@ -127,6 +127,51 @@ func TestGetAllRoles(t *testing.T) {
 	}
 }
 
+func TestCreateRole(t *testing.T) {
+	type args struct {
+		req roleproto.RoleRequest
+	}
+	tests := []struct {
+		name               string
+		args               args
+		ClientFunc         func(clientName string) (*grpc.ClientConn, error)
+		NewRolesClientFunc func(cc *grpc.ClientConn) roleproto.RolesClient
+		want               *roleproto.RoleResponse
+		wantErr            bool
+	}{
+		{
+			name:               "Client func error",
+			args:               args{},
+			ClientFunc:         func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewRolesClientFunc: func(cc *grpc.ClientConn) roleproto.RolesClient { return nil },
+			want:               nil,
+			wantErr:            true,
+		},
+		{
+			name:               "CreateRole error",
+			args:               args{},
+			ClientFunc:         func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewRolesClientFunc: func(cc *grpc.ClientConn) roleproto.RolesClient { return fakeStruct{} },
+			want:               nil,
+			wantErr:            true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewRolesClientFunc = tt.NewRolesClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CreateRole(tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateRole() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateRole() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestUpdateRole(t *testing.T) {
 	type args struct {
 		req roleproto.UpdateRoleRequest
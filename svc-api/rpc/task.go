@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
@@ -198,3 +199,46 @@ func GetTaskService(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, err
 	defer conn.Close()
 	return rsp, nil
 }
+
+// UpdateTaskService will do the rpc calls for the svc-task UpdateTaskService
+func UpdateTaskService(req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error) {
+	conn, connErr := ClientFunc(services.Tasks)
+	if connErr != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", connErr)
+	}
+	defer conn.Close()
+	tService := NewGetTaskServiceClientFunc(conn)
+	// perform rpc call to svc-task to update TaskService resource
+	rsp, err := tService.UpdateTaskService(context.TODO(), req)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to make UpdateTaskService rpc call: %v", err)
+	}
+	return rsp, nil
+}
+
+// DoExportTaskHistory will do the rpc calls for the svc-task ExportTaskHistory,
+// send is called with each message of the stream
+func DoExportTaskHistory(ctx context.Context, req *taskproto.GetTaskRequest, send func(*taskproto.TaskHistoryRecord) error) error {
+	conn, connErr := ClientFunc(services.Tasks)
+	if connErr != nil {
+		return fmt.Errorf("Failed to create client connection: %v", connErr)
+	}
+	defer conn.Close()
+	tService := NewGetTaskServiceClientFunc(conn)
+	stream, err := tService.ExportTaskHistory(ctx, req)
+	if err != nil {
+		return fmt.Errorf("error while trying to make ExportTaskHistory rpc call: %v", err)
+	}
+	for {
+		record, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error while trying to receive the task history: %v", err)
+		}
+		if err := send(record); err != nil {
+			return err
+		}
+	}
+}
@@ -387,6 +387,7 @@ func MockGetEvtSubscriptions(searchKey string) ([]evmodel.Subscription, error) {
 				SubscriptionID:       "81de0110-c35a-4859-984c-072d6c5a32d7",
 				Destination:          "https://odim.destination.com:9090/events",
 				Name:                 "Subscription",
+				Protocol:             "Redfish",
 				Location:             "https://odim.2.com/EventService/Subscriptions/1",
 				Context:              "context",
 				EventTypes:           []string{"Alert", "ResourceAdded"},
@@ -642,3 +643,118 @@ func MockDeleteUndeliveredEventsFlag(destination string) error {
 func MockDeleteUndeliveredEvents(destination string) error {
 	return nil
 }
+
+// MockGetDeliverySequence is for mocking up of getting the sequence number of the events of a destination
+func MockGetDeliverySequence(destinationID string) (int, error) {
+	return 1, nil
+}
+
+// MockSaveEventDestination is for mocking up of saving the event destination
+func MockSaveEventDestination(destinationID, destination string) error {
+	return nil
+}
+
+// MockGetEventDestinations is for mocking up of getting the event destinations
+func MockGetEventDestinations() (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+// MockDeleteEventDestination is for mocking up of deleting the event destination
+func MockDeleteEventDestination(destinationID string) error {
+	return nil
+}
+
+// MockSaveQueuedEvent is for mocking up of saving the queued event
+func MockSaveQueuedEvent(event evmodel.QueuedEvent) error {
+	return nil
+}
+
+// MockGetQueuedEvents is for mocking up of getting the queued events of a destination
+func MockGetQueuedEvents(destinationID string) ([]evmodel.QueuedEvent, error) {
+	return []evmodel.QueuedEvent{}, nil
+}
+
+// MockDeleteQueuedEvent is for mocking up of deleting the queued event
+func MockDeleteQueuedEvent(destinationID, eventID string) error {
+	return nil
+}
+
+// MockAcquireDeliveryLease is for mocking up of acquiring the delivery lease, the lease
+// is not given so that the events are not delivered in the unit tests
+func MockAcquireDeliveryLease(destinationID, owner string, leaseSeconds int) (bool, error) {
+	return false, nil
+}
+
+// MockReleaseDeliveryLease is for mocking up of releasing the delivery lease
+func MockReleaseDeliveryLease(destinationID, owner string) error {
+	return nil
+}
+
+// MockSaveDeadLetterEvent is for mocking up of saving the undelivered event of a subscription
+func MockSaveDeadLetterEvent(event evmodel.DeadLetterEvent) error {
+	return nil
+}
+
+// MockGetDeadLetterEvents is for mocking up of getting the undelivered events of a subscription
+func MockGetDeadLetterEvents(subscriptionID string) ([]evmodel.DeadLetterEvent, error) {
+	if subscriptionID == "81de0110-c35a-4859-984c-072d6c5a32d7" {
+		return []evmodel.DeadLetterEvent{
+			{
+				QueuedEvent: evmodel.QueuedEvent{
+					ID:          "00000000000000000001",
+					Destination: "https://odim.destination.com:9090/events",
+					Event:       `{"Events":[{"EventType":"Alert","MessageId":"IndicatorChanged"}]}`,
+					Attempts:    2,
+					LastError:   "destination responded with the status 503 Service Unavailable",
+				},
+				SubscriptionID: subscriptionID,
+			},
+		}, nil
+	}
+	return []evmodel.DeadLetterEvent{}, nil
+}
+
+// MockDeleteDeadLetterEvent is for mocking up of deleting the undelivered event of a subscription
+func MockDeleteDeadLetterEvent(subscriptionID, eventID string) error {
+	return nil
+}
+
+// MockGetDeliveryRetrySettings is for mocking up of get delivery retry settings
+func MockGetDeliveryRetrySettings() (*evmodel.DeliveryRetrySettings, error) {
+	return nil, nil
+}
+
+// MockSaveDeliveryRetrySettings is for mocking up of save delivery retry settings
+func MockSaveDeliveryRetrySettings(settings evmodel.DeliveryRetrySettings) error {
+	return nil
+}
+
+// MockSaveSSEEvent is for mocking up of adding an event to the SSE event buffer
+func MockSaveSSEEvent(eventFormatType, data string, bufferSize int) (int, error) {
+	return 1, nil
+}
+
+// MockGetLastSSEEventID is for mocking up of get the ID of the last event in the SSE event buffer
+func MockGetLastSSEEventID() (int, error) {
+	return 0, nil
+}
+
+// MockGetSSEEvent is for mocking up of get an event from the SSE event buffer
+func MockGetSSEEvent(id int) (*evmodel.SSEEvent, error) {
+	return nil, nil
+}
+
+// MockSaveSSEClientInstance is for mocking up of save the instance having SSE clients connected
+func MockSaveSSEClientInstance(instanceID string, leaseSeconds int) error {
+	return nil
+}
+
+// MockDeleteSSEClientInstance is for mocking up of delete the instance having SSE clients connected
+func MockDeleteSSEClientInstance(instanceID string) error {
+	return nil
+}
+
+// MockIsSSEClientConnected is for mocking up of check whether any SSE client is connected
+func MockIsSSEClientConnected() (bool, error) {
+	return true, nil
+}
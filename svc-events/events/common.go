@@ -73,6 +73,26 @@ type DB struct {
 	GetAggregateHosts                func(aggregateIP string) ([]string, error)
 	UpdateAggregateHosts             func(aggregateId string, hostIP []string) error
 	GetAggregateList                 func(hostIP string) ([]string, error)
+	GetDeliverySequence              func(destinationID string) (int, error)
+	SaveEventDestination             func(destinationID, destination string) error
+	GetEventDestinations             func() (map[string]string, error)
+	DeleteEventDestination           func(destinationID string) error
+	SaveQueuedEvent                  func(evmodel.QueuedEvent) error
+	GetQueuedEvents                  func(destinationID string) ([]evmodel.QueuedEvent, error)
+	DeleteQueuedEvent                func(destinationID, eventID string) error
+	AcquireDeliveryLease             func(destinationID, owner string, leaseSeconds int) (bool, error)
+	ReleaseDeliveryLease             func(destinationID, owner string) error
+	SaveDeadLetterEvent              func(evmodel.DeadLetterEvent) error
+	GetDeadLetterEvents              func(subscriptionID string) ([]evmodel.DeadLetterEvent, error)
+	DeleteDeadLetterEvent            func(subscriptionID, eventID string) error
+	GetDeliveryRetrySettings         func() (*evmodel.DeliveryRetrySettings, error)
+	SaveDeliveryRetrySettings        func(evmodel.DeliveryRetrySettings) error
+	SaveSSEEvent                     func(eventFormatType, data string, bufferSize int) (int, error)
+	GetLastSSEEventID                func() (int, error)
+	GetSSEEvent                      func(id int) (*evmodel.SSEEvent, error)
+	SaveSSEClientInstance            func(instanceID string, leaseSeconds int) error
+	DeleteSSEClientInstance          func(instanceID string) error
+	IsSSEClientConnected             func() (bool, error)
 }
 
 // fillTaskData is to fill task information in TaskData struct
@@ -276,9 +296,10 @@ func validateFields(request *evmodel.RequestBody) (int32, string, []interface{},
 
 	if request.DeliveryRetryPolicy == "" {
 		request.DeliveryRetryPolicy = evmodel.DeliveryRetryPolicy
-	} else if request.DeliveryRetryPolicy == "TerminateAfterRetries" || request.DeliveryRetryPolicy == "SuspendRetries" || request.DeliveryRetryPolicy == "RetryForeverWithBackoff" {
+	} else if request.DeliveryRetryPolicy == "RetryForeverWithBackoff" {
 		return http.StatusBadRequest, errResponse.PropertyValueNotInList, []interface{}{request.DeliveryRetryPolicy, "DeliveryRetryPolicy"}, fmt.Errorf("Unsupported DeliveryRetryPolicy")
-	} else if request.DeliveryRetryPolicy != evmodel.DeliveryRetryPolicy {
+	} else if request.DeliveryRetryPolicy != evmodel.DeliveryRetryPolicy &&
+		request.DeliveryRetryPolicy != evmodel.TerminateAfterRetries && request.DeliveryRetryPolicy != evmodel.SuspendRetries {
 		return http.StatusBadRequest, errResponse.PropertyValueNotInList, []interface{}{request.DeliveryRetryPolicy, "DeliveryRetryPolicy"}, fmt.Errorf("Invalid DeliveryRetryPolicy")
 	}
 
@@ -454,6 +475,10 @@ func (e *ExternalInterfaces) checkCollection(origin string) ([]string, string, b
 		return collection, "FabricsCollection", true, "", false, err
 	case "/redfish/v1/TaskService/Tasks":
 		return []string{}, "TasksCollection", true, "", false, nil
+	case "/redfish/v1/LicenseService/Licenses":
+		return []string{}, "LicensesCollection", true, "", false, nil
+	case "/redfish/v1/TelemetryService/Triggers":
+		return []string{}, "TriggersCollection", true, "", false, nil
 	}
 	if strings.Contains(origin, "/AggregationService/Aggregates/") {
 		aggregateCollection, err := e.GetAggregateData(origin)
@@ -229,6 +229,11 @@ func (e *ExternalInterfaces) DeleteEventSubscriptionsDetails(req *eventsproto.Ev
 			evcommon.GenErrorResponse(errorMessage, response.ResourceNotFound, http.StatusBadRequest, msgArgs, &resp)
 			return resp
 		}
+
+		// Delete the undelivered events of the subscription
+		if err = e.purgeDeadLetterEvents(evtSubscription.SubscriptionID); err != nil {
+			log.Error("error while deleting the undelivered events of the subscription: " + err.Error())
+		}
 	}
 
 	commonResponse := response.Response{
@@ -271,53 +276,13 @@ func (e *ExternalInterfaces) deleteAndReSubscribetoEvents(evtSubscription evmode
 			deleteflag = true
 		}
 
-		var context, protocol, destination, name string
-		var eventTypes, messageIDs, resourceTypes []string
-
-		for index, evtSub := range subscriptionDetails {
+		var remainingSubscriptions []evmodel.Subscription
+		for _, evtSub := range subscriptionDetails {
 			if evtSubscription.SubscriptionID != evtSub.SubscriptionID {
-				if len(evtSub.EventTypes) > 0 && (index == 0 || len(eventTypes) > 0) {
-					eventTypes = append(eventTypes, evtSub.EventTypes...)
-				} else {
-					eventTypes = []string{}
-				}
-
-				if len(evtSub.MessageIds) > 0 && (index == 0 || len(messageIDs) > 0) {
-					messageIDs = append(messageIDs, evtSub.MessageIds...)
-				} else {
-					messageIDs = []string{}
-				}
-
-				if len(evtSub.ResourceTypes) > 0 && (index == 0 || len(resourceTypes) > 0) {
-					resourceTypes = append(resourceTypes, evtSub.ResourceTypes...)
-				} else {
-					resourceTypes = []string{}
-				}
-				name = evtSub.Name
-				context = evtSub.Context
-				protocol = evtSub.Protocol
-				destination = evtSub.Destination
+				remainingSubscriptions = append(remainingSubscriptions, evtSub)
 			}
 		}
-
-		eventTypesCount := len(eventTypes)
-		messageIDsCount := len(messageIDs)
-		resourceTypesCount := len(resourceTypes)
-		removeDuplicatesFromSlice(&eventTypes, &eventTypesCount)
-		removeDuplicatesFromSlice(&messageIDs, &messageIDsCount)
-		removeDuplicatesFromSlice(&resourceTypes, &resourceTypesCount)
-		var httpHeadersSlice = make([]evmodel.HTTPHeaders, 0)
-		httpHeadersSlice = append(httpHeadersSlice, evmodel.HTTPHeaders{ContentType: "application/json"})
-		subscriptionPost := evmodel.EvtSubPost{
-			Name:          name,
-			EventTypes:    eventTypes,
-			MessageIds:    messageIDs,
-			ResourceTypes: resourceTypes,
-			HTTPHeaders:   httpHeadersSlice,
-			Context:       context,
-			Protocol:      protocol,
-			Destination:   destination,
-		}
+		subscriptionPost := getSubscriptionPost(remainingSubscriptions)
 
 		err = e.subscribe(subscriptionPost, origin, deleteflag, sessionToken)
 		if err != nil {
@@ -327,6 +292,57 @@ func (e *ExternalInterfaces) deleteAndReSubscribetoEvents(evtSubscription evmode
 	return nil
 }
 
+// getSubscriptionPost combines the subscriptions of an origin resource into the
+// subscription request for its device. If any of the subscriptions has empty
+// EventTypes, MessageIds or ResourceTypes, the device is subscribed to all of them.
+func getSubscriptionPost(subscriptions []evmodel.Subscription) evmodel.EvtSubPost {
+	var context, protocol, destination, name string
+	var eventTypes, messageIDs, resourceTypes []string
+
+	for index, evtSub := range subscriptions {
+		if len(evtSub.EventTypes) > 0 && (index == 0 || len(eventTypes) > 0) {
+			eventTypes = append(eventTypes, evtSub.EventTypes...)
+		} else {
+			eventTypes = []string{}
+		}
+
+		if len(evtSub.MessageIds) > 0 && (index == 0 || len(messageIDs) > 0) {
+			messageIDs = append(messageIDs, evtSub.MessageIds...)
+		} else {
+			messageIDs = []string{}
+		}
+
+		if len(evtSub.ResourceTypes) > 0 && (index == 0 || len(resourceTypes) > 0) {
+			resourceTypes = append(resourceTypes, evtSub.ResourceTypes...)
+		} else {
+			resourceTypes = []string{}
+		}
+		name = evtSub.Name
+		context = evtSub.Context
+		protocol = evtSub.Protocol
+		destination = evtSub.Destination
+	}
+
+	eventTypesCount := len(eventTypes)
+	messageIDsCount := len(messageIDs)
+	resourceTypesCount := len(resourceTypes)
+	removeDuplicatesFromSlice(&eventTypes, &eventTypesCount)
+	removeDuplicatesFromSlice(&messageIDs, &messageIDsCount)
+	removeDuplicatesFromSlice(&resourceTypes, &resourceTypesCount)
+	var httpHeadersSlice = make([]evmodel.HTTPHeaders, 0)
+	httpHeadersSlice = append(httpHeadersSlice, evmodel.HTTPHeaders{ContentType: "application/json"})
+	return evmodel.EvtSubPost{
+		Name:          name,
+		EventTypes:    eventTypes,
+		MessageIds:    messageIDs,
+		ResourceTypes: resourceTypes,
+		HTTPHeaders:   httpHeadersSlice,
+		Context:       context,
+		Protocol:      protocol,
+		Destination:   destination,
+	}
+}
+
 func isCollectionOriginResourceURI(origin string) bool {
 
 	if origin == "" || !strings.HasPrefix(origin, "/") {
@@ -0,0 +1,528 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
+	"github.com/ODIM-Project/ODIM/svc-events/evresponse"
+	uuid "github.com/satori/go.uuid"
+)
+
+// deliveryLeaseSeconds is the time for which an instance holds the lease to deliver
+// the events of a destination. The lease is renewed till the delivery queue is empty.
+const deliveryLeaseSeconds = 60
+
+// instanceID identifies the instance of the service holding a delivery lease
+var instanceID = uuid.NewV4().String()
+
+// deliveryWorkers has the destinations whose events are being delivered by this instance
+var deliveryWorkers = struct {
+	sync.Mutex
+	destinations map[string]bool
+}{destinations: make(map[string]bool)}
+
+// getDestinationID returns the ID of the delivery queue of the destination
+func getDestinationID(destination string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(destination)))
+}
+
+// StartEventDelivery moves the undelivered events saved by the earlier releases into the
+// delivery queue, and periodically starts the delivery for the destinations whose lease
+// is not held by any instance, so that the queued events survive the restart of the service.
+// The delivery retry settings updated by the other instances are also reloaded periodically.
+func (e *ExternalInterfaces) StartEventDelivery() {
+	e.migrateUndeliveredEvents()
+	for {
+		e.loadDeliveryRetrySettings()
+		destinations, err := e.GetEventDestinations()
+		if err != nil {
+			log.Error("error while getting the event destinations: " + err.Error())
+		}
+		for destinationID, destination := range destinations {
+			e.startDeliveryWorker(destinationID, destination)
+		}
+		time.Sleep(deliveryLeaseSeconds * time.Second)
+	}
+}
+
+// enqueueEvent adds the event to the delivery queue of the destination. The event is
+// saved as undelivered for the subscriptions whose delivery is suspended.
+func (e *ExternalInterfaces) enqueueEvent(destination string, subscriptions []evmodel.Subscription, event []byte) error {
+	destinationID := getDestinationID(destination)
+	sequence, err := e.GetDeliverySequence(destinationID)
+	if err != nil {
+		log.Error("error while queuing the event for " + destination + ": " + err.Error())
+		return err
+	}
+	now := time.Now().UTC()
+	queuedEvent := evmodel.QueuedEvent{
+		ID:              fmt.Sprintf("%020d", sequence),
+		DestinationID:   destinationID,
+		Destination:     destination,
+		Event:           string(event),
+		QueuedTime:      now,
+		NextAttemptTime: now,
+	}
+	queued := make(map[string]bool)
+	for _, subscription := range subscriptions {
+		if queued[subscription.SubscriptionID] {
+			continue
+		}
+		queued[subscription.SubscriptionID] = true
+		if subscription.Suspended {
+			e.saveDeadLetterEvent(queuedEvent, subscription.SubscriptionID, "delivery of the subscription is suspended")
+			continue
+		}
+		queuedEvent.SubscriptionIDs = append(queuedEvent.SubscriptionIDs, subscription.SubscriptionID)
+		queuedEvent.DeliveryRetryPolicy = getRetryPolicy(queuedEvent.DeliveryRetryPolicy, subscription.DeliveryRetryPolicy)
+	}
+	if len(queuedEvent.SubscriptionIDs) == 0 {
+		return nil
+	}
+	if err := e.SaveQueuedEvent(queuedEvent); err != nil {
+		log.Error("error while queuing the event for " + destination + ": " + err.Error())
+		return err
+	}
+	if err := e.SaveEventDestination(destinationID, destination); err != nil {
+		log.Error("error while saving the event destination " + destination + ": " + err.Error())
+	}
+	e.startDeliveryWorker(destinationID, destination)
+	return nil
+}
+
+// getRetryPolicy returns the policy which retries the longest, when the subscriptions
+// sharing the destination have different delivery retry policies
+func getRetryPolicy(current, policy string) string {
+	rank := func(policy string) int {
+		switch policy {
+		case evmodel.TerminateAfterRetries:
+			return 1
+		case evmodel.SuspendRetries:
+			return 2
+		default:
+			return 3
+		}
+	}
+	if current == "" || rank(policy) > rank(current) {
+		if policy == "" {
+			return evmodel.DeliveryRetryPolicy
+		}
+		return policy
+	}
+	return current
+}
+
+// startDeliveryWorker starts delivering the events of the destination,
+// if this instance is not delivering them already
+func (e *ExternalInterfaces) startDeliveryWorker(destinationID, destination string) {
+	deliveryWorkers.Lock()
+	defer deliveryWorkers.Unlock()
+	if deliveryWorkers.destinations[destinationID] {
+		return
+	}
+	deliveryWorkers.destinations[destinationID] = true
+	go e.deliverQueuedEvents(destinationID, destination)
+}
+
+// deliverQueuedEvents delivers the queued events of the destination in the order of
+// their arrival. An event is delivered only after the events queued before it are
+// either delivered or moved out of the queue after all the retries. The queue is read
+// once and walked in order, and is read again for the events queued in the meantime.
+func (e *ExternalInterfaces) deliverQueuedEvents(destinationID, destination string) {
+	defer func() {
+		deliveryWorkers.Lock()
+		delete(deliveryWorkers.destinations, destinationID)
+		deliveryWorkers.Unlock()
+	}()
+	for {
+		if !e.holdDeliveryLease(destinationID, destination) {
+			return
+		}
+		queuedEvents, err := e.GetQueuedEvents(destinationID)
+		if err != nil {
+			log.Error("error while getting the queued events for " + destination + ": " + err.Error())
+			e.releaseDeliveryLease(destinationID)
+			return
+		}
+		if len(queuedEvents) == 0 {
+			if err := e.DeleteEventDestination(destinationID); err != nil {
+				log.Error("error while deleting the event destination " + destination + ": " + err.Error())
+			}
+			// an event could have been queued while deleting the destination
+			if queuedEvents, err = e.GetQueuedEvents(destinationID); err == nil && len(queuedEvents) > 0 {
+				e.SaveEventDestination(destinationID, destination)
+				continue
+			}
+			e.releaseDeliveryLease(destinationID)
+			return
+		}
+		for len(queuedEvents) > 0 {
+			if wait := time.Until(queuedEvents[0].NextAttemptTime); wait > 0 {
+				// waiting in parts, so that the lease is renewed before it expires
+				if maxWait := deliveryLeaseSeconds * time.Second / 2; wait > maxWait {
+					wait = maxWait
+				}
+				time.Sleep(wait)
+			} else if queuedEvent, queued := e.attemptDelivery(queuedEvents[0]); queued {
+				queuedEvents[0] = queuedEvent
+			} else {
+				queuedEvents = queuedEvents[1:]
+			}
+			if !e.holdDeliveryLease(destinationID, destination) {
+				return
+			}
+		}
+	}
+}
+
+// holdDeliveryLease acquires or renews the lease to deliver the events of the destination.
+// It returns false if the lease is held by another instance or could not be acquired.
+func (e *ExternalInterfaces) holdDeliveryLease(destinationID, destination string) bool {
+	acquired, err := e.AcquireDeliveryLease(destinationID, instanceID, deliveryLeaseSeconds)
+	if err != nil {
+		log.Error("error while acquiring the delivery lease for " + destination + ": " + err.Error())
+		return false
+	}
+	return acquired
+}
+
+func (e *ExternalInterfaces) releaseDeliveryLease(destinationID string) {
+	if err := e.ReleaseDeliveryLease(destinationID, instanceID); err != nil {
+		log.Error("error while releasing the delivery lease: " + err.Error())
+	}
+}
+
+// attemptDelivery sends the queued event to the destination. If the delivery fails, the
+// next attempt is scheduled, or the event is moved to the undelivered events of the
+// subscriptions as per their delivery retry policy. It returns the event with its next
+// attempt scheduled and true, if the event is still in the queue.
+func (e *ExternalInterfaces) attemptDelivery(queuedEvent evmodel.QueuedEvent) (evmodel.QueuedEvent, bool) {
+	subscriptions, err := e.getQueuedEventSubscriptions(queuedEvent)
+	if err != nil {
+		log.Error("error while getting the subscriptions of the queued event: " + err.Error())
+		queuedEvent.NextAttemptTime = time.Now().UTC().Add(getRetryInterval(1))
+		if err := e.SaveQueuedEvent(queuedEvent); err != nil {
+			log.Error("error while updating the queued event: " + err.Error())
+		}
+		return queuedEvent, true
+	}
+	if len(subscriptions) == 0 {
+		e.removeQueuedEvent(queuedEvent)
+		return queuedEvent, false
+	}
+	queuedEvent.Attempts++
+	queuedEvent.LastAttemptTime = time.Now().UTC()
+	resp, err := sendEvent(queuedEvent.Destination, []byte(queuedEvent.Event))
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+			log.Info("Event is successfully forwarded to " + queuedEvent.Destination)
+			e.removeQueuedEvent(queuedEvent)
+			return queuedEvent, false
+		}
+		err = fmt.Errorf("destination responded with the status %v", resp.Status)
+	}
+	queuedEvent.LastError = err.Error()
+	log.Error("error while forwarding the event to " + queuedEvent.Destination + ": " + queuedEvent.LastError)
+
+	if queuedEvent.DeliveryRetryPolicy == evmodel.DeliveryRetryPolicy ||
+		queuedEvent.Attempts <= getDeliveryRetrySettings().DeliveryRetryAttempts {
+		queuedEvent.NextAttemptTime = queuedEvent.LastAttemptTime.Add(getRetryInterval(queuedEvent.Attempts))
+		if err := e.SaveQueuedEvent(queuedEvent); err != nil {
+			log.Error("error while updating the queued event: " + err.Error())
+		}
+		return queuedEvent, true
+	}
+
+	for _, subscription := range subscriptions {
+		e.saveDeadLetterEvent(queuedEvent, subscription.SubscriptionID, queuedEvent.LastError)
+		if subscription.DeliveryRetryPolicy == evmodel.SuspendRetries {
+			log.Warn("suspending the delivery of the subscription " + subscription.SubscriptionID +
+				" after " + fmt.Sprint(queuedEvent.Attempts) + " attempts")
+			subscription.Suspended = true
+			if err := e.UpdateEventSubscription(subscription); err != nil {
+				log.Error("error while suspending the subscription " + subscription.SubscriptionID + ": " + err.Error())
+			}
+		}
+	}
+	e.removeQueuedEvent(queuedEvent)
+	return queuedEvent, false
+}
+
+// getQueuedEventSubscriptions returns the subscriptions of the queued event for which
+// the event is to be delivered. The event is saved as undelivered for the subscriptions
+// suspended after the event is queued.
+func (e *ExternalInterfaces) getQueuedEventSubscriptions(queuedEvent evmodel.QueuedEvent) ([]evmodel.Subscription, error) {
+	var subscriptions []evmodel.Subscription
+	for _, subscriptionID := range queuedEvent.SubscriptionIDs {
+		subscription, err := e.getSubscription(subscriptionID)
+		if err != nil {
+			return nil, err
+		}
+		if subscription == nil {
+			log.Info("event not forwarded as the subscription " + subscriptionID + " is deleted")
+			continue
+		}
+		if subscription.Suspended {
+			e.saveDeadLetterEvent(queuedEvent, subscriptionID, "delivery of the subscription is suspended")
+			continue
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+	return subscriptions, nil
+}
+
+// getSubscription returns the subscription with the given ID, or nil if it does not exist
+func (e *ExternalInterfaces) getSubscription(subscriptionID string) (*evmodel.Subscription, error) {
+	subscriptions, err := e.GetEvtSubscriptions(subscriptionID)
+	if err != nil && !strings.Contains(err.Error(), "No data found for the key") {
+		return nil, err
+	}
+	for _, subscription := range subscriptions {
+		// since the subscriptions are searched with pattern, the subscription id has to be matched
+		if subscription.SubscriptionID == subscriptionID {
+			return &subscription, nil
+		}
+	}
+	return nil, nil
+}
+
+func (e *ExternalInterfaces) removeQueuedEvent(queuedEvent evmodel.QueuedEvent) {
+	if err := e.DeleteQueuedEvent(queuedEvent.DestinationID, queuedEvent.ID); err != nil {
+		log.Error("error while removing the event from the delivery queue: " + err.Error())
+	}
+}
+
+func (e *ExternalInterfaces) saveDeadLetterEvent(queuedEvent evmodel.QueuedEvent, subscriptionID, reason string) {
+	if queuedEvent.LastError == "" {
+		queuedEvent.LastError = reason
+	}
+	deadLetterEvent := evmodel.DeadLetterEvent{
+		QueuedEvent:      queuedEvent,
+		SubscriptionID:   subscriptionID,
+		DeadLetteredTime: time.Now().UTC(),
+	}
+	if err := e.SaveDeadLetterEvent(deadLetterEvent); err != nil {
+		log.Error("error while saving the undelivered event of the subscription " + subscriptionID + ": " + err.Error())
+	}
+}
+
+// getRetryInterval returns the time to wait before the next attempt. The interval starts
+// with DeliveryRetryIntervalSeconds and is doubled after every failed attempt till
+// DeliveryRetryMaxIntervalSeconds. A random jitter of up to half of the interval is
+// subtracted, so that the retries of the destinations failed together are spread out.
+func getRetryInterval(attempts int) time.Duration {
+	interval := time.Duration(getDeliveryRetrySettings().DeliveryRetryIntervalSeconds) * time.Second
+	maxInterval := time.Duration(config.Data.EventConf.DeliveryRetryMaxIntervalSeconds) * time.Second
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+	for i := 1; i < attempts && interval < maxInterval; i++ {
+		interval *= 2
+	}
+	if interval > maxInterval {
+		interval = maxInterval
+	}
+	return interval - time.Duration(rand.Int63n(int64(interval/2)+1))
+}
+
+// migrateUndeliveredEvents moves the undelivered events saved by the earlier
+// releases into the delivery queue of their destinations
+func (e *ExternalInterfaces) migrateUndeliveredEvents() {
+	keys, err := e.GetAllMatchingDetails(evmodel.UndeliveredEvents, "", common.OnDisk)
+	if err != nil {
+		log.Error("error while getting the undelivered events: " + err.Error())
+		return
+	}
+	for _, key := range keys {
+		// undelivered events are saved with the key destination:eventID
+		index := strings.LastIndex(key, ":")
+		if index < 0 {
+			continue
+		}
+		destination := key[:index]
+		data, err := e.GetUndeliveredEvents(key)
+		if err != nil {
+			log.Error("error while getting the undelivered event: " + err.Error())
+			continue
+		}
+		var event string
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			event = data
+		}
+		var subscriptions []evmodel.Subscription
+		destinationSubscriptions, _ := e.GetEvtSubscriptions(destination)
+		for _, subscription := range destinationSubscriptions {
+			if subscription.Destination == destination {
+				subscriptions = append(subscriptions, subscription)
+			}
+		}
+		if err := e.enqueueEvent(destination, subscriptions, []byte(event)); err != nil {
+			continue
+		}
+		if err := e.DeleteUndeliveredEvents(key); err != nil {
+			log.Error("error while deleting the undelivered event: " + err.Error())
+		}
+	}
+}
+
+// GetUndeliveredEventsCollection returns the events which could not be delivered
+// to the destination of the subscription
+func (e *ExternalInterfaces) GetUndeliveredEventsCollection(req *eventsproto.EventRequest) response.RPC {
+	subscription, resp := e.authorizeUndeliveredEventsRequest(req)
+	if subscription == nil {
+		return resp
+	}
+	deadLetterEvents, err := e.GetDeadLetterEvents(subscription.SubscriptionID)
+	if err != nil {
+		errorMessage := "error while getting the undelivered events: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	undeliveredEventsURI := "/redfish/v1/EventService/Subscriptions/" + subscription.SubscriptionID + "/UndeliveredEvents"
+	undeliveredEvents := evresponse.UndeliveredEventsResponse{
+		OdataID:      undeliveredEventsURI,
+		Name:         "Undelivered Events",
+		Description:  "Events which could not be delivered to the destination of the subscription",
+		MembersCount: len(deadLetterEvents),
+		Members:      []evresponse.UndeliveredEvent{},
+		Actions: evresponse.UndeliveredEventsActions{
+			Replay: evresponse.ActionTarget{
+				Target: undeliveredEventsURI + "/Actions/UndeliveredEvents.Replay",
+			},
+		},
+	}
+	for _, deadLetterEvent := range deadLetterEvents {
+		var event interface{} = deadLetterEvent.Event
+		if json.Valid([]byte(deadLetterEvent.Event)) {
+			event = json.RawMessage(deadLetterEvent.Event)
+		}
+		undeliveredEvent := evresponse.UndeliveredEvent{
+			ID:              deadLetterEvent.ID,
+			Event:           event,
+			Attempts:        deadLetterEvent.Attempts,
+			QueuedTime:      deadLetterEvent.QueuedTime.Format(time.RFC3339),
+			UndeliveredTime: deadLetterEvent.DeadLetteredTime.Format(time.RFC3339),
+			LastError:       deadLetterEvent.LastError,
+		}
+		if !deadLetterEvent.LastAttemptTime.IsZero() {
+			undeliveredEvent.LastAttemptTime = deadLetterEvent.LastAttemptTime.Format(time.RFC3339)
+		}
+		undeliveredEvents.Members = append(undeliveredEvents.Members, undeliveredEvent)
+	}
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	resp.Body = undeliveredEvents
+	return resp
+}
+
+// ReplayUndeliveredEvents adds the undelivered events of the subscription back to the
+// delivery queue of its destination. The delivery of the subscription is resumed,
+// if it was suspended.
+func (e *ExternalInterfaces) ReplayUndeliveredEvents(req *eventsproto.EventRequest) response.RPC {
+	subscription, resp := e.authorizeUndeliveredEventsRequest(req)
+	if subscription == nil {
+		return resp
+	}
+	deadLetterEvents, err := e.GetDeadLetterEvents(subscription.SubscriptionID)
+	if err != nil {
+		errorMessage := "error while getting the undelivered events: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if subscription.Suspended {
+		subscription.Suspended = false
+		if err := e.UpdateEventSubscription(*subscription); err != nil {
+			errorMessage := "error while resuming the subscription: " + err.Error()
+			log.Error(errorMessage)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+		log.Info("resumed the delivery of the subscription " + subscription.SubscriptionID)
+	}
+	for _, deadLetterEvent := range deadLetterEvents {
+		if err := e.enqueueEvent(subscription.Destination, []evmodel.Subscription{*subscription}, []byte(deadLetterEvent.Event)); err != nil {
+			errorMessage := "error while replaying the undelivered events: " + err.Error()
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+		if err := e.DeleteDeadLetterEvent(subscription.SubscriptionID, deadLetterEvent.ID); err != nil {
+			log.Error("error while deleting the replayed event: " + err.Error())
+		}
+	}
+	return common.GeneralError(http.StatusOK, response.Success, "", nil, nil)
+}
+
+// PurgeUndeliveredEvents deletes the undelivered events of the subscription
+func (e *ExternalInterfaces) PurgeUndeliveredEvents(req *eventsproto.EventRequest) response.RPC {
+	subscription, resp := e.authorizeUndeliveredEventsRequest(req)
+	if subscription == nil {
+		return resp
+	}
+	if err := e.purgeDeadLetterEvents(subscription.SubscriptionID); err != nil {
+		errorMessage := "error while purging the undelivered events: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	return common.GeneralError(http.StatusOK, response.Success, "", nil, nil)
+}
+
+func (e *ExternalInterfaces) purgeDeadLetterEvents(subscriptionID string) error {
+	deadLetterEvents, err := e.GetDeadLetterEvents(subscriptionID)
+	if err != nil {
+		return err
+	}
+	for _, deadLetterEvent := range deadLetterEvents {
+		if err := e.DeleteDeadLetterEvent(subscriptionID, deadLetterEvent.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// authorizeUndeliveredEventsRequest authorizes the session and returns the subscription of
+// the request. If the subscription is nil, the returned response has the error.
+func (e *ExternalInterfaces) authorizeUndeliveredEventsRequest(req *eventsproto.EventRequest) (*evmodel.Subscription, response.RPC) {
+	authResp := e.Auth(req.SessionToken, []string{common.PrivilegeConfigureComponents}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		log.Error("error while trying to authenticate session: status code: " + fmt.Sprint(authResp.StatusCode) + ", status message: " + authResp.StatusMessage)
+		return nil, authResp
+	}
+	subscription, err := e.getSubscription(req.EventSubscriptionID)
+	if err != nil {
+		errorMessage := "error while getting the subscription: " + err.Error()
+		log.Error(errorMessage)
+		return nil, common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if subscription == nil {
+		errorMessage := "Subscription details not found for subscription id: " + req.EventSubscriptionID
+		log.Error(errorMessage)
+		return nil, common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"EventSubscription", req.EventSubscriptionID}, nil)
+	}
+	return subscription, response.RPC{}
+}
@@ -0,0 +1,306 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
+	"github.com/ODIM-Project/ODIM/svc-events/evresponse"
+)
+
+// mockDeliveryStore keeps the delivery queue, undelivered events
+// and subscriptions in memory for the unit tests
+type mockDeliveryStore struct {
+	sync.Mutex
+	queue         map[string]evmodel.QueuedEvent
+	deadLetters   map[string]evmodel.DeadLetterEvent
+	subscriptions map[string]evmodel.Subscription
+}
+
+func getMockDeliveryMethods(subscriptions ...evmodel.Subscription) (ExternalInterfaces, *mockDeliveryStore) {
+	store := &mockDeliveryStore{
+		queue:         make(map[string]evmodel.QueuedEvent),
+		deadLetters:   make(map[string]evmodel.DeadLetterEvent),
+		subscriptions: make(map[string]evmodel.Subscription),
+	}
+	for _, subscription := range subscriptions {
+		store.subscriptions[subscription.SubscriptionID] = subscription
+	}
+	pc := getMockMethods()
+	pc.GetEvtSubscriptions = func(searchKey string) ([]evmodel.Subscription, error) {
+		store.Lock()
+		defer store.Unlock()
+		if subscription, ok := store.subscriptions[searchKey]; ok {
+			return []evmodel.Subscription{subscription}, nil
+		}
+		return nil, nil
+	}
+	pc.UpdateEventSubscription = func(subscription evmodel.Subscription) error {
+		store.Lock()
+		defer store.Unlock()
+		store.subscriptions[subscription.SubscriptionID] = subscription
+		return nil
+	}
+	pc.SaveQueuedEvent = func(event evmodel.QueuedEvent) error {
+		store.Lock()
+		defer store.Unlock()
+		store.queue[event.ID] = event
+		return nil
+	}
+	pc.DeleteQueuedEvent = func(destinationID, eventID string) error {
+		store.Lock()
+		defer store.Unlock()
+		delete(store.queue, eventID)
+		return nil
+	}
+	pc.SaveDeadLetterEvent = func(event evmodel.DeadLetterEvent) error {
+		store.Lock()
+		defer store.Unlock()
+		store.deadLetters[event.SubscriptionID+":"+event.ID] = event
+		return nil
+	}
+	return pc, store
+}
+
+func setUpDeliveryConfig() {
+	common.SetUpMockConfig()
+	config.Data.EventConf = &config.EventConf{
+		DeliveryRetryAttempts:           1,
+		DeliveryRetryIntervalSeconds:    1,
+		DeliveryRetryMaxIntervalSeconds: 4,
+	}
+}
+
+func mockSubscription(id, destination, policy string) evmodel.Subscription {
+	return evmodel.Subscription{
+		SubscriptionID:      id,
+		Destination:         destination,
+		DeliveryRetryPolicy: policy,
+	}
+}
+
+func TestGetRetryInterval(t *testing.T) {
+	setUpDeliveryConfig()
+	tests := []struct {
+		name     string
+		attempts int
+		min      time.Duration
+		max      time.Duration
+	}{
+		{name: "first retry", attempts: 1, min: 500 * time.Millisecond, max: time.Second},
+		{name: "second retry", attempts: 2, min: time.Second, max: 2 * time.Second},
+		{name: "third retry", attempts: 3, min: 2 * time.Second, max: 4 * time.Second},
+		{name: "retry after max interval", attempts: 10, min: 2 * time.Second, max: 4 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				got := getRetryInterval(tt.attempts)
+				if got < tt.min || got > tt.max {
+					t.Errorf("getRetryInterval() = %v, want between %v and %v", got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestGetRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		policy  string
+		want    string
+	}{
+		{name: "first subscription", current: "", policy: evmodel.SuspendRetries, want: evmodel.SuspendRetries},
+		{name: "policy not set", current: "", policy: "", want: evmodel.DeliveryRetryPolicy},
+		{name: "retry forever retained", current: evmodel.DeliveryRetryPolicy, policy: evmodel.TerminateAfterRetries, want: evmodel.DeliveryRetryPolicy},
+		{name: "suspend preferred to terminate", current: evmodel.TerminateAfterRetries, policy: evmodel.SuspendRetries, want: evmodel.SuspendRetries},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getRetryPolicy(tt.current, tt.policy); got != tt.want {
+				t.Errorf("getRetryPolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttemptDelivery(t *testing.T) {
+	setUpDeliveryConfig()
+	statusCode := http.StatusOK
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+	config.Data.KeyCertConf = &config.KeyCertConf{
+		RootCACertificate: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+	}
+
+	tests := []struct {
+		name          string
+		statusCode    int
+		policy        string
+		attempts      int
+		wantQueued    bool
+		wantAttempts  int
+		wantUndeliver bool
+		wantSuspended bool
+	}{
+		{name: "delivered", statusCode: http.StatusOK, policy: evmodel.DeliveryRetryPolicy},
+		{name: "retried", statusCode: http.StatusServiceUnavailable, policy: evmodel.TerminateAfterRetries, wantQueued: true, wantAttempts: 1},
+		{name: "terminated after retries", statusCode: http.StatusServiceUnavailable, policy: evmodel.TerminateAfterRetries, attempts: 1, wantUndeliver: true},
+		{name: "suspended after retries", statusCode: http.StatusServiceUnavailable, policy: evmodel.SuspendRetries, attempts: 1, wantUndeliver: true, wantSuspended: true},
+		{name: "retried forever", statusCode: http.StatusServiceUnavailable, policy: evmodel.DeliveryRetryPolicy, attempts: 5, wantQueued: true, wantAttempts: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusCode = tt.statusCode
+			subscription := mockSubscription("1", server.URL, tt.policy)
+			pc, store := getMockDeliveryMethods(subscription)
+			queuedEvent := evmodel.QueuedEvent{
+				ID:                  "00000000000000000001",
+				Destination:         server.URL,
+				SubscriptionIDs:     []string{"1"},
+				DeliveryRetryPolicy: tt.policy,
+				Event:               `{"Events":[]}`,
+				Attempts:            tt.attempts,
+			}
+			store.queue[queuedEvent.ID] = queuedEvent
+			pc.attemptDelivery(queuedEvent)
+
+			requeued, queued := store.queue[queuedEvent.ID]
+			assert.Equal(t, tt.wantQueued, queued, "event should be in the queue: %v", tt.wantQueued)
+			if queued {
+				assert.Equal(t, tt.wantAttempts, requeued.Attempts)
+				assert.True(t, requeued.NextAttemptTime.After(time.Now()), "next attempt should be scheduled")
+				assert.NotEmpty(t, requeued.LastError)
+			}
+			_, undelivered := store.deadLetters["1:"+queuedEvent.ID]
+			assert.Equal(t, tt.wantUndeliver, undelivered, "event should be undelivered: %v", tt.wantUndeliver)
+			assert.Equal(t, tt.wantSuspended, store.subscriptions["1"].Suspended, "subscription should be suspended: %v", tt.wantSuspended)
+		})
+	}
+}
+
+func TestDeliverQueuedEvents(t *testing.T) {
+	setUpDeliveryConfig()
+	var delivered []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := make([]byte, r.ContentLength)
+		r.Body.Read(body)
+		delivered = append(delivered, string(body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	config.Data.KeyCertConf = &config.KeyCertConf{
+		RootCACertificate: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+	}
+	pc, store := getMockDeliveryMethods(mockSubscription("1", server.URL, evmodel.DeliveryRetryPolicy))
+	var reads int
+	pc.GetQueuedEvents = func(destinationID string) ([]evmodel.QueuedEvent, error) {
+		store.Lock()
+		defer store.Unlock()
+		reads++
+		var queuedEvents []evmodel.QueuedEvent
+		for _, id := range []string{"1", "2", "3"} {
+			if queuedEvent, ok := store.queue[id]; ok {
+				queuedEvents = append(queuedEvents, queuedEvent)
+			}
+		}
+		return queuedEvents, nil
+	}
+	pc.AcquireDeliveryLease = func(destinationID, owner string, leaseSeconds int) (bool, error) {
+		return true, nil
+	}
+	pc.ReleaseDeliveryLease = func(destinationID, owner string) error {
+		return nil
+	}
+	pc.DeleteEventDestination = func(destinationID string) error {
+		return nil
+	}
+	for _, id := range []string{"1", "2", "3"} {
+		store.queue[id] = evmodel.QueuedEvent{
+			ID:              id,
+			Destination:     server.URL,
+			SubscriptionIDs: []string{"1"},
+			Event:           id,
+		}
+	}
+	pc.deliverQueuedEvents("destination", server.URL)
+
+	assert.Equal(t, []string{"1", "2", "3"}, delivered, "events should be delivered in the order of arrival")
+	assert.Equal(t, 0, len(store.queue), "delivery queue should be empty")
+	// the queue is read once to deliver the events, and twice to find it empty
+	assert.Equal(t, 3, reads, "delivery queue should not be read for every event")
+}
+
+func TestEnqueueEvent(t *testing.T) {
+	setUpDeliveryConfig()
+	active := mockSubscription("1", "https://odim.destination.com:9090/events", evmodel.TerminateAfterRetries)
+	suspended := mockSubscription("2", "https://odim.destination.com:9090/events", evmodel.SuspendRetries)
+	suspended.Suspended = true
+	pc, store := getMockDeliveryMethods(active, suspended)
+
+	err := pc.enqueueEvent(active.Destination, []evmodel.Subscription{active, suspended, active}, []byte(`{"Events":[]}`))
+	assert.Nil(t, err, "There should be no error")
+	if assert.Equal(t, 1, len(store.queue), "event should be queued") {
+		for _, queuedEvent := range store.queue {
+			assert.Equal(t, []string{"1"}, queuedEvent.SubscriptionIDs)
+			assert.Equal(t, evmodel.TerminateAfterRetries, queuedEvent.DeliveryRetryPolicy)
+			assert.Equal(t, getDestinationID(active.Destination), queuedEvent.DestinationID)
+		}
+	}
+	assert.Equal(t, 1, len(store.deadLetters), "event should be undelivered for the suspended subscription")
+}
+
+func TestUndeliveredEvents(t *testing.T) {
+	setUpDeliveryConfig()
+	pc := getMockMethods()
+	subscriptionID := "81de0110-c35a-4859-984c-072d6c5a32d7"
+	req := &eventsproto.EventRequest{
+		SessionToken:        "validToken",
+		EventSubscriptionID: subscriptionID,
+	}
+	resp := pc.GetUndeliveredEventsCollection(req)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status Code should be StatusOK")
+	data := resp.Body.(evresponse.UndeliveredEventsResponse)
+	assert.Equal(t, 1, data.MembersCount, "there should be one undelivered event")
+	assert.Equal(t, "/redfish/v1/EventService/Subscriptions/"+subscriptionID+"/UndeliveredEvents/Actions/UndeliveredEvents.Replay", data.Actions.Replay.Target)
+
+	resp = pc.ReplayUndeliveredEvents(req)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status Code should be StatusOK")
+
+	resp = pc.PurgeUndeliveredEvents(req)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status Code should be StatusOK")
+
+	// Invalid token
+	resp = pc.GetUndeliveredEventsCollection(&eventsproto.EventRequest{SessionToken: "InValidToken", EventSubscriptionID: subscriptionID})
+	assert.Equal(t, http.StatusUnauthorized, int(resp.StatusCode), "Status Code should be StatusUnauthorized")
+
+	// invalid subscription id
+	resp = pc.PurgeUndeliveredEvents(&eventsproto.EventRequest{SessionToken: "validToken", EventSubscriptionID: "1234"})
+	assert.Equal(t, http.StatusNotFound, int(resp.StatusCode), "Status Code should be StatusNotFound")
+}
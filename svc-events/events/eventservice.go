@@ -0,0 +1,137 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
+)
+
+// deliveryRetrySettings holds the delivery retry settings updated with PATCH on
+// the EventService, the values in the configuration are used till they are updated
+var deliveryRetrySettings = struct {
+	sync.RWMutex
+	settings *evmodel.DeliveryRetrySettings
+}{}
+
+// patchableEventServiceProperties are the properties of the EventService which can be updated
+var patchableEventServiceProperties = map[string]int{
+	"DeliveryRetryAttempts":        0,
+	"DeliveryRetryIntervalSeconds": 1,
+}
+
+// getDeliveryRetrySettings returns the delivery retry settings in effect
+func getDeliveryRetrySettings() evmodel.DeliveryRetrySettings {
+	deliveryRetrySettings.RLock()
+	defer deliveryRetrySettings.RUnlock()
+	if deliveryRetrySettings.settings != nil {
+		return *deliveryRetrySettings.settings
+	}
+	return evmodel.DeliveryRetrySettings{
+		DeliveryRetryAttempts:        config.Data.EventConf.DeliveryRetryAttempts,
+		DeliveryRetryIntervalSeconds: config.Data.EventConf.DeliveryRetryIntervalSeconds,
+	}
+}
+
+// setDeliveryRetrySettings sets the delivery retry settings in effect
+func setDeliveryRetrySettings(settings *evmodel.DeliveryRetrySettings) {
+	deliveryRetrySettings.Lock()
+	defer deliveryRetrySettings.Unlock()
+	deliveryRetrySettings.settings = settings
+}
+
+// loadDeliveryRetrySettings reads the delivery retry settings saved by any
+// instance of the service, so that all the instances use the same settings
+func (e *ExternalInterfaces) loadDeliveryRetrySettings() {
+	settings, err := e.GetDeliveryRetrySettings()
+	if err != nil {
+		log.Error("error while getting the delivery retry settings: " + err.Error())
+		return
+	}
+	setDeliveryRetrySettings(settings)
+}
+
+// CurrentDeliveryRetrySettings returns the delivery retry settings of the EventService
+func (e *ExternalInterfaces) CurrentDeliveryRetrySettings() evmodel.DeliveryRetrySettings {
+	e.loadDeliveryRetrySettings()
+	return getDeliveryRetrySettings()
+}
+
+// UpdateEventService updates the delivery retry settings of the EventService and persists them,
+// the settings take effect on the next delivery attempt of the events
+func (e *ExternalInterfaces) UpdateEventService(req *eventsproto.EventSubRequest) response.RPC {
+	authResp := e.Auth(req.SessionToken, []string{common.PrivilegeConfigureManager}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		log.Error(fmt.Sprintf("error while trying to authenticate session: status code: %v, status message: %v", authResp.StatusCode, authResp.StatusMessage))
+		return authResp
+	}
+
+	var patchRequest map[string]interface{}
+	if err := json.Unmarshal(req.PostBody, &patchRequest); err != nil {
+		errorMessage := "error while trying to unmarshal the EventService update request: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+	}
+	if len(patchRequest) == 0 {
+		errorMessage := "error: request body of the EventService update is empty"
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage, []interface{}{"DeliveryRetryAttempts"}, nil)
+	}
+
+	settings := e.CurrentDeliveryRetrySettings()
+	for key, value := range patchRequest {
+		minValue, ok := patchableEventServiceProperties[key]
+		if !ok {
+			errorMessage := "error: " + key + " is not a writable property of EventService"
+			log.Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{key}, nil)
+		}
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			errorMessage := "error: " + key + " must be an integer"
+			log.Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueTypeError, errorMessage, []interface{}{fmt.Sprint(value), key}, nil)
+		}
+		if number < float64(minValue) || number > math.MaxInt32 {
+			errorMessage := fmt.Sprintf("error: %v must not be less than %v", key, minValue)
+			log.Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage, []interface{}{fmt.Sprint(value), key}, nil)
+		}
+		if key == "DeliveryRetryAttempts" {
+			settings.DeliveryRetryAttempts = int(number)
+		} else {
+			settings.DeliveryRetryIntervalSeconds = int(number)
+		}
+	}
+
+	if err := e.SaveDeliveryRetrySettings(settings); err != nil {
+		errorMessage := "error while trying to save the EventService settings: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	setDeliveryRetrySettings(&settings)
+	return common.GeneralError(http.StatusOK, response.Success, "", nil, nil)
+}
@@ -0,0 +1,86 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
+)
+
+func TestUpdateEventService(t *testing.T) {
+	common.SetUpMockConfig()
+	config.Data.EventConf = &config.EventConf{
+		DeliveryRetryAttempts:        3,
+		DeliveryRetryIntervalSeconds: 60,
+	}
+	defer setDeliveryRetrySettings(nil)
+
+	var savedSettings *evmodel.DeliveryRetrySettings
+	pc := getMockMethods()
+	pc.GetDeliveryRetrySettings = func() (*evmodel.DeliveryRetrySettings, error) {
+		return savedSettings, nil
+	}
+	pc.SaveDeliveryRetrySettings = func(settings evmodel.DeliveryRetrySettings) error {
+		savedSettings = &settings
+		return nil
+	}
+
+	assert.Equal(t, evmodel.DeliveryRetrySettings{DeliveryRetryAttempts: 3, DeliveryRetryIntervalSeconds: 60},
+		pc.CurrentDeliveryRetrySettings(), "settings in the configuration should be used till they are updated")
+
+	tests := []struct {
+		name           string
+		token          string
+		requestBody    string
+		wantStatusCode int
+		wantSettings   evmodel.DeliveryRetrySettings
+	}{
+		{name: "update retry attempts", token: "validToken", requestBody: `{"DeliveryRetryAttempts":5}`,
+			wantStatusCode: http.StatusOK, wantSettings: evmodel.DeliveryRetrySettings{DeliveryRetryAttempts: 5, DeliveryRetryIntervalSeconds: 60}},
+		{name: "update retry interval", token: "validToken", requestBody: `{"DeliveryRetryIntervalSeconds":30}`,
+			wantStatusCode: http.StatusOK, wantSettings: evmodel.DeliveryRetrySettings{DeliveryRetryAttempts: 5, DeliveryRetryIntervalSeconds: 30}},
+		{name: "invalid token", token: "InValidToken", requestBody: `{"DeliveryRetryAttempts":1}`,
+			wantStatusCode: http.StatusUnauthorized, wantSettings: evmodel.DeliveryRetrySettings{DeliveryRetryAttempts: 5, DeliveryRetryIntervalSeconds: 30}},
+		{name: "malformed request", token: "validToken", requestBody: `{"DeliveryRetryAttempts":`,
+			wantStatusCode: http.StatusBadRequest, wantSettings: evmodel.DeliveryRetrySettings{DeliveryRetryAttempts: 5, DeliveryRetryIntervalSeconds: 30}},
+		{name: "empty request", token: "validToken", requestBody: `{}`,
+			wantStatusCode: http.StatusBadRequest, wantSettings: evmodel.DeliveryRetrySettings{DeliveryRetryAttempts: 5, DeliveryRetryIntervalSeconds: 30}},
+		{name: "read only property", token: "validToken", requestBody: `{"ServiceEnabled":false}`,
+			wantStatusCode: http.StatusBadRequest, wantSettings: evmodel.DeliveryRetrySettings{DeliveryRetryAttempts: 5, DeliveryRetryIntervalSeconds: 30}},
+		{name: "invalid property type", token: "validToken", requestBody: `{"DeliveryRetryAttempts":"5"}`,
+			wantStatusCode: http.StatusBadRequest, wantSettings: evmodel.DeliveryRetrySettings{DeliveryRetryAttempts: 5, DeliveryRetryIntervalSeconds: 30}},
+		{name: "fractional value", token: "validToken", requestBody: `{"DeliveryRetryAttempts":1.5}`,
+			wantStatusCode: http.StatusBadRequest, wantSettings: evmodel.DeliveryRetrySettings{DeliveryRetryAttempts: 5, DeliveryRetryIntervalSeconds: 30}},
+		{name: "interval less than minimum", token: "validToken", requestBody: `{"DeliveryRetryIntervalSeconds":0}`,
+			wantStatusCode: http.StatusBadRequest, wantSettings: evmodel.DeliveryRetrySettings{DeliveryRetryAttempts: 5, DeliveryRetryIntervalSeconds: 30}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := pc.UpdateEventService(&eventsproto.EventSubRequest{
+				SessionToken: tt.token,
+				PostBody:     []byte(tt.requestBody),
+			})
+			assert.Equal(t, tt.wantStatusCode, int(resp.StatusCode), "status code should be %v", tt.wantStatusCode)
+			assert.Equal(t, tt.wantSettings, getDeliveryRetrySettings(), "settings in effect should be updated")
+		})
+	}
+}
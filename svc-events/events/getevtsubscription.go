@@ -75,26 +75,7 @@ func (e *ExternalInterfaces) GetEventSubscriptionsDetails(req *eventsproto.Event
 			errorMessage := fmt.Sprintf("Subscription details not found for ID: %v", req.EventSubscriptionID)
 			return common.GeneralError(http.StatusBadRequest, response.ResourceNotFound, errorMessage, []interface{}{"EventSubscription", req.EventSubscriptionID}, nil)
 		}
-		commonResponse := response.Response{
-			OdataType:    common.EventDestinationType,
-			ID:           evtSubscription.SubscriptionID,
-			Name:         evtSubscription.Name,
-			OdataContext: "/redfish/v1/$metadata#EventDestination.EventDestination",
-			OdataID:      "/redfish/v1/EventService/Subscriptions/" + evtSubscription.SubscriptionID,
-		}
-
-		subscriptions = &evresponse.SubscriptionResponse{
-			Response:            commonResponse,
-			Destination:         evtSubscription.Destination,
-			Protocol:            evtSubscription.Protocol,
-			Context:             evtSubscription.Context,
-			EventTypes:          evtSubscription.EventTypes,
-			SubscriptionType:    evtSubscription.SubscriptionType,
-			MessageIds:          evtSubscription.MessageIds,
-			ResourceTypes:       evtSubscription.ResourceTypes,
-			OriginResources:     updateOriginResourceswithOdataID(evtSubscription.OriginResources),
-			DeliveryRetryPolicy: evtSubscription.DeliveryRetryPolicy,
-		}
+		subscriptions = createSubscriptionResponse(evtSubscription)
 	}
 	resp.Body = subscriptions
 	resp.StatusCode = http.StatusOK
@@ -102,6 +83,30 @@ func (e *ExternalInterfaces) GetEventSubscriptionsDetails(req *eventsproto.Event
 	return resp
 }
 
+// createSubscriptionResponse returns the EventDestination resource of the subscription
+func createSubscriptionResponse(evtSubscription evmodel.Subscription) *evresponse.SubscriptionResponse {
+	commonResponse := response.Response{
+		OdataType:    common.EventDestinationType,
+		ID:           evtSubscription.SubscriptionID,
+		Name:         evtSubscription.Name,
+		OdataContext: "/redfish/v1/$metadata#EventDestination.EventDestination",
+		OdataID:      "/redfish/v1/EventService/Subscriptions/" + evtSubscription.SubscriptionID,
+	}
+
+	return &evresponse.SubscriptionResponse{
+		Response:            commonResponse,
+		Destination:         evtSubscription.Destination,
+		Protocol:            evtSubscription.Protocol,
+		Context:             evtSubscription.Context,
+		EventTypes:          evtSubscription.EventTypes,
+		SubscriptionType:    evtSubscription.SubscriptionType,
+		MessageIds:          evtSubscription.MessageIds,
+		ResourceTypes:       evtSubscription.ResourceTypes,
+		OriginResources:     updateOriginResourceswithOdataID(evtSubscription.OriginResources),
+		DeliveryRetryPolicy: evtSubscription.DeliveryRetryPolicy,
+	}
+}
+
 // GetEventSubscriptionsCollection collects all subscription details
 func (e *ExternalInterfaces) GetEventSubscriptionsCollection(req *eventsproto.EventRequest) response.RPC {
 	var resp response.RPC
@@ -66,6 +66,26 @@ func getMockMethods() ExternalInterfaces {
 			GetAggregateHosts:                evcommon.MockGetAggregateHosts,
 			UpdateAggregateHosts:             evcommon.MockSaveAggregateSubscription,
 			GetAggregateList:                 evcommon.MockGetAggregateHosts,
+			GetDeliverySequence:              evcommon.MockGetDeliverySequence,
+			SaveEventDestination:             evcommon.MockSaveEventDestination,
+			GetEventDestinations:             evcommon.MockGetEventDestinations,
+			DeleteEventDestination:           evcommon.MockDeleteEventDestination,
+			SaveQueuedEvent:                  evcommon.MockSaveQueuedEvent,
+			GetQueuedEvents:                  evcommon.MockGetQueuedEvents,
+			DeleteQueuedEvent:                evcommon.MockDeleteQueuedEvent,
+			AcquireDeliveryLease:             evcommon.MockAcquireDeliveryLease,
+			ReleaseDeliveryLease:             evcommon.MockReleaseDeliveryLease,
+			SaveDeadLetterEvent:              evcommon.MockSaveDeadLetterEvent,
+			GetDeadLetterEvents:              evcommon.MockGetDeadLetterEvents,
+			DeleteDeadLetterEvent:            evcommon.MockDeleteDeadLetterEvent,
+			GetDeliveryRetrySettings:         evcommon.MockGetDeliveryRetrySettings,
+			SaveDeliveryRetrySettings:        evcommon.MockSaveDeliveryRetrySettings,
+			SaveSSEEvent:                     evcommon.MockSaveSSEEvent,
+			GetLastSSEEventID:                evcommon.MockGetLastSSEEventID,
+			GetSSEEvent:                      evcommon.MockGetSSEEvent,
+			SaveSSEClientInstance:            evcommon.MockSaveSSEClientInstance,
+			DeleteSSEClientInstance:          evcommon.MockDeleteSSEClientInstance,
+			IsSSEClientConnected:             evcommon.MockIsSSEClientConnected,
 		},
 	}
 }
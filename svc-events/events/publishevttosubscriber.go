@@ -28,8 +28,8 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"path"
 	"strings"
-	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -37,12 +37,28 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
 	fabricproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/fabrics"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-events/evcommon"
 	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
-	uuid "github.com/satori/go.uuid"
 )
 
+// memberEventCollections are the origin resources of the events published by the
+// services for the members of their collections, the task events published by svc-task
+// and the license events published by svc-licenses, mapped to the resource type of the members
+var memberEventCollections = map[string]string{
+	"/redfish/v1/TaskService/Tasks":       "Task",
+	"/redfish/v1/LicenseService/Licenses": "License",
+}
+
+// serviceEventHosts are the hosts the services publish their events with, they are
+// the names of the collections saved for the collection subscriptions
+var serviceEventHosts = map[string]bool{
+	"TasksCollection":    true,
+	"LicensesCollection": true,
+	"TriggersCollection": true,
+}
+
 // addFabric will add the new fabric resource to db when an event is ResourceAdded and
 // originofcondition has fabrics odataid.
 func (e *ExternalInterfaces) addFabric(requestData, host string) {
@@ -91,7 +107,10 @@ func (e *ExternalInterfaces) PublishEventsToDestination(data interface{}) bool {
 	if err != nil {
 		host = event.IP
 	}
-	host = strings.ToLower(host)
+	// only the host names of the devices are saved in lower case
+	if !serviceEventHosts[host] {
+		host = strings.ToLower(host)
+	}
 	log.Info("After splitting host address, IP is: ", host)
 
 	var requestData = string(event.Request)
@@ -152,9 +171,9 @@ func (e *ExternalInterfaces) PublishEventsToDestination(data interface{}) bool {
 		log.Error("failed to unmarshal the incoming event: ", requestData, " with the error: ", err.Error())
 		return false
 	}
-	eventUniqueID := uuid.NewV4().String()
-
+	e.publishServerSentEvent(eventFormat, requestData)
 	eventMap := make(map[string][]common.Event)
+	destinationSubscriptions := make(map[string][]evmodel.Subscription)
 	for _, inEvent := range message.Events {
 		if inEvent.OriginOfCondition == nil {
 			log.Info("event not forwarded as Originofcondition is empty in incoming event: ", requestData)
@@ -189,6 +208,7 @@ func (e *ExternalInterfaces) PublishEventsToDestination(data interface{}) bool {
 		for _, sub := range aggregateSubscriptionList {
 			if filterEventsToBeForwarded(sub, inEvent, deviceSubscription.OriginResources) {
 				eventMap[sub.Destination] = append(eventMap[sub.Destination], inEvent)
+				destinationSubscriptions[sub.Destination] = append(destinationSubscriptions[sub.Destination], sub)
 				flag = true
 			}
 		}
@@ -201,6 +221,7 @@ func (e *ExternalInterfaces) PublishEventsToDestination(data interface{}) bool {
 				if isHostPresentInEventForward(sub.Hosts, host) {
 					if filterEventsToBeForwarded(sub, inEvent, deviceSubscription.OriginResources) {
 						eventMap[sub.Destination] = append(eventMap[sub.Destination], inEvent)
+						destinationSubscriptions[sub.Destination] = append(destinationSubscriptions[sub.Destination], sub)
 						flag = true
 					}
 				} else {
@@ -237,23 +258,65 @@ func (e *ExternalInterfaces) PublishEventsToDestination(data interface{}) bool {
 			log.Error("unable to converts event into bytes: ", err.Error())
 			continue
 		}
-		go e.postEvent(key, eventUniqueID, data)
+		e.enqueueEvent(key, destinationSubscriptions[key], data)
 	}
 	return flag
 }
 
 func (e *ExternalInterfaces) publishMetricReport(requestData string) bool {
-	eventUniqueID := uuid.NewV4().String()
 	subscriptions, err := e.GetEvtSubscriptions("MetricReport")
 	if err != nil {
 		return false
 	}
+	destinationSubscriptions := make(map[string][]evmodel.Subscription)
 	for _, sub := range subscriptions {
-		go e.postEvent(sub.Destination, eventUniqueID, []byte(requestData))
+		destinationSubscriptions[sub.Destination] = append(destinationSubscriptions[sub.Destination], sub)
 	}
+	for destination, subs := range destinationSubscriptions {
+		e.enqueueEvent(destination, subs, []byte(requestData))
+	}
+	e.publishServerSentEvent(metricReportFormat, requestData)
+	go e.evaluateTriggers(requestData)
 	return true
 }
 
+// evaluateTriggers will send the metric report to the telemetry service for evaluating
+// the triggers, and publish the events generated for the triggers to the subscribers
+// of the triggers collection
+func (e *ExternalInterfaces) evaluateTriggers(requestData string) {
+	conn, err := services.ODIMService.Client(services.Telemetry)
+	if err != nil {
+		log.Error("failed to get client connection object for telemetry service: ", err.Error())
+		return
+	}
+	defer conn.Close()
+	telemetry := teleproto.NewTelemetryClient(conn)
+	resp, err := telemetry.EvaluateTriggers(context.TODO(), &teleproto.TelemetryRequest{
+		RequestBody: []byte(requestData),
+	})
+	if err != nil {
+		log.Error("error while evaluating the triggers: ", err.Error())
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		log.Error("error while evaluating the triggers: ", string(resp.Body))
+		return
+	}
+	var message common.MessageData
+	if err := json.Unmarshal(resp.Body, &message); err != nil {
+		log.Error("failed to unmarshal the trigger events: ", err.Error())
+		return
+	}
+	if len(message.Events) == 0 {
+		return
+	}
+	e.PublishEventsToDestination(common.Events{
+		IP:        "TriggersCollection",
+		Request:   resp.Body,
+		EventType: "Event",
+	})
+}
+
 func filterEventsToBeForwarded(subscription evmodel.Subscription, event common.Event, originResources []string) bool {
 	eventTypes := subscription.EventTypes
 	messageIds := subscription.MessageIds
@@ -261,7 +324,8 @@ func filterEventsToBeForwarded(subscription evmodel.Subscription, event common.E
 	originCondition := strings.TrimSuffix(event.OriginOfCondition.Oid, "/")
 	if (len(eventTypes) == 0 || isStringPresentInSlice(eventTypes, event.EventType, "event type")) &&
 		(len(messageIds) == 0 || isStringPresentInSlice(messageIds, event.MessageID, "message id")) &&
-		(len(resourceTypes) == 0 || isResourceTypeSubscribed(resourceTypes, event.OriginOfCondition.Oid, subscription.SubordinateResources)) {
+		(len(resourceTypes) == 0 || isMemberResourceTypeSubscribed(resourceTypes, originCondition, originResources) ||
+			isResourceTypeSubscribed(resourceTypes, event.OriginOfCondition.Oid, subscription.SubordinateResources)) {
 		// if SubordinateResources is true then check if originofresource is top level of originofcondition
 		// if SubordinateResources is flase then check originofresource is same as originofcondition
 		for _, origin := range originResources {
@@ -270,7 +334,7 @@ func filterEventsToBeForwarded(subscription evmodel.Subscription, event common.E
 					return true
 				}
 			} else {
-				if origin == originCondition {
+				if origin == originCondition || isMemberOfCollection(origin, originCondition) {
 					return true
 				}
 			}
@@ -280,6 +344,36 @@ func filterEventsToBeForwarded(subscription evmodel.Subscription, event common.E
 	return false
 }
 
+// isMemberOfCollection returns true if the origin is a collection like the task or the license
+// collection and the originofcondition is a member of it, since they are subscribed through the collection.
+// A sub task is a member of the task collection as well, it is served under the SubTasks of its parent task.
+func isMemberOfCollection(origin, originCondition string) bool {
+	if _, ok := memberEventCollections[origin]; !ok {
+		return false
+	}
+	collection := path.Dir(originCondition)
+	if path.Base(collection) == "SubTasks" {
+		collection = path.Dir(path.Dir(collection))
+	}
+	return collection == origin
+}
+
+// isMemberResourceTypeSubscribed returns true if the originofcondition is a member of a collection
+// of the origin resources and the resource type of the members of the collection is subscribed
+func isMemberResourceTypeSubscribed(resourceTypes []string, originCondition string, originResources []string) bool {
+	for _, origin := range originResources {
+		if !isMemberOfCollection(origin, originCondition) {
+			continue
+		}
+		for _, resourceType := range resourceTypes {
+			if resourceType == memberEventCollections[origin] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // formatEvent will format the event string according to the odimra
 // add uuid:systemid/chassisid inplace of systemid/chassisid
 func formatEvent(event, originResource, hostIP string) (string, string) {
@@ -348,25 +442,6 @@ func isStringPresentInSlice(slice []string, str, message string) bool {
 	return false
 }
 
-// postEvent will post the event to destination
-func (e *ExternalInterfaces) postEvent(destination, eventUniqueID string, event []byte) {
-	resp, err := sendEvent(destination, event)
-	if err == nil {
-		resp.Body.Close()
-		log.Info("Event is successfully forwarded")
-		// check any undelivered events are present in db for the destination and publish those
-		go e.checkUndeliveredEvents(destination)
-		return
-	}
-	undeliveredEventID := destination + ":" + eventUniqueID
-	serr := e.SaveUndeliveredEvents(undeliveredEventID, event)
-	if serr != nil {
-		log.Error("error while saving undelivered event: ", serr.Error())
-	}
-	go e.reAttemptEvents(destination, undeliveredEventID, event)
-	return
-}
-
 func sendEvent(destination string, event []byte) (*http.Response, error) {
 	httpConf := &config.HTTPConfig{
 		CACertificate: &config.Data.KeyCertConf.RootCACertificate,
@@ -388,39 +463,6 @@ func sendEvent(destination string, event []byte) (*http.Response, error) {
 	return httpClient.Do(req)
 }
 
-func (e *ExternalInterfaces) reAttemptEvents(destination, undeliveredEventID string, event []byte) {
-	var resp *http.Response
-	var err error
-	count := config.Data.EventConf.DeliveryRetryAttempts
-	for i := 0; i < count; i++ {
-		log.Info("Retry event forwarding on destination: ", destination)
-		time.Sleep(time.Second * time.Duration(config.Data.EventConf.DeliveryRetryIntervalSeconds))
-		// if undelivered event already published then ignore retrying
-		eventString, err := e.GetUndeliveredEvents(undeliveredEventID)
-		if err != nil || len(eventString) < 1 {
-			log.Info("Event is forwarded to destination")
-			return
-		}
-		resp, err = sendEvent(destination, event)
-		if err == nil {
-			resp.Body.Close()
-			log.Info("Event is successfully forwarded")
-			// if event is delivered then delete the same which is saved in 1st attempt
-			err = e.DeleteUndeliveredEvents(undeliveredEventID)
-			if err != nil {
-				log.Error("error while deleting undelivered events: ", err.Error())
-			}
-			// check any undelivered events are present in db for the destination and publish those
-			go e.checkUndeliveredEvents(destination)
-			return
-		}
-
-	}
-	if err != nil {
-		log.Error("error while make https call to send the event: ", err.Error())
-	}
-}
-
 // rediscoverSystemInventory will be triggered when ever the System Restart or Power On
 // event is detected it will create a rpc for aggregation which will delete all system inventory //
 // and rediscover all of them
@@ -562,47 +604,6 @@ func callPluginStartUp(event common.Events) {
 	return
 }
 
-func (e *ExternalInterfaces) checkUndeliveredEvents(destination string) {
-	// first check any of the instance have already picked up for publishing
-	// undelivered events for the destination
-	flag, _ := e.GetUndeliveredEventsFlag(destination)
-	if !flag {
-		// if flag is false then set the flag true, so other instance shouldnt have to read the undelivered events and publish
-		err := e.SetUndeliveredEventsFlag(destination)
-		if err != nil {
-			log.Error("error while setting undelivered events flag: ", err.Error())
-		}
-		destData, _ := e.GetAllMatchingDetails(evmodel.UndeliveredEvents, destination, common.OnDisk)
-		for _, dest := range destData {
-			event, err := e.GetUndeliveredEvents(dest)
-			if err != nil {
-				log.Error("error while getting undelivered events: ", err.Error())
-				continue
-			}
-			event = strings.Replace(event, "\\", "", -1)
-			event = strings.TrimPrefix(event, "\"")
-			event = strings.TrimSuffix(event, "\"")
-			resp, err := sendEvent(destination, []byte(event))
-			if err != nil {
-				log.Error("error while make https call to send the event: ", err.Error())
-				resp.Body.Close()
-				continue
-			}
-			resp.Body.Close()
-			log.Info("Event is successfully forwarded")
-			err = e.DeleteUndeliveredEvents(dest)
-			if err != nil {
-				log.Error("error while deleting undelivered events: ", err.Error())
-			}
-		}
-		// handle logic if inter connection fails
-		derr := e.DeleteUndeliveredEventsFlag(destination)
-		if derr != nil {
-			log.Error("error while deleting undelivered events flag: ", derr.Error())
-		}
-	}
-}
-
 func (e *ExternalInterfaces) getCollectionSubscriptionInfoForOID(oid, host string) []evmodel.Subscription {
 	var key string
 	if strings.Contains(oid, "Systems") && host != "SystemsCollection" {
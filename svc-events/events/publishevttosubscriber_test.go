@@ -22,10 +22,12 @@ package events
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -155,3 +157,90 @@ func TestPublishEventsToDestiantionWithMultipleEvents(t *testing.T) {
 		assert.True(t, flag)
 	}
 }
+
+func TestPublishTaskEventsToDestination(t *testing.T) {
+	config.SetUpMockConfig(t)
+	message := common.MessageData{
+		OdataType: common.EventType,
+		Events: []common.Event{
+			{
+				EventType: "StatusChange",
+				EventID:   "123",
+				Severity:  "OK",
+				Message:   "The task with Id 'task1' has started.",
+				MessageID: "TaskEvent.1.0.3.TaskStarted",
+				OriginOfCondition: &common.Link{
+					Oid: "/redfish/v1/TaskService/Tasks/task1",
+				},
+			},
+		},
+	}
+	request, _ := json.Marshal(message)
+	pc := getMockMethods()
+	pc.DB.GetDeviceSubscriptions = func(searchKey string) (*evmodel.DeviceSubscription, error) {
+		if searchKey != "TasksCollection[^0-9]" {
+			return nil, fmt.Errorf("no device subscription found for %v", searchKey)
+		}
+		return &evmodel.DeviceSubscription{
+			EventHostIP:     "TasksCollection",
+			OriginResources: []string{"/redfish/v1/TaskService/Tasks"},
+		}, nil
+	}
+	pc.DB.GetEvtSubscriptions = func(searchKey string) ([]evmodel.Subscription, error) {
+		if searchKey != "[^0-9]TasksCollection[^0-9]" {
+			return nil, nil
+		}
+		return []evmodel.Subscription{
+			{
+				SubscriptionID:  "1",
+				Destination:     "https://odim.destination.com:9090/events",
+				ResourceTypes:   []string{"Task"},
+				OriginResources: []string{"/redfish/v1/TaskService/Tasks"},
+				Hosts:           []string{"TasksCollection"},
+			},
+		}, nil
+	}
+	flag := pc.PublishEventsToDestination(common.Events{IP: "TasksCollection", Request: request})
+	assert.True(t, flag)
+}
+
+func TestFilterTaskEventsToBeForwarded(t *testing.T) {
+	subscription := evmodel.Subscription{ResourceTypes: []string{"Task"}}
+	originResources := []string{"/redfish/v1/TaskService/Tasks"}
+	event := common.Event{
+		EventType: "StatusChange",
+		MessageID: "TaskEvent.1.0.3.TaskCompletedOK",
+		OriginOfCondition: &common.Link{
+			Oid: "/redfish/v1/TaskService/Tasks/task1",
+		},
+	}
+	assert.True(t, filterEventsToBeForwarded(subscription, event, originResources))
+
+	event.OriginOfCondition.Oid = "/redfish/v1/TaskService/Tasks/task1/SubTasks/task2"
+	assert.True(t, filterEventsToBeForwarded(subscription, event, originResources))
+	subscription.SubordinateResources = true
+	assert.True(t, filterEventsToBeForwarded(subscription, event, originResources))
+
+	subscription.ResourceTypes = []string{"ComputerSystem"}
+	assert.False(t, filterEventsToBeForwarded(subscription, event, originResources))
+
+	subscription = evmodel.Subscription{ResourceTypes: []string{"Task"}}
+	event.OriginOfCondition.Oid = "/redfish/v1/TaskService/Tasks/task1/SubTasks"
+	assert.False(t, filterEventsToBeForwarded(subscription, event, originResources))
+}
+
+func TestFilterLicenseEventsToBeForwarded(t *testing.T) {
+	subscription := evmodel.Subscription{ResourceTypes: []string{"License"}}
+	originResources := []string{"/redfish/v1/LicenseService/Licenses"}
+	event := common.Event{
+		EventType: "Alert",
+		MessageID: "License.1.0.3.DaysBeforeExpiration",
+		OriginOfCondition: &common.Link{
+			Oid: "/redfish/v1/LicenseService/Licenses/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1",
+		},
+	}
+	assert.True(t, filterEventsToBeForwarded(subscription, event, originResources))
+
+	originResources = []string{"/redfish/v1/TaskService/Tasks"}
+	assert.False(t, filterEventsToBeForwarded(subscription, event, originResources))
+}
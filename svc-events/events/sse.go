@@ -0,0 +1,491 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
+)
+
+const (
+	// eventFormat is the EventFormatType of the events
+	eventFormat = "Event"
+	// metricReportFormat is the EventFormatType of the metric reports
+	metricReportFormat = "MetricReport"
+)
+
+// sseReadInterval is the interval at which the SSE event buffer is read
+// for the events to be streamed
+var sseReadInterval = time.Second
+
+// sseClientLeaseSeconds is the time for which an instance is recorded as having
+// SSE clients connected. The record is renewed every sseReadInterval.
+const sseClientLeaseSeconds = 60
+
+// sseClients has the SSE streams connected to this instance. The events are read
+// from the SSE event buffer by a single reader of the instance, while it has
+// streams connected, and pushed to the channels of the streams. lastID is the
+// ID of the last event pushed to the streams.
+var sseClients = struct {
+	sync.Mutex
+	streams map[chan *evmodel.SSEEvent]bool
+	lastID  int
+	reading bool
+	// connected caches whether any instance has SSE clients connected, till checkedAt plus sseReadInterval
+	connected bool
+	checkedAt time.Time
+}{streams: make(map[chan *evmodel.SSEEvent]bool)}
+
+// sseFilterProperties are the properties supported in the $filter of the SSE stream
+var sseFilterProperties = map[string]bool{
+	"EventFormatType":        true,
+	"EventType":              true,
+	"MessageId":              true,
+	"MetricReportDefinition": true,
+	"OriginResource":         true,
+	"RegistryPrefix":         true,
+	"ResourceType":           true,
+	"SubordinateResources":   true,
+}
+
+// sseFilter is the parsed $filter of the SSE stream. SubordinateResources is not
+// matched with the events, it extends OriginResource and ResourceType to the
+// subordinate resources of the given resource.
+type sseFilter struct {
+	expression           *common.FilterExpression
+	subordinateResources bool
+}
+
+// publishServerSentEvent adds the event to the SSE event buffer, from where it is
+// streamed by all the instances of the service to their SSE clients. The event is
+// not buffered when no SSE client is connected to any of the instances.
+func (e *ExternalInterfaces) publishServerSentEvent(eventFormatType, data string) {
+	if !e.isSSEClientConnected() {
+		return
+	}
+	if _, err := e.SaveSSEEvent(eventFormatType, data, config.Data.EventConf.SSEReplayBufferSize); err != nil {
+		log.Error("error while adding the event to the SSE event buffer: " + err.Error())
+	}
+}
+
+// isSSEClientConnected returns true if an SSE client is connected to this instance,
+// or to any other instance as checked within the last sseReadInterval
+func (e *ExternalInterfaces) isSSEClientConnected() bool {
+	sseClients.Lock()
+	defer sseClients.Unlock()
+	if len(sseClients.streams) > 0 {
+		return true
+	}
+	if time.Since(sseClients.checkedAt) < sseReadInterval {
+		return sseClients.connected
+	}
+	connected, err := e.IsSSEClientConnected()
+	if err != nil {
+		log.Error("error while checking the SSE clients: " + err.Error())
+		// the event is buffered, when it is not known whether a client is connected
+		return true
+	}
+	sseClients.connected, sseClients.checkedAt = connected, time.Now()
+	return connected
+}
+
+// StreamServerSentEvents streams the events matching the $filter of the request
+// with send, till the ctx is done. The first message sent has the status of the
+// request. If the LastEventID is given, the events after it which are still in
+// the SSE event buffer are streamed first.
+func (e *ExternalInterfaces) StreamServerSentEvents(ctx context.Context, req *eventsproto.EventRequest, send func(*eventsproto.ServerSentEvent) error) error {
+	authResp := e.Auth(req.SessionToken, []string{common.PrivilegeLogin}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		log.Error(fmt.Sprintf("error while trying to authenticate session: status code: %v, status message: %v", authResp.StatusCode, authResp.StatusMessage))
+		return send(sseResponse(authResp))
+	}
+	filter, err := parseSSEFilter(req.Filter)
+	if err != nil {
+		errorMessage := "error: invalid $filter for the SSE stream: " + err.Error()
+		log.Error(errorMessage)
+		return send(sseResponse(common.GeneralError(http.StatusBadRequest, response.QueryParameterValueFormatError, errorMessage, []interface{}{req.Filter, common.QueryFilter}, nil)))
+	}
+	events, lastID, err := e.addSSEStream()
+	if err != nil {
+		errorMessage := "error while trying to get the last event ID: " + err.Error()
+		log.Error(errorMessage)
+		return send(sseResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)))
+	}
+	defer removeSSEStream(events)
+	if err := send(&eventsproto.ServerSentEvent{StatusCode: http.StatusOK}); err != nil {
+		return err
+	}
+
+	// the events after lastID are pushed to the stream by the reader of the instance
+	if id, err := strconv.Atoi(req.LastEventID); err == nil && id >= 0 && id < lastID {
+		if err := e.readSSEEventBuffer(id, lastID, func(event *evmodel.SSEEvent) error {
+			return streamSSEEvent(filter, event, send)
+		}); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				// the client could resume the stream from the last event received with Last-Event-ID
+				log.Warn("SSE stream is closed as the client is not reading the events")
+				return nil
+			}
+			if err := streamSSEEvent(filter, event, send); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// addSSEStream adds a stream to the SSE streams of the instance and returns its channel,
+// along with the ID of the last event read before the stream is added. The reader of
+// the SSE event buffer is started, if it is the first stream of the instance.
+func (e *ExternalInterfaces) addSSEStream() (chan *evmodel.SSEEvent, int, error) {
+	sseClients.Lock()
+	defer sseClients.Unlock()
+	if !sseClients.reading {
+		// the instance is recorded before getting the last ID, so that the events
+		// published by the other instances after it are buffered
+		if err := e.SaveSSEClientInstance(instanceID, sseClientLeaseSeconds); err != nil {
+			return nil, 0, err
+		}
+		lastID, err := e.GetLastSSEEventID()
+		if err != nil {
+			return nil, 0, err
+		}
+		sseClients.lastID = lastID
+		sseClients.reading = true
+		go e.readSSEEvents()
+	}
+	events := make(chan *evmodel.SSEEvent, config.Data.EventConf.SSEReplayBufferSize)
+	sseClients.streams[events] = true
+	return events, sseClients.lastID, nil
+}
+
+func removeSSEStream(events chan *evmodel.SSEEvent) {
+	sseClients.Lock()
+	delete(sseClients.streams, events)
+	sseClients.Unlock()
+}
+
+// readSSEEvents reads the events added to the SSE event buffer every sseReadInterval
+// and pushes them to the SSE streams of the instance, till all the streams are closed.
+// A stream which is not reading the events is closed once its channel is full.
+func (e *ExternalInterfaces) readSSEEvents() {
+	ticker := time.NewTicker(sseReadInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		sseClients.Lock()
+		if len(sseClients.streams) == 0 {
+			sseClients.reading = false
+			if err := e.DeleteSSEClientInstance(instanceID); err != nil {
+				log.Error("error while deleting the SSE client instance: " + err.Error())
+			}
+			sseClients.Unlock()
+			return
+		}
+		lastID := sseClients.lastID
+		sseClients.Unlock()
+
+		if err := e.SaveSSEClientInstance(instanceID, sseClientLeaseSeconds); err != nil {
+			log.Error("error while saving the SSE client instance: " + err.Error())
+		}
+		latestID, err := e.GetLastSSEEventID()
+		if err != nil {
+			log.Error("error while trying to get the last event ID: " + err.Error())
+			continue
+		}
+		if latestID < lastID {
+			// event IDs are started again, when the DB is cleared
+			lastID = 0
+		}
+		var events []*evmodel.SSEEvent
+		e.readSSEEventBuffer(lastID, latestID, func(event *evmodel.SSEEvent) error {
+			events = append(events, event)
+			return nil
+		})
+
+		sseClients.Lock()
+		sseClients.lastID = latestID
+		for stream := range sseClients.streams {
+			if !pushSSEEvents(stream, events) {
+				close(stream)
+				delete(sseClients.streams, stream)
+			}
+		}
+		sseClients.Unlock()
+	}
+}
+
+// pushSSEEvents pushes the events to the channel of the stream without
+// blocking, and returns false if the channel is full
+func pushSSEEvents(stream chan *evmodel.SSEEvent, events []*evmodel.SSEEvent) bool {
+	for _, event := range events {
+		select {
+		case stream <- event:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// readSSEEventBuffer passes the events in the SSE event buffer after lastID
+// till latestID to handle, in the order of their IDs
+func (e *ExternalInterfaces) readSSEEventBuffer(lastID, latestID int, handle func(*evmodel.SSEEvent) error) error {
+	firstID := lastID + 1
+	if bufferSize := config.Data.EventConf.SSEReplayBufferSize; latestID-lastID > bufferSize {
+		firstID = latestID - bufferSize + 1
+	}
+	for id := firstID; id <= latestID; id++ {
+		event, err := e.GetSSEEvent(id)
+		if err != nil {
+			log.Error("error while trying to get the event from the SSE event buffer: " + err.Error())
+			continue
+		}
+		if event == nil {
+			continue
+		}
+		if err := handle(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamSSEEvent sends the event, if it matches the filter of the stream
+func streamSSEEvent(filter *sseFilter, event *evmodel.SSEEvent, send func(*eventsproto.ServerSentEvent) error) error {
+	data, ok := filter.apply(event)
+	if !ok {
+		return nil
+	}
+	return send(&eventsproto.ServerSentEvent{ID: strconv.Itoa(event.ID), Data: data})
+}
+
+// sseResponse converts the response of the request to the first message of the SSE stream
+func sseResponse(resp response.RPC) *eventsproto.ServerSentEvent {
+	body, _ := json.Marshal(resp.Body)
+	return &eventsproto.ServerSentEvent{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}
+}
+
+// parseSSEFilter parses the $filter of the SSE stream, which supports only the
+// properties in sseFilterProperties compared with eq or ne
+func parseSSEFilter(filter string) (*sseFilter, error) {
+	if filter == "" {
+		return &sseFilter{}, nil
+	}
+	expression, err := common.ParseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	f := &sseFilter{expression: expression}
+	if err := f.validate(expression); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *sseFilter) validate(expression *common.FilterExpression) error {
+	switch expression.Operator {
+	case "and", "or":
+		if err := f.validate(expression.Left); err != nil {
+			return err
+		}
+		return f.validate(expression.Right)
+	case "not":
+		return f.validate(expression.Left)
+	case "eq", "ne":
+	default:
+		return fmt.Errorf("operator %v is not supported", expression.Operator)
+	}
+	if !sseFilterProperties[expression.Property] {
+		return fmt.Errorf("property %v is not supported", expression.Property)
+	}
+	if expression.Property == "SubordinateResources" {
+		value, ok := expression.Value.(bool)
+		if !ok {
+			return fmt.Errorf("SubordinateResources should be true or false")
+		}
+		f.subordinateResources = value == (expression.Operator == "eq")
+		return nil
+	}
+	if _, ok := expression.Value.(string); !ok {
+		return fmt.Errorf("value of %v should be a string", expression.Property)
+	}
+	return nil
+}
+
+// apply returns the data of the event to be streamed, if the event matches the filter.
+// For an event with multiple records, only the matching records are streamed.
+func (f *sseFilter) apply(event *evmodel.SSEEvent) ([]byte, bool) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(event.Data), &data); err != nil {
+		log.Error("error while trying to unmarshal the event in the SSE event buffer: " + err.Error())
+		return nil, false
+	}
+	if event.EventFormatType == metricReportFormat {
+		if f.expression != nil && !f.evaluate(f.expression, func(property, value string) bool {
+			return f.matchMetricReport(data, property, value)
+		}) {
+			return nil, false
+		}
+	} else {
+		var message common.MessageData
+		json.Unmarshal([]byte(event.Data), &message)
+		records, _ := data["Events"].([]interface{})
+		var matchingRecords []interface{}
+		for i, record := range message.Events {
+			if i >= len(records) {
+				break
+			}
+			if f.expression == nil || f.evaluate(f.expression, func(property, value string) bool {
+				return f.matchEventRecord(record, property, value)
+			}) {
+				matchingRecords = append(matchingRecords, records[i])
+			}
+		}
+		if len(matchingRecords) == 0 {
+			return nil, false
+		}
+		data["Events"] = matchingRecords
+		data["Id"] = strconv.Itoa(event.ID)
+	}
+	// the data of an SSE message should be in a single line
+	streamData, err := json.Marshal(data)
+	if err != nil {
+		log.Error("error while trying to marshal the event to be streamed: " + err.Error())
+		return nil, false
+	}
+	return streamData, true
+}
+
+// evaluate evaluates the filter expression, where match returns true if the
+// property of the event is equal to the value
+func (f *sseFilter) evaluate(expression *common.FilterExpression, match func(property, value string) bool) bool {
+	switch expression.Operator {
+	case "and":
+		return f.evaluate(expression.Left, match) && f.evaluate(expression.Right, match)
+	case "or":
+		return f.evaluate(expression.Left, match) || f.evaluate(expression.Right, match)
+	case "not":
+		return !f.evaluate(expression.Left, match)
+	}
+	if expression.Property == "SubordinateResources" {
+		return true
+	}
+	value, _ := expression.Value.(string)
+	return match(expression.Property, value) == (expression.Operator == "eq")
+}
+
+func (f *sseFilter) matchEventRecord(record common.Event, property, value string) bool {
+	var originOfCondition string
+	if record.OriginOfCondition != nil {
+		originOfCondition = strings.TrimSuffix(record.OriginOfCondition.Oid, "/")
+	}
+	switch property {
+	case "EventFormatType":
+		return value == eventFormat
+	case "EventType":
+		return record.EventType == value
+	case "MessageId":
+		return matchMessageID(record.MessageID, value)
+	case "RegistryPrefix":
+		return strings.Split(record.MessageID, ".")[0] == value
+	case "OriginResource":
+		value = strings.TrimSuffix(value, "/")
+		return originOfCondition != "" && (originOfCondition == value ||
+			(f.subordinateResources && strings.HasPrefix(originOfCondition, value+"/")))
+	case "ResourceType":
+		return matchResourceType(value, originOfCondition, f.subordinateResources)
+	}
+	return false
+}
+
+func (f *sseFilter) matchMetricReport(report map[string]interface{}, property, value string) bool {
+	switch property {
+	case "EventFormatType":
+		return value == metricReportFormat
+	case "MetricReportDefinition":
+		definition, _ := report["MetricReportDefinition"].(map[string]interface{})
+		oid, _ := definition["@odata.id"].(string)
+		return oid != "" && strings.TrimSuffix(oid, "/") == strings.TrimSuffix(value, "/")
+	}
+	return false
+}
+
+// matchMessageID returns true if the MessageId of the event is the value,
+// the version of the registry is ignored if the value does not have it
+func matchMessageID(messageID, value string) bool {
+	if messageID == value {
+		return true
+	}
+	idParts := strings.Split(messageID, ".")
+	valueParts := strings.Split(value, ".")
+	return len(valueParts) == 2 && len(idParts) > 2 &&
+		idParts[0] == valueParts[0] && idParts[len(idParts)-1] == valueParts[1]
+}
+
+// matchResourceType returns true if the origin of condition is a resource of
+// the resource type, or of a resource of the resource type with subordinate resources
+func matchResourceType(resourceType, originOfCondition string, subordinateResources bool) bool {
+	// the tasks and the licenses are subscribed through their collections
+	for origin, memberType := range memberEventCollections {
+		if memberType == resourceType && isMemberOfCollection(origin, originOfCondition) {
+			return true
+		}
+	}
+	collection, ok := common.ResourceTypes[resourceType]
+	if !ok {
+		collection = resourceType
+	}
+	segments := strings.Split(originOfCondition, "/")
+	if len(segments) < 3 {
+		return false
+	}
+	// the segment before the ID of the resource is its collection
+	if segments[len(segments)-2] == collection {
+		return true
+	}
+	if subordinateResources {
+		for _, segment := range segments[:len(segments)-2] {
+			if segment == collection {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,232 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
+)
+
+const (
+	sseTestEvent = `{"@odata.type":"#Event.v1_7_0.Event","Name":"Event","Events":[` +
+		`{"EventType":"Alert","EventId":"1","MessageId":"Alert.1.0.LanDisconnect",` +
+		`"OriginOfCondition":{"@odata.id":"/redfish/v1/Systems/uuid.1/EthernetInterfaces/1"}},` +
+		`{"EventType":"ResourceAdded","EventId":"2","MessageId":"ResourceEvent.1.0.ResourceAdded",` +
+		`"OriginOfCondition":{"@odata.id":"/redfish/v1/Systems/uuid.1"}}]}`
+	sseTestMetricReport = `{"@odata.type":"#MetricReport.v1_4_2.MetricReport","Id":"CPUUtilCustom1",` +
+		`"MetricReportDefinition":{"@odata.id":"/redfish/v1/TelemetryService/MetricReportDefinitions/CPUUtilCustom1"},"MetricValues":[]}`
+)
+
+// getSSEEventIDs returns the EventId of the records in the streamed event
+func getSSEEventIDs(t *testing.T, data []byte) []string {
+	var message common.MessageData
+	if err := json.Unmarshal(data, &message); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	var ids []string
+	for _, record := range message.Events {
+		ids = append(ids, record.EventID)
+	}
+	return ids
+}
+
+func TestParseSSEFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		wantErr bool
+	}{
+		{name: "empty filter", filter: ""},
+		{name: "single property", filter: "EventType eq Alert"},
+		{name: "combined properties", filter: "(MessageId eq 'Alert.1.0.LanDisconnect' or RegistryPrefix eq ResourceEvent) and OriginResource eq '/redfish/v1/Systems/uuid.1'"},
+		{name: "subordinate resources", filter: "OriginResource eq '/redfish/v1/Systems/uuid.1' and SubordinateResources eq true"},
+		{name: "invalid syntax", filter: "EventType eq", wantErr: true},
+		{name: "unsupported property", filter: "Severity eq OK", wantErr: true},
+		{name: "unsupported operator", filter: "EventType gt Alert", wantErr: true},
+		{name: "invalid subordinate resources", filter: "SubordinateResources eq 'yes'", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSSEFilter(tt.filter)
+			assert.Equal(t, tt.wantErr, err != nil, "error should be as expected")
+		})
+	}
+}
+
+func TestSSEFilterApply(t *testing.T) {
+	event := &evmodel.SSEEvent{ID: 7, EventFormatType: eventFormat, Data: sseTestEvent}
+	metricReport := &evmodel.SSEEvent{ID: 8, EventFormatType: metricReportFormat, Data: sseTestMetricReport}
+	tests := []struct {
+		name             string
+		filter           string
+		wantEventIDs     []string
+		wantMetricReport bool
+	}{
+		{name: "no filter", filter: "", wantEventIDs: []string{"1", "2"}, wantMetricReport: true},
+		{name: "event format", filter: "EventFormatType eq Event", wantEventIDs: []string{"1", "2"}},
+		{name: "metric report format", filter: "EventFormatType eq MetricReport", wantMetricReport: true},
+		{name: "event type", filter: "EventType eq Alert", wantEventIDs: []string{"1"}},
+		{name: "message id without version", filter: "MessageId eq 'ResourceEvent.ResourceAdded'", wantEventIDs: []string{"2"}},
+		{name: "registry prefix", filter: "RegistryPrefix eq Alert or RegistryPrefix eq ResourceEvent", wantEventIDs: []string{"1", "2"}},
+		{name: "origin resource", filter: "OriginResource eq '/redfish/v1/Systems/uuid.1'", wantEventIDs: []string{"2"}},
+		{name: "origin resource with subordinate resources",
+			filter: "OriginResource eq '/redfish/v1/Systems/uuid.1' and SubordinateResources eq true", wantEventIDs: []string{"1", "2"}},
+		{name: "resource type", filter: "ResourceType eq EthernetInterface", wantEventIDs: []string{"1"}},
+		{name: "resource type with subordinate resources",
+			filter: "ResourceType eq ComputerSystem and SubordinateResources eq true", wantEventIDs: []string{"1", "2"}},
+		{name: "not", filter: "not (EventType eq Alert)", wantEventIDs: []string{"2"}, wantMetricReport: true},
+		{name: "metric report definition",
+			filter: "MetricReportDefinition eq '/redfish/v1/TelemetryService/MetricReportDefinitions/CPUUtilCustom1'", wantMetricReport: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseSSEFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			data, ok := filter.apply(event)
+			assert.Equal(t, len(tt.wantEventIDs) > 0, ok, "event should be matched as expected")
+			if ok {
+				assert.Equal(t, tt.wantEventIDs, getSSEEventIDs(t, data), "matching records should be streamed")
+				var streamed map[string]interface{}
+				json.Unmarshal(data, &streamed)
+				assert.Equal(t, "7", streamed["Id"], "Id of the event should be the event ID")
+			}
+			_, ok = filter.apply(metricReport)
+			assert.Equal(t, tt.wantMetricReport, ok, "metric report should be matched as expected")
+		})
+	}
+}
+
+func TestStreamServerSentEvents(t *testing.T) {
+	config.SetUpMockConfig(t)
+	readInterval := sseReadInterval
+	sseReadInterval = 10 * time.Millisecond
+	defer func() { sseReadInterval = readInterval }()
+
+	var mutex sync.Mutex
+	buffer := map[int]*evmodel.SSEEvent{}
+	var lastID int
+	pc := getMockMethods()
+	pc.SaveSSEEvent = func(eventFormatType, data string, bufferSize int) (int, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		lastID++
+		buffer[lastID] = &evmodel.SSEEvent{ID: lastID, EventFormatType: eventFormatType, Data: data}
+		delete(buffer, lastID-bufferSize)
+		return lastID, nil
+	}
+	pc.GetLastSSEEventID = func() (int, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return lastID, nil
+	}
+	pc.GetSSEEvent = func(id int) (*evmodel.SSEEvent, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return buffer[id], nil
+	}
+	// the mock config keeps the last three events
+	for i := 0; i < 4; i++ {
+		pc.publishServerSentEvent(eventFormat, sseTestEvent)
+	}
+	pc.publishServerSentEvent(metricReportFormat, sseTestMetricReport)
+
+	stream := func(req *eventsproto.EventRequest, wantMessages int) []*eventsproto.ServerSentEvent {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		var messages []*eventsproto.ServerSentEvent
+		err := pc.StreamServerSentEvents(ctx, req, func(message *eventsproto.ServerSentEvent) error {
+			messages = append(messages, message)
+			if len(messages) == wantMessages {
+				cancel()
+			}
+			return nil
+		})
+		assert.Nil(t, err, "There should be no error")
+		return messages
+	}
+
+	// without Last-Event-ID, only the new events are streamed
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		pc.publishServerSentEvent(eventFormat, sseTestEvent)
+	}()
+	messages := stream(&eventsproto.EventRequest{SessionToken: "validToken", Filter: "EventType eq Alert"}, 2)
+	if assert.Equal(t, 2, len(messages), "status and the new event should be streamed") {
+		assert.Equal(t, int32(http.StatusOK), messages[0].StatusCode)
+		assert.Equal(t, "6", messages[1].ID)
+		assert.Equal(t, []string{"1"}, getSSEEventIDs(t, messages[1].Data), "only the matching records should be streamed")
+	}
+
+	// with Last-Event-ID, the events in the buffer after it are streamed
+	messages = stream(&eventsproto.EventRequest{SessionToken: "validToken", LastEventID: "4"}, 3)
+	if assert.Equal(t, 3, len(messages), "status and the events after the Last-Event-ID should be streamed") {
+		assert.Equal(t, "5", messages[1].ID)
+		assert.Equal(t, "6", messages[2].ID)
+	}
+
+	// events which are removed from the buffer are skipped
+	messages = stream(&eventsproto.EventRequest{SessionToken: "validToken", LastEventID: "1", Filter: "EventFormatType eq Event"}, 3)
+	if assert.Equal(t, 3, len(messages), "status and the events in the buffer should be streamed") {
+		assert.Equal(t, "4", messages[1].ID)
+		assert.Equal(t, "6", messages[2].ID)
+	}
+
+	messages = stream(&eventsproto.EventRequest{SessionToken: "InValidToken"}, 1)
+	if assert.Equal(t, 1, len(messages), "only the status should be sent") {
+		assert.Equal(t, int32(http.StatusUnauthorized), messages[0].StatusCode)
+	}
+	messages = stream(&eventsproto.EventRequest{SessionToken: "validToken", Filter: "Severity eq OK"}, 1)
+	if assert.Equal(t, 1, len(messages), "only the status should be sent") {
+		assert.Equal(t, int32(http.StatusBadRequest), messages[0].StatusCode)
+	}
+}
+
+func TestPublishServerSentEvent(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() { sseClients.checkedAt = time.Time{} }()
+	var saved int
+	connected := false
+	pc := getMockMethods()
+	pc.SaveSSEEvent = func(eventFormatType, data string, bufferSize int) (int, error) {
+		saved++
+		return saved, nil
+	}
+	pc.IsSSEClientConnected = func() (bool, error) {
+		return connected, nil
+	}
+
+	sseClients.checkedAt = time.Time{}
+	pc.publishServerSentEvent(eventFormat, sseTestEvent)
+	assert.Equal(t, 0, saved, "event should not be buffered when no SSE client is connected")
+
+	// a client connected to another instance is found once the cached check expires
+	connected = true
+	sseClients.checkedAt = time.Time{}
+	pc.publishServerSentEvent(eventFormat, sseTestEvent)
+	assert.Equal(t, 1, saved, "event should be buffered when an SSE client is connected")
+}
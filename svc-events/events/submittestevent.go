@@ -27,12 +27,12 @@ import (
 	"net/http"
 	"time"
 
-	uuid "github.com/satori/go.uuid"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
 )
 
 // SubmitTestEvent is a helper method to handle the submit test event request.
@@ -93,14 +93,14 @@ func (e *ExternalInterfaces) SubmitTestEvent(req *eventsproto.EventSubRequest) r
 	var message common.MessageData
 	message.Events = append(message.Events, *testEvent)
 	messageBytes, _ := json.Marshal(message)
-	eventUniqueID := uuid.NewV4().String()
 	for _, sub := range subscriptions {
 
 		for _, origin := range sub.OriginResources {
 			if sub.Destination != "" {
 				if filterEventsToBeForwarded(sub, message.Events[0], []string{origin}) {
 					log.Info("Destination: " + sub.Destination)
-					go e.postEvent(sub.Destination, eventUniqueID, messageBytes)
+					e.enqueueEvent(sub.Destination, []evmodel.Subscription{sub}, messageBytes)
+					break
 				}
 			}
 		}
@@ -0,0 +1,219 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
+)
+
+// patchableSubscriptionProperties are the properties of the event subscription which can be updated
+var patchableSubscriptionProperties = map[string]bool{
+	"Context":             true,
+	"DeliveryRetryPolicy": true,
+	"EventTypes":          true,
+	"MessageIds":          true,
+	"ResourceTypes":       true,
+}
+
+// UpdateEventSubscriptionsDetails updates the writable properties of the event subscription.
+// The devices of the origin resources are subscribed again only when the change in
+// EventTypes, MessageIds or ResourceTypes changes the events subscribed on the device.
+func (e *ExternalInterfaces) UpdateEventSubscriptionsDetails(req *eventsproto.EventRequest) response.RPC {
+	authResp := e.Auth(req.SessionToken, []string{common.PrivilegeConfigureComponents}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		log.Error(fmt.Sprintf("error while trying to authenticate session: status code: %v, status message: %v", authResp.StatusCode, authResp.StatusMessage))
+		return authResp
+	}
+	evtSubscription, err := e.getSubscription(req.EventSubscriptionID)
+	if err != nil {
+		errorMessage := "error while getting the event subscription: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if evtSubscription == nil {
+		errorMessage := "Subscription details not found for subscription id: " + req.EventSubscriptionID
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"EventSubscription", req.EventSubscriptionID}, nil)
+	}
+
+	var patchRequest map[string]interface{}
+	if err := json.Unmarshal(req.RequestBody, &patchRequest); err != nil {
+		errorMessage := "error while trying to unmarshal the event subscription update request: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+	}
+	if len(patchRequest) == 0 {
+		errorMessage := "error: request body of the event subscription update is empty"
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage, []interface{}{"Context"}, nil)
+	}
+	for key := range patchRequest {
+		if !patchableSubscriptionProperties[key] {
+			errorMessage := "error: " + key + " is not a writable property of event subscription"
+			log.Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{key}, nil)
+		}
+	}
+
+	request := evmodel.RequestBody{
+		Name:                 evtSubscription.Name,
+		Destination:          evtSubscription.Destination,
+		EventTypes:           evtSubscription.EventTypes,
+		MessageIds:           evtSubscription.MessageIds,
+		ResourceTypes:        evtSubscription.ResourceTypes,
+		Context:              evtSubscription.Context,
+		Protocol:             evtSubscription.Protocol,
+		SubscriptionType:     evtSubscription.SubscriptionType,
+		EventFormatType:      evtSubscription.EventFormatType,
+		SubordinateResources: evtSubscription.SubordinateResources,
+		DeliveryRetryPolicy:  evtSubscription.DeliveryRetryPolicy,
+	}
+	if err := json.Unmarshal(req.RequestBody, &request); err != nil {
+		errorMessage := "error: event subscription update request has an invalid property type: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueTypeError, errorMessage, []interface{}{string(req.RequestBody), "EventDestination"}, nil)
+	}
+	if statusCode, statusMessage, messageArgs, err := validateFields(&request); err != nil {
+		errorMessage := "error: request payload validation failed: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(statusCode, statusMessage, errorMessage, messageArgs, nil)
+	}
+
+	updatedSubscription := *evtSubscription
+	updatedSubscription.Context = request.Context
+	updatedSubscription.DeliveryRetryPolicy = request.DeliveryRetryPolicy
+	updatedSubscription.EventTypes = request.EventTypes
+	updatedSubscription.MessageIds = request.MessageIds
+	updatedSubscription.ResourceTypes = request.ResourceTypes
+
+	if !equalStringSets(evtSubscription.EventTypes, updatedSubscription.EventTypes) ||
+		!equalStringSets(evtSubscription.MessageIds, updatedSubscription.MessageIds) ||
+		!equalStringSets(evtSubscription.ResourceTypes, updatedSubscription.ResourceTypes) {
+		if err := e.resubscribeUpdatedSubscription(updatedSubscription, req.SessionToken); err != nil {
+			errorMessage := "error while subscribing the origin resources again: " + err.Error()
+			log.Error(errorMessage)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+	}
+
+	if err := e.UpdateEventSubscription(updatedSubscription); err != nil {
+		errorMessage := "error while trying to update the event subscription: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          createSubscriptionResponse(updatedSubscription),
+	}
+}
+
+// resubscribeUpdatedSubscription subscribes again the devices of the origin resources
+// of the updated subscription, whose subscribed events are changed by the update
+func (e *ExternalInterfaces) resubscribeUpdatedSubscription(updatedSubscription evmodel.Subscription, sessionToken string) error {
+	for _, origin := range updatedSubscription.OriginResources {
+		// ignore if origin is empty
+		if origin == "" {
+			continue
+		}
+		origins := []string{origin}
+		collection := ""
+		if isCollectionOriginResourceURI(origin) && !strings.Contains(origin, "Fabrics") {
+			members, _, _, _, _, err := e.checkCollection(origin)
+			if err != nil {
+				return err
+			}
+			origins = members
+			collection = origin
+		}
+		for _, memberOrigin := range origins {
+			subscriptions, err := e.getOriginSubscriptions(memberOrigin, collection)
+			if err != nil {
+				return err
+			}
+			var updatedSubscriptions []evmodel.Subscription
+			for _, evtSub := range subscriptions {
+				if evtSub.SubscriptionID == updatedSubscription.SubscriptionID {
+					evtSub = updatedSubscription
+				}
+				updatedSubscriptions = append(updatedSubscriptions, evtSub)
+			}
+			subscriptionPost := getSubscriptionPost(subscriptions)
+			updatedSubscriptionPost := getSubscriptionPost(updatedSubscriptions)
+			if equalStringSets(subscriptionPost.EventTypes, updatedSubscriptionPost.EventTypes) &&
+				equalStringSets(subscriptionPost.MessageIds, updatedSubscriptionPost.MessageIds) &&
+				equalStringSets(subscriptionPost.ResourceTypes, updatedSubscriptionPost.ResourceTypes) {
+				log.Info("Subscribed events are not changed for the origin resource " + memberOrigin)
+				continue
+			}
+			if err := e.subscribe(updatedSubscriptionPost, memberOrigin, false, sessionToken); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getOriginSubscriptions returns the subscriptions of the origin resource along with
+// the subscriptions of the collection, if the origin resource is a member of it
+func (e *ExternalInterfaces) getOriginSubscriptions(origin, collection string) ([]evmodel.Subscription, error) {
+	subscriptions, err := e.GetEvtSubscriptions(origin)
+	if err != nil && !strings.Contains(err.Error(), "No data found for the key") {
+		return nil, err
+	}
+	if collection != "" {
+		collectionSubscriptions, err := e.GetEvtSubscriptions(collection)
+		if err != nil && !strings.Contains(err.Error(), "No data found for the key") {
+			return nil, err
+		}
+		for _, evtSub := range collectionSubscriptions {
+			for _, originResource := range evtSub.OriginResources {
+				if originResource == collection {
+					subscriptions = append(subscriptions, evtSub)
+					break
+				}
+			}
+		}
+	}
+	subscriptions = e.getAllSubscriptions(origin, subscriptions)
+	return removeDuplicatesFromSubscription(subscriptions), nil
+}
+
+// equalStringSets checks whether both the slices have the same elements irrespective of the order
+func equalStringSets(first, second []string) bool {
+	firstSet := make(map[string]bool, len(first))
+	for _, element := range first {
+		firstSet[element] = true
+	}
+	secondSet := make(map[string]bool, len(second))
+	for _, element := range second {
+		if !firstSet[element] {
+			return false
+		}
+		secondSet[element] = true
+	}
+	return len(firstSet) == len(secondSet)
+}
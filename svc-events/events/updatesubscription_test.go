@@ -0,0 +1,147 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
+	"github.com/ODIM-Project/ODIM/svc-events/evcommon"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
+	"github.com/ODIM-Project/ODIM/svc-events/evresponse"
+)
+
+func TestUpdateEventSubscriptionsDetails(t *testing.T) {
+	config.SetUpMockConfig(t)
+	const subscriptionID = "81de0110-c35a-4859-984c-072d6c5a32d7"
+	tests := []struct {
+		name           string
+		token          string
+		subscriptionID string
+		requestBody    string
+		wantStatusCode int
+		wantPluginCall bool
+	}{
+		{name: "update context", token: "validToken", subscriptionID: subscriptionID, requestBody: `{"Context":"Rotated"}`, wantStatusCode: http.StatusOK},
+		{name: "update retry policy", token: "validToken", subscriptionID: subscriptionID, requestBody: `{"DeliveryRetryPolicy":"SuspendRetries"}`, wantStatusCode: http.StatusOK},
+		{name: "same event types in other order", token: "validToken", subscriptionID: subscriptionID, requestBody: `{"EventTypes":["ResourceAdded","Alert"]}`, wantStatusCode: http.StatusOK},
+		{name: "update event types", token: "validToken", subscriptionID: subscriptionID, requestBody: `{"EventTypes":["Alert"]}`, wantStatusCode: http.StatusOK, wantPluginCall: true},
+		{name: "invalid token", token: "InValidToken", subscriptionID: subscriptionID, requestBody: `{"Context":"Rotated"}`, wantStatusCode: http.StatusUnauthorized},
+		{name: "invalid subscription id", token: "validToken", subscriptionID: "1234", requestBody: `{"Context":"Rotated"}`, wantStatusCode: http.StatusNotFound},
+		{name: "malformed request", token: "validToken", subscriptionID: subscriptionID, requestBody: `{"Context":`, wantStatusCode: http.StatusBadRequest},
+		{name: "empty request", token: "validToken", subscriptionID: subscriptionID, requestBody: `{}`, wantStatusCode: http.StatusBadRequest},
+		{name: "read only property", token: "validToken", subscriptionID: subscriptionID, requestBody: `{"Destination":"https://10.10.10.10:8080/events"}`, wantStatusCode: http.StatusBadRequest},
+		{name: "invalid property type", token: "validToken", subscriptionID: subscriptionID, requestBody: `{"EventTypes":"Alert"}`, wantStatusCode: http.StatusBadRequest},
+		{name: "invalid event type", token: "validToken", subscriptionID: subscriptionID, requestBody: `{"EventTypes":["Invalid"]}`, wantStatusCode: http.StatusBadRequest},
+		{name: "invalid retry policy", token: "validToken", subscriptionID: subscriptionID, requestBody: `{"DeliveryRetryPolicy":"Invalid"}`, wantStatusCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := getMockMethods()
+			var pluginCalled bool
+			pc.ContactClient = func(url, method, token, odataID string, body interface{}, credentials map[string]string) (*http.Response, error) {
+				pluginCalled = true
+				return evcommon.MockContactClient(url, method, token, odataID, body, credentials)
+			}
+			var updatedSubscription *evmodel.Subscription
+			pc.UpdateEventSubscription = func(subscription evmodel.Subscription) error {
+				updatedSubscription = &subscription
+				return nil
+			}
+			resp := pc.UpdateEventSubscriptionsDetails(&eventsproto.EventRequest{
+				SessionToken:        tt.token,
+				EventSubscriptionID: tt.subscriptionID,
+				RequestBody:         []byte(tt.requestBody),
+			})
+			assert.Equal(t, tt.wantStatusCode, int(resp.StatusCode), "status code should be %v", tt.wantStatusCode)
+			assert.Equal(t, tt.wantPluginCall, pluginCalled, "origin resources should be subscribed again: %v", tt.wantPluginCall)
+			if tt.wantStatusCode != http.StatusOK {
+				assert.Nil(t, updatedSubscription, "subscription should not be updated")
+				return
+			}
+			if assert.NotNil(t, updatedSubscription, "subscription should be updated") {
+				body := resp.Body.(*evresponse.SubscriptionResponse)
+				assert.Equal(t, updatedSubscription.Context, body.Context)
+				assert.Equal(t, updatedSubscription.EventTypes, body.EventTypes)
+				assert.Equal(t, "https://odim.destination.com:9090/events", updatedSubscription.Destination)
+			}
+		})
+	}
+}
+
+func TestResubscribeUpdatedSubscription(t *testing.T) {
+	config.SetUpMockConfig(t)
+	origin := "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"
+	subscription := evmodel.Subscription{
+		SubscriptionID:  "1",
+		Destination:     "https://odim.destination.com:9090/events",
+		Protocol:        "Redfish",
+		EventTypes:      []string{"Alert"},
+		OriginResources: []string{origin},
+	}
+	// the other subscription of the origin resource subscribes to all the event types
+	otherSubscription := evmodel.Subscription{
+		SubscriptionID:  "2",
+		Destination:     "https://odim.destination.com:9091/events",
+		Protocol:        "Redfish",
+		OriginResources: []string{origin},
+	}
+	pc := getMockMethods()
+	pc.GetEvtSubscriptions = func(searchKey string) ([]evmodel.Subscription, error) {
+		return []evmodel.Subscription{subscription, otherSubscription}, nil
+	}
+	var pluginCalled bool
+	pc.ContactClient = func(url, method, token, odataID string, body interface{}, credentials map[string]string) (*http.Response, error) {
+		pluginCalled = true
+		return evcommon.MockContactClient(url, method, token, odataID, body, credentials)
+	}
+
+	updatedSubscription := subscription
+	updatedSubscription.EventTypes = []string{"Alert", "StatusChange"}
+	err := pc.resubscribeUpdatedSubscription(updatedSubscription, "validToken")
+	assert.Nil(t, err, "There should be no error")
+	assert.False(t, pluginCalled, "device should not be subscribed again when its event types are not changed")
+
+	otherSubscription.EventTypes = []string{"ResourceAdded"}
+	err = pc.resubscribeUpdatedSubscription(updatedSubscription, "validToken")
+	assert.Nil(t, err, "There should be no error")
+	assert.True(t, pluginCalled, "device should be subscribed again when its event types are changed")
+}
+
+func TestEqualStringSets(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  []string
+		second []string
+		want   bool
+	}{
+		{name: "both empty", first: nil, second: []string{}, want: true},
+		{name: "different order", first: []string{"Alert", "StatusChange"}, second: []string{"StatusChange", "Alert"}, want: true},
+		{name: "duplicates", first: []string{"Alert", "Alert"}, second: []string{"Alert"}, want: true},
+		{name: "different elements", first: []string{"Alert"}, second: []string{"StatusChange"}, want: false},
+		{name: "subset", first: []string{"Alert", "StatusChange"}, second: []string{"Alert"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := equalStringSets(tt.first, tt.second); got != tt.want {
+				t.Errorf("equalStringSets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
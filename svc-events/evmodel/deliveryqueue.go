@@ -0,0 +1,277 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package evmodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+const (
+	// EventDeliveryQueue holds table for the events waiting to be delivered to a destination
+	EventDeliveryQueue = "EventDeliveryQueue"
+
+	// EventDeliverySequence holds table for the sequence number of the events of a destination
+	EventDeliverySequence = "EventDeliverySequence"
+
+	// EventDeliveryDestination holds table for the destinations having events in the delivery queue
+	EventDeliveryDestination = "EventDeliveryDestination"
+
+	// EventDeliveryLease holds table for the instance which delivers the events of a destination
+	EventDeliveryLease = "EventDeliveryLease"
+
+	// DeadLetterEvents holds table for the events which could not be delivered after all the retries
+	DeadLetterEvents = "DeadLetterEvents"
+
+	// TerminateAfterRetries is the delivery retry policy to stop retrying an event
+	// after DeliveryRetryAttempts
+	TerminateAfterRetries = "TerminateAfterRetries"
+
+	// SuspendRetries is the delivery retry policy to suspend the subscription
+	// after DeliveryRetryAttempts
+	SuspendRetries = "SuspendRetries"
+)
+
+// QueuedEvent is the model for an event waiting in the delivery queue of a destination
+type QueuedEvent struct {
+	ID                  string    `json:"ID"`
+	DestinationID       string    `json:"DestinationID"`
+	Destination         string    `json:"Destination"`
+	SubscriptionIDs     []string  `json:"SubscriptionIDs"`
+	DeliveryRetryPolicy string    `json:"DeliveryRetryPolicy"`
+	Event               string    `json:"Event"`
+	Attempts            int       `json:"Attempts"`
+	QueuedTime          time.Time `json:"QueuedTime"`
+	LastAttemptTime     time.Time `json:"LastAttemptTime,omitempty"`
+	NextAttemptTime     time.Time `json:"NextAttemptTime"`
+	LastError           string    `json:"LastError,omitempty"`
+}
+
+// DeadLetterEvent is the model for an event of a subscription which could not be delivered
+type DeadLetterEvent struct {
+	QueuedEvent
+	SubscriptionID   string    `json:"SubscriptionID"`
+	DeadLetteredTime time.Time `json:"DeadLetteredTime"`
+}
+
+// queueKey returns the key of the event of the destination or the subscription
+func queueKey(prefix, id string) string {
+	return prefix + ":" + id
+}
+
+// GetDeliverySequence returns the next sequence number of the events of the destination
+func GetDeliverySequence(destinationID string) (int, error) {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return 0, fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	sequence, err := conn.Incr(EventDeliverySequence, destinationID)
+	if err != nil {
+		return 0, fmt.Errorf("error while trying to get the event sequence: %v", err.Error())
+	}
+	return sequence, nil
+}
+
+// SaveEventDestination saves the destination which has events in the delivery queue
+func SaveEventDestination(destinationID, destination string) error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	if err = conn.AddResourceData(EventDeliveryDestination, destinationID, destination); err != nil {
+		return fmt.Errorf("error while trying to save the event destination: %v", err.Error())
+	}
+	return nil
+}
+
+// GetEventDestinations returns the destinations which have events in the delivery queue
+// as a map of the destination ID to the destination
+func GetEventDestinations() (map[string]string, error) {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	keys, err := conn.GetAllDetails(EventDeliveryDestination)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to get the event destinations: %v", err.Error())
+	}
+	destinations := make(map[string]string, len(keys))
+	for _, key := range keys {
+		data, err := conn.Read(EventDeliveryDestination, key)
+		if err != nil {
+			continue
+		}
+		var destination string
+		if err := json.Unmarshal([]byte(data), &destination); err != nil {
+			return nil, fmt.Errorf("error while trying to unmarshal the event destination: %v", err.Error())
+		}
+		destinations[key] = destination
+	}
+	return destinations, nil
+}
+
+// DeleteEventDestination deletes the destination once its delivery queue is empty
+func DeleteEventDestination(destinationID string) error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	if err := conn.Delete(EventDeliveryDestination, destinationID); err != nil && err.ErrNo() != errors.DBKeyNotFound {
+		return fmt.Errorf("error while trying to delete the event destination: %v", err.Error())
+	}
+	return nil
+}
+
+// SaveQueuedEvent adds the event to the delivery queue of its destination,
+// or updates it if the event is already in the queue
+func SaveQueuedEvent(event QueuedEvent) error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	if err = conn.AddResourceData(EventDeliveryQueue, queueKey(event.DestinationID, event.ID), event); err != nil {
+		return fmt.Errorf("error while trying to save the queued event: %v", err.Error())
+	}
+	return nil
+}
+
+// GetQueuedEvents returns the events in the delivery queue of the destination
+// in the order of their arrival
+func GetQueuedEvents(destinationID string) ([]QueuedEvent, error) {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	keys, err := conn.GetAllMatchingDetails(EventDeliveryQueue, queueKey(destinationID, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to get the queued events: %v", err.Error())
+	}
+	sort.Strings(keys)
+	events := make([]QueuedEvent, 0, len(keys))
+	for _, key := range keys {
+		data, err := conn.Read(EventDeliveryQueue, key)
+		if err != nil {
+			continue
+		}
+		var event QueuedEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return nil, fmt.Errorf("error while trying to unmarshal the queued event: %v", err.Error())
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// DeleteQueuedEvent removes the event from the delivery queue of the destination
+func DeleteQueuedEvent(destinationID, eventID string) error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	if err := conn.Delete(EventDeliveryQueue, queueKey(destinationID, eventID)); err != nil && err.ErrNo() != errors.DBKeyNotFound {
+		return fmt.Errorf("error while trying to delete the queued event: %v", err.Error())
+	}
+	return nil
+}
+
+// AcquireDeliveryLease acquires or renews the lease of the instance to deliver the
+// events of the destination. It returns false if another instance holds the lease.
+func AcquireDeliveryLease(destinationID, owner string, leaseSeconds int) (bool, error) {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return false, fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	acquired, err := conn.AcquireLease(EventDeliveryLease, destinationID, owner, leaseSeconds)
+	if err != nil {
+		return false, fmt.Errorf("error while trying to acquire the delivery lease: %v", err.Error())
+	}
+	if acquired {
+		return true, nil
+	}
+	// renewing the lease, if it is held by the owner
+	renewed, err := conn.RenewLease(EventDeliveryLease, destinationID, owner, leaseSeconds)
+	if err != nil {
+		return false, fmt.Errorf("error while trying to renew the delivery lease: %v", err.Error())
+	}
+	return renewed, nil
+}
+
+// ReleaseDeliveryLease releases the lease of the instance to deliver the events of the destination
+func ReleaseDeliveryLease(destinationID, owner string) error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	if err := conn.ReleaseLease(EventDeliveryLease, destinationID, owner); err != nil {
+		return fmt.Errorf("error while trying to release the delivery lease: %v", err.Error())
+	}
+	return nil
+}
+
+// SaveDeadLetterEvent saves the event which could not be delivered for the subscription
+func SaveDeadLetterEvent(event DeadLetterEvent) error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	if err = conn.AddResourceData(DeadLetterEvents, queueKey(event.SubscriptionID, event.ID), event); err != nil {
+		return fmt.Errorf("error while trying to save the dead letter event: %v", err.Error())
+	}
+	return nil
+}
+
+// GetDeadLetterEvents returns the events of the subscription which could not be
+// delivered, in the order of their arrival
+func GetDeadLetterEvents(subscriptionID string) ([]DeadLetterEvent, error) {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	keys, err := conn.GetAllMatchingDetails(DeadLetterEvents, queueKey(subscriptionID, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to get the dead letter events: %v", err.Error())
+	}
+	sort.Strings(keys)
+	events := make([]DeadLetterEvent, 0, len(keys))
+	for _, key := range keys {
+		data, err := conn.Read(DeadLetterEvents, key)
+		if err != nil {
+			continue
+		}
+		var event DeadLetterEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return nil, fmt.Errorf("error while trying to unmarshal the dead letter event: %v", err.Error())
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// DeleteDeadLetterEvent deletes the dead letter event of the subscription
+func DeleteDeadLetterEvent(subscriptionID, eventID string) error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	if err := conn.Delete(DeadLetterEvents, queueKey(subscriptionID, eventID)); err != nil && err.ErrNo() != errors.DBKeyNotFound {
+		return fmt.Errorf("error while trying to delete the dead letter event: %v", err.Error())
+	}
+	return nil
+}
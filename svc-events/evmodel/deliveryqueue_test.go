@@ -0,0 +1,123 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package evmodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+func TestDeliveryQueue(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		if err := common.TruncateDB(common.OnDisk); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+	const destinationID = "destination-1"
+
+	sequence, err := GetDeliverySequence(destinationID)
+	assert.Nil(t, err, "There should be no error")
+	next, err := GetDeliverySequence(destinationID)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, sequence+1, next, "sequence should be incremented")
+
+	err = SaveEventDestination(destinationID, "https://odim.destination.com:9090/events")
+	assert.Nil(t, err, "There should be no error")
+	destinations, err := GetEventDestinations()
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, "https://odim.destination.com:9090/events", destinations[destinationID])
+
+	for _, id := range []string{"00000000000000000002", "00000000000000000001", "00000000000000000003"} {
+		err = SaveQueuedEvent(QueuedEvent{ID: id, DestinationID: destinationID, Event: `{"Events":[]}`})
+		assert.Nil(t, err, "There should be no error")
+	}
+	events, err := GetQueuedEvents(destinationID)
+	assert.Nil(t, err, "There should be no error")
+	if assert.Equal(t, 3, len(events), "there should be three queued events") {
+		assert.Equal(t, "00000000000000000001", events[0].ID, "events should be in the order of ID")
+		assert.Equal(t, "00000000000000000003", events[2].ID, "events should be in the order of ID")
+	}
+	assert.Nil(t, DeleteQueuedEvent(destinationID, "00000000000000000001"), "There should be no error")
+	assert.Nil(t, DeleteQueuedEvent(destinationID, "00000000000000000001"), "deleting a removed event should not fail")
+	events, _ = GetQueuedEvents(destinationID)
+	assert.Equal(t, 2, len(events), "there should be two queued events")
+
+	assert.Nil(t, DeleteEventDestination(destinationID), "There should be no error")
+	destinations, _ = GetEventDestinations()
+	assert.Equal(t, 0, len(destinations), "there should be no destinations")
+}
+
+func TestDeliveryLease(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		if err := common.TruncateDB(common.OnDisk); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+	const destinationID = "destination-1"
+
+	acquired, err := AcquireDeliveryLease(destinationID, "instance-1", 60)
+	assert.Nil(t, err, "There should be no error")
+	assert.True(t, acquired, "lease should be acquired")
+
+	acquired, err = AcquireDeliveryLease(destinationID, "instance-2", 60)
+	assert.Nil(t, err, "There should be no error")
+	assert.False(t, acquired, "lease held by another instance should not be acquired")
+
+	acquired, err = AcquireDeliveryLease(destinationID, "instance-1", 60)
+	assert.Nil(t, err, "There should be no error")
+	assert.True(t, acquired, "lease should be renewed by the holder")
+
+	ReleaseDeliveryLease(destinationID, "instance-2")
+	acquired, _ = AcquireDeliveryLease(destinationID, "instance-2", 60)
+	assert.False(t, acquired, "lease should not be released by another instance")
+
+	ReleaseDeliveryLease(destinationID, "instance-1")
+	acquired, _ = AcquireDeliveryLease(destinationID, "instance-2", 60)
+	assert.True(t, acquired, "released lease should be acquired")
+}
+
+func TestDeadLetterEvents(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		if err := common.TruncateDB(common.OnDisk); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+	for _, id := range []string{"00000000000000000002", "00000000000000000001"} {
+		err := SaveDeadLetterEvent(DeadLetterEvent{
+			QueuedEvent:    QueuedEvent{ID: id, Event: `{"Events":[]}`},
+			SubscriptionID: "subscription-1",
+		})
+		assert.Nil(t, err, "There should be no error")
+	}
+	events, err := GetDeadLetterEvents("subscription-1")
+	assert.Nil(t, err, "There should be no error")
+	if assert.Equal(t, 2, len(events), "there should be two undelivered events") {
+		assert.Equal(t, "00000000000000000001", events[0].ID, "events should be in the order of ID")
+	}
+	events, err = GetDeadLetterEvents("subscription-2")
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, 0, len(events), "there should be no undelivered events")
+
+	assert.Nil(t, DeleteDeadLetterEvent("subscription-1", "00000000000000000001"), "There should be no error")
+	events, _ = GetDeadLetterEvents("subscription-1")
+	assert.Equal(t, 1, len(events), "there should be one undelivered event")
+}
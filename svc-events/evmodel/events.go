@@ -57,8 +57,18 @@ const (
 	// AggregateSubscriptionIndex is a index name which required for indexing
 	// subscription of device
 	AggregateSubscriptionIndex = common.AggregateSubscriptionIndex
+
+	// EventServiceSettings holds table for the EventService properties updated with PATCH
+	EventServiceSettings = "EventServiceSettings"
 )
 
+// DeliveryRetrySettings is the model for the delivery retry properties of
+// the EventService which override the values in the configuration
+type DeliveryRetrySettings struct {
+	DeliveryRetryAttempts        int `json:"DeliveryRetryAttempts"`
+	DeliveryRetryIntervalSeconds int `json:"DeliveryRetryIntervalSeconds"`
+}
+
 // OdataIDLink containes link to a resource
 type OdataIDLink struct {
 	OdataID string `json:"@odata.id"`
@@ -106,6 +116,8 @@ type Subscription struct {
 	ExcludeMessageIds       []string `json:"ExcludeMessageIds,omitempty"`
 	ExcludeRegistryPrefixes []string `json:"ExcludeRegistryPrefixes,omitempty"`
 	DeliveryRetryPolicy     string   `json:"DeliveryRetryPolicy"`
+	// Suspended is set when the delivery is suspended by the SuspendRetries policy
+	Suspended bool `json:"Suspended,omitempty"`
 }
 
 //DeviceSubscription is a model to store the subscription details of a device
@@ -655,3 +667,36 @@ func GetAggregateList(hostIP string) ([]string, error) {
 	}
 	return aggregates, nil
 }
+
+// GetDeliveryRetrySettings returns the delivery retry settings of the EventService,
+// it returns nil if the settings are not updated yet
+func GetDeliveryRetrySettings() (*DeliveryRetrySettings, error) {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	data, err := conn.Read(EventServiceSettings, "EventService")
+	if err != nil {
+		if errors.DBKeyNotFound == err.ErrNo() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error while trying to get the EventService settings: %v", err.Error())
+	}
+	var settings DeliveryRetrySettings
+	if err := json.Unmarshal([]byte(data), &settings); err != nil {
+		return nil, fmt.Errorf("error while trying to unmarshal the EventService settings: %v", err.Error())
+	}
+	return &settings, nil
+}
+
+// SaveDeliveryRetrySettings saves the delivery retry settings of the EventService
+func SaveDeliveryRetrySettings(settings DeliveryRetrySettings) error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	if err = conn.AddResourceData(EventServiceSettings, "EventService", settings); err != nil {
+		return fmt.Errorf("error while trying to save the EventService settings: %v", err.Error())
+	}
+	return nil
+}
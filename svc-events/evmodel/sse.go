@@ -0,0 +1,156 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package evmodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+const (
+	// SSEEventBuffer holds table for the recent events kept for the server-sent event streams
+	SSEEventBuffer = "SSEEventBuffer"
+
+	// SSEEventSequence holds table for the ID of the last event added to the SSE event buffer
+	SSEEventSequence = "SSEEventSequence"
+
+	// SSEClientInstances holds table for the instances of the service having SSE clients connected
+	SSEClientInstances = "SSEClientInstances"
+
+	sseSequenceKey = "LastEventID"
+)
+
+// SSEEvent is the model for an event in the SSE event buffer
+type SSEEvent struct {
+	ID              int    `json:"ID"`
+	EventFormatType string `json:"EventFormatType"`
+	Data            string `json:"Data"`
+}
+
+// SaveSSEEvent adds the event to the SSE event buffer and returns its ID.
+// The oldest event is removed once the buffer has more than bufferSize events.
+func SaveSSEEvent(eventFormatType, data string, bufferSize int) (int, error) {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return 0, fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	id, err := conn.Incr(SSEEventSequence, sseSequenceKey)
+	if err != nil {
+		return 0, fmt.Errorf("error while trying to get the event ID: %v", err.Error())
+	}
+	event := SSEEvent{
+		ID:              id,
+		EventFormatType: eventFormatType,
+		Data:            data,
+	}
+	if err = conn.AddResourceData(SSEEventBuffer, strconv.Itoa(id), event); err != nil {
+		return 0, fmt.Errorf("error while trying to save the event: %v", err.Error())
+	}
+	if id > bufferSize {
+		if err := conn.Delete(SSEEventBuffer, strconv.Itoa(id-bufferSize)); err != nil && err.ErrNo() != errors.DBKeyNotFound {
+			return id, fmt.Errorf("error while trying to delete the oldest event: %v", err.Error())
+		}
+	}
+	return id, nil
+}
+
+// GetLastSSEEventID returns the ID of the last event added to the SSE event buffer
+func GetLastSSEEventID() (int, error) {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return 0, fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	data, err := conn.Read(SSEEventSequence, sseSequenceKey)
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("error while trying to get the last event ID: %v", err.Error())
+	}
+	id, cerr := strconv.Atoi(data)
+	if cerr != nil {
+		return 0, fmt.Errorf("error while trying to convert the last event ID: %v", cerr.Error())
+	}
+	return id, nil
+}
+
+// GetSSEEvent returns the event with the ID from the SSE event buffer.
+// It returns nil if the event is no longer in the buffer.
+func GetSSEEvent(id int) (*SSEEvent, error) {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	data, err := conn.Read(SSEEventBuffer, strconv.Itoa(id))
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error while trying to get the event: %v", err.Error())
+	}
+	var event SSEEvent
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		return nil, fmt.Errorf("error while trying to unmarshal the event: %v", err.Error())
+	}
+	return &event, nil
+}
+
+// SaveSSEClientInstance records that the instance has SSE clients connected.
+// The record expires after leaseSeconds, unless it is saved again.
+func SaveSSEClientInstance(instanceID string, leaseSeconds int) error {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	acquired, err := conn.AcquireLease(SSEClientInstances, instanceID, instanceID, leaseSeconds)
+	if err != nil {
+		return fmt.Errorf("error while trying to save the SSE client instance: %v", err.Error())
+	}
+	if !acquired {
+		if _, err := conn.RenewLease(SSEClientInstances, instanceID, instanceID, leaseSeconds); err != nil {
+			return fmt.Errorf("error while trying to renew the SSE client instance: %v", err.Error())
+		}
+	}
+	return nil
+}
+
+// DeleteSSEClientInstance deletes the record of the instance once its SSE clients are disconnected
+func DeleteSSEClientInstance(instanceID string) error {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	if err := conn.ReleaseLease(SSEClientInstances, instanceID, instanceID); err != nil {
+		return fmt.Errorf("error while trying to delete the SSE client instance: %v", err.Error())
+	}
+	return nil
+}
+
+// IsSSEClientConnected returns true if any instance of the service has SSE clients connected
+func IsSSEClientConnected() (bool, error) {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return false, fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	instances, err := conn.GetAllDetails(SSEClientInstances)
+	if err != nil {
+		return false, fmt.Errorf("error while trying to get the SSE client instances: %v", err.Error())
+	}
+	return len(instances) > 0, nil
+}
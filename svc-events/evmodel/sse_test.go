@@ -0,0 +1,57 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package evmodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+func TestSSEEventBuffer(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		if err := common.TruncateDB(common.InMemory); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+
+	lastID, err := GetLastSSEEventID()
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, 0, lastID, "there should be no events")
+
+	for i := 1; i <= 3; i++ {
+		id, err := SaveSSEEvent("Event", `{"Events":[]}`, 2)
+		assert.Nil(t, err, "There should be no error")
+		assert.Equal(t, i, id, "event ID should be incremented")
+	}
+	lastID, err = GetLastSSEEventID()
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, 3, lastID, "last event ID should be 3")
+
+	event, err := GetSSEEvent(1)
+	assert.Nil(t, err, "There should be no error")
+	assert.Nil(t, event, "oldest event should be removed from the buffer")
+
+	event, err = GetSSEEvent(3)
+	assert.Nil(t, err, "There should be no error")
+	if assert.NotNil(t, event, "event should be in the buffer") {
+		assert.Equal(t, "Event", event.EventFormatType)
+		assert.Equal(t, `{"Events":[]}`, event.Data)
+	}
+}
@@ -138,6 +138,38 @@ type Action struct {
 type Oem struct {
 }
 
+// UndeliveredEventsResponse is the response for the events which could not
+// be delivered to the destination of a subscription
+type UndeliveredEventsResponse struct {
+	OdataID      string                   `json:"@odata.id"`
+	Name         string                   `json:"Name"`
+	Description  string                   `json:"Description,omitempty"`
+	MembersCount int                      `json:"Members@odata.count"`
+	Members      []UndeliveredEvent       `json:"Members"`
+	Actions      UndeliveredEventsActions `json:"Actions"`
+}
+
+// UndeliveredEvent is an event which could not be delivered to the destination
+type UndeliveredEvent struct {
+	ID              string      `json:"Id"`
+	Event           interface{} `json:"Event"`
+	Attempts        int         `json:"Attempts"`
+	QueuedTime      string      `json:"QueuedTime"`
+	LastAttemptTime string      `json:"LastAttemptTime,omitempty"`
+	UndeliveredTime string      `json:"UndeliveredTime"`
+	LastError       string      `json:"LastError,omitempty"`
+}
+
+// UndeliveredEventsActions has the actions on the undelivered events
+type UndeliveredEventsActions struct {
+	Replay ActionTarget `json:"#UndeliveredEvents.Replay"`
+}
+
+// ActionTarget has the target URI of an action
+type ActionTarget struct {
+	Target string `json:"target"`
+}
+
 // MutexLock is a struct for mutex lock and Response and hosts
 type MutexLock struct {
 	Lock     *sync.Mutex
@@ -68,6 +68,8 @@ func main() {
 	// RunReadWorkers will create a worker pool for doing a specific task
 	// which is passed to it as PublishEventsToDestination method after reading the data from the channel.
 	common.RunReadWorkers(consumer.Out, events.Connector.PublishEventsToDestination, 5)
+	// StartEventDelivery delivers the events queued before the restart of the service
+	go events.Connector.StartEventDelivery()
 
 	// CreateJobQueue defines the queue which will act as an infinite buffer
 	// In channel is an entry or input channel and the Out channel is an exit or output channel
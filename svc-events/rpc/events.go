@@ -78,6 +78,26 @@ func GetPluginContactInitializer() *Events {
 			GetAggregateHosts:                evmodel.GetAggregateHosts,
 			UpdateAggregateHosts:             evmodel.UpdateAggregateHosts,
 			GetAggregateList:                 evmodel.GetAggregateList,
+			GetDeliverySequence:              evmodel.GetDeliverySequence,
+			SaveEventDestination:             evmodel.SaveEventDestination,
+			GetEventDestinations:             evmodel.GetEventDestinations,
+			DeleteEventDestination:           evmodel.DeleteEventDestination,
+			SaveQueuedEvent:                  evmodel.SaveQueuedEvent,
+			GetQueuedEvents:                  evmodel.GetQueuedEvents,
+			DeleteQueuedEvent:                evmodel.DeleteQueuedEvent,
+			AcquireDeliveryLease:             evmodel.AcquireDeliveryLease,
+			ReleaseDeliveryLease:             evmodel.ReleaseDeliveryLease,
+			SaveDeadLetterEvent:              evmodel.SaveDeadLetterEvent,
+			GetDeadLetterEvents:              evmodel.GetDeadLetterEvents,
+			DeleteDeadLetterEvent:            evmodel.DeleteDeadLetterEvent,
+			GetDeliveryRetrySettings:         evmodel.GetDeliveryRetrySettings,
+			SaveDeliveryRetrySettings:        evmodel.SaveDeliveryRetrySettings,
+			SaveSSEEvent:                     evmodel.SaveSSEEvent,
+			GetLastSSEEventID:                evmodel.GetLastSSEEventID,
+			GetSSEEvent:                      evmodel.GetSSEEvent,
+			SaveSSEClientInstance:            evmodel.SaveSSEClientInstance,
+			DeleteSSEClientInstance:          evmodel.DeleteSSEClientInstance,
+			IsSSEClientConnected:             evmodel.IsSSEClientConnected,
 		},
 	}
 	return &Events{
@@ -122,6 +142,7 @@ func (e *Events) GetEventService(ctx context.Context, req *eventsproto.EventSubR
 			break
 		}
 	}
+	deliveryRetrySettings := e.Connector.CurrentDeliveryRetrySettings()
 	var resourceTypes []string
 	for resType := range common.ResourceTypes {
 		resourceTypes = append(resourceTypes, resType)
@@ -146,8 +167,8 @@ func (e *Events) GetEventService(ctx context.Context, req *eventsproto.EventSubR
 			},
 			Oem: evresponse.Oem{},
 		},
-		DeliveryRetryAttempts:        config.Data.EventConf.DeliveryRetryAttempts,
-		DeliveryRetryIntervalSeconds: config.Data.EventConf.DeliveryRetryIntervalSeconds,
+		DeliveryRetryAttempts:        deliveryRetrySettings.DeliveryRetryAttempts,
+		DeliveryRetryIntervalSeconds: deliveryRetrySettings.DeliveryRetryIntervalSeconds,
 		EventFormatTypes:             []string{"Event", "MetricReport"},
 		EventTypesForSubscription: []string{
 			"StatusChange",
@@ -155,22 +176,20 @@ func (e *Events) GetEventService(ctx context.Context, req *eventsproto.EventSubR
 			"ResourceAdded",
 			"ResourceRemoved",
 			"Alert"},
-		RegistryPrefixes: []string{},
-		ResourceTypes:    resourceTypes,
-		//		ServerSentEventURI:"/redfish/v1/EventService/SSE",
-		ServiceEnabled: isServiceEnabled,
-		/*
-			SSEFilterPropertiesSupported: &evresponse.SSEFilterPropertiesSupported{
-				EventFormatType:        true,
-				EventType:              true,
-				MessageID:              true,
-				MetricReportDefinition: false,
-				OriginResource:         true,
-				RegistryPrefix:         false,
-				ResourceType:           true,
-				SubordinateResources:   true,
-			},
-		*/
+		RegistryPrefixes:   []string{},
+		ResourceTypes:      resourceTypes,
+		ServerSentEventURI: "/redfish/v1/EventService/SSE",
+		ServiceEnabled:     isServiceEnabled,
+		SSEFilterPropertiesSupported: &evresponse.SSEFilterPropertiesSupported{
+			EventFormatType:        true,
+			EventType:              true,
+			MessageID:              true,
+			MetricReportDefinition: true,
+			OriginResource:         true,
+			RegistryPrefix:         true,
+			ResourceType:           true,
+			SubordinateResources:   true,
+		},
 
 		Status: evresponse.Status{
 			Health:       "OK",
@@ -406,3 +425,61 @@ func (e *Events) DeleteAggregateSubscriptionsRPC(ctx context.Context, req *event
 	resp.Status = true
 	return &resp, nil
 }
+
+// GetUndeliveredEvents defines the operations which handles the RPC request response
+// for getting the events which could not be delivered to the destination of the subscription
+func (e *Events) GetUndeliveredEvents(ctx context.Context, req *eventsproto.EventRequest) (*eventsproto.EventSubResponse, error) {
+	return generateRPCResponse(e.Connector.GetUndeliveredEventsCollection(req)), nil
+}
+
+// ReplayUndeliveredEvents defines the operations which handles the RPC request response
+// for delivering again the undelivered events of the subscription
+func (e *Events) ReplayUndeliveredEvents(ctx context.Context, req *eventsproto.EventRequest) (*eventsproto.EventSubResponse, error) {
+	return generateRPCResponse(e.Connector.ReplayUndeliveredEvents(req)), nil
+}
+
+// PurgeUndeliveredEvents defines the operations which handles the RPC request response
+// for deleting the undelivered events of the subscription
+func (e *Events) PurgeUndeliveredEvents(ctx context.Context, req *eventsproto.EventRequest) (*eventsproto.EventSubResponse, error) {
+	return generateRPCResponse(e.Connector.PurgeUndeliveredEvents(req)), nil
+}
+
+// UpdateEventService defines the operations which handles the RPC request response
+// for updating the delivery retry settings of the EventService
+func (e *Events) UpdateEventService(ctx context.Context, req *eventsproto.EventSubRequest) (*eventsproto.EventSubResponse, error) {
+	data := e.Connector.UpdateEventService(req)
+	if data.StatusCode != http.StatusOK {
+		return generateRPCResponse(data), nil
+	}
+	return e.GetEventService(ctx, req)
+}
+
+// UpdateEventSubscription defines the operations which handles the RPC request response
+// for updating the writable properties of the event subscription
+func (e *Events) UpdateEventSubscription(ctx context.Context, req *eventsproto.EventRequest) (*eventsproto.EventSubResponse, error) {
+	return generateRPCResponse(e.Connector.UpdateEventSubscriptionsDetails(req)), nil
+}
+
+func generateRPCResponse(data response.RPC) *eventsproto.EventSubResponse {
+	var resp eventsproto.EventSubResponse
+	var err error
+	resp.Body, err = json.Marshal(data.Body)
+	if err != nil {
+		errorMessage := "error while trying marshal the response body: " + err.Error()
+		resp.StatusCode = http.StatusInternalServerError
+		resp.StatusMessage = response.InternalError
+		resp.Body, _ = json.Marshal(common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil).Body)
+		log.Error(errorMessage)
+		return &resp
+	}
+	resp.StatusCode = data.StatusCode
+	resp.StatusMessage = data.StatusMessage
+	resp.Header = data.Header
+	return &resp
+}
+
+// GetServerSentEvents defines the operations which handles the RPC request
+// for streaming the events to the SSE client
+func (e *Events) GetServerSentEvents(req *eventsproto.EventRequest, stream eventsproto.Events_GetServerSentEventsServer) error {
+	return e.Connector.StreamServerSentEvents(stream.Context(), req, stream.Send)
+}
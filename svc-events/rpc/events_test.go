@@ -56,6 +56,26 @@ func getMockPluginContactInitializer() *Events {
 			GetAllMatchingDetails:            evcommon.MockGetAllMatchingDetails,
 			SaveDeviceSubscription:           evcommon.MockSaveDeviceSubscription,
 			SaveUndeliveredEvents:            evcommon.MockSaveUndeliveredEvents,
+			GetDeliverySequence:              evcommon.MockGetDeliverySequence,
+			SaveEventDestination:             evcommon.MockSaveEventDestination,
+			GetEventDestinations:             evcommon.MockGetEventDestinations,
+			DeleteEventDestination:           evcommon.MockDeleteEventDestination,
+			SaveQueuedEvent:                  evcommon.MockSaveQueuedEvent,
+			GetQueuedEvents:                  evcommon.MockGetQueuedEvents,
+			DeleteQueuedEvent:                evcommon.MockDeleteQueuedEvent,
+			AcquireDeliveryLease:             evcommon.MockAcquireDeliveryLease,
+			ReleaseDeliveryLease:             evcommon.MockReleaseDeliveryLease,
+			SaveDeadLetterEvent:              evcommon.MockSaveDeadLetterEvent,
+			GetDeadLetterEvents:              evcommon.MockGetDeadLetterEvents,
+			DeleteDeadLetterEvent:            evcommon.MockDeleteDeadLetterEvent,
+			GetDeliveryRetrySettings:         evcommon.MockGetDeliveryRetrySettings,
+			SaveDeliveryRetrySettings:        evcommon.MockSaveDeliveryRetrySettings,
+			SaveSSEEvent:                     evcommon.MockSaveSSEEvent,
+			GetLastSSEEventID:                evcommon.MockGetLastSSEEventID,
+			GetSSEEvent:                      evcommon.MockGetSSEEvent,
+			SaveSSEClientInstance:            evcommon.MockSaveSSEClientInstance,
+			DeleteSSEClientInstance:          evcommon.MockDeleteSSEClientInstance,
+			IsSSEClientConnected:             evcommon.MockIsSSEClientConnected,
 		},
 	}
 	return &Events{
@@ -231,6 +251,57 @@ func TestDeleteEventSubscription(t *testing.T) {
 	assert.Equal(t, int(delResp.StatusCode), http.StatusNotFound, "Status code should be StatusNotFound.")
 }
 
+func TestUpdateEventSubscription(t *testing.T) {
+	config.SetUpMockConfig(t)
+	var ctx context.Context
+	events := getMockPluginContactInitializer()
+	req := &eventsproto.EventRequest{
+		SessionToken:        "validToken",
+		EventSubscriptionID: "81de0110-c35a-4859-984c-072d6c5a32d7",
+		RequestBody:         []byte(`{"Context":"Rotated"}`),
+	}
+
+	resp, err := events.UpdateEventSubscription(ctx, req)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, int(resp.StatusCode), http.StatusOK, "Status code should be StatusOK.")
+	var subscription evresponse.SubscriptionResponse
+	json.Unmarshal(resp.Body, &subscription)
+	assert.Equal(t, "Rotated", subscription.Context, "Context should be updated")
+
+	req.EventSubscriptionID = "81de0110"
+	updateResp, _ := events.UpdateEventSubscription(ctx, req)
+	assert.Equal(t, int(updateResp.StatusCode), http.StatusNotFound, "Status code should be StatusNotFound.")
+}
+
+func TestUpdateEventService(t *testing.T) {
+	config.SetUpMockConfig(t)
+	var ctx context.Context
+	events := getMockPluginContactInitializer()
+	var savedSettings *evmodel.DeliveryRetrySettings
+	events.Connector.GetDeliveryRetrySettings = func() (*evmodel.DeliveryRetrySettings, error) {
+		return savedSettings, nil
+	}
+	events.Connector.SaveDeliveryRetrySettings = func(settings evmodel.DeliveryRetrySettings) error {
+		savedSettings = &settings
+		return nil
+	}
+	req := &eventsproto.EventSubRequest{
+		SessionToken: "validToken",
+		PostBody:     []byte(`{"DeliveryRetryAttempts":5}`),
+	}
+
+	resp, err := events.UpdateEventService(ctx, req)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, int(resp.StatusCode), http.StatusOK, "Status code should be StatusOK.")
+	var eventServiceResp evresponse.EventServiceResponse
+	json.Unmarshal(resp.Body, &eventServiceResp)
+	assert.Equal(t, 5, eventServiceResp.DeliveryRetryAttempts, "DeliveryRetryAttempts should be updated")
+
+	req.PostBody = []byte(`{"DeliveryRetryAttempts":-1}`)
+	updateResp, _ := events.UpdateEventService(ctx, req)
+	assert.Equal(t, int(updateResp.StatusCode), http.StatusBadRequest, "Status code should be StatusBadRequest.")
+}
+
 func TestDeleteEventSubscriptionwithUUID(t *testing.T) {
 	config.SetUpMockConfig(t)
 	var ctx context.Context
@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-persistence-manager/persistencemgr"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
@@ -29,9 +30,17 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-licenses/model"
 
+	uuid "github.com/satori/go.uuid"
 	log "github.com/sirupsen/logrus"
 )
 
+const (
+	// LicenseExpiryCheckTable is the table name for the claims of the license expiry checks
+	LicenseExpiryCheckTable = "LicenseExpiryCheck"
+	// LicenseExpiryNotificationTable is the table name for the expiry state of the licenses last notified
+	LicenseExpiryNotificationTable = "LicenseExpiryNotification"
+)
+
 //GetAllKeysFromTable fetches all keys in a given table
 func GetAllKeysFromTable(table string, dbtype persistencemgr.DbType) ([]string, error) {
 	conn, err := persistencemgr.GetDBConnection(dbtype)
@@ -62,6 +71,88 @@ func GetResource(Table, key string, dbtype persistencemgr.DbType) (interface{},
 	return resource, nil
 }
 
+// DeleteResource deletes the resource of the key from the table
+func DeleteResource(table, key string, dbtype persistencemgr.DbType) *errors.Error {
+	conn, err := persistencemgr.GetDBConnection(dbtype)
+	if err != nil {
+		return err
+	}
+	if err = conn.Delete(table, key); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to delete resource: ", err.Error())
+	}
+	return nil
+}
+
+// AddToIndex adds the key to the sorted index in the in-memory database, ordered by the score
+func AddToIndex(index string, score int64, key string) error {
+	conn, err := persistencemgr.GetDBConnection(persistencemgr.InMemory)
+	if err != nil {
+		return err
+	}
+	if err := conn.CreateTaskIndex(index, score, key); err != nil {
+		return fmt.Errorf("error while trying to add %v to index %v: %v", key, index, err.Error())
+	}
+	return nil
+}
+
+// GetIndexRange fetches the keys of the sorted index with a score between min and max
+func GetIndexRange(index string, min, max int) ([]string, error) {
+	conn, err := persistencemgr.GetDBConnection(persistencemgr.InMemory)
+	if err != nil {
+		return nil, err
+	}
+	keys, getErr := conn.GetRange(index, min, max, true)
+	if getErr != nil {
+		return nil, fmt.Errorf("error while trying to get the range of index %v: %v", index, getErr.Error())
+	}
+	return keys, nil
+}
+
+// DeleteFromIndex removes the key from the sorted index
+func DeleteFromIndex(index, key string) error {
+	conn, err := persistencemgr.GetDBConnection(persistencemgr.InMemory)
+	if err != nil {
+		return err
+	}
+	if err := conn.Del(index, key); err != nil {
+		return fmt.Errorf("error while trying to delete %v from index %v: %v", key, index, err.Error())
+	}
+	return nil
+}
+
+// instanceID identifies this instance of the license service as the owner of the expiry check claims
+var instanceID = uuid.NewV4().String()
+
+// ClaimExpiryCheck marks the license expiry check at checkTime as taken, so that only one instance
+// of the license service runs it. Error is returned when the check is already claimed.
+func ClaimExpiryCheck(checkTime time.Time, expiretime int) error {
+	conn, err := persistencemgr.GetDBConnection(persistencemgr.InMemory)
+	if err != nil {
+		return err
+	}
+	key := strconv.FormatInt(checkTime.Unix(), 10)
+	claimed, err := conn.AcquireLease(LicenseExpiryCheckTable, key, instanceID, expiretime)
+	if err != nil {
+		return fmt.Errorf("unable to claim the license expiry check %v: %v", key, err.Error())
+	}
+	if !claimed {
+		return fmt.Errorf("the license expiry check %v is already claimed", key)
+	}
+	return nil
+}
+
+// SaveExpiryNotification records the expiry state of the license last notified to the subscribers
+func SaveExpiryNotification(uri, state string) error {
+	conn, err := persistencemgr.GetDBConnection(persistencemgr.InMemory)
+	if err != nil {
+		return err
+	}
+	if err = conn.AddResourceData(LicenseExpiryNotificationTable, uri, state); err != nil {
+		return fmt.Errorf("error while trying to save the expiry notification of %v: %v", uri, err.Error())
+	}
+	return nil
+}
+
 //GetTarget fetches the System(Target Device Credentials) table details
 func GetTarget(deviceUUID string) (*model.Target, *errors.Error) {
 	var target model.Target
@@ -133,7 +224,7 @@ func ContactPlugin(req model.PluginContactRequest, errorMessage string) ([]byte,
 		return nil, "", resp, fmt.Errorf(errorMessage)
 	}
 
-	if pluginResponse.StatusCode != http.StatusCreated && pluginResponse.StatusCode != http.StatusOK {
+	if pluginResponse.StatusCode != http.StatusCreated && pluginResponse.StatusCode != http.StatusOK && pluginResponse.StatusCode != http.StatusNoContent {
 		if pluginResponse.StatusCode == http.StatusUnauthorized {
 			errorMessage += "error: invalid resource username/password"
 			resp.StatusCode = int32(pluginResponse.StatusCode)
@@ -15,15 +15,21 @@
 package licenses
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"runtime"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-persistence-manager/persistencemgr"
 	"github.com/ODIM-Project/ODIM/lib-rest-client/pmbhandle"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	lcommon "github.com/ODIM-Project/ODIM/svc-licenses/lcommon"
+	"github.com/ODIM-Project/ODIM/svc-licenses/lmessagebus"
 	"github.com/ODIM-Project/ODIM/svc-licenses/model"
 )
 
@@ -43,12 +49,22 @@ type External struct {
 	GetTarget          func(string) (*model.Target, *errors.Error)
 	GetSessionUserName func(string) (string, error)
 	GenericSave        func([]byte, string, string) error
+	PublishEvent       func(common.Event)
+	CreateTask         func(string) (string, error)
+	CreateChildTask    func(string, string) (string, error)
+	UpdateTask         func(common.TaskData) error
 }
 
 // DB struct holds the function pointers to database operations
 type DB struct {
 	GetAllKeysFromTable func(string, persistencemgr.DbType) ([]string, error)
 	GetResource         func(string, string, persistencemgr.DbType) (interface{}, *errors.Error)
+	DeleteResource      func(string, string, persistencemgr.DbType) *errors.Error
+	AddToIndex          func(string, int64, string) error
+	GetIndexRange       func(string, int, int) ([]string, error)
+	DeleteFromIndex     func(string, string) error
+	ClaimExpiryCheck    func(time.Time, int) error
+	SaveNotification    func(string, string) error
 }
 
 // GetExternalInterface retrieves all the external connections update package functions uses
@@ -63,10 +79,61 @@ func GetExternalInterface() *ExternalInterface {
 			GetTarget:          lcommon.GetTarget,
 			GetSessionUserName: services.GetSessionUserName,
 			GenericSave:        lcommon.GenericSave,
+			PublishEvent:       lmessagebus.Publish,
+			CreateTask:         services.CreateTask,
+			CreateChildTask:    services.CreateChildTask,
+			UpdateTask:         UpdateTaskData,
 		},
 		DB: DB{
 			GetAllKeysFromTable: lcommon.GetAllKeysFromTable,
 			GetResource:         lcommon.GetResource,
+			DeleteResource:      lcommon.DeleteResource,
+			AddToIndex:          lcommon.AddToIndex,
+			GetIndexRange:       lcommon.GetIndexRange,
+			DeleteFromIndex:     lcommon.DeleteFromIndex,
+			ClaimExpiryCheck:    lcommon.ClaimExpiryCheck,
+			SaveNotification:    lcommon.SaveExpiryNotification,
 		},
 	}
 }
+
+// UpdateTaskData update the task with the given data
+func UpdateTaskData(taskData common.TaskData) error {
+	respBody, _ := json.Marshal(taskData.Response.Body)
+	payLoad := &taskproto.Payload{
+		HTTPHeaders:   taskData.Response.Header,
+		HTTPOperation: taskData.HTTPMethod,
+		JSONBody:      taskData.TaskRequest,
+		StatusCode:    taskData.Response.StatusCode,
+		TargetURI:     taskData.TargetURI,
+		ResponseBody:  respBody,
+	}
+
+	err := services.UpdateTask(taskData.TaskID, taskData.TaskState, taskData.TaskStatus, taskData.PercentComplete, payLoad, time.Now())
+	if err != nil && (err.Error() == common.Cancelling) {
+		// the install in progress for the task stops and marks the task cancelled
+		if common.CancelTaskContext(taskData.TaskID) {
+			return err
+		}
+		// The licenses installed on the managers cannot be reverted, the task is only marked cancelled
+		services.UpdateTask(taskData.TaskID, common.Cancelled, taskData.TaskStatus, taskData.PercentComplete, payLoad, time.Now())
+		if taskData.PercentComplete == 0 {
+			return fmt.Errorf("error while starting the task: %v", err)
+		}
+		runtime.Goexit()
+	}
+	return nil
+}
+
+func fillTaskData(taskID, targetURI, request string, resp response.RPC, taskState string, taskStatus string, percentComplete int32, httpMethod string) common.TaskData {
+	return common.TaskData{
+		TaskID:          taskID,
+		TargetURI:       targetURI,
+		TaskRequest:     request,
+		Response:        resp,
+		TaskState:       taskState,
+		TaskStatus:      taskStatus,
+		PercentComplete: percentComplete,
+		HTTPMethod:      httpMethod,
+	}
+}
@@ -49,17 +49,24 @@ func TestGetExternalInterface(t *testing.T) {
 func mockGetExternalInterface() *ExternalInterface {
 	return &ExternalInterface{
 		External: External{
-			Auth:           mockIsAuthorized,
-			ContactClient:  mockContactClient,
-			GetTarget:      mockGetTarget,
-			GetPluginData:  mockGetPluginData,
-			ContactPlugin:  mockContactPlugin,
-			DevicePassword: stubDevicePassword,
-			GenericSave:    stubGenericSave,
+			Auth:            mockIsAuthorized,
+			ContactClient:   mockContactClient,
+			GetTarget:       mockGetTarget,
+			GetPluginData:   mockGetPluginData,
+			ContactPlugin:   mockContactPlugin,
+			DevicePassword:  stubDevicePassword,
+			GenericSave:     stubGenericSave,
+			PublishEvent:    stubPublishEvent,
+			CreateChildTask: mockCreateChildTask,
+			UpdateTask:      mockUpdateTask,
 		},
 		DB: DB{
 			GetAllKeysFromTable: mockGetAllKeysFromTable,
 			GetResource:         mockGetResource,
+			DeleteResource:      stubDeleteResource,
+			AddToIndex:          stubAddToIndex,
+			GetIndexRange:       stubGetIndexRange,
+			DeleteFromIndex:     stubDeleteFromIndex,
 		},
 	}
 }
@@ -74,6 +81,32 @@ func stubGenericSave(reqBody []byte, table string, uuid string) error {
 	return nil
 }
 
+func stubPublishEvent(event common.Event) {}
+
+func mockCreateChildTask(sessionUserName, taskID string) (string, error) {
+	return "/redfish/v1/TaskService/Tasks/subtask12345", nil
+}
+
+func mockUpdateTask(task common.TaskData) error {
+	return nil
+}
+
+func stubDeleteResource(table, key string, dbtype persistencemgr.DbType) *errors.Error {
+	return nil
+}
+
+func stubAddToIndex(index string, score int64, key string) error {
+	return nil
+}
+
+func stubGetIndexRange(index string, min, max int) ([]string, error) {
+	return []string{}, nil
+}
+
+func stubDeleteFromIndex(index, key string) error {
+	return nil
+}
+
 func stubDevicePassword(password []byte) ([]byte, error) {
 	return password, nil
 }
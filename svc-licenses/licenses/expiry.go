@@ -0,0 +1,331 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package licenses
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-persistence-manager/persistencemgr"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	lcommon "github.com/ODIM-Project/ODIM/svc-licenses/lcommon"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// licenseExpiryIndex is the sorted index of the licenses scored by the unix time of their expiry
+	licenseExpiryIndex = "LicenseExpiryIndex"
+	// licenseGraceExpiryIndex is the sorted index of the licenses scored by the unix time of the end of their grace period
+	licenseGraceExpiryIndex = "LicenseGraceExpiryIndex"
+	day                     = 24 * time.Hour
+)
+
+// expiry states of the licenses
+const (
+	expiryStatePerpetual   = "Perpetual"
+	expiryStateActive      = "Active"
+	expiryStateExpiring    = "Expiring"
+	expiryStateGracePeriod = "GracePeriod"
+	expiryStateExpired     = "Expired"
+)
+
+// message keys of the License registry published when a license is about to expire or has expired
+const (
+	daysBeforeExpiration = "DaysBeforeExpiration"
+	gracePeriod          = "GracePeriod"
+	expired              = "Expired"
+)
+
+// licenseEventRegistry holds the severity and the message format of the
+// License registry messages, the license ID is always the first argument
+var licenseEventRegistry = map[string]struct {
+	severity string
+	message  string
+}{
+	daysBeforeExpiration: {common.Warning, "The license '%v' will expire in %v days."},
+	gracePeriod:          {common.Warning, "The license '%v' has expired, %v day grace period before licensed functionality is disabled."},
+	expired:              {common.Critical, "The license '%v' has expired."},
+}
+
+// licenseExpiry holds the expiry of a license derived from its expiration date and grace period
+type licenseExpiry struct {
+	expirationDate     time.Time
+	graceEndDate       time.Time
+	state              string
+	remainingDays      int
+	remainingGraceDays int
+}
+
+// expirationWarningDays returns the days before the expiry of a license from which it is reported as expiring
+func expirationWarningDays() int {
+	if config.Data.LicenseConf == nil {
+		return config.DefaultLicenseExpirationWarningDays
+	}
+	return config.Data.LicenseConf.ExpirationWarningDays
+}
+
+// getLicenseExpiry returns the expiry of the license at the time now,
+// it returns false for a perpetual license or a license without a valid expiration date
+func getLicenseExpiry(license dmtf.License, now time.Time) (licenseExpiry, bool) {
+	if license.ExpirationDate == "" || strings.EqualFold(license.LicenseType, expiryStatePerpetual) {
+		return licenseExpiry{state: expiryStatePerpetual}, false
+	}
+	expirationDate, err := time.Parse(time.RFC3339, license.ExpirationDate)
+	if err != nil {
+		log.Warn("invalid ExpirationDate " + license.ExpirationDate + " of license " + license.ID + ": " + err.Error())
+		return licenseExpiry{state: expiryStatePerpetual}, false
+	}
+	graceEndDate := expirationDate.Add(time.Duration(license.GracePeriodDays) * day)
+	expiry := licenseExpiry{
+		expirationDate:     expirationDate,
+		graceEndDate:       graceEndDate,
+		remainingDays:      daysUntil(expirationDate, now),
+		remainingGraceDays: daysUntil(graceEndDate, now),
+	}
+	switch {
+	case !now.Before(expiry.graceEndDate):
+		expiry.state = expiryStateExpired
+	case !now.Before(expiry.expirationDate):
+		expiry.state = expiryStateGracePeriod
+	case expiry.expirationDate.Sub(now) <= time.Duration(expirationWarningDays())*day:
+		expiry.state = expiryStateExpiring
+	default:
+		expiry.state = expiryStateActive
+	}
+	return expiry, true
+}
+
+// daysUntil returns the days left till the time t, a part of a day is counted as a day
+func daysUntil(t, now time.Time) int {
+	if !t.After(now) {
+		return 0
+	}
+	return int(math.Ceil(float64(t.Sub(now)) / float64(day)))
+}
+
+// getLicenses reads the licenses of all the managers, keyed by their URI
+func (e *ExternalInterface) getLicenses() (map[string]dmtf.License, error) {
+	keys, err := e.DB.GetAllKeysFromTable("Licenses", persistencemgr.InMemory)
+	if err != nil {
+		return nil, err
+	}
+	licenses := make(map[string]dmtf.License, len(keys))
+	for _, key := range keys {
+		data, dbErr := e.DB.GetResource("Licenses", key, persistencemgr.InMemory)
+		if dbErr != nil {
+			// license is removed after the keys are read
+			log.Warn("Unable to get license data of " + key + ": " + dbErr.Error())
+			continue
+		}
+		var license dmtf.License
+		licenseData, _ := data.(string)
+		if err := json.Unmarshal([]byte(licenseData), &license); err != nil {
+			log.Warn("Unable to unmarshal the license data of " + key + ": " + err.Error())
+			continue
+		}
+		licenses[key] = license
+	}
+	return licenses, nil
+}
+
+// TrackLicenseExpiry checks the expiry of the licenses at the interval in the configuration,
+// and publishes an event when a license is about to expire, enters its grace period or expires.
+// Every instance wakes up at the start of the interval, and the check is run by the one which claims it.
+func (e *ExternalInterface) TrackLicenseExpiry() {
+	for {
+		interval := time.Duration(config.Data.LicenseConf.ExpiryCheckIntervalInMins) * time.Minute
+		checkTime := time.Now().Truncate(interval)
+		if err := e.DB.ClaimExpiryCheck(checkTime, int(interval.Seconds())); err != nil {
+			log.Debug("license expiry check is taken by another instance: " + err.Error())
+		} else {
+			e.checkLicenseExpiry(time.Now())
+		}
+		time.Sleep(time.Until(checkTime.Add(interval)))
+	}
+}
+
+// checkLicenseExpiry updates the expiry indexes with the licenses and publishes the events
+// of the licenses whose expiry state has changed since the last notification
+func (e *ExternalInterface) checkLicenseExpiry(now time.Time) {
+	licenses, err := e.getLicenses()
+	if err != nil {
+		log.Error("error while getting the licenses to check their expiry: " + err.Error())
+		return
+	}
+	if err := e.updateExpiryIndexes(licenses); err != nil {
+		log.Error("error while updating the license expiry indexes: " + err.Error())
+		return
+	}
+	states, err := e.getExpiryStates(now)
+	if err != nil {
+		log.Error("error while getting the expiring licenses: " + err.Error())
+		return
+	}
+	notified, err := e.getNotifiedStates()
+	if err != nil {
+		log.Error("error while getting the notified expiry states: " + err.Error())
+		return
+	}
+	for uri, state := range states {
+		license, ok := licenses[uri]
+		if !ok || notified[uri] == state {
+			continue
+		}
+		// the state is saved first, so that the event is not published again when it can't be saved
+		if err := e.DB.SaveNotification(uri, state); err != nil {
+			log.Error(err.Error())
+			continue
+		}
+		expiry, _ := getLicenseExpiry(license, now)
+		expiry.state = state
+		e.External.PublishEvent(licenseExpiryEvent(uri, expiry))
+	}
+	for uri := range notified {
+		if _, ok := states[uri]; !ok {
+			if err := e.DB.DeleteResource(lcommon.LicenseExpiryNotificationTable, uri, persistencemgr.InMemory); err != nil {
+				log.Warn(err.Error())
+			}
+		}
+	}
+}
+
+// getNotifiedStates reads the expiry state of the licenses last notified, keyed by their URI
+func (e *ExternalInterface) getNotifiedStates() (map[string]string, error) {
+	keys, err := e.DB.GetAllKeysFromTable(lcommon.LicenseExpiryNotificationTable, persistencemgr.InMemory)
+	if err != nil {
+		return nil, err
+	}
+	notified := make(map[string]string, len(keys))
+	for _, key := range keys {
+		data, dbErr := e.DB.GetResource(lcommon.LicenseExpiryNotificationTable, key, persistencemgr.InMemory)
+		if dbErr != nil {
+			// notification is removed after the keys are read
+			log.Warn("Unable to get the expiry notification of " + key + ": " + dbErr.Error())
+			continue
+		}
+		notified[key], _ = data.(string)
+	}
+	return notified, nil
+}
+
+// updateExpiryIndexes adds the licenses with an expiry to the expiry indexes,
+// and removes the licenses which are deleted or do not expire anymore
+func (e *ExternalInterface) updateExpiryIndexes(licenses map[string]dmtf.License) error {
+	for _, index := range []string{licenseExpiryIndex, licenseGraceExpiryIndex} {
+		indexed, err := e.DB.GetIndexRange(index, 0, math.MaxInt64)
+		if err != nil {
+			return err
+		}
+		for _, uri := range indexed {
+			if license, ok := licenses[uri]; ok {
+				if _, expires := getLicenseExpiry(license, time.Now()); expires {
+					continue
+				}
+			}
+			if err := e.DB.DeleteFromIndex(index, uri); err != nil {
+				log.Warn(err.Error())
+			}
+		}
+	}
+	for uri, license := range licenses {
+		expiry, ok := getLicenseExpiry(license, time.Now())
+		if !ok {
+			continue
+		}
+		if err := e.DB.AddToIndex(licenseExpiryIndex, expiry.expirationDate.Unix(), uri); err != nil {
+			return err
+		}
+		if err := e.DB.AddToIndex(licenseGraceExpiryIndex, expiry.graceEndDate.Unix(), uri); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getExpiryStates returns the expiry state of the licenses which are expiring, in grace period
+// or expired at the time now, from the ranges of the expiry indexes
+func (e *ExternalInterface) getExpiryStates(now time.Time) (map[string]string, error) {
+	states := make(map[string]string)
+	expiringLicenses, err := e.DB.GetIndexRange(licenseExpiryIndex, int(now.Unix())+1, int(now.Add(time.Duration(expirationWarningDays())*day).Unix()))
+	if err != nil {
+		return nil, err
+	}
+	for _, uri := range expiringLicenses {
+		states[uri] = expiryStateExpiring
+	}
+	gracePeriodLicenses, err := e.DB.GetIndexRange(licenseExpiryIndex, 0, int(now.Unix()))
+	if err != nil {
+		return nil, err
+	}
+	for _, uri := range gracePeriodLicenses {
+		states[uri] = expiryStateGracePeriod
+	}
+	expiredLicenses, err := e.DB.GetIndexRange(licenseGraceExpiryIndex, 0, int(now.Unix()))
+	if err != nil {
+		return nil, err
+	}
+	for _, uri := range expiredLicenses {
+		states[uri] = expiryStateExpired
+	}
+	return states, nil
+}
+
+// removeFromExpiryIndexes removes the deleted license from the expiry indexes
+func (e *ExternalInterface) removeFromExpiryIndexes(uri string) {
+	for _, index := range []string{licenseExpiryIndex, licenseGraceExpiryIndex} {
+		// the licenses which do not expire are not in the indexes
+		if err := e.DB.DeleteFromIndex(index, uri); err != nil {
+			log.Debug(err.Error())
+		}
+	}
+}
+
+// licenseExpiryEvent builds the Alert event published for the expiry state of the license,
+// the OriginOfCondition of the event is the license
+func licenseExpiryEvent(uri string, expiry licenseExpiry) common.Event {
+	key := expired
+	messageArgs := []string{path.Base(uri)}
+	switch expiry.state {
+	case expiryStateExpiring:
+		key = daysBeforeExpiration
+		messageArgs = append(messageArgs, strconv.Itoa(expiry.remainingDays))
+	case expiryStateGracePeriod:
+		key = gracePeriod
+		messageArgs = append(messageArgs, strconv.Itoa(expiry.remainingGraceDays))
+	}
+	entry := licenseEventRegistry[key]
+	args := make([]interface{}, len(messageArgs))
+	for i, arg := range messageArgs {
+		args[i] = arg
+	}
+	return common.Event{
+		EventType:   "Alert",
+		MessageID:   common.LicenseEventType + "." + key,
+		Severity:    entry.severity,
+		Message:     fmt.Sprintf(entry.message, args...),
+		MessageArgs: messageArgs,
+		OriginOfCondition: &common.Link{
+			Oid: uri,
+		},
+	}
+}
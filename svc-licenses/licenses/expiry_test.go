@@ -0,0 +1,241 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package licenses
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-persistence-manager/persistencemgr"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	licenseproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/licenses"
+	lcommon "github.com/ODIM-Project/ODIM/svc-licenses/lcommon"
+	"github.com/ODIM-Project/ODIM/svc-licenses/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const licensesURI = "/redfish/v1/LicenseService/Licenses/"
+
+// mockLicenseDB holds the licenses table, the expiry notifications, the claimed
+// expiry checks and the sorted indexes of the in-memory database
+type mockLicenseDB struct {
+	licenses      map[string]dmtf.License
+	notifications map[string]string
+	checks        map[time.Time]bool
+	indexes       map[string]map[string]int64
+}
+
+func newMockLicenseDB(licenses map[string]dmtf.License) *mockLicenseDB {
+	return &mockLicenseDB{
+		licenses:      licenses,
+		notifications: make(map[string]string),
+		checks:        make(map[time.Time]bool),
+		indexes:       make(map[string]map[string]int64),
+	}
+}
+
+func (db *mockLicenseDB) externalInterface(events *[]common.Event) *ExternalInterface {
+	e := mockGetExternalInterface()
+	e.External.PublishEvent = func(event common.Event) {
+		*events = append(*events, event)
+	}
+	e.DB.GetAllKeysFromTable = func(table string, dbtype persistencemgr.DbType) ([]string, error) {
+		var keys []string
+		if table == lcommon.LicenseExpiryNotificationTable {
+			for key := range db.notifications {
+				keys = append(keys, key)
+			}
+			return keys, nil
+		}
+		for key := range db.licenses {
+			keys = append(keys, key)
+		}
+		return keys, nil
+	}
+	e.DB.GetResource = func(table, key string, dbtype persistencemgr.DbType) (interface{}, *errors.Error) {
+		if table == lcommon.LicenseExpiryNotificationTable {
+			state, ok := db.notifications[key]
+			if !ok {
+				return "", errors.PackError(errors.DBKeyNotFound, "not found")
+			}
+			return state, nil
+		}
+		license, ok := db.licenses[key]
+		if !ok {
+			return "", errors.PackError(errors.DBKeyNotFound, "not found")
+		}
+		data, _ := json.Marshal(license)
+		return string(data), nil
+	}
+	e.DB.AddToIndex = func(index string, score int64, key string) error {
+		if db.indexes[index] == nil {
+			db.indexes[index] = make(map[string]int64)
+		}
+		db.indexes[index][key] = score
+		return nil
+	}
+	e.DB.GetIndexRange = func(index string, min, max int) ([]string, error) {
+		keys := []string{}
+		for key, score := range db.indexes[index] {
+			if score >= int64(min) && score <= int64(max) {
+				keys = append(keys, key)
+			}
+		}
+		return keys, nil
+	}
+	e.DB.DeleteResource = func(table, key string, dbtype persistencemgr.DbType) *errors.Error {
+		if table == lcommon.LicenseExpiryNotificationTable {
+			delete(db.notifications, key)
+		}
+		return nil
+	}
+	e.DB.SaveNotification = func(uri, state string) error {
+		db.notifications[uri] = state
+		return nil
+	}
+	e.DB.ClaimExpiryCheck = func(checkTime time.Time, expiretime int) error {
+		if db.checks[checkTime] {
+			return fmt.Errorf("already claimed")
+		}
+		db.checks[checkTime] = true
+		return nil
+	}
+	e.DB.DeleteFromIndex = func(index, key string) error {
+		if _, ok := db.indexes[index][key]; !ok {
+			return fmt.Errorf("no data with ID found")
+		}
+		delete(db.indexes[index], key)
+		return nil
+	}
+	return e
+}
+
+func testLicenses(now time.Time) map[string]dmtf.License {
+	license := func(id string, expiresIn time.Duration, gracePeriodDays int32) dmtf.License {
+		return dmtf.License{
+			ID:              id,
+			Name:            "iLO License",
+			LicenseType:     "Production",
+			ExpirationDate:  now.Add(expiresIn).Format(time.RFC3339),
+			GracePeriodDays: gracePeriodDays,
+			Links: &dmtf.LicenseLink{
+				AuthorizedDevices: []*dmtf.Link{{Oid: "/redfish/v1/Managers/uuid.1"}},
+			},
+		}
+	}
+	return map[string]dmtf.License{
+		licensesURI + "uuid.1.1": {ID: "1", Name: "iLO License", LicenseType: "Perpetual"},
+		licensesURI + "uuid.1.2": license("2", 10*day, 5),
+		licensesURI + "uuid.1.3": license("3", -2*day, 5),
+		licensesURI + "uuid.1.4": license("4", -10*day, 5),
+		licensesURI + "uuid.1.5": license("5", 100*day, 0),
+	}
+}
+
+func TestGetLicenseExpiry(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 12, 0, 0, 0, time.UTC)
+	licenses := testLicenses(now)
+	tests := []struct {
+		uri                string
+		expires            bool
+		state              string
+		remainingDays      int
+		remainingGraceDays int
+	}{
+		{"uuid.1.1", false, expiryStatePerpetual, 0, 0},
+		{"uuid.1.2", true, expiryStateExpiring, 10, 15},
+		{"uuid.1.3", true, expiryStateGracePeriod, 0, 3},
+		{"uuid.1.4", true, expiryStateExpired, 0, 0},
+		{"uuid.1.5", true, expiryStateActive, 100, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			expiry, expires := getLicenseExpiry(licenses[licensesURI+tt.uri], now)
+			assert.Equal(t, tt.expires, expires)
+			assert.Equal(t, tt.state, expiry.state)
+			assert.Equal(t, tt.remainingDays, expiry.remainingDays)
+			assert.Equal(t, tt.remainingGraceDays, expiry.remainingGraceDays)
+		})
+	}
+}
+
+func TestCheckLicenseExpiry(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 12, 0, 0, 0, time.UTC)
+	db := newMockLicenseDB(testLicenses(now))
+	var events []common.Event
+	e := db.externalInterface(&events)
+
+	e.checkLicenseExpiry(now)
+	assert.Len(t, db.indexes[licenseExpiryIndex], 4, "Licenses with an expiry should be indexed")
+	assert.Len(t, db.indexes[licenseGraceExpiryIndex], 4, "Licenses with an expiry should be indexed")
+	assert.Equal(t, now.Add(10*day).Unix(), db.indexes[licenseExpiryIndex][licensesURI+"uuid.1.2"])
+	assert.Equal(t, now.Add(15*day).Unix(), db.indexes[licenseGraceExpiryIndex][licensesURI+"uuid.1.2"])
+
+	sort.Slice(events, func(i, j int) bool { return events[i].OriginOfCondition.Oid < events[j].OriginOfCondition.Oid })
+	assert.Len(t, events, 3, "Events should be published for the expiring and expired licenses")
+	assert.Equal(t, "License.1.0.3.DaysBeforeExpiration", events[0].MessageID)
+	assert.Equal(t, "The license 'uuid.1.2' will expire in 10 days.", events[0].Message)
+	assert.Equal(t, common.Warning, events[0].Severity)
+	assert.Equal(t, "License.1.0.3.GracePeriod", events[1].MessageID)
+	assert.Equal(t, []string{"uuid.1.3", "3"}, events[1].MessageArgs)
+	assert.Equal(t, "License.1.0.3.Expired", events[2].MessageID)
+	assert.Equal(t, common.Critical, events[2].Severity)
+	assert.Equal(t, "Alert", events[2].EventType)
+
+	events = nil
+	e.checkLicenseExpiry(now.Add(time.Hour))
+	assert.Empty(t, events, "Events should not be published again for the same expiry state")
+
+	// another instance of the service shares the notified expiry states
+	other := db.externalInterface(&events)
+	other.checkLicenseExpiry(now.Add(2 * time.Hour))
+	assert.Empty(t, events, "Events should not be published again by another instance")
+
+	events = nil
+	delete(db.licenses, licensesURI+"uuid.1.2")
+	e.checkLicenseExpiry(now.Add(11 * day))
+	assert.NotContains(t, db.indexes[licenseExpiryIndex], licensesURI+"uuid.1.2", "Deleted license should be removed from the index")
+	assert.NotContains(t, db.notifications, licensesURI+"uuid.1.2", "Notification of the deleted license should be removed")
+	assert.Len(t, events, 1, "Event should be published when the license expires")
+	assert.Equal(t, "License.1.0.3.Expired", events[0].MessageID)
+	assert.Equal(t, licensesURI+"uuid.1.3", events[0].OriginOfCondition.Oid)
+}
+
+func TestGetLicenseReport(t *testing.T) {
+	db := newMockLicenseDB(testLicenses(time.Now()))
+	var events []common.Event
+	e := db.externalInterface(&events)
+	response := e.GetLicenseReport(&licenseproto.GetLicenseRequest{})
+
+	assert.Equal(t, http.StatusOK, int(response.StatusCode), "Status code should be StatusOK.")
+	report := response.Body.(model.LicenseReport)
+	assert.Equal(t, LicenseReportURI, report.OdataID)
+	assert.Equal(t, 5, report.MembersCount)
+	var states []string
+	for _, member := range report.Members {
+		states = append(states, member.ExpiryState)
+	}
+	assert.Equal(t, []string{expiryStatePerpetual, expiryStateExpiring, expiryStateGracePeriod, expiryStateExpired, expiryStateActive}, states)
+	assert.Nil(t, report.Members[0].RemainingDays, "Perpetual license should not have the remaining days")
+	assert.Equal(t, 3, *report.Members[2].RemainingGraceDays)
+	assert.Equal(t, "uuid.1.2", report.Members[1].ID)
+	assert.Equal(t, []string{"/redfish/v1/Managers/uuid.1"}, report.Members[1].AuthorizedDevices)
+}
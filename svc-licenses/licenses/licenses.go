@@ -15,9 +15,12 @@
 package licenses
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
@@ -36,6 +39,9 @@ var (
 	JsonMarshalFunc   = json.Marshal
 )
 
+// installLicenseWorkers is the number of managers the license is installed on at a time
+const installLicenseWorkers = 10
+
 // GetLicenseService to get license service details
 func (e *ExternalInterface) GetLicenseService(req *licenseproto.GetLicenseServiceRequest) response.RPC {
 	var resp response.RPC
@@ -49,6 +55,12 @@ func (e *ExternalInterface) GetLicenseService(req *licenseproto.GetLicenseServic
 		ServiceEnabled: true,
 	}
 	license.Licenses = &dmtf.Link{Oid: "/redfish/v1/LicenseService/Licenses"}
+	license.LicenseExpirationWarningDays = int32(expirationWarningDays())
+	license.Oem = map[string]interface{}{
+		"Odim": map[string]interface{}{
+			"LicenseReport": dmtf.Link{Oid: LicenseReportURI},
+		},
+	}
 
 	resp.Body = license
 	resp.StatusCode = http.StatusOK
@@ -116,32 +128,34 @@ func (e *ExternalInterface) GetLicenseResource(req *licenseproto.GetLicenseResou
 	return resp
 }
 
-// InstallLicenseService to install license
-func (e *ExternalInterface) InstallLicenseService(req *licenseproto.InstallLicenseRequest) response.RPC {
+// InstallLicenseService installs the license on the managers of the AuthorizedDevices in the request,
+// the license is installed on every manager in its own SubTask of the task
+func (e *ExternalInterface) InstallLicenseService(taskID, sessionUserName string, req *licenseproto.InstallLicenseRequest) response.RPC {
 	var resp response.RPC
-	var contactRequest model.PluginContactRequest
 	var installreq dmtf.LicenseInstallRequest
+	targetURI := "/redfish/v1/LicenseService/Licenses"
+	taskInfo := &common.TaskUpdateInfo{TaskID: taskID, TargetURI: targetURI, UpdateTask: e.External.UpdateTask, TaskRequest: string(req.RequestBody)}
 
 	genErr := JsonUnMarshalFunc(req.RequestBody, &installreq)
 	if genErr != nil {
 		errMsg := "Unable to unmarshal the install license request" + genErr.Error()
 		log.Error(errMsg)
-		return common.GeneralError(http.StatusBadRequest, response.InternalError, errMsg, nil, nil)
+		return common.GeneralError(http.StatusBadRequest, response.InternalError, errMsg, nil, taskInfo)
 	}
 
 	if installreq.Links == nil {
 		errMsg := "Invalid request,mandatory field Links missing"
 		log.Error(errMsg)
-		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"Links"}, nil)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"Links"}, taskInfo)
 	} else if installreq.LicenseString == "" {
 		errMsg := "Invalid request, mandatory field LicenseString is missing"
 		log.Error(errMsg)
-		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"LicenseString"}, nil)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"LicenseString"}, taskInfo)
 
 	} else if len(installreq.Links.Link) == 0 {
 		errMsg := "Invalid request, mandatory field AuthorizedDevices links is missing"
 		log.Error(errMsg)
-	return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"LicenseString"}, nil)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"AuthorizedDevices"}, taskInfo)
 
 	}
 	var serverURI string
@@ -156,7 +170,7 @@ func (e *ExternalInterface) InstallLicenseService(req *licenseproto.InstallLicen
 			if err != nil {
 				errMsg := "Unable to get manager link"
 				log.Error(errMsg)
-				return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+				return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
 			}
 			for _, link := range managerLink {
 				linksMap[link] = true
@@ -168,7 +182,7 @@ func (e *ExternalInterface) InstallLicenseService(req *licenseproto.InstallLicen
 			if err != nil {
 				errMsg := "Unable to get manager link from aggregates"
 				log.Error(errMsg)
-				return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+				return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
 			}
 			for _, link := range managerLink {
 				linksMap[link] = true
@@ -176,88 +190,272 @@ func (e *ExternalInterface) InstallLicenseService(req *licenseproto.InstallLicen
 		default:
 			errMsg := "Invalid AuthorizedDevices links"
 			log.Error(errMsg)
-			return common.GeneralError(http.StatusBadRequest, response.InternalError, errMsg, nil, nil)
+			return common.GeneralError(http.StatusBadRequest, response.InternalError, errMsg, nil, taskInfo)
 		}
 	}
 	log.Info("Map with manager Links: ", linksMap)
 
+	managerURIs := make([]string, 0, len(linksMap))
 	for serverURI := range linksMap {
-		uuid, managerID, err := lcommon.GetIDsFromURI(serverURI)
-		if err != nil {
-			errMsg := "error while trying to get system ID from " + serverURI + ": " + err.Error()
-			log.Error(errMsg)
-			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"SystemID", serverURI}, nil)
-		}
-		// Get target device Credentials from using device UUID
-		target, targetErr := e.External.GetTarget(uuid)
-		if targetErr != nil {
-			errMsg := targetErr.Error()
-			log.Error(errMsg)
-			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"target", uuid}, nil)
+		managerURIs = append(managerURIs, serverURI)
+	}
+	sort.Strings(managerURIs)
+
+	// the installs in progress and the ones yet to start are stopped when the task is cancelled
+	ctx, release := common.WithTaskCancel(context.Background(), taskID)
+	managerChannel := make(chan string)
+	subTaskChannel := make(chan int32, len(managerURIs))
+	for i := 0; i < installLicenseWorkers && i < len(managerURIs); i++ {
+		go func() {
+			for serverURI := range managerChannel {
+				e.installLicense(ctx, taskID, sessionUserName, serverURI, installreq.LicenseString, subTaskChannel)
+			}
+		}()
+	}
+	go func() {
+		defer close(managerChannel)
+		for _, serverURI := range managerURIs {
+			managerChannel <- serverURI
 		}
+	}()
 
-		decryptedPasswordByte, err := e.External.DevicePassword(target.Password)
-		if err != nil {
-			errMsg := "error while trying to decrypt device password: " + err.Error()
-			log.Error(errMsg)
-			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	var failed int
+	var percentComplete int32
+	resp.StatusCode = http.StatusOK
+	for completed := 1; completed <= len(managerURIs); completed++ {
+		statusCode := <-subTaskChannel
+		if statusCode != http.StatusOK {
+			failed++
+			if resp.StatusCode < statusCode {
+				resp.StatusCode = statusCode
+			}
+		}
+		if completed < len(managerURIs) && ctx.Err() == nil {
+			percentComplete = int32((completed * 100) / len(managerURIs))
+			// UpdateTask cancels the context of the task when it is cancelled
+			task := fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Running, common.OK, percentComplete, http.MethodPost)
+			e.External.UpdateTask(task)
 		}
-		target.Password = decryptedPasswordByte
+	}
 
-		// Get the Plugin info
-		plugin, errs := e.External.GetPluginData(target.PluginID)
-		if errs != nil {
-			errMsg := "error while getting plugin data: " + errs.Error()
-			log.Error(errMsg)
-			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"PluginData", target.PluginID}, nil)
+	// a cancel which arrives from here on is handled by UpdateTask, as for the tasks without a context
+	cancelled := ctx.Err() != nil
+	release()
+	if cancelled {
+		log.Info("Installing the license is cancelled. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/" + taskID)
+		task := fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Cancelled, common.Warning, percentComplete, http.MethodPost)
+		e.External.UpdateTask(task)
+		return resp
+	}
+
+	if failed > 0 {
+		errMsg := fmt.Sprintf("Installing the license failed on %d of %d managers. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/%s",
+			failed, len(managerURIs), taskID)
+		log.Warn(errMsg)
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return common.GeneralError(http.StatusUnauthorized, response.ResourceAtURIUnauthorized, errMsg, []interface{}{targetURI}, taskInfo)
+		case http.StatusNotFound:
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"Manager", fmt.Sprintf("%v", managerURIs)}, taskInfo)
+		default:
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
 		}
-		log.Info("Plugin info: ", plugin)
+	}
 
-		encodedKey := base64.StdEncoding.EncodeToString([]byte(installreq.LicenseString))
-		managerURI := "/redfish/v1/Managers/" + managerID
-		reqPostBody := map[string]interface{}{"LicenseString": encodedKey, "AuthorizedDevices": managerURI}
-		reqBody, _ := json.Marshal(reqPostBody)
+	log.Info("License is installed on all the managers. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/" + taskID)
+	resp.StatusMessage = response.Success
+	args := response.Args{
+		Code:    resp.StatusMessage,
+		Message: "Request completed successfully",
+	}
+	resp.Body = args.CreateGenericErrorResponse()
+	task := fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Completed, common.OK, 100, http.MethodPost)
+	e.External.UpdateTask(task)
+	return resp
+}
 
-		contactRequest.Plugin = *plugin
-		contactRequest.ContactClient = e.External.ContactClient
-		contactRequest.Plugin.ID = target.PluginID
-		contactRequest.HTTPMethodType = http.MethodPost
+// installLicense installs the license on the manager in a SubTask of the task, and sends the
+// status code of the installation on the channel once the SubTask is updated.
+// The manager is skipped when the task is already cancelled, and the call to the plugin
+// is abandoned when the task or the SubTask is cancelled while it is in progress.
+func (e *ExternalInterface) installLicense(ctx context.Context, taskID, sessionUserName, serverURI, licenseString string, subTaskChannel chan<- int32) {
+	var resp response.RPC
+	resp.StatusCode = http.StatusInternalServerError
+	defer func() {
+		subTaskChannel <- resp.StatusCode
+	}()
+	if ctx.Err() != nil {
+		log.Info("Installing the license on " + serverURI + " is skipped as the task is cancelled")
+		return
+	}
+	subTaskURI, err := e.External.CreateChildTask(sessionUserName, taskID)
+	if err != nil {
+		log.Error("Unable to create sub task for " + serverURI + ": " + err.Error())
+		return
+	}
+	strArray := strings.Split(strings.TrimSuffix(subTaskURI, "/"), "/")
+	subTaskID := strArray[len(strArray)-1]
+	// a cancelled SubTask only stops the install on its manager, while its context is
+	// registered UpdateTask returns the Cancelling error instead of exiting the worker
+	ctx, release := common.WithTaskCancel(ctx, subTaskID)
+	defer release()
 
-		if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
-			contactRequest.DeviceInfo = map[string]interface{}{
-				"UserName": plugin.Username,
-				"Password": string(plugin.Password),
-			}
-			contactRequest.OID = "/ODIM/v1/Sessions"
-			_, token, getResponse, err := lcommon.ContactPlugin(contactRequest, "error while logging in to plugin: ")
-			if err != nil {
-				errMsg := err.Error()
-				log.Error(errMsg)
-				return common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, errMsg, getResponse.MsgArgs, nil)
-			}
-			contactRequest.Token = token
-		} else {
-			contactRequest.LoginCredentials = map[string]string{
-				"UserName": plugin.Username,
-				"Password": string(plugin.Password),
-			}
+	uuid, managerID, err := lcommon.GetIDsFromURI(serverURI)
+	encodedKey := base64.StdEncoding.EncodeToString([]byte(licenseString))
+	managerURI := "/redfish/v1/Managers/" + managerID
+	reqPostBody := map[string]interface{}{"LicenseString": encodedKey, "AuthorizedDevices": managerURI}
+	reqBody, _ := json.Marshal(reqPostBody)
+	taskInfo := &common.TaskUpdateInfo{TaskID: subTaskID, TargetURI: serverURI, UpdateTask: e.External.UpdateTask, TaskRequest: string(reqBody)}
+	if err != nil {
+		errMsg := "error while trying to get system ID from " + serverURI + ": " + err.Error()
+		log.Error(errMsg)
+		resp = common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"SystemID", serverURI}, taskInfo)
+		return
+	}
 
+	contactRequest, target, errResp := e.getPluginContactRequest(uuid)
+	if errResp != nil {
+		resp = *errResp
+		e.External.UpdateTask(fillTaskData(subTaskID, serverURI, string(reqBody), resp, common.Exception, common.Critical, 100, http.MethodPost))
+		return
+	}
+	contactRequest.HTTPMethodType = http.MethodPost
+	target.PostBody = []byte(reqBody)
+	contactRequest.DeviceInfo = target
+	contactRequest.OID = "/ODIM/v1/LicenseService/Licenses"
+	contactRequest.PostBody = reqBody
+	var getResponse model.ResponseStatus
+	if common.CallWithTaskCancel(ctx, func() {
+		_, _, getResponse, err = e.External.ContactPlugin(contactRequest, "error while installing license: ")
+	}) != nil {
+		log.Info("Installing the license on " + serverURI + " is cancelled")
+		task := fillTaskData(subTaskID, serverURI, string(reqBody), resp, common.Cancelled, common.Warning, 0, http.MethodPost)
+		e.External.UpdateTask(task)
+		return
+	}
+	if err != nil {
+		errMsg := err.Error()
+		log.Error(errMsg)
+		resp = common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, errMsg, getResponse.MsgArgs, taskInfo)
+		return
+	}
+	log.Info("Install license response of "+serverURI+": ", getResponse)
+
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	args := response.Args{
+		Code:    resp.StatusMessage,
+		Message: "Request completed successfully",
+	}
+	resp.Body = args.CreateGenericErrorResponse()
+	task := fillTaskData(subTaskID, serverURI, string(reqBody), resp, common.Completed, common.OK, 100, http.MethodPost)
+	// the license installed on the manager cannot be reverted, the SubTask is only marked cancelled
+	if err := e.External.UpdateTask(task); err != nil && err.Error() == common.Cancelling {
+		task.TaskState = common.Cancelled
+		e.External.UpdateTask(task)
+	}
+}
+
+// DeleteLicense removes the license from the manager it is installed on, and from the license collection
+func (e *ExternalInterface) DeleteLicense(req *licenseproto.GetLicenseResourceRequest) response.RPC {
+	var resp response.RPC
+	uri := strings.TrimSuffix(req.URL, "/")
+	if _, dbErr := e.DB.GetResource("Licenses", uri, persistencemgr.InMemory); dbErr != nil {
+		errMsg := "Unable to get license data : " + dbErr.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"License", uri}, nil)
+	}
+	uuid, licenseID, err := lcommon.GetIDsFromURI(uri)
+	if err != nil {
+		errMsg := "error while trying to get license ID from " + uri + ": " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"License", uri}, nil)
+	}
+
+	contactRequest, target, errResp := e.getPluginContactRequest(uuid)
+	if errResp != nil {
+		return *errResp
+	}
+	contactRequest.HTTPMethodType = http.MethodDelete
+	contactRequest.DeviceInfo = target
+	contactRequest.OID = "/ODIM/v1/LicenseService/Licenses/" + licenseID
+	_, _, getResponse, err := e.External.ContactPlugin(contactRequest, "error while deleting license: ")
+	if err != nil {
+		errMsg := err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, errMsg, getResponse.MsgArgs, nil)
+	}
+
+	if dbErr := e.DB.DeleteResource("Licenses", uri, persistencemgr.InMemory); dbErr != nil {
+		errMsg := "error while trying to delete license data: " + dbErr.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	e.removeFromExpiryIndexes(uri)
+	log.Info("License " + uri + " is deleted")
+
+	resp.StatusCode = http.StatusNoContent
+	return resp
+}
+
+// getPluginContactRequest prepares the request to the plugin of the device with the decrypted
+// credentials of the device, the plugin is logged in to when it prefers the session authentication
+func (e *ExternalInterface) getPluginContactRequest(uuid string) (model.PluginContactRequest, *model.Target, *response.RPC) {
+	var contactRequest model.PluginContactRequest
+	// Get target device Credentials from using device UUID
+	target, targetErr := e.External.GetTarget(uuid)
+	if targetErr != nil {
+		errMsg := targetErr.Error()
+		log.Error(errMsg)
+		resp := common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"target", uuid}, nil)
+		return contactRequest, nil, &resp
+	}
+
+	decryptedPasswordByte, err := e.External.DevicePassword(target.Password)
+	if err != nil {
+		errMsg := "error while trying to decrypt device password: " + err.Error()
+		log.Error(errMsg)
+		resp := common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+		return contactRequest, nil, &resp
+	}
+	target.Password = decryptedPasswordByte
+
+	// Get the Plugin info
+	plugin, errs := e.External.GetPluginData(target.PluginID)
+	if errs != nil {
+		errMsg := "error while getting plugin data: " + errs.Error()
+		log.Error(errMsg)
+		resp := common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"PluginData", target.PluginID}, nil)
+		return contactRequest, nil, &resp
+	}
+	log.Info("Plugin info: ", plugin)
+
+	contactRequest.Plugin = *plugin
+	contactRequest.ContactClient = e.External.ContactClient
+	contactRequest.Plugin.ID = target.PluginID
+
+	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		contactRequest.HTTPMethodType = http.MethodPost
+		contactRequest.DeviceInfo = map[string]interface{}{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
 		}
-		target.PostBody = []byte(reqBody)
-		contactRequest.DeviceInfo = target
-		contactRequest.OID = "/ODIM/v1/LicenseService/Licenses"
-		contactRequest.PostBody = reqBody
-		_, _, getResponse, err := e.External.ContactPlugin(contactRequest, "error while installing license: ")
+		contactRequest.OID = "/ODIM/v1/Sessions"
+		_, token, getResponse, err := e.External.ContactPlugin(contactRequest, "error while logging in to plugin: ")
 		if err != nil {
 			errMsg := err.Error()
 			log.Error(errMsg)
-			return common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, errMsg, getResponse.MsgArgs, nil)
+			resp := common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, errMsg, getResponse.MsgArgs, nil)
+			return contactRequest, nil, &resp
+		}
+		contactRequest.Token = token
+	} else {
+		contactRequest.BasicAuth = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
 		}
-		log.Info("Install license response: ", getResponse)
 	}
-
-	resp.StatusCode = http.StatusNoContent
-	return resp
+	return contactRequest, target, nil
 }
 
 func (e *ExternalInterface) getDetailsFromAggregate(aggregateURI string) ([]string, error) {
@@ -17,22 +17,36 @@ package licenses
 import (
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-persistence-manager/persistencemgr"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	licenseproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/licenses"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-licenses/model"
 	"github.com/stretchr/testify/assert"
 )
 
 var licenseServiceResponse = dmtf.LicenseService{
-	OdataContext:   "/redfish/v1/$metadata#LicenseService.LicenseService",
-	OdataID:        "/redfish/v1/LicenseService",
-	OdataType:      "#LicenseService.v1_0_0.LicenseService",
-	Description:    "License Service",
-	Name:           "License Service",
-	ServiceEnabled: true,
-	ID:             "LicenseService",
-	Licenses:       &dmtf.Link{Oid: "/redfish/v1/LicenseService/Licenses"},
+	OdataContext:                 "/redfish/v1/$metadata#LicenseService.LicenseService",
+	OdataID:                      "/redfish/v1/LicenseService",
+	OdataType:                    "#LicenseService.v1_0_0.LicenseService",
+	Description:                  "License Service",
+	Name:                         "License Service",
+	ServiceEnabled:               true,
+	ID:                           "LicenseService",
+	Licenses:                     &dmtf.Link{Oid: "/redfish/v1/LicenseService/Licenses"},
+	LicenseExpirationWarningDays: 30,
+	Oem: map[string]interface{}{
+		"Odim": map[string]interface{}{
+			"LicenseReport": dmtf.Link{Oid: "/redfish/v1/LicenseService/Oem/Odim/LicenseReport"},
+		},
+	},
 }
 
 var licenseCollectionResponse = dmtf.LicenseCollection{
@@ -104,15 +118,15 @@ func TestInstallLicenseService(t *testing.T) {
 			}
 		}`)}
 	e := mockGetExternalInterface()
-	response := e.InstallLicenseService(req)
+	response := e.InstallLicenseService("task12345", "admin", req)
 
-	assert.Equal(t, http.StatusNoContent, int(response.StatusCode), "Status code should be StatusNoContent.")
+	assert.Equal(t, http.StatusOK, int(response.StatusCode), "Status code should be StatusOK.")
 }
 
 func TestInstallLicenseService_InvalidRequest(t *testing.T) {
 	req := &licenseproto.InstallLicenseRequest{}
 	e := mockGetExternalInterface()
-	response := e.InstallLicenseService(req)
+	response := e.InstallLicenseService("task12345", "admin", req)
 
 	assert.Equal(t, http.StatusBadRequest, int(response.StatusCode), "Status code should be StatusBadRequest.")
 }
@@ -123,7 +137,7 @@ func TestInstallLicenseService_EmptyLinks(t *testing.T) {
 			"LicenseString": "XXX-XXX-XXX-XXX-XXX"
 		}`)}
 	e := mockGetExternalInterface()
-	response := e.InstallLicenseService(req)
+	response := e.InstallLicenseService("task12345", "admin", req)
 
 	assert.Equal(t, http.StatusBadRequest, int(response.StatusCode), "Status code should be StatusBadRequest.")
 }
@@ -139,7 +153,7 @@ func TestInstallLicenseService_InvalidManager(t *testing.T) {
 			}
 		}`)}
 	e := mockGetExternalInterface()
-	response := e.InstallLicenseService(req)
+	response := e.InstallLicenseService("task12345", "admin", req)
 
 	assert.Equal(t, http.StatusInternalServerError, int(response.StatusCode), "Status code should be StatusInternalServerError.")
 }
@@ -155,7 +169,7 @@ func TestInstallLicenseService_InvalidAuthorizedDevices(t *testing.T) {
 			}
 		}`)}
 	e := mockGetExternalInterface()
-	response := e.InstallLicenseService(req)
+	response := e.InstallLicenseService("task12345", "admin", req)
 
 	assert.Equal(t, http.StatusBadRequest, int(response.StatusCode), "Status code should be StatusBadRequest.")
 }
@@ -171,9 +185,9 @@ func TestInstallLicenseService_ManagerURL(t *testing.T) {
 			}
 		}`)}
 	e := mockGetExternalInterface()
-	response := e.InstallLicenseService(req)
+	response := e.InstallLicenseService("task12345", "admin", req)
 
-	assert.Equal(t, http.StatusNoContent, int(response.StatusCode), "Status code should be StatusNoContent.")
+	assert.Equal(t, http.StatusOK, int(response.StatusCode), "Status code should be StatusOK.")
 }
 
 func TestInstallLicenseService_Agrregates(t *testing.T) {
@@ -190,9 +204,9 @@ func TestInstallLicenseService_Agrregates(t *testing.T) {
 			}
 		}`)}
 	e := mockGetExternalInterface()
-	response := e.InstallLicenseService(req)
+	response := e.InstallLicenseService("task12345", "admin", req)
 
-	assert.Equal(t, http.StatusNoContent, int(response.StatusCode), "Status code should be StatusNoContent.")
+	assert.Equal(t, http.StatusOK, int(response.StatusCode), "Status code should be StatusOK.")
 }
 
 func TestInstallLicenseService_Agrregates_InvalidURI(t *testing.T) {
@@ -207,7 +221,168 @@ func TestInstallLicenseService_Agrregates_InvalidURI(t *testing.T) {
 			}
 		}`)}
 	e := mockGetExternalInterface()
-	response := e.InstallLicenseService(req)
+	response := e.InstallLicenseService("task12345", "admin", req)
+
+	assert.Equal(t, http.StatusInternalServerError, int(response.StatusCode), "Status code should be StatusInternalServerError.")
+}
+
+func TestInstallLicenseService_SubTasks(t *testing.T) {
+	req := &licenseproto.InstallLicenseRequest{
+		RequestBody: []byte(`{
+			"LicenseString": "XXX-XXX-XXX-XXX-XXX",
+			"Links": {
+				"AuthorizedDevices": [{
+					"@odata.id": "/redfish/v1/AggregationService/Aggregates/uuid"
+					},{
+						"@odata.id": "/redfish/v1/AggregationService/Aggregates/uuid2"
+					}
+					]
+			}
+		}`)}
+	e := mockGetExternalInterface()
+	var lock sync.Mutex
+	subTasks := make(map[string]string)
+	tasks := make(map[string]common.TaskData)
+	e.External.CreateChildTask = func(sessionUserName, taskID string) (string, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		subTaskID := fmt.Sprintf("subtask%d", len(subTasks)+1)
+		subTasks[subTaskID] = taskID
+		return "/redfish/v1/TaskService/Tasks/" + subTaskID, nil
+	}
+	e.External.UpdateTask = func(task common.TaskData) error {
+		lock.Lock()
+		defer lock.Unlock()
+		tasks[task.TaskID] = task
+		return nil
+	}
+	e.External.ContactPlugin = func(req model.PluginContactRequest, errorMessage string) ([]byte, string, model.ResponseStatus, error) {
+		if strings.Contains(string(req.PostBody.([]byte)), "/redfish/v1/Managers/2") {
+			return nil, "", model.ResponseStatus{StatusCode: http.StatusInternalServerError, StatusMessage: response.InternalError}, fmt.Errorf("license is not valid for the manager")
+		}
+		return nil, "", model.ResponseStatus{StatusCode: http.StatusCreated}, nil
+	}
+	resp := e.InstallLicenseService("task12345", "admin", req)
+
+	assert.Equal(t, http.StatusInternalServerError, int(resp.StatusCode), "Status code should be StatusInternalServerError.")
+	assert.Len(t, subTasks, 2, "SubTask should be created for every manager")
+	states := make(map[string]string)
+	for subTaskID, taskID := range subTasks {
+		assert.Equal(t, "task12345", taskID, "SubTask should be created for the task")
+		states[tasks[subTaskID].TargetURI] = tasks[subTaskID].TaskState
+	}
+	assert.Equal(t, map[string]string{"/redfish/v1/Managers/uuid.1": common.Completed, "/redfish/v1/Managers/uuid.2": common.Exception}, states)
+	assert.Equal(t, common.Exception, tasks["task12345"].TaskState, "Task should fail when the license is not installed on a manager")
+	assert.Equal(t, int32(100), tasks["task12345"].PercentComplete)
+}
+
+func TestDeleteLicense(t *testing.T) {
+	req := &licenseproto.GetLicenseResourceRequest{
+		URL: "/redfish/v1/LicenseService/Licenses/uuid.1.1",
+	}
+	e := mockGetExternalInterface()
+	var pluginRequest model.PluginContactRequest
+	e.External.ContactPlugin = func(req model.PluginContactRequest, errorMessage string) ([]byte, string, model.ResponseStatus, error) {
+		pluginRequest = req
+		return nil, "", model.ResponseStatus{StatusCode: http.StatusNoContent}, nil
+	}
+	var deletedKey string
+	e.DB.DeleteResource = func(table, key string, dbtype persistencemgr.DbType) *errors.Error {
+		deletedKey = key
+		return nil
+	}
+	response := e.DeleteLicense(req)
+
+	assert.Equal(t, http.StatusNoContent, int(response.StatusCode), "Status code should be StatusNoContent.")
+	assert.Equal(t, http.MethodDelete, pluginRequest.HTTPMethodType, "License should be deleted on the plugin.")
+	assert.Equal(t, "/ODIM/v1/LicenseService/Licenses/1.1", pluginRequest.OID, "License ID of the device should be sent to the plugin.")
+	assert.Equal(t, "/redfish/v1/LicenseService/Licenses/uuid.1.1", deletedKey, "License should be deleted from the DB.")
+}
+
+func TestDeleteLicense_NotFound(t *testing.T) {
+	req := &licenseproto.GetLicenseResourceRequest{
+		URL: "/redfish/v1/LicenseService/Licenses",
+	}
+	e := mockGetExternalInterface()
+	response := e.DeleteLicense(req)
+
+	assert.Equal(t, http.StatusNotFound, int(response.StatusCode), "Status code should be StatusNotFound.")
+}
+
+func TestDeleteLicense_PluginError(t *testing.T) {
+	req := &licenseproto.GetLicenseResourceRequest{
+		URL: "/redfish/v1/LicenseService/Licenses/uuid.1.1",
+	}
+	e := mockGetExternalInterface()
+	e.External.ContactPlugin = func(req model.PluginContactRequest, errorMessage string) ([]byte, string, model.ResponseStatus, error) {
+		return nil, "", model.ResponseStatus{StatusCode: http.StatusBadRequest, StatusMessage: response.GeneralError}, fmt.Errorf("license is not removable")
+	}
+	e.DB.DeleteResource = func(table, key string, dbtype persistencemgr.DbType) *errors.Error {
+		t.Errorf("License should not be deleted from the DB when the plugin fails to delete it.")
+		return nil
+	}
+	response := e.DeleteLicense(req)
+
+	assert.Equal(t, http.StatusBadRequest, int(response.StatusCode), "Status code should be StatusBadRequest.")
+}
+
+func TestInstallLicenseServiceCancelled(t *testing.T) {
+	var links []string
+	for i := 0; i < 3*installLicenseWorkers; i++ {
+		links = append(links, fmt.Sprintf(`{"@odata.id": "/redfish/v1/Managers/uuid%d.1"}`, i))
+	}
+	req := &licenseproto.InstallLicenseRequest{
+		RequestBody: []byte(`{
+			"LicenseString": "XXX-XXX-XXX-XXX-XXX",
+			"Links": {
+				"AuthorizedDevices": [` + strings.Join(links, ",") + `]
+			}
+		}`)}
+	var mutex sync.Mutex
+	var calls int
+	var cancelledTasks []string
+	block := make(chan struct{})
+	defer close(block)
+	e := mockGetExternalInterface()
+	e.External.ContactPlugin = func(req model.PluginContactRequest, errorMessage string) ([]byte, string, model.ResponseStatus, error) {
+		mutex.Lock()
+		calls++
+		mutex.Unlock()
+		<-block
+		return mockContactPlugin(req, errorMessage)
+	}
+	e.External.UpdateTask = func(task common.TaskData) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if task.TaskState == common.Cancelled {
+			cancelledTasks = append(cancelledTasks, task.TaskID)
+		}
+		return nil
+	}
+	done := make(chan response.RPC)
+	go func() {
+		done <- e.InstallLicenseService("cancelledTask", "admin", req)
+	}()
+	// cancelling once all the workers are waiting on the plugin
+	for {
+		mutex.Lock()
+		inFlight := calls
+		mutex.Unlock()
+		if inFlight == installLicenseWorkers {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.True(t, common.CancelTaskContext("cancelledTask"), "task context should be registered")
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("InstallLicenseService did not stop on cancel")
+	}
 
-	assert.Equal(t, http.StatusInternalServerError, int(response.StatusCode), "Status code should be StatusNoContent.")
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, installLicenseWorkers, calls, "only the installs started before the cancel should reach the plugin")
+	assert.Equal(t, installLicenseWorkers+1, len(cancelledTasks), "the installs in progress and the task should be cancelled")
+	assert.Equal(t, "cancelledTask", cancelledTasks[len(cancelledTasks)-1], "the task should be cancelled last")
 }
@@ -0,0 +1,95 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package licenses
+
+import (
+	"net/http"
+	"path"
+	"sort"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	licenseproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/licenses"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-licenses/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// LicenseReportURI is the URI of the report of the licenses of all the managers
+const LicenseReportURI = "/redfish/v1/LicenseService/Oem/Odim/LicenseReport"
+
+// GetLicenseReport returns the licenses of all the managers with their expiry.
+// The members of the report are the license entries, so that the API service
+// applies $filter and $select on them as on the members of any collection.
+func (e *ExternalInterface) GetLicenseReport(req *licenseproto.GetLicenseRequest) response.RPC {
+	var resp response.RPC
+	licenses, err := e.getLicenses()
+	if err != nil {
+		errMsg := "error while getting the licenses for the license report: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	uris := make([]string, 0, len(licenses))
+	for uri := range licenses {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	now := time.Now()
+	members := make([]model.LicenseReportEntry, 0, len(uris))
+	for _, uri := range uris {
+		members = append(members, licenseReportEntry(uri, licenses[uri], now))
+	}
+	resp.Body = model.LicenseReport{
+		OdataContext: "/redfish/v1/$metadata#OdimLicenseReport.OdimLicenseReport",
+		OdataID:      LicenseReportURI,
+		OdataType:    "#OdimLicenseReport.v1_0_0.OdimLicenseReport",
+		ID:           "LicenseReport",
+		Name:         "License Report",
+		Description:  "Expiry of the licenses of all the managers",
+		Members:      members,
+		MembersCount: len(members),
+	}
+	resp.StatusCode = http.StatusOK
+	return resp
+}
+
+// licenseReportEntry returns the entry of the license in the license report at the time now
+func licenseReportEntry(uri string, license dmtf.License, now time.Time) model.LicenseReportEntry {
+	entry := model.LicenseReportEntry{
+		OdataID:           uri,
+		ID:                path.Base(uri),
+		Name:              license.Name,
+		LicenseType:       license.LicenseType,
+		AuthorizedDevices: []string{},
+		ExpirationDate:    license.ExpirationDate,
+		GracePeriodDays:   license.GracePeriodDays,
+	}
+	if license.Links != nil {
+		for _, device := range license.Links.AuthorizedDevices {
+			entry.AuthorizedDevices = append(entry.AuthorizedDevices, device.Oid)
+		}
+	}
+	expiry, ok := getLicenseExpiry(license, now)
+	entry.ExpiryState = expiry.state
+	if ok {
+		entry.GracePeriodEndDate = expiry.graceEndDate.Format(time.RFC3339)
+		entry.RemainingDays = &expiry.remainingDays
+		entry.RemainingGraceDays = &expiry.remainingGraceDays
+	}
+	return entry
+}
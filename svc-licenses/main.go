@@ -21,6 +21,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	licenseproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/licenses"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
+	"github.com/ODIM-Project/ODIM/svc-licenses/licenses"
 	"github.com/ODIM-Project/ODIM/svc-licenses/rpc"
 
 	"github.com/sirupsen/logrus"
@@ -52,6 +53,9 @@ func main() {
 
 	registerHandlers()
 
+	// check the expiry of the licenses and notify the subscribers of the licenses about to expire
+	go licenses.GetExternalInterface().TrackLicenseExpiry()
+
 	if err := services.ODIMService.Run(); err != nil {
 		log.Error(err)
 	}
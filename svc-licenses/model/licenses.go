@@ -66,3 +66,31 @@ type Elements struct {
 type OdataIDLinks struct {
 	OdataID string `json:"@odata.id,omitempty"`
 }
+
+// LicenseReport is the report of the licenses of all the managers with their expiry
+type LicenseReport struct {
+	OdataContext string               `json:"@odata.context"`
+	OdataID      string               `json:"@odata.id"`
+	OdataType    string               `json:"@odata.type"`
+	ID           string               `json:"Id"`
+	Name         string               `json:"Name"`
+	Description  string               `json:"Description"`
+	Members      []LicenseReportEntry `json:"Members"`
+	MembersCount int                  `json:"Members@odata.count"`
+}
+
+// LicenseReportEntry is the entry of a license in the license report, the remaining
+// days and the end of the grace period are not present for the perpetual licenses
+type LicenseReportEntry struct {
+	OdataID            string   `json:"@odata.id"`
+	ID                 string   `json:"Id"`
+	Name               string   `json:"Name"`
+	LicenseType        string   `json:"LicenseType,omitempty"`
+	AuthorizedDevices  []string `json:"AuthorizedDevices"`
+	ExpirationDate     string   `json:"ExpirationDate,omitempty"`
+	GracePeriodDays    int32    `json:"GracePeriodDays"`
+	GracePeriodEndDate string   `json:"GracePeriodEndDate,omitempty"`
+	RemainingDays      *int     `json:"RemainingDays,omitempty"`
+	RemainingGraceDays *int     `json:"RemainingGraceDays,omitempty"`
+	ExpiryState        string   `json:"ExpiryState"`
+}
@@ -17,9 +17,13 @@ package rpc
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	licenseproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/licenses"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+
+	log "github.com/sirupsen/logrus"
 )
 
 // GetLicenseService to get license service details
@@ -66,6 +70,67 @@ func (l *Licenses) InstallLicenseService(ctx context.Context, req *licenseproto.
 		fillProtoResponse(resp, authResp)
 		return resp, nil
 	}
-	fillProtoResponse(resp, l.connector.InstallLicenseService(req))
+	sessionUserName, err := l.connector.External.GetSessionUserName(req.SessionToken)
+	if err != nil {
+		errMsg := "error while trying to get the session username: " + err.Error()
+		generateRPCResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		log.Error(errMsg)
+		return resp, nil
+	}
+	taskURI, err := l.connector.External.CreateTask(sessionUserName)
+	if err != nil {
+		errMsg := "error while trying to create task: " + err.Error()
+		generateRPCResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		log.Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(strings.TrimSuffix(taskURI, "/"), "/")
+	taskID := strArray[len(strArray)-1]
+	err = l.connector.External.UpdateTask(common.TaskData{
+		TaskID:          taskID,
+		TargetURI:       taskURI,
+		TaskState:       common.Running,
+		TaskStatus:      common.OK,
+		PercentComplete: 0,
+		HTTPMethod:      http.MethodPost,
+	})
+	if err != nil {
+		log.Warn("error while contacting task-service with UpdateTask RPC : " + err.Error())
+	}
+	go l.connector.InstallLicenseService(taskID, sessionUserName, req)
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateRPCResponse(rpcResp, resp)
+	return resp, nil
+}
+
+// DeleteLicense to delete a license from the manager it is installed on
+func (l *Licenses) DeleteLicense(ctx context.Context, req *licenseproto.GetLicenseResourceRequest) (*licenseproto.GetLicenseResponse, error) {
+	resp := &licenseproto.GetLicenseResponse{}
+	authResp := l.connector.External.Auth(req.SessionToken, []string{common.PrivilegeConfigureComponents}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		fillProtoResponse(resp, authResp)
+		return resp, nil
+	}
+	fillProtoResponse(resp, l.connector.DeleteLicense(req))
+	return resp, nil
+}
+
+// GetLicenseReport to get the licenses of all the managers with their expiry
+func (l *Licenses) GetLicenseReport(ctx context.Context, req *licenseproto.GetLicenseRequest) (*licenseproto.GetLicenseResponse, error) {
+	resp := &licenseproto.GetLicenseResponse{}
+	authResp := l.connector.External.Auth(req.SessionToken, []string{common.PrivilegeLogin}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		fillProtoResponse(resp, authResp)
+		return resp, nil
+	}
+	fillProtoResponse(resp, l.connector.GetLicenseReport(req))
 	return resp, nil
 }
@@ -34,17 +34,24 @@ import (
 func mockGetExternalInterface() *licenseService.ExternalInterface {
 	return &licenseService.ExternalInterface{
 		External: licenseService.External{
-			Auth:           mockIsAuthorized,
-			ContactClient:  mockContactClient,
-			GetTarget:      mockGetTarget,
-			GetPluginData:  mockGetPluginData,
-			ContactPlugin:  mockContactPlugin,
-			DevicePassword: stubDevicePassword,
-			GenericSave:    stubGenericSave,
+			Auth:               mockIsAuthorized,
+			ContactClient:      mockContactClient,
+			GetTarget:          mockGetTarget,
+			GetPluginData:      mockGetPluginData,
+			ContactPlugin:      mockContactPlugin,
+			DevicePassword:     stubDevicePassword,
+			GenericSave:        stubGenericSave,
+			PublishEvent:       func(common.Event) {},
+			GetSessionUserName: mockGetSessionUserName,
+			CreateTask:         mockCreateTask,
+			CreateChildTask:    mockCreateChildTask,
+			UpdateTask:         mockUpdateTask,
 		},
 		DB: licenseService.DB{
 			GetAllKeysFromTable: mockGetAllKeysFromTable,
 			GetResource:         mockGetResource,
+			DeleteResource:      stubDeleteResource,
+			DeleteFromIndex:     stubDeleteFromIndex,
 		},
 	}
 }
@@ -55,10 +62,34 @@ func mockContactPlugin(req model.PluginContactRequest, errorMessage string) ([]b
 	return []byte(`{"Attributes":"sample"}`), "token", responseStatus, nil
 }
 
+func mockGetSessionUserName(sessionToken string) (string, error) {
+	return "admin", nil
+}
+
+func mockCreateTask(sessionUserName string) (string, error) {
+	return "/redfish/v1/TaskService/Tasks/task12345", nil
+}
+
+func mockCreateChildTask(sessionUserName, taskID string) (string, error) {
+	return "/redfish/v1/TaskService/Tasks/task23456", nil
+}
+
+func mockUpdateTask(task common.TaskData) error {
+	return nil
+}
+
 func stubGenericSave(reqBody []byte, table string, uuid string) error {
 	return nil
 }
 
+func stubDeleteResource(table, key string, dbtype persistencemgr.DbType) *errors.Error {
+	return nil
+}
+
+func stubDeleteFromIndex(index, key string) error {
+	return nil
+}
+
 func stubDevicePassword(password []byte) ([]byte, error) {
 	return password, nil
 }
@@ -249,37 +280,118 @@ func TestUpdate_GetLicenseResource(t *testing.T) {
 func TestUpdate_InstallLicenseService(t *testing.T) {
 	license := new(Licenses)
 	license.connector = mockGetExternalInterface()
-	type args struct {
-		ctx context.Context
-		req *licenseproto.InstallLicenseRequest
+	taskFailure := new(Licenses)
+	taskFailure.connector = mockGetExternalInterface()
+	taskFailure.connector.External.CreateTask = func(sessionUserName string) (string, error) {
+		return "", fmt.Errorf("task service is unavailable")
 	}
 	tests := []struct {
-		name    string
-		a       *Licenses
-		args    args
-		wantErr bool
+		name       string
+		a          *Licenses
+		req        *licenseproto.InstallLicenseRequest
+		statusCode int32
+		location   string
 	}{
 		{
-			name: "positive InstallLicenseService",
-			a:    license,
-			args: args{
-				req: &licenseproto.InstallLicenseRequest{SessionToken: "validToken"},
-			},
-			wantErr: false,
+			name:       "positive InstallLicenseService",
+			a:          license,
+			req:        &licenseproto.InstallLicenseRequest{SessionToken: "validToken"},
+			statusCode: http.StatusAccepted,
+			location:   "/taskmon/task12345",
 		},
 		{
-			name: "auth fail",
-			a:    license,
-			args: args{
-				req: &licenseproto.InstallLicenseRequest{SessionToken: "invalidToken"},
-			},
-			wantErr: false,
+			name:       "auth fail",
+			a:          license,
+			req:        &licenseproto.InstallLicenseRequest{SessionToken: "invalidToken"},
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "task creation fail",
+			a:          taskFailure,
+			req:        &licenseproto.InstallLicenseRequest{SessionToken: "validToken"},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := tt.a.InstallLicenseService(context.TODO(), tt.req)
+			if err != nil {
+				t.Errorf("License.InstallLicenseService() error = %v", err)
+			}
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("License.InstallLicenseService() status code = %v, want %v", resp.StatusCode, tt.statusCode)
+			}
+			if resp.Header["Location"] != tt.location {
+				t.Errorf("License.InstallLicenseService() Location = %v, want %v", resp.Header["Location"], tt.location)
+			}
+		})
+	}
+}
+
+func TestUpdate_DeleteLicense(t *testing.T) {
+	license := new(Licenses)
+	license.connector = mockGetExternalInterface()
+	tests := []struct {
+		name       string
+		req        *licenseproto.GetLicenseResourceRequest
+		statusCode int32
+	}{
+		{
+			name:       "positive DeleteLicense",
+			req:        &licenseproto.GetLicenseResourceRequest{SessionToken: "validToken", URL: "/redfish/v1/LicenseService/Licenses/uuid.1.1"},
+			statusCode: http.StatusNoContent,
+		},
+		{
+			name:       "license not found",
+			req:        &licenseproto.GetLicenseResourceRequest{SessionToken: "validToken", URL: "/redfish/v1/LicenseService/Licenses"},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "auth fail",
+			req:        &licenseproto.GetLicenseResourceRequest{SessionToken: "invalidToken", URL: "/redfish/v1/LicenseService/Licenses/uuid.1.1"},
+			statusCode: http.StatusUnauthorized,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if _, err := tt.a.InstallLicenseService(tt.args.ctx, tt.args.req); (err != nil) != tt.wantErr {
-				t.Errorf("License.InstallLicenseService() error = %v, wantErr %v", err, tt.wantErr)
+			resp, err := license.DeleteLicense(context.TODO(), tt.req)
+			if err != nil {
+				t.Errorf("License.DeleteLicense() error = %v", err)
+			}
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("License.DeleteLicense() status code = %v, want %v", resp.StatusCode, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestUpdate_GetLicenseReport(t *testing.T) {
+	license := new(Licenses)
+	license.connector = mockGetExternalInterface()
+	tests := []struct {
+		name       string
+		req        *licenseproto.GetLicenseRequest
+		statusCode int32
+	}{
+		{
+			name:       "positive GetLicenseReport",
+			req:        &licenseproto.GetLicenseRequest{SessionToken: "validToken"},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "auth fail",
+			req:        &licenseproto.GetLicenseRequest{SessionToken: "invalidToken"},
+			statusCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := license.GetLicenseReport(context.TODO(), tt.req)
+			if err != nil {
+				t.Errorf("License.GetLicenseReport() error = %v", err)
+			}
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("License.GetLicenseReport() status code = %v, want %v", resp.StatusCode, tt.statusCode)
 			}
 		})
 	}
@@ -0,0 +1,747 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package managers
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-managers/mgrmodel"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+const (
+	certificateServiceURI   = "/redfish/v1/CertificateService"
+	certificateLocationsURI = certificateServiceURI + "/CertificateLocations"
+	generateCSRActionURI    = certificateServiceURI + "/Actions/CertificateService.GenerateCSR"
+	replaceCertActionURI    = certificateServiceURI + "/Actions/CertificateService.ReplaceCertificate"
+	// gatewayCertificateID is the id of the API gateway certificate of ODIM
+	gatewayCertificateID = "1"
+	// rpcCertificateID is the id of the certificate used by the ODIM services for RPC
+	rpcCertificateID = "2"
+	// csrPrivateKeyStorePrefix prefixes the keys in the certificate store of the private keys
+	// of the CSRs generated for ODIM, till the signed certificates are installed
+	csrPrivateKeyStorePrefix = "CSR."
+)
+
+// deviceCertificateURI matches the certificate resources of the BMCs,
+// which are prefixed with the device UUID
+var deviceCertificateURI = regexp.MustCompile(`^/redfish/v1/(Managers|Systems|Chassis)/([^/.]+)\.([^/]+)/.+$`)
+
+// odimCertificate holds a certificate owned by ODIM along with its private key
+// and the key it is saved with in the certificate store once replaced
+type odimCertificate struct {
+	ID          string
+	Name        string
+	Certificate []byte
+	PrivateKey  []byte
+	StoreKey    string
+}
+
+func odimCertificateCollectionURI() string {
+	return "/redfish/v1/Managers/" + config.Data.RootServiceUUID + "/NetworkProtocol/HTTPS/Certificates"
+}
+
+// getODIMCertificates returns the certificates currently used by ODIM
+func getODIMCertificates() []odimCertificate {
+	config.TLSConfMutex.RLock()
+	defer config.TLSConfMutex.RUnlock()
+	return []odimCertificate{
+		{
+			ID:          gatewayCertificateID,
+			Name:        "API Gateway Certificate",
+			Certificate: append([]byte{}, config.Data.APIGatewayConf.Certificate...),
+			PrivateKey:  append([]byte{}, config.Data.APIGatewayConf.PrivateKey...),
+			StoreKey:    common.APIGatewayCertificateKey,
+		},
+		{
+			ID:          rpcCertificateID,
+			Name:        "RPC Certificate",
+			Certificate: append([]byte{}, config.Data.KeyCertConf.RPCCertificate...),
+			PrivateKey:  append([]byte{}, config.Data.KeyCertConf.RPCPrivateKey...),
+			StoreKey:    common.RPCCertificateKey,
+		},
+	}
+}
+
+func getODIMCertificate(uri string) (odimCertificate, bool) {
+	for _, cert := range getODIMCertificates() {
+		if uri == odimCertificateCollectionURI()+"/"+cert.ID {
+			return cert, true
+		}
+	}
+	return odimCertificate{}, false
+}
+
+// GetCertificateService is used to fetch the CertificateService resource
+func (e *ExternalInterface) GetCertificateService(req *managersproto.ManagerRequest) response.RPC {
+	var resp response.RPC
+	resp.Body = dmtf.CertificateService{
+		OdataContext: "/redfish/v1/$metadata#CertificateService.CertificateService",
+		OdataID:      certificateServiceURI,
+		OdataType:    common.CertificateServiceType,
+		ID:           "CertificateService",
+		Name:         "Certificate Service",
+		Description:  "Certificate Service",
+		Actions: &dmtf.CertificateServiceActions{
+			GenerateCSR:        &dmtf.ActionTarget{Target: generateCSRActionURI},
+			ReplaceCertificate: &dmtf.ActionTarget{Target: replaceCertActionURI},
+		},
+		CertificateLocations: &dmtf.Link{Oid: certificateLocationsURI},
+	}
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	return resp
+}
+
+// GetCertificateLocations is used to fetch the locations of the certificates
+// installed on ODIM and on the BMCs managed by ODIM.
+// The certificates of the BMCs are read from the CertificateLocations of the devices,
+// and the devices which are not reachable are left out from the response.
+func (e *ExternalInterface) GetCertificateLocations(req *managersproto.ManagerRequest) response.RPC {
+	var resp response.RPC
+	var certificates []*dmtf.Link
+	for _, cert := range getODIMCertificates() {
+		certificates = append(certificates, &dmtf.Link{Oid: odimCertificateCollectionURI() + "/" + cert.ID})
+	}
+
+	managerURIs, err := e.DB.GetAllKeysFromTable("Managers")
+	if err != nil {
+		errorMessage := "unable to get the managers: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	// the certificate locations are read once for each device
+	devices := make(map[string]string)
+	for _, managerURI := range managerURIs {
+		requestData := strings.SplitN(managerURI[strings.LastIndex(managerURI, "/")+1:], ".", 2)
+		if len(requestData) > 1 {
+			devices[requestData[0]] = requestData[1]
+		}
+	}
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var deviceCertificates []string
+	for uuid, managerID := range devices {
+		wg.Add(1)
+		go func(uuid, managerID string) {
+			defer wg.Done()
+			links, err := e.getDeviceCertificateLocations(uuid, managerID)
+			if err != nil {
+				log.Warn("unable to get the certificate locations of the device " + uuid + ": " + err.Error())
+				return
+			}
+			lock.Lock()
+			deviceCertificates = append(deviceCertificates, links...)
+			lock.Unlock()
+		}(uuid, managerID)
+	}
+	wg.Wait()
+	sort.Strings(deviceCertificates)
+	for _, link := range deviceCertificates {
+		certificates = append(certificates, &dmtf.Link{Oid: link})
+	}
+
+	resp.Body = dmtf.CertificateLocations{
+		OdataContext: "/redfish/v1/$metadata#CertificateLocations.CertificateLocations",
+		OdataID:      certificateLocationsURI,
+		OdataType:    common.CertificateLocationsType,
+		ID:           "CertificateLocations",
+		Name:         "Certificate Locations",
+		Description:  "Certificates installed on ODIM and on the managed BMCs",
+		Links: &dmtf.CertificateLocationsLinks{
+			Certificates:      certificates,
+			CertificatesCount: len(certificates),
+		},
+	}
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	return resp
+}
+
+func (e *ExternalInterface) getDeviceCertificateLocations(uuid, managerID string) ([]string, error) {
+	data, err := e.getResourceInfoFromDevice(certificateLocationsURI, uuid, managerID)
+	if err != nil {
+		return nil, err
+	}
+	var locations dmtf.CertificateLocations
+	if err := json.Unmarshal([]byte(data), &locations); err != nil {
+		return nil, err
+	}
+	if locations.Links == nil {
+		return nil, nil
+	}
+	var links []string
+	for _, link := range locations.Links.Certificates {
+		// only the certificates of the resources aggregated by ODIM can be addressed
+		if link != nil && strings.Contains(link.Oid, "/"+uuid+".") {
+			links = append(links, link.Oid)
+		}
+	}
+	return links, nil
+}
+
+// GetODIMCertificateResource is used to fetch the certificates of ODIM
+// which are listed under the HTTPS certificates of the ODIM manager
+func GetODIMCertificateResource(uri string) response.RPC {
+	var resp response.RPC
+	uri = strings.TrimSuffix(strings.SplitN(uri, "?", 2)[0], "/")
+	if uri == odimCertificateCollectionURI() {
+		var members []*dmtf.Link
+		for _, cert := range getODIMCertificates() {
+			members = append(members, &dmtf.Link{Oid: uri + "/" + cert.ID})
+		}
+		resp.Body = dmtf.CertificateCollection{
+			OdataContext: "/redfish/v1/$metadata#CertificateCollection.CertificateCollection",
+			OdataID:      uri,
+			OdataType:    common.CertificateCollectionType,
+			Name:         "Certificate Collection",
+			Members:      members,
+			MembersCount: len(members),
+		}
+		resp.StatusCode = http.StatusOK
+		resp.StatusMessage = response.Success
+		return resp
+	}
+	cert, ok := getODIMCertificate(uri)
+	if !ok {
+		errorMessage := "unable to find the certificate " + uri
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"Certificate", uri[strings.LastIndex(uri, "/")+1:]}, nil)
+	}
+	certificate, err := convertToCertificateModel(uri, cert.ID, cert.Name, cert.Certificate)
+	if err != nil {
+		errorMessage := "unable to read the certificate " + uri + ": " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	resp.Body = certificate
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	return resp
+}
+
+// isODIMCertificateResource checks if the request is for the certificates of ODIM
+func isODIMCertificateResource(managerID, uri string) bool {
+	return managerID == config.Data.RootServiceUUID && strings.HasPrefix(uri, odimCertificateCollectionURI())
+}
+
+func convertToCertificateModel(uri, id, name string, data []byte) (dmtf.Certificate, error) {
+	certs, err := parseCertificates(data)
+	if err != nil {
+		return dmtf.Certificate{}, err
+	}
+	leaf := certs[0]
+	return dmtf.Certificate{
+		OdataContext:      "/redfish/v1/$metadata#Certificate.Certificate",
+		OdataID:           uri,
+		OdataType:         common.CertificateType,
+		ID:                id,
+		Name:              name,
+		CertificateString: string(data),
+		CertificateType:   "PEM",
+		Issuer:            convertToCertificateIdentifier(leaf.Issuer),
+		Subject:           convertToCertificateIdentifier(leaf.Subject),
+		KeyUsage:          getKeyUsage(leaf),
+		SerialNumber:      fmt.Sprintf("%X", leaf.SerialNumber),
+		ValidNotBefore:    leaf.NotBefore.UTC().Format(time.RFC3339),
+		ValidNotAfter:     leaf.NotAfter.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+func convertToCertificateIdentifier(name pkix.Name) *dmtf.CertificateIdentifier {
+	return &dmtf.CertificateIdentifier{
+		City:               strings.Join(name.Locality, ","),
+		CommonName:         name.CommonName,
+		Country:            strings.Join(name.Country, ","),
+		Organization:       strings.Join(name.Organization, ","),
+		OrganizationalUnit: strings.Join(name.OrganizationalUnit, ","),
+		State:              strings.Join(name.Province, ","),
+	}
+}
+
+func getKeyUsage(cert *x509.Certificate) []string {
+	keyUsages := []struct {
+		usage x509.KeyUsage
+		name  string
+	}{
+		{x509.KeyUsageDigitalSignature, "DigitalSignature"},
+		{x509.KeyUsageContentCommitment, "NonRepudiation"},
+		{x509.KeyUsageKeyEncipherment, "KeyEncipherment"},
+		{x509.KeyUsageDataEncipherment, "DataEncipherment"},
+		{x509.KeyUsageKeyAgreement, "KeyAgreement"},
+		{x509.KeyUsageCertSign, "KeyCertSign"},
+		{x509.KeyUsageCRLSign, "CRLSigning"},
+		{x509.KeyUsageEncipherOnly, "EncipherOnly"},
+		{x509.KeyUsageDecipherOnly, "DecipherOnly"},
+	}
+	var usages []string
+	for _, keyUsage := range keyUsages {
+		if cert.KeyUsage&keyUsage.usage != 0 {
+			usages = append(usages, keyUsage.name)
+		}
+	}
+	for _, extKeyUsage := range cert.ExtKeyUsage {
+		switch extKeyUsage {
+		case x509.ExtKeyUsageServerAuth:
+			usages = append(usages, "ServerAuthentication")
+		case x509.ExtKeyUsageClientAuth:
+			usages = append(usages, "ClientAuthentication")
+		case x509.ExtKeyUsageCodeSigning:
+			usages = append(usages, "CodeSigning")
+		case x509.ExtKeyUsageEmailProtection:
+			usages = append(usages, "EmailProtection")
+		case x509.ExtKeyUsageTimeStamping:
+			usages = append(usages, "Timestamping")
+		case x509.ExtKeyUsageOCSPSigning:
+			usages = append(usages, "OCSPSigning")
+		}
+	}
+	return usages
+}
+
+// parseCertificates decodes the PEM encoded certificates, the first one being the leaf certificate
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return certs, nil
+}
+
+// GenerateCSR is used to generate a certificate signing request for the given certificate collection.
+// For the certificates of ODIM the key pair is generated by ODIM and the private key
+// is kept till the signed certificate is installed using ReplaceCertificate,
+// for the certificates of the BMCs the request is passed on to the device through the plugin.
+func (e *ExternalInterface) GenerateCSR(req *managersproto.ManagerRequest) response.RPC {
+	var csrReq mgrmodel.GenerateCSR
+	if err := json.Unmarshal(req.RequestBody, &csrReq); err != nil {
+		errorMessage := "while unmarshaling the generate CSR request: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, []interface{}{}, nil)
+	}
+
+	// Validating the request JSON properties for case sensitive
+	invalidProperties, err := RequestParamsCaseValidatorFunc(req.RequestBody, csrReq)
+	if err != nil {
+		errMsg := "while validating request parameters for generating CSR: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	} else if invalidProperties != "" {
+		errorMessage := "one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, nil)
+	}
+
+	statuscode, statusMessage, messageArgs, err := validateRequiredFields(&csrReq)
+	if err == nil && csrReq.CertificateCollection.Oid == "" {
+		statuscode, statusMessage, messageArgs = http.StatusBadRequest, response.PropertyMissing, []interface{}{"CertificateCollection"}
+		err = fmt.Errorf("CertificateCollection field is missing")
+	}
+	if err != nil {
+		errorMessage := "request payload validation failed: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(statuscode, statusMessage, errorMessage, messageArgs, nil)
+	}
+
+	collectionURI := strings.TrimSuffix(csrReq.CertificateCollection.Oid, "/")
+	if collectionURI == odimCertificateCollectionURI() {
+		return e.generateODIMCSR(csrReq)
+	}
+	match := deviceCertificateURI.FindStringSubmatch(collectionURI)
+	if match == nil {
+		errorMessage := "unable to find the certificate collection " + collectionURI
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"CertificateCollection", collectionURI}, nil)
+	}
+	uuid, resourceID := match[2], match[3]
+	csrReq.CertificateCollection = &dmtf.Link{Oid: strings.Replace(collectionURI, uuid+"."+resourceID, resourceID, 1)}
+	requestBody, err := json.Marshal(csrReq)
+	if err != nil {
+		log.Error("while marshalling the generate CSR request: " + err.Error())
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+	}
+	resp := e.deviceCommunication(generateCSRActionURI, uuid, resourceID, http.MethodPost, requestBody)
+	if resp.StatusCode != http.StatusOK {
+		return resp
+	}
+	data, _ := json.Marshal(resp.Body)
+	var csrResp dmtf.GenerateCSRResponse
+	if err := json.Unmarshal(data, &csrResp); err != nil || csrResp.CSRString == "" {
+		errorMessage := "invalid generate CSR response from the device " + uuid
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	csrResp.CertificateCollection = &dmtf.Link{Oid: collectionURI}
+	resp.Body = csrResp
+	return resp
+}
+
+// validateRequiredFields will validate the request payload, if any mandatory fields are missing then it will generate an error
+func validateRequiredFields(request interface{}) (int32, string, []interface{}, error) {
+	validate := validator.New()
+	if err := validate.Struct(request); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			return http.StatusBadRequest, response.PropertyMissing, []interface{}{err.Field()}, fmt.Errorf(err.Field() + " field is missing")
+		}
+	}
+	return http.StatusOK, common.OK, []interface{}{}, nil
+}
+
+func (e *ExternalInterface) generateODIMCSR(csrReq mgrmodel.GenerateCSR) response.RPC {
+	privateKey, keyBlock, statuscode, statusMessage, messageArgs, err := generatePrivateKey(csrReq)
+	if err != nil {
+		errorMessage := "unable to generate the key pair: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(statuscode, statusMessage, errorMessage, messageArgs, nil)
+	}
+
+	template := &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName: csrReq.CommonName,
+		},
+	}
+	if csrReq.Country != "" {
+		template.Subject.Country = []string{csrReq.Country}
+	}
+	if csrReq.State != "" {
+		template.Subject.Province = []string{csrReq.State}
+	}
+	if csrReq.City != "" {
+		template.Subject.Locality = []string{csrReq.City}
+	}
+	if csrReq.Organization != "" {
+		template.Subject.Organization = []string{csrReq.Organization}
+	}
+	if csrReq.OrganizationalUnit != "" {
+		template.Subject.OrganizationalUnit = []string{csrReq.OrganizationalUnit}
+	}
+	if csrReq.Email != "" {
+		template.EmailAddresses = []string{csrReq.Email}
+	}
+	for _, name := range csrReq.AlternativeNames {
+		if ip := net.ParseIP(name); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, name)
+		}
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, template, privateKey)
+	if err != nil {
+		errorMessage := "unable to create the certificate signing request: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+
+	// the private key is kept in the db, so that any instance of the service can install the
+	// signed certificate. Every CSR has its own key, so that the certificates of the API gateway
+	// and of the services can be requested at the same time.
+	storeKey, err := csrPrivateKeyStoreKey(privateKey.Public())
+	if err != nil {
+		errorMessage := "unable to identify the private key of the certificate signing request: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if err := e.DB.SaveStoredCertificate(storeKey, common.StoredCertificate{PrivateKey: pem.EncodeToMemory(keyBlock)}); err != nil {
+		errorMessage := "unable to save the private key of the certificate signing request: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+
+	var resp response.RPC
+	resp.Body = dmtf.GenerateCSRResponse{
+		CSRString:             string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr})),
+		CertificateCollection: &dmtf.Link{Oid: odimCertificateCollectionURI()},
+	}
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	return resp
+}
+
+// csrPrivateKeyStoreKey returns the key in the certificate store of the private key of a CSR,
+// which is derived from the public key, so that it is found from the signed certificate
+func csrPrivateKeyStoreKey(publicKey crypto.PublicKey) (string, error) {
+	data, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return csrPrivateKeyStorePrefix + hex.EncodeToString(sum[:]), nil
+}
+
+// generatePrivateKey generates the private key as per the KeyPairAlgorithm of the request,
+// RSA keys with 2048 bits are generated by default
+func generatePrivateKey(csrReq mgrmodel.GenerateCSR) (crypto.Signer, *pem.Block, int32, string, []interface{}, error) {
+	switch csrReq.KeyPairAlgorithm {
+	case "", "TPM_ALG_RSA":
+		bitLength := csrReq.KeyBitLength
+		if bitLength == 0 {
+			bitLength = 2048
+		}
+		if bitLength != 2048 && bitLength != 3072 && bitLength != 4096 {
+			return nil, nil, http.StatusBadRequest, response.PropertyValueNotInList, []interface{}{fmt.Sprintf("%v", bitLength), "KeyBitLength"},
+				fmt.Errorf("KeyBitLength %v is not supported", bitLength)
+		}
+		key, err := rsa.GenerateKey(rand.Reader, bitLength)
+		if err != nil {
+			return nil, nil, http.StatusInternalServerError, response.InternalError, nil, err
+		}
+		return key, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}, http.StatusOK, response.Success, nil, nil
+	case "TPM_ALG_ECDSA":
+		var curve elliptic.Curve
+		switch csrReq.KeyCurveID {
+		case "", "TPM_ECC_NIST_P256":
+			curve = elliptic.P256()
+		case "TPM_ECC_NIST_P384":
+			curve = elliptic.P384()
+		default:
+			return nil, nil, http.StatusBadRequest, response.PropertyValueNotInList, []interface{}{csrReq.KeyCurveID, "KeyCurveId"},
+				fmt.Errorf("KeyCurveId %v is not supported", csrReq.KeyCurveID)
+		}
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			return nil, nil, http.StatusInternalServerError, response.InternalError, nil, err
+		}
+		data, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, nil, http.StatusInternalServerError, response.InternalError, nil, err
+		}
+		return key, &pem.Block{Type: "EC PRIVATE KEY", Bytes: data}, http.StatusOK, response.Success, nil, nil
+	}
+	return nil, nil, http.StatusBadRequest, response.PropertyValueNotInList, []interface{}{csrReq.KeyPairAlgorithm, "KeyPairAlgorithm"},
+		fmt.Errorf("KeyPairAlgorithm %v is not supported", csrReq.KeyPairAlgorithm)
+}
+
+// ReplaceCertificate is used to replace a certificate of ODIM or of a BMC.
+// A certificate of ODIM must be issued by the root CA of ODIM, and its key must either be the key
+// in use or the key of a CSR generated for ODIM. The new certificate and key are saved
+// in the db, from where all the instances of the services load them.
+// The certificates of the BMCs are replaced on the device through the plugin.
+func (e *ExternalInterface) ReplaceCertificate(req *managersproto.ManagerRequest) response.RPC {
+	var replaceReq mgrmodel.ReplaceCertificate
+	if err := json.Unmarshal(req.RequestBody, &replaceReq); err != nil {
+		errorMessage := "while unmarshaling the replace certificate request: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, []interface{}{}, nil)
+	}
+
+	// Validating the request JSON properties for case sensitive
+	invalidProperties, err := RequestParamsCaseValidatorFunc(req.RequestBody, replaceReq)
+	if err != nil {
+		errMsg := "while validating request parameters for replacing certificate: " + err.Error()
+		log.Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	} else if invalidProperties != "" {
+		errorMessage := "one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, nil)
+	}
+
+	statuscode, statusMessage, messageArgs, err := validateRequiredFields(&replaceReq)
+	if err == nil && replaceReq.CertificateURI.Oid == "" {
+		statuscode, statusMessage, messageArgs = http.StatusBadRequest, response.PropertyMissing, []interface{}{"CertificateUri"}
+		err = fmt.Errorf("CertificateUri field is missing")
+	}
+	if err != nil {
+		errorMessage := "request payload validation failed: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(statuscode, statusMessage, errorMessage, messageArgs, nil)
+	}
+	if replaceReq.CertificateType != "PEM" && replaceReq.CertificateType != "PEMchain" {
+		errorMessage := "CertificateType " + replaceReq.CertificateType + " is not supported"
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage, []interface{}{replaceReq.CertificateType, "CertificateType"}, nil)
+	}
+
+	certificateURI := strings.TrimSuffix(replaceReq.CertificateURI.Oid, "/")
+	if strings.HasPrefix(certificateURI, odimCertificateCollectionURI()+"/") {
+		cert, ok := getODIMCertificate(certificateURI)
+		if !ok {
+			errorMessage := "unable to find the certificate " + certificateURI
+			log.Error(errorMessage)
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"Certificate", certificateURI}, nil)
+		}
+		return e.replaceODIMCertificate(certificateURI, cert, []byte(replaceReq.CertificateString))
+	}
+	match := deviceCertificateURI.FindStringSubmatch(certificateURI)
+	if match == nil {
+		errorMessage := "unable to find the certificate " + certificateURI
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"Certificate", certificateURI}, nil)
+	}
+	uuid, resourceID := match[2], match[3]
+	replaceReq.CertificateURI = &dmtf.Link{Oid: strings.Replace(certificateURI, uuid+"."+resourceID, resourceID, 1)}
+	requestBody, err := json.Marshal(replaceReq)
+	if err != nil {
+		log.Error("while marshalling the replace certificate request: " + err.Error())
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+	}
+	resp := e.deviceCommunication(replaceCertActionURI, uuid, resourceID, http.MethodPost, requestBody)
+	if resp.StatusCode != http.StatusOK {
+		return resp
+	}
+	if resp.Body == nil {
+		resp.StatusCode = http.StatusNoContent
+		return resp
+	}
+	data, _ := json.Marshal(resp.Body)
+	var body interface{}
+	json.Unmarshal([]byte(translateDeviceURIs(string(data), uuid)), &body)
+	resp.Body = body
+	return resp
+}
+
+func (e *ExternalInterface) replaceODIMCertificate(uri string, cert odimCertificate, certificate []byte) response.RPC {
+	certs, err := parseCertificates(certificate)
+	if err != nil {
+		errorMessage := "unable to parse the certificate: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage, []interface{}{"CertificateString", "CertificateString"}, nil)
+	}
+	if err := verifyODIMCertificate(cert.ID, certs); err != nil {
+		errorMessage := "certificate verification failed: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage, []interface{}{"CertificateString", "CertificateString"}, nil)
+	}
+
+	// the key of the certificate must be the key in use or the key of a CSR generated for ODIM
+	keys := [][]byte{cert.PrivateKey}
+	csrStoreKey, err := csrPrivateKeyStoreKey(certs[0].PublicKey)
+	if err != nil {
+		errorMessage := "unable to read the public key of the certificate: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage, []interface{}{"CertificateString", "CertificateString"}, nil)
+	}
+	csrKey, dbErr := e.DB.GetStoredCertificate(csrStoreKey)
+	if dbErr != nil {
+		errorMessage := "unable to get the private key of the CSR: " + dbErr.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if csrKey != nil {
+		keys = append(keys, csrKey.PrivateKey)
+	}
+	var privateKey []byte
+	for _, key := range keys {
+		if _, err := tls.X509KeyPair(certificate, key); err == nil {
+			privateKey = key
+			break
+		}
+	}
+	if privateKey == nil {
+		errorMessage := "the certificate does not match the private key in use or the private key of a generated CSR"
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errorMessage, []interface{}{"CertificateString", "CertificateUri"}, nil)
+	}
+
+	// the certificate and its key are saved together, so that the services never load a mismatched pair
+	if err := e.DB.SaveStoredCertificate(cert.StoreKey, common.StoredCertificate{Certificate: certificate, PrivateKey: privateKey}); err != nil {
+		errorMessage := "unable to save the certificate: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if err := e.DB.DeleteStoredCertificate(csrStoreKey); err != nil {
+		log.Warn("unable to remove the private key of the CSR: " + err.Error())
+	}
+	log.Info("replaced the certificate " + uri)
+
+	var resp response.RPC
+	certificateModel, err := convertToCertificateModel(uri, cert.ID, cert.Name, certificate)
+	if err != nil {
+		errorMessage := "unable to read the certificate " + uri + ": " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	resp.Body = certificateModel
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	return resp
+}
+
+// verifyODIMCertificate checks that the certificate is issued by the root CA of ODIM,
+// as it is the only CA trusted by the services and the clients of ODIM
+func verifyODIMCertificate(id string, certs []*x509.Certificate) error {
+	config.TLSConfMutex.RLock()
+	rootCA := append([]byte{}, config.Data.KeyCertConf.RootCACertificate...)
+	serverName := config.Data.LocalhostFQDN
+	config.TLSConfMutex.RUnlock()
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(rootCA) {
+		return fmt.Errorf("unable to load the root CA certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	// the services contact each other using the LocalhostFQDN
+	if id == rpcCertificateID {
+		opts.DNSName = serverName
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
+
+// translateDeviceURIs adds the device UUID to the URIs in the response of a device
+func translateDeviceURIs(data, uuid string) string {
+	for _, collection := range []string{"Systems", "Managers", "Chassis"} {
+		data = strings.Replace(data, "/redfish/v1/"+collection+"/", "/redfish/v1/"+collection+"/"+uuid+".", -1)
+	}
+	return data
+}
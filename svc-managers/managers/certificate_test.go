@@ -0,0 +1,305 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package managers
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-managers/mgrcommon"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *rsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ODIM test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) certificatePEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+}
+
+func (ca *testCA) sign(t *testing.T, csrPEM string) []byte {
+	block, _ := pem.Decode([]byte(csrPEM))
+	if block == nil {
+		t.Fatal("invalid CSR")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      csr.Subject,
+		DNSNames:     csr.DNSNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, csr.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// mockCertificateStore holds the certificates saved through the mock DB functions
+var mockCertificateStore map[string]common.StoredCertificate
+
+func mockCertificates(t *testing.T, ca *testCA) {
+	config.SetUpMockConfig(t)
+	config.Data.KeyCertConf.RootCACertificate = ca.certificatePEM()
+	mockCertificateStore = make(map[string]common.StoredCertificate)
+}
+
+func mockGetStoredCertificate(key string) (*common.StoredCertificate, *errors.Error) {
+	cert, ok := mockCertificateStore[key]
+	if !ok {
+		return nil, nil
+	}
+	return &cert, nil
+}
+
+func mockSaveStoredCertificate(key string, cert common.StoredCertificate) *errors.Error {
+	mockCertificateStore[key] = cert
+	return nil
+}
+
+func mockDeleteStoredCertificate(key string) *errors.Error {
+	delete(mockCertificateStore, key)
+	return nil
+}
+
+func mockCertificateDeviceInfo(req mgrcommon.ResourceInfoRequest) (string, error) {
+	locations := dmtf.CertificateLocations{
+		OdataID: certificateLocationsURI,
+		Links: &dmtf.CertificateLocationsLinks{
+			Certificates: []*dmtf.Link{
+				{Oid: "/redfish/v1/Managers/" + req.UUID + ".1/NetworkProtocol/HTTPS/Certificates/1"},
+				{Oid: "/redfish/v1/AccountService/Accounts/1/Certificates/1"},
+			},
+		},
+	}
+	data, err := json.Marshal(locations)
+	return string(data), err
+}
+
+func TestGetCertificateService(t *testing.T) {
+	config.SetUpMockConfig(t)
+	e := mockGetExternalInterface()
+	resp := e.GetCertificateService(&managersproto.ManagerRequest{URL: certificateServiceURI})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	service := resp.Body.(dmtf.CertificateService)
+	assert.Equal(t, generateCSRActionURI, service.Actions.GenerateCSR.Target)
+	assert.Equal(t, replaceCertActionURI, service.Actions.ReplaceCertificate.Target)
+	assert.Equal(t, certificateLocationsURI, service.CertificateLocations.Oid)
+}
+
+func TestGetCertificateLocations(t *testing.T) {
+	config.SetUpMockConfig(t)
+	e := mockGetExternalInterface()
+	e.Device.GetDeviceInfo = mockCertificateDeviceInfo
+	resp := e.GetCertificateLocations(&managersproto.ManagerRequest{URL: certificateLocationsURI})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	locations := resp.Body.(dmtf.CertificateLocations)
+	assert.Equal(t, 3, locations.Links.CertificatesCount)
+	assert.Equal(t, odimCertificateCollectionURI()+"/"+gatewayCertificateID, locations.Links.Certificates[0].Oid)
+	assert.Equal(t, odimCertificateCollectionURI()+"/"+rpcCertificateID, locations.Links.Certificates[1].Oid)
+	assert.Equal(t, "/redfish/v1/Managers/uuid.1/NetworkProtocol/HTTPS/Certificates/1", locations.Links.Certificates[2].Oid)
+
+	// devices which are not reachable are left out
+	e.Device.GetDeviceInfo = mockGetDeviceInfo
+	resp = e.GetCertificateLocations(&managersproto.ManagerRequest{URL: certificateLocationsURI})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	assert.Equal(t, 2, resp.Body.(dmtf.CertificateLocations).Links.CertificatesCount)
+}
+
+func TestGetODIMCertificateResource(t *testing.T) {
+	config.SetUpMockConfig(t)
+	e := mockGetExternalInterface()
+	req := &managersproto.ManagerRequest{
+		ManagerID: config.Data.RootServiceUUID,
+		URL:       odimCertificateCollectionURI(),
+	}
+	resp := e.GetManagersResource(req)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	assert.Equal(t, 2, resp.Body.(dmtf.CertificateCollection).MembersCount)
+
+	req.URL = odimCertificateCollectionURI() + "/" + rpcCertificateID
+	req.ResourceID = rpcCertificateID
+	resp = e.GetManagersResource(req)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	certificate := resp.Body.(dmtf.Certificate)
+	assert.Equal(t, common.CertificateType, certificate.OdataType)
+	assert.Equal(t, "PEM", certificate.CertificateType)
+	assert.NotEmpty(t, certificate.ValidNotAfter)
+
+	req.URL = odimCertificateCollectionURI() + "/3"
+	req.ResourceID = "3"
+	resp = e.GetManagersResource(req)
+	assert.Equal(t, http.StatusNotFound, int(resp.StatusCode), "Status code should be StatusNotFound.")
+}
+
+func TestGenerateCSRAndReplaceODIMCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	mockCertificates(t, ca)
+	e := mockGetExternalInterface()
+
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+	}{
+		{"missing common name", `{"CertificateCollection":{"@odata.id":"` + odimCertificateCollectionURI() + `"}}`, http.StatusBadRequest},
+		{"missing collection", `{"CommonName":"odim.test.com"}`, http.StatusBadRequest},
+		{"unknown collection", `{"CommonName":"odim.test.com","CertificateCollection":{"@odata.id":"/redfish/v1/Managers/uuid/Certificates"}}`, http.StatusNotFound},
+		{"invalid algorithm", `{"CommonName":"odim.test.com","KeyPairAlgorithm":"TPM_ALG_DSA","CertificateCollection":{"@odata.id":"` + odimCertificateCollectionURI() + `"}}`, http.StatusBadRequest},
+		{"invalid key length", `{"CommonName":"odim.test.com","KeyBitLength":1024,"CertificateCollection":{"@odata.id":"` + odimCertificateCollectionURI() + `"}}`, http.StatusBadRequest},
+		{"invalid json", `{"CommonName":}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := e.GenerateCSR(&managersproto.ManagerRequest{URL: generateCSRActionURI, RequestBody: []byte(tt.body)})
+			assert.Equal(t, tt.statusCode, int(resp.StatusCode))
+		})
+	}
+
+	generateCSR := func(commonName string) []byte {
+		resp := e.GenerateCSR(&managersproto.ManagerRequest{
+			URL: generateCSRActionURI,
+			RequestBody: []byte(`{"CommonName":"` + commonName + `","AlternativeNames":["` + commonName + `"],"Country":"US",
+			"CertificateCollection":{"@odata.id":"` + odimCertificateCollectionURI() + `"}}`),
+		})
+		assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+		csrResp := resp.Body.(dmtf.GenerateCSRResponse)
+		assert.Equal(t, odimCertificateCollectionURI(), csrResp.CertificateCollection.Oid)
+		return ca.sign(t, csrResp.CSRString)
+	}
+	// the CSRs of the certificates of the services and of the API gateway are pending together
+	signed := generateCSR("odim.test.com")
+	gatewaySigned := generateCSR("gateway.odim.test.com")
+	assert.Len(t, mockCertificateStore, 2)
+	replaceBody := func(certificate []byte, id string) []byte {
+		body, _ := json.Marshal(map[string]interface{}{
+			"CertificateString": string(certificate),
+			"CertificateType":   "PEM",
+			"CertificateUri":    map[string]string{"@odata.id": odimCertificateCollectionURI() + "/" + id},
+		})
+		return body
+	}
+
+	// a certificate which is not issued by the root CA is rejected
+	resp := e.ReplaceCertificate(&managersproto.ManagerRequest{RequestBody: replaceBody(newTestCA(t).certificatePEM(), rpcCertificateID)})
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "Status code should be StatusBadRequest.")
+	resp = e.ReplaceCertificate(&managersproto.ManagerRequest{RequestBody: replaceBody(signed, "3")})
+	assert.Equal(t, http.StatusNotFound, int(resp.StatusCode), "Status code should be StatusNotFound.")
+
+	resp = e.ReplaceCertificate(&managersproto.ManagerRequest{RequestBody: replaceBody(signed, rpcCertificateID)})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	assert.Equal(t, "odim.test.com", resp.Body.(dmtf.Certificate).Subject.CommonName)
+	stored := mockCertificateStore[common.RPCCertificateKey]
+	assert.Equal(t, signed, stored.Certificate)
+	assert.NotEqual(t, config.Data.KeyCertConf.RPCPrivateKey, stored.PrivateKey)
+	assert.Len(t, mockCertificateStore, 2, "only the private key of the installed CSR should be removed")
+
+	// the key of the installed certificate is neither in use nor from a pending CSR
+	resp = e.ReplaceCertificate(&managersproto.ManagerRequest{RequestBody: replaceBody(signed, gatewayCertificateID)})
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "Status code should be StatusBadRequest.")
+	assert.Equal(t, response.PropertyValueConflict, resp.StatusMessage)
+
+	resp = e.ReplaceCertificate(&managersproto.ManagerRequest{RequestBody: replaceBody(gatewaySigned, gatewayCertificateID)})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	assert.Equal(t, gatewaySigned, mockCertificateStore[common.APIGatewayCertificateKey].Certificate)
+	assert.Len(t, mockCertificateStore, 2, "the private keys of the CSRs should be removed once installed")
+}
+
+func TestCertificateActionsOnDevice(t *testing.T) {
+	config.SetUpMockConfig(t)
+	e := mockGetExternalInterface()
+	var deviceReq mgrcommon.ResourceInfoRequest
+	e.Device.DeviceRequest = func(req mgrcommon.ResourceInfoRequest) response.RPC {
+		deviceReq = req
+		resp := response.RPC{StatusCode: http.StatusOK, StatusMessage: response.Success}
+		if req.URL == generateCSRActionURI {
+			resp.Body = map[string]interface{}{
+				"CSRString":             "csr",
+				"CertificateCollection": map[string]string{"@odata.id": "/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates"},
+			}
+		}
+		return resp
+	}
+
+	resp := e.GenerateCSR(&managersproto.ManagerRequest{
+		URL:         generateCSRActionURI,
+		RequestBody: []byte(`{"CommonName":"bmc","CertificateCollection":{"@odata.id":"/redfish/v1/Managers/uuid.1/NetworkProtocol/HTTPS/Certificates"}}`),
+	})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	assert.Equal(t, "/redfish/v1/Managers/uuid.1/NetworkProtocol/HTTPS/Certificates", resp.Body.(dmtf.GenerateCSRResponse).CertificateCollection.Oid)
+	assert.Equal(t, "uuid", deviceReq.UUID)
+	assert.Equal(t, http.MethodPost, deviceReq.HTTPMethod)
+	assert.Contains(t, string(deviceReq.RequestBody), `"/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates"`)
+
+	resp = e.ReplaceCertificate(&managersproto.ManagerRequest{
+		URL: replaceCertActionURI,
+		RequestBody: []byte(`{"CertificateString":"cert","CertificateType":"PEM",
+			"CertificateUri":{"@odata.id":"/redfish/v1/Managers/uuid.1/NetworkProtocol/HTTPS/Certificates/1"}}`),
+	})
+	assert.Equal(t, http.StatusNoContent, int(resp.StatusCode), "Status code should be StatusNoContent.")
+	assert.Equal(t, replaceCertActionURI, deviceReq.URL)
+	assert.Contains(t, string(deviceReq.RequestBody), `"/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates/1"`)
+
+	resp = e.ReplaceCertificate(&managersproto.ManagerRequest{
+		URL:         replaceCertActionURI,
+		RequestBody: []byte(`{"CertificateString":"cert","CertificateType":"DER","CertificateUri":{"@odata.id":"/redfish/v1/Managers/uuid.1/NetworkProtocol/HTTPS/Certificates/1"}}`),
+	})
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "Status code should be StatusBadRequest.")
+}
@@ -45,6 +45,10 @@ type DB struct {
 	GetPluginData       func(string) (mgrmodel.Plugin, *errors.Error)
 	UpdateData          func(string, map[string]interface{}, string) error
 	GetResource         func(string, string) (string, *errors.Error)
+
+	GetStoredCertificate    func(string) (*common.StoredCertificate, *errors.Error)
+	SaveStoredCertificate   func(string, common.StoredCertificate) *errors.Error
+	DeleteStoredCertificate func(string) *errors.Error
 }
 
 // GetExternalInterface retrieves all the external connections managers package functions uses
@@ -62,6 +66,10 @@ func GetExternalInterface() *ExternalInterface {
 			GetPluginData:       mgrmodel.GetPluginData,
 			UpdateData:          mgrmodel.UpdateData,
 			GetResource:         mgrmodel.GetResource,
+
+			GetStoredCertificate:    common.GetStoredCertificate,
+			SaveStoredCertificate:   common.SaveStoredCertificate,
+			DeleteStoredCertificate: common.DeleteStoredCertificate,
 		},
 	}
 }
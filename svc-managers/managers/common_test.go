@@ -61,6 +61,10 @@ func mockGetExternalInterface() *ExternalInterface {
 			GetPluginData:       mockGetPluginData,
 			UpdateData:          mockUpdateData,
 			GetResource:         mockGetResource,
+
+			GetStoredCertificate:    mockGetStoredCertificate,
+			SaveStoredCertificate:   mockSaveStoredCertificate,
+			DeleteStoredCertificate: mockDeleteStoredCertificate,
 		},
 	}
 }
@@ -263,6 +263,9 @@ func (e *ExternalInterface) getManagerDetails(id string) (mgrmodel.Manager, erro
 // status code, status message, headers and body and the second value is error.
 func (e *ExternalInterface) GetManagersResource(req *managersproto.ManagerRequest) response.RPC {
 	var resp response.RPC
+	if isODIMCertificateResource(req.ManagerID, req.URL) {
+		return GetODIMCertificateResource(req.URL)
+	}
 	var tableName string
 	var resourceName string
 	var resource map[string]interface{}
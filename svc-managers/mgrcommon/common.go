@@ -148,6 +148,10 @@ func DeviceCommunication(req ResourceInfoRequest) response.RPC {
 	}
 	resp.StatusCode = http.StatusOK
 	resp.StatusMessage = response.Success
+	// actions may not return any content
+	if len(body) == 0 {
+		return resp
+	}
 	err = JSON_UnmarshalFunc(body, &resp.Body)
 	if err != nil {
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
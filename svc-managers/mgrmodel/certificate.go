@@ -0,0 +1,49 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package mgrmodel
+
+import (
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+)
+
+// GenerateCSR struct is to store the generate CSR request payload
+type GenerateCSR struct {
+	CertificateCollection *dmtf.Link `json:"CertificateCollection" validate:"required"`
+	CommonName            string     `json:"CommonName" validate:"required"`
+	AlternativeNames      []string   `json:"AlternativeNames,omitempty"`
+	ChallengePassword     string     `json:"ChallengePassword,omitempty"`
+	City                  string     `json:"City,omitempty"`
+	ContactPerson         string     `json:"ContactPerson,omitempty"`
+	Country               string     `json:"Country,omitempty"`
+	Email                 string     `json:"Email,omitempty"`
+	GivenName             string     `json:"GivenName,omitempty"`
+	Initials              string     `json:"Initials,omitempty"`
+	KeyBitLength          int        `json:"KeyBitLength,omitempty"`
+	KeyCurveID            string     `json:"KeyCurveId,omitempty"`
+	KeyPairAlgorithm      string     `json:"KeyPairAlgorithm,omitempty"`
+	KeyUsage              []string   `json:"KeyUsage,omitempty"`
+	Organization          string     `json:"Organization,omitempty"`
+	OrganizationalUnit    string     `json:"OrganizationalUnit,omitempty"`
+	State                 string     `json:"State,omitempty"`
+	Surname               string     `json:"Surname,omitempty"`
+	UnstructuredName      string     `json:"UnstructuredName,omitempty"`
+}
+
+// ReplaceCertificate struct is to store the replace certificate request payload
+type ReplaceCertificate struct {
+	CertificateString string     `json:"CertificateString" validate:"required"`
+	CertificateType   string     `json:"CertificateType" validate:"required"`
+	CertificateURI    *dmtf.Link `json:"CertificateUri" validate:"required"`
+}
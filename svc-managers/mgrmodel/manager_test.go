@@ -43,7 +43,7 @@ func TestGenericSave(t *testing.T) {
 	assert.Nil(t, err, "There should be no error")
 	assert.Equal(t, data, string(body), "should be same")
 
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return nil, &errors.Error{}
 	}
 	err = GenericSave(body, table, key)
@@ -63,7 +63,7 @@ func TestManager_Update(t *testing.T) {
 	body := []byte(`{"Status":{"State":"Enabled"}}`)
 	table := "Managers"
 	key := "xyz"
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return common.GetDBConnection(dbFlag)
 	}
 	err := GenericSave(body, table, key)
@@ -74,7 +74,7 @@ func TestManager_Update(t *testing.T) {
 			"State": "Absent",
 		},
 	}
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return common.GetDBConnection(dbFlag)
 	}
 	err = UpdateData(key, m, "Managers")
@@ -111,7 +111,7 @@ func TestGetResourceNegativeTestCases(t *testing.T) {
 	_, err = GetResource(table, key)
 	assert.NotNil(t, err, "There should be an error")
 
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return nil, &errors.Error{}
 	}
 	_, err = GetResource(table, key)
@@ -130,7 +130,7 @@ func TestGetAllkeysFromTable(t *testing.T) {
 	body := []byte(`body`)
 	table := "EthernetInterfaces"
 	key := "/redfish/v1/Managers/uuid.1/EthernetInterfaces/1"
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return common.GetDBConnection(dbFlag)
 	}
 	err := GenericSave(body, table, key)
@@ -139,7 +139,7 @@ func TestGetAllkeysFromTable(t *testing.T) {
 	allKeys, err := GetAllKeysFromTable(table)
 	assert.Nil(t, err, "There should be no error")
 	assert.Equal(t, len(allKeys), 1, "There should be one entry in DB")
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return nil, &errors.Error{}
 	}
 	_, err = GetAllKeysFromTable(table)
@@ -159,7 +159,7 @@ func TestGetManagerByURL(t *testing.T) {
 	body := []byte(`body`)
 	table := "Managers"
 	key := "/redfish/v1/Managers/uuid.1"
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return common.GetDBConnection(dbFlag)
 	}
 	err := GenericSave(body, table, key)
@@ -169,7 +169,7 @@ func TestGetManagerByURL(t *testing.T) {
 	assert.Nil(t, err, "There should be no error")
 	assert.Equal(t, data, string(body), "should be same")
 
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return nil, &errors.Error{}
 	}
 	_, err1 := GetManagerByURL(key)
@@ -210,7 +210,7 @@ func TestAddManagertoDB(t *testing.T) {
 		UUID:            "3bd1f589-117a-4cf9-89f2-da44ee8e012b",
 		State:           "Enabled",
 	}
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return common.GetDBConnection(dbFlag)
 	}
 	MarshalFunc = func(v interface{}) ([]byte, error) {
@@ -230,12 +230,12 @@ func TestAddManagertoDB(t *testing.T) {
 	assert.Equal(t, manager.ID, "3bd1f589-117a-4cf9-89f2-da44ee8e012b", "managerid should be 3bd1f589-117a-4cf9-89f2-da44ee8e012b")
 	assert.Equal(t, manager.UUID, "3bd1f589-117a-4cf9-89f2-da44ee8e012b", "uuid should be 3bd1f589-117a-4cf9-89f2-da44ee8e012b")
 	assert.Equal(t, manager.State, "Enabled", "state should be Enabled")
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return nil, &errors.Error{}
 	}
 	err = AddManagertoDB(mngr)
 	assert.NotNil(t, err, "unable to marshal data for updating: %v")
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return common.GetDBConnection(dbFlag)
 	}
 	MarshalFunc = func(v interface{}) ([]byte, error) {
@@ -265,7 +265,7 @@ func TestUpdateData(t *testing.T) {
 	err = UpdateData("test", m, "Managers")
 	assert.NotNil(t, err, "unable to marshal data for updating: %v")
 
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return nil, &errors.Error{}
 	}
 	err = UpdateData("test", m, "Managers")
@@ -274,7 +274,7 @@ func TestUpdateData(t *testing.T) {
 }
 func Test_UpdateData(t *testing.T) {
 
-	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return nil, nil
 	}
 	MarshalFunc = func(v interface{}) ([]byte, error) {
@@ -502,3 +502,44 @@ func TestUpdateRemoteAccountService(t *testing.T) {
 	resp, _ = mgr.UpdateRemoteAccountService(ctx, req)
 	assert.Equal(t, int(resp.StatusCode), http.StatusUnauthorized, "Status code should be StatusUnauthorized.")
 }
+
+func TestCertificateService(t *testing.T) {
+	config.SetUpMockConfig(t)
+	var ctx context.Context
+	mgr := new(Managers)
+	mgr.IsAuthorizedRPC = mockIsAuthorized
+	mgr.EI = mockGetExternalInterface()
+
+	req := &managersproto.ManagerRequest{
+		SessionToken: "validToken",
+		URL:          "/redfish/v1/CertificateService",
+	}
+	resp, err := mgr.GetCertificateService(ctx, req)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+
+	req.URL = "/redfish/v1/CertificateService/CertificateLocations"
+	resp, err = mgr.GetCertificateLocations(ctx, req)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+
+	req.URL = "/redfish/v1/CertificateService/Actions/CertificateService.GenerateCSR"
+	req.RequestBody = []byte(`{}`)
+	resp, err = mgr.GenerateCSR(ctx, req)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "Status code should be StatusBadRequest.")
+
+	req.URL = "/redfish/v1/CertificateService/Actions/CertificateService.ReplaceCertificate"
+	resp, err = mgr.ReplaceCertificate(ctx, req)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "Status code should be StatusBadRequest.")
+
+	// Invalid
+	req.SessionToken = "InvalidToken"
+	for _, rpcFunc := range []func(context.Context, *managersproto.ManagerRequest) (*managersproto.ManagerResponse, error){
+		mgr.GetCertificateService, mgr.GetCertificateLocations, mgr.GenerateCSR, mgr.ReplaceCertificate,
+	} {
+		resp, _ = rpcFunc(ctx, req)
+		assert.Equal(t, http.StatusUnauthorized, int(resp.StatusCode), "Status code should be StatusUnauthorized.")
+	}
+}
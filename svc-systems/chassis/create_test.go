@@ -100,7 +100,7 @@ func TestNewCreateHandler(t *testing.T) {
 	assert.NotNil(t, response, "Status code should be StatusBadRequest")
 
 	// Mocking Db Connection with error
-	GetDbConnectFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDbConnectFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return nil, &errors.Error{}
 	}
 	req = chassisproto.CreateChassisRequest{
@@ -120,7 +120,7 @@ func TestNewCreateHandler(t *testing.T) {
 	response = create.Handle(&req)
 	assert.NotNil(t, response, "Can not acquire database connection")
 
-	GetDbConnectFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+	GetDbConnectFunc = func(dbFlag common.DbType) (persistencemgr.DBInterface, *errors.Error) {
 		return common.GetDBConnection(dbFlag)
 	}
 	//Mocking GenericSave Func
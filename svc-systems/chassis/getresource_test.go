@@ -100,6 +100,17 @@ func TestPluginContact_GetChassisResource(t *testing.T) {
 		t.Fatalf("Error in creating mock resource data :%v", err2)
 	}
 
+	for table, uri := range map[string]string{
+		"PowerSuppliesCollection": "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/PowerSubsystem/PowerSupplies",
+		"PowerSupplies":           "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/PowerSubsystem/PowerSupplies/1",
+		"EnvironmentMetrics":      "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/EnvironmentMetrics",
+	} {
+		data, _ := json.Marshal(map[string]interface{}{"@odata.id": uri})
+		if err := mockChassisResourceData(data, table, uri); err != nil {
+			t.Fatalf("Error in creating mock resource data :%v", err)
+		}
+	}
+
 	errArgs := response.Args{
 		Code:    response.GeneralError,
 		Message: "",
@@ -218,6 +229,55 @@ func TestPluginContact_GetChassisResource(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "successful get PowerSupplies collection",
+			p:    &pluginContact,
+			args: args{
+				req: &chassisproto.GetChassisRequest{
+					RequestParam: "6d4a0a66-7efa-578e-83cf-44dc68d2874e.1",
+					URL:          "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/PowerSubsystem/PowerSupplies",
+				},
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusOK,
+				StatusMessage: response.Success,
+				Body:          map[string]interface{}{"@odata.id": "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/PowerSubsystem/PowerSupplies"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "successful get PowerSupply",
+			p:    &pluginContact,
+			args: args{
+				req: &chassisproto.GetChassisRequest{
+					RequestParam: "6d4a0a66-7efa-578e-83cf-44dc68d2874e.1",
+					URL:          "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/PowerSubsystem/PowerSupplies/1",
+					ResourceID:   "1",
+				},
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusOK,
+				StatusMessage: response.Success,
+				Body:          map[string]interface{}{"@odata.id": "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/PowerSubsystem/PowerSupplies/1"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "successful get EnvironmentMetrics",
+			p:    &pluginContact,
+			args: args{
+				req: &chassisproto.GetChassisRequest{
+					RequestParam: "6d4a0a66-7efa-578e-83cf-44dc68d2874e.1",
+					URL:          "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/EnvironmentMetrics",
+				},
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusOK,
+				StatusMessage: response.Success,
+				Body:          map[string]interface{}{"@odata.id": "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/EnvironmentMetrics"},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
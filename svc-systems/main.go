@@ -23,6 +23,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	chassisproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/chassis"
 	systemsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/systems"
+	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-systems/chassis"
 	"github.com/ODIM-Project/ODIM/svc-systems/plugin"
@@ -90,6 +91,7 @@ func registerHandler() {
 
 	systemRPC.EI = systems.GetExternalInterface()
 	systemsproto.RegisterSystemsServer(services.ODIMService.Server(), systemRPC)
+	taskproto.RegisterTaskCancelServer(services.ODIMService.Server(), new(services.TaskCanceller))
 
 	pcf := plugin.NewClientFactory(config.Data.URLTranslation)
 	chassisRPC := rpc.NewChassisRPC(
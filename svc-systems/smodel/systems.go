@@ -25,7 +25,6 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 
-	"github.com/gomodule/redigo/redis"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -151,45 +150,23 @@ func FindAll(table, key string) ([][]byte, error) {
 		return nil, err
 	}
 
-	if len(affectedKeys) == 0 {
-		return [][]byte{}, nil
+	data, readErr := cp.ReadMultipleKeys(affectedKeys)
+	if readErr != nil {
+		return nil, readErr
 	}
-
-	conn := cp.ReadPool.Get()
-	defer conn.Close()
-
-	return redis.ByteSlices(conn.Do("MGET", affectedKeys...))
+	resources := make([][]byte, 0, len(data))
+	for _, resource := range data {
+		resources = append(resources, []byte(resource))
+	}
+	return resources, nil
 }
 
-func scan(cp *persistencemgr.ConnPool, key string) ([]interface{}, error) {
-	conn := cp.ReadPool.Get()
-	defer conn.Close()
-
-	var (
-		cursor int64
-		items  []interface{}
-	)
-
-	results := make([]interface{}, 0)
-
-	for {
-		values, err := redis.Values(conn.Do("SCAN", cursor, "MATCH", key))
-		if err != nil {
-			return nil, err
-		}
-
-		_, err = redis.Scan(values, &cursor, &items)
-		if err != nil {
-			return nil, err
-		}
-		results = append(results, items...)
-
-		if cursor == 0 {
-			break
-		}
+func scan(cp persistencemgr.DBInterface, key string) ([]string, error) {
+	keys, err := cp.ScanKeys(key)
+	if err != nil {
+		return nil, err
 	}
-
-	return results, nil
+	return keys, nil
 }
 
 //GetAllKeysFromTable fetches all keys in a given table
@@ -16,6 +16,7 @@
 package systems
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -63,6 +64,7 @@ type ResetComputerSystem struct {
 
 // monitorTaskRequest hold values required monitorTask function
 type monitorTaskRequest struct {
+	ctx           context.Context
 	taskID        string
 	respBody      []byte
 	serverURI     string
@@ -94,6 +96,9 @@ func UpdateTaskData(taskData common.TaskData) error {
 
 	err := services.UpdateTask(taskData.TaskID, taskData.TaskState, taskData.TaskStatus, taskData.PercentComplete, payLoad, time.Now())
 	if err != nil && (err.Error() == common.Cancelling) {
+		if common.CancelTaskContext(taskData.TaskID) {
+			return err
+		}
 		// We cant do anything here as the task has done it work completely, we cant reverse it.
 		//Unless if we can do opposite/reverse action for delete server which is add server.
 		services.UpdateTask(taskData.TaskID, common.Cancelled, taskData.TaskStatus, taskData.PercentComplete, payLoad, time.Now())
@@ -131,14 +136,24 @@ func (e *PluginContact) monitorPluginTask(monitorTaskData *monitorTaskRequest) (
 		var updatetask = fillTaskData(monitorTaskData.taskID, monitorTaskData.serverURI, monitorTaskData.requestBody, monitorTaskData.resp, task.TaskState, task.TaskStatus, task.PercentComplete, http.MethodPost)
 		err := e.UpdateTask(updatetask)
 		if err != nil && err.Error() == common.Cancelling {
-			var updatetask = fillTaskData(monitorTaskData.taskID, monitorTaskData.serverURI, monitorTaskData.requestBody, monitorTaskData.resp, common.Cancelled, common.Critical, 100, http.MethodPost)
-			e.UpdateTask(updatetask)
-			return monitorTaskData.respBody, err
+			return monitorTaskData.respBody, e.abortPluginTask(monitorTaskData)
+		}
+		select {
+		case <-monitorTaskData.ctx.Done():
+			return monitorTaskData.respBody, e.abortPluginTask(monitorTaskData)
+		case <-time.After(time.Second * 5):
 		}
-		time.Sleep(time.Second * 5)
-		monitorTaskData.pluginRequest.OID = monitorTaskData.location
-		monitorTaskData.pluginRequest.HTTPMethodType = http.MethodGet
-		monitorTaskData.respBody, _, monitorTaskData.getResponse, err = ContactPluginFunc(monitorTaskData.pluginRequest, "error while reseting the computer system: ")
+		pluginRequest := monitorTaskData.pluginRequest
+		pluginRequest.OID = monitorTaskData.location
+		pluginRequest.HTTPMethodType = http.MethodGet
+		var respBody []byte
+		var getResponse scommon.ResponseStatus
+		if common.CallWithTaskCancel(monitorTaskData.ctx, func() {
+			respBody, _, getResponse, err = ContactPluginFunc(pluginRequest, "error while reseting the computer system: ")
+		}) != nil {
+			return monitorTaskData.respBody, e.abortPluginTask(monitorTaskData)
+		}
+		monitorTaskData.respBody, monitorTaskData.getResponse = respBody, getResponse
 		if err != nil {
 			errMsg := err.Error()
 			log.Warn(errMsg)
@@ -151,3 +166,19 @@ func (e *PluginContact) monitorPluginTask(monitorTaskData *monitorTaskRequest) (
 	}
 	return monitorTaskData.respBody, nil
 }
+
+// abortPluginTask aborts the reset job started on the plugin for the cancelled task
+func (e *PluginContact) abortPluginTask(monitorTaskData *monitorTaskRequest) error {
+	return common.AbortPluginTask(monitorTaskData.taskID, monitorTaskData.location, monitorTaskData.taskInfo,
+		func() (int32, error) {
+			pluginRequest := monitorTaskData.pluginRequest
+			pluginRequest.OID = monitorTaskData.location
+			pluginRequest.HTTPMethodType = http.MethodDelete
+			_, _, getResponse, err := ContactPluginFunc(pluginRequest, "error while aborting the reset task on the plugin: ")
+			return getResponse.StatusCode, err
+		},
+		func() {
+			var task = fillTaskData(monitorTaskData.taskID, monitorTaskData.serverURI, monitorTaskData.requestBody, monitorTaskData.resp, common.Cancelled, common.Warning, 100, http.MethodPost)
+			e.UpdateTask(task)
+		})
+}
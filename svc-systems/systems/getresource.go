@@ -17,7 +17,6 @@ package systems
 
 import (
 	"context"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -52,6 +51,8 @@ var (
 	GetDeviceLoadInfoFunc = getDeviceLoadInfo
 	// GetStringFunc function pointer for the smodel.GetString
 	GetStringFunc = smodel.GetString
+	// GetResourceFunc function pointer for the smodel.GetResource
+	GetResourceFunc = smodel.GetResource
 )
 
 func setRegexFlag(val string) bool {
@@ -64,192 +65,124 @@ func setRegexFlag(val string) bool {
 	return false
 }
 
-func errorResp(each string, resp response.RPC) (response.RPC, error) {
-	if each == "" {
-		errorMessage := " not a valid search/filter expression"
-		return common.GeneralError(http.StatusBadRequest, response.QueryCombinationInvalid, errorMessage, []interface{}{"ComputerSystem", ""}, nil), fmt.Errorf(errorMessage)
+//GetMembers will fetch the resource members which satisfy the comparison in the filter expression
+func GetMembers(filter *common.FilterExpression, resp response.RPC) ([]dmtf.Link, response.RPC, error) {
+	var searchKey map[string]string
+	for _, value := range scommon.SF.SearchKeys {
+		if v, ok := value[filter.Property]; ok {
+			searchKey = v
+			break
+		}
 	}
-	return resp, nil
-}
-
-func ifMatches(strPara string, operator string, resp response.RPC) (response.RPC, error) {
-	strSplit := strings.Split(strPara, operator)
-	for _, e := range strSplit {
-
-		resp, err := errorResp(e, resp)
-		if err != nil {
-			log.Error(err.Error())
-			return resp, err
+	// the systems are filtered with the indexes, which are maintained only for the search keys
+	if searchKey == nil {
+		errorMessage := "error: filtering the systems on " + filter.Property + " is not supported"
+		return nil, common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, errorMessage, nil, nil), fmt.Errorf(errorMessage)
+	}
+	var conditionAllowed bool
+	for _, value := range scommon.SF.ConditionKeys {
+		if value == filter.Operator {
+			conditionAllowed = true
+			break
 		}
 	}
-	return resp, nil
-}
+	if !conditionAllowed {
+		errorMessage := "error: operator " + filter.Operator + " is not supported for " + filter.Property
+		return nil, common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, errorMessage, nil, nil), fmt.Errorf(errorMessage)
+	}
+	typeFlag := searchKey["type"] != "string" && searchKey["type"] != "[]string"
+	arrayFlag := searchKey["type"] == "[]float64" || searchKey["type"] == "[]int"
+	key := filter.Property
+	val := filter.Literal()
 
-func validate(strPara string, resp response.RPC) (response.RPC, error) {
-	if strings.Contains(strPara, "and") {
-		resp, err := ifMatches(strPara, "and", resp)
+	var list []string
+	var err error
+	if setRegexFlag(val) {
+		// regular expression flag is true then get all data for key depending on the type
+		if arrayFlag {
+			list, err = smodel.GetStorageList(key, "ne", 0, true)
+		} else if !typeFlag {
+			list, err = getStringData(key, "", "eq", true)
+		} else {
+			list, err = getRangeData(key, "ge", 0, true)
+		}
 		if err != nil {
-			log.Error(err.Error())
-			return resp, err
+			return nil, common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, err.Error(), []interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil), err
 		}
-	}
-	if strings.Contains(strPara, "or") {
-		resp, err := ifMatches(strPara, "or", resp)
+		// parse the data with the regex
+		if list, err = parseRegexData(list, val); err != nil {
+			errorMessage := " not a valid search/filter expression"
+			return nil, common.GeneralError(http.StatusBadRequest, response.QueryCombinationInvalid, errorMessage, []interface{}{"ComputerSystem", ""}, nil), fmt.Errorf(errorMessage)
+		}
+	} else if arrayFlag {
+		searchValue, err := strconv.ParseFloat(val, 64)
 		if err != nil {
-			log.Error(err.Error())
-			return resp, err
+			return nil, common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{key, "Invalida value"}, nil), err
 		}
-	}
-	return resp, nil
-}
-
-// validateLastParameter checks whether last parameter in the expression
-// is an operator or not. It throughs an error if the last parameter is operator.
-// It also checks whether the expression is empty eg: /redfish/v1/Systems?$filter=%20
-func validateLastParameter(expression []string) error {
-	var lastParam string
-	for i := len(expression) - 1; i >= 0; i-- {
-		if expression[i] != "" {
-			lastParam = expression[i]
-			break
+		if list, err = smodel.GetStorageList(key, filter.Operator, searchValue, false); err != nil {
+			return nil, common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, err.Error(), []interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil), err
 		}
-	}
-	if lastParam == "" {
-		return fmt.Errorf("no valid expression found")
-	}
-	operatorSet := []string{"eq", "ne", "gt", "ge", "lt", "le"}
-	for _, op := range operatorSet {
-		if lastParam == op {
-			return fmt.Errorf("expression ends with an operator %v", op)
+	} else if !typeFlag {
+		// rejecting the request if expression is not eq or ne for type string
+		if !(filter.Operator == "eq" || filter.Operator == "ne") {
+			return nil, common.GeneralError(http.StatusBadRequest, response.QueryCombinationInvalid, "error:invalid expression", []interface{}{filter.Operator, "Invalid Expression for " + key}, nil), fmt.Errorf("error:invalid expression")
+		}
+		if list, err = getStringData(key, val, filter.Operator, false); err != nil {
+			return nil, common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, err.Error(), []interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil), err
+		}
+	} else {
+		//validate the value
+		searchValue, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{key, "Invalida value"}, nil), err
+		}
+		if list, err = getRangeData(key, filter.Operator, searchValue, false); err != nil {
+			return nil, common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, err.Error(), []interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil), err
 		}
 	}
-	return nil
-}
-
-//GetMembers will fetch the resource members based on the filter expression
-func GetMembers(allowed map[string]map[string]bool, expression []string, resp response.RPC) ([]dmtf.Link, response.RPC, error) {
-	err := validateLastParameter(expression)
-	if err != nil {
-		return nil, common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, err.Error(), nil, nil), err
+	members := []dmtf.Link{}
+	for _, oid := range list {
+		members = append(members, dmtf.Link{Oid: oid})
 	}
+	return members, resp, nil
+}
 
-	allowed["searchKeys"] = make(map[string]bool)
-	allowed["conditionKeys"] = make(map[string]bool)
-	for _, value := range scommon.SF.SearchKeys {
-		for k := range value {
-			allowed["searchKeys"][k] = true
+// filterMembers walks the parsed filter expression and fetches the members
+// for each comparison, which are then combined with the logical operators
+func filterMembers(filter *common.FilterExpression, resp response.RPC) ([]dmtf.Link, response.RPC, error) {
+	switch filter.Operator {
+	case "and", "or":
+		left, resp, err := filterMembers(filter.Left, resp)
+		if err != nil {
+			return nil, resp, err
 		}
-	}
-	for _, value := range scommon.SF.ConditionKeys {
-		allowed["conditionKeys"][value] = true
-	}
-	var members []dmtf.Link
-	var regexFlag, typeFlag, arrayFlag bool
-	for amp, pam := range expression {
-		for _, value := range scommon.SF.SearchKeys {
-			for k, v := range value {
-				if k == pam {
-					if v["type"] != "string" && v["type"] != "[]string" {
-						typeFlag = true
-					}
-					if v["type"] == "[]float64" || v["type"] == "[]int" {
-						arrayFlag = true
-					}
-				}
-			}
+		right, resp, err := filterMembers(filter.Right, resp)
+		if err != nil {
+			return nil, resp, err
 		}
-		if allowed["searchKeys"][pam] {
-			key := strings.Replace(pam, "/", "\\/", -1)
-			new := amp + 1
-			if new < len(expression) {
-				var val, regex string
-				if allowed["conditionKeys"][expression[new]] {
-					val = expression[new+1]
-					regexFlag = setRegexFlag(val)
-					key = strings.Replace(pam, "\\/", "/", -1)
-					if regexFlag {
-						regex = val
-						// regular expression flag is true then get all data for key depending on the type
-						var list []string
-						var err error
-						if arrayFlag {
-							list, err = smodel.GetStorageList(key, "ne", 0, true)
-							if err != nil {
-								return nil, common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, err.Error(), []interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil), err
-							}
-						} else {
-							if !typeFlag {
-								list, err = getStringData(key, "", "eq", true)
-								if err != nil {
-									return nil, common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, err.Error(), []interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil), err
-								}
-							} else {
-								list, err = getRangeData(key, "ge", 0, true)
-								if err != nil {
-									return nil, common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, err.Error(), []interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil), err
-								}
-							}
-						}
-						// parse the data with the regex
-						parsedList, err := parseRegexData(list, regex)
-						if err != nil {
-							errorMessage := " not a valid search/filter expression"
-							return nil, common.GeneralError(http.StatusBadRequest, response.QueryCombinationInvalid, errorMessage, []interface{}{"ComputerSystem", ""}, nil), fmt.Errorf(errorMessage)
-						}
-						for i := 0; i < len(parsedList); i++ {
-							members = append(members, dmtf.Link{Oid: parsedList[i]})
-						}
-					} else if arrayFlag {
-						searchValue, err := strconv.ParseFloat(val, 64)
-						if err != nil {
-							return nil, common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{key, "Invalida value"}, nil), err
-						}
-						list, err := smodel.GetStorageList(key, expression[new], searchValue, false)
-						if err != nil {
-							return nil, common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, err.Error(), []interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil), err
-
-						}
-						for i := 0; i < len(list); i++ {
-							members = append(members, dmtf.Link{Oid: list[i]})
-						}
-					} else {
-						// to get the members for type string
-						if !typeFlag {
-							// rejecting the request if expression is not eq or ne
-							if !(expression[new] == "eq" || expression[new] == "ne") {
-								return nil, common.GeneralError(http.StatusBadRequest, response.QueryCombinationInvalid, "error:invalid expression", []interface{}{expression[new], "Invalid Expression for " + key}, nil), fmt.Errorf("error:invalid expression")
-							}
-							list, err := getStringData(key, val, expression[new], false)
-							if err != nil {
-								return nil, common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, err.Error(), []interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil), err
-							}
-							for i := 0; i < len(list); i++ {
-								members = append(members, dmtf.Link{Oid: list[i]})
-							}
-						} else {
-							//validate the value
-							searchValue, err := strconv.Atoi(val)
-							if err != nil {
-								return nil, common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{key, "Invalida value"}, nil), err
-							}
-							list, err := getRangeData(key, expression[new], searchValue, false)
-							if err != nil {
-								return nil, common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, err.Error(), []interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil), err
-							}
-							for i := 0; i < len(list); i++ {
-								members = append(members, dmtf.Link{Oid: list[i]})
-							}
-						}
-
-					}
-
-				}
-
+		return LogicalOperation([][]dmtf.Link{left, right}, filter.Operator), resp, nil
+	case "not":
+		all, resp, err := getAllSystemIDs(resp)
+		if err != nil {
+			return nil, resp, err
+		}
+		excluded, resp, err := filterMembers(filter.Left, resp)
+		if err != nil {
+			return nil, resp, err
+		}
+		exclude := make(map[dmtf.Link]bool, len(excluded))
+		for _, member := range excluded {
+			exclude[member] = true
+		}
+		members := []dmtf.Link{}
+		for _, member := range all {
+			if !exclude[member] {
+				members = append(members, member)
 			}
-
 		}
+		return members, resp, nil
 	}
-	return members, resp, nil
+	return GetMembers(filter, resp)
 }
 
 //getAllSystemIDs will fetch all the document ID's present in the DB
@@ -310,226 +243,33 @@ func LogicalOperation(interm [][]dmtf.Link, lo string) []dmtf.Link {
 	return respMembers
 }
 
-//SearchAndFilter take the url as input and return the search result based on the filter expression
+//SearchAndFilter take the url as input and return the search result based on the query.
+//The systems are filtered with $filter using the indexes, and the result is paged and expanded
+//with $top, $skip, $select and $expand using the systems in the db.
 func SearchAndFilter(paramStr []string, resp response.RPC) (response.RPC, error) {
-	allowed := make(map[string]map[string]bool)
-	allowed["queryKeys"] = make(map[string]bool)
-	allowed["logicalOperators"] = make(map[string]bool)
-
-	for _, value := range scommon.SF.QueryKeys {
-		value = "$" + value
-		allowed["queryKeys"][value] = true
-	}
-	query := strings.Split(paramStr[1], "=")
-	if len(query) < 2 {
-		errorMessage := " not a valid search/filter expression"
-		return common.GeneralError(http.StatusBadRequest, response.QueryCombinationInvalid, errorMessage, []interface{}{"ComputerSystem", ""}, nil), fmt.Errorf(errorMessage)
-	}
-	if !allowed["queryKeys"][query[0]] {
-		errorMessage := " not a valid search/filter expression"
-		return common.GeneralError(http.StatusBadRequest, response.QueryCombinationInvalid, errorMessage, []interface{}{"ComputerSystem", ""}, nil), fmt.Errorf(errorMessage)
-	}
-	percentSpl := strings.Split(query[1], "%")
-	var strPara string
-	if len(percentSpl) > 1 {
-		for i, j := range percentSpl {
-			if i == 0 {
-				strPara += j
-			} else {
-				bl, _ := hex.DecodeString(j[:2])
-				strung := string(bl)
-				strPara += strung
-				strPara += j[2:]
-			}
-		}
-	} else {
-		strPara = paramStr[1]
+	query, queryErr := common.ParseODataQuery(paramStr[1])
+	if queryErr != nil {
+		log.Error(queryErr.Error())
+		return queryErr.Response(), queryErr
 	}
 	var respMembers []dmtf.Link
 	var err error
-
-	if checkParentheses(strPara) {
-		if strings.Count(strPara, "(") != strings.Count(strPara, ")") {
-			errorMessage := " not a valid search/filter expression"
-			return common.GeneralError(http.StatusBadRequest, response.QueryCombinationInvalid, errorMessage, []interface{}{"ComputerSystem", ""}, nil), fmt.Errorf(errorMessage)
-		}
-
-		var sa []dmtf.Link
-		re := regexp.MustCompile(`\((.*?)\)`)
-		submatchall := re.FindAllString(strPara, -1)
-		for _, element := range submatchall {
-			element = strings.Trim(element, "(")
-			element = strings.Trim(element, ")")
-			fmt.Println(element)
-			if strings.Contains(element, " and ") || strings.Contains(element, " or ") {
-				var x []string
-				if strings.Contains(element, " and ") {
-					x = strings.Split(element, " and ")
-				} else {
-					x = strings.Split(element, " or ")
-				}
-				for _, each := range x {
-					sa, resp, err = GetMembers(allowed, strings.Split(each, " "), resp)
-					if err != nil {
-						return resp, err
-					}
-					s, err := json.Marshal(sa)
-					if err != nil {
-						errorMessage := " error while marshalling database data"
-						return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil), fmt.Errorf(errorMessage)
-					}
-					strPara = strings.Replace(strPara, each, string(s), -1)
-				}
-			} else {
-				sa, resp, err = GetMembers(allowed, strings.Split(element, " "), resp)
-				if err != nil {
-					return resp, err
-				}
-				s, err := json.Marshal(sa)
-				if err != nil {
-					errorMessage := " error while marshalling database data"
-					return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil), fmt.Errorf(errorMessage)
-				}
-				strPara = strings.Replace(strPara, element, string(s), -1)
+	if query.Filter != nil {
+		var filterAllowed bool
+		for _, value := range scommon.SF.QueryKeys {
+			if "$"+value == common.QueryFilter {
+				filterAllowed = true
 			}
 		}
-		expressionVals := re.FindAllString(strPara, -1)
-		for _, eles := range expressionVals {
-			var ww []dmtf.Link
-			ele := strings.Trim(eles, "(")
-			ele = strings.Trim(ele, ")")
-			if strings.Contains(ele, " and ") {
-				var inter [][]dmtf.Link
-				for _, e := range strings.Split(ele, " and ") {
-					_ = json.Unmarshal([]byte(e), &ww)
-					inter = append(inter, ww)
-				}
-				interResp := LogicalOperation(inter, "and")
-				interSam, _ := json.Marshal(interResp)
-				strPara = strings.Replace(strPara, eles, string(interSam), 1)
-			} else if strings.Contains(ele, " or ") {
-				var inter [][]dmtf.Link
-				for _, e := range strings.Split(ele, " or ") {
-					_ = json.Unmarshal([]byte(e), &ww)
-					inter = append(inter, ww)
-				}
-				interResp := LogicalOperation(inter, "or")
-				interSam, _ := json.Marshal(interResp)
-				strPara = strings.Replace(strPara, eles, string(interSam), 1)
-			} else {
-				strPara = strings.Replace(strPara, eles, ele, 1)
-			}
-		}
-
-		if strings.Contains(strPara, "and ") || strings.Contains(strPara, " and") || strings.Contains(strPara, "or ") || strings.Contains(strPara, " or") {
-			var ww []dmtf.Link
-			var inter [][]dmtf.Link
-			resp, err = validate(strPara, resp)
-			if err != nil {
-				log.Error(err.Error())
-				return resp, err
-			}
-			if strings.Contains(strPara, " and ") {
-				for _, e := range strings.Split(strPara, " and ") {
-					resp, err = errorResp(e, resp)
-					if err != nil {
-						log.Error(err.Error())
-						return resp, err
-					}
-					_ = json.Unmarshal([]byte(e), &ww)
-					inter = append(inter, ww)
-				}
-				respMembers = LogicalOperation(inter, "and")
-			}
-			if strings.Contains(strPara, " or ") {
-				for _, e := range strings.Split(strPara, " or ") {
-					resp, err = errorResp(e, resp)
-					if err != nil {
-						log.Error(err.Error())
-						return resp, err
-					}
-					_ = json.Unmarshal([]byte(e), &ww)
-					inter = append(inter, ww)
-				}
-				respMembers = LogicalOperation(inter, "or")
-
-			}
-		}
-		if !strings.Contains(strPara, " and ") && !strings.Contains(strPara, " or ") && !strings.Contains(strPara, " not ") {
-			var mems []dmtf.Link
-			var mem dmtf.Link
-			para := strings.Trim(strPara, "[")
-			para = strings.Trim(para, "]")
-			for _, e := range strings.Split(para, ",") {
-				_ = json.Unmarshal([]byte(e), &mem)
-				mems = append(mems, mem)
-			}
-			respMembers = mems
-		}
-
-	} else if strings.Contains(strPara, "and ") || strings.Contains(strPara, " and") || strings.Contains(strPara, "or ") || strings.Contains(strPara, " or") {
-		var ww []dmtf.Link
-		var inter [][]dmtf.Link
-		resp, err = validate(strPara, resp)
-		if err != nil {
-			log.Error(err.Error())
-			return resp, err
-		}
-		if strings.Contains(strPara, " and ") {
-			for _, each := range strings.Split(strPara, " and ") {
-				resp, err = errorResp(each, resp)
-				if err != nil {
-					log.Error(err.Error())
-					return resp, err
-				}
-				ww, resp, err = GetMembers(allowed, strings.Split(each, " "), resp)
-				if err != nil {
-					return resp, err
-				}
-				inter = append(inter, ww)
-			}
-			respMembers = LogicalOperation(inter, "and")
-		}
-		if strings.Contains(strPara, " or ") {
-			for _, each := range strings.Split(strPara, " or ") {
-				resp, err = errorResp(each, resp)
-				if err != nil {
-					log.Error(err.Error())
-					return resp, err
-				}
-				ww, resp, err = GetMembers(allowed, strings.Split(each, " "), resp)
-				if err != nil {
-					return resp, err
-				}
-				inter = append(inter, ww)
-			}
-			respMembers = LogicalOperation(inter, "or")
-
-		}
-	} else if strings.Contains(strPara, " not ") || strings.Contains(strPara, "not ") {
-		var all, exp []dmtf.Link
-		all, resp, err = getAllSystemIDs(resp)
-		if err != nil {
-			return resp, err
-		}
-		expression := strings.Split(strPara, "not ")
-		exp, resp, err = GetMembers(allowed, strings.Split(expression[1], " "), resp)
-		if err != nil {
-			return resp, err
-		}
-		for _, eachA := range exp {
-			for j, eachB := range all {
-				if eachA == eachB {
-					all = append(all[:j], all[j+1:]...)
-				}
-			}
+		if !filterAllowed {
+			errorMessage := " not a valid search/filter expression"
+			return common.GeneralError(http.StatusBadRequest, response.QueryCombinationInvalid, errorMessage, []interface{}{"ComputerSystem", ""}, nil), fmt.Errorf(errorMessage)
 		}
-		respMembers = all
-	} else {
-		respMembers, resp, err = GetMembers(allowed, strings.Split(strPara, " "), resp)
-		if err != nil {
+		if respMembers, resp, err = filterMembers(query.Filter, resp); err != nil {
 			return resp, err
 		}
+	} else if respMembers, resp, err = getAllSystemIDs(resp); err != nil {
+		return resp, err
 	}
 	systemCollection := sresponse.Collection{
 		OdataContext: "/redfish/v1/$metadata#ComputerSystemCollection.ComputerSystemCollection",
@@ -543,9 +283,38 @@ func SearchAndFilter(paramStr []string, resp response.RPC) (response.RPC, error)
 	}
 	systemCollection.Members = respMembers
 	systemCollection.MembersCount = len(respMembers)
-	resp.Body = systemCollection
 	resp.StatusCode = http.StatusOK
 	resp.StatusMessage = response.Success
+	resp.Body = systemCollection
+
+	// the filter is already applied with the indexes
+	query.Filter = nil
+	if query.IsEmpty() {
+		return resp, nil
+	}
+	var collection map[string]interface{}
+	data, _ := json.Marshal(systemCollection)
+	json.Unmarshal(data, &collection)
+	err = query.ApplyToCollection(collection, func(uri string) (map[string]interface{}, error) {
+		data, err := GetResourceFunc("ComputerSystem", uri)
+		if err != nil {
+			if err.ErrNo() == errors.DBKeyNotFound {
+				return nil, nil
+			}
+			return nil, err
+		}
+		var system map[string]interface{}
+		if err := json.Unmarshal([]byte(data), &system); err != nil {
+			return nil, err
+		}
+		return system, nil
+	})
+	if err != nil {
+		errorMessage := "error while trying to apply the query on the systems: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil), err
+	}
+	resp.Body = collection
 	return resp, nil
 }
 
@@ -917,15 +686,3 @@ func parseRegexData(data []string, regex string) ([]string, error) {
 	}
 	return list, nil
 }
-
-// this function checks query has open bracket["("] as prefix to ignore the brackets inside the string
-// for e.g if query is ProcessorSummary/Model eq Intel(R) Xeon(R) Gold 6152 CPU @ 2.10GHz
-// here Inter(R) has bracket inbetween the string, so ignore this string for the first if search criteria
-func checkParentheses(strPara string) bool {
-	for _, val := range strings.Split(strPara, " ") {
-		if strings.HasPrefix(val, "(") {
-			return true
-		}
-	}
-	return false
-}
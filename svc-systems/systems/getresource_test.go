@@ -1057,16 +1057,69 @@ func Test_getRangeData(t *testing.T) {
 }
 
 func Test_rediscoverStorageInventory(t *testing.T) {
-	errorResp("", response.RPC{})
-	validate("and", response.RPC{})
-	validate("or", response.RPC{})
-	validateLastParameter([]string{})
-	GetMembers(map[string]map[string]bool{}, []string{}, response.RPC{})
-
 	SearchAndFilter([]string{"", "dummy"}, response.RPC{})
 	SearchAndFilter([]string{"", "dummy=0"}, response.RPC{})
 }
 
+func TestSearchAndFilterInvalidQuery(t *testing.T) {
+	scommon.SF.QueryKeys = []string{"filter"}
+	for _, query := range []string{
+		"$filter=%20",
+		"$filter=ProcessorSummary/Count%20eq",
+		"$filter=(ProcessorSummary/Count%20eq%202",
+		"$filter=ProcessorSummary/Count%20eq%202%20and",
+		"$filter=ProcessorSummary/Model%20eq%20'Intel",
+		"$top=a",
+	} {
+		resp, err := SearchAndFilter([]string{"/redfish/v1/Systems", query}, response.RPC{})
+		assert.NotNil(t, err, "error is expected for the query "+query)
+		assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "status code should be StatusBadRequest for the query "+query)
+	}
+
+	// only the indexed properties can be filtered
+	resp, err := SearchAndFilter([]string{"/redfish/v1/Systems", "$filter=PowerState%20eq%20On"}, response.RPC{})
+	assert.NotNil(t, err, "error is expected for a property which is not indexed")
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "status code should be StatusBadRequest")
+	assert.Equal(t, response.QueryNotSupported, resp.StatusMessage)
+}
+
+func TestSearchAndFilterPaging(t *testing.T) {
+	defer func() {
+		GetAllKeysFromTableFunc = smodel.GetAllKeysFromTable
+		GetResourceFunc = smodel.GetResource
+	}()
+	systems := map[string]string{
+		"/redfish/v1/Systems/uuid.1": `{"@odata.id":"/redfish/v1/Systems/uuid.1","Id":"1","PowerState":"On","Status":{"Health":"OK","State":"Enabled"}}`,
+		"/redfish/v1/Systems/uuid.2": `{"@odata.id":"/redfish/v1/Systems/uuid.2","Id":"2","PowerState":"Off","Status":{"Health":"Warning","State":"Enabled"}}`,
+	}
+	GetAllKeysFromTableFunc = func(table string) ([]string, error) {
+		return []string{"/redfish/v1/Systems/uuid.1", "/redfish/v1/Systems/uuid.2"}, nil
+	}
+	var fetched []string
+	GetResourceFunc = func(table, key string) (string, *errors.Error) {
+		fetched = append(fetched, key)
+		return systems[key], nil
+	}
+
+	resp, err := SearchAndFilter([]string{"/redfish/v1/Systems", "$select=PowerState,Status/Health&$top=1&$skip=1"}, response.RPC{})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "status code should be StatusOK")
+	collection := resp.Body.(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"@odata.id":  "/redfish/v1/Systems/uuid.2",
+		"PowerState": "Off",
+		"Status":     map[string]interface{}{"Health": "Warning"},
+	}}, collection["Members"])
+	assert.Equal(t, 2, collection["Members@odata.count"])
+	assert.Equal(t, []string{"/redfish/v1/Systems/uuid.2"}, fetched, "only the system of the page should be read")
+
+	resp, err = SearchAndFilter([]string{"/redfish/v1/Systems", "$expand=.&$top=1"}, response.RPC{})
+	assert.Nil(t, err)
+	collection = resp.Body.(map[string]interface{})
+	assert.Equal(t, "1", collection["Members"].([]interface{})[0].(map[string]interface{})["Id"])
+	assert.Equal(t, "/redfish/v1/Systems?$expand=.&$top=1&$skip=1", collection["Members@odata.nextLink"])
+}
+
 func Test_getAllSystemIDs(t *testing.T) {
 	GetAllKeysFromTableFunc = func(table string) ([]string, error) {
 		return nil, &errors.Error{}
@@ -16,6 +16,7 @@
 package systems
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
@@ -160,7 +161,10 @@ func (p *PluginContact) ComputerSystemReset(req *systemsproto.ComputerSystemRese
 	}
 	if getResponse.StatusCode == http.StatusAccepted {
 
+		// the task is cancelled along with the job started on the plugin for it
+		ctx, release := common.WithTaskCancel(context.Background(), taskID)
 		body, err = p.monitorPluginTask(&monitorTaskRequest{
+			ctx:           ctx,
 			taskID:        taskID,
 			serverURI:     targetURI,
 			requestBody:   string(postBody),
@@ -171,6 +175,7 @@ func (p *PluginContact) ComputerSystemReset(req *systemsproto.ComputerSystemRese
 			pluginRequest: contactRequest,
 			resp:          resp,
 		})
+		release()
 
 		if err != nil {
 			return resp
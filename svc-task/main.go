@@ -78,12 +78,35 @@ func main() {
 	task.PersistTaskModel = tmodel.PersistTask
 	task.ValidateTaskUserNameModel = tmodel.ValidateTaskUserName
 	task.PublishToMessageBus = tmessagebus.Publish
+	task.CancelOwnerTaskRPC = services.CancelTask
+	task.GetTaskServiceSettingsModel = tmodel.GetTaskServiceSettings
+	task.SaveTaskServiceSettingsModel = tmodel.SaveTaskServiceSettings
+	task.ArchiveTaskModel = tmodel.ArchiveTask
+	task.GetArchivedTasksModel = tmodel.GetArchivedTasks
 	thandle.TaskCollection = thandle.TaskCollectionData{
 		TaskCollection: make(map[string]int32),
 		Lock:           sync.Mutex{},
 	}
 	taskproto.RegisterGetTaskServiceServer(services.ODIMService.Server(), task)
 
+	jobs := new(thandle.JobsRPC)
+	jobs.AuthenticationRPC = auth.Authentication
+	jobs.GetSessionUserNameRPC = auth.GetSessionUserName
+	jobs.GetSessionUserRoleIDRPC = auth.GetSessionUserRoleID
+	jobs.GetSessionAccountProviderTypeRPC = auth.GetSessionAccountProviderType
+	jobs.CreateJobSessionRPC = auth.CreateJobSession
+	jobs.DeleteJobSessionRPC = auth.DeleteJobSession
+	jobs.ReplayJobPayloadRPC = thandle.ReplayJobPayload
+	jobs.PersistJobModel = tmodel.PersistJob
+	jobs.GetJobModel = tmodel.GetJob
+	jobs.GetAllJobKeysModel = tmodel.GetAllJobKeys
+	jobs.DeleteJobModel = tmodel.DeleteJob
+	jobs.ClaimJobRunModel = tmodel.ClaimJobRun
+	jobs.CreateTaskUtilHelper = task.CreateTaskUtil
+	jobs.CompleteTaskUtilHelper = task.CompleteTaskUtil
+	taskproto.RegisterJobServiceServer(services.ODIMService.Server(), jobs)
+	go jobs.RunScheduledJobs()
+
 	// Run server
 	if err := services.ODIMService.Run(); err != nil {
 		log.Fatal(err.Error())
@@ -33,3 +33,23 @@ func Authentication(sessionToken string, privileges []string) response.RPC {
 func GetSessionUserName(sessionToken string) (string, error) {
 	return srv.GetSessionUserName(sessionToken)
 }
+
+// GetSessionUserRoleID is used to get the role ID of the session user from svc-account-session
+func GetSessionUserRoleID(sessionToken string) (string, error) {
+	return srv.GetSessionUserRoleID(sessionToken)
+}
+
+// GetSessionAccountProviderType is used to get the account provider type of the session user from svc-account-session
+func GetSessionAccountProviderType(sessionToken string) (string, error) {
+	return srv.GetSessionAccountProviderType(sessionToken)
+}
+
+// CreateJobSession is used to create a session for the owner of the scheduled job in svc-account-session
+func CreateJobSession(userName, jobURI string) (string, string, error) {
+	return srv.CreateJobSession(userName, jobURI)
+}
+
+// DeleteJobSession is used to delete the session created for a run of the scheduled job in svc-account-session
+func DeleteJobSession(sessionID, sessionToken string) error {
+	return srv.DeleteSession(sessionID, sessionToken)
+}
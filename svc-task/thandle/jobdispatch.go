@@ -0,0 +1,184 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package thandle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	systemsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/systems"
+	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
+	updateproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/update"
+	"github.com/ODIM-Project/ODIM/lib-utilities/services"
+	"github.com/ODIM-Project/ODIM/svc-task/tmodel"
+)
+
+// jobTarget is an operation which can be scheduled through the JobService.
+// The payload of the job is replayed against the RPC of the service
+// which handles the operation.
+type jobTarget struct {
+	httpOperation string
+	uri           *regexp.Regexp
+	replay        func(sessionToken string, targetURI string, params []string, body []byte) (*taskproto.TaskResponse, error)
+}
+
+var jobTargets = []jobTarget{
+	{
+		httpOperation: http.MethodPost,
+		uri:           regexp.MustCompile(`^/redfish/v1/AggregationService/Actions/AggregationService\.Reset/?$`),
+		replay: func(sessionToken, targetURI string, params []string, body []byte) (*taskproto.TaskResponse, error) {
+			return replayAggregatorRequest(sessionToken, targetURI, body, func(client aggregatorproto.AggregatorClient, req *aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+				return client.Reset(context.TODO(), req)
+			})
+		},
+	},
+	{
+		httpOperation: http.MethodPost,
+		uri:           regexp.MustCompile(`^/redfish/v1/AggregationService/Actions/AggregationService\.SetDefaultBootOrder/?$`),
+		replay: func(sessionToken, targetURI string, params []string, body []byte) (*taskproto.TaskResponse, error) {
+			return replayAggregatorRequest(sessionToken, targetURI, body, func(client aggregatorproto.AggregatorClient, req *aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+				return client.SetDefaultBootOrder(context.TODO(), req)
+			})
+		},
+	},
+	{
+		httpOperation: http.MethodPost,
+		uri:           regexp.MustCompile(`^/redfish/v1/AggregationService/Aggregates/[^/]+/Actions/Aggregate\.Reset/?$`),
+		replay: func(sessionToken, targetURI string, params []string, body []byte) (*taskproto.TaskResponse, error) {
+			return replayAggregatorRequest(sessionToken, targetURI, body, func(client aggregatorproto.AggregatorClient, req *aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+				return client.ResetElementsOfAggregate(context.TODO(), req)
+			})
+		},
+	},
+	{
+		httpOperation: http.MethodPost,
+		uri:           regexp.MustCompile(`^/redfish/v1/AggregationService/Aggregates/[^/]+/Actions/Aggregate\.SetDefaultBootOrder/?$`),
+		replay: func(sessionToken, targetURI string, params []string, body []byte) (*taskproto.TaskResponse, error) {
+			return replayAggregatorRequest(sessionToken, targetURI, body, func(client aggregatorproto.AggregatorClient, req *aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+				return client.SetDefaultBootOrderElementsOfAggregate(context.TODO(), req)
+			})
+		},
+	},
+	{
+		httpOperation: http.MethodPost,
+		uri:           regexp.MustCompile(`^/redfish/v1/Systems/([^/]+)/Actions/ComputerSystem\.Reset/?$`),
+		replay:        replaySystemReset,
+	},
+	{
+		httpOperation: http.MethodPatch,
+		uri:           regexp.MustCompile(`^/redfish/v1/Systems/([^/]+)/Bios/Settings/?$`),
+		replay:        replayBiosSettings,
+	},
+	{
+		httpOperation: http.MethodPost,
+		uri:           regexp.MustCompile(`^/redfish/v1/UpdateService/Actions/UpdateService\.SimpleUpdate/?$`),
+		replay:        replaySimpleUpdate,
+	},
+}
+
+// findJobTarget returns the target matching the HTTP operation and URI
+// of the job payload along with the parameters captured from the URI
+func findJobTarget(httpOperation, targetURI string) (*jobTarget, []string) {
+	for i := range jobTargets {
+		if jobTargets[i].httpOperation != httpOperation {
+			continue
+		}
+		if match := jobTargets[i].uri.FindStringSubmatch(targetURI); match != nil {
+			return &jobTargets[i], match[1:]
+		}
+	}
+	return nil, nil
+}
+
+// ReplayJobPayload runs the payload of the scheduled job with the session token
+// created for the job owner, the response of the target service is returned
+func ReplayJobPayload(sessionToken string, payload tmodel.Payload) (*taskproto.TaskResponse, error) {
+	target, params := findJobTarget(payload.HTTPOperation, payload.TargetURI)
+	if target == nil {
+		return nil, fmt.Errorf("%s %s is not supported by the JobService", payload.HTTPOperation, payload.TargetURI)
+	}
+	return target.replay(sessionToken, payload.TargetURI, params, []byte(payload.JSONBody))
+}
+
+func replayAggregatorRequest(sessionToken, targetURI string, body []byte,
+	call func(aggregatorproto.AggregatorClient, *aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)) (*taskproto.TaskResponse, error) {
+	conn, err := services.ODIMService.Client(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	resp, err := call(aggregatorproto.NewAggregatorClient(conn), &aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		URL:          targetURI,
+		RequestBody:  body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return &taskproto.TaskResponse{StatusCode: resp.StatusCode, StatusMessage: resp.StatusMessage, Header: resp.Header, Body: resp.Body}, nil
+}
+
+func replaySystemReset(sessionToken, targetURI string, params []string, body []byte) (*taskproto.TaskResponse, error) {
+	conn, err := services.ODIMService.Client(services.Systems)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	resp, err := systemsproto.NewSystemsClient(conn).ComputerSystemReset(context.TODO(), &systemsproto.ComputerSystemResetRequest{
+		SessionToken: sessionToken,
+		SystemID:     params[0],
+		RequestBody:  body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return &taskproto.TaskResponse{StatusCode: resp.StatusCode, StatusMessage: resp.StatusMessage, Header: resp.Header, Body: resp.Body}, nil
+}
+
+func replayBiosSettings(sessionToken, targetURI string, params []string, body []byte) (*taskproto.TaskResponse, error) {
+	conn, err := services.ODIMService.Client(services.Systems)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	resp, err := systemsproto.NewSystemsClient(conn).ChangeBiosSettings(context.TODO(), &systemsproto.BiosSettingsRequest{
+		SessionToken: sessionToken,
+		SystemID:     params[0],
+		RequestBody:  body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return &taskproto.TaskResponse{StatusCode: resp.StatusCode, StatusMessage: resp.StatusMessage, Header: resp.Header, Body: resp.Body}, nil
+}
+
+func replaySimpleUpdate(sessionToken, targetURI string, params []string, body []byte) (*taskproto.TaskResponse, error) {
+	conn, err := services.ODIMService.Client(services.Update)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	defer conn.Close()
+	resp, err := updateproto.NewUpdateClient(conn).SimepleUpdate(context.TODO(), &updateproto.UpdateRequest{
+		SessionToken: sessionToken,
+		RequestBody:  body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return &taskproto.TaskResponse{StatusCode: resp.StatusCode, StatusMessage: resp.StatusMessage, Header: resp.Header, Body: resp.Body}, nil
+}
@@ -0,0 +1,637 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package thandle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-task/tmodel"
+	"github.com/ODIM-Project/ODIM/svc-task/tresponse"
+	"github.com/ODIM-Project/ODIM/svc-task/tschedule"
+	"github.com/google/uuid"
+)
+
+const (
+	// JobServiceURI is the URI of the JobService
+	JobServiceURI = "/redfish/v1/JobService"
+	// JobCollectionURI is the URI of the scheduled jobs collection
+	JobCollectionURI = "/redfish/v1/JobService/Jobs"
+	// jobType has schema version to be returned with Job
+	jobType = "#Job.v1_2_0.Job"
+	// jobSchedulerInterval is the interval at which the due jobs are looked up
+	jobSchedulerInterval = 30 * time.Second
+)
+
+// JobsRPC used to register the JobService handlers used as rpc call
+// and to run the scheduled jobs when they are due
+type JobsRPC struct {
+	AuthenticationRPC                func(sessionToken string, privileges []string) response.RPC
+	GetSessionUserNameRPC            func(sessionToken string) (string, error)
+	GetSessionUserRoleIDRPC          func(sessionToken string) (string, error)
+	GetSessionAccountProviderTypeRPC func(sessionToken string) (string, error)
+	CreateJobSessionRPC              func(userName, jobURI string) (string, string, error)
+	DeleteJobSessionRPC              func(sessionID, sessionToken string) error
+	ReplayJobPayloadRPC              func(sessionToken string, payload tmodel.Payload) (*taskproto.TaskResponse, error)
+	PersistJobModel                  func(job *tmodel.Job) error
+	GetJobModel                      func(jobID string) (*tmodel.Job, error)
+	GetAllJobKeysModel               func() ([]string, error)
+	DeleteJobModel                   func(jobID string) error
+	ClaimJobRunModel                 func(jobID string, runTime time.Time) error
+	CreateTaskUtilHelper             func(userName string) (string, error)
+	CompleteTaskUtilHelper           func(taskID string, resp *taskproto.TaskResponse, payload tmodel.Payload) error
+}
+
+// jobCreateRequest is the request body for creating a scheduled job
+type jobCreateRequest struct {
+	Name     string              `json:"Name"`
+	Payload  *jobPayloadRequest  `json:"Payload"`
+	Schedule *jobScheduleRequest `json:"Schedule"`
+	Oem      *jobOemRequest      `json:"Oem"`
+}
+
+type jobPayloadRequest struct {
+	HTTPOperation string `json:"HttpOperation"`
+	TargetURI     string `json:"TargetUri"`
+	JSONBody      string `json:"JsonBody"`
+}
+
+type jobScheduleRequest struct {
+	InitialStartTime   *time.Time `json:"InitialStartTime"`
+	RecurrenceInterval string     `json:"RecurrenceInterval"`
+	EnabledDaysOfWeek  []string   `json:"EnabledDaysOfWeek"`
+	MaxOccurrences     int        `json:"MaxOccurrences"`
+}
+
+type jobOemRequest struct {
+	Odim *jobOdimOemRequest `json:"Odim"`
+}
+
+type jobOdimOemRequest struct {
+	CronSchedule string `json:"CronSchedule"`
+}
+
+// GetJobService is an API handler to get the JobService details
+func (js *JobsRPC) GetJobService(ctx context.Context, req *taskproto.JobRequest) (*taskproto.TaskResponse, error) {
+	var rsp taskproto.TaskResponse
+	constructCommonResponseHeader(&rsp)
+	rsp.Header["Link"] = "</redfish/v1/SchemaStore/en/JobService.json>; rel=describedby"
+	authResp := js.AuthenticationRPC(req.SessionToken, []string{common.PrivilegeLogin})
+	if authResp.StatusCode != http.StatusOK {
+		fillProtoResponse(&rsp, authResp)
+		log.Error(authErrorMessage)
+		return &rsp, nil
+	}
+
+	isServiceEnabled := false
+	serviceState := "Disabled"
+	for _, service := range config.Data.EnabledServices {
+		if service == "JobService" {
+			isServiceEnabled = true
+			serviceState = "Enabled"
+			break
+		}
+	}
+
+	rsp.StatusCode = http.StatusOK
+	rsp.StatusMessage = response.Success
+	jobServiceResponse := tresponse.JobServiceResponse{
+		Response: response.Response{
+			OdataType:    "#JobService.v1_0_3.JobService",
+			ID:           "JobService",
+			Name:         "JobService",
+			Description:  "JobService",
+			OdataContext: "/redfish/v1/$metadata#JobService.JobService",
+			OdataID:      JobServiceURI,
+		},
+		DateTime:       time.Now().UTC(),
+		ServiceEnabled: isServiceEnabled,
+		ServiceCapabilities: tresponse.ServiceCapabilities{
+			Scheduling: true,
+		},
+		Status: tresponse.Status{
+			State:        serviceState,
+			Health:       "OK",
+			HealthRollup: "OK",
+		},
+		Jobs: tresponse.ListMember{
+			OdataID: JobCollectionURI,
+		},
+	}
+	rsp.Body = generateResponse(jobServiceResponse)
+	return &rsp, nil
+}
+
+// GetJobCollection is an API handler to list the scheduled jobs.
+// Users having ConfigureUsers privilege get all the jobs, others get their own jobs.
+func (js *JobsRPC) GetJobCollection(ctx context.Context, req *taskproto.JobRequest) (*taskproto.TaskResponse, error) {
+	var rsp taskproto.TaskResponse
+	constructCommonResponseHeader(&rsp)
+	authResp := js.AuthenticationRPC(req.SessionToken, []string{common.PrivilegeLogin})
+	if authResp.StatusCode != http.StatusOK {
+		fillProtoResponse(&rsp, authResp)
+		log.Error(authErrorMessage)
+		return &rsp, nil
+	}
+	sessionUserName, err := js.GetSessionUserNameRPC(req.SessionToken)
+	if err != nil {
+		fillProtoResponse(&rsp, common.GeneralError(http.StatusUnauthorized, response.NoValidSession, authErrorMessage, nil, nil))
+		log.Error(authErrorMessage)
+		return &rsp, nil
+	}
+	jobIDs, err := js.GetAllJobKeysModel()
+	if err != nil {
+		errorMessage := "error: while trying to get all job keys from db: " + err.Error()
+		fillProtoResponse(&rsp, common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil))
+		log.Error(errorMessage)
+		return &rsp, nil
+	}
+	isAdmin := js.AuthenticationRPC(req.SessionToken, []string{common.PrivilegeConfigureUsers}).StatusCode == http.StatusOK
+	listMembers := []tresponse.ListMember{}
+	for _, jobID := range jobIDs {
+		if !isAdmin {
+			job, err := js.GetJobModel(jobID)
+			if err != nil {
+				log.Error("error while getting the job " + jobID + ": " + err.Error())
+				continue
+			}
+			if job.UserName != sessionUserName {
+				continue
+			}
+		}
+		listMembers = append(listMembers, tresponse.ListMember{OdataID: JobCollectionURI + "/" + jobID})
+	}
+
+	rsp.StatusCode = http.StatusOK
+	rsp.StatusMessage = response.Success
+	rsp.Body = generateResponse(tresponse.TaskCollectionResponse{
+		Response: response.Response{
+			Name:         "Job Collection",
+			OdataContext: "/redfish/v1/$metadata#JobCollection.JobCollection",
+			OdataID:      JobCollectionURI,
+			OdataType:    "#JobCollection.JobCollection",
+		},
+		MembersCount: len(listMembers),
+		Members:      listMembers,
+	})
+	return &rsp, nil
+}
+
+// GetJob is an API handler to get the details of the scheduled job
+func (js *JobsRPC) GetJob(ctx context.Context, req *taskproto.JobRequest) (*taskproto.TaskResponse, error) {
+	var rsp taskproto.TaskResponse
+	constructCommonResponseHeader(&rsp)
+	job, err := js.validateAndAuthorizeJob(req, []string{common.PrivilegeLogin}, &rsp)
+	if err != nil {
+		return &rsp, nil
+	}
+	rsp.Header["Link"] = "</redfish/v1/SchemaStore/en/Job.json>; rel=describedby"
+	rsp.StatusCode = http.StatusOK
+	rsp.StatusMessage = response.Success
+	rsp.Body = generateResponse(getJobResponse(job))
+	return &rsp, nil
+}
+
+// CreateJob is an API handler to schedule the payload given in the request
+// to be run at the initial start time and then at every recurrence
+func (js *JobsRPC) CreateJob(ctx context.Context, req *taskproto.JobRequest) (*taskproto.TaskResponse, error) {
+	var rsp taskproto.TaskResponse
+	constructCommonResponseHeader(&rsp)
+	authResp := js.AuthenticationRPC(req.SessionToken, []string{common.PrivilegeConfigureComponents})
+	if authResp.StatusCode != http.StatusOK {
+		fillProtoResponse(&rsp, authResp)
+		log.Error(authErrorMessage)
+		return &rsp, nil
+	}
+	sessionUserName, err := js.GetSessionUserNameRPC(req.SessionToken)
+	if err != nil {
+		fillProtoResponse(&rsp, common.GeneralError(http.StatusUnauthorized, response.NoValidSession, authErrorMessage, nil, nil))
+		log.Error(authErrorMessage)
+		return &rsp, nil
+	}
+	roleID, err := js.GetSessionUserRoleIDRPC(req.SessionToken)
+	if err != nil {
+		fillProtoResponse(&rsp, common.GeneralError(http.StatusUnauthorized, response.NoValidSession, authErrorMessage, nil, nil))
+		log.Error(authErrorMessage)
+		return &rsp, nil
+	}
+	accountProviderType, err := js.GetSessionAccountProviderTypeRPC(req.SessionToken)
+	if err != nil {
+		fillProtoResponse(&rsp, common.GeneralError(http.StatusUnauthorized, response.NoValidSession, authErrorMessage, nil, nil))
+		log.Error(authErrorMessage)
+		return &rsp, nil
+	}
+
+	var createReq jobCreateRequest
+	if err := json.Unmarshal(req.RequestBody, &createReq); err != nil {
+		errorMessage := "error while trying to parse the create job request: " + err.Error()
+		log.Error(errorMessage)
+		fillProtoResponse(&rsp, common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil))
+		return &rsp, nil
+	}
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, createReq)
+	if err != nil {
+		errorMessage := "error while validating request parameters: " + err.Error()
+		log.Error(errorMessage)
+		fillProtoResponse(&rsp, common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil))
+		return &rsp, nil
+	} else if invalidProperties != "" {
+		errorMessage := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		log.Error(errorMessage)
+		fillProtoResponse(&rsp, common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, nil))
+		return &rsp, nil
+	}
+
+	now := time.Now().UTC()
+	job, errResp := newJob(&createReq, now)
+	if errResp != nil {
+		fillProtoResponse(&rsp, *errResp)
+		return &rsp, nil
+	}
+	job.UserName = sessionUserName
+	job.RoleID = roleID
+	job.AccountProviderType = accountProviderType
+	if err := js.PersistJobModel(job); err != nil {
+		errorMessage := "error while trying to save the job: " + err.Error()
+		log.Error(errorMessage)
+		fillProtoResponse(&rsp, common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil))
+		return &rsp, nil
+	}
+	log.Info("job " + job.ID + " for " + job.Payload.HTTPOperation + " " + job.Payload.TargetURI + " is scheduled at " + job.NextRunTime.String())
+
+	rsp.StatusCode = http.StatusCreated
+	rsp.StatusMessage = response.Created
+	rsp.Header["Location"] = JobCollectionURI + "/" + job.ID
+	rsp.Body = generateResponse(getJobResponse(job))
+	return &rsp, nil
+}
+
+// DeleteJob is an API handler to remove the scheduled job, the runs of the
+// job which are already started are not affected
+func (js *JobsRPC) DeleteJob(ctx context.Context, req *taskproto.JobRequest) (*taskproto.TaskResponse, error) {
+	var rsp taskproto.TaskResponse
+	constructCommonResponseHeader(&rsp)
+	if _, err := js.validateAndAuthorizeJob(req, []string{common.PrivilegeConfigureComponents}, &rsp); err != nil {
+		return &rsp, nil
+	}
+	if err := js.DeleteJobModel(req.JobID); err != nil {
+		errorMessage := "error while trying to delete the job: " + err.Error()
+		log.Error(errorMessage)
+		fillProtoResponse(&rsp, common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil))
+		return &rsp, nil
+	}
+	rsp.StatusCode = http.StatusNoContent
+	rsp.StatusMessage = response.ResourceRemoved
+	return &rsp, nil
+}
+
+// validateAndAuthorizeJob checks the privileges of the session and returns the
+// requested job, the job is accessible to its owner and to the users having
+// ConfigureUsers privilege
+func (js *JobsRPC) validateAndAuthorizeJob(req *taskproto.JobRequest, privileges []string, rsp *taskproto.TaskResponse) (*tmodel.Job, error) {
+	authResp := js.AuthenticationRPC(req.SessionToken, privileges)
+	if authResp.StatusCode != http.StatusOK {
+		fillProtoResponse(rsp, authResp)
+		log.Error(authErrorMessage)
+		return nil, fmt.Errorf(authErrorMessage)
+	}
+	sessionUserName, err := js.GetSessionUserNameRPC(req.SessionToken)
+	if err != nil {
+		fillProtoResponse(rsp, common.GeneralError(http.StatusUnauthorized, response.NoValidSession, authErrorMessage, nil, nil))
+		log.Error(authErrorMessage)
+		return nil, fmt.Errorf(authErrorMessage)
+	}
+	job, err := js.GetJobModel(req.JobID)
+	if err != nil {
+		log.Error("error getting the job : " + err.Error())
+		fillProtoResponse(rsp, common.GeneralError(http.StatusNotFound, response.ResourceNotFound, err.Error(), []interface{}{"Job", req.JobID}, nil))
+		return nil, err
+	}
+	if sessionUserName != job.UserName {
+		authResp := js.AuthenticationRPC(req.SessionToken, []string{common.PrivilegeConfigureUsers})
+		if authResp.StatusCode != http.StatusOK {
+			fillProtoResponse(rsp, authResp)
+			log.Error(authErrorMessage)
+			return nil, fmt.Errorf(authErrorMessage)
+		}
+	}
+	return job, nil
+}
+
+// newJob validates the create job request and builds the job with its first run time
+func newJob(req *jobCreateRequest, now time.Time) (*tmodel.Job, *response.RPC) {
+	badRequest := func(statusMessage, errorMessage string, args []interface{}) *response.RPC {
+		log.Error(errorMessage)
+		resp := common.GeneralError(http.StatusBadRequest, statusMessage, errorMessage, args, nil)
+		return &resp
+	}
+	if req.Payload == nil {
+		return nil, badRequest(response.PropertyMissing, "error: Payload is missing in the request", []interface{}{"Payload"})
+	}
+	if req.Payload.TargetURI == "" {
+		return nil, badRequest(response.PropertyMissing, "error: TargetUri is missing in the Payload", []interface{}{"Payload/TargetUri"})
+	}
+	if req.Payload.HTTPOperation == "" {
+		req.Payload.HTTPOperation = http.MethodPost
+	}
+	req.Payload.HTTPOperation = strings.ToUpper(req.Payload.HTTPOperation)
+	if target, _ := findJobTarget(req.Payload.HTTPOperation, req.Payload.TargetURI); target == nil {
+		errorMessage := "error: " + req.Payload.HTTPOperation + " " + req.Payload.TargetURI + " can not be scheduled"
+		return nil, badRequest(response.PropertyValueNotInList, errorMessage, []interface{}{req.Payload.TargetURI, "Payload/TargetUri"})
+	}
+	if req.Payload.JSONBody != "" && !json.Valid([]byte(req.Payload.JSONBody)) {
+		return nil, badRequest(response.PropertyValueFormatError, "error: JsonBody of the Payload is not a valid JSON", []interface{}{req.Payload.JSONBody, "Payload/JsonBody"})
+	}
+
+	var schedule tschedule.Schedule
+	if req.Schedule != nil {
+		if req.Schedule.InitialStartTime != nil {
+			schedule.InitialStartTime = req.Schedule.InitialStartTime.UTC()
+		}
+		schedule.RecurrenceInterval = req.Schedule.RecurrenceInterval
+		schedule.EnabledDaysOfWeek = req.Schedule.EnabledDaysOfWeek
+		schedule.MaxOccurrences = req.Schedule.MaxOccurrences
+	}
+	if req.Oem != nil && req.Oem.Odim != nil {
+		schedule.CronSchedule = req.Oem.Odim.CronSchedule
+	}
+	if property, err := schedule.Validate(); err != nil {
+		return nil, badRequest(response.PropertyValueFormatError, "error: invalid schedule: "+err.Error(), []interface{}{fmt.Sprintf("%v", schedulePropertyValue(&schedule, property)), property})
+	}
+	if schedule.InitialStartTime.IsZero() {
+		schedule.InitialStartTime = now
+	} else if !schedule.IsRecurring() && schedule.InitialStartTime.Before(now) {
+		return nil, badRequest(response.PropertyValueFormatError, "error: InitialStartTime of a job which is not recurring must not be in the past",
+			[]interface{}{schedule.InitialStartTime.Format(time.RFC3339), "Schedule/InitialStartTime"})
+	}
+	nextRunTime := schedule.Next(now.Add(-time.Second), 0)
+	if nextRunTime.IsZero() {
+		return nil, badRequest(response.PropertyValueFormatError, "error: the schedule has no run in the future", []interface{}{schedule.InitialStartTime.Format(time.RFC3339), "Schedule/InitialStartTime"})
+	}
+
+	jobID := uuid.New().String()
+	name := req.Name
+	if name == "" {
+		name = "Job " + jobID
+	}
+	return &tmodel.Job{
+		ID:        jobID,
+		Name:      name,
+		JobState:  common.Pending,
+		JobStatus: common.OK,
+		Payload: tmodel.Payload{
+			HTTPOperation: req.Payload.HTTPOperation,
+			TargetURI:     req.Payload.TargetURI,
+			JSONBody:      req.Payload.JSONBody,
+		},
+		Schedule:    schedule,
+		CreatedTime: now,
+		NextRunTime: nextRunTime,
+	}, nil
+}
+
+func schedulePropertyValue(schedule *tschedule.Schedule, property string) interface{} {
+	switch property {
+	case "MaxOccurrences":
+		return schedule.MaxOccurrences
+	case "RecurrenceInterval":
+		return schedule.RecurrenceInterval
+	case "CronSchedule":
+		return schedule.CronSchedule
+	case "EnabledDaysOfWeek":
+		return strings.Join(schedule.EnabledDaysOfWeek, ",")
+	}
+	return ""
+}
+
+func getJobResponse(job *tmodel.Job) tresponse.Job {
+	messages := []tresponse.Messages{}
+	for _, element := range job.Messages {
+		messages = append(messages, tresponse.Messages{
+			MessageID:         element.MessageID,
+			RelatedProperties: element.RelatedProperties,
+			Message:           element.Message,
+			MessageArgs:       element.MessageArgs,
+			Severity:          element.Severity,
+		})
+	}
+	tasks := []tresponse.ListMember{}
+	for _, taskURI := range job.TaskURIs {
+		tasks = append(tasks, tresponse.ListMember{OdataID: taskURI})
+	}
+	jobResponse := tresponse.Job{
+		Response: response.Response{
+			OdataType:    jobType,
+			ID:           job.ID,
+			Name:         job.Name,
+			OdataContext: "/redfish/v1/$metadata#Job.Job",
+			OdataID:      JobCollectionURI + "/" + job.ID,
+		},
+		JobState:  job.JobState,
+		JobStatus: job.JobStatus,
+		CreatedBy: job.UserName,
+		Payload: tresponse.Payload{
+			HTTPHeaders:   []string{},
+			HTTPOperation: job.Payload.HTTPOperation,
+			JSONBody:      job.Payload.JSONBody,
+			TargetURI:     job.Payload.TargetURI,
+		},
+		Schedule: tresponse.JobSchedule{
+			InitialStartTime:   job.Schedule.InitialStartTime,
+			RecurrenceInterval: job.Schedule.RecurrenceInterval,
+			EnabledDaysOfWeek:  job.Schedule.EnabledDaysOfWeek,
+			MaxOccurrences:     job.Schedule.MaxOccurrences,
+		},
+		Messages: messages,
+		Oem: tresponse.JobOem{
+			Odim: tresponse.JobOdimOem{
+				CronSchedule: job.Schedule.CronSchedule,
+				Occurrences:  job.Occurrences,
+				Tasks:        tasks,
+			},
+		},
+	}
+	if !job.StartTime.IsZero() {
+		startTime := job.StartTime.UTC()
+		jobResponse.StartTime = &startTime
+	}
+	if !job.EndTime.IsZero() {
+		endTime := job.EndTime.UTC()
+		jobResponse.EndTime = &endTime
+	}
+	if !job.NextRunTime.IsZero() {
+		nextRunTime := job.NextRunTime.UTC()
+		jobResponse.Oem.Odim.NextRunTime = &nextRunTime
+	}
+	return jobResponse
+}
+
+// RunScheduledJobs looks up the jobs which are due at every scheduler interval
+// and runs them. Each run is claimed in the db before it is started, so when
+// there are multiple instances of the task service a run is started only once.
+func (js *JobsRPC) RunScheduledJobs() {
+	for {
+		js.runDueJobs(time.Now().UTC())
+		time.Sleep(jobSchedulerInterval)
+	}
+}
+
+func (js *JobsRPC) runDueJobs(now time.Time) {
+	jobIDs, err := js.GetAllJobKeysModel()
+	if err != nil {
+		log.Error("error while getting the scheduled jobs: " + err.Error())
+		return
+	}
+	for _, jobID := range jobIDs {
+		job, err := js.GetJobModel(jobID)
+		if err != nil {
+			log.Error("error while getting the job " + jobID + ": " + err.Error())
+			continue
+		}
+		if job.JobState != common.Pending || job.NextRunTime.IsZero() || job.NextRunTime.After(now) {
+			continue
+		}
+		if err := js.ClaimJobRunModel(job.ID, job.NextRunTime); err != nil {
+			log.Debug("run of the job " + job.ID + " is taken by another instance: " + err.Error())
+			continue
+		}
+		go js.runJob(job, now)
+	}
+}
+
+// runJob replays the payload of the job with a session created for the job owner
+// and computes the next run of the job
+func (js *JobsRPC) runJob(job *tmodel.Job, now time.Time) {
+	jobURI := JobCollectionURI + "/" + job.ID
+	runTime := job.NextRunTime
+	job.JobState = common.Running
+	if job.StartTime.IsZero() {
+		job.StartTime = now
+	}
+	if err := js.PersistJobModel(job); err != nil {
+		log.Error("error while updating the job " + job.ID + ": " + err.Error())
+	}
+
+	taskURI, message := js.replayJob(job, jobURI)
+	if taskURI != "" {
+		job.TaskURIs = append(job.TaskURIs, taskURI)
+	}
+	job.Occurrences++
+	job.JobStatus = message.Severity
+	job.Messages = []*tmodel.Message{message}
+	job.NextRunTime = job.Schedule.Next(runTime, job.Occurrences)
+	switch {
+	case !job.NextRunTime.IsZero():
+		job.JobState = common.Pending
+	case message.Severity == common.OK:
+		job.JobState = common.Completed
+		job.EndTime = time.Now().UTC()
+	default:
+		job.JobState = common.Exception
+		job.EndTime = time.Now().UTC()
+	}
+	// the job could be deleted while it was running
+	if _, err := js.GetJobModel(job.ID); err != nil {
+		log.Info("job " + job.ID + " is removed while running, the run result is not saved")
+		return
+	}
+	if err := js.PersistJobModel(job); err != nil {
+		log.Error("error while updating the job " + job.ID + ": " + err.Error())
+	}
+}
+
+// replayJob runs the job payload and returns the URI of the task tracking
+// the run along with the message describing the result of the run
+func (js *JobsRPC) replayJob(job *tmodel.Job, jobURI string) (string, *tmodel.Message) {
+	run := job.Occurrences + 1
+	failure := func(errorMessage string) (string, *tmodel.Message) {
+		log.Error(errorMessage)
+		return "", &tmodel.Message{
+			MessageID:   response.InternalError,
+			Message:     fmt.Sprintf("Run %d of the job failed: %s", run, errorMessage),
+			MessageArgs: []string{},
+			Severity:    common.Critical,
+		}
+	}
+	sessionID, sessionToken, err := js.CreateJobSessionRPC(job.UserName, jobURI)
+	if err != nil {
+		return failure("error while creating session for the job " + job.ID + ": " + err.Error())
+	}
+	defer func() {
+		if err := js.DeleteJobSessionRPC(sessionID, sessionToken); err != nil {
+			log.Error("error while deleting the session of the job " + job.ID + ": " + err.Error())
+		}
+	}()
+	resp, err := js.ReplayJobPayloadRPC(sessionToken, job.Payload)
+	if err != nil {
+		return failure("error while running the job " + job.ID + ": " + err.Error())
+	}
+
+	severity := common.OK
+	if resp.StatusCode >= http.StatusBadRequest {
+		severity = common.Critical
+	}
+	message := &tmodel.Message{
+		MessageID:   resp.StatusMessage,
+		Message:     fmt.Sprintf("Run %d of the job returned the status code %d.", run, resp.StatusCode),
+		MessageArgs: []string{},
+		Severity:    severity,
+	}
+	// the target service tracks the asynchronous operations in its own task
+	if resp.StatusCode == http.StatusAccepted {
+		if location, ok := resp.Header["Location"]; ok {
+			return "/redfish/v1/TaskService/Tasks/" + strings.TrimPrefix(location, "/taskmon/"), message
+		}
+	}
+	taskURI, err := js.CreateTaskUtilHelper(job.UserName)
+	if err != nil {
+		log.Error("error while creating task for the run of the job " + job.ID + ": " + err.Error())
+		return "", message
+	}
+	strArray := strings.Split(taskURI, "/")
+	if err := js.CompleteTaskUtilHelper(strArray[len(strArray)-1], resp, job.Payload); err != nil {
+		log.Error("error while updating the task for the run of the job " + job.ID + ": " + err.Error())
+	}
+	return taskURI, message
+}
+
+// CompleteTaskUtil completes the task created for a run of a scheduled job
+// with the response of the operation run synchronously by the target service
+func (ts *TasksRPC) CompleteTaskUtil(taskID string, resp *taskproto.TaskResponse, payload tmodel.Payload) error {
+	taskState := common.Completed
+	taskStatus := common.OK
+	if resp.StatusCode >= http.StatusBadRequest {
+		taskState = common.Exception
+		taskStatus = common.Critical
+	}
+	return ts.updateTaskUtil(taskID, taskState, taskStatus, 100, &taskproto.Payload{
+		HTTPHeaders:   resp.Header,
+		HTTPOperation: payload.HTTPOperation,
+		JSONBody:      payload.JSONBody,
+		TargetURI:     payload.TargetURI,
+		StatusCode:    resp.StatusCode,
+		ResponseBody:  resp.Body,
+	}, time.Now())
+}
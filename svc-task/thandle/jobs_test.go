@@ -0,0 +1,432 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package thandle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
+	"github.com/ODIM-Project/ODIM/svc-task/tmodel"
+	"github.com/ODIM-Project/ODIM/svc-task/tschedule"
+)
+
+type mockJobStore struct {
+	lock     sync.Mutex
+	jobs     map[string]tmodel.Job
+	claims   map[string]bool
+	sessions map[string]bool
+}
+
+func (m *mockJobStore) persist(job *tmodel.Job) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.jobs[job.ID] = *job
+	return nil
+}
+
+func (m *mockJobStore) get(jobID string) (*tmodel.Job, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	job, ok := m.jobs[jobID]
+	if !ok {
+		return nil, fmt.Errorf("no data with the with key %s found", jobID)
+	}
+	return &job, nil
+}
+
+func (m *mockJobStore) keys() ([]string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var keys []string
+	for key := range m.jobs {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (m *mockJobStore) delete(jobID string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.jobs, jobID)
+	return nil
+}
+
+func (m *mockJobStore) claim(jobID string, runTime time.Time) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	key := fmt.Sprintf("%s:%d", jobID, runTime.Unix())
+	if m.claims[key] {
+		return fmt.Errorf("run %s is already claimed", key)
+	}
+	m.claims[key] = true
+	return nil
+}
+
+func mockGetSessionUserRoleID(sessionToken string) (string, error) {
+	if _, err := mockGetSessionUserName(sessionToken); err != nil {
+		return "", err
+	}
+	return common.RoleAdmin, nil
+}
+
+func mockGetSessionAccountProviderType(sessionToken string) (string, error) {
+	if _, err := mockGetSessionUserName(sessionToken); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+func (m *mockJobStore) createSession(userName, jobURI string) (string, string, error) {
+	if userName != "validUser" {
+		return "", "", fmt.Errorf("user %s not found", userName)
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	sessionID := fmt.Sprintf("session%d", len(m.sessions)+1)
+	m.sessions[sessionID] = true
+	return sessionID, "jobToken", nil
+}
+
+func (m *mockJobStore) deleteSession(sessionID, sessionToken string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if !m.sessions[sessionID] {
+		return fmt.Errorf("session %s not found", sessionID)
+	}
+	delete(m.sessions, sessionID)
+	return nil
+}
+
+func (m *mockJobStore) openSessions() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	count := 0
+	for _, open := range m.sessions {
+		if open {
+			count++
+		}
+	}
+	return count
+}
+
+func newMockJobsRPC(store *mockJobStore, replay func(string, tmodel.Payload) (*taskproto.TaskResponse, error)) *JobsRPC {
+	return &JobsRPC{
+		AuthenticationRPC:                mockIsAuthorized,
+		GetSessionUserNameRPC:            mockGetSessionUserName,
+		GetSessionUserRoleIDRPC:          mockGetSessionUserRoleID,
+		GetSessionAccountProviderTypeRPC: mockGetSessionAccountProviderType,
+		CreateJobSessionRPC:              store.createSession,
+		DeleteJobSessionRPC:              store.deleteSession,
+		ReplayJobPayloadRPC:              replay,
+		PersistJobModel:                  store.persist,
+		GetJobModel:                      store.get,
+		GetAllJobKeysModel:               store.keys,
+		DeleteJobModel:                   store.delete,
+		ClaimJobRunModel:                 store.claim,
+		CreateTaskUtilHelper:             mockCreateTaskUtil,
+		CompleteTaskUtilHelper: func(taskID string, resp *taskproto.TaskResponse, payload tmodel.Payload) error {
+			return nil
+		},
+	}
+}
+
+func newMockJobStore() *mockJobStore {
+	return &mockJobStore{
+		jobs:     make(map[string]tmodel.Job),
+		claims:   make(map[string]bool),
+		sessions: make(map[string]bool),
+	}
+}
+
+func TestJobsRPC_CreateJob(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	tests := []struct {
+		name         string
+		sessionToken string
+		body         string
+		want         int32
+	}{
+		{
+			name:         "one time system reset",
+			sessionToken: "validToken",
+			body:         `{"Payload":{"TargetUri":"/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset","HttpOperation":"POST","JsonBody":"{\"ResetType\":\"ForceRestart\"}"},"Schedule":{"InitialStartTime":"` + future + `"}}`,
+			want:         http.StatusCreated,
+		},
+		{
+			name:         "cron scheduled bios settings",
+			sessionToken: "validToken",
+			body:         `{"Payload":{"TargetUri":"/redfish/v1/Systems/uuid.1/Bios/Settings","HttpOperation":"PATCH","JsonBody":"{\"Attributes\":{}}"},"Oem":{"Odim":{"CronSchedule":"0 2 * * SAT"}}}`,
+			want:         http.StatusCreated,
+		},
+		{
+			name:         "recurring simple update",
+			sessionToken: "validToken",
+			body:         `{"Payload":{"TargetUri":"/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate"},"Schedule":{"InitialStartTime":"` + past + `","RecurrenceInterval":"P1D","MaxOccurrences":3}}`,
+			want:         http.StatusCreated,
+		},
+		{
+			name:         "invalid session",
+			sessionToken: "InvalidToken",
+			body:         `{}`,
+			want:         http.StatusUnauthorized,
+		},
+		{
+			name:         "malformed json",
+			sessionToken: "validToken",
+			body:         `{`,
+			want:         http.StatusBadRequest,
+		},
+		{
+			name:         "missing payload",
+			sessionToken: "validToken",
+			body:         `{"Name":"job"}`,
+			want:         http.StatusBadRequest,
+		},
+		{
+			name:         "unsupported target",
+			sessionToken: "validToken",
+			body:         `{"Payload":{"TargetUri":"/redfish/v1/AccountService/Accounts","HttpOperation":"POST"}}`,
+			want:         http.StatusBadRequest,
+		},
+		{
+			name:         "invalid json body",
+			sessionToken: "validToken",
+			body:         `{"Payload":{"TargetUri":"/redfish/v1/AggregationService/Actions/AggregationService.Reset","JsonBody":"{"}}`,
+			want:         http.StatusBadRequest,
+		},
+		{
+			name:         "invalid cron schedule",
+			sessionToken: "validToken",
+			body:         `{"Payload":{"TargetUri":"/redfish/v1/AggregationService/Actions/AggregationService.Reset"},"Oem":{"Odim":{"CronSchedule":"0 25 * * *"}}}`,
+			want:         http.StatusBadRequest,
+		},
+		{
+			name:         "one time job in the past",
+			sessionToken: "validToken",
+			body:         `{"Payload":{"TargetUri":"/redfish/v1/AggregationService/Actions/AggregationService.Reset"},"Schedule":{"InitialStartTime":"` + past + `"}}`,
+			want:         http.StatusBadRequest,
+		},
+		{
+			name:         "property with invalid case",
+			sessionToken: "validToken",
+			body:         `{"payload":{"TargetUri":"/redfish/v1/AggregationService/Actions/AggregationService.Reset"}}`,
+			want:         http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockJobStore()
+			js := newMockJobsRPC(store, nil)
+			rsp, err := js.CreateJob(context.TODO(), &taskproto.JobRequest{SessionToken: tt.sessionToken, RequestBody: []byte(tt.body)})
+			if err != nil || rsp.StatusCode != tt.want {
+				t.Errorf("JobsRPC.CreateJob() got = %v, want %v, body %s", rsp.StatusCode, tt.want, string(rsp.Body))
+				return
+			}
+			if tt.want != http.StatusCreated {
+				return
+			}
+			if len(store.jobs) != 1 {
+				t.Fatalf("JobsRPC.CreateJob() expected the job to be saved")
+			}
+			for _, job := range store.jobs {
+				if job.UserName != "validUser" || job.RoleID != common.RoleAdmin || job.JobState != common.Pending || job.NextRunTime.IsZero() {
+					t.Errorf("JobsRPC.CreateJob() saved unexpected job %+v", job)
+				}
+				if rsp.Header["Location"] != JobCollectionURI+"/"+job.ID {
+					t.Errorf("JobsRPC.CreateJob() got location %v", rsp.Header["Location"])
+				}
+			}
+		})
+	}
+}
+
+func TestJobsRPC_GetAndDeleteJob(t *testing.T) {
+	store := newMockJobStore()
+	store.persist(&tmodel.Job{ID: "job1", UserName: "validUser", JobState: common.Pending})
+	js := newMockJobsRPC(store, nil)
+
+	tests := []struct {
+		name         string
+		sessionToken string
+		jobID        string
+		want         int32
+	}{
+		{name: "owner", sessionToken: "validToken", jobID: "job1", want: http.StatusOK},
+		{name: "admin", sessionToken: "NotTaskUserButAdminToken", jobID: "job1", want: http.StatusOK},
+		{name: "other user", sessionToken: "NotTaskUserToken", jobID: "job1", want: http.StatusUnauthorized},
+		{name: "invalid session", sessionToken: "InvalidToken", jobID: "job1", want: http.StatusUnauthorized},
+		{name: "not found", sessionToken: "validToken", jobID: "job2", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rsp, _ := js.GetJob(context.TODO(), &taskproto.JobRequest{SessionToken: tt.sessionToken, JobID: tt.jobID})
+			if rsp.StatusCode != tt.want {
+				t.Errorf("JobsRPC.GetJob() got = %v, want %v", rsp.StatusCode, tt.want)
+			}
+		})
+	}
+
+	rsp, _ := js.GetJobCollection(context.TODO(), &taskproto.JobRequest{SessionToken: "NotTaskUserToken"})
+	var collection map[string]interface{}
+	json.Unmarshal(rsp.Body, &collection)
+	if rsp.StatusCode != http.StatusOK || collection["Members@odata.count"] != float64(0) {
+		t.Errorf("JobsRPC.GetJobCollection() expected no jobs for other user, got %s", string(rsp.Body))
+	}
+	rsp, _ = js.GetJobCollection(context.TODO(), &taskproto.JobRequest{SessionToken: "validToken"})
+	json.Unmarshal(rsp.Body, &collection)
+	if rsp.StatusCode != http.StatusOK || collection["Members@odata.count"] != float64(1) {
+		t.Errorf("JobsRPC.GetJobCollection() expected the owned job, got %s", string(rsp.Body))
+	}
+
+	rsp, _ = js.DeleteJob(context.TODO(), &taskproto.JobRequest{SessionToken: "validToken", JobID: "job1"})
+	if rsp.StatusCode != http.StatusNoContent {
+		t.Errorf("JobsRPC.DeleteJob() got = %v, want %v", rsp.StatusCode, http.StatusNoContent)
+	}
+	if _, err := store.get("job1"); err == nil {
+		t.Errorf("JobsRPC.DeleteJob() expected the job to be removed")
+	}
+}
+
+func TestJobsRPC_runDueJobs(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 30, 0, time.UTC)
+	store := newMockJobStore()
+	store.persist(&tmodel.Job{
+		ID:          "recurring",
+		UserName:    "validUser",
+		JobState:    common.Pending,
+		Payload:     tmodel.Payload{HTTPOperation: http.MethodPost, TargetURI: "/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset"},
+		Schedule:    tschedule.Schedule{InitialStartTime: now.Add(-30 * time.Second), RecurrenceInterval: "PT1H", MaxOccurrences: 2},
+		NextRunTime: now.Add(-30 * time.Second),
+	})
+	store.persist(&tmodel.Job{
+		ID:          "onetime",
+		UserName:    "validUser",
+		JobState:    common.Pending,
+		Payload:     tmodel.Payload{HTTPOperation: http.MethodPost, TargetURI: "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate"},
+		Schedule:    tschedule.Schedule{InitialStartTime: now.Add(-30 * time.Second)},
+		NextRunTime: now.Add(-30 * time.Second),
+	})
+	store.persist(&tmodel.Job{
+		ID:          "future",
+		UserName:    "validUser",
+		JobState:    common.Pending,
+		Schedule:    tschedule.Schedule{InitialStartTime: now.Add(time.Hour)},
+		NextRunTime: now.Add(time.Hour),
+	})
+
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	replayed := map[string]string{}
+	wg.Add(2)
+	js := newMockJobsRPC(store, func(sessionToken string, payload tmodel.Payload) (*taskproto.TaskResponse, error) {
+		defer wg.Done()
+		lock.Lock()
+		defer lock.Unlock()
+		replayed[payload.TargetURI] = sessionToken
+		if payload.TargetURI == "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate" {
+			return &taskproto.TaskResponse{StatusCode: http.StatusAccepted, Header: map[string]string{"Location": "/taskmon/task123"}}, nil
+		}
+		return &taskproto.TaskResponse{StatusCode: http.StatusOK}, nil
+	})
+	js.runDueJobs(now)
+	wg.Wait()
+	// a second look up of the same runs must not run the jobs again
+	js.runDueJobs(now)
+
+	if len(replayed) != 2 || replayed["/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset"] != "jobToken" {
+		t.Fatalf("runDueJobs() replayed unexpected payloads %v", replayed)
+	}
+	// wait for the runs to save the job state
+	for i := 0; i < 100; i++ {
+		recurring, _ := store.get("recurring")
+		onetime, _ := store.get("onetime")
+		if recurring.JobState == common.Pending && recurring.Occurrences == 1 && onetime.JobState == common.Completed {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	recurring, _ := store.get("recurring")
+	if recurring.JobState != common.Pending || recurring.Occurrences != 1 || !recurring.NextRunTime.Equal(now.Add(59*time.Minute+30*time.Second)) {
+		t.Errorf("runDueJobs() unexpected recurring job %+v", recurring)
+	}
+	if len(recurring.TaskURIs) != 1 || recurring.TaskURIs[0] != "/redfish/v1/TaskService/Tasks/validTaskID" {
+		t.Errorf("runDueJobs() expected a task for the synchronous run, got %v", recurring.TaskURIs)
+	}
+	onetime, _ := store.get("onetime")
+	if onetime.JobState != common.Completed || !onetime.NextRunTime.IsZero() || onetime.EndTime.IsZero() {
+		t.Errorf("runDueJobs() unexpected one time job %+v", onetime)
+	}
+	if len(onetime.TaskURIs) != 1 || onetime.TaskURIs[0] != "/redfish/v1/TaskService/Tasks/task123" {
+		t.Errorf("runDueJobs() expected the task of the target service, got %v", onetime.TaskURIs)
+	}
+	future, _ := store.get("future")
+	if future.Occurrences != 0 || future.JobState != common.Pending {
+		t.Errorf("runDueJobs() must not run the job which is not due %+v", future)
+	}
+	if open := store.openSessions(); open != 0 {
+		t.Errorf("runDueJobs() left %d job sessions open", open)
+	}
+}
+
+func TestJobsRPC_runJobFailure(t *testing.T) {
+	store := newMockJobStore()
+	job := &tmodel.Job{
+		ID:          "job1",
+		UserName:    "removedUser",
+		JobState:    common.Pending,
+		NextRunTime: time.Now(),
+	}
+	store.persist(job)
+	js := newMockJobsRPC(store, nil)
+	js.runJob(job, time.Now())
+	got, _ := store.get("job1")
+	if got.JobState != common.Exception || got.JobStatus != common.Critical || len(got.Messages) != 1 {
+		t.Errorf("runJob() expected the job to fail %+v", got)
+	}
+}
+
+func TestFindJobTarget(t *testing.T) {
+	tests := []struct {
+		operation string
+		uri       string
+		found     bool
+		params    []string
+	}{
+		{http.MethodPost, "/redfish/v1/AggregationService/Actions/AggregationService.Reset/", true, []string{}},
+		{http.MethodPost, "/redfish/v1/AggregationService/Aggregates/a1/Actions/Aggregate.SetDefaultBootOrder", true, []string{}},
+		{http.MethodPost, "/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset", true, []string{"uuid.1"}},
+		{http.MethodPatch, "/redfish/v1/Systems/uuid.1/Bios/Settings", true, []string{"uuid.1"}},
+		{http.MethodPost, "/redfish/v1/Systems/uuid.1/Bios/Settings", false, nil},
+		{http.MethodPost, "/redfish/v1/UpdateService/Actions/UpdateService.StartUpdate", false, nil},
+	}
+	for _, tt := range tests {
+		target, params := findJobTarget(tt.operation, tt.uri)
+		if (target != nil) != tt.found {
+			t.Errorf("findJobTarget(%v, %v) found = %v, want %v", tt.operation, tt.uri, target != nil, tt.found)
+			continue
+		}
+		if tt.found && fmt.Sprint(params) != fmt.Sprint(tt.params) {
+			t.Errorf("findJobTarget(%v, %v) params = %v, want %v", tt.operation, tt.uri, params, tt.params)
+		}
+	}
+}
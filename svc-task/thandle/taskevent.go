@@ -0,0 +1,129 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package thandle
+
+import (
+	"fmt"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/svc-task/tmodel"
+)
+
+// message keys of the TaskEvent registry published on task state change
+const (
+	taskStarted          = "TaskStarted"
+	taskProgressChanged  = "TaskProgressChanged"
+	taskCompletedOK      = "TaskCompletedOK"
+	taskCompletedWarning = "TaskCompletedWarning"
+	taskAborted          = "TaskAborted"
+	taskCancelled        = "TaskCancelled"
+	taskPaused           = "TaskPaused"
+	taskResumed          = "TaskResumed"
+)
+
+// taskEventRegistry holds the severity and the message format of the
+// TaskEvent registry messages, the task ID is always the first argument
+var taskEventRegistry = map[string]struct {
+	severity string
+	message  string
+}{
+	taskStarted:          {common.OK, "The task with Id '%v' has started."},
+	taskProgressChanged:  {common.OK, "The task with Id '%v' has changed to progress %v percent complete."},
+	taskCompletedOK:      {common.OK, "The task with Id '%v' has completed."},
+	taskCompletedWarning: {common.Warning, "The task with Id '%v' has completed with warnings."},
+	taskAborted:          {common.Critical, "The task with Id '%v' has completed with errors."},
+	taskCancelled:        {common.Warning, "Work on the task with Id '%v' has been halted prior to completion due to an explicit request."},
+	taskPaused:           {common.Warning, "The task with Id '%v' has been paused."},
+	taskResumed:          {common.OK, "The task with Id '%v' has been resumed."},
+}
+
+// taskEventMessageKey returns the TaskEvent registry message for the transition
+// of a task from previousState to its current state, it returns false when the
+// transition is not notified to the subscribers
+func taskEventMessageKey(task *tmodel.Task, previousState string) (string, bool) {
+	switch task.TaskState {
+	case common.Starting, common.Service:
+		return taskStarted, true
+	case common.Running:
+		switch previousState {
+		case common.New, common.Pending, common.Starting:
+			return taskStarted, true
+		case common.Suspended, common.Interrupted, common.Stopping:
+			return taskResumed, true
+		}
+		return taskProgressChanged, true
+	case common.Suspended, common.Interrupted, common.Stopping:
+		return taskPaused, true
+	case common.Completed:
+		switch task.TaskStatus {
+		case common.OK:
+			return taskCompletedOK, true
+		case common.Warning:
+			return taskCompletedWarning, true
+		}
+		return taskAborted, true
+	case common.Exception, common.Killed:
+		return taskAborted, true
+	case common.Cancelled:
+		return taskCancelled, true
+	}
+	return "", false
+}
+
+// taskStateChangeEvent builds the StatusChange event to be published for the
+// state transition of the task, the OriginOfCondition of the event is the task
+func taskStateChangeEvent(task *tmodel.Task, previousState string) (common.Event, bool) {
+	key, ok := taskEventMessageKey(task, previousState)
+	if !ok {
+		return common.Event{}, false
+	}
+	entry := taskEventRegistry[key]
+	messageArgs := []string{task.ID}
+	if key == taskProgressChanged {
+		messageArgs = append(messageArgs, fmt.Sprintf("%v", task.PercentComplete))
+	}
+	args := make([]interface{}, len(messageArgs))
+	for i, arg := range messageArgs {
+		args[i] = arg
+	}
+	return common.Event{
+		EventType:   "StatusChange",
+		MessageID:   common.TaskEventType + "." + key,
+		Severity:    entry.severity,
+		Message:     fmt.Sprintf(entry.message, args...),
+		MessageArgs: messageArgs,
+		OriginOfCondition: &common.Link{
+			Oid: taskEventOrigin(task),
+		},
+	}, true
+}
+
+// taskEventOrigin returns the URI through which the task is exposed,
+// a sub task is served under the SubTasks collection of its parent
+func taskEventOrigin(task *tmodel.Task) string {
+	if task.ParentID == "" {
+		return task.URI
+	}
+	return "/redfish/v1/TaskService/Tasks/" + task.ParentID + "/SubTasks/" + task.ID
+}
+
+// isTaskTerminalState returns true when no further update is expected for the task
+func isTaskTerminalState(taskState string) bool {
+	switch taskState {
+	case common.Completed, common.Exception, common.Killed, common.Cancelled:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package thandle
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/svc-task/tmodel"
+)
+
+func TestTaskStateChangeEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		task          tmodel.Task
+		previousState string
+		wantMessageID string
+		wantSeverity  string
+		wantArgs      []string
+		wantOrigin    string
+		wantOK        bool
+	}{
+		{
+			name:          "task started",
+			task:          tmodel.Task{ID: "task1", URI: "/redfish/v1/TaskService/Tasks/task1", TaskState: common.Running},
+			previousState: common.New,
+			wantMessageID: "TaskEvent.1.0.3.TaskStarted",
+			wantSeverity:  common.OK,
+			wantArgs:      []string{"task1"},
+			wantOrigin:    "/redfish/v1/TaskService/Tasks/task1",
+			wantOK:        true,
+		},
+		{
+			name:          "task progress",
+			task:          tmodel.Task{ID: "task1", URI: "/redfish/v1/TaskService/Tasks/task1", TaskState: common.Running, PercentComplete: 40},
+			previousState: common.Running,
+			wantMessageID: "TaskEvent.1.0.3.TaskProgressChanged",
+			wantSeverity:  common.OK,
+			wantArgs:      []string{"task1", "40"},
+			wantOrigin:    "/redfish/v1/TaskService/Tasks/task1",
+			wantOK:        true,
+		},
+		{
+			name:          "task completed with warning",
+			task:          tmodel.Task{ID: "task1", URI: "/redfish/v1/TaskService/Tasks/task1", TaskState: common.Completed, TaskStatus: common.Warning},
+			previousState: common.Running,
+			wantMessageID: "TaskEvent.1.0.3.TaskCompletedWarning",
+			wantSeverity:  common.Warning,
+			wantArgs:      []string{"task1"},
+			wantOrigin:    "/redfish/v1/TaskService/Tasks/task1",
+			wantOK:        true,
+		},
+		{
+			name:          "sub task aborted",
+			task:          tmodel.Task{ID: "task2", ParentID: "task1", URI: "/redfish/v1/TaskService/Tasks/task1/task2", TaskState: common.Exception, TaskStatus: common.Critical},
+			previousState: common.Running,
+			wantMessageID: "TaskEvent.1.0.3.TaskAborted",
+			wantSeverity:  common.Critical,
+			wantArgs:      []string{"task2"},
+			wantOrigin:    "/redfish/v1/TaskService/Tasks/task1/SubTasks/task2",
+			wantOK:        true,
+		},
+		{
+			name:          "new task is not notified",
+			task:          tmodel.Task{ID: "task1", TaskState: common.New},
+			previousState: common.New,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, ok := taskStateChangeEvent(&tt.task, tt.previousState)
+			if ok != tt.wantOK {
+				t.Fatalf("taskStateChangeEvent() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if event.MessageID != tt.wantMessageID || event.Severity != tt.wantSeverity ||
+				!reflect.DeepEqual(event.MessageArgs, tt.wantArgs) || event.OriginOfCondition.Oid != tt.wantOrigin {
+				t.Errorf("taskStateChangeEvent() = %+v", event)
+			}
+		})
+	}
+}
+
+func TestTasksRPC_updateTaskUtilPublishesTaskEvent(t *testing.T) {
+	defer setTaskServiceSettings(nil)
+	setTaskServiceSettings(&tmodel.TaskServiceSettings{LifeCycleEventOnTaskStateChange: true})
+	TaskCollection = TaskCollectionData{TaskCollection: make(map[string]int32)}
+	var published []common.Event
+	task := &tmodel.Task{ID: "task1", URI: "/redfish/v1/TaskService/Tasks/task1", TaskState: common.New}
+	ts := &TasksRPC{
+		GetTaskStatusModel: func(taskID string, db common.DbType) (*tmodel.Task, error) {
+			return task, nil
+		},
+		UpdateTaskStatusModel: mockUpdateTaskStatusModel,
+		PublishToMessageBus: func(event common.Event) {
+			published = append(published, event)
+		},
+	}
+	ts.updateTaskUtil("task1", common.Running, common.OK, 0, nil, time.Time{})
+	ts.updateTaskUtil("task1", common.Running, common.OK, 50, nil, time.Time{})
+	ts.updateTaskUtil("task1", common.Running, common.OK, 50, nil, time.Time{})
+	ts.updateTaskUtil("task1", common.Completed, common.OK, 100, nil, time.Now())
+	var got []string
+	for _, event := range published {
+		got = append(got, event.MessageID)
+	}
+	want := []string{"TaskEvent.1.0.3.TaskStarted", "TaskEvent.1.0.3.TaskProgressChanged", "TaskEvent.1.0.3.TaskCompletedOK"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("published events = %v, want %v", got, want)
+	}
+	if _, ok := TaskCollection.TaskCollection["task1"]; ok {
+		t.Errorf("progress of the completed task is not removed")
+	}
+}
@@ -0,0 +1,334 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package thandle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-task/tmodel"
+	"github.com/ODIM-Project/ODIM/svc-task/tresponse"
+)
+
+const (
+	// TaskHistoryURI is the URI to export the records of the deleted tasks
+	TaskHistoryURI = "/redfish/v1/TaskService/Oem/Odim/TaskHistory"
+	// overWritePolicyManual does not delete the completed tasks to make room for the new tasks
+	overWritePolicyManual = "Manual"
+	// overWritePolicyOldest deletes the oldest completed tasks to make room for the new tasks
+	overWritePolicyOldest = "Oldest"
+	// taskServiceSettingsRefreshInterval is the interval at which the settings
+	// updated by the other instances of the service are read again
+	taskServiceSettingsRefreshInterval = 30 * time.Second
+)
+
+// taskServiceSettings holds the TaskService settings updated with PATCH,
+// the values in the configuration are used till they are updated
+var taskServiceSettings = struct {
+	sync.Mutex
+	settings *tmodel.TaskServiceSettings
+	loadedAt time.Time
+}{}
+
+// defaultTaskServiceSettings returns the TaskService settings from the configuration
+func defaultTaskServiceSettings() tmodel.TaskServiceSettings {
+	settings := tmodel.TaskServiceSettings{
+		CompletedTaskOverWritePolicy:    overWritePolicyOldest,
+		LifeCycleEventOnTaskStateChange: true,
+		TaskAutoDeleteTimeoutMinutes:    config.DefaultTaskAutoDeleteTimeoutMinutes,
+		MaxCompletedTasksPerUser:        config.DefaultMaxCompletedTasksPerUser,
+	}
+	if config.Data.TaskConf != nil {
+		settings.TaskAutoDeleteTimeoutMinutes = config.Data.TaskConf.TaskAutoDeleteTimeoutMinutes
+		settings.MaxCompletedTasksPerUser = config.Data.TaskConf.MaxCompletedTasksPerUser
+	}
+	return settings
+}
+
+// setTaskServiceSettings sets the TaskService settings in effect
+func setTaskServiceSettings(settings *tmodel.TaskServiceSettings) {
+	taskServiceSettings.Lock()
+	defer taskServiceSettings.Unlock()
+	taskServiceSettings.settings = settings
+	taskServiceSettings.loadedAt = time.Time{}
+	if settings != nil {
+		taskServiceSettings.loadedAt = time.Now()
+	}
+}
+
+// currentTaskServiceSettings returns the TaskService settings in effect, the settings
+// saved by any instance of the service are read again once the refresh interval elapses
+func (ts *TasksRPC) currentTaskServiceSettings() tmodel.TaskServiceSettings {
+	taskServiceSettings.Lock()
+	defer taskServiceSettings.Unlock()
+	if ts.GetTaskServiceSettingsModel != nil && time.Since(taskServiceSettings.loadedAt) > taskServiceSettingsRefreshInterval {
+		settings, err := ts.GetTaskServiceSettingsModel()
+		if err != nil {
+			log.Error("error while getting the TaskService settings: " + err.Error())
+		} else {
+			taskServiceSettings.settings = settings
+			taskServiceSettings.loadedAt = time.Now()
+		}
+	}
+	if taskServiceSettings.settings != nil {
+		return *taskServiceSettings.settings
+	}
+	return defaultTaskServiceSettings()
+}
+
+// taskServiceResponse builds the TaskService resource with the settings in effect
+func taskServiceResponse(settings tmodel.TaskServiceSettings) tresponse.TaskServiceResponse {
+	// Check whether the Task Service is enbaled in configuration file.
+	//If so set ServiceEnabled to true.
+	isServiceEnabled := false
+	serviceState := "Disabled"
+	for _, service := range config.Data.EnabledServices {
+		if service == "TaskService" {
+			isServiceEnabled = true
+			serviceState = "Enabled"
+			break
+		}
+	}
+	commonResponse := response.Response{
+		OdataType:    "#TaskService.v1_2_0.TaskService",
+		ID:           "TaskService",
+		Name:         "TaskService",
+		Description:  "TaskService",
+		OdataContext: "/redfish/v1/$metadata#TaskService.TaskService",
+		OdataID:      "/redfish/v1/TaskService",
+	}
+	return tresponse.TaskServiceResponse{
+		Response:                        commonResponse,
+		CompletedTaskOverWritePolicy:    settings.CompletedTaskOverWritePolicy,
+		DateTime:                        time.Now().UTC(),
+		LifeCycleEventOnTaskStateChange: settings.LifeCycleEventOnTaskStateChange,
+		ServiceEnabled:                  isServiceEnabled,
+		Status: tresponse.Status{
+			State:        serviceState,
+			Health:       "OK",
+			HealthRollup: "OK",
+		},
+		Tasks: tresponse.Tasks{
+			OdataID: "/redfish/v1/TaskService/Tasks",
+		},
+		TaskAutoDeleteTimeoutMinutes: settings.TaskAutoDeleteTimeoutMinutes,
+		Oem: &tresponse.TaskServiceOem{
+			Odim: tresponse.TaskServiceOdimOem{
+				MaxCompletedTasksPerUser: settings.MaxCompletedTasksPerUser,
+				TaskHistory:              tresponse.ListMember{OdataID: TaskHistoryURI},
+			},
+		},
+	}
+}
+
+// UpdateTaskService is an API handler to update the settings of the TaskService and persist them,
+// the settings take effect on the next task created or updated by any instance of the service
+func (ts *TasksRPC) UpdateTaskService(ctx context.Context, req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error) {
+	var rsp taskproto.TaskResponse
+	constructCommonResponseHeader(&rsp)
+	authResp := ts.AuthenticationRPC(req.SessionToken, []string{common.PrivilegeConfigureManager})
+	if authResp.StatusCode != http.StatusOK {
+		fillProtoResponse(&rsp, authResp)
+		log.Error(authErrorMessage)
+		return &rsp, nil
+	}
+
+	var patchRequest map[string]interface{}
+	if err := json.Unmarshal(req.RequestBody, &patchRequest); err != nil {
+		errorMessage := "error while trying to unmarshal the TaskService update request: " + err.Error()
+		log.Error(errorMessage)
+		fillProtoResponse(&rsp, common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil))
+		return &rsp, nil
+	}
+	if len(patchRequest) == 0 {
+		errorMessage := "error: request body of the TaskService update is empty"
+		log.Error(errorMessage)
+		fillProtoResponse(&rsp, common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage, []interface{}{"CompletedTaskOverWritePolicy"}, nil))
+		return &rsp, nil
+	}
+
+	settings := ts.currentTaskServiceSettings()
+	if errResp := applyTaskServicePatch(&settings, patchRequest); errResp != nil {
+		fillProtoResponse(&rsp, *errResp)
+		return &rsp, nil
+	}
+	if err := ts.SaveTaskServiceSettingsModel(settings); err != nil {
+		errorMessage := "error while trying to save the TaskService settings: " + err.Error()
+		log.Error(errorMessage)
+		fillProtoResponse(&rsp, common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil))
+		return &rsp, nil
+	}
+	setTaskServiceSettings(&settings)
+
+	rsp.Header["Link"] = "</redfish/v1/SchemaStore/en/TaskService.json>; rel=describedby"
+	rsp.StatusCode = http.StatusOK
+	rsp.StatusMessage = response.Success
+	rsp.Body = generateResponse(taskServiceResponse(settings))
+	return &rsp, nil
+}
+
+// applyTaskServicePatch validates the properties in the PATCH request and sets them in settings
+func applyTaskServicePatch(settings *tmodel.TaskServiceSettings, patchRequest map[string]interface{}) *response.RPC {
+	badRequest := func(statusMessage, errorMessage string, args []interface{}) *response.RPC {
+		log.Error(errorMessage)
+		resp := common.GeneralError(http.StatusBadRequest, statusMessage, errorMessage, args, nil)
+		return &resp
+	}
+	for key, value := range patchRequest {
+		switch key {
+		case "CompletedTaskOverWritePolicy":
+			policy, ok := value.(string)
+			if !ok {
+				return badRequest(response.PropertyValueTypeError, "error: "+key+" must be a string", []interface{}{fmt.Sprint(value), key})
+			}
+			if policy != overWritePolicyManual && policy != overWritePolicyOldest {
+				return badRequest(response.PropertyValueNotInList, "error: "+key+" must be one of "+overWritePolicyManual+", "+overWritePolicyOldest, []interface{}{policy, key})
+			}
+			settings.CompletedTaskOverWritePolicy = policy
+		case "LifeCycleEventOnTaskStateChange":
+			enabled, ok := value.(bool)
+			if !ok {
+				return badRequest(response.PropertyValueTypeError, "error: "+key+" must be a boolean", []interface{}{fmt.Sprint(value), key})
+			}
+			settings.LifeCycleEventOnTaskStateChange = enabled
+		case "TaskAutoDeleteTimeoutMinutes":
+			minutes, errResp := positiveIntegerProperty(key, value)
+			if errResp != nil {
+				return errResp
+			}
+			settings.TaskAutoDeleteTimeoutMinutes = minutes
+		case "Oem":
+			oem, ok := value.(map[string]interface{})
+			if !ok {
+				return badRequest(response.PropertyValueTypeError, "error: Oem must be an object", []interface{}{fmt.Sprint(value), key})
+			}
+			for vendor, vendorValue := range oem {
+				odim, ok := vendorValue.(map[string]interface{})
+				if vendor != "Odim" || !ok {
+					return badRequest(response.PropertyUnknown, "error: Oem/"+vendor+" is not a writable property of TaskService", []interface{}{"Oem/" + vendor})
+				}
+				for property, propertyValue := range odim {
+					if property != "MaxCompletedTasksPerUser" {
+						return badRequest(response.PropertyUnknown, "error: Oem/Odim/"+property+" is not a writable property of TaskService", []interface{}{"Oem/Odim/" + property})
+					}
+					maxTasks, errResp := positiveIntegerProperty("Oem/Odim/"+property, propertyValue)
+					if errResp != nil {
+						return errResp
+					}
+					settings.MaxCompletedTasksPerUser = maxTasks
+				}
+			}
+		default:
+			return badRequest(response.PropertyUnknown, "error: "+key+" is not a writable property of TaskService", []interface{}{key})
+		}
+	}
+	return nil
+}
+
+// positiveIntegerProperty returns the value of the property when it is an integer greater than zero
+func positiveIntegerProperty(key string, value interface{}) (int, *response.RPC) {
+	number, ok := value.(float64)
+	if !ok || number != math.Trunc(number) {
+		errorMessage := "error: " + key + " must be an integer"
+		log.Error(errorMessage)
+		resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueTypeError, errorMessage, []interface{}{fmt.Sprint(value), key}, nil)
+		return 0, &resp
+	}
+	if number < 1 || number > math.MaxInt32 {
+		errorMessage := "error: " + key + " must not be less than 1"
+		log.Error(errorMessage)
+		resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage, []interface{}{fmt.Sprint(value), key}, nil)
+		return 0, &resp
+	}
+	return int(number), nil
+}
+
+// archiveTask keeps the record of the task for export before the task is deleted,
+// the record is removed once the configured retention time elapses
+func (ts *TasksRPC) archiveTask(task *tmodel.Task) error {
+	if ts.ArchiveTaskModel == nil {
+		return nil
+	}
+	retentionDays := config.DefaultTaskArchiveRetentionDays
+	if config.Data.TaskConf != nil {
+		retentionDays = config.Data.TaskConf.TaskArchiveRetentionDays
+	}
+	return ts.ArchiveTaskModel(tmodel.NewTaskRecord(task), retentionDays*24*60*60)
+}
+
+// ExportTaskHistory is an API handler to stream the records of the deleted tasks,
+// which are kept till the retention time elapses, as newline delimited JSON.
+// The first message has the response status, and the later messages have the records.
+func (ts *TasksRPC) ExportTaskHistory(req *taskproto.GetTaskRequest, stream taskproto.GetTaskService_ExportTaskHistoryServer) error {
+	authResp := ts.AuthenticationRPC(req.SessionToken, []string{common.PrivilegeConfigureUsers})
+	if authResp.StatusCode != http.StatusOK {
+		log.Error(authErrorMessage)
+		return stream.Send(taskHistoryResponse(authResp))
+	}
+	// the status is sent along with the first batch, so that a failure
+	// before any record is sent is responded with its status
+	statusSent := false
+	sendStatus := func() error {
+		if statusSent {
+			return nil
+		}
+		statusSent = true
+		return stream.Send(&taskproto.TaskHistoryRecord{StatusCode: http.StatusOK})
+	}
+	err := ts.GetArchivedTasksModel(func(records []tmodel.TaskRecord) error {
+		if err := sendStatus(); err != nil {
+			return err
+		}
+		for _, record := range records {
+			data, err := json.Marshal(record)
+			if err != nil {
+				log.Error("error while trying to marshal the archived task " + record.ID + ": " + err.Error())
+				continue
+			}
+			if err := stream.Send(&taskproto.TaskHistoryRecord{Record: data}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		errorMessage := "error while trying to get the archived tasks: " + err.Error()
+		log.Error(errorMessage)
+		if statusSent {
+			return err
+		}
+		return stream.Send(taskHistoryResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)))
+	}
+	return sendStatus()
+}
+
+func taskHistoryResponse(resp response.RPC) *taskproto.TaskHistoryRecord {
+	return &taskproto.TaskHistoryRecord{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       generateResponse(resp.Body),
+	}
+}
@@ -0,0 +1,276 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package thandle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
+	"github.com/ODIM-Project/ODIM/svc-task/tmodel"
+	"github.com/ODIM-Project/ODIM/svc-task/tresponse"
+	"google.golang.org/grpc"
+)
+
+type mockTaskHistoryStream struct {
+	grpc.ServerStream
+	messages []*taskproto.TaskHistoryRecord
+}
+
+func (m *mockTaskHistoryStream) Send(record *taskproto.TaskHistoryRecord) error {
+	m.messages = append(m.messages, record)
+	return nil
+}
+
+func completedTaskIndex(userName string, age time.Duration, taskID string) string {
+	endTime := time.Unix(0, time.Now().Add(-age).UnixNano()).UTC()
+	return userName + "::" + endTime.String() + "::" + taskID
+}
+
+func TestTasksRPC_UpdateTaskService(t *testing.T) {
+	defer setTaskServiceSettings(nil)
+	var saved *tmodel.TaskServiceSettings
+	ts := &TasksRPC{
+		AuthenticationRPC: mockIsAuthorized,
+		SaveTaskServiceSettingsModel: func(settings tmodel.TaskServiceSettings) error {
+			saved = &settings
+			return nil
+		},
+	}
+	tests := []struct {
+		name        string
+		token       string
+		body        string
+		wantStatus  int32
+		wantMessage string
+	}{
+		{"invalid session token", "InvalidToken", `{"TaskAutoDeleteTimeoutMinutes": 10}`, http.StatusUnauthorized, ""},
+		{"malformed request", "validToken", `{"TaskAutoDeleteTimeoutMinutes"`, http.StatusBadRequest, "MalformedJSON"},
+		{"empty request", "validToken", `{}`, http.StatusBadRequest, "PropertyMissing"},
+		{"unknown property", "validToken", `{"ServiceEnabled": false}`, http.StatusBadRequest, "PropertyUnknown"},
+		{"invalid overwrite policy", "validToken", `{"CompletedTaskOverWritePolicy": "Newest"}`, http.StatusBadRequest, "PropertyValueNotInList"},
+		{"invalid life cycle event type", "validToken", `{"LifeCycleEventOnTaskStateChange": "false"}`, http.StatusBadRequest, "PropertyValueTypeError"},
+		{"invalid timeout", "validToken", `{"TaskAutoDeleteTimeoutMinutes": 0}`, http.StatusBadRequest, "PropertyValueNotInList"},
+		{"unknown Oem property", "validToken", `{"Oem": {"Odim": {"MaxTasks": 10}}}`, http.StatusBadRequest, "PropertyUnknown"},
+		{"invalid max completed tasks", "validToken", `{"Oem": {"Odim": {"MaxCompletedTasksPerUser": 1.5}}}`, http.StatusBadRequest, "PropertyValueTypeError"},
+		{
+			"valid request", "validToken",
+			`{"CompletedTaskOverWritePolicy": "Manual", "LifeCycleEventOnTaskStateChange": false, "TaskAutoDeleteTimeoutMinutes": 60, "Oem": {"Odim": {"MaxCompletedTasksPerUser": 5}}}`,
+			http.StatusOK, "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rsp, err := ts.UpdateTaskService(context.Background(), &taskproto.GetTaskRequest{SessionToken: tt.token, RequestBody: []byte(tt.body)})
+			if err != nil || rsp.StatusCode != tt.wantStatus {
+				t.Fatalf("TasksRPC.UpdateTaskService() status = %v, error = %v, want %v", rsp.StatusCode, err, tt.wantStatus)
+			}
+			if tt.wantMessage != "" && !strings.Contains(string(rsp.Body), tt.wantMessage) {
+				t.Errorf("TasksRPC.UpdateTaskService() body = %s, want %v", rsp.Body, tt.wantMessage)
+			}
+		})
+	}
+
+	want := tmodel.TaskServiceSettings{
+		CompletedTaskOverWritePolicy:    "Manual",
+		LifeCycleEventOnTaskStateChange: false,
+		TaskAutoDeleteTimeoutMinutes:    60,
+		MaxCompletedTasksPerUser:        5,
+	}
+	if saved == nil || *saved != want {
+		t.Fatalf("TasksRPC.UpdateTaskService() saved settings = %v, want %v", saved, want)
+	}
+	rsp, _ := ts.GetTaskService(context.Background(), &taskproto.GetTaskRequest{SessionToken: "validToken"})
+	var taskService tresponse.TaskServiceResponse
+	if err := json.Unmarshal(rsp.Body, &taskService); err != nil {
+		t.Fatalf("error while unmarshaling the TaskService: %v", err)
+	}
+	if taskService.CompletedTaskOverWritePolicy != "Manual" || taskService.LifeCycleEventOnTaskStateChange ||
+		taskService.TaskAutoDeleteTimeoutMinutes != 60 || taskService.Oem == nil ||
+		taskService.Oem.Odim.MaxCompletedTasksPerUser != 5 || taskService.Oem.Odim.TaskHistory.OdataID != TaskHistoryURI {
+		t.Errorf("TasksRPC.GetTaskService() = %s", rsp.Body)
+	}
+}
+
+func TestTasksRPC_OverWriteCompletedTaskUtilLimits(t *testing.T) {
+	defer setTaskServiceSettings(nil)
+	taskList := []string{
+		completedTaskIndex("admin", 3*time.Hour, "task1"),
+		completedTaskIndex("operator", 2*time.Hour, "task2"),
+		completedTaskIndex("admin", 90*time.Minute, "task3"),
+		completedTaskIndex("admin", time.Hour, "task4"),
+		completedTaskIndex("admin", time.Minute, "task5"),
+	}
+	var deleted, archived []string
+	ts := &TasksRPC{
+		GetCompletedTasksIndexModel: func(userName string) ([]string, error) {
+			return taskList, nil
+		},
+		GetTaskStatusModel: func(taskID string, db common.DbType) (*tmodel.Task, error) {
+			return &tmodel.Task{ID: taskID}, nil
+		},
+		DeleteTaskFromDBModel: func(task *tmodel.Task) error {
+			deleted = append(deleted, task.ID)
+			return nil
+		},
+		DeleteTaskIndex: mockDeleteTaskIndex,
+		ArchiveTaskModel: func(record *tmodel.TaskRecord, retentionSeconds int) error {
+			archived = append(archived, record.ID)
+			return nil
+		},
+	}
+
+	setTaskServiceSettings(&tmodel.TaskServiceSettings{
+		CompletedTaskOverWritePolicy: overWritePolicyOldest,
+		TaskAutoDeleteTimeoutMinutes: 150,
+		MaxCompletedTasksPerUser:     2,
+	})
+	if err := ts.OverWriteCompletedTaskUtil("admin"); err != nil {
+		t.Fatalf("TasksRPC.OverWriteCompletedTaskUtil() error = %v", err)
+	}
+	if fmt.Sprint(deleted) != "[task1 task3]" || fmt.Sprint(archived) != "[task1 task3]" {
+		t.Errorf("TasksRPC.OverWriteCompletedTaskUtil() deleted %v, archived %v, want [task1 task3]", deleted, archived)
+	}
+
+	// the completed tasks are not deleted for the quota with the Manual policy
+	deleted = nil
+	setTaskServiceSettings(&tmodel.TaskServiceSettings{
+		CompletedTaskOverWritePolicy: overWritePolicyManual,
+		TaskAutoDeleteTimeoutMinutes: 150,
+		MaxCompletedTasksPerUser:     2,
+	})
+	if err := ts.OverWriteCompletedTaskUtil("admin"); err != nil {
+		t.Fatalf("TasksRPC.OverWriteCompletedTaskUtil() error = %v", err)
+	}
+	if fmt.Sprint(deleted) != "[task1]" {
+		t.Errorf("TasksRPC.OverWriteCompletedTaskUtil() with Manual policy deleted %v, want [task1]", deleted)
+	}
+	if err := ts.checkCompletedTaskLimit("admin"); err == nil {
+		t.Errorf("TasksRPC.checkCompletedTaskLimit() expected error for the user with 4 completed tasks")
+	}
+	if err := ts.checkCompletedTaskLimit("operator"); err != nil {
+		t.Errorf("TasksRPC.checkCompletedTaskLimit() error = %v", err)
+	}
+}
+
+func TestTasksRPC_deleteCompletedTaskArchiveFailure(t *testing.T) {
+	var deleted bool
+	ts := &TasksRPC{
+		GetTaskStatusModel: func(taskID string, db common.DbType) (*tmodel.Task, error) {
+			return &tmodel.Task{ID: taskID}, nil
+		},
+		DeleteTaskFromDBModel: func(task *tmodel.Task) error {
+			deleted = true
+			return nil
+		},
+		ArchiveTaskModel: func(record *tmodel.TaskRecord, retentionSeconds int) error {
+			return fmt.Errorf("error while trying to connecting to DB")
+		},
+	}
+	if err := ts.deleteCompletedTask("task1"); err == nil || deleted {
+		t.Errorf("TasksRPC.deleteCompletedTask() error = %v, deleted = %v, want the task retained", err, deleted)
+	}
+}
+
+func TestTasksRPC_updateTaskUtilLifeCycleEvent(t *testing.T) {
+	defer setTaskServiceSettings(nil)
+	TaskCollection = TaskCollectionData{TaskCollection: make(map[string]int32)}
+	var published int
+	ts := &TasksRPC{
+		GetTaskStatusModel: func(taskID string, db common.DbType) (*tmodel.Task, error) {
+			return &tmodel.Task{ID: taskID, TaskState: common.Running}, nil
+		},
+		UpdateTaskStatusModel: mockUpdateTaskStatusModel,
+		PublishToMessageBus: func(event common.Event) {
+			published++
+		},
+	}
+	if err := ts.updateTaskUtil("task1", common.Running, common.OK, 10, nil, time.Time{}); err != nil || published != 1 {
+		t.Fatalf("TasksRPC.updateTaskUtil() error = %v, published = %v, want 1", err, published)
+	}
+	setTaskServiceSettings(&tmodel.TaskServiceSettings{LifeCycleEventOnTaskStateChange: false})
+	if err := ts.updateTaskUtil("task1", common.Running, common.OK, 20, nil, time.Time{}); err != nil || published != 1 {
+		t.Errorf("TasksRPC.updateTaskUtil() with events disabled error = %v, published = %v, want 1", err, published)
+	}
+}
+
+func TestTasksRPC_ExportTaskHistory(t *testing.T) {
+	records := []tmodel.TaskRecord{
+		{ID: "task1", UserName: "admin", TaskState: common.Completed},
+		{ID: "task2", UserName: "operator", TaskState: common.Exception},
+	}
+	ts := &TasksRPC{
+		AuthenticationRPC: mockIsAuthorized,
+		GetArchivedTasksModel: func(send func([]tmodel.TaskRecord) error) error {
+			for _, record := range records {
+				if err := send([]tmodel.TaskRecord{record}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	stream := &mockTaskHistoryStream{}
+	if err := ts.ExportTaskHistory(&taskproto.GetTaskRequest{SessionToken: "InvalidToken"}, stream); err != nil {
+		t.Fatalf("TasksRPC.ExportTaskHistory() error = %v", err)
+	}
+	if len(stream.messages) != 1 || stream.messages[0].StatusCode != http.StatusUnauthorized {
+		t.Errorf("TasksRPC.ExportTaskHistory() with invalid token = %v", stream.messages)
+	}
+
+	stream = &mockTaskHistoryStream{}
+	if err := ts.ExportTaskHistory(&taskproto.GetTaskRequest{SessionToken: "validToken"}, stream); err != nil {
+		t.Fatalf("TasksRPC.ExportTaskHistory() error = %v", err)
+	}
+	if len(stream.messages) != 3 || stream.messages[0].StatusCode != http.StatusOK {
+		t.Fatalf("TasksRPC.ExportTaskHistory() = %v, want the status and 2 records", stream.messages)
+	}
+	var record tmodel.TaskRecord
+	if err := json.Unmarshal(stream.messages[2].Record, &record); err != nil || record.ID != "task2" || record.UserName != "operator" {
+		t.Errorf("TasksRPC.ExportTaskHistory() record = %s, error = %v", stream.messages[2].Record, err)
+	}
+
+	ts.GetArchivedTasksModel = func(send func([]tmodel.TaskRecord) error) error {
+		return fmt.Errorf("DB unavailable")
+	}
+	stream = &mockTaskHistoryStream{}
+	if err := ts.ExportTaskHistory(&taskproto.GetTaskRequest{SessionToken: "validToken"}, stream); err != nil {
+		t.Fatalf("TasksRPC.ExportTaskHistory() error = %v", err)
+	}
+	if len(stream.messages) != 1 || stream.messages[0].StatusCode != http.StatusInternalServerError {
+		t.Errorf("TasksRPC.ExportTaskHistory() with failed read = %v", stream.messages)
+	}
+
+	ts.GetArchivedTasksModel = func(send func([]tmodel.TaskRecord) error) error {
+		if err := send(records); err != nil {
+			return err
+		}
+		return fmt.Errorf("DB unavailable")
+	}
+	stream = &mockTaskHistoryStream{}
+	if err := ts.ExportTaskHistory(&taskproto.GetTaskRequest{SessionToken: "validToken"}, stream); err == nil {
+		t.Errorf("TasksRPC.ExportTaskHistory() with failed read after the records are sent, error = nil")
+	}
+	if len(stream.messages) != 3 || stream.messages[0].StatusCode != http.StatusOK {
+		t.Errorf("TasksRPC.ExportTaskHistory() with failed read after the records are sent = %v", stream.messages)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"path"
 	"strings"
 	"sync"
 	"time"
@@ -27,7 +28,6 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
-	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-task/tmodel"
@@ -57,7 +57,12 @@ type TasksRPC struct {
 	UpdateTaskStatusModel            func(t *tmodel.Task, db common.DbType) error
 	PersistTaskModel                 func(t *tmodel.Task, db common.DbType) error
 	ValidateTaskUserNameModel        func(userName string) error
-	PublishToMessageBus              func(taskURI string, taskEvenMessageID string, eventType string, taskMessage string)
+	PublishToMessageBus              func(event common.Event)
+	CancelOwnerTaskRPC               func(serviceName string, taskID string) (bool, error)
+	GetTaskServiceSettingsModel      func() (*tmodel.TaskServiceSettings, error)
+	SaveTaskServiceSettingsModel     func(settings tmodel.TaskServiceSettings) error
+	ArchiveTaskModel                 func(record *tmodel.TaskRecord, retentionSeconds int) error
+	GetArchivedTasksModel            func(send func([]tmodel.TaskRecord) error) error
 }
 
 //TaskCollectionData ....
@@ -66,23 +71,25 @@ type TaskCollectionData struct {
 	Lock           sync.Mutex
 }
 
+// getTaskFromCollectionData returns true when the progress of the task is
+// already notified, otherwise the progress is recorded for the task
 func (t *TaskCollectionData) getTaskFromCollectionData(taskID string, percentComplete int) bool {
 	t.Lock.Lock()
 	defer t.Lock.Unlock()
-	if prevComplete, ok := t.TaskCollection[fmt.Sprintf("%s:%v", taskID, percentComplete)]; ok {
-		if prevComplete == int32(percentComplete) {
-			return true
-		} else if percentComplete == 100 {
-			delete(t.TaskCollection, taskID)
-			return false
-		}
-
+	if prevComplete, ok := t.TaskCollection[taskID]; ok && prevComplete == int32(percentComplete) {
+		return true
 	}
 	t.TaskCollection[taskID] = int32(percentComplete)
-
 	return false
 }
 
+// removeTaskFromCollectionData removes the progress recorded for the task
+func (t *TaskCollectionData) removeTaskFromCollectionData(taskID string) {
+	t.Lock.Lock()
+	defer t.Lock.Unlock()
+	delete(t.TaskCollection, taskID)
+}
+
 var (
 	// TaskCollection ...
 	TaskCollection TaskCollectionData
@@ -91,6 +98,11 @@ var (
 //CreateTask is a rpc handler which intern call actual CreatTask to create new task
 func (ts *TasksRPC) CreateTask(ctx context.Context, req *taskproto.CreateTaskRequest) (*taskproto.CreateTaskResponse, error) {
 	var rsp taskproto.CreateTaskResponse
+	// With the Manual overwrite policy the completed tasks are not deleted
+	// to make room for the new task, so the task is not created
+	if err := ts.checkCompletedTaskLimit(req.UserName); err != nil {
+		return &rsp, err
+	}
 	// Check for completed task if there are any, get the oldest Completed
 	//Task and Delete from the db along with it subtask as well.
 	// Search for the Completed tasks
@@ -101,11 +113,32 @@ func (ts *TasksRPC) CreateTask(ctx context.Context, req *taskproto.CreateTaskReq
 		}
 	}()
 	taskURI, err := ts.CreateTaskUtilHelper(req.UserName)
+	if err == nil && req.ServiceName != "" {
+		err = ts.setTaskOwner(taskURI, req.ServiceName)
+	}
 	rsp.TaskURI = taskURI
 	return &rsp, err
 }
 
-//OverWriteCompletedTaskUtil is helper method to find and delete eligible completed task
+// setTaskOwner records the service instance which runs the task
+func (ts *TasksRPC) setTaskOwner(taskURI, serviceName string) error {
+	task, err := ts.GetTaskStatusModel(path.Base(taskURI), common.InMemory)
+	if err != nil {
+		log.Error("error while retrieving the task details from DB: " + err.Error())
+		return err
+	}
+	task.ServiceName = serviceName
+	if err = ts.UpdateTaskStatusModel(task, common.InMemory); err != nil {
+		log.Error("error while updating the task details in to DB: " + err.Error())
+		return err
+	}
+	return nil
+}
+
+//OverWriteCompletedTaskUtil is helper method to find and delete eligible completed task.
+//The completed tasks older than TaskAutoDeleteTimeoutMinutes are deleted, and with
+//the Oldest overwrite policy the oldest completed tasks of the user are deleted
+//to retain not more than MaxCompletedTasksPerUser tasks.
 func (ts *TasksRPC) OverWriteCompletedTaskUtil(userName string) error {
 	var taskID string
 
@@ -114,26 +147,64 @@ func (ts *TasksRPC) OverWriteCompletedTaskUtil(userName string) error {
 		log.Error("error while getting the completed task: " + err.Error())
 		return err
 	}
+	settings := ts.currentTaskServiceSettings()
+	timeToLive := time.Duration(settings.TaskAutoDeleteTimeoutMinutes) * time.Minute
 	inputTimeStringformat := "2006-01-02 15:04:05 +0000 UTC"
+	// the index is sorted by the end time, so the oldest tasks of the user are first
+	var userTaskIDs []string
 	for _, value := range taskList {
 		endTimeString := (strings.Split(value, "::"))[1]
 		endTime, _ := time.Parse(inputTimeStringformat, endTimeString)
-		timeNow := time.Now().UnixNano()
-		elapsedTimeNano := timeNow - endTime.UnixNano()
-		timeToLeaveString, _ := time.ParseDuration("24h")
-		timeToLeaveNano := timeToLeaveString.Nanoseconds()
 		taskID = (strings.Split(value, "::"))[2]
-		if elapsedTimeNano > timeToLeaveNano {
+		if time.Since(endTime) > timeToLive {
 			err = ts.deleteCompletedTask(taskID)
 			if err != nil {
 				log.Error("error while deleting the completed task: " + err.Error())
 
 			}
+			continue
+		}
+		if (strings.Split(value, "::"))[0] == userName {
+			userTaskIDs = append(userTaskIDs, taskID)
+		}
+	}
+	if settings.CompletedTaskOverWritePolicy == overWritePolicyManual {
+		return nil
+	}
+	for i := 0; i < len(userTaskIDs)-settings.MaxCompletedTasksPerUser; i++ {
+		if err = ts.deleteCompletedTask(userTaskIDs[i]); err != nil {
+			log.Error("error while deleting the completed task: " + err.Error())
 		}
 	}
 	return nil
 }
 
+// checkCompletedTaskLimit returns error when the overwrite policy is Manual
+// and the user has MaxCompletedTasksPerUser completed tasks already
+func (ts *TasksRPC) checkCompletedTaskLimit(userName string) error {
+	settings := ts.currentTaskServiceSettings()
+	if settings.CompletedTaskOverWritePolicy != overWritePolicyManual {
+		return nil
+	}
+	taskList, err := ts.GetCompletedTasksIndexModel(userName)
+	if err != nil {
+		log.Error("error while getting the completed task: " + err.Error())
+		return err
+	}
+	completedTasks := 0
+	for _, value := range taskList {
+		if strings.HasPrefix(value, userName+"::") {
+			completedTasks++
+		}
+	}
+	if completedTasks >= settings.MaxCompletedTasksPerUser {
+		errorMessage := fmt.Sprintf("error: user %v has %v completed tasks, which must be deleted before creating a new task", userName, completedTasks)
+		log.Error(errorMessage)
+		return fmt.Errorf(errorMessage)
+	}
+	return nil
+}
+
 func (ts *TasksRPC) deleteCompletedTask(taskID string) error {
 	task, err := ts.GetTaskStatusModel(taskID, common.InMemory)
 	if err != nil {
@@ -146,11 +217,20 @@ func (ts *TasksRPC) deleteCompletedTask(taskID string) error {
 			log.Error("error getting task status : " + err.Error())
 			continue
 		}
+		if err = ts.archiveTask(subTask); err != nil {
+			log.Error("error while archiving the subtask: " + err.Error())
+			return err
+		}
 		err = ts.DeleteTaskFromDBModel(subTask)
 		if err != nil {
 			log.Error("error while deleting subtask: " + err.Error())
 		}
 	}
+	// The task is retained when it could not be archived, so that it is not lost from the task history
+	if err = ts.archiveTask(task); err != nil {
+		log.Error("error while archiving the main task: " + err.Error())
+		return err
+	}
 	err = ts.DeleteTaskFromDBModel(task)
 	if err != nil {
 		log.Error("error while deleting the main task: " + err.Error())
@@ -167,7 +247,7 @@ func (ts *TasksRPC) deleteCompletedTask(taskID string) error {
 //CreateChildTask is a rpc handler which intern call actual CreateChildTask to create sub task under parent task.
 func (ts *TasksRPC) CreateChildTask(ctx context.Context, req *taskproto.CreateTaskRequest) (*taskproto.CreateTaskResponse, error) {
 	var rsp taskproto.CreateTaskResponse
-	taskURI, err := ts.CreateChildTaskUtil(req.UserName, req.ParentTaskID)
+	taskURI, err := ts.CreateChildTaskUtil(req.UserName, req.ParentTaskID, req.ServiceName)
 	rsp.TaskURI = taskURI
 	return &rsp, err
 }
@@ -340,7 +420,8 @@ func (ts *TasksRPC) taskCancelCallBack(taskID string) error {
 		log.Error("error getting task status : " + err.Error())
 		return nil
 	}
-	if task.TaskState == common.Completed || task.TaskState == common.Exception || task.TaskState == common.Pending {
+	if task.TaskState == common.Completed || task.TaskState == common.Exception || task.TaskState == common.Pending ||
+		task.TaskState == common.Cancelled {
 		// check if this task has any child tasks, if so delete them.
 		for _, subTaskID := range task.ChildTaskIDs {
 			subTask, err := ts.GetTaskStatusModel(subTaskID, common.InMemory)
@@ -348,8 +429,14 @@ func (ts *TasksRPC) taskCancelCallBack(taskID string) error {
 				log.Error("error getting task status : " + err.Error())
 				continue
 			}
+			if err = ts.archiveTask(subTask); err != nil {
+				log.Error("error while archiving the subtask: " + err.Error())
+			}
 			ts.DeleteTaskFromDBModel(subTask)
 		}
+		if err = ts.archiveTask(task); err != nil {
+			log.Error("error while archiving the task: " + err.Error())
+		}
 		err = ts.DeleteTaskFromDBModel(task)
 		return nil
 	}
@@ -371,6 +458,7 @@ func (ts *TasksRPC) taskCancelCallBack(taskID string) error {
 				log.Error("error while updating the task: " + err.Error())
 				return err
 			}
+			go ts.cancelOwnerTask(subTask)
 			go ts.asyncTaskDelete(subTaskID)
 		}
 	}
@@ -382,12 +470,31 @@ func (ts *TasksRPC) taskCancelCallBack(taskID string) error {
 			log.Error("error while updating the task: " + err.Error())
 			return err
 		}
+		go ts.cancelOwnerTask(task)
 		go ts.asyncTaskDelete(taskID)
 	}
 
 	return nil
 }
 
+// cancelOwnerTask sends the cancel request to the service instance which runs the task,
+// so that the operation in progress is stopped right away and the job started by it
+// on the plugin is aborted. Tasks of the services which are not running the operation
+// any more or which could not be reached are cancelled on their next update.
+func (ts *TasksRPC) cancelOwnerTask(task *tmodel.Task) {
+	if task.ServiceName == "" || ts.CancelOwnerTaskRPC == nil {
+		return
+	}
+	inProgress, err := ts.CancelOwnerTaskRPC(task.ServiceName, task.ID)
+	if err != nil {
+		log.Warn("unable to send the cancel request of the task " + task.ID + " to " + task.ServiceName + ": " + err.Error())
+		return
+	}
+	if !inProgress {
+		log.Info("task " + task.ID + " is not in progress in " + task.ServiceName + ", it will be cancelled on its next update")
+	}
+}
+
 func (ts *TasksRPC) asyncTaskDelete(taskID string) {
 	//Polling for the taskstate.
 	//If the taskstate becomes Cancelled, then this means the thread associated with this task exited succefully,
@@ -401,6 +508,14 @@ func (ts *TasksRPC) asyncTaskDelete(taskID string) {
 			return
 		}
 		if task.TaskState == common.Cancelled {
+			// The sub tasks whose operation could not be aborted are retained along with the task to report it
+			if ts.hasExceptionSubTask(task) {
+				log.Warn("task " + taskID + " is cancelled, but the operation of one or more of its sub tasks could not be aborted")
+				break
+			}
+			if err = ts.archiveTask(task); err != nil {
+				log.Error("error while archiving the task: " + err.Error())
+			}
 			err = ts.DeleteTaskFromDBModel(task)
 			if err != nil {
 				log.Error("error unable to delete the task from db: " + err.Error())
@@ -408,11 +523,26 @@ func (ts *TasksRPC) asyncTaskDelete(taskID string) {
 			}
 			break
 		}
+		// The operation could not be aborted, the task is retained to report it
+		if task.TaskState == common.Exception {
+			log.Warn("task " + taskID + " could not be cancelled, the operation associated with it has completed with errors")
+			break
+		}
 		time.Sleep(5000 * time.Millisecond)
 	}
 	return
 }
 
+func (ts *TasksRPC) hasExceptionSubTask(task *tmodel.Task) bool {
+	for _, subTaskID := range task.ChildTaskIDs {
+		subTask, err := ts.GetTaskStatusModel(subTaskID, common.InMemory)
+		if err == nil && subTask.TaskState == common.Exception {
+			return true
+		}
+	}
+	return false
+}
+
 //GetSubTasks is an API end point to get all available tasks
 func (ts *TasksRPC) GetSubTasks(ctx context.Context, req *taskproto.GetTaskRequest) (*taskproto.TaskResponse, error) {
 	var rsp taskproto.TaskResponse
@@ -738,46 +868,10 @@ func (ts *TasksRPC) GetTaskService(ctx context.Context, req *taskproto.GetTaskRe
 		return &rsp, nil
 	}
 
-	// Check whether the Task Service is enbaled in configuration file.
-	//If so set ServiceEnabled to true.
-	isServiceEnabled := false
-	serviceState := "Disabled"
-	for _, service := range config.Data.EnabledServices {
-		if service == "TaskService" {
-			isServiceEnabled = true
-			serviceState = "Enabled"
-			break
-		}
-	}
-
 	rsp.StatusCode = http.StatusOK
 	rsp.StatusMessage = response.Success
-	commonResponse := response.Response{
-		OdataType:    "#TaskService.v1_2_0.TaskService",
-		ID:           "TaskService",
-		Name:         "TaskService",
-		Description:  "TaskService",
-		OdataContext: "/redfish/v1/$metadata#TaskService.TaskService",
-		OdataID:      "/redfish/v1/TaskService",
-	}
-
 	// Construct the response body hear as below
-	taskServiceResponse := tresponse.TaskServiceResponse{
-		Response:                        commonResponse,
-		CompletedTaskOverWritePolicy:    "Oldest",
-		DateTime:                        time.Now().UTC(),
-		LifeCycleEventOnTaskStateChange: true,
-		ServiceEnabled:                  isServiceEnabled,
-		Status: tresponse.Status{
-			State:        serviceState,
-			Health:       "OK",
-			HealthRollup: "OK",
-		},
-		Tasks: tresponse.Tasks{
-			OdataID: "/redfish/v1/TaskService/Tasks",
-		},
-	}
-	rsp.Body = generateResponse(taskServiceResponse)
+	rsp.Body = generateResponse(taskServiceResponse(ts.currentTaskServiceSettings()))
 	return &rsp, nil
 }
 
@@ -845,11 +939,12 @@ func (ts *TasksRPC) CreateTaskUtil(userName string) (string, error) {
 //CreateChildTaskUtil Creates the child task and attaches to the parent task provided.
 // Taskes:
 //	parentTaskID of type string - Contains Parent task ID for Child task yet to be created
+//	serviceName of type string - Contains the name of the service instance which runs the Child task
 // Returns:
 //	err of type error
 //	nil - On Success
 //	Non nil - On Failure
-func (ts *TasksRPC) CreateChildTaskUtil(userName string, parentTaskID string) (string, error) {
+func (ts *TasksRPC) CreateChildTaskUtil(userName string, parentTaskID string, serviceName string) (string, error) {
 
 	var parentTask *tmodel.Task
 	var childTask *tmodel.Task
@@ -884,6 +979,7 @@ func (ts *TasksRPC) CreateChildTaskUtil(userName string, parentTaskID string) (s
 		return "", fmt.Errorf("error while retrieving the child/sub task from DB: " + err.Error())
 	}
 	childTask.ParentID = parentTaskID
+	childTask.ServiceName = serviceName
 	childTask.URI = "/redfish/v1/TaskService/Tasks/" + parentTaskID + "/" + childTaskID
 	// Store the updated task in to In Memory DB
 	err = ts.UpdateTaskStatusModel(childTask, common.InMemory)
@@ -916,17 +1012,20 @@ func (ts *TasksRPC) CreateChildTaskUtil(userName string, parentTaskID string) (s
 func (ts *TasksRPC) updateTaskUtil(taskID string, taskState string, taskStatus string, percentComplete int32, payLoad *taskproto.Payload, endTime time.Time) error {
 
 	var task *tmodel.Task
-	var taskEvenMessageID, taskMessage string
 	// Retrieve the task details using taskID
 	task, err := ts.GetTaskStatusModel(taskID, common.InMemory)
 	if err != nil {
 		return fmt.Errorf("error while retrieving the task details from db: " + err.Error())
 	}
+	previousState := task.TaskState
 	//If the task is already in cancelled state, then updates are not allowed to it.
 	if task.TaskState == common.Cancelled {
 		return fmt.Errorf(common.Cancelled)
 	}
-	if task.TaskState == common.Cancelling && taskState != common.Cancelled {
+	// A task doing the work itself reports an Exception when the job it started
+	// on the plugin could not be aborted, the parent tasks are only cancelled.
+	if task.TaskState == common.Cancelling && taskState != common.Cancelled &&
+		(taskState != common.Exception || len(task.ChildTaskIDs) != 0) {
 		return fmt.Errorf(common.Cancelling)
 	}
 	// Set the task state
@@ -956,9 +1055,6 @@ func (ts *TasksRPC) updateTaskUtil(taskID string, taskState string, taskStatus s
 			task.TaskResponse = payLoad.ResponseBody
 		}
 		task.PercentComplete = percentComplete
-		// Constuct the appropriate messageID for task status change nitification
-		taskEvenMessageID = common.TaskEventType + ".Task" + taskState + taskStatus
-		taskMessage = fmt.Sprintf("The task with Id %v has completed.", taskID)
 	case "Killed":
 		/*This state shall represent that the operation is complete because the task
 		was killed by an operator. Deprecated v1.2+. This value has been deprecated
@@ -980,9 +1076,6 @@ func (ts *TasksRPC) updateTaskUtil(taskID string, taskState string, taskStatus s
 			task.StatusCode = payLoad.StatusCode
 		}
 		task.EndTime = endTime
-		// Constuct the appropriate messageID for task status change nitification
-		taskEvenMessageID = common.TaskEventType + ".TaskAborted"
-		taskMessage = fmt.Sprintf("The task with Id %v has completed with errors.", taskID)
 	case "Cancelled":
 		/* This state shall represent that the operation was cancelled either
 		through a Delete on a Task Monitor or Task Resource or by an internal
@@ -1003,9 +1096,6 @@ func (ts *TasksRPC) updateTaskUtil(taskID string, taskState string, taskStatus s
 			task.StatusCode = payLoad.StatusCode
 		}
 		task.EndTime = endTime
-		// Constuct the appropriate messageID for task status change nitification
-		taskEvenMessageID = common.TaskEventType + ".Task" + taskState
-		taskMessage = fmt.Sprintf("Work on the task with Id %v has been halted prior to completion due to an explicit request.", taskID)
 	case "Exception":
 		/* This state shall represent that the operation is complete and
 		completed with errors.
@@ -1030,17 +1120,11 @@ func (ts *TasksRPC) updateTaskUtil(taskID string, taskState string, taskStatus s
 			task.TaskResponse = payLoad.ResponseBody
 		}
 		task.PercentComplete = percentComplete
-		// Constuct the appropriate messageID for task status change nitification
-		taskEvenMessageID = common.TaskEventType + ".Task" + taskState + taskStatus
-		taskMessage = fmt.Sprintf("The task with Id %v has completed with errors.", taskID)
 	case "Cancelling":
 		/*This state shall represent that the operation is in the process of being
 		cancelled.
 		*/
 		task.TaskState = taskState
-		// Constuct the appropriate messageID for task status change nitification
-		taskEvenMessageID = common.TaskEventType + ".Task" + taskState
-		taskMessage = fmt.Sprintf("Work on the task with Id %v has been halted prior to completion due to an explicit request.", taskID)
 		// TODO
 	case "Interrupted":
 		/* This state shall represent that the operation has been interrupted but is
@@ -1051,9 +1135,6 @@ func (ts *TasksRPC) updateTaskUtil(taskID string, taskState string, taskStatus s
 			task.StatusCode = payLoad.StatusCode
 		}
 		task.PercentComplete = percentComplete
-		// Constuct the appropriate messageID for task status change nitification
-		taskEvenMessageID = common.TaskEventType + ".Task" + taskState
-		taskMessage = fmt.Sprintf("The task with Id %v has completed with errors..", taskID)
 		// TODO
 	case "New":
 		/* This state shall represent that this task is newly created but the
@@ -1061,60 +1142,39 @@ func (ts *TasksRPC) updateTaskUtil(taskID string, taskState string, taskStatus s
 		*/
 		task.TaskState = taskState
 		task.PercentComplete = percentComplete
-		// Constuct the appropriate messageID for task status change nitification
-		taskEvenMessageID = common.TaskEventType + ".Task" + taskState
-		taskMessage = fmt.Sprintf("The task with Id %v has started.", taskID)
 		// TODO
 	case "Pending":
 		/*This state shall represent that the operation is pending some condition and
 		has not yet begun to execute.
 		*/
 		task.TaskState = taskState
-		// Constuct the appropriate messageID for task status change nitification
-		taskEvenMessageID = common.TaskEventType + ".Task" + taskState
-		taskMessage = fmt.Sprintf("The task with Id %v has completed with errors.", taskID)
 		// TODO
 	case "Running":
 		// This state shall represent that the operation is executing.
 		task.TaskState = taskState
 		task.PercentComplete = percentComplete
-		// Constuct the appropriate messageID for task status change nitification
-		taskEvenMessageID = common.TaskEventType + ".TaskProgressChanged"
-		taskMessage = fmt.Sprintf("The task with Id %v has changed to progress %v percent complete.", taskID, percentComplete)
 		// TODO
 	case "Service":
 		/* This state shall represent that the operation is now running as a service
 		and expected to continue operation until stopped or killed.
 		*/
 		task.TaskState = taskState
-		// Constuct the appropriate messageID for task status change nitification
-		taskEvenMessageID = common.TaskEventType + ".Task" + taskState
-		taskMessage = fmt.Sprintf("The task with Id %v has started.", taskID)
 		// TODO
 	case "Starting":
 		// This state shall represent that the operation is starting.
 		task.TaskState = taskState
-		// Constuct the appropriate messageID for task status change nitification
-		taskEvenMessageID = common.TaskEventType + ".Task" + taskState
-		taskMessage = fmt.Sprintf("The task with Id %v has started.", taskID)
 		// TODO
 	case "Stopping":
 		/* This state shall represent that the operation is stopping but is not yet
 		complete.
 		*/
 		task.TaskState = taskState
-		// Constuct the appropriate messageID for task status change nitification
-		taskEvenMessageID = common.TaskEventType + ".Task" + taskState
-		taskMessage = fmt.Sprintf("The task with Id %v has been paused.", taskID)
 		// TODO
 	case "Suspended":
 		/*This state shall represent that the operation has been suspended but is
 		expected to restart and is therefore not complete.
 		*/
 		task.TaskState = taskState
-		// Constuct the appropriate messageID for task status change nitification
-		taskEvenMessageID = common.TaskEventType + ".Task" + taskState
-		taskMessage = fmt.Sprintf("The task with Id %v has completed with errors.", taskID)
 		// TODO
 	default:
 		log.Error("error invalid task state")
@@ -1126,11 +1186,22 @@ func (ts *TasksRPC) updateTaskUtil(taskID string, taskState string, taskStatus s
 		log.Error("error while updating the task in to In-memory DB: " + err.Error())
 		return fmt.Errorf("error while updating the task in to In-memory DB: " + err.Error())
 	}
-	// Notify the user about task state change by sending statuschange event
-	//	notifyTaskStateChange(task.URI, taskEvenMessageID)
-	eventType := "StatusChange"
-	if !TaskCollection.getTaskFromCollectionData(taskID, int(percentComplete)) {
-		ts.PublishToMessageBus(task.URI, taskEvenMessageID, eventType, taskMessage)
+	// Notify the subscribers about the task state change by sending the
+	// TaskEvent registry message for the new state
+	if !ts.currentTaskServiceSettings().LifeCycleEventOnTaskStateChange {
+		return nil
+	}
+	event, ok := taskStateChangeEvent(task, previousState)
+	if !ok {
+		return nil
+	}
+	if event.MessageID == common.TaskEventType+"."+taskProgressChanged &&
+		TaskCollection.getTaskFromCollectionData(taskID, int(percentComplete)) {
+		return nil
 	}
-	return err
+	if isTaskTerminalState(task.TaskState) {
+		TaskCollection.removeTaskFromCollectionData(taskID)
+	}
+	ts.PublishToMessageBus(event)
+	return nil
 }
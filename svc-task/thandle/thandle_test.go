@@ -136,7 +136,7 @@ func mockUpdateTaskStatusModel(task *tmodel.Task, db common.DbType) error {
 	return nil
 }
 
-func mockPublishToMessageBus(taskURI, taskEvenMessageID, eventType, taskMessage string) {
+func mockPublishToMessageBus(event common.Event) {
 
 }
 func mockValidateTaskUserNameModel(userName string) error {
@@ -1797,3 +1797,82 @@ func TestTasksRPC_taskCancelCallBack(t *testing.T) {
 		})
 	}
 }
+
+func TestTasksRPC_cancelOwnerTask(t *testing.T) {
+	var calledWith []string
+	ts := &TasksRPC{
+		CancelOwnerTaskRPC: func(serviceName, taskID string) (bool, error) {
+			calledWith = append(calledWith, serviceName+":"+taskID)
+			if serviceName == "svc.unreachable" {
+				return false, fmt.Errorf("No service with svc.unreachable found in the service registry")
+			}
+			return true, nil
+		},
+	}
+	ts.cancelOwnerTask(&tmodel.Task{ID: "taskWithoutOwner"})
+	ts.cancelOwnerTask(&tmodel.Task{ID: "RunningSubTaskID", ServiceName: "svc.update-1"})
+	ts.cancelOwnerTask(&tmodel.Task{ID: "RunningTaskID", ServiceName: "svc.unreachable"})
+	want := []string{"svc.update-1:RunningSubTaskID", "svc.unreachable:RunningTaskID"}
+	if !reflect.DeepEqual(calledWith, want) {
+		t.Errorf("cancel requests sent = %v, want %v", calledWith, want)
+	}
+}
+
+func TestTasksRPC_updateTaskUtilCancellingTask(t *testing.T) {
+	config.SetUpMockConfig(t)
+	TaskCollection = TaskCollectionData{
+		TaskCollection: make(map[string]int32),
+	}
+	getTaskStatus := func(taskID string, db common.DbType) (*tmodel.Task, error) {
+		task := &tmodel.Task{ID: taskID, TaskState: common.Cancelling, TaskStatus: common.OK}
+		if taskID == "CancellingParentTaskID" {
+			task.ChildTaskIDs = []string{"CancellingTaskID"}
+		}
+		return task, nil
+	}
+	ts := &TasksRPC{
+		GetTaskStatusModel:    getTaskStatus,
+		UpdateTaskStatusModel: mockUpdateTaskStatusModel,
+		PublishToMessageBus:   mockPublishToMessageBus,
+	}
+	tests := []struct {
+		name      string
+		taskID    string
+		taskState string
+		wantErr   bool
+	}{
+		{
+			name:      "task is cancelled",
+			taskID:    "CancellingTaskID",
+			taskState: common.Cancelled,
+		},
+		{
+			name:      "job on the plugin could not be aborted",
+			taskID:    "CancellingTaskID",
+			taskState: common.Exception,
+		},
+		{
+			name:      "progress update of a cancelling task",
+			taskID:    "CancellingTaskID",
+			taskState: common.Running,
+			wantErr:   true,
+		},
+		{
+			name:      "parent task is only cancelled",
+			taskID:    "CancellingParentTaskID",
+			taskState: common.Exception,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ts.updateTaskUtil(tt.taskID, tt.taskState, common.Critical, 100, nil, time.Now())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TasksRPC.updateTaskUtil() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && err.Error() != common.Cancelling {
+				t.Errorf("TasksRPC.updateTaskUtil() error = %v, want %v", err, common.Cancelling)
+			}
+		})
+	}
+}
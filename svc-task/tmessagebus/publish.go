@@ -26,8 +26,8 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
-//Publish will takes the taskURI, messageID, Event type and publishes the data to message bus
-func Publish(taskURI, messageID, eventType, taskMessage string) {
+//Publish will takes the task event, fills the event ID and time stamp and publishes the data to message bus
+func Publish(event common.Event) {
 	topicName := config.Data.MessageBusConf.MessageBusQueue[0]
 	k, err := dc.Communicator(config.Data.MessageBusConf.MessageBusType, config.Data.MessageBusConf.MessageBusConfigFilePath, topicName)
 	if err != nil {
@@ -35,18 +35,8 @@ func Publish(taskURI, messageID, eventType, taskMessage string) {
 		return
 	}
 
-	var eventID = uuid.NewV4().String()
-	var event = common.Event{
-		EventID:        eventID,
-		MessageID:      messageID,
-		EventTimestamp: time.Now().Format(time.RFC3339),
-		EventType:      eventType,
-		Message:        taskMessage,
-		OriginOfCondition: &common.Link{
-			Oid: taskURI,
-		},
-		Severity: "OK",
-	}
+	event.EventID = uuid.NewV4().String()
+	event.EventTimestamp = time.Now().Format(time.RFC3339)
 	var events = []common.Event{event}
 	var messageData = common.MessageData{
 		Name:      "Task Event",
@@ -64,5 +54,5 @@ func Publish(taskURI, messageID, eventType, taskMessage string) {
 		log.Error("unable to publish the event to message bus: " + err.Error())
 		return
 	}
-	log.Error("info: TaskURI:" + taskURI + ", EventID:" + eventID + ", MessageID:" + messageID)
+	log.Info("TaskURI:" + event.OriginOfCondition.Oid + ", EventID:" + event.EventID + ", MessageID:" + event.MessageID)
 }
@@ -0,0 +1,143 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tmodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/svc-task/tschedule"
+)
+
+const (
+	// JobTable is the table name for the scheduled jobs
+	JobTable = "Job"
+	// JobRunTable is the table name for the claims of the scheduled job runs
+	JobRunTable = "JobRun"
+	// jobRunExpiry is the time in seconds after which a job run claim is removed
+	jobRunExpiry = 24 * 60 * 60
+)
+
+// instanceID identifies this instance of the task service as the owner of the job run claims
+var instanceID = uuid.New().String()
+
+// Job is the model of a scheduled job
+type Job struct {
+	ID                  string
+	Name                string
+	UserName            string
+	RoleID              string
+	AccountProviderType string
+	JobState            string
+	JobStatus           string
+	Payload             Payload
+	Schedule            tschedule.Schedule
+	CreatedTime         time.Time
+	StartTime           time.Time
+	EndTime             time.Time
+	NextRunTime         time.Time
+	Occurrences         int
+	TaskURIs            []string
+	Messages            []*Message
+}
+
+// PersistJob stores the job in the OnDisk db, the job is created when
+// it is not present already else it is overwritten
+func PersistJob(job *Job) error {
+	connPool, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		log.Error("PersistJob : error while trying to get DB Connection : " + err.Error())
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	if err = connPool.AddResourceData(JobTable, job.ID, job); err != nil {
+		log.Error("PersistJob : error while trying to save job : " + err.Error())
+		return fmt.Errorf("error while trying to save job: %v", err.Error())
+	}
+	return nil
+}
+
+// GetJob retrieves the job with the given ID from the OnDisk db
+func GetJob(jobID string) (*Job, error) {
+	job := new(Job)
+	connPool, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		log.Error("GetJob : error while trying to get DB Connection : " + err.Error())
+		return job, fmt.Errorf("error while trying to connnect to DB: %v", err.Error())
+	}
+	jobData, err := connPool.Read(JobTable, jobID)
+	if err != nil {
+		log.Error("GetJob : Unable to read job data from DB: " + err.Error())
+		return job, fmt.Errorf("error while trying to read from DB: %v", err.Error())
+	}
+	if errs := json.Unmarshal([]byte(jobData), job); errs != nil {
+		return job, fmt.Errorf("error while trying to unmarshal job data: %v", errs)
+	}
+	return job, nil
+}
+
+// GetAllJobKeys collects the IDs of all the jobs available in the db
+func GetAllJobKeys() ([]string, error) {
+	connPool, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		log.Error("GetAllJobKeys : error while trying to get DB Connection : " + err.Error())
+		return nil, fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	jobKeys, err := connPool.GetAllDetails(JobTable)
+	if err != nil {
+		log.Error("GetAllJobKeys : error while trying to get job keys from DB : " + err.Error())
+		return nil, fmt.Errorf("error while fetching data: %v", err.Error())
+	}
+	return jobKeys, nil
+}
+
+// DeleteJob removes the job with the given ID from the db
+func DeleteJob(jobID string) error {
+	connPool, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		log.Error("DeleteJob : error while trying to get DB Connection : " + err.Error())
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	if err = connPool.Delete(JobTable, jobID); err != nil {
+		log.Error("DeleteJob : Unable to delete job : " + err.Error())
+		return fmt.Errorf("error while trying to delete the job: %v", err.Error())
+	}
+	return nil
+}
+
+// ClaimJobRun marks the run of the job scheduled at runTime as taken,
+// so that only one instance of the task service runs it.
+// Error is returned when the run is already claimed.
+func ClaimJobRun(jobID string, runTime time.Time) error {
+	connPool, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		log.Error("ClaimJobRun : error while trying to get DB Connection : " + err.Error())
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	key := jobID + ":" + strconv.FormatInt(runTime.Unix(), 10)
+	claimed, err := connPool.AcquireLease(JobRunTable, key, instanceID, jobRunExpiry)
+	if err != nil {
+		return fmt.Errorf("unable to claim the run %s of the job: %v", key, err.Error())
+	}
+	if !claimed {
+		return fmt.Errorf("the run %s of the job is already claimed", key)
+	}
+	return nil
+}
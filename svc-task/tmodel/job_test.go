@@ -0,0 +1,69 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tmodel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-task/tschedule"
+)
+
+func TestJob(t *testing.T) {
+	config.SetUpMockConfig(t)
+	common.SetUpMockConfig()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	job := &Job{
+		ID:       "job1",
+		UserName: "admin",
+		JobState: common.Pending,
+		Schedule: tschedule.Schedule{CronSchedule: "0 2 * * *"},
+	}
+	if err := PersistJob(job); err != nil {
+		t.Fatalf("PersistJob() error = %v", err)
+	}
+	job.Occurrences = 1
+	if err := PersistJob(job); err != nil {
+		t.Fatalf("PersistJob() of existing job error = %v", err)
+	}
+	got, err := GetJob("job1")
+	if err != nil || got.Occurrences != 1 || got.Schedule.CronSchedule != "0 2 * * *" {
+		t.Errorf("GetJob() = %+v, error = %v", got, err)
+	}
+	keys, err := GetAllJobKeys()
+	if err != nil || len(keys) != 1 || keys[0] != "job1" {
+		t.Errorf("GetAllJobKeys() = %v, error = %v", keys, err)
+	}
+
+	runTime := time.Now()
+	if err := ClaimJobRun("job1", runTime); err != nil {
+		t.Errorf("ClaimJobRun() error = %v", err)
+	}
+	if err := ClaimJobRun("job1", runTime); err == nil {
+		t.Errorf("ClaimJobRun() expected error for the run already claimed")
+	}
+
+	if err := DeleteJob("job1"); err != nil {
+		t.Errorf("DeleteJob() error = %v", err)
+	}
+	if _, err := GetJob("job1"); err == nil {
+		t.Errorf("GetJob() expected error for deleted job")
+	}
+}
@@ -0,0 +1,164 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tmodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+const (
+	// TaskServiceSettingsTable is the table name for the TaskService properties updated with PATCH
+	TaskServiceSettingsTable = "TaskServiceSettings"
+	// TaskArchiveTable is the table name for the records of the deleted tasks kept for export
+	TaskArchiveTable = "TaskArchive"
+)
+
+// TaskServiceSettings is the model for the properties of the TaskService
+// which override the values in the configuration
+type TaskServiceSettings struct {
+	CompletedTaskOverWritePolicy    string `json:"CompletedTaskOverWritePolicy"`
+	LifeCycleEventOnTaskStateChange bool   `json:"LifeCycleEventOnTaskStateChange"`
+	TaskAutoDeleteTimeoutMinutes    int    `json:"TaskAutoDeleteTimeoutMinutes"`
+	MaxCompletedTasksPerUser        int    `json:"MaxCompletedTasksPerUser"`
+}
+
+// TaskRecord is the audit record of a task, which is kept after the task is deleted
+type TaskRecord struct {
+	ID            string     `json:"Id"`
+	Name          string     `json:"Name"`
+	UserName      string     `json:"UserName"`
+	ParentTaskID  string     `json:"ParentTaskId,omitempty"`
+	TaskState     string     `json:"TaskState"`
+	TaskStatus    string     `json:"TaskStatus"`
+	StartTime     time.Time  `json:"StartTime"`
+	EndTime       time.Time  `json:"EndTime"`
+	HTTPOperation string     `json:"HttpOperation,omitempty"`
+	TargetURI     string     `json:"TargetUri,omitempty"`
+	StatusCode    int32      `json:"StatusCode,omitempty"`
+	Messages      []*Message `json:"Messages,omitempty"`
+}
+
+// GetTaskServiceSettings reads the TaskService settings from the OnDisk db,
+// nil is returned when the settings are never updated
+func GetTaskServiceSettings() (*TaskServiceSettings, error) {
+	connPool, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		log.Error("GetTaskServiceSettings : error while trying to get DB Connection : " + err.Error())
+		return nil, fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	data, dbErr := connPool.Read(TaskServiceSettingsTable, "TaskService")
+	if dbErr != nil {
+		if errors.DBKeyNotFound == dbErr.ErrNo() {
+			return nil, nil
+		}
+		log.Error("GetTaskServiceSettings : error while trying to read the settings : " + dbErr.Error())
+		return nil, fmt.Errorf("error while trying to get the TaskService settings: %v", dbErr.Error())
+	}
+	var settings TaskServiceSettings
+	if err := json.Unmarshal([]byte(data), &settings); err != nil {
+		return nil, fmt.Errorf("error while trying to unmarshal the TaskService settings: %v", err.Error())
+	}
+	return &settings, nil
+}
+
+// SaveTaskServiceSettings saves the TaskService settings in the OnDisk db
+func SaveTaskServiceSettings(settings TaskServiceSettings) error {
+	connPool, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		log.Error("SaveTaskServiceSettings : error while trying to get DB Connection : " + err.Error())
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	if err = connPool.AddResourceData(TaskServiceSettingsTable, "TaskService", settings); err != nil {
+		log.Error("SaveTaskServiceSettings : error while trying to save the settings : " + err.Error())
+		return fmt.Errorf("error while trying to save the TaskService settings: %v", err.Error())
+	}
+	return nil
+}
+
+// NewTaskRecord builds the audit record of the task
+func NewTaskRecord(t *Task) *TaskRecord {
+	return &TaskRecord{
+		ID:            t.ID,
+		Name:          t.Name,
+		UserName:      t.UserName,
+		ParentTaskID:  t.ParentID,
+		TaskState:     t.TaskState,
+		TaskStatus:    t.TaskStatus,
+		StartTime:     t.StartTime,
+		EndTime:       t.EndTime,
+		HTTPOperation: t.Payload.HTTPOperation,
+		TargetURI:     t.Payload.TargetURI,
+		StatusCode:    t.StatusCode,
+		Messages:      t.Messages,
+	}
+}
+
+// ArchiveTask stores the record of the task in the OnDisk db, the record is
+// removed from the db after the retention time, given in seconds, elapses
+func ArchiveTask(record *TaskRecord, retentionSeconds int) error {
+	connPool, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		log.Error("ArchiveTask : error while trying to get DB Connection : " + err.Error())
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	if dbErr := connPool.SetExpire(TaskArchiveTable, record.ID, record, retentionSeconds); dbErr != nil {
+		// the task is already archived when its deletion is retried
+		if errors.DBKeyAlreadyExist == dbErr.ErrNo() {
+			return nil
+		}
+		log.Error("ArchiveTask : error while trying to archive the task : " + dbErr.Error())
+		return fmt.Errorf("error while trying to archive the task: %v", dbErr.Error())
+	}
+	return nil
+}
+
+// GetArchivedTasks reads the records of the archived tasks in batches as the keys are
+// scanned, and passes each batch to the send function, so that the records are not
+// collected in memory. The records are not ordered, each record has its end time.
+func GetArchivedTasks(send func([]TaskRecord) error) error {
+	connPool, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		log.Error("GetArchivedTasks : error while trying to get DB Connection : " + err.Error())
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	dbErr := connPool.ScanKeysInBatches(TaskArchiveTable+":*", func(keys []string) error {
+		// the records which expired after the keys are scanned are skipped
+		data, dbErr := connPool.ReadMultipleKeys(keys)
+		if dbErr != nil {
+			return fmt.Errorf("error while trying to read the archived tasks: %v", dbErr.Error())
+		}
+		records := make([]TaskRecord, 0, len(data))
+		for _, value := range data {
+			var record TaskRecord
+			if err := json.Unmarshal([]byte(value), &record); err != nil {
+				return fmt.Errorf("error while trying to unmarshal the archived task: %v", err.Error())
+			}
+			records = append(records, record)
+		}
+		return send(records)
+	})
+	if dbErr != nil {
+		log.Error("GetArchivedTasks : error while trying to get the archived tasks : " + dbErr.Error())
+		return fmt.Errorf("error while fetching data: %v", dbErr.Error())
+	}
+	return nil
+}
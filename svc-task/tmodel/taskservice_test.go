@@ -0,0 +1,84 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tmodel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+func TestTaskServiceSettings(t *testing.T) {
+	config.SetUpMockConfig(t)
+	common.SetUpMockConfig()
+	defer common.TruncateDB(common.OnDisk)
+
+	settings, err := GetTaskServiceSettings()
+	if err != nil || settings != nil {
+		t.Fatalf("GetTaskServiceSettings() before update = %v, error = %v", settings, err)
+	}
+	want := TaskServiceSettings{
+		CompletedTaskOverWritePolicy:    "Manual",
+		LifeCycleEventOnTaskStateChange: false,
+		TaskAutoDeleteTimeoutMinutes:    60,
+		MaxCompletedTasksPerUser:        5,
+	}
+	if err := SaveTaskServiceSettings(want); err != nil {
+		t.Fatalf("SaveTaskServiceSettings() error = %v", err)
+	}
+	settings, err = GetTaskServiceSettings()
+	if err != nil || settings == nil || *settings != want {
+		t.Errorf("GetTaskServiceSettings() = %v, error = %v, want %v", settings, err, want)
+	}
+}
+
+func TestArchiveTask(t *testing.T) {
+	config.SetUpMockConfig(t)
+	common.SetUpMockConfig()
+	defer common.TruncateDB(common.OnDisk)
+
+	now := time.Now().UTC()
+	tasks := []*Task{
+		{ID: "task2", UserName: "admin", TaskState: common.Completed, EndTime: now},
+		{ID: "task1", UserName: "operator", TaskState: common.Exception, EndTime: now.Add(-time.Hour),
+			Payload: Payload{HTTPOperation: "POST", TargetURI: "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate"}},
+	}
+	for _, task := range tasks {
+		if err := ArchiveTask(NewTaskRecord(task), 60); err != nil {
+			t.Fatalf("ArchiveTask() error = %v", err)
+		}
+	}
+	if err := ArchiveTask(NewTaskRecord(tasks[0]), 60); err != nil {
+		t.Errorf("ArchiveTask() of the task already archived error = %v", err)
+	}
+	records := make(map[string]TaskRecord)
+	err := GetArchivedTasks(func(batch []TaskRecord) error {
+		for _, record := range batch {
+			records[record.ID] = record
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("GetArchivedTasks() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("GetArchivedTasks() = %+v, want task1 and task2", records)
+	}
+	if records["task1"].UserName != "operator" || records["task1"].HTTPOperation != "POST" || !records["task2"].EndTime.Equal(now) {
+		t.Errorf("GetArchivedTasks() records = %+v", records)
+	}
+}
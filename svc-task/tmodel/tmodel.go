@@ -47,9 +47,12 @@ type Task struct {
 	ID           string
 	URI          string
 	UserName     string
-	Name         string
-	HidePayload  bool
-	Payload      Payload
+	// ServiceName is the name of the service instance which runs the task,
+	// a cancel request on the task is sent to this instance
+	ServiceName string
+	Name        string
+	HidePayload bool
+	Payload     Payload
 	/*The value of this property shall indicate the completion progress of
 	the task, reported in percent of completion.
 	If the task has not been started, the value shall be zero.
@@ -0,0 +1,71 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tresponse
+
+import (
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+// JobServiceResponse is used to give back the JobService response
+type JobServiceResponse struct {
+	response.Response
+	DateTime            time.Time           `json:"DateTime"`
+	ServiceEnabled      bool                `json:"ServiceEnabled"`
+	ServiceCapabilities ServiceCapabilities `json:"ServiceCapabilities"`
+	Status              Status              `json:"Status"`
+	Jobs                ListMember          `json:"Jobs"`
+}
+
+// ServiceCapabilities lists the capabilities of the JobService
+type ServiceCapabilities struct {
+	Scheduling bool `json:"Scheduling"`
+}
+
+// Job struct is used to display the scheduled job to the user
+type Job struct {
+	response.Response
+	JobState  string      `json:"JobState"`
+	JobStatus string      `json:"JobStatus"`
+	CreatedBy string      `json:"CreatedBy"`
+	StartTime *time.Time  `json:"StartTime,omitempty"`
+	EndTime   *time.Time  `json:"EndTime,omitempty"`
+	Payload   Payload     `json:"Payload"`
+	Schedule  JobSchedule `json:"Schedule"`
+	Messages  []Messages  `json:"Messages"`
+	Oem       JobOem      `json:"Oem"`
+}
+
+// JobSchedule is the Redfish Schedule of the job
+type JobSchedule struct {
+	InitialStartTime   time.Time `json:"InitialStartTime"`
+	RecurrenceInterval string    `json:"RecurrenceInterval,omitempty"`
+	EnabledDaysOfWeek  []string  `json:"EnabledDaysOfWeek,omitempty"`
+	MaxOccurrences     int       `json:"MaxOccurrences,omitempty"`
+}
+
+// JobOem holds the Odim specific properties of the job
+type JobOem struct {
+	Odim JobOdimOem `json:"Odim"`
+}
+
+// JobOdimOem holds the cron schedule of the job and the tasks created by its runs
+type JobOdimOem struct {
+	CronSchedule string       `json:"CronSchedule,omitempty"`
+	NextRunTime  *time.Time   `json:"NextRunTime,omitempty"`
+	Occurrences  int          `json:"Occurrences"`
+	Tasks        []ListMember `json:"Tasks"`
+}
@@ -89,15 +89,27 @@ type TaskCollectionResponse struct {
 //TaskServiceResponse is used to give baxk the response
 type TaskServiceResponse struct {
 	response.Response
-	CompletedTaskOverWritePolicy    string      `json:"CompletedTaskOverWritePolicy,omitempty"`
-	DateTime                        time.Time   `json:"DateTime,omitempty"`
-	LifeCycleEventOnTaskStateChange bool        `json:"LifeCycleEventOnTaskStateChange,omitempty"`
-	ServiceEnabled                  bool        `json:"ServiceEnabled,omitempty"`
-	Status                          Status      `json:"Status,omitempty"`
-	Tasks                           Tasks       `json:"Tasks,omitempty"`
-	TaskAutoDeleteTimeoutMinutes    int         `json:"TaskAutoDeleteTimeoutMinutes,omitempty"`
-	Actions                         *OemActions `json:"Actions,omitempty"`
-	Oem                             Oem         `json:"Oem,omitempty"`
+	CompletedTaskOverWritePolicy    string          `json:"CompletedTaskOverWritePolicy,omitempty"`
+	DateTime                        time.Time       `json:"DateTime,omitempty"`
+	LifeCycleEventOnTaskStateChange bool            `json:"LifeCycleEventOnTaskStateChange"`
+	ServiceEnabled                  bool            `json:"ServiceEnabled,omitempty"`
+	Status                          Status          `json:"Status,omitempty"`
+	Tasks                           Tasks           `json:"Tasks,omitempty"`
+	TaskAutoDeleteTimeoutMinutes    int             `json:"TaskAutoDeleteTimeoutMinutes,omitempty"`
+	Actions                         *OemActions     `json:"Actions,omitempty"`
+	Oem                             *TaskServiceOem `json:"Oem,omitempty"`
+}
+
+// TaskServiceOem holds the Odim specific properties of the TaskService
+type TaskServiceOem struct {
+	Odim TaskServiceOdimOem `json:"Odim"`
+}
+
+// TaskServiceOdimOem holds the limit of the completed tasks retained for a user
+// and the link to export the history of the deleted tasks
+type TaskServiceOdimOem struct {
+	MaxCompletedTasksPerUser int        `json:"MaxCompletedTasksPerUser"`
+	TaskHistory              ListMember `json:"TaskHistory"`
 }
 
 //OemActions struct for oem actions
@@ -0,0 +1,197 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tschedule
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxScheduleLookAhead is the maximum time span searched for the next
+// run of a schedule before the schedule is considered as exhausted
+const maxScheduleLookAhead = 5 * 366 * 24 * time.Hour
+
+// weekDays holds the values allowed in EnabledDaysOfWeek
+var weekDays = map[string]time.Weekday{
+	"Sunday":    time.Sunday,
+	"Monday":    time.Monday,
+	"Tuesday":   time.Tuesday,
+	"Wednesday": time.Wednesday,
+	"Thursday":  time.Thursday,
+	"Friday":    time.Friday,
+	"Saturday":  time.Saturday,
+}
+
+// everyDay is the EnabledDaysOfWeek value which enables all the days
+const everyDay = "Every"
+
+var intervalRegex = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// Schedule holds the properties of a Redfish Schedule along with the
+// Odim cron expression used for computing the runs of a job
+type Schedule struct {
+	InitialStartTime   time.Time `json:"InitialStartTime"`
+	RecurrenceInterval string    `json:"RecurrenceInterval,omitempty"`
+	EnabledDaysOfWeek  []string  `json:"EnabledDaysOfWeek,omitempty"`
+	MaxOccurrences     int       `json:"MaxOccurrences,omitempty"`
+	CronSchedule       string    `json:"CronSchedule,omitempty"`
+}
+
+// Interval is the parsed form of an ISO 8601 duration.
+// Years, months and days are kept apart from the time part since
+// their length depends on the calendar
+type Interval struct {
+	Years  int
+	Months int
+	Days   int
+	Time   time.Duration
+}
+
+// ParseInterval parses the ISO 8601 duration given as the RecurrenceInterval
+// of the schedule, for example P1D, PT30M or P0DT06H00M00S
+func ParseInterval(value string) (Interval, error) {
+	var interval Interval
+	match := intervalRegex.FindStringSubmatch(value)
+	if match == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return interval, fmt.Errorf("%s is not a valid ISO 8601 duration", value)
+	}
+	atoi := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	interval.Years = atoi(match[1])
+	interval.Months = atoi(match[2])
+	interval.Days = atoi(match[3])*7 + atoi(match[4])
+	interval.Time = time.Duration(atoi(match[5]))*time.Hour + time.Duration(atoi(match[6]))*time.Minute
+	if match[7] != "" {
+		seconds, _ := strconv.ParseFloat(match[7], 64)
+		interval.Time += time.Duration(seconds * float64(time.Second))
+	}
+	if interval.IsZero() {
+		return interval, fmt.Errorf("%s is not a valid recurrence interval, it must be greater than zero", value)
+	}
+	return interval, nil
+}
+
+// IsZero reports whether the interval has no length
+func (i Interval) IsZero() bool {
+	return i.Years == 0 && i.Months == 0 && i.Days == 0 && i.Time == 0
+}
+
+// AddTo returns the time t moved forward by the interval
+func (i Interval) AddTo(t time.Time) time.Time {
+	return t.AddDate(i.Years, i.Months, i.Days).Add(i.Time)
+}
+
+// Validate checks the schedule properties and returns the name of the
+// invalid property along with the error
+func (s *Schedule) Validate() (string, error) {
+	if s.MaxOccurrences < 0 {
+		return "MaxOccurrences", fmt.Errorf("MaxOccurrences must not be negative")
+	}
+	if s.RecurrenceInterval != "" && s.CronSchedule != "" {
+		return "CronSchedule", fmt.Errorf("RecurrenceInterval and CronSchedule can not be used together")
+	}
+	if s.RecurrenceInterval != "" {
+		if _, err := ParseInterval(s.RecurrenceInterval); err != nil {
+			return "RecurrenceInterval", err
+		}
+	}
+	if s.CronSchedule != "" {
+		if _, err := ParseCron(s.CronSchedule); err != nil {
+			return "CronSchedule", err
+		}
+	}
+	for _, day := range s.EnabledDaysOfWeek {
+		if _, ok := weekDays[day]; !ok && day != everyDay {
+			return "EnabledDaysOfWeek", fmt.Errorf("%s is not a valid day of week", day)
+		}
+	}
+	return "", nil
+}
+
+// IsRecurring reports whether the schedule has more than one run
+func (s *Schedule) IsRecurring() bool {
+	return s.RecurrenceInterval != "" || s.CronSchedule != ""
+}
+
+// dayEnabled checks whether the day of the given time is one of the
+// EnabledDaysOfWeek, all days are enabled when the list is empty
+func (s *Schedule) dayEnabled(t time.Time) bool {
+	if len(s.EnabledDaysOfWeek) == 0 {
+		return true
+	}
+	for _, day := range s.EnabledDaysOfWeek {
+		if day == everyDay || weekDays[day] == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the time of the first run of the schedule which is after the
+// given time, occurrences is the number of runs already completed.
+// Zero time is returned when the schedule has no more runs.
+// Schedule is expected to be validated before calling Next.
+func (s *Schedule) Next(after time.Time, occurrences int) time.Time {
+	if s.MaxOccurrences > 0 && occurrences >= s.MaxOccurrences {
+		return time.Time{}
+	}
+	limit := after.Add(maxScheduleLookAhead)
+	if s.CronSchedule != "" {
+		cron, err := ParseCron(s.CronSchedule)
+		if err != nil {
+			return time.Time{}
+		}
+		next := after
+		if s.InitialStartTime.After(next) {
+			next = s.InitialStartTime.Add(-time.Minute)
+		}
+		for {
+			next = cron.Next(next)
+			if next.IsZero() || next.After(limit) {
+				return time.Time{}
+			}
+			if s.dayEnabled(next) {
+				return next
+			}
+		}
+	}
+	if s.InitialStartTime.After(after) && s.dayEnabled(s.InitialStartTime) {
+		return s.InitialStartTime
+	}
+	if s.RecurrenceInterval == "" {
+		return time.Time{}
+	}
+	interval, err := ParseInterval(s.RecurrenceInterval)
+	if err != nil {
+		return time.Time{}
+	}
+	next := s.InitialStartTime
+	// skip the whole intervals in one go when the interval has a fixed length
+	if interval.Years == 0 && interval.Months == 0 && interval.Days == 0 && after.After(next) {
+		next = next.Add(after.Sub(next) / interval.Time * interval.Time)
+	}
+	for !next.After(after) || !s.dayEnabled(next) {
+		next = interval.AddTo(next)
+		if next.After(limit) {
+			return time.Time{}
+		}
+	}
+	return next
+}
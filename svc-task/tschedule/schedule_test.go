@@ -0,0 +1,174 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tschedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Interval
+		wantErr bool
+	}{
+		{name: "days", value: "P1D", want: Interval{Days: 1}},
+		{name: "weeks and days", value: "P1W2D", want: Interval{Days: 9}},
+		{name: "time", value: "PT1H30M", want: Interval{Time: 90 * time.Minute}},
+		{name: "redfish format", value: "P0DT06H00M00S", want: Interval{Time: 6 * time.Hour}},
+		{name: "months", value: "P1Y2M", want: Interval{Years: 1, Months: 2}},
+		{name: "zero", value: "PT0S", wantErr: true},
+		{name: "empty", value: "P", wantErr: true},
+		{name: "missing time", value: "P1DT", wantErr: true},
+		{name: "invalid", value: "1 day", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInterval(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseInterval() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCron_Next(t *testing.T) {
+	// 2022-06-01 is a Wednesday
+	from := time.Date(2022, 6, 1, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{name: "every minute", expr: "* * * * *", want: time.Date(2022, 6, 1, 10, 8, 0, 0, time.UTC)},
+		{name: "step", expr: "*/15 * * * *", want: time.Date(2022, 6, 1, 10, 15, 0, 0, time.UTC)},
+		{name: "daily", expr: "0 2 * * *", want: time.Date(2022, 6, 2, 2, 0, 0, 0, time.UTC)},
+		{name: "week day names", expr: "30 22 * * SAT,SUN", want: time.Date(2022, 6, 4, 22, 30, 0, 0, time.UTC)},
+		{name: "sunday as 7", expr: "0 0 * * 7", want: time.Date(2022, 6, 5, 0, 0, 0, 0, time.UTC)},
+		{name: "month", expr: "0 0 1 JAN *", want: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "day of month or week", expr: "0 0 15 * MON", want: time.Date(2022, 6, 6, 0, 0, 0, 0, time.UTC)},
+		{name: "never", expr: "0 0 31 2 *", want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			if got := cron.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{"* * * *", "60 * * * *", "* 24 * * *", "5-1 * * * *", "*/0 * * * *", "* * * FOO *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) expected error", expr)
+		}
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	start := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		schedule    Schedule
+		after       time.Time
+		occurrences int
+		want        time.Time
+	}{
+		{
+			name:     "one time run pending",
+			schedule: Schedule{InitialStartTime: start},
+			after:    start.Add(-time.Hour),
+			want:     start,
+		},
+		{
+			name:     "one time run done",
+			schedule: Schedule{InitialStartTime: start},
+			after:    start,
+			want:     time.Time{},
+		},
+		{
+			name:     "recurrence",
+			schedule: Schedule{InitialStartTime: start, RecurrenceInterval: "PT6H"},
+			after:    start.Add(13 * time.Hour),
+			want:     start.Add(18 * time.Hour),
+		},
+		{
+			name:     "enabled days",
+			schedule: Schedule{InitialStartTime: start, RecurrenceInterval: "P1D", EnabledDaysOfWeek: []string{"Monday"}},
+			after:    start,
+			want:     time.Date(2022, 6, 6, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "max occurrences reached",
+			schedule:    Schedule{InitialStartTime: start, RecurrenceInterval: "P1D", MaxOccurrences: 2},
+			after:       start.Add(24 * time.Hour),
+			occurrences: 2,
+			want:        time.Time{},
+		},
+		{
+			name:     "cron before initial start time",
+			schedule: Schedule{InitialStartTime: start.Add(24 * time.Hour), CronSchedule: "0 * * * *"},
+			after:    start,
+			want:     start.Add(24 * time.Hour),
+		},
+		{
+			name:     "cron with enabled days",
+			schedule: Schedule{CronSchedule: "0 1 * * *", EnabledDaysOfWeek: []string{"Friday"}},
+			after:    start,
+			want:     time.Date(2022, 6, 3, 1, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.Next(tt.after, tt.occurrences); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule Schedule
+		want     string
+	}{
+		{name: "valid", schedule: Schedule{RecurrenceInterval: "P1D", EnabledDaysOfWeek: []string{"Every"}}, want: ""},
+		{name: "negative occurrences", schedule: Schedule{MaxOccurrences: -1}, want: "MaxOccurrences"},
+		{name: "both recurrences", schedule: Schedule{RecurrenceInterval: "P1D", CronSchedule: "* * * * *"}, want: "CronSchedule"},
+		{name: "invalid interval", schedule: Schedule{RecurrenceInterval: "1D"}, want: "RecurrenceInterval"},
+		{name: "invalid cron", schedule: Schedule{CronSchedule: "* *"}, want: "CronSchedule"},
+		{name: "invalid day", schedule: Schedule{EnabledDaysOfWeek: []string{"Funday"}}, want: "EnabledDaysOfWeek"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := tt.schedule.Validate()
+			if got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -128,7 +128,7 @@ func (a *Telemetry) GetTrigger(ctx context.Context, req *teleproto.TelemetryRequ
 // UpdateTrigger is an rpc handler which is invoked during update on Trigger
 func (a *Telemetry) UpdateTrigger(ctx context.Context, req *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
 	resp := &teleproto.TelemetryResponse{}
-	authResp := a.connector.External.Auth(req.SessionToken, []string{common.PrivilegeLogin}, []string{})
+	authResp := a.connector.External.Auth(req.SessionToken, []string{common.PrivilegeConfigureComponents}, []string{})
 	if authResp.StatusCode != http.StatusOK {
 		fillProtoResponse(resp, authResp)
 		return resp, nil
@@ -136,3 +136,11 @@ func (a *Telemetry) UpdateTrigger(ctx context.Context, req *teleproto.TelemetryR
 	fillProtoResponse(resp, a.connector.UpdateTrigger(req))
 	return resp, nil
 }
+
+// EvaluateTriggers is an rpc handler which is invoked by the event service
+// on receiving a metric report, to evaluate the triggers against its metric values
+func (a *Telemetry) EvaluateTriggers(ctx context.Context, req *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	resp := &teleproto.TelemetryResponse{}
+	fillProtoResponse(resp, a.connector.EvaluateTriggers(req))
+	return resp, nil
+}
@@ -300,10 +300,3 @@ func (e *ExternalInterface) GetTrigger(req *teleproto.TelemetryRequest) response
 	return resp
 
 }
-
-// UpdateTrigger ...
-func (e *ExternalInterface) UpdateTrigger(req *teleproto.TelemetryRequest) response.RPC {
-	var resp response.RPC
-	// Todo: code for update operation
-	return resp
-}
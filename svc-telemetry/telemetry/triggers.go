@@ -0,0 +1,648 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tcommon"
+	uuid "github.com/satori/go.uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	triggerTable = "Triggers"
+
+	numericMetricType  = "Numeric"
+	discreteMetricType = "Discrete"
+
+	redfishEventAction = "RedfishEvent"
+
+	increasingActivation = "Increasing"
+	decreasingActivation = "Decreasing"
+	eitherActivation     = "Either"
+
+	specifiedCondition = "Specified"
+	changedCondition   = "Changed"
+)
+
+// patchableTriggerProperties are the properties of a trigger which can be modified with PATCH
+var patchableTriggerProperties = map[string]bool{
+	"Name":                     true,
+	"MetricType":               true,
+	"MetricProperties":         true,
+	"MetricIds":                true,
+	"Wildcards":                true,
+	"NumericThresholds":        true,
+	"DiscreteTriggerCondition": true,
+	"DiscreteTriggers":         true,
+	"EventTriggers":            true,
+	"TriggerActions":           true,
+	"Status":                   true,
+}
+
+// metricState holds the last evaluated reading of a metric for a trigger.
+// pending holds the time from which a threshold crossing is waiting for the
+// dwell time to elapse, along with the side of the threshold it crossed to.
+type metricState struct {
+	reading float64
+	value   string
+	pending map[string]pendingCrossing
+}
+
+type pendingCrossing struct {
+	since   time.Time
+	outside bool
+}
+
+// triggerStates holds the evaluation state of all the trigger and metric pairs,
+// the key is the trigger URI followed by the metric property or metric ID
+var triggerStates = struct {
+	sync.Mutex
+	states map[string]*metricState
+}{states: make(map[string]*metricState)}
+
+// UpdateTrigger updates the thresholds and the other writable properties of the trigger
+// on the BMCs having it, and persists them once the BMCs accepted the update,
+// so that they take effect on the next metric report evaluated
+func (e *ExternalInterface) UpdateTrigger(req *teleproto.TelemetryRequest) response.RPC {
+	var resp response.RPC
+	data, gerr := e.DB.GetResource(triggerTable, req.URL, common.InMemory)
+	if gerr != nil {
+		log.Warn("Unable to get Triggers details : " + gerr.Error())
+		errorMessage := gerr.Error()
+		if errors.DBKeyNotFound == gerr.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"Triggers", req.URL}, nil)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+
+	var patchRequest map[string]interface{}
+	if err := json.Unmarshal(req.RequestBody, &patchRequest); err != nil {
+		errorMessage := "error while trying to unmarshal the trigger update request: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+	}
+	if len(patchRequest) == 0 {
+		errorMessage := "error: request body of the trigger update is empty"
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage, []interface{}{"NumericThresholds"}, nil)
+	}
+	for key := range patchRequest {
+		if !patchableTriggerProperties[key] {
+			errorMessage := "error: " + key + " is not a writable property of trigger"
+			log.Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{key}, nil)
+		}
+	}
+
+	var trigger map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &trigger); err != nil {
+		errorMessage := "error while trying to unmarshal the trigger data: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	for key, value := range patchRequest {
+		trigger[key] = value
+	}
+	triggerData, err := json.Marshal(trigger)
+	if err != nil {
+		errorMessage := "error while trying to marshal the trigger data: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	var triggerModel dmtf.Triggers
+	if err := json.Unmarshal(triggerData, &triggerModel); err != nil {
+		errorMessage := "error: trigger update request has an invalid property type: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueTypeError, errorMessage, []interface{}{string(req.RequestBody), "Triggers"}, nil)
+	}
+	if statusMessage, messageArgs, err := validateTrigger(triggerModel); err != nil {
+		errorMessage := "error: invalid trigger update request: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, statusMessage, errorMessage, messageArgs, nil)
+	}
+
+	// the triggers are collected from the BMCs, so the update is applied on them first
+	if statusCode, statusMessage, err := e.updateTriggerOnDevices(req.URL, req.RequestBody); err != nil {
+		errorMessage := "error while trying to update the trigger on the servers: " + err.Error()
+		log.Error(errorMessage)
+		var messageArgs []interface{}
+		if statusMessage == response.ResourceNotFound {
+			messageArgs = []interface{}{"Triggers", req.URL}
+		}
+		return common.GeneralError(statusCode, statusMessage, errorMessage, messageArgs, nil)
+	}
+
+	if err := e.External.GenericSave(triggerData, triggerTable, req.URL); err != nil {
+		errorMessage := "error while trying to save the trigger data: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	resetTriggerStates(req.URL)
+
+	resp.Header = map[string]string{
+		"Link": "</redfish/v1/SchemaStore/en/Triggers.json>; rel=describedby",
+	}
+	resp.Body = trigger
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	return resp
+}
+
+// updateTriggerOnDevices sends the trigger update to the plugins of all the servers.
+// A server whose BMC does not have the trigger answers with StatusNotFound and is skipped,
+// the update fails when any other server fails or when none of the servers has the trigger.
+func (e *ExternalInterface) updateTriggerOnDevices(triggerURI string, requestBody []byte) (int32, string, error) {
+	deviceUUIDs, err := e.DB.GetAllKeysFromTable("System", common.OnDisk)
+	if err != nil {
+		return http.StatusInternalServerError, response.InternalError, fmt.Errorf("unable to get the servers: %v", err)
+	}
+	var (
+		wg            sync.WaitGroup
+		lock          sync.Mutex
+		updated       int
+		failureStatus int32
+		failure       error
+	)
+	for _, deviceUUID := range deviceUUIDs {
+		wg.Add(1)
+		go func(deviceUUID string) {
+			defer wg.Done()
+			statusCode, err := e.updateTriggerOnDevice(deviceUUID, triggerURI, requestBody)
+			lock.Lock()
+			defer lock.Unlock()
+			switch {
+			case err == nil:
+				updated++
+			case statusCode == http.StatusNotFound:
+			case failure == nil:
+				failureStatus, failure = statusCode, err
+			}
+		}(deviceUUID)
+	}
+	wg.Wait()
+	if failure != nil {
+		if failureStatus < http.StatusBadRequest || failureStatus >= http.StatusInternalServerError {
+			return http.StatusInternalServerError, response.InternalError, failure
+		}
+		return failureStatus, response.GeneralError, failure
+	}
+	if updated == 0 {
+		return http.StatusNotFound, response.ResourceNotFound, fmt.Errorf("none of the servers has the trigger %v", triggerURI)
+	}
+	return http.StatusOK, response.Success, nil
+}
+
+// updateTriggerOnDevice sends the trigger update to the plugin of the server, and returns
+// the status code of the plugin when the update fails
+func (e *ExternalInterface) updateTriggerOnDevice(deviceUUID, triggerURI string, requestBody []byte) (int32, error) {
+	target, gerr := e.External.GetTarget(deviceUUID)
+	if gerr != nil {
+		return http.StatusInternalServerError, fmt.Errorf("unable to get the details of the server %v: %v", deviceUUID, gerr.Error())
+	}
+	password, err := e.External.DevicePassword(target.Password)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("unable to decrypt the password of the server %v: %v", deviceUUID, err)
+	}
+	target.Password = password
+	plugin, gerr := e.External.GetPluginData(target.PluginID)
+	if gerr != nil {
+		return http.StatusInternalServerError, fmt.Errorf("unable to get the details of the plugin %v: %v", target.PluginID, gerr.Error())
+	}
+
+	contactRequest := tcommon.PluginContactRequest{
+		ContactClient:   e.External.ContactClient,
+		GetPluginStatus: e.External.GetPluginStatus,
+		Plugin:          plugin,
+	}
+	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		contactRequest.HTTPMethodType = http.MethodPost
+		contactRequest.DeviceInfo = map[string]interface{}{
+			"Username": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+		contactRequest.OID = "/ODIM/v1/Sessions"
+		_, token, status, err := e.External.ContactPlugin(contactRequest, "error while creating the session with the plugin "+plugin.ID+": ")
+		if err != nil {
+			return status.StatusCode, err
+		}
+		contactRequest.Token = token
+	} else {
+		contactRequest.BasicAuth = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+	}
+	target.PostBody = requestBody
+	contactRequest.DeviceInfo = target
+	contactRequest.OID = triggerURI
+	contactRequest.HTTPMethodType = http.MethodPatch
+	_, _, status, err := e.External.ContactPlugin(contactRequest, "error while updating the trigger "+triggerURI+" on the server "+deviceUUID+": ")
+	if err != nil && status.StatusCode != http.StatusNoContent {
+		return status.StatusCode, err
+	}
+	return http.StatusOK, nil
+}
+
+// validateTrigger validates the enumerations and durations of a trigger
+func validateTrigger(trigger dmtf.Triggers) (string, []interface{}, error) {
+	if trigger.MetricType != "" && trigger.MetricType != numericMetricType && trigger.MetricType != discreteMetricType {
+		return response.PropertyValueNotInList, []interface{}{trigger.MetricType, "MetricType"}, fmt.Errorf("invalid MetricType %v", trigger.MetricType)
+	}
+	for _, action := range trigger.TriggerActions {
+		if action != redfishEventAction && action != "LogToLogService" && action != "RedfishMetricReport" {
+			return response.PropertyValueNotInList, []interface{}{action, "TriggerActions"}, fmt.Errorf("invalid TriggerActions %v", action)
+		}
+	}
+	if trigger.DiscreteTriggerCondition != "" && trigger.DiscreteTriggerCondition != specifiedCondition &&
+		trigger.DiscreteTriggerCondition != changedCondition {
+		return response.PropertyValueNotInList, []interface{}{trigger.DiscreteTriggerCondition, "DiscreteTriggerCondition"},
+			fmt.Errorf("invalid DiscreteTriggerCondition %v", trigger.DiscreteTriggerCondition)
+	}
+	for _, discreteTrigger := range trigger.DiscreteTriggers {
+		if discreteTrigger.DwellTime == "" {
+			continue
+		}
+		if _, err := common.ParseISO8601Duration(discreteTrigger.DwellTime); err != nil {
+			return response.PropertyValueFormatError, []interface{}{discreteTrigger.DwellTime, "DwellTime"}, err
+		}
+	}
+	for name, threshold := range getThresholds(trigger.NumericThresholds) {
+		switch threshold.Activation {
+		case "", increasingActivation, decreasingActivation, eitherActivation:
+		default:
+			return response.PropertyValueNotInList, []interface{}{threshold.Activation, name + "/Activation"},
+				fmt.Errorf("invalid Activation %v for %v", threshold.Activation, name)
+		}
+		if threshold.DwellTime == "" {
+			continue
+		}
+		if _, err := common.ParseISO8601Duration(threshold.DwellTime); err != nil {
+			return response.PropertyValueFormatError, []interface{}{threshold.DwellTime, name + "/DwellTime"}, err
+		}
+	}
+	thresholds := trigger.NumericThresholds
+	if thresholds.UpperWarning != nil && thresholds.UpperCritical != nil && thresholds.UpperWarning.Reading > thresholds.UpperCritical.Reading {
+		return response.PropertyValueConflict, []interface{}{"UpperWarning", "UpperCritical"}, fmt.Errorf("UpperWarning is greater than UpperCritical")
+	}
+	if thresholds.LowerWarning != nil && thresholds.LowerCritical != nil && thresholds.LowerWarning.Reading < thresholds.LowerCritical.Reading {
+		return response.PropertyValueConflict, []interface{}{"LowerWarning", "LowerCritical"}, fmt.Errorf("LowerWarning is less than LowerCritical")
+	}
+	return response.Success, nil, nil
+}
+
+// getThresholds returns the configured thresholds of the trigger against their names
+func getThresholds(thresholds dmtf.NumericThresholds) map[string]*dmtf.Threshold {
+	configured := make(map[string]*dmtf.Threshold)
+	for name, threshold := range map[string]*dmtf.Threshold{
+		"UpperCritical": thresholds.UpperCritical,
+		"UpperWarning":  thresholds.UpperWarning,
+		"LowerCritical": thresholds.LowerCritical,
+		"LowerWarning":  thresholds.LowerWarning,
+	} {
+		if threshold != nil {
+			configured[name] = threshold
+		}
+	}
+	return configured
+}
+
+// EvaluateTriggers evaluates all the triggers against the metric values of the
+// metric report received from the device, and returns the events to be sent to
+// the subscribers for the thresholds and the discrete conditions which are met
+func (e *ExternalInterface) EvaluateTriggers(req *teleproto.TelemetryRequest) response.RPC {
+	var resp response.RPC
+	var report dmtf.MetricReports
+	if err := json.Unmarshal(req.RequestBody, &report); err != nil {
+		errorMessage := "error while trying to unmarshal the metric report: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+	}
+	triggerKeys, err := e.DB.GetAllKeysFromTable(triggerTable, common.InMemory)
+	if err != nil {
+		errorMessage := "error while trying to get the triggers: " + err.Error()
+		log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+
+	var events []common.Event
+	triggerURIs := make(map[string]bool, len(triggerKeys))
+	for _, key := range triggerKeys {
+		triggerURIs[key] = true
+		data, gerr := e.DB.GetResource(triggerTable, key, common.InMemory)
+		if gerr != nil {
+			log.Warn("Unable to get Triggers details : " + gerr.Error())
+			continue
+		}
+		var trigger dmtf.Triggers
+		if err := json.Unmarshal([]byte(data), &trigger); err != nil {
+			log.Warn("Unable to unmarshal trigger " + key + ": " + err.Error())
+			continue
+		}
+		if trigger.ODataID == "" {
+			trigger.ODataID = key
+		}
+		triggerURIs[trigger.ODataID] = true
+		events = append(events, evaluateTrigger(trigger, report)...)
+	}
+	pruneTriggerStates(triggerURIs)
+
+	resp.Body = common.MessageData{
+		OdataType: common.EventType,
+		Name:      "Telemetry Trigger Event",
+		Context:   "/redfish/v1/$metadata#Event.Event",
+		Events:    events,
+	}
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	return resp
+}
+
+// evaluateTrigger evaluates a trigger against each of the metric values in the report
+func evaluateTrigger(trigger dmtf.Triggers, report dmtf.MetricReports) []common.Event {
+	var events []common.Event
+	if strings.EqualFold(trigger.Status.State, "Disabled") || !isStringPresent(trigger.TriggerActions, redfishEventAction) {
+		return events
+	}
+	properties := expandWildcards(trigger.MetricProperties, trigger.Wildcards)
+	for _, metricValue := range report.MetricValues {
+		var metric string
+		switch {
+		case len(properties) > 0:
+			if !isStringPresent(properties, metricValue.MetricProperty) {
+				continue
+			}
+			metric = metricValue.MetricProperty
+		case len(trigger.MetricIds) > 0:
+			if !isStringPresent(trigger.MetricIds, metricValue.MetricID) {
+				continue
+			}
+			metric = metricValue.MetricID
+		default:
+			continue
+		}
+		timestamp := getTimestamp(metricValue.Timestamp, report.Timestamp)
+		switch trigger.MetricType {
+		case discreteMetricType:
+			events = append(events, evaluateDiscreteTrigger(trigger, metric, metricValue.MetricValue, timestamp)...)
+		default:
+			reading, err := strconv.ParseFloat(metricValue.MetricValue, 64)
+			if err != nil {
+				log.Warn("Unable to evaluate trigger " + trigger.ODataID + " for non numeric value of " + metric)
+				continue
+			}
+			events = append(events, evaluateNumericTrigger(trigger, metric, reading, timestamp)...)
+		}
+	}
+	return events
+}
+
+// evaluateNumericTrigger checks whether the reading crossed any of the thresholds of the trigger
+// in the direction of its activation and stayed there for the dwell time
+func evaluateNumericTrigger(trigger dmtf.Triggers, metric string, reading float64, timestamp time.Time) []common.Event {
+	var events []common.Event
+	triggerStates.Lock()
+	defer triggerStates.Unlock()
+	state, initialized := triggerStates.states[trigger.ODataID+"|"+metric]
+	if !initialized {
+		state = &metricState{pending: make(map[string]pendingCrossing)}
+		triggerStates.states[trigger.ODataID+"|"+metric] = state
+	}
+	for name, threshold := range getThresholds(trigger.NumericThresholds) {
+		upper := strings.HasPrefix(name, "Upper")
+		outside := reading > threshold.Reading
+		wasOutside := initialized && state.reading > threshold.Reading
+		if !upper {
+			outside = reading < threshold.Reading
+			wasOutside = initialized && state.reading < threshold.Reading
+		}
+		if outside != wasOutside {
+			delete(state.pending, name)
+			if !isActivated(threshold.Activation, upper, outside) {
+				continue
+			}
+			state.pending[name] = pendingCrossing{since: timestamp, outside: outside}
+		}
+		crossing, ok := state.pending[name]
+		if !ok {
+			continue
+		}
+		if crossing.outside != outside {
+			delete(state.pending, name)
+			continue
+		}
+		if !isDwellTimeElapsed(threshold.DwellTime, crossing.since, timestamp) {
+			continue
+		}
+		delete(state.pending, name)
+		events = append(events, numericTriggerEvent(trigger, metric, name, reading, threshold.Reading, upper, outside, timestamp))
+	}
+	state.reading = reading
+	return events
+}
+
+// isActivated checks whether the direction of a threshold crossing matches its activation
+func isActivated(activation string, upper, outside bool) bool {
+	increasing := outside == upper
+	switch activation {
+	case eitherActivation:
+		return true
+	case increasingActivation:
+		return increasing
+	case decreasingActivation:
+		return !increasing
+	}
+	// by default only the crossing which violates the threshold is activated
+	return outside
+}
+
+// evaluateDiscreteTrigger checks whether the discrete value meets the trigger condition
+func evaluateDiscreteTrigger(trigger dmtf.Triggers, metric, value string, timestamp time.Time) []common.Event {
+	var events []common.Event
+	triggerStates.Lock()
+	defer triggerStates.Unlock()
+	state, initialized := triggerStates.states[trigger.ODataID+"|"+metric]
+	if !initialized {
+		state = &metricState{pending: make(map[string]pendingCrossing)}
+		triggerStates.states[trigger.ODataID+"|"+metric] = state
+	}
+	previousValue := state.value
+	state.value = value
+
+	if trigger.DiscreteTriggerCondition == changedCondition {
+		if initialized && previousValue != value {
+			events = append(events, discreteTriggerEvent(trigger, metric, value, "Warning", timestamp))
+		}
+		return events
+	}
+	for _, discreteTrigger := range trigger.DiscreteTriggers {
+		key := "Discrete:" + discreteTrigger.Value
+		if value != discreteTrigger.Value {
+			delete(state.pending, key)
+			continue
+		}
+		if !initialized || previousValue != value {
+			state.pending[key] = pendingCrossing{since: timestamp, outside: true}
+		}
+		crossing, ok := state.pending[key]
+		if !ok || !isDwellTimeElapsed(discreteTrigger.DwellTime, crossing.since, timestamp) {
+			continue
+		}
+		delete(state.pending, key)
+		severity := discreteTrigger.Severity
+		if severity == "" {
+			severity = "Warning"
+		}
+		events = append(events, discreteTriggerEvent(trigger, metric, value, severity, timestamp))
+	}
+	return events
+}
+
+func numericTriggerEvent(trigger dmtf.Triggers, metric, thresholdName string, reading, thresholdReading float64, upper, outside bool, timestamp time.Time) common.Event {
+	var messageID, message string
+	readingStr := strconv.FormatFloat(reading, 'f', -1, 64)
+	thresholdStr := strconv.FormatFloat(thresholdReading, 'f', -1, 64)
+	switch {
+	case upper && outside:
+		messageID = "TriggerNumericAboveUpperThreshold"
+		message = fmt.Sprintf("Metric '%s' value of %s is above the %s threshold of %s.", metric, readingStr, thresholdName, thresholdStr)
+	case upper:
+		messageID = "TriggerNumericBelowUpperThreshold"
+		message = fmt.Sprintf("Metric '%s' value of %s is now below the %s threshold of %s.", metric, readingStr, thresholdName, thresholdStr)
+	case outside:
+		messageID = "TriggerNumericBelowLowerThreshold"
+		message = fmt.Sprintf("Metric '%s' value of %s is below the %s threshold of %s.", metric, readingStr, thresholdName, thresholdStr)
+	default:
+		messageID = "TriggerNumericAboveLowerThreshold"
+		message = fmt.Sprintf("Metric '%s' value of %s is now above the %s threshold of %s.", metric, readingStr, thresholdName, thresholdStr)
+	}
+	severity := "OK"
+	if outside {
+		severity = "Warning"
+		if strings.HasSuffix(thresholdName, "Critical") {
+			severity = "Critical"
+		}
+	}
+	return common.Event{
+		EventType:         "Alert",
+		EventID:           uuid.NewV4().String(),
+		Severity:          severity,
+		EventTimestamp:    timestamp.Format(time.RFC3339),
+		Message:           message,
+		MessageArgs:       []string{metric, readingStr, thresholdName, thresholdStr},
+		MessageID:         common.TelemetryEventType + "." + messageID,
+		OriginOfCondition: &common.Link{Oid: trigger.ODataID},
+	}
+}
+
+func discreteTriggerEvent(trigger dmtf.Triggers, metric, value, severity string, timestamp time.Time) common.Event {
+	return common.Event{
+		EventType:         "Alert",
+		EventID:           uuid.NewV4().String(),
+		Severity:          severity,
+		EventTimestamp:    timestamp.Format(time.RFC3339),
+		Message:           fmt.Sprintf("Metric '%s' has the value '%s', which meets the discrete trigger condition.", metric, value),
+		MessageArgs:       []string{metric, value},
+		MessageID:         common.TelemetryEventType + ".TriggerDiscreteConditionMet",
+		OriginOfCondition: &common.Link{Oid: trigger.ODataID},
+	}
+}
+
+// isDwellTimeElapsed checks whether the crossing has lasted for the dwell time
+func isDwellTimeElapsed(dwellTime string, since, now time.Time) bool {
+	if dwellTime == "" {
+		return true
+	}
+	duration, err := common.ParseISO8601Duration(dwellTime)
+	if err != nil {
+		log.Warn("Ignoring invalid dwell time " + dwellTime + ": " + err.Error())
+		return true
+	}
+	return now.Sub(since) >= duration
+}
+
+// expandWildcards substitutes the wildcards in the metric properties with their values
+func expandWildcards(properties []string, wildcards []dmtf.WildCard) []string {
+	expanded := properties
+	for _, wildcard := range wildcards {
+		pattern := "{" + wildcard.Name + "}"
+		var substituted []string
+		for _, property := range expanded {
+			if !strings.Contains(property, pattern) {
+				substituted = append(substituted, property)
+				continue
+			}
+			for _, value := range wildcard.Values {
+				substituted = append(substituted, strings.Replace(property, pattern, value, -1))
+			}
+		}
+		expanded = substituted
+	}
+	return expanded
+}
+
+// resetTriggerStates clears the evaluation state of the trigger, so that the
+// modified thresholds are evaluated afresh on the next metric report
+func resetTriggerStates(triggerURI string) {
+	triggerStates.Lock()
+	defer triggerStates.Unlock()
+	for key := range triggerStates.states {
+		if strings.HasPrefix(key, triggerURI+"|") {
+			delete(triggerStates.states, key)
+		}
+	}
+}
+
+// pruneTriggerStates clears the evaluation state of the triggers which are deleted
+// along with the resources of their plugin
+func pruneTriggerStates(triggerURIs map[string]bool) {
+	triggerStates.Lock()
+	defer triggerStates.Unlock()
+	for key := range triggerStates.states {
+		if !triggerURIs[key[:strings.Index(key, "|")]] {
+			delete(triggerStates.states, key)
+		}
+	}
+}
+
+func getTimestamp(timestamps ...string) time.Time {
+	for _, timestamp := range timestamps {
+		if t, err := time.Parse(time.RFC3339, timestamp); err == nil {
+			return t
+		}
+	}
+	return time.Now()
+}
+
+func isStringPresent(slice []string, str string) bool {
+	for _, value := range slice {
+		if value == str {
+			return true
+		}
+	}
+	return false
+}
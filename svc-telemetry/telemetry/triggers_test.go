@@ -0,0 +1,280 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tcommon"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tmodel"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	numericTriggerURI  = "/redfish/v1/TelemetryService/Triggers/CPUTemperature"
+	discreteTriggerURI = "/redfish/v1/TelemetryService/Triggers/PowerState"
+)
+
+var mockTriggers map[string]string
+
+func mockTriggerGetResource(table, key string, dbType common.DbType) (string, *errors.Error) {
+	if data, ok := mockTriggers[key]; ok {
+		return data, nil
+	}
+	return "", errors.PackError(errors.DBKeyNotFound, "no data with the with key ", key, " found")
+}
+
+// mockTriggerServers holds the status code the plugin answers for the trigger update on each server
+var mockTriggerServers map[string]int32
+
+// mockTriggerUpdates holds the trigger updates sent to the plugins, by server
+var mockTriggerUpdates map[string]string
+
+func mockTriggerGetAllKeysFromTable(table string, dbType common.DbType) ([]string, error) {
+	var keys []string
+	if table == "System" {
+		for key := range mockTriggerServers {
+			keys = append(keys, key)
+		}
+		return keys, nil
+	}
+	for key := range mockTriggers {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func mockTriggerGetTarget(deviceUUID string) (*tmodel.Target, *errors.Error) {
+	return &tmodel.Target{ManagerAddress: deviceUUID, UserName: "admin", Password: []byte("password"), DeviceUUID: deviceUUID, PluginID: "GRF"}, nil
+}
+
+func mockTriggerGetPluginData(pluginID string) (tmodel.Plugin, *errors.Error) {
+	return tmodel.Plugin{ID: pluginID, IP: "localhost", Port: "45001", PreferredAuthType: "BasicAuth"}, nil
+}
+
+func mockTriggerContactPlugin(req tcommon.PluginContactRequest, errorMessage string) ([]byte, string, tcommon.ResponseStatus, error) {
+	target := req.DeviceInfo.(*tmodel.Target)
+	if req.HTTPMethodType != http.MethodPatch || req.OID != numericTriggerURI && req.OID != discreteTriggerURI {
+		return nil, "", tcommon.ResponseStatus{StatusCode: http.StatusNotFound}, fmt.Errorf(errorMessage)
+	}
+	statusCode := mockTriggerServers[target.ManagerAddress]
+	if statusCode != http.StatusOK {
+		return nil, "", tcommon.ResponseStatus{StatusCode: statusCode}, fmt.Errorf(errorMessage)
+	}
+	mockTriggerUpdates[target.DeviceUUID] = string(target.PostBody)
+	return []byte(`{}`), "", tcommon.ResponseStatus{}, nil
+}
+
+func mockTriggerGenericSave(body []byte, table, key string) error {
+	mockTriggers[key] = string(body)
+	return nil
+}
+
+func mockTriggerInterface() *ExternalInterface {
+	mockTriggers = map[string]string{
+		numericTriggerURI: `{"@odata.id":"` + numericTriggerURI + `","Id":"CPUTemperature","Name":"CPU Temperature","MetricType":"Numeric",` +
+			`"TriggerActions":["RedfishEvent"],"MetricProperties":["/redfish/v1/Chassis/{ChassisID}/Thermal#/Temperatures/0/ReadingCelsius"],` +
+			`"Wildcards":[{"Name":"ChassisID","Values":["1"]}],` +
+			`"NumericThresholds":{"UpperCritical":{"Reading":90},"LowerWarning":{"Reading":10,"Activation":"Either"}}}`,
+		discreteTriggerURI: `{"@odata.id":"` + discreteTriggerURI + `","Id":"PowerState","Name":"Power State","MetricType":"Discrete",` +
+			`"TriggerActions":["RedfishEvent"],"MetricIds":["PowerState"],"DiscreteTriggerCondition":"Specified",` +
+			`"DiscreteTriggers":[{"Value":"Off","Severity":"Critical","DwellTime":"PT1M"}]}`,
+	}
+	mockTriggerServers = map[string]int32{"server-1": http.StatusOK, "server-2": http.StatusNotFound}
+	mockTriggerUpdates = make(map[string]string)
+	resetTriggerStates(numericTriggerURI)
+	resetTriggerStates(discreteTriggerURI)
+	return &ExternalInterface{
+		External: External{
+			GenericSave:    mockTriggerGenericSave,
+			GetTarget:      mockTriggerGetTarget,
+			DevicePassword: func(password []byte) ([]byte, error) { return password, nil },
+			GetPluginData:  mockTriggerGetPluginData,
+			ContactPlugin:  mockTriggerContactPlugin,
+		},
+		DB: DB{
+			GetAllKeysFromTable: mockTriggerGetAllKeysFromTable,
+			GetResource:         mockTriggerGetResource,
+		},
+	}
+}
+
+func metricReport(metricValues string) []byte {
+	return []byte(`{"@odata.id":"/redfish/v1/TelemetryService/MetricReports/Temperature","Id":"Temperature","MetricValues":[` + metricValues + `]}`)
+}
+
+func evaluate(t *testing.T, e *ExternalInterface, metricValues string) []common.Event {
+	resp := e.EvaluateTriggers(&teleproto.TelemetryRequest{RequestBody: metricReport(metricValues)})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	message := resp.Body.(common.MessageData)
+	return message.Events
+}
+
+func TestUpdateTrigger(t *testing.T) {
+	e := mockTriggerInterface()
+	tests := []struct {
+		name       string
+		url        string
+		body       string
+		statusCode int32
+	}{
+		{"valid thresholds", numericTriggerURI, `{"NumericThresholds":{"UpperWarning":{"Reading":80,"DwellTime":"PT30S"}}}`, http.StatusOK},
+		{"trigger not found", "/redfish/v1/TelemetryService/Triggers/invalid", `{"Name":"trigger"}`, http.StatusNotFound},
+		{"malformed request", numericTriggerURI, `{`, http.StatusBadRequest},
+		{"empty request", numericTriggerURI, `{}`, http.StatusBadRequest},
+		{"read only property", numericTriggerURI, `{"Id":"1"}`, http.StatusBadRequest},
+		{"invalid property type", numericTriggerURI, `{"MetricIds":"CPU"}`, http.StatusBadRequest},
+		{"invalid activation", numericTriggerURI, `{"NumericThresholds":{"UpperWarning":{"Reading":80,"Activation":"Up"}}}`, http.StatusBadRequest},
+		{"invalid dwell time", numericTriggerURI, `{"NumericThresholds":{"UpperWarning":{"Reading":80,"DwellTime":"30S"}}}`, http.StatusBadRequest},
+		{"warning above critical", numericTriggerURI, `{"NumericThresholds":{"UpperWarning":{"Reading":95},"UpperCritical":{"Reading":90}}}`, http.StatusBadRequest},
+		{"invalid trigger action", numericTriggerURI, `{"TriggerActions":["SendMail"]}`, http.StatusBadRequest},
+		{"invalid discrete condition", discreteTriggerURI, `{"DiscreteTriggerCondition":"Always"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := e.UpdateTrigger(&teleproto.TelemetryRequest{URL: tt.url, RequestBody: []byte(tt.body)})
+			assert.Equal(t, int(tt.statusCode), int(resp.StatusCode), "Status code mismatch")
+		})
+	}
+
+	var trigger map[string]interface{}
+	json.Unmarshal([]byte(mockTriggers[numericTriggerURI]), &trigger)
+	thresholds := trigger["NumericThresholds"].(map[string]interface{})
+	assert.Contains(t, thresholds, "UpperWarning", "updated threshold should be persisted")
+	assert.Equal(t, "CPU Temperature", trigger["Name"], "other properties should be retained")
+	assert.Equal(t, map[string]string{"server-1": tests[0].body}, mockTriggerUpdates, "the update should be sent to the server having the trigger")
+}
+
+func TestUpdateTriggerOnDeviceFailure(t *testing.T) {
+	e := mockTriggerInterface()
+	body := []byte(`{"NumericThresholds":{"UpperWarning":{"Reading":80}}}`)
+	saved := mockTriggers[numericTriggerURI]
+
+	mockTriggerServers["server-2"] = http.StatusBadRequest
+	resp := e.UpdateTrigger(&teleproto.TelemetryRequest{URL: numericTriggerURI, RequestBody: body})
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "the error of the server should be returned")
+	assert.Equal(t, saved, mockTriggers[numericTriggerURI], "the trigger should not be saved when a server rejects the update")
+
+	mockTriggerServers = map[string]int32{"server-2": http.StatusNotFound}
+	resp = e.UpdateTrigger(&teleproto.TelemetryRequest{URL: numericTriggerURI, RequestBody: body})
+	assert.Equal(t, http.StatusNotFound, int(resp.StatusCode), "the trigger should not be found when no server has it")
+	assert.Equal(t, saved, mockTriggers[numericTriggerURI], "the trigger should not be saved when no server has it")
+}
+
+func TestEvaluateNumericTrigger(t *testing.T) {
+	e := mockTriggerInterface()
+	metric := `{"MetricProperty":"/redfish/v1/Chassis/1/Thermal#/Temperatures/0/ReadingCelsius","MetricValue":"%v","Timestamp":"2022-01-01T00:00:%vZ"}`
+	value := func(reading, second string) string {
+		return fmt.Sprintf(metric, reading, second)
+	}
+
+	assert.Empty(t, evaluate(t, e, value("50", "00")), "no event expected within the thresholds")
+
+	events := evaluate(t, e, value("95", "10"))
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "Telemetry.1.0.0.TriggerNumericAboveUpperThreshold", events[0].MessageID)
+		assert.Equal(t, "Critical", events[0].Severity)
+		assert.Equal(t, numericTriggerURI, events[0].OriginOfCondition.Oid)
+		assert.Equal(t, []string{"/redfish/v1/Chassis/1/Thermal#/Temperatures/0/ReadingCelsius", "95", "UpperCritical", "90"}, events[0].MessageArgs)
+	}
+	assert.Empty(t, evaluate(t, e, value("96", "20")), "no event expected while staying above the threshold")
+	assert.Empty(t, evaluate(t, e, value("80", "30")), "falling below the upper threshold is not activated by default")
+
+	events = evaluate(t, e, value("5", "40"))
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "Telemetry.1.0.0.TriggerNumericBelowLowerThreshold", events[0].MessageID)
+		assert.Equal(t, "Warning", events[0].Severity)
+	}
+	events = evaluate(t, e, value("15", "50"))
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "Telemetry.1.0.0.TriggerNumericAboveLowerThreshold", events[0].MessageID)
+		assert.Equal(t, "OK", events[0].Severity)
+	}
+}
+
+func TestEvaluateNumericTriggerWithDwellTime(t *testing.T) {
+	e := mockTriggerInterface()
+	resp := e.UpdateTrigger(&teleproto.TelemetryRequest{
+		URL:         numericTriggerURI,
+		RequestBody: []byte(`{"NumericThresholds":{"UpperCritical":{"Reading":90,"DwellTime":"PT30S"}}}`),
+	})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	metric := `{"MetricProperty":"/redfish/v1/Chassis/1/Thermal#/Temperatures/0/ReadingCelsius","MetricValue":"%v","Timestamp":"2022-01-01T00:00:%vZ"}`
+	value := func(reading, second string) string {
+		return fmt.Sprintf(metric, reading, second)
+	}
+
+	assert.Empty(t, evaluate(t, e, value("50", "00")))
+	assert.Empty(t, evaluate(t, e, value("95", "10")), "event should wait for the dwell time")
+	assert.Empty(t, evaluate(t, e, value("50", "20")), "crossing back should cancel the pending event")
+	assert.Empty(t, evaluate(t, e, value("95", "25")))
+	assert.Empty(t, evaluate(t, e, value("97", "40")))
+	assert.Len(t, evaluate(t, e, value("97", "55")), 1, "event expected once the dwell time elapsed")
+	assert.Empty(t, evaluate(t, e, value("97", "58")), "event should be sent only once")
+}
+
+func TestEvaluateDiscreteTrigger(t *testing.T) {
+	e := mockTriggerInterface()
+	metric := `{"MetricId":"PowerState","MetricValue":"%v","Timestamp":"2022-01-01T00:%v:00Z"}`
+	value := func(state, minute string) string {
+		return fmt.Sprintf(metric, state, minute)
+	}
+
+	assert.Empty(t, evaluate(t, e, value("On", "00")))
+	assert.Empty(t, evaluate(t, e, value("Off", "01")), "event should wait for the dwell time")
+	events := evaluate(t, e, value("Off", "02"))
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "Telemetry.1.0.0.TriggerDiscreteConditionMet", events[0].MessageID)
+		assert.Equal(t, "Critical", events[0].Severity)
+		assert.Equal(t, discreteTriggerURI, events[0].OriginOfCondition.Oid)
+	}
+	assert.Empty(t, evaluate(t, e, value("Off", "03")))
+
+	resp := e.UpdateTrigger(&teleproto.TelemetryRequest{
+		URL:         discreteTriggerURI,
+		RequestBody: []byte(`{"DiscreteTriggerCondition":"Changed"}`),
+	})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	assert.Empty(t, evaluate(t, e, value("Off", "04")))
+	assert.Len(t, evaluate(t, e, value("On", "05")), 1, "event expected on change of value")
+}
+
+func TestEvaluateTriggersPrunesDeletedTriggers(t *testing.T) {
+	e := mockTriggerInterface()
+	evaluate(t, e, `{"MetricId":"PowerState","MetricValue":"Off","Timestamp":"2022-01-01T00:00:00Z"}`)
+	triggerStates.Lock()
+	_, ok := triggerStates.states[discreteTriggerURI+"|PowerState"]
+	triggerStates.Unlock()
+	assert.True(t, ok, "state should be held for the evaluated trigger")
+
+	delete(mockTriggers, discreteTriggerURI)
+	evaluate(t, e, `{"MetricId":"PowerState","MetricValue":"Off","Timestamp":"2022-01-01T00:00:10Z"}`)
+	triggerStates.Lock()
+	_, ok = triggerStates.states[discreteTriggerURI+"|PowerState"]
+	triggerStates.Unlock()
+	assert.False(t, ok, "state of the deleted trigger should be pruned")
+}
+
+func TestEvaluateTriggersWithInvalidReport(t *testing.T) {
+	e := mockTriggerInterface()
+	resp := e.EvaluateTriggers(&teleproto.TelemetryRequest{RequestBody: []byte("{")})
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "Status code should be StatusBadRequest.")
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
 	updateproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/update"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-update/rpc"
@@ -64,4 +65,5 @@ func registerHandlers() {
 	}
 	updater := rpc.GetUpdater()
 	updateproto.RegisterUpdateServer(services.ODIMService.Server(), updater)
+	taskproto.RegisterTaskCancelServer(services.ODIMService.Server(), new(services.TaskCanceller))
 }
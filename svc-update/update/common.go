@@ -15,6 +15,7 @@
 package update
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -96,10 +97,32 @@ type SimpleUpdateRequest struct {
 	TransferProtocol          string   `json:"TransferProtocol,omitempty"`
 	Username                  string   `json:"Username,omitempty"`
 	RedfishOperationApplyTime string   `json:"@Redfish.OperationApplyTime,omitempty"`
+	// BatchSize, DelayBetweenBatchesInSeconds and MaxFailurePercentage are optional
+	// parameters for rolling out the update to the systems in batches
+	BatchSize                    int `json:"BatchSize,omitempty"`
+	DelayBetweenBatchesInSeconds int `json:"DelayBetweenBatchesInSeconds,omitempty"`
+	MaxFailurePercentage         int `json:"MaxFailurePercentage,omitempty"`
+}
+
+// validateRolloutParameters validates the batch parameters of the simple update request
+func (req SimpleUpdateRequest) validateRolloutParameters() (string, []interface{}, error) {
+	if req.BatchSize < 0 {
+		return response.PropertyValueNotInList, []interface{}{fmt.Sprintf("%v", req.BatchSize), "BatchSize"}, fmt.Errorf("BatchSize cannot be negative")
+	}
+	if req.DelayBetweenBatchesInSeconds < 0 {
+		return response.PropertyValueNotInList, []interface{}{fmt.Sprintf("%v", req.DelayBetweenBatchesInSeconds), "DelayBetweenBatchesInSeconds"},
+			fmt.Errorf("DelayBetweenBatchesInSeconds cannot be negative")
+	}
+	if req.MaxFailurePercentage < 0 || req.MaxFailurePercentage > 100 {
+		return response.PropertyValueNotInList, []interface{}{fmt.Sprintf("%v", req.MaxFailurePercentage), "MaxFailurePercentage"},
+			fmt.Errorf("MaxFailurePercentage should be between 0 and 100")
+	}
+	return "", nil, nil
 }
 
 // monitorTaskRequest hold values required monitorTask function
 type monitorTaskRequest struct {
+	ctx               context.Context
 	respBody          []byte
 	subTaskID         string
 	serverURI         string
@@ -148,6 +171,9 @@ func TaskData(taskData common.TaskData) error {
 
 	err := ServicesUpdateTaskFunc(taskData.TaskID, taskData.TaskState, taskData.TaskStatus, taskData.PercentComplete, payLoad, time.Now())
 	if err != nil && (err.Error() == common.Cancelling) {
+		if common.CancelTaskContext(taskData.TaskID) {
+			return err
+		}
 		// We cant do anything here as the task has done it work completely, we cant reverse it.
 		//Unless if we can do opposite/reverse action for delete server which is add server.
 		ServicesUpdateTaskFunc(taskData.TaskID, common.Cancelled, taskData.TaskStatus, taskData.PercentComplete, payLoad, time.Now())
@@ -186,15 +212,30 @@ func (e *ExternalInterface) monitorPluginTask(subTaskChannel chan<- int32, monit
 		var updatetask = fillTaskData(monitorTaskData.subTaskID, monitorTaskData.serverURI, monitorTaskData.updateRequestBody, monitorTaskData.resp, task.TaskState, task.TaskStatus, task.PercentComplete, http.MethodPost)
 		err := e.External.UpdateTask(updatetask)
 		if err != nil && err.Error() == common.Cancelling {
-			var updatetask = fillTaskData(monitorTaskData.subTaskID, monitorTaskData.serverURI, monitorTaskData.updateRequestBody, monitorTaskData.resp, common.Cancelled, common.Critical, 100, http.MethodPost)
+			getResponse, err := e.abortPluginTask(monitorTaskData)
 			subTaskChannel <- http.StatusInternalServerError
-			e.External.UpdateTask(updatetask)
-			return monitorTaskData.getResponse, err
+			return getResponse, err
 		}
-		time.Sleep(time.Second * 5)
-		monitorTaskData.pluginRequest.OID = monitorTaskData.location
-		monitorTaskData.pluginRequest.HTTPMethodType = http.MethodGet
-		monitorTaskData.respBody, _, monitorTaskData.getResponse, err = e.External.ContactPlugin(monitorTaskData.pluginRequest, "error while performing simple update action: ")
+		select {
+		case <-monitorTaskData.ctx.Done():
+			getResponse, err := e.abortPluginTask(monitorTaskData)
+			subTaskChannel <- http.StatusInternalServerError
+			return getResponse, err
+		case <-time.After(time.Second * 5):
+		}
+		pluginRequest := monitorTaskData.pluginRequest
+		pluginRequest.OID = monitorTaskData.location
+		pluginRequest.HTTPMethodType = http.MethodGet
+		var respBody []byte
+		var getResponse ucommon.ResponseStatus
+		if common.CallWithTaskCancel(monitorTaskData.ctx, func() {
+			respBody, _, getResponse, err = e.External.ContactPlugin(pluginRequest, "error while performing simple update action: ")
+		}) != nil {
+			getResponse, err := e.abortPluginTask(monitorTaskData)
+			subTaskChannel <- http.StatusInternalServerError
+			return getResponse, err
+		}
+		monitorTaskData.respBody, monitorTaskData.getResponse = respBody, getResponse
 		if err != nil {
 			subTaskChannel <- monitorTaskData.getResponse.StatusCode
 			errMsg := err.Error()
@@ -208,3 +249,22 @@ func (e *ExternalInterface) monitorPluginTask(subTaskChannel chan<- int32, monit
 	}
 	return monitorTaskData.getResponse, nil
 }
+
+// abortPluginTask aborts the update job started on the plugin for the cancelled sub task
+func (e *ExternalInterface) abortPluginTask(monitorTaskData *monitorTaskRequest) (ucommon.ResponseStatus, error) {
+	var getResponse ucommon.ResponseStatus
+	err := common.AbortPluginTask(monitorTaskData.subTaskID, monitorTaskData.location, monitorTaskData.taskInfo,
+		func() (int32, error) {
+			pluginRequest := monitorTaskData.pluginRequest
+			pluginRequest.OID = monitorTaskData.location
+			pluginRequest.HTTPMethodType = http.MethodDelete
+			var err error
+			_, _, getResponse, err = e.External.ContactPlugin(pluginRequest, "error while aborting the update task on the plugin: ")
+			return getResponse.StatusCode, err
+		},
+		func() {
+			var task = fillTaskData(monitorTaskData.subTaskID, monitorTaskData.serverURI, monitorTaskData.updateRequestBody, monitorTaskData.resp, common.Cancelled, common.Warning, 100, http.MethodPost)
+			e.External.UpdateTask(task)
+		})
+	return getResponse, err
+}
@@ -15,12 +15,16 @@
 package update
 
 import (
+	"context"
 	"errors"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
+	"github.com/ODIM-Project/ODIM/lib-utilities/services"
+	"github.com/ODIM-Project/ODIM/svc-update/ucommon"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,3 +43,115 @@ func TestGetExternalInterface(t *testing.T) {
 	assert.Nil(t, resp, "There should be no error")
 
 }
+
+func TestMonitorPluginTaskCancel(t *testing.T) {
+	tests := []struct {
+		name           string
+		cancelled      bool
+		cancelInFlight bool
+		updateErr      error
+		deleteStatus   int32
+		deleteErr      error
+		wantTaskState  string
+		wantTaskStatus string
+	}{
+		{
+			name:           "job aborted on cancel request",
+			cancelled:      true,
+			deleteStatus:   http.StatusNoContent,
+			deleteErr:      errors.New("error while aborting the update task on the plugin: "),
+			wantTaskState:  common.Cancelled,
+			wantTaskStatus: common.Warning,
+		},
+		{
+			name:           "job aborted on cancel request during the task monitor request",
+			cancelInFlight: true,
+			deleteStatus:   http.StatusNoContent,
+			wantTaskState:  common.Cancelled,
+			wantTaskStatus: common.Warning,
+		},
+		{
+			name:           "job aborted when the task update reports cancelling",
+			updateErr:      errors.New(common.Cancelling),
+			deleteStatus:   http.StatusOK,
+			wantTaskState:  common.Cancelled,
+			wantTaskStatus: common.Warning,
+		},
+		{
+			name:           "job could not be aborted",
+			cancelled:      true,
+			deleteStatus:   http.StatusMethodNotAllowed,
+			deleteErr:      errors.New("error while aborting the update task on the plugin: "),
+			wantTaskState:  common.Exception,
+			wantTaskStatus: common.Critical,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lastTask common.TaskData
+			var deleteRequests []string
+			updateTask := func(task common.TaskData) error {
+				lastTask = task
+				if task.TaskState == common.Running {
+					return tt.updateErr
+				}
+				return nil
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.cancelled {
+				cancel()
+			}
+			defer cancel()
+			// the task monitor request in flight does not return till the test ends
+			inFlight := make(chan struct{})
+			defer close(inFlight)
+			e := &ExternalInterface{
+				External: External{
+					UpdateTask: updateTask,
+					ContactPlugin: func(req ucommon.PluginContactRequest, errMsg string) ([]byte, string, ucommon.ResponseStatus, error) {
+						if req.HTTPMethodType == http.MethodDelete {
+							deleteRequests = append(deleteRequests, req.OID)
+							return nil, "", ucommon.ResponseStatus{StatusCode: tt.deleteStatus}, tt.deleteErr
+						}
+						if tt.cancelInFlight {
+							cancel()
+							<-inFlight
+						}
+						return []byte(`{"TaskState":"Running"}`), "", ucommon.ResponseStatus{StatusCode: http.StatusAccepted}, nil
+					},
+				},
+			}
+			subTaskChannel := make(chan int32, 1)
+			_, err := e.monitorPluginTask(subTaskChannel, &monitorTaskRequest{
+				ctx:       ctx,
+				respBody:  []byte(`{"TaskState":"Running"}`),
+				subTaskID: "subTaskID",
+				serverURI: "/redfish/v1/Systems/uuid.1",
+				location:  "/taskmon/pluginTaskID",
+				taskInfo:  &common.TaskUpdateInfo{TaskID: "subTaskID", TargetURI: "/redfish/v1/Systems/uuid.1", UpdateTask: updateTask},
+			})
+			assert.NotNil(t, err, "There should be an error")
+			assert.Equal(t, []string{"/taskmon/pluginTaskID"}, deleteRequests, "task monitor of the plugin should be deleted")
+			assert.Equal(t, tt.wantTaskState, lastTask.TaskState, "final task state should reflect the abort")
+			assert.Equal(t, tt.wantTaskStatus, lastTask.TaskStatus, "final task status should reflect the abort")
+			assert.Equal(t, int32(http.StatusInternalServerError), <-subTaskChannel, "sub task should report the failure")
+		})
+	}
+}
+
+func TestTaskDataCancelRegisteredTask(t *testing.T) {
+	defer func() {
+		ServicesUpdateTaskFunc = services.UpdateTask
+	}()
+	var states []string
+	ServicesUpdateTaskFunc = func(taskID, taskState, taskStatus string, percentComplete int32, payLoad *task.Payload, endTime time.Time) error {
+		states = append(states, taskState)
+		return errors.New(common.Cancelling)
+	}
+	ctx, release := common.WithTaskCancel(context.Background(), "subTaskID")
+	defer release()
+	err := TaskData(common.TaskData{TaskID: "subTaskID", TaskState: common.Running, PercentComplete: 50})
+	assert.NotNil(t, err, "There should be an error")
+	assert.NotNil(t, ctx.Err(), "context of the task should be cancelled")
+	assert.Equal(t, []string{common.Running}, states, "task should be left to the operation in progress")
+}
@@ -19,12 +19,15 @@ package update
 // IMPORT Section
 //
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"runtime"
+	"sort"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -85,46 +88,114 @@ func (e *ExternalInterface) SimpleUpdate(taskID string, sessionUserName string,
 		log.Warn(errorMessage)
 		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"System", fmt.Sprintf("%v", updateRequest.Targets)}, taskInfo)
 	}
+	if statusMessage, messageArgs, err := updateRequest.validateRolloutParameters(); err != nil {
+		errMsg := "Invalid rollout parameters in the simple update request: " + err.Error()
+		log.Warn(errMsg)
+		return common.GeneralError(http.StatusBadRequest, statusMessage, errMsg, messageArgs, taskInfo)
+	}
+	batchSize := updateRequest.BatchSize
+	delayBetweenBatches := time.Second * time.Duration(updateRequest.DelayBetweenBatchesInSeconds)
+	maxFailurePercentage := updateRequest.MaxFailurePercentage
+	// the rollout parameters are consumed here and not forwarded to the plugins
+	updateRequest.BatchSize, updateRequest.DelayBetweenBatchesInSeconds, updateRequest.MaxFailurePercentage = 0, 0, 0
+
+	// sorting the systems so that the batches are formed in the same order on every request
+	systemIDs := make([]string, 0, len(targetList))
+	for id := range targetList {
+		systemIDs = append(systemIDs, id)
+	}
+	sort.Strings(systemIDs)
+	if batchSize == 0 {
+		batchSize = len(systemIDs)
+	}
+
+	// the rollout is stopped before the next batch when the task is cancelled,
+	// a cancel which arrives after the context is released is handled by UpdateTask
+	ctx, release := common.WithTaskCancel(context.Background(), taskID)
+
 	partialResultFlag := false
 	subTaskChannel := make(chan int32, len(targetList))
 	serverURI := ""
-	for id, target := range targetList {
-		updateRequest.Targets = target
-		marshalBody, err := JSONMarshalFunc(updateRequest)
-		if err != nil {
-			errMsg := "Unable to parse the simple update request" + err.Error()
-			log.Warn(errMsg)
-			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
-		}
-		updateRequestBody := string(marshalBody)
-		serverURI = "/redfish/v1/Systems/" + id
-		go e.sendRequest(id, taskID, serverURI, updateRequestBody, updateRequest.RedfishOperationApplyTime, subTaskChannel, sessionUserName)
-	}
-
+	var completed, failed int
 	resp.StatusCode = http.StatusOK
-	for i := 0; i < len(targetList); i++ {
-		select {
-		case statusCode := <-subTaskChannel:
-			if statusCode != http.StatusOK {
-				partialResultFlag = true
-				if resp.StatusCode < statusCode {
-					resp.StatusCode = statusCode
+	for batchStart := 0; batchStart < len(systemIDs); batchStart += batchSize {
+		batchEnd := batchStart + batchSize
+		if batchEnd > len(systemIDs) {
+			batchEnd = len(systemIDs)
+		}
+		if batchStart > 0 {
+			log.Info("Waiting for " + delayBetweenBatches.String() + " before starting the next batch of SimpleUpdate requests")
+			select {
+			case <-time.After(delayBetweenBatches):
+			case <-ctx.Done():
+				release()
+				errMsg := "SimpleUpdate is cancelled before updating the remaining systems"
+				log.Info(errMsg)
+				for _, id := range systemIDs[batchStart:] {
+					updateRequest.Targets = targetList[id]
+					marshalBody, _ := JSONMarshalFunc(updateRequest)
+					e.haltRequest(taskID, "/redfish/v1/Systems/"+id, string(marshalBody), sessionUserName, errMsg)
 				}
+				task := fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Cancelled, common.Warning, percentComplete, http.MethodPost)
+				e.External.UpdateTask(task)
+				return resp
 			}
-			if i < len(targetList)-1 {
-				percentComplete := int32(((i + 1) / len(targetList)) * 100)
-				var task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Running, common.OK, percentComplete, http.MethodPost)
-				err := e.External.UpdateTask(task)
-				if err != nil && err.Error() == common.Cancelling {
-					task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Cancelled, common.OK, percentComplete, http.MethodPost)
-					e.External.UpdateTask(task)
-					runtime.Goexit()
+		}
+		for _, id := range systemIDs[batchStart:batchEnd] {
+			updateRequest.Targets = targetList[id]
+			marshalBody, err := JSONMarshalFunc(updateRequest)
+			if err != nil {
+				release()
+				errMsg := "Unable to parse the simple update request" + err.Error()
+				log.Warn(errMsg)
+				return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+			}
+			updateRequestBody := string(marshalBody)
+			serverURI = "/redfish/v1/Systems/" + id
+			go e.sendRequest(id, taskID, serverURI, updateRequestBody, updateRequest.RedfishOperationApplyTime, subTaskChannel, sessionUserName)
+		}
+
+		for i := batchStart; i < batchEnd; i++ {
+			select {
+			case statusCode := <-subTaskChannel:
+				completed++
+				if statusCode != http.StatusOK {
+					failed++
+					partialResultFlag = true
+					if resp.StatusCode < statusCode {
+						resp.StatusCode = statusCode
+					}
+				}
+				if completed < len(systemIDs) {
+					percentComplete = int32((completed * 100) / len(systemIDs))
+					var task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Running, common.OK, percentComplete, http.MethodPost)
+					err := e.External.UpdateTask(task)
+					if err != nil && err.Error() == common.Cancelling {
+						release()
+						task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Cancelled, common.OK, percentComplete, http.MethodPost)
+						e.External.UpdateTask(task)
+						runtime.Goexit()
+					}
 				}
+			}
+		}
 
+		if batchEnd < len(systemIDs) && maxFailurePercentage > 0 && (failed*100)/completed > maxFailurePercentage {
+			release()
+			errMsg := fmt.Sprintf("SimpleUpdate halted as %d of the %d systems updated so far failed, which exceeds the MaxFailurePercentage of %d",
+				failed, completed, maxFailurePercentage)
+			log.Warn(errMsg)
+			for _, id := range systemIDs[batchEnd:] {
+				updateRequest.Targets = targetList[id]
+				marshalBody, _ := JSONMarshalFunc(updateRequest)
+				e.haltRequest(taskID, "/redfish/v1/Systems/"+id, string(marshalBody), sessionUserName, errMsg)
 			}
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg+". for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/"+taskID, nil, taskInfo)
 		}
 	}
 
+	release()
+
 	taskStatus := common.OK
 	if partialResultFlag {
 		taskStatus = common.Warning
@@ -271,7 +342,10 @@ func (e *ExternalInterface) sendRequest(uuid, taskID, serverURI, updateRequestBo
 		return
 	}
 	if getResponse.StatusCode == http.StatusAccepted {
+		// the sub task is cancelled along with the job started on the plugin for it
+		ctx, release := common.WithTaskCancel(context.Background(), subTaskID)
 		getResponse, err = e.monitorPluginTask(subTaskChannel, &monitorTaskRequest{
+			ctx:               ctx,
 			subTaskID:         subTaskID,
 			serverURI:         serverURI,
 			updateRequestBody: updateRequestBody,
@@ -282,6 +356,7 @@ func (e *ExternalInterface) sendRequest(uuid, taskID, serverURI, updateRequestBo
 			pluginRequest:     contactRequest,
 			resp:              resp,
 		})
+		release()
 
 		if err != nil {
 			return
@@ -300,6 +375,28 @@ func (e *ExternalInterface) sendRequest(uuid, taskID, serverURI, updateRequestBo
 	return
 }
 
+// haltRequest creates a sub task for a system which is not updated as the rollout is halted,
+// and marks it cancelled so that the systems left out are listed in the SubTasks of the task
+func (e *ExternalInterface) haltRequest(taskID, serverURI, updateRequestBody, sessionUserName, errMsg string) {
+	subTaskURI, err := e.External.CreateChildTask(sessionUserName, taskID)
+	if err != nil {
+		log.Warn("Unable to create sub task for " + serverURI + ": " + err.Error())
+		return
+	}
+	var subTaskID string
+	strArray := strings.Split(subTaskURI, "/")
+	if strings.HasSuffix(subTaskURI, "/") {
+		subTaskID = strArray[len(strArray)-2]
+	} else {
+		subTaskID = strArray[len(strArray)-1]
+	}
+	resp := common.GeneralError(http.StatusServiceUnavailable, response.GeneralError, errMsg, nil, nil)
+	task := fillTaskData(subTaskID, serverURI, updateRequestBody, resp, common.Cancelled, common.Warning, 0, http.MethodPost)
+	if err := e.External.UpdateTask(task); err != nil {
+		log.Warn("Unable to update the sub task " + subTaskID + ": " + err.Error())
+	}
+}
+
 func sortTargetList(Targets []string) (map[string][]string, error) {
 	returnList := make(map[string][]string)
 	for _, individualTarget := range Targets {
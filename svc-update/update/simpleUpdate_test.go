@@ -19,12 +19,16 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	errs "github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	updateproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/update"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-update/umodel"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -260,3 +264,99 @@ func TestExternalInterface_sendRequestPreferedAuthType(t *testing.T) {
 		}
 	}
 }
+
+func TestSimpleUpdateInBatches(t *testing.T) {
+	config.SetUpMockConfig(t)
+	targets := `["/redfish/v1/Systems/uuidA.1","/redfish/v1/Systems/uuidB.1","/redfish/v1/Systems/uuidC.1","/redfish/v1/Systems/uuidD.1"]`
+	var mutex sync.Mutex
+	var cancelledTasks []string
+	e := mockGetExternalInterface()
+	e.External.UpdateTask = func(task common.TaskData) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if task.TaskState == common.Cancelled {
+			cancelledTasks = append(cancelledTasks, task.TargetURI)
+		}
+		return nil
+	}
+	e.External.GetTarget = func(id string) (*umodel.Target, *errs.Error) {
+		if id == "uuidB" {
+			return mockGetTargetError(id)
+		}
+		return mockGetTarget(id)
+	}
+	tests := []struct {
+		name           string
+		request        string
+		wantStatusCode int32
+		wantCancelled  []string
+	}{
+		{
+			name:           "failures within the budget",
+			request:        `{"ImageURI":"abc","Targets":` + targets + `,"BatchSize":2,"MaxFailurePercentage":50}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "failures exceeding the budget",
+			request:        `{"ImageURI":"abc","Targets":` + targets + `,"BatchSize":1,"MaxFailurePercentage":10}`,
+			wantStatusCode: http.StatusInternalServerError,
+			wantCancelled:  []string{"/redfish/v1/Systems/uuidC", "/redfish/v1/Systems/uuidD"},
+		},
+		{
+			name:           "failures exceeding the budget of the systems updated so far",
+			request:        `{"ImageURI":"abc","Targets":` + targets + `,"BatchSize":1,"MaxFailurePercentage":40}`,
+			wantStatusCode: http.StatusInternalServerError,
+			wantCancelled:  []string{"/redfish/v1/Systems/uuidC", "/redfish/v1/Systems/uuidD"},
+		},
+		{
+			name:           "negative batch size",
+			request:        `{"ImageURI":"abc","Targets":` + targets + `,"BatchSize":-1}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid failure percentage",
+			request:        `{"ImageURI":"abc","Targets":` + targets + `,"MaxFailurePercentage":101}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cancelledTasks = nil
+			got := e.SimpleUpdate("someID", "someUser", &updateproto.UpdateRequest{RequestBody: []byte(tt.request)})
+			assert.Equal(t, tt.wantStatusCode, got.StatusCode, "Status code mismatch")
+			assert.Equal(t, tt.wantCancelled, cancelledTasks, "Systems left out of the rollout mismatch")
+		})
+	}
+}
+
+func TestSimpleUpdateCancelledBetweenBatches(t *testing.T) {
+	config.SetUpMockConfig(t)
+	targets := `["/redfish/v1/Systems/uuidA.1","/redfish/v1/Systems/uuidB.1","/redfish/v1/Systems/uuidC.1"]`
+	var mutex sync.Mutex
+	var cancelledTasks []string
+	e := mockGetExternalInterface()
+	e.External.UpdateTask = func(task common.TaskData) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if task.TaskState == common.Cancelled {
+			cancelledTasks = append(cancelledTasks, task.TargetURI)
+		}
+		return nil
+	}
+	request := `{"ImageURI":"abc","Targets":` + targets + `,"BatchSize":1,"DelayBetweenBatchesInSeconds":3600}`
+	done := make(chan response.RPC)
+	go func() {
+		done <- e.SimpleUpdate("cancelledTask", "someUser", &updateproto.UpdateRequest{RequestBody: []byte(request)})
+	}()
+	// the context of the task is registered once the rollout starts
+	for !common.CancelTaskContext("cancelledTask") {
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("SimpleUpdate did not stop on cancel")
+	}
+	want := []string{"/redfish/v1/Systems/uuidB", "/redfish/v1/Systems/uuidC", "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate"}
+	assert.Equal(t, want, cancelledTasks, "Cancelled tasks mismatch")
+}
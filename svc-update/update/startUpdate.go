@@ -19,6 +19,7 @@ package update
 // IMPORT Section
 //
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"runtime"
@@ -239,7 +240,10 @@ func (e *ExternalInterface) startRequest(uuid, taskID, data string, subTaskChann
 		return
 	}
 	if getResponse.StatusCode == http.StatusAccepted {
+		// the sub task is cancelled along with the job started on the plugin for it
+		ctx, release := common.WithTaskCancel(context.Background(), subTaskID)
 		getResponse, err = e.monitorPluginTask(subTaskChannel, &monitorTaskRequest{
+			ctx:               ctx,
 			subTaskID:         subTaskID,
 			serverURI:         uuid,
 			updateRequestBody: data,
@@ -250,6 +254,7 @@ func (e *ExternalInterface) startRequest(uuid, taskID, data string, subTaskChann
 			pluginRequest:     contactRequest,
 			resp:              resp,
 		})
+		release()
 		if err != nil {
 			return
 		}